@@ -0,0 +1,43 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The compiler should reject Go types whose layout does not match
+// the C type they mirror according to //go:cgolayout.
+
+package main
+
+/*
+struct point { int x; int y; long long z; };
+struct bits { int a; unsigned int f : 3; int b; };
+#pragma pack(push, 1)
+struct packed { char c; int i; };
+#pragma pack(pop)
+struct empty { int x; };
+*/
+import "C"
+
+//go:cgolayout C.struct_point // ERROR HERE: offset of Point.Z is 12, but offset of C.struct_point.z is 8
+type Point struct {
+	X, Y int32
+	_    int32
+	Z    int32
+}
+
+//go:cgolayout C.struct_bits // ERROR HERE: C.struct_bits has no field F
+type Bits struct {
+	A int32
+	F uint32
+	B int32
+}
+
+//go:cgolayout C.struct_packed // ERROR HERE: size of Packed is 8, but size of C.struct_packed is 5
+type Packed struct {
+	C int8
+	I int32
+}
+
+//go:cgolayout C.int // ERROR HERE: C.int is not a struct type
+type Int struct{ x int32 }
+
+func main() {}
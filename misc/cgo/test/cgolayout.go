@@ -0,0 +1,46 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Go types that mirror C structs with //go:cgolayout, which the
+// compiler checks against the types cgo generates. No runtime test;
+// just make sure it compiles.
+
+package cgotest
+
+/*
+struct cgolayoutPoint { int x; char c; long long z; };
+typedef struct cgolayoutPoint cgolayoutPoint_t;
+struct cgolayoutBits { int a; unsigned int f : 3; unsigned int g : 5; int b; };
+#pragma pack(push, 1)
+struct cgolayoutPacked { char c; int i; short s; };
+#pragma pack(pop)
+*/
+import "C"
+
+//go:cgolayout C.struct_cgolayoutPoint
+type cgolayoutPoint struct {
+	X int32
+	C int8
+	Z int64
+}
+
+//go:cgolayout C.cgolayoutPoint_t
+type cgolayoutPointT struct {
+	X int32
+	_ [1]byte
+	Z int64
+}
+
+//go:cgolayout C.struct_cgolayoutBits
+type cgolayoutBits struct {
+	A int32
+	_ uint32 // f and g
+	B int32
+}
+
+//go:cgolayout C.struct_cgolayoutPacked
+type cgolayoutPacked struct {
+	C int8
+	_ [6]byte // i and s, which are misaligned
+}
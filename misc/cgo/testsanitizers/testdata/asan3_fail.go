@@ -0,0 +1,27 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+/*
+#include <stdlib.h>
+#include <sanitizer/asan_interface.h>
+
+int *p;
+int* f() {
+  p = (int *)malloc(4*sizeof(int));
+  ASAN_POISON_MEMORY_REGION(p, 4*sizeof(int));
+  return p;
+}
+*/
+import "C"
+import "fmt"
+
+func main() {
+	a := C.f()
+	// Read of C memory the C side has poisoned.
+	v := *a
+	// We shouldn't get here; asan should stop us first.
+	fmt.Println(v)
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+/*
+#include <stdlib.h>
+
+void fill(int *p, int n) {
+  int i;
+  for (i = 0; i < n; i++) {
+    p[i] = i;
+  }
+}
+*/
+import "C"
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+type node struct {
+	next *node
+	buf  [64]byte
+}
+
+func main() {
+	// Go memory passed to C must not be reported.
+	a := make([]C.int, 16)
+	C.fill(&a[0], C.int(len(a)))
+	for i, v := range a {
+		if int(v) != i {
+			panic(fmt.Sprintf("a[%d] = %d, want %d", i, v, i))
+		}
+	}
+
+	// Exercise allocation, freeing and reuse of heap objects.
+	var head *node
+	for i := 0; i < 100000; i++ {
+		n := &node{next: head}
+		n.buf[i%len(n.buf)] = byte(i)
+		if i%100 == 0 {
+			head = nil
+		} else {
+			head = n
+		}
+	}
+	runtime.GC()
+
+	m := make(map[int]string)
+	for i := 0; i < 1000; i++ {
+		m[i] = fmt.Sprint(i)
+	}
+	s := ""
+	for i := 0; i < 100; i++ {
+		s += m[i]
+	}
+	ch := make(chan []byte, 1)
+	ch <- []byte(s)
+	b := <-ch
+	_ = unsafe.Pointer(&b[0])
+	if len(b) != len(s) {
+		panic("bad length")
+	}
+}
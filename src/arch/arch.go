@@ -0,0 +1,37 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package arch provides CPU and vector operations for performance
+// sensitive code that would otherwise be written in assembly.
+//
+// Every function in this package is an ordinary Go function with a
+// portable implementation. On architectures where the compiler knows
+// a better instruction sequence, calls are replaced by that sequence,
+// so unlike calls to assembly functions they can be inlined, they add
+// no call overhead, and they appear normally in stack traces.
+//
+// The vector operations work on eight byte-wide lanes packed into a
+// uint64, with lane i held in bits 8*i through 8*i+7. A lane mask is
+// a uint64 with the high bit of each selected lane set and every other
+// bit clear.
+package arch
+
+import "unsafe"
+
+// Prefetch hints to the processor that the memory at addr will be
+// read soon and should be brought into all levels of the cache.
+// It never faults, whatever addr is, and has no effect on the
+// program's behavior.
+//
+// Prefetch is implemented by a single instruction on amd64 and arm64
+// and does nothing on other architectures.
+func Prefetch(addr unsafe.Pointer) {}
+
+// PrefetchStreamed is like Prefetch, but hints that the memory will
+// be read only once, so it should be kept out of the cache as far as
+// possible.
+//
+// PrefetchStreamed is implemented by a single instruction on amd64
+// and arm64 and does nothing on other architectures.
+func PrefetchStreamed(addr unsafe.Pointer) {}
@@ -0,0 +1,159 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package arch
+
+import (
+	"math/rand"
+	"testing"
+	"unsafe"
+)
+
+func TestPrefetch(t *testing.T) {
+	b := make([]byte, 256)
+	for i := range b {
+		Prefetch(unsafe.Pointer(&b[i]))
+		PrefetchStreamed(unsafe.Pointer(&b[i]))
+	}
+	// Prefetching never faults.
+	Prefetch(nil)
+	PrefetchStreamed(nil)
+}
+
+// lanes returns the lanes of x.
+func lanes(x uint64) (l [8]byte) {
+	for i := range l {
+		l[i] = byte(x >> (8 * i))
+	}
+	return l
+}
+
+// mask returns the lane mask selecting the lanes for which f is true.
+func mask(f func(i int) bool) uint64 {
+	var m uint64
+	for i := 0; i < 8; i++ {
+		if f(i) {
+			m |= 0x80 << (8 * i)
+		}
+	}
+	return m
+}
+
+// testValues returns random lanes biased toward the edge cases of
+// the lane operations: zero, one, 0x80 and 0xff lanes.
+func testValues(r *rand.Rand, n int) []uint64 {
+	special := []byte{0, 0, 1, 0x7f, 0x80, 0x81, 0xff}
+	var xs []uint64
+	for i := 0; i < n; i++ {
+		var x uint64
+		for j := 0; j < 8; j++ {
+			b := byte(r.Intn(256))
+			if r.Intn(2) == 0 {
+				b = special[r.Intn(len(special))]
+			}
+			x |= uint64(b) << (8 * j)
+		}
+		xs = append(xs, x)
+	}
+	return xs
+}
+
+func TestLoad8(t *testing.T) {
+	b := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if got, want := Load8(b), uint64(0x0807060504030201); got != want {
+		t.Errorf("Load8(%v) = %#x, want %#x", b, got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Load8 of 7 bytes did not panic")
+		}
+	}()
+	Load8(b[:7])
+}
+
+func TestBroadcast8(t *testing.T) {
+	for b := 0; b < 256; b++ {
+		for i, l := range lanes(Broadcast8(byte(b))) {
+			if l != byte(b) {
+				t.Fatalf("Broadcast8(%#x) lane %d = %#x", b, i, l)
+			}
+		}
+	}
+}
+
+func TestLaneMasks(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	xs := testValues(r, 1000)
+	for i, x := range xs {
+		y := xs[(i+1)%len(xs)]
+		lx, ly := lanes(x), lanes(y)
+		if got, want := ZeroLanes8(x), mask(func(i int) bool { return lx[i] == 0 }); got != want {
+			t.Errorf("ZeroLanes8(%#x) = %#x, want %#x", x, got, want)
+		}
+		// Make some lanes equal.
+		y = y&^0xff00ff | x&0xff00ff
+		ly = lanes(y)
+		if got, want := EqualLanes8(x, y), mask(func(i int) bool { return lx[i] == ly[i] }); got != want {
+			t.Errorf("EqualLanes8(%#x, %#x) = %#x, want %#x", x, y, got, want)
+		}
+	}
+}
+
+func TestLaneIndex(t *testing.T) {
+	for m := 0; m < 256; m++ {
+		sel := func(i int) bool { return m&(1<<i) != 0 }
+		mk := mask(sel)
+		first, last, count := 8, -1, 0
+		for i := 0; i < 8; i++ {
+			if sel(i) {
+				if first == 8 {
+					first = i
+				}
+				last = i
+				count++
+			}
+		}
+		if got := FirstLane8(mk); got != first {
+			t.Errorf("FirstLane8(%#x) = %d, want %d", mk, got, first)
+		}
+		if got := LastLane8(mk); got != last {
+			t.Errorf("LastLane8(%#x) = %d, want %d", mk, got, last)
+		}
+		if got := CountLanes8(mk); got != count {
+			t.Errorf("CountLanes8(%#x) = %d, want %d", mk, got, count)
+		}
+	}
+}
+
+// indexByte is a portable bytes.IndexByte built from the lane operations.
+func indexByte(s []byte, c byte) int {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if m := EqualLanes8(Load8(s[i:]), Broadcast8(c)); m != 0 {
+			return i + FirstLane8(m)
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestIndexByte(t *testing.T) {
+	s := []byte("the quick brown fox jumps over the lazy dog\x00\x01\x80\xff")
+	for c := 0; c < 256; c++ {
+		want := -1
+		for i, b := range s {
+			if b == byte(c) {
+				want = i
+				break
+			}
+		}
+		if got := indexByte(s, byte(c)); got != want {
+			t.Errorf("indexByte(%q, %#x) = %d, want %d", s, c, got, want)
+		}
+	}
+}
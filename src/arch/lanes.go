@@ -0,0 +1,57 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package arch
+
+import "math/bits"
+
+const (
+	lo8 = 0x0101010101010101
+	hi8 = 0x8080808080808080
+	lo7 = 0x7f7f7f7f7f7f7f7f
+)
+
+// Load8 returns the first eight bytes of b as lanes, b[i] in lane i.
+// It panics if len(b) < 8.
+func Load8(b []byte) uint64 {
+	_ = b[7] // bounds check hint to compiler; see golang.org/issue/14808
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+}
+
+// Broadcast8 returns the lanes that all hold b.
+func Broadcast8(b byte) uint64 {
+	return uint64(b) * lo8
+}
+
+// ZeroLanes8 returns the mask of the lanes of x that are zero.
+func ZeroLanes8(x uint64) uint64 {
+	// Adding 0x7f to the low seven bits of a lane carries into its
+	// high bit unless they are all zero, so this is exact, unlike
+	// the shorter (x - lo8) &^ x & hi8, which may also select a 0x01
+	// lane above a zero lane.
+	return ^((x&lo7 + lo7) | x | lo7)
+}
+
+// EqualLanes8 returns the mask of the lanes in which x and y are equal.
+func EqualLanes8(x, y uint64) uint64 {
+	return ZeroLanes8(x ^ y)
+}
+
+// FirstLane8 returns the lowest lane selected by mask,
+// or 8 if mask selects no lane.
+func FirstLane8(mask uint64) int {
+	return bits.TrailingZeros64(mask) >> 3
+}
+
+// LastLane8 returns the highest lane selected by mask,
+// or -1 if mask selects no lane.
+func LastLane8(mask uint64) int {
+	return (63 - bits.LeadingZeros64(mask)) >> 3
+}
+
+// CountLanes8 returns the number of lanes selected by mask.
+func CountLanes8(mask uint64) int {
+	return bits.OnesCount64(mask & hi8)
+}
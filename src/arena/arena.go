@@ -0,0 +1,73 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package arena provides region-based allocation: groups of values
+// whose lifetimes end together, such as the values built while
+// serving one request, can be allocated from an Arena and released
+// at once with Free.
+//
+// Allocating from an arena is cheaper than allocating each value
+// separately, and the values occupy a small number of large heap
+// objects, which reduces the work the garbage collector does per
+// value. Values allocated from an arena are ordinary Go values:
+// they may be referenced from anywhere, and memory stays valid for as
+// long as any of it is reachable, even after Free. Free only allows
+// the arena's memory to be reclaimed once nothing in it is
+// referenced any more, and makes further allocation from the arena
+// panic.
+//
+// Because memory is reclaimed a chunk at a time, a single long-lived
+// value keeps every value that shares its chunk alive. Arenas are
+// therefore best suited to values that really do die together.
+//
+// An Arena is not safe for concurrent use by multiple goroutines.
+package arena
+
+import "unsafe"
+
+// An Arena is a region from which values can be allocated.
+// The zero value is not usable; use NewArena.
+type Arena struct {
+	a unsafe.Pointer
+}
+
+// NewArena returns a new, empty arena.
+func NewArena() *Arena {
+	return &Arena{a: runtime_arena_newArena()}
+}
+
+// New allocates a zeroed value of the type that typ points to, and
+// returns a pointer to it. typ must be a nil pointer of the desired
+// pointer type; the result has the same dynamic type as typ.
+// For example:
+//
+//	p := a.New((*T)(nil)).(*T)
+//
+func (a *Arena) New(typ interface{}) interface{} {
+	return runtime_arena_arena_New(a.a, typ)
+}
+
+// MakeSlice allocates a slice with the given length and capacity.
+// typ must be a nil slice of the desired slice type; the result has
+// the same dynamic type as typ. For example:
+//
+//	s := a.MakeSlice([]T(nil), 0, 16).([]T)
+//
+func (a *Arena) MakeSlice(typ interface{}, len, cap int) interface{} {
+	return runtime_arena_arena_MakeSlice(a.a, typ, len, cap)
+}
+
+// Free releases the arena. Values already allocated from it remain
+// valid for as long as they are referenced, but the arena can no
+// longer be used to allocate.
+func (a *Arena) Free() {
+	runtime_arena_arena_Free(a.a)
+}
+
+// Implemented in runtime.
+
+func runtime_arena_newArena() unsafe.Pointer
+func runtime_arena_arena_New(arena unsafe.Pointer, typ interface{}) interface{}
+func runtime_arena_arena_MakeSlice(arena unsafe.Pointer, typ interface{}, len, cap int) interface{}
+func runtime_arena_arena_Free(arena unsafe.Pointer)
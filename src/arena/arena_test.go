@@ -0,0 +1,150 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package arena_test
+
+import (
+	"arena"
+	"runtime"
+	"testing"
+)
+
+type node struct {
+	next  *node
+	value int
+	name  string
+}
+
+func TestNew(t *testing.T) {
+	a := arena.NewArena()
+	defer a.Free()
+
+	var head *node
+	const n = 10000
+	for i := 0; i < n; i++ {
+		x := a.New((*node)(nil)).(*node)
+		if x.next != nil || x.value != 0 || x.name != "" {
+			t.Fatalf("New returned non-zero value %+v", *x)
+		}
+		x.next = head
+		x.value = i
+		x.name = string(rune('a' + i%26))
+		head = x
+	}
+
+	// The arena's chunks must be scanned correctly: values only
+	// reachable through arena memory must survive a GC.
+	runtime.GC()
+	runtime.GC()
+
+	i := n - 1
+	for x := head; x != nil; x = x.next {
+		if x.value != i || x.name != string(rune('a'+i%26)) {
+			t.Fatalf("node %d = {%d, %q}", i, x.value, x.name)
+		}
+		i--
+	}
+	if i != -1 {
+		t.Fatalf("list has %d nodes, want %d", n-1-i, n)
+	}
+}
+
+func TestMakeSlice(t *testing.T) {
+	a := arena.NewArena()
+	defer a.Free()
+
+	s := a.MakeSlice([]*int(nil), 3, 10).([]*int)
+	if len(s) != 3 || cap(s) != 10 {
+		t.Fatalf("len, cap = %d, %d; want 3, 10", len(s), cap(s))
+	}
+	for i := range s {
+		v := i
+		s[i] = &v
+	}
+	b := a.MakeSlice([]byte(nil), 100, 100).([]byte)
+	for i := range b {
+		if b[i] != 0 {
+			t.Fatalf("b[%d] = %d, want 0", i, b[i])
+		}
+		b[i] = byte(i)
+	}
+	big := a.MakeSlice([]int64(nil), 1<<16, 1<<16).([]int64)
+	big[len(big)-1] = 1
+
+	runtime.GC()
+	for i := range s {
+		if *s[i] != i {
+			t.Fatalf("*s[%d] = %d, want %d", i, *s[i], i)
+		}
+	}
+	for i := range b {
+		if b[i] != byte(i) {
+			t.Fatalf("b[%d] = %d, want %d", i, b[i], byte(i))
+		}
+	}
+}
+
+func TestBadType(t *testing.T) {
+	a := arena.NewArena()
+	defer a.Free()
+
+	for _, f := range []func(){
+		func() { a.New(node{}) },
+		func() { a.New(nil) },
+		func() { a.MakeSlice((*node)(nil), 0, 0) },
+		func() { a.MakeSlice([]int(nil), 2, 1) },
+		func() { a.MakeSlice([]int(nil), -1, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestFree(t *testing.T) {
+	a := arena.NewArena()
+	x := a.New((*node)(nil)).(*node)
+	x.value = 42
+	a.Free()
+
+	// Values outlive Free for as long as they are referenced.
+	runtime.GC()
+	if x.value != 42 {
+		t.Errorf("x.value = %d after Free, want 42", x.value)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New on freed arena did not panic")
+		}
+	}()
+	a.New((*node)(nil))
+}
+
+func BenchmarkArenaNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a := arena.NewArena()
+		for j := 0; j < 1000; j++ {
+			_ = a.New((*node)(nil)).(*node)
+		}
+		a.Free()
+	}
+}
+
+var sink *node
+
+func BenchmarkHeapNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			sink = new(node)
+		}
+	}
+}
@@ -40,7 +40,7 @@ Flags:
 	-shared
 		Generate code that can be linked into a shared library.
 	-spectre list
-		Enable spectre mitigations in list (all, ret).
+		Enable spectre mitigations in list (all, ret, retthunk).
 	-trimpath prefix
 		Remove prefix from recorded source file paths.
 
@@ -451,6 +451,10 @@ func TestPPC64EndToEnd(t *testing.T) {
 	testEndToEnd(t, "ppc64", "ppc64")
 }
 
+func TestPPC64Errors(t *testing.T) {
+	testErrors(t, "ppc64", "ppc64error")
+}
+
 func TestRISCVEndToEnd(t *testing.T) {
 	testEndToEnd(t, "riscv64", "riscv64")
 }
@@ -375,6 +375,10 @@ TEXT asmtest(SB),DUPOK|NOSPLIT,$0
 	LWSYNC                          // 7c2004ac
 
 	DARN $1, R5                     // 7ca105e6
+	SETBC $2, R3                    // 7c620300
+	SETBCR $2, R3                   // 7c620340
+	SETNBC $2, R3                   // 7c620380
+	SETNBCR $2, R3                  // 7c6203c0
 
 	DCBF (R3)(R4)                   // 7c0418ac
 	DCBI (R3)(R4)                   // 7c041bac
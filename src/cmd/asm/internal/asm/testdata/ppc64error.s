@@ -0,0 +1,10 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+TEXT errors(SB),$0
+	MOVD	R3, R29		// ERROR "frameless function writes frame pointer R29"
+	ADD	$8, R29		// ERROR "frameless function writes frame pointer R29"
+	CMP	R29, R3
+	MOVD	R29, 8(R1)
+	RET
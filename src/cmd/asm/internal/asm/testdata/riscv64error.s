@@ -11,4 +11,5 @@ TEXT errors(SB),$0
 	MOVH	$1, X5				// ERROR "unsupported constant load"
 	MOVW	$1, X5				// ERROR "unsupported constant load"
 	MOVF	$1, X5				// ERROR "unsupported constant load"
+	MOV	X5, X8				// ERROR "frameless function writes frame pointer X8"
 	RET
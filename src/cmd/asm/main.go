@@ -48,11 +48,16 @@ func main() {
 		os.Exit(2)
 	case "":
 		// nothing
-	case "index":
+	case "index", "load":
 		// known to compiler; ignore here so people can use
 		// the same list with -gcflags=-spectre=LIST and -asmflags=-spectrre=LIST
 	case "all", "ret":
 		ctxt.Retpoline = true
+	case "retthunk":
+		if GOARCH != "amd64" {
+			log.Fatalf("GOARCH=%s does not support -spectre=retthunk", GOARCH)
+		}
+		ctxt.RetThunk = true
 	}
 
 	ctxt.Bso = bufio.NewWriter(os.Stdout)
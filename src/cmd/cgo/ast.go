@@ -139,6 +139,7 @@ func (f *File) ParseGo(abspath string, src []byte) {
 	}
 	f.walk(ast2, ctxProg, (*File).validateIdents)
 	f.walk(ast2, ctxProg, (*File).saveExprs)
+	f.saveCgoLayouts(ast1.Comments)
 
 	// Accumulate exported functions.
 	// The comments are only on ast1 but we need to
@@ -195,6 +196,30 @@ func (f *File) saveExprs(x interface{}, context astContext) {
 	}
 }
 
+// saveCgoLayouts records the C types named by //go:cgolayout
+// directives, so that the Go definitions of those types are written
+// out for the compiler to check the annotated Go types against, even
+// if the package does not otherwise refer to them.
+func (f *File) saveCgoLayouts(comments []*ast.CommentGroup) {
+	for _, cg := range comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, "//go:cgolayout ") {
+				continue
+			}
+			fields := strings.Fields(c.Text[len("//go:cgolayout "):])
+			if len(fields) == 0 {
+				continue // the compiler reports the error
+			}
+			goname := strings.TrimPrefix(fields[0], "C.")
+			if f.Name[goname] == nil {
+				name := &Name{Go: goname, C: cname(goname)}
+				f.Name[goname] = name
+				f.NamePos[name] = c.Pos()
+			}
+		}
+	}
+}
+
 // Save references to C.xxx for later processing.
 func (f *File) saveRef(n *ast.Expr, context astContext) {
 	sel := (*n).(*ast.SelectorExpr)
@@ -167,6 +167,19 @@ struct_, union_, or enum_, as in C.struct_stat.
 The size of any C type T is available as C.sizeof_T, as in
 C.sizeof_struct_stat.
 
+A Go struct type that mirrors the layout of a C struct can say so with
+a //go:cgolayout directive naming the C type:
+
+	//go:cgolayout C.struct_stat
+	type Stat struct { ... }
+
+The compiler then reports an error if the size or alignment of the Go
+type differs from that of the C type, or if a named field of the Go
+type differs in offset or size from the C field of the same name
+(ignoring case and the underscore prefix described above). Since bit
+fields and misaligned fields are omitted, they must be covered by
+blank (_) fields in the Go type.
+
 A C function may be declared in the Go file with a parameter type of
 the special name _GoString_. This function may be called with an
 ordinary Go string value. The string length, and a pointer to the
@@ -42,6 +42,8 @@ Flags:
 		Print assembly listing to standard output (code and data).
 	-V
 		Print compiler version and exit.
+	-asan
+		Insert calls to C/C++ address sanitizer.
 	-asmhdr file
 		Write assembly header to file.
 	-buildid id
@@ -50,14 +52,41 @@ Flags:
 		Write block profile for the compilation to file.
 	-c int
 		Concurrency during compilation. Set 1 for no concurrency (default is 1).
+	-caret
+		Print the source line of each error and warning after the message,
+		with a caret under the token at the reported position.
+	-color mode
+		Color the positions and carets printed with -caret. The mode is
+		auto (the default), which colors output to a terminal unless
+		$NO_COLOR is set, always, or never.
 	-complete
 		Assume package has no non-Go components.
+	-covermode mode
+		Count how many times each block of code runs, as go test -cover does.
+		The mode is set, count, or atomic. On Linux, a program with covered
+		packages writes a profile for go tool cover to the file named by
+		$GOCOVERPROFILE when it exits normally.
 	-cpuprofile file
 		Write a CPU profile for the compilation to file.
 	-dynlink
 		Allow references to Go symbols in shared libraries (experimental).
 	-e
 		Remove the limit on the number of errors reported (default limit is 10).
+		Same as -maxerrors=0; an explicit -maxerrors takes precedence.
+	-errorcodes
+		Print the code of each error that has one, such as type-loop or
+		duplicate-method, in brackets after its message. The codes are
+		stable and can be passed to -explain.
+	-errorsort
+		Sort errors by file name, line and column, rather than by the order
+		in which the compiler read the files, so that the output depends
+		only on the errors found.
+	-explain code
+		Print an explanation of the diagnostic code, with examples, and
+		exit. Use -explain=help for the list of codes.
+	-fieldtrack
+		Record the uses of struct fields tagged go:"track", for the linker's
+		-fieldtrack flag. Implied by GOEXPERIMENT=fieldtrack.
 	-goversion string
 		Specify required go tool version of the runtime.
 		Exits when the runtime go version does not match goversion.
@@ -69,6 +98,29 @@ Flags:
 	-importmap old=new
 		Interpret import "old" as import "new" during compilation.
 		The option may be repeated to add multiple mappings.
+	-inlinebigbudget cost
+		Set the maximum cost of a function inlined into a big function
+		(default 20).
+	-inlinebigfunc n
+		Treat functions with at least n nodes as big when inlining
+		into them (default 5000).
+	-inlinebudget cost
+		Set the maximum cost of a function that can be inlined
+		(default 80). Only the bodies of functions within the budget
+		are exported, so to inline more across packages, set it for
+		all packages, as in go build -gcflags=all=-inlinebudget=160.
+	-inlinecallcost cost
+		Set the cost charged for a call that is not inlined
+		(default 57).
+	-inlinehints file
+		Read inlining hints from file. Each line of the file is either
+		"noinline path.F", to never inline the function path.F, or
+		"inline path.F [budget]", to inline it even if its cost exceeds
+		the budget, or, if budget is given, if its cost is at most
+		budget. Methods are named as in path.(*T).M. Blank lines and
+		lines starting with # are ignored. As with -inlinebudget, hints
+		for inlining functions across packages must be given when
+		compiling both packages.
 	-installsuffix suffix
 		Look for packages in $GOROOT/pkg/$GOOS_$GOARCH_suffix
 		instead of $GOROOT/pkg/$GOOS_$GOARCH.
@@ -85,6 +137,11 @@ Flags:
 	-m
 		Print optimization decisions. Higher values or repetition
 		produce more detail.
+	-maxerrors n
+		Stop after reporting n errors (default 10). Zero means no limit.
+	-maxstackframe n
+		Report an error for functions whose stack frames are larger
+		than n bytes, listing their largest local variables.
 	-memprofile file
 		Write memory profile for the compilation to file.
 	-memprofilerate rate
@@ -109,11 +166,33 @@ Flags:
 	-shared
 		Generate code that can be linked into a shared library.
 	-spectre list
-		Enable spectre mitigations in list (all, index, ret).
+		Enable spectre mitigations in list (all, index, load, ret, retthunk).
+		The index mode masks each index after its bounds check so that a
+		mispredicted check cannot read out of bounds. The load mode also
+		masks the indexes the compiler knows to be in bounds, such as those
+		of range loops, and indexes whose checks are disabled by -B, since
+		that knowledge rests on branches that can be mispredicted as well.
+		The ret mode replaces indirect calls and jumps with retpolines, and
+		the retthunk mode replaces returns with jumps to a return thunk that
+		captures their speculative execution. All means index and ret.
+		To apply a mitigation to some packages only, set the flag for them
+		alone, as in go build -gcflags=example.com/crypto/...=-spectre=load.
+	-stackprotect
+		Store a canary value at the top of the stack frame of functions
+		containing address-taken byte arrays, and abort the program if
+		it has been overwritten when the function returns.
 	-traceprofile file
 		Write an execution trace to file.
 	-trimpath prefix
 		Remove prefix from recorded source file paths.
+	-warn list
+		Enable the warnings in list, a comma-separated list of names, or
+		all. A name preceded by a minus sign disables that warning. Try
+		-warn=help for the list of warnings. Warnings report valid code
+		that is probably not what was intended, using information only the
+		compiler has, and do not stop compilation.
+	-Werror
+		Report the warnings enabled by -warn as errors.
 
 Flags related to debugging information:
 
@@ -207,12 +286,14 @@ which typically must be a declaration.
 
 	//go:noescape
 
-The //go:noescape directive must be followed by a function declaration without
-a body (meaning that the function has an implementation not written in Go).
+The //go:noescape directive must be followed by a function declaration.
 It specifies that the function does not allow any of the pointers passed as
 arguments to escape into the heap or into the values returned from the function.
 This information can be used during the compiler's escape analysis of Go code
-calling the function.
+calling the function. If the declaration has no body (meaning that the function
+has an implementation not written in Go), the compiler trusts the directive.
+Otherwise, escape analysis verifies it and reports an error for each parameter
+that escapes.
 
 	//go:uintptrescapes
 
@@ -244,6 +325,68 @@ It specifies that the function must omit its usual stack overflow check.
 This is most commonly used by low-level runtime code invoked
 at times when it is unsafe for the calling goroutine to be preempted.
 
+	//go:multiversion
+
+The //go:multiversion directive must be followed by a function declaration.
+On amd64, it specifies that the function should be compiled twice: once for
+any CPU, and once assuming the CPU has the optional features (currently
+POPCNT, SSE4.1 and FMA) that the compiler otherwise checks for at run time
+before using the instructions that need them. On entry, the function calls
+the second version, named with a ".multiversion" suffix, if the CPU has all
+of these features. Such a function is never inlined, and it must not call
+recover. On other architectures, and with GOAMD64=v3 or later, the directive
+has no effect.
+
+	//go:nocheckbounds
+
+The //go:nocheckbounds directive must be followed by a function declaration.
+It specifies that the function must omit the bounds checks on its indexing
+and slicing operations and the checks for nil pointer dereferences, as if
+every index had been proved to be in range. An out of range index then reads
+or writes arbitrary memory instead of panicking, so the directive should only
+be used on small, carefully audited functions whose checks the compiler cannot
+remove and that are hot enough for the checks to matter. Code inlined into the
+function keeps its checks, and the function itself is never inlined. The
+directive is ignored when compiling with -race or -d=checkptr. Like
+//go:linkname, it is only enabled in files that have imported "unsafe".
+
+	//go:pure
+
+The //go:pure directive must be followed by a declaration of a function or
+method with results. It specifies that the results of the function depend only
+on its arguments and that calling it has no side effects, so that the compiler
+may remove a call whose results are unused and may reuse the results of an
+earlier call with the same arguments. The directive is recorded in export data,
+so it also applies to calls from other packages. The compiler does not check
+the claim; a function that reads or writes memory other than through its
+arguments, or that may panic, should not be marked pure.
+
+	//go:wasmexport name
+
+The //go:wasmexport directive must be followed by a declaration of a
+function, not a method, with a body. With GOOS=js GOARCH=wasm, it specifies
+that the WebAssembly module exports a function called name that calls the
+Go function, so that hosts other than wasm_exec.js can call into Go without
+going through syscall/js. The parameters may have the types int32, uint32,
+int64, uint64, int, uint, float32, float64, bool, uintptr and unsafe.Pointer,
+passed as the corresponding WebAssembly value type (i32 for bool and
+pointers), and syscall/js.Externref, which receives a host reference passed
+as externref; the function may have one result of one of the types other
+than syscall/js.Externref. The call runs on a new goroutine while the caller
+waits, like a function created by syscall/js.FuncOf, so the program must
+still be running (for example, blocked in select {}) and the function must
+not block on work the host does asynchronously. On other systems, the
+directive has no effect.
+
+	//go:lang go1.N
+
+The //go:lang directive must appear before the package clause. It sets the
+language version used to check the file containing it, in place of the
+-lang flag (which the go command derives from the go directive in go.mod).
+It lets a package keep a single file, such as vendored generated code, at
+a different language version than the rest of the package. The version may
+be no newer than the version of the compiler.
+
 	//go:linkname localname [importpath.name]
 
 This special directive does not apply to the Go code that follows it.
@@ -255,5 +398,15 @@ symbol's default object file symbol name and only has the effect of making
 the symbol accessible to other packages.
 Because this directive can subvert the type system and package
 modularity, it is only enabled in files that have imported "unsafe".
+
+	//go:asmoffsets [importpath.]name...
+
+Like //go:linkname, this directive does not apply to the Go code that follows it.
+It names types whose size and, for structs, field offsets the compiler writes
+to the assembly header given by -asmhdr, in addition to the constants and struct
+types that the package itself declares. Each name is a type declared in the
+package or, qualified by its import path, a type in a package imported by it.
+The constants for an imported type are prefixed by its package name, as in
+abi_RegArgs__size and abi_RegArgs_Ints for internal/abi.RegArgs.
 */
 package main
@@ -21,31 +21,58 @@ var Debug DebugFlags
 // DebugFlags defines the debugging configuration values (see var Debug).
 // Each struct field is a different value, named for the lower-case of the field name.
 // Each field must be an int or string and must have a `help` struct tag.
+// An int field may also have a `values` struct tag listing names for
+// some of its values, as in `values:"json=2"`.
 //
 // The -d option takes a comma-separated list of settings.
 // Each setting is name=value; for ints, name is short for name=1.
 type DebugFlags struct {
+	Align                int    `help:"trace type size and alignment calculation; 2 also traces CheckSize calls and their deferral"`
+	AllocSummary         int    `help:"print a summary of heap allocation sites grouped by allocated type"`
 	Append               int    `help:"print information about append compilation"`
+	CallGraph            string `help:"write the static call graph to this file, as JSON if it ends in .json and in Graphviz dot format otherwise"`
 	Checkptr             int    `help:"instrument unsafe pointer conversions"`
+	CheckptrExclude      string `help:"disable checkptr instrumentation for packages matching this pattern (... is a wildcard)"`
+	CheckptrSite         int    `help:"report the position and text of the conversion when a checkptr check fails (with checkptr)"`
 	Closure              int    `help:"print information about closure compilation"`
+	CodeSize             int    `help:"print the machine code size each source function contributes after inlining, including its inlined copies; 2 prints JSON"`
+	ConstExpr            int    `help:"print information about compile-time evaluation of calls in package initialization"`
+	CopySize             int    `help:"report copies of values larger than this many bytes"`
 	DclStack             int    `help:"run internal dclstack check"`
 	Defer                int    `help:"print information about defer compilation"`
 	DisableNil           int    `help:"disable nil checks"`
 	DumpPtrs             int    `help:"show Node pointers values in dump output"`
 	DwarfInl             int    `help:"print information about DWARF inlined function creation"`
+	EscapeGraph          string `help:"write the escape analysis graph to this file, as JSON if it ends in .json and in Graphviz dot format otherwise"`
 	Export               int    `help:"print export data"`
+	FrameSize            int    `help:"print each function's stack frame size; 2 prints JSON"`
+	FuncHash             int    `help:"print a hash of each function's typed IR"`
 	GCProg               int    `help:"print dump of GC programs"`
 	InlFuncsWithClosures int    `help:"allow functions with closures to be inlined"`
+	LayoutCompare        string `help:"report declared types whose size, alignment or field offsets differ on this GOARCH"`
+	LazyItabs            int    `help:"generate itabs at run time on first use instead of statically (except in the runtime)"`
 	Libfuzzer            int    `help:"enable coverage instrumentation for libfuzzer"`
 	LocationLists        int    `help:"print information about DWARF location list creation"`
+	LocationQuality      int    `help:"print how much of each function's code its variables have DWARF locations for"`
 	Nil                  int    `help:"print information about nil checks"`
+	NilCheckReport       int    `help:"print a summary of the nil checks in each function"`
 	PCTab                string `help:"print named pc-value table"`
 	Panic                int    `help:"show all compiler panics"`
+	RaceFields           int    `help:"instrument struct accesses one field at a time (with -race)"`
+	Remarks              string `help:"write optimization remarks to this file, as YAML if it ends in .yaml or .yml and as JSON lines otherwise"`
 	Slice                int    `help:"print information about slice compilation"`
 	SoftFloat            int    `help:"force compiler to emit soft-float code"`
+	SSADir               string `help:"write the SSA of each function to a file in this directory; see ssa/help for per-pass output"`
+	SSAStress            int    `help:"stress-test the SSA backend with this seed: randomize value and block order, check the SSA after every pass, and compare each function with a compile using the next seed"`
+	StaticMaps           int    `help:"build unexported maps initialized with constant string keys and elements and never changed as static perfect hash tables"`
+	TailCall             int    `help:"print information about tail call elimination"`
 	TypeAssert           int    `help:"print information about type assertion inlining"`
+	TypeBits             int    `help:"print the pointer mask or GC program given to the garbage collector for each type descriptor"`
+	TypeSizes            int    `help:"print the size and alignment of each declared type, largest first"`
 	TypecheckInl         int    `help:"eager typechecking of inline function bodies"`
-	WB                   int    `help:"print information about write barriers"`
+	Unroll               int    `help:"fully unroll loops with at most this many iterations"`
+	WB                   int    `help:"print information about write barriers; json prints a log of each function's write barriers" values:"json=2"`
+	WBReport             int    `help:"print a summary of the write barriers in each function"`
 	ABIWrap              int    `help:"print information about ABI wrapper generation"`
 
 	any bool // set when any of the values have been set
@@ -55,9 +82,10 @@ type DebugFlags struct {
 func (d *DebugFlags) Any() bool { return d.any }
 
 type debugField struct {
-	name string
-	help string
-	val  interface{} // *int or *string
+	name   string
+	help   string
+	val    interface{}    // *int or *string
+	values map[string]int // named int values
 }
 
 var debugTab []debugField
@@ -82,10 +110,34 @@ func init() {
 		case *int, *string:
 			// ok
 		}
-		debugTab = append(debugTab, debugField{name, help, ptr})
+		values := valuesTag("base.Debug", f)
+		debugTab = append(debugTab, debugField{name, help, ptr, values})
 	}
 }
 
+// valuesTag returns the named values listed in the `values` struct
+// tag of the field f of the struct var named by what, or nil if it has
+// none.
+func valuesTag(what string, f reflect.StructField) map[string]int {
+	tag := f.Tag.Get("values")
+	if tag == "" {
+		return nil
+	}
+	values := make(map[string]int)
+	for _, kv := range strings.Split(tag, ",") {
+		i := strings.Index(kv, "=")
+		if i < 0 {
+			panic(fmt.Sprintf("%s.%s has invalid values tag %q", what, f.Name, tag))
+		}
+		n, err := strconv.Atoi(kv[i+1:])
+		if err != nil {
+			panic(fmt.Sprintf("%s.%s has invalid values tag %q", what, f.Name, tag))
+		}
+		values[kv[:i]] = n
+	}
+	return values
+}
+
 // DebugSSA is called to set a -d ssa/... option.
 // If nil, those options are reported as invalid options.
 // If DebugSSA returns a non-empty string, that text is reported as a compiler error.
@@ -140,7 +192,11 @@ Split:
 				*vp = valstring
 			case *int:
 				if !haveInt {
-					log.Fatalf("invalid debug value %v", name)
+					v, ok := t.values[valstring]
+					if !ok {
+						log.Fatalf("invalid debug value %v", name)
+					}
+					val = v
 				}
 				*vp = val
 			default:
@@ -185,4 +241,9 @@ Key "checkptr" supports values:
 
 Key "pctab" supports values:
 	"pctospadj", "pctofile", "pctoline", "pctoinline", "pctopcdata"
+
+Key "wb" supports values:
+	"1": report each write barrier
+	"json": print one JSON object per function listing its write
+	    barrier stores, their types, and why they were not elided
 `
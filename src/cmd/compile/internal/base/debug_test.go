@@ -0,0 +1,52 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package base
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValuesTag(t *testing.T) {
+	type flags struct {
+		None  int
+		JSON  int `values:"json=2"`
+		Multi int `values:"json=-1,all=3"`
+	}
+	typ := reflect.TypeOf(flags{})
+	for _, test := range []struct {
+		field string
+		want  map[string]int
+	}{
+		{"None", nil},
+		{"JSON", map[string]int{"json": 2}},
+		{"Multi", map[string]int{"json": -1, "all": 3}},
+	} {
+		f, _ := typ.FieldByName(test.field)
+		if got := valuesTag("flags", f); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("valuesTag(%s) = %v, want %v", test.field, got, test.want)
+		}
+	}
+}
+
+func TestValuesTagInvalid(t *testing.T) {
+	type flags struct {
+		NoValue  int `values:"json"`
+		BadValue int `values:"json=x"`
+		Empty    int `values:"json=2,"`
+	}
+	typ := reflect.TypeOf(flags{})
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("valuesTag(%s) did not panic", f.Name)
+				}
+			}()
+			valuesTag("flags", f)
+		}()
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package base
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cmd/internal/src"
+)
+
+// JSONDiagnostics reports whether -json=diagnostics was given, in
+// which case errors and warnings are printed as JSON objects, one per
+// line, instead of as text.
+func JSONDiagnostics() bool {
+	return Flag.JSON == "diagnostics"
+}
+
+// Codes of diagnostics that tools may want to recognize. Most
+// diagnostics have no code. The codes are stable: once assigned, a
+// code keeps its meaning, so that it can be used to look up an
+// explanation with -explain or documentation elsewhere.
+const (
+	CodeSyntax            = "syntax"                 // syntax error
+	CodeUndefined         = "undefined"              // undefined name
+	CodeUnused            = "unused"                 // variable or import not used
+	CodeTypeLoop          = "type-loop"              // invalid recursive type
+	CodeInitLoop          = "init-loop"              // initialization loop
+	CodeDuplicateMethod   = "duplicate-method"       // interface with two methods of the same name
+	CodeEmbeddedNonIface  = "embedded-non-interface" // interface embedding a non-interface type
+	CodeInterfaceTooLarge = "interface-too-large"    // interface with too many methods
+	CodeTypeTooLarge      = "type-too-large"         // type larger than the address space
+	CodeLayoutCheck       = "layout-check"           // failed //go:layoutcheck or //go:cgolayout
+	CodeTooMany           = "too-many"               // too many errors; compilation stopped
+	CodeInternal          = "internal-error"         // internal compiler error
+)
+
+// errorCode returns the code of the error message msg reported
+// without an explicit code, based on its text.
+func errorCode(msg string) string {
+	switch {
+	case strings.HasPrefix(msg, "syntax error"):
+		return CodeSyntax
+	case strings.HasPrefix(msg, "undefined: "):
+		return CodeUndefined
+	case strings.Contains(msg, "declared but not used"), strings.Contains(msg, "imported and not used"):
+		return CodeUnused
+	}
+	return ""
+}
+
+// A Note is a secondary position related to an error, such as one
+// element of a type loop.
+type Note struct {
+	Pos src.XPos
+	Msg string
+}
+
+// A Fix is a change to the source text that fixes an error, for
+// editors and other tools to apply. Fixes are only printed by
+// -json=diagnostics.
+type Fix struct {
+	Msg   string // what the fix does, as in "remove import"
+	Edits []Edit
+}
+
+// An Edit replaces the Len bytes of source text starting at Pos with
+// New. The bytes may extend past the end of the line.
+type Edit struct {
+	Pos src.XPos
+	Len int
+	New string
+}
+
+// A Diagnostic is an error or warning as printed by -json=diagnostics.
+type Diagnostic struct {
+	Severity       string // "error" or "warning"
+	Code           string `json:",omitempty"` // one of the Code constants
+	Range          *Range `json:",omitempty"`
+	Message        string
+	Related        []RelatedInfo  `json:",omitempty"`
+	SuggestedFixes []SuggestedFix `json:",omitempty"`
+}
+
+// A RelatedInfo is a Note as printed by -json=diagnostics.
+type RelatedInfo struct {
+	Range   *Range `json:",omitempty"`
+	Message string
+}
+
+// A SuggestedFix is a Fix as printed by -json=diagnostics.
+type SuggestedFix struct {
+	Message string
+	Edits   []TextEdit
+}
+
+// A TextEdit is an Edit as printed by -json=diagnostics: it replaces
+// the bytes of File from offset Start up to End with NewText. Unlike
+// the File of a Position, File is the name of the file the compiler
+// read, regardless of //line directives.
+type TextEdit struct {
+	File       string
+	Start, End int
+	NewText    string
+}
+
+// A Range is a range of source text. The compiler only records the
+// start of each range, so End is the same as Start.
+type Range struct {
+	Start, End Position
+}
+
+// A Position is a source position as shown in error messages, after
+// any //line directives. Line and Col start at 1; Col is 0 if unknown.
+type Position struct {
+	File string
+	Line uint
+	Col  uint
+}
+
+// diagRange returns the range for pos, or nil if pos is unknown.
+func diagRange(pos src.XPos) *Range {
+	if !pos.IsKnown() || Ctxt == nil {
+		return nil
+	}
+	p := Ctxt.OutermostPos(pos)
+	start := Position{File: p.RelFilename(), Line: p.RelLine(), Col: p.RelCol()}
+	return &Range{Start: start, End: start}
+}
+
+// newDiagnostic returns the JSON form of the message msg at pos.
+func newDiagnostic(severity, code string, pos src.XPos, msg string, notes []Note, fix *Fix) *Diagnostic {
+	d := &Diagnostic{
+		Severity: severity,
+		Code:     code,
+		Range:    diagRange(pos),
+		Message:  msg,
+	}
+	for _, n := range notes {
+		d.Related = append(d.Related, RelatedInfo{Range: diagRange(n.Pos), Message: n.Msg})
+	}
+	if sf := suggestedFix(fix); sf != nil {
+		d.SuggestedFixes = []SuggestedFix{*sf}
+	}
+	return d
+}
+
+// suggestedFix returns the JSON form of fix, or nil if fix is nil or
+// the source text it edits is not available.
+func suggestedFix(fix *Fix) *SuggestedFix {
+	if fix == nil || Ctxt == nil {
+		return nil
+	}
+	sf := &SuggestedFix{Message: fix.Msg}
+	for _, e := range fix.Edits {
+		if !e.Pos.IsKnown() {
+			return nil
+		}
+		p := Ctxt.OutermostPos(e.Pos)
+		f := readSource(p.Filename())
+		if f == nil || p.Line() < 1 || int(p.Line()) > len(f.lines) {
+			return nil
+		}
+		start := f.lines[p.Line()-1] + int(p.Col()) - 1
+		if p.Col() < 1 || start+e.Len > len(f.data) {
+			return nil
+		}
+		sf.Edits = append(sf.Edits, TextEdit{File: p.Filename(), Start: start, End: start + e.Len, NewText: e.New})
+	}
+	return sf
+}
+
+// formatNotes returns the text form of notes, as lines to append to
+// an error message.
+func formatNotes(notes []Note) string {
+	var b strings.Builder
+	for _, n := range notes {
+		fmt.Fprintf(&b, "\n\t%v: %s", FmtPos(n.Pos), n.Msg)
+	}
+	return b.String()
+}
+
+// marshalDiagnostic returns d as a line of JSON.
+func marshalDiagnostic(d *Diagnostic) string {
+	b, err := json.Marshal(d)
+	if err != nil {
+		panic(err)
+	}
+	return string(b) + "\n"
+}
@@ -0,0 +1,193 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package base
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// explanations holds the text printed by -explain for each diagnostic
+// code, including the names of warnings.
+var explanations = map[string]string{
+	CodeSyntax: `
+The source file is not syntactically valid Go. The message says what
+the parser found and what it expected instead, as in
+
+	func f() {
+		x := 1 +
+	}
+
+	syntax error: unexpected }, expecting expression
+
+Only the first syntax error on each line is reported.`,
+
+	CodeUndefined: `
+A name is used that is not declared in the current scope, the package
+or the universe block, or a qualified name refers to something the
+imported package does not declare or export:
+
+	fmt.Printn("hi")  // undefined: fmt.Printn
+
+Check the spelling and capitalization of the name, and that the file
+declaring it is part of the package being compiled.`,
+
+	CodeUnused: `
+A local variable is declared but never used, or a package is imported
+but not referred to. Go reports these as errors rather than warnings:
+
+	func f() {
+		x := compute()  // x declared but not used
+	}
+
+Remove the declaration or import, assign the value to the blank
+identifier (_ = x), or import the package for its side effects only
+with import _ "path".`,
+
+	CodeTypeLoop: `
+A type refers to itself in a way that would make its values infinitely
+large. The notes list the types in the loop:
+
+	type T struct {
+		next T  // invalid recursive type T
+	}
+
+Break the loop with an indirection such as a pointer, slice or map:
+
+	type T struct {
+		next *T
+	}`,
+
+	CodeInitLoop: `
+The initialization expression of a package-level variable depends,
+directly or through function calls, on the variable itself. The notes
+list the chain of references:
+
+	var x = f()
+	func f() int { return x }  // initialization loop
+
+Move the part of the computation that refers to the variable into an
+init function or into code run after initialization.`,
+
+	CodeDuplicateMethod: `
+An interface type has two methods with the same name, either declared
+explicitly or through embedded interfaces with different signatures:
+
+	type I interface {
+		M()
+		M(int)  // duplicate method M
+	}
+
+Since Go 1.14, embedding two interfaces that declare the same method
+with identical signatures is allowed; the methods must still agree.`,
+
+	CodeEmbeddedNonIface: `
+An interface type embeds a type that is not an interface. Only
+interface types can be embedded in interfaces:
+
+	type I interface {
+		int  // interface contains embedded non-interface int
+	}
+
+Embed an interface type, or declare the methods directly.`,
+
+	CodeInterfaceTooLarge: `
+An interface type has more methods than the method table of its
+values can address on the target architecture. This happens only for
+generated code; split the interface into smaller ones.`,
+
+	CodeTypeTooLarge: `
+A type is larger than the compiler can lay out on the target
+architecture: its size does not fit in the address space, or on 32-bit
+systems exceeds 2GB. Channel element types are limited to 64kB.
+
+	var a [1 << 40]byte  // type [1099511627776]byte larger than address space (on 32-bit systems)
+
+Allocate large buffers at run time with make instead, and send
+pointers to large values over channels.`,
+
+	CodeLayoutCheck: `
+A //go:layoutcheck or //go:cgolayout directive states a size,
+alignment or field offset that does not match the layout the compiler
+computed for the struct type that follows the directive:
+
+	//go:layoutcheck size=12
+	type T struct {
+		a int32
+		b int64
+	}  // go:layoutcheck failed: size of T is 16, not 12 (on 64-bit systems)
+
+Either the type changed and the directive needs updating, or code that
+depends on the layout, such as assembly or C, needs to change with it.
+-d=layoutcompare=GOARCH shows the layout on another architecture.`,
+
+	CodeTooMany: `
+The compiler stops after reporting 10 errors, or the number set by
+-maxerrors. Fix the reported errors, or use -maxerrors=0 (or -e) to
+report all of them.`,
+
+	CodeInternal: `
+The compiler failed because of a bug in the compiler itself, not in the
+program being compiled. Please report it at https://golang.org/issue/new,
+with the program and the full message.`,
+
+	"unusedresult": `
+With -warn=unusedresult, the compiler reports call statements whose
+callee is marked //go:pure. Such a function has no effect other than
+returning its results, so a call that discards them does nothing and
+the compiler removes it:
+
+	//go:pure
+	func square(x int) int { return x * x }
+
+	square(n)  // result of pure function square is not used
+
+Usually the result was meant to be assigned.`,
+
+	"largealloc": `
+With -warn=largealloc, the compiler reports values that escape analysis
+would keep on the stack but that are too large for it, so that they are
+allocated on the heap instead:
+
+	var buf [20 << 20]byte  // buf is allocated on the heap because it is too large for the stack
+
+Such allocations are costly if they happen in a loop or a frequently
+called function; consider reusing the buffer.`,
+
+	"largecopy": `
+With -warn=largecopy, the compiler reports assignments, function
+arguments, method receivers and range statements that copy more than
+1kB, or more than the -d=copysize setting:
+
+	for _, v := range bigValues {  // range value copies 4096 bytes of Big per iteration
+
+Use a pointer, or index the slice, to avoid the copy.`,
+}
+
+// explain prints the explanation of the diagnostic code for
+// -explain and exits.
+func explain(code string) {
+	text, ok := explanations[code]
+	if !ok {
+		if code != "help" {
+			log.Printf("unknown diagnostic code %q", code)
+		}
+		var codes []string
+		for c := range explanations {
+			codes = append(codes, c)
+		}
+		sort.Strings(codes)
+		fmt.Printf("usage: -explain=code, where code is one of:\n\n\t%s\n", strings.Join(codes, "\n\t"))
+		if code != "help" {
+			os.Exit(2)
+		}
+		os.Exit(0)
+	}
+	fmt.Printf("%s:\n%s\n", code, text)
+	os.Exit(0)
+}
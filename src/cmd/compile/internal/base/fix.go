@@ -0,0 +1,72 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package base
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"cmd/internal/src"
+)
+
+// SourceAt returns the source text from pos to the end of its line,
+// for errors that suggest fixes, or false if it is not available.
+func SourceAt(pos src.XPos) (string, bool) {
+	if !pos.IsKnown() || Ctxt == nil {
+		return "", false
+	}
+	p := Ctxt.OutermostPos(pos)
+	line, ok := sourceLine(p.Filename(), p.Line())
+	if !ok || p.Col() < 1 || int(p.Col()) > len(line)+1 {
+		return "", false
+	}
+	return string(line[p.Col()-1:]), true
+}
+
+// IdentAt reports whether the source text at pos is the identifier
+// name.
+func IdentAt(pos src.XPos, name string) bool {
+	text, ok := SourceAt(pos)
+	return ok && len(text) >= len(name) && text[:len(name)] == name && !isIdentRune(text[len(name):])
+}
+
+// FindIdent returns the position of the identifier name on the line
+// containing pos, for errors reported at the position of a statement
+// rather than of the name. It returns false unless name occurs on the
+// line exactly once, other than after a dot.
+func FindIdent(pos src.XPos, name string) (src.XPos, bool) {
+	line, ok := SourceAt(pos.AtColumn1())
+	if !ok || name == "" {
+		return src.NoXPos, false
+	}
+	col := -1
+	for i := 0; i+len(name) <= len(line); i++ {
+		if line[i:i+len(name)] != name || isIdentRune(line[i+len(name):]) {
+			continue
+		}
+		if i > 0 {
+			r, _ := utf8.DecodeLastRuneInString(line[:i])
+			if r == '.' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				continue
+			}
+		}
+		if col >= 0 {
+			return src.NoXPos, false
+		}
+		col = i
+	}
+	if col < 0 {
+		return src.NoXPos, false
+	}
+	p := Ctxt.PosTable.Pos(pos)
+	return Ctxt.PosTable.XPos(src.MakePos(p.Base(), p.Line(), uint(col+1))), true
+}
+
+// isIdentRune reports whether s starts with a rune that can continue
+// an identifier.
+func isIdentRune(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+	return size > 0 && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+}
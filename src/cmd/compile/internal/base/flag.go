@@ -8,11 +8,14 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"go/layout"
 	"io/ioutil"
 	"log"
 	"os"
 	"reflect"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"cmd/internal/objabi"
@@ -48,6 +51,8 @@ type CountFlag int
 //
 // The allowed field types are bool, int, string, pointers to those (for values stored elsewhere),
 // CountFlag (for a counting flag), and func(string) (for a flag that uses special code for parsing).
+// A CountFlag field may also have a `values` struct tag listing names for
+// some of its values, as in `values:"json=-1"`.
 type CmdFlags struct {
 	// Single letters
 	B CountFlag    "help:\"disable bounds checking\""
@@ -59,13 +64,13 @@ type CmdFlags struct {
 	K CountFlag    "help:\"debug missing line numbers\""
 	L CountFlag    "help:\"show full file names in error messages\""
 	N CountFlag    "help:\"disable optimizations\""
-	S CountFlag    "help:\"print assembly listing\""
+	S CountFlag    "help:\"print assembly listing; json prints the instructions of each function as JSON\" values:\"json=-1\""
 	// V is added by objabi.AddVersionFlag
 	W CountFlag "help:\"debug parse tree after type checking\""
 
 	LowerC int          "help:\"concurrency during compilation (1 means no concurrency)\""
 	LowerD func(string) "help:\"enable debugging settings; try -d help\""
-	LowerE CountFlag    "help:\"no limit on number of errors reported\""
+	LowerE CountFlag    "help:\"no limit on number of errors reported (same as -maxerrors=0)\""
 	LowerH CountFlag    "help:\"halt on error\""
 	LowerJ CountFlag    "help:\"debug runtime-initialized variables\""
 	LowerL CountFlag    "help:\"disable inlining\""
@@ -83,30 +88,45 @@ type CmdFlags struct {
 
 	// Longer names
 	ABIWrapLimit       int          "help:\"emit at most N ABI wrappers (for debugging)\""
+	ASan               bool         "help:\"build code compatible with C/C++ address sanitizer\""
 	AsmHdr             string       "help:\"write assembly header to `file`\""
 	Bench              string       "help:\"append benchmark times to `file`\""
 	BlockProfile       string       "help:\"write block profile to `file`\""
 	BuildID            string       "help:\"record `id` as the build id in the export metadata\""
 	CPUProfile         string       "help:\"write cpu profile to `file`\""
+	Caret              bool         "help:\"print the source line of each error and warning with a caret under its position\""
+	Color              string       "help:\"color the -caret output: `mode` auto (when printing to a terminal), always, or never\""
 	Complete           bool         "help:\"compiling complete package (no C or assembly)\""
 	ClobberDead        bool         "help:\"clobber dead stack slots (for debugging)\""
 	ClobberDeadReg     bool         "help:\"clobber dead registers (for debugging)\""
+	CoverMode          string       "help:\"instrument the package for coverage, counting in `mode` set, count, or atomic\""
 	Dwarf              bool         "help:\"generate DWARF symbols\""
 	DwarfBASEntries    *bool        "help:\"use base address selection entries in DWARF\""                        // &Ctxt.UseBASEntries, set below
 	DwarfLocationLists *bool        "help:\"add location lists to DWARF in optimized mode\""                      // &Ctxt.Flag_locationlists, set below
 	Dynlink            *bool        "help:\"support references to Go symbols defined in other shared libraries\"" // &Ctxt.Flag_dynlink, set below
 	EmbedCfg           func(string) "help:\"read go:embed configuration from `file`\""
+	ErrorCodes         bool         "help:\"print the code of each error that has one after its message\""
+	ErrorSort          bool         "help:\"sort errors by file name and position rather than by the order the files were read\""
+	Explain            func(string) "help:\"print an explanation of the diagnostic `code` and exit; try -explain=help\""
+	FieldTrack         bool         "help:\"track uses of struct fields tagged go:\\\"track\\\"\""
 	GenDwarfInl        int          "help:\"generate DWARF inline info records\"" // 0=disabled, 1=funcs, 2=funcs+formals/locals
 	GoVersion          string       "help:\"required version of the runtime\""
 	ImportCfg          func(string) "help:\"read import configuration from `file`\""
 	ImportMap          func(string) "help:\"add `definition` of the form source=actual to import map\""
+	InlineBigBudget    int          "help:\"set the maximum `cost` of a function inlined into a big function\""
+	InlineBigFunc      int          "help:\"treat functions with at least `n` nodes as big when inlining into them\""
+	InlineBudget       int          "help:\"set the maximum `cost` of an inlinable function\""
+	InlineCallCost     int          "help:\"set the `cost` of a call that is not inlined\""
+	InlineHints        func(string) "help:\"read inlining hints from `file`\""
 	InstallSuffix      string       "help:\"set pkg directory `suffix`\""
-	JSON               string       "help:\"version,file for JSON compiler/optimizer detail output\""
+	JSON               string       "help:\"version,file for JSON compiler/optimizer detail output, or diagnostics to print errors as JSON\""
 	Lang               string       "help:\"Go language version source code expects\""
 	LinkObj            string       "help:\"write linker-specific object to `file`\""
 	LinkShared         *bool        "help:\"generate code that will be linked against Go shared libraries\"" // &Ctxt.Flag_linkshared, set below
-	Live               CountFlag    "help:\"debug liveness analysis\""
+	Live               CountFlag    "help:\"debug liveness analysis; json prints the stack map of each safe point as JSON\" values:\"json=-1\""
 	MSan               bool         "help:\"build code compatible with C/C++ memory sanitizer\""
+	MaxErrors          int          "help:\"stop after reporting `n` errors (0 means no limit)\""
+	MaxStackFrame      int          "help:\"report an error for stack frames larger than `n` bytes\""
 	MemProfile         string       "help:\"write memory profile to `file`\""
 	MemProfileRate     int64        "help:\"set runtime.MemProfileRate to `rate`\""
 	MutexProfile       string       "help:\"write mutex profile to `file`\""
@@ -115,12 +135,15 @@ type CmdFlags struct {
 	Race               bool         "help:\"enable race detector\""
 	Shared             *bool        "help:\"generate code that can be linked into a shared library\"" // &Ctxt.Flag_shared, set below
 	SmallFrames        bool         "help:\"reduce the size limit for stack allocated objects\""      // small stacks, to diagnose GC latency; see golang.org/issue/27732
-	Spectre            string       "help:\"enable spectre mitigations in `list` (all, index, ret)\""
+	Spectre            string       "help:\"enable spectre mitigations in `list` (all, index, load, ret, retthunk)\""
+	StackProtect       bool         "help:\"insert stack canaries in frames containing address-taken byte arrays\""
 	Std                bool         "help:\"compiling standard library\""
 	SymABIs            string       "help:\"read symbol ABIs from `file`\""
 	TraceProfile       string       "help:\"write an execution trace to `file`\""
 	TrimPath           string       "help:\"remove `prefix` from recorded source file paths\""
 	WB                 bool         "help:\"enable write barrier\"" // TODO: remove
+	Warn               func(string) "help:\"enable the warnings in `list` (names separated by commas, all, or -name to disable one; try -warn=help)\""
+	Werror             bool         "flag:\"Werror\" help:\"report warnings enabled by -warn as errors\""
 
 	// Configuration derived from flags; not a flag itself.
 	Cfg struct {
@@ -128,16 +151,21 @@ type CmdFlags struct {
 			Patterns map[string][]string
 			Files    map[string]string
 		}
-		ImportDirs   []string          // appended to by -I
-		ImportMap    map[string]string // set by -importmap OR -importcfg
-		PackageFile  map[string]string // set by -importcfg; nil means not in use
-		SpectreIndex bool              // set by -spectre=index or -spectre=all
+		ImportDirs   []string              // appended to by -I
+		ImportMap    map[string]string     // set by -importmap OR -importcfg
+		InlineHints  map[string]InlineHint // set by -inlinehints; keyed by ir.PkgFuncName
+		PackageFile  map[string]string     // set by -importcfg; nil means not in use
+		SpectreIndex bool                  // set by -spectre=index, -spectre=load or -spectre=all
+		SpectreLoad  bool                  // set by -spectre=load
 		// Whether we are adding any sort of code instrumentation, such as
 		// when the race detector is enabled.
 		Instrumenting bool
 	}
 }
 
+// LiveJSON is the value of Flag.Live set by -live=json.
+const LiveJSON = -1
+
 // ParseFlags parses the command-line flags into Flag.
 func ParseFlags() {
 	Flag.I = addImportDir
@@ -152,14 +180,24 @@ func ParseFlags() {
 	Flag.DwarfLocationLists = &Ctxt.Flag_locationlists
 	*Flag.DwarfLocationLists = true
 	Flag.Dynlink = &Ctxt.Flag_dynlink
+	Flag.Color = "auto"
 	Flag.EmbedCfg = readEmbedCfg
+	Flag.Explain = explain
+	Flag.Warn = parseWarn
 	Flag.GenDwarfInl = 2
 	Flag.ImportCfg = readImportCfg
 	Flag.ImportMap = addImportMap
+	Flag.InlineBigBudget = 20
+	Flag.InlineBigFunc = 5000
+	Flag.InlineBudget = 80
+	Flag.InlineCallCost = 57 // benchmarked to provide most benefit with no bad surprises; see https://github.com/golang/go/issues/19348#issuecomment-439370742
+	Flag.InlineHints = readInlineHints
 	Flag.LinkShared = &Ctxt.Flag_linkshared
+	Flag.MaxErrors = 10
 	Flag.Shared = &Ctxt.Flag_shared
 	Flag.WB = true
 	Debug.InlFuncsWithClosures = 1
+	Debug.Unroll = 4
 
 	Debug.Checkptr = -1 // so we can tell whether it is set explicitly
 
@@ -172,6 +210,9 @@ func ParseFlags() {
 	if Flag.MSan && !sys.MSanSupported(objabi.GOOS, objabi.GOARCH) {
 		log.Fatalf("%s/%s does not support -msan", objabi.GOOS, objabi.GOARCH)
 	}
+	if Flag.ASan && !sys.ASanSupported(objabi.GOOS, objabi.GOARCH) {
+		log.Fatalf("%s/%s does not support -asan", objabi.GOOS, objabi.GOARCH)
+	}
 	if Flag.Race && !sys.RaceDetectorSupported(objabi.GOOS, objabi.GOARCH) {
 		log.Fatalf("%s/%s does not support -race", objabi.GOOS, objabi.GOARCH)
 	}
@@ -179,6 +220,21 @@ func ParseFlags() {
 		log.Fatalf("%s/%s does not support -shared", objabi.GOOS, objabi.GOARCH)
 	}
 	parseSpectre(Flag.Spectre) // left as string for RecordFlags
+	parseColor(Flag.Color)
+	if Flag.LowerE != 0 && !isFlagSet("maxerrors") {
+		Flag.MaxErrors = 0
+	}
+	if Flag.MaxErrors < 0 {
+		log.Fatalf("invalid -maxerrors %d: must not be negative", Flag.MaxErrors)
+	}
+	switch Flag.CoverMode {
+	case "", "set", "count", "atomic":
+	default:
+		log.Fatalf("invalid -covermode %q: must be set, count, or atomic", Flag.CoverMode)
+	}
+	if Debug.LayoutCompare != "" && layout.ArchFor(Debug.LayoutCompare) == nil {
+		log.Fatalf("invalid -d=layoutcompare %q: unknown GOARCH", Debug.LayoutCompare)
+	}
 
 	Ctxt.Flag_shared = Ctxt.Flag_dynlink || Ctxt.Flag_shared
 	Ctxt.Flag_optimize = Flag.N == 0
@@ -216,8 +272,17 @@ func ParseFlags() {
 	if Flag.Race && Flag.MSan {
 		log.Fatal("cannot use both -race and -msan")
 	}
-	if Flag.Race || Flag.MSan {
-		// -race and -msan imply -d=checkptr for now.
+	if Flag.Race && Flag.ASan {
+		log.Fatal("cannot use both -race and -asan")
+	}
+	if Flag.MSan && Flag.ASan {
+		log.Fatal("cannot use both -msan and -asan")
+	}
+	if objabi.Experiment.FieldTrack {
+		Flag.FieldTrack = true
+	}
+	if Flag.Race || Flag.MSan || Flag.ASan {
+		// -race, -msan and -asan imply -d=checkptr for now.
 		if Debug.Checkptr == -1 { // if not set explicitly
 			Debug.Checkptr = 1
 		}
@@ -226,6 +291,9 @@ func ParseFlags() {
 	if Flag.CompilingRuntime && Flag.N != 0 {
 		log.Fatal("cannot disable optimizations while compiling runtime")
 	}
+	if Flag.InlineBudget < 0 || Flag.InlineBigBudget < 0 || Flag.InlineBigFunc < 0 || Flag.InlineCallCost < 0 {
+		log.Fatal("inlining budgets and costs must not be negative")
+	}
 	if Flag.LowerC < 1 {
 		log.Fatalf("-c must be at least 1, got %d", Flag.LowerC)
 	}
@@ -239,6 +307,14 @@ func ParseFlags() {
 
 		// Fuzzing the runtime isn't interesting either.
 		Debug.Libfuzzer = 0
+
+		// Nor is its coverage, which it would have to record
+		// in code that must not be instrumented.
+		Flag.CoverMode = ""
+	}
+
+	if Debug.CheckptrExclude != "" && matchPkgPattern(Debug.CheckptrExclude, Ctxt.Pkgpath) {
+		Debug.Checkptr = 0
 	}
 
 	if Debug.Checkptr == -1 { // if not set explicitly
@@ -249,6 +325,19 @@ func ParseFlags() {
 	Ctxt.Debugpcln = Debug.PCTab
 }
 
+// matchPkgPattern reports whether the import path matches pattern,
+// in which ... matches any string. As with the go command, a
+// pattern ending in /... also matches the path without it, so
+// that net/... matches net.
+func matchPkgPattern(pattern, path string) bool {
+	re := regexp.QuoteMeta(pattern)
+	re = strings.ReplaceAll(re, `\.\.\.`, `.*`)
+	if strings.HasSuffix(re, `/.*`) {
+		re = strings.TrimSuffix(re, `/.*`) + `(/.*)?`
+	}
+	return regexp.MustCompile(`^` + re + `$`).MatchString(path)
+}
+
 // registerFlags adds flag registrations for all the fields in Flag.
 // See the comment on type CmdFlags for the rules.
 func registerFlags() {
@@ -313,6 +402,10 @@ func registerFlags() {
 			flag.StringVar(p, name, *p, help)
 		case countType:
 			p := (*int)(v.Field(i).Addr().Interface().(*CountFlag))
+			if values := valuesTag("base.Flag", f); values != nil {
+				flag.Var(&namedCount{p, values}, name, help)
+				break
+			}
 			objabi.Flagcount(name, help, p)
 		case funcType:
 			f := v.Field(i).Interface().(func(string))
@@ -321,6 +414,56 @@ func registerFlags() {
 	}
 }
 
+// namedCount is the flag.Value of a CountFlag with named values.
+// Besides the usual counting flag syntax, it accepts -name=value
+// for each of the names.
+type namedCount struct {
+	p      *int
+	values map[string]int
+}
+
+func (c *namedCount) String() string {
+	if c.p == nil {
+		return "0"
+	}
+	return strconv.Itoa(*c.p)
+}
+
+func (c *namedCount) Set(s string) error {
+	if n, ok := c.values[s]; ok {
+		*c.p = n
+		return nil
+	}
+	switch s {
+	case "true":
+		*c.p++
+	case "false":
+		*c.p = 0
+	default:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid count %q", s)
+		}
+		*c.p = n
+	}
+	return nil
+}
+
+func (c *namedCount) IsBoolFlag() bool {
+	return true
+}
+
+// isFlagSet reports whether the flag name was given on the command line.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
 // concurrentFlagOk reports whether the current compiler flags
 // are compatible with concurrent compilation.
 func concurrentFlagOk() bool {
@@ -344,11 +487,11 @@ func concurrentBackendAllowed() bool {
 	// while writing the object file, and that is non-concurrent.
 	// Adding Debug_vlog, however, causes Debug.S to also print
 	// while flushing the plist, which happens concurrently.
-	if Ctxt.Debugvlog || Debug.Any() || Flag.Live > 0 {
+	if Ctxt.Debugvlog || Debug.Any() || Flag.Live != 0 {
 		return false
 	}
 	// TODO: Test and delete this condition.
-	if objabi.Experiment.FieldTrack {
+	if Flag.FieldTrack {
 		return false
 	}
 	// TODO: fix races and enable the following flags
@@ -439,6 +582,71 @@ func readEmbedCfg(file string) {
 	}
 }
 
+// An InlineHint overrides the inliner's decision for a function named
+// in the -inlinehints file.
+type InlineHint struct {
+	// Inline is true if the function should be inlined even if it
+	// exceeds the inlining budget, and false if it should never be
+	// inlined.
+	Inline bool
+
+	// Budget, if Inline is true and Budget is not 0, is the budget
+	// to use for the function instead of -inlinebudget. If it is 0,
+	// the function is inlined whatever its cost.
+	Budget int
+}
+
+// readInlineHints reads the -inlinehints file, which has one hint per
+// line of the form
+//
+//	noinline path.F
+//	inline path.F [budget]
+//
+// where path.F is the fully qualified name of a function or method,
+// such as example.com/p.F or example.com/p.(*T).M.
+func readInlineHints(file string) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatalf("-inlinehints: %v", err)
+	}
+
+	Flag.Cfg.InlineHints = make(map[string]InlineHint)
+	for lineNum, line := range strings.Split(string(data), "\n") {
+		lineNum++ // 1-based
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		f := strings.Fields(line)
+		var hint InlineHint
+		switch f[0] {
+		default:
+			log.Fatalf("%s:%d: unknown directive %q", file, lineNum, f[0])
+		case "noinline":
+			if len(f) != 2 {
+				log.Fatalf(`%s:%d: invalid noinline: syntax is "noinline path.F"`, file, lineNum)
+			}
+		case "inline":
+			if len(f) != 2 && len(f) != 3 {
+				log.Fatalf(`%s:%d: invalid inline: syntax is "inline path.F [budget]"`, file, lineNum)
+			}
+			hint.Inline = true
+			if len(f) == 3 {
+				n, err := strconv.Atoi(f[2])
+				if err != nil || n <= 0 {
+					log.Fatalf("%s:%d: invalid inline budget %q", file, lineNum, f[2])
+				}
+				hint.Budget = n
+			}
+		}
+		if _, ok := Flag.Cfg.InlineHints[f[1]]; ok {
+			log.Fatalf("%s:%d: duplicate hint for %s", file, lineNum, f[1])
+		}
+		Flag.Cfg.InlineHints[f[1]] = hint
+	}
+}
+
 // parseSpectre parses the spectre configuration from the string s.
 func parseSpectre(s string) {
 	for _, f := range strings.Split(s, ",") {
@@ -453,8 +661,13 @@ func parseSpectre(s string) {
 			Ctxt.Retpoline = true
 		case "index":
 			Flag.Cfg.SpectreIndex = true
+		case "load":
+			Flag.Cfg.SpectreIndex = true
+			Flag.Cfg.SpectreLoad = true
 		case "ret":
 			Ctxt.Retpoline = true
+		case "retthunk":
+			Ctxt.RetThunk = true
 		}
 	}
 
@@ -466,4 +679,7 @@ func parseSpectre(s string) {
 			log.Fatalf("GOARCH=%s does not support -spectre=index", objabi.GOARCH)
 		}
 	}
+	if Ctxt.RetThunk && objabi.GOARCH != "amd64" {
+		log.Fatalf("GOARCH=%s does not support -spectre=retthunk", objabi.GOARCH)
+	}
 }
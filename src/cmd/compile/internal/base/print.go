@@ -17,8 +17,11 @@ import (
 
 // An errorMsg is a queued error message, waiting to be printed.
 type errorMsg struct {
-	pos src.XPos
-	msg string
+	pos     src.XPos
+	msg     string
+	code    string      // diagnostic code, shown after the first line of msg with -errorcodes
+	snippet string      // source line and caret shown after the first line of msg, for -caret
+	diag    *Diagnostic // JSON form of msg, for -json=diagnostics
 }
 
 // Pos is the current source position being processed,
@@ -41,20 +44,65 @@ func SyntaxErrors() int {
 	return numSyntaxErrors
 }
 
-// addErrorMsg adds a new errorMsg (which may be a warning) to errorMsgs.
-func addErrorMsg(pos src.XPos, format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
+// addErrorMsg adds a new errorMsg (which may be a warning) to errorMsgs,
+// for the message msg of the given severity and code, followed by notes.
+// The suggested fix, if any, is only shown by -json=diagnostics.
+func addErrorMsg(pos src.XPos, severity, code string, notes []Note, fix *Fix, msg string) {
+	var diag *Diagnostic
+	if JSONDiagnostics() {
+		diag = newDiagnostic(severity, code, pos, msg, notes, fix)
+	}
+	msg += formatNotes(notes)
 	// Only add the position if know the position.
 	// See issue golang.org/issue/11361.
 	if pos.IsKnown() {
 		msg = fmt.Sprintf("%v: %s", FmtPos(pos), msg)
 	}
 	errorMsgs = append(errorMsgs, errorMsg{
-		pos: pos,
-		msg: msg + "\n",
+		pos:     pos,
+		msg:     msg + "\n",
+		code:    code,
+		snippet: snippet(pos, severity),
+		diag:    diag,
 	})
 }
 
+// text returns the text of e as printed, with the code (for
+// -errorcodes) and the -caret snippet, if any, after its first line.
+func (e *errorMsg) text() string {
+	msg := e.msg
+	if useColor && e.pos.IsKnown() {
+		if pos := FmtPos(e.pos); strings.HasPrefix(msg, pos+":") {
+			msg = colorBold + pos + colorReset + msg[len(pos):]
+		}
+	}
+	if suffix := codeSuffix(e.code) + e.snippet; suffix != "" {
+		i := strings.IndexByte(msg, '\n')
+		msg = msg[:i] + suffix + msg[i:]
+	}
+	return msg
+}
+
+// printMsg prints the message msg at pos, of the given severity and
+// code, immediately.
+func printMsg(pos src.XPos, severity, code, msg string) {
+	if JSONDiagnostics() {
+		fmt.Print(marshalDiagnostic(newDiagnostic(severity, code, pos, msg, nil, nil)))
+		return
+	}
+	fmt.Printf("%v: %s%s\n", FmtPos(pos), msg, codeSuffix(code))
+}
+
+// codeSuffix returns the text appended to a message with the given
+// code for -errorcodes, or "" if there is none. Warnings name the
+// -warn setting that enables them instead.
+func codeSuffix(code string) string {
+	if !Flag.ErrorCodes || code == "" || isWarning(code) {
+		return ""
+	}
+	return " [" + code + "]"
+}
+
 // FmtPos formats pos as a file:line string.
 func FmtPos(pos src.XPos) string {
 	if Ctxt == nil {
@@ -70,6 +118,33 @@ func (x byPos) Len() int           { return len(x) }
 func (x byPos) Less(i, j int) bool { return x[i].pos.Before(x[j].pos) }
 func (x byPos) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
 
+// byFilePos sorts errors by file name, then by line and column, then
+// by message, for -errorsort. Unlike byPos, which orders files by when
+// the compiler first read them, the order depends only on the errors.
+type byFilePos []errorMsg
+
+func (x byFilePos) Len() int      { return len(x) }
+func (x byFilePos) Swap(i, j int) { x[i], x[j] = x[j], x[i] }
+func (x byFilePos) Less(i, j int) bool {
+	a, b := x[i].pos, x[j].pos
+	if a.IsKnown() != b.IsKnown() {
+		return !a.IsKnown()
+	}
+	if a.IsKnown() {
+		p, q := Ctxt.OutermostPos(a), Ctxt.OutermostPos(b)
+		if p.Filename() != q.Filename() {
+			return p.Filename() < q.Filename()
+		}
+		if p.Line() != q.Line() {
+			return p.Line() < q.Line()
+		}
+		if p.Col() != q.Col() {
+			return p.Col() < q.Col()
+		}
+	}
+	return x[i].msg < x[j].msg
+}
+
 // FlushErrors sorts errors seen so far by line number, prints them to stdout,
 // and empties the errors array.
 func FlushErrors() {
@@ -79,10 +154,18 @@ func FlushErrors() {
 	if len(errorMsgs) == 0 {
 		return
 	}
-	sort.Stable(byPos(errorMsgs))
+	if Flag.ErrorSort {
+		sort.Stable(byFilePos(errorMsgs))
+	} else {
+		sort.Stable(byPos(errorMsgs))
+	}
 	for i, err := range errorMsgs {
 		if i == 0 || err.msg != errorMsgs[i-1].msg {
-			fmt.Printf("%s", err.msg)
+			if err.diag != nil {
+				fmt.Print(marshalDiagnostic(err.diag))
+			} else {
+				fmt.Printf("%s", err.text())
+			}
 		}
 	}
 	errorMsgs = errorMsgs[:0]
@@ -111,7 +194,30 @@ func Errorf(format string, args ...interface{}) {
 // ErrorfAt reports a formatted error message at pos.
 func ErrorfAt(pos src.XPos, format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
+	errorAt(pos, errorCode(msg), nil, nil, msg)
+}
 
+// ErrorfAtCode reports a formatted error message at pos, with the
+// given code.
+func ErrorfAtCode(pos src.XPos, code string, format string, args ...interface{}) {
+	errorAt(pos, code, nil, nil, fmt.Sprintf(format, args...))
+}
+
+// ErrorfAtFix reports a formatted error message at pos, with the given
+// code and a suggested fix, which may be nil.
+func ErrorfAtFix(pos src.XPos, code string, fix *Fix, format string, args ...interface{}) {
+	errorAt(pos, code, nil, fix, fmt.Sprintf(format, args...))
+}
+
+// ErrorfAtNotes reports a formatted error message at pos, with the
+// given code, followed by notes at related positions. Each note is
+// printed on a line of its own after the message.
+func ErrorfAtNotes(pos src.XPos, code string, notes []Note, format string, args ...interface{}) {
+	errorAt(pos, code, notes, nil, fmt.Sprintf(format, args...))
+}
+
+// errorAt reports the error message msg at pos.
+func errorAt(pos src.XPos, code string, notes []Note, fix *Fix, msg string) {
 	if strings.HasPrefix(msg, "syntax error") {
 		numSyntaxErrors++
 		// only one syntax error per line, no matter what error
@@ -125,29 +231,68 @@ func ErrorfAt(pos src.XPos, format string, args ...interface{}) {
 		// here as best as we can (they may not appear in order)
 		// so that we don't count them here and exit early, and
 		// then have nothing to show for.)
-		if sameline(lasterror.other, pos) && lasterror.msg == msg {
+		text := msg + formatNotes(notes)
+		if sameline(lasterror.other, pos) && lasterror.msg == text {
 			return
 		}
 		lasterror.other = pos
-		lasterror.msg = msg
+		lasterror.msg = text
 	}
 
-	addErrorMsg(pos, "%s", msg)
+	addErrorMsg(pos, "error", code, notes, fix, msg)
 	numErrors++
 
 	hcrash()
-	if numErrors >= 10 && Flag.LowerE == 0 {
+	if Flag.MaxErrors > 0 && numErrors >= Flag.MaxErrors {
 		FlushErrors()
-		fmt.Printf("%v: too many errors\n", FmtPos(pos))
+		printMsg(pos, "error", CodeTooMany, "too many errors")
 		ErrorExit()
 	}
 }
 
 // ErrorfVers reports that a language feature (format, args) requires a later version of Go.
 func ErrorfVers(lang string, format string, args ...interface{}) {
+	if l := FileLang(Pos); l != "" {
+		Errorf("%s requires %s or later (file has //go:lang %s)", fmt.Sprintf(format, args...), lang, l)
+		return
+	}
 	Errorf("%s requires %s or later (-lang was set to %s; check go.mod)", fmt.Sprintf(format, args...), lang, Flag.Lang)
 }
 
+// fileLangs maps the name of a source file to the language version
+// selected by a //go:lang directive in that file.
+var fileLangs map[string]string
+
+// SetFileLang records that the file containing pos selects
+// language version lang with a //go:lang directive.
+func SetFileLang(pos src.XPos, lang string) {
+	if fileLangs == nil {
+		fileLangs = make(map[string]string)
+	}
+	fileLangs[posFile(pos)] = lang
+}
+
+// FileLang returns the language version selected by a //go:lang
+// directive in the file containing pos, or "" if there is none.
+func FileLang(pos src.XPos) string {
+	if len(fileLangs) == 0 || !pos.IsKnown() {
+		return ""
+	}
+	return fileLangs[posFile(pos)]
+}
+
+// posFile returns the name of the source file containing pos,
+// looking through any //line directives.
+func posFile(pos src.XPos) string {
+	b := Ctxt.PosTable.Pos(pos).Base()
+	for b != nil && b.Pos().Base() != b {
+		// A //line directive's base is positioned at the
+		// directive itself, in the enclosing file.
+		b = b.Pos().Base()
+	}
+	return b.Filename()
+}
+
 // UpdateErrorDot is a clumsy hack that rewrites the last error,
 // if it was "LINE: undefined: NAME", to be "LINE: undefined: NAME in EXPR".
 // It is used to give better error messages for dot (selector) expressions.
@@ -158,6 +303,9 @@ func UpdateErrorDot(line string, name, expr string) {
 	e := &errorMsgs[len(errorMsgs)-1]
 	if strings.HasPrefix(e.msg, line) && e.msg == fmt.Sprintf("%v: undefined: %v\n", line, name) {
 		e.msg = fmt.Sprintf("%v: undefined: %v in %v\n", line, name, expr)
+		if e.diag != nil {
+			e.diag.Message = fmt.Sprintf("undefined: %v in %v", name, expr)
+		}
 	}
 }
 
@@ -174,7 +322,7 @@ func Warn(format string, args ...interface{}) {
 // so this should be used only when the user has opted in
 // to additional output by setting a particular flag.
 func WarnfAt(pos src.XPos, format string, args ...interface{}) {
-	addErrorMsg(pos, format, args...)
+	addErrorMsg(pos, "warning", "", nil, nil, fmt.Sprintf(format, args...))
 	if Flag.LowerM != 0 {
 		FlushErrors()
 	}
@@ -212,20 +360,24 @@ func FatalfAt(pos src.XPos, format string, args ...interface{}) {
 	FlushErrors()
 
 	if Debug.Panic != 0 || numErrors == 0 {
-		fmt.Printf("%v: internal compiler error: ", FmtPos(pos))
-		fmt.Printf(format, args...)
-		fmt.Printf("\n")
-
-		// If this is a released compiler version, ask for a bug report.
-		if strings.HasPrefix(objabi.Version, "go") {
-			fmt.Printf("\n")
-			fmt.Printf("Please file a bug report including a short program that triggers the error.\n")
-			fmt.Printf("https://golang.org/issue/new\n")
+		if JSONDiagnostics() {
+			printMsg(pos, "error", CodeInternal, "internal compiler error: "+fmt.Sprintf(format, args...))
 		} else {
-			// Not a release; dump a stack trace, too.
-			fmt.Println()
-			os.Stdout.Write(debug.Stack())
-			fmt.Println()
+			fmt.Printf("%v: internal compiler error: ", FmtPos(pos))
+			fmt.Printf(format, args...)
+			fmt.Printf("\n")
+
+			// If this is a released compiler version, ask for a bug report.
+			if strings.HasPrefix(objabi.Version, "go") {
+				fmt.Printf("\n")
+				fmt.Printf("Please file a bug report including a short program that triggers the error.\n")
+				fmt.Printf("https://golang.org/issue/new\n")
+			} else {
+				// Not a release; dump a stack trace, too.
+				fmt.Println()
+				os.Stdout.Write(debug.Stack())
+				fmt.Println()
+			}
 		}
 	}
 
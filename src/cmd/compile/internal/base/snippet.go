@@ -0,0 +1,163 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package base
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"cmd/internal/src"
+)
+
+// ANSI escape sequences used by -color.
+const (
+	colorBold    = "\x1b[1m"
+	colorRed     = "\x1b[1;31m"
+	colorMagenta = "\x1b[1;35m"
+	colorReset   = "\x1b[0m"
+)
+
+// useColor reports whether -caret output is colored, as set by
+// -color: always, never, or auto, which colors output to a terminal
+// unless $NO_COLOR is set or $TERM is dumb. Colors highlight the
+// position of each message and its caret.
+var useColor bool
+
+// parseColor sets useColor according to the -color setting.
+func parseColor(mode string) {
+	switch mode {
+	case "always":
+		useColor = true
+	case "never":
+		useColor = false
+	case "", "auto":
+		useColor = isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+	default:
+		log.Fatalf("invalid -color %q: must be auto, always, or never", mode)
+	}
+	useColor = useColor && Flag.Caret
+}
+
+// isTerminal reports whether f is a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// A sourceFile is a source file read for -caret or for suggested
+// fixes.
+type sourceFile struct {
+	data  []byte
+	lines []int // offset of the start of each line
+}
+
+// sourceFiles caches the files read by readSource.
+var sourceFiles = make(map[string]*sourceFile)
+
+// readSource returns the contents of file, or nil if it cannot be read.
+func readSource(file string) *sourceFile {
+	f, ok := sourceFiles[file]
+	if !ok {
+		if data, err := ioutil.ReadFile(file); err == nil {
+			f = &sourceFile{data: data, lines: []int{0}}
+			for i, c := range data {
+				if c == '\n' {
+					f.lines = append(f.lines, i+1)
+				}
+			}
+		}
+		sourceFiles[file] = f
+	}
+	return f
+}
+
+// sourceLine returns line n, counting from 1, of file, or false if
+// the file cannot be read or has no such line.
+func sourceLine(file string, n uint) ([]byte, bool) {
+	f := readSource(file)
+	if f == nil || n < 1 || int(n) > len(f.lines) {
+		return nil, false
+	}
+	line := f.data[f.lines[n-1]:]
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	return bytes.TrimSuffix(line, []byte("\r")), true
+}
+
+// snippet returns, for -caret, the source line containing pos and a
+// line underlining the token that starts at pos, as lines to append
+// to a message of the given severity. It returns "" without -caret,
+// or if the source is not available.
+func snippet(pos src.XPos, severity string) string {
+	if !Flag.Caret || !pos.IsKnown() || Ctxt == nil {
+		return ""
+	}
+	p := Ctxt.OutermostPos(pos)
+	line, ok := sourceLine(p.Filename(), p.Line())
+	col := int(p.Col())
+	if !ok || col < 1 || col > len(line)+1 {
+		return ""
+	}
+
+	// Indent the marker like the line, keeping its tabs so that the
+	// marker lines up however wide they are displayed.
+	var marker strings.Builder
+	for _, c := range string(line[:col-1]) {
+		if c == '\t' {
+			marker.WriteByte('\t')
+		} else {
+			marker.WriteByte(' ')
+		}
+	}
+	marker.WriteByte('^')
+	marker.WriteString(strings.Repeat("~", len(tokenAt(line[col-1:]))-1))
+
+	m := marker.String()
+	if useColor {
+		color := colorRed
+		if severity == "warning" {
+			color = colorMagenta
+		}
+		m = strings.Replace(m, "^", color+"^", 1) + colorReset
+	}
+	return "\n" + string(line) + "\n" + m
+}
+
+// tokenAt returns the runes of the identifier, number or quoted
+// literal at the start of b, or its first rune if it starts with
+// anything else.
+func tokenAt(b []byte) []rune {
+	r, size := utf8.DecodeRune(b)
+	if size == 0 {
+		return []rune{' '}
+	}
+	tok := []rune{r}
+	switch {
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		for _, r := range string(b[size:]) {
+			if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			tok = append(tok, r)
+		}
+	case r == '"' || r == '`' || r == '\'':
+		quote := r
+		escaped := false
+		for _, c := range string(b[size:]) {
+			tok = append(tok, c)
+			if c == quote && !escaped {
+				break
+			}
+			escaped = c == '\\' && !escaped && quote != '`'
+		}
+	}
+	return tok
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package base
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"cmd/internal/src"
+)
+
+// A Warning is a class of optional diagnostics, enabled by -warn.
+// Warnings report code that is valid but probably not what was
+// intended, using information that only the compiler has, such as
+// type sizes and escape analysis results. They are printed like
+// errors, followed by the -warn setting that enables them, but do
+// not stop compilation unless -Werror is given.
+type Warning int
+
+const (
+	WarnUnusedResult Warning = iota // result of a //go:pure call discarded
+	WarnLargeAlloc                  // heap allocation because of size
+	WarnLargeCopy                   // copy of a large value
+
+	numWarnings
+)
+
+var warnings = [numWarnings]struct {
+	name string
+	help string
+}{
+	WarnUnusedResult: {"unusedresult", "call of a //go:pure function whose results are not used"},
+	WarnLargeAlloc:   {"largealloc", "value allocated on the heap only because it is too large for the stack"},
+	WarnLargeCopy:    {"largecopy", "assignment, argument or range that copies more than 1kB (or -d=copysize)"},
+}
+
+// warnEnabled records the warnings enabled by -warn.
+var warnEnabled [numWarnings]bool
+
+// String returns the name of w, as used by -warn.
+func (w Warning) String() string {
+	return warnings[w].name
+}
+
+// Enabled reports whether w was enabled by -warn.
+func (w Warning) Enabled() bool {
+	return warnEnabled[w]
+}
+
+// WarnAt reports the warning w, a formatted message, at pos if w is
+// enabled. With -Werror, the warning is reported as an error.
+func WarnAt(w Warning, pos src.XPos, format string, args ...interface{}) {
+	if !w.Enabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, args...) + " [-warn=" + w.String() + "]"
+	if Flag.Werror {
+		errorAt(pos, w.String(), nil, nil, msg)
+		return
+	}
+	addErrorMsg(pos, "warning", w.String(), nil, nil, msg)
+}
+
+// isWarning reports whether code is the name of a warning.
+func isWarning(code string) bool {
+	for _, w := range warnings {
+		if w.name == code {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWarn parses the -warn argument, a comma-separated list of
+// warning names to enable, "all", or -name to disable one.
+func parseWarn(s string) {
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		on := !strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		switch name {
+		case "":
+			continue
+		case "help":
+			fmt.Printf("usage: -warn=name,...\n\n")
+			for _, w := range warnings {
+				fmt.Printf("\t%-12s\t%s\n", w.name, w.help)
+			}
+			fmt.Printf("\nall enables every warning, and -name disables one.\n")
+			os.Exit(0)
+		case "all":
+			for w := range warnEnabled {
+				warnEnabled[w] = on
+			}
+			continue
+		}
+		found := false
+		for w := range warnings {
+			if warnings[w].name == name {
+				warnEnabled[w] = on
+				found = true
+			}
+		}
+		if !found {
+			log.Fatalf("unknown warning -warn=%s; try -warn=help", name)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package coverage implements the coverage instrumentation enabled by
+// -covermode, which counts how many times each block of the package's
+// code runs.
+package coverage
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/objw"
+	"cmd/compile/internal/staticdata"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+	"cmd/internal/obj"
+	"cmd/internal/src"
+)
+
+// A block is a sequence of statements that control enters only at the
+// first statement, and that it leaves only after the last, except
+// through a panic. Each block gets a counter.
+type block struct {
+	inc        *ir.BlockStmt // holds the counter update
+	start, end src.XPos      // first and last positions of the block's code
+	nstmt      int           // number of statements
+}
+
+// Instrument adds a counter to each block of the functions of the
+// package, in the same blocks as cmd/cover does, if -covermode is set.
+// It must run after typechecking and before deadcode elimination, so
+// that blocks that can never run are reported too.
+//
+// The counters are the elements of the array pkg..covctrs, which the
+// linker puts in its own section. The symbol pkg..covmeta describes
+// them, for the runtime to write the coverage profile at exit; its
+// layout must match the coverMeta type in runtime/coverage.go.
+//
+// The counters are updated by
+//
+//	pkg..covctrs[i] = 1 // -covermode=set
+//	pkg..covctrs[i]++   // -covermode=count
+//
+// and with an atomic add for -covermode=atomic, which walk produces.
+func Instrument() {
+	if base.Flag.CoverMode == "" {
+		return
+	}
+	var blocks []*block
+	for _, n := range typecheck.Target.Decls {
+		if fn, ok := n.(*ir.Func); ok && instrumentable(fn) {
+			fn.Body = stmts(fn.Body, &blocks)
+		}
+	}
+	if len(blocks) == 0 {
+		return
+	}
+
+	ctrs := typecheck.NewName(typecheck.Lookup(".covctrs"))
+	typecheck.Declare(ctrs, ir.PEXTERN)
+	ctrs.SetType(types.NewArray(types.Types[types.TUINT32], int64(len(blocks))))
+	ctrs.SetCoverageCounter(true)
+
+	var meta strings.Builder
+	for i, b := range blocks {
+		pos := b.inc.Pos()
+		x := ir.NewIndexExpr(pos, ctrs, ir.NewInt(int64(i)))
+		var inc ir.Node
+		if base.Flag.CoverMode == "set" {
+			inc = ir.NewAssignStmt(pos, x, ir.NewInt(1))
+		} else {
+			op := ir.NewAssignOpStmt(pos, ir.OADD, x, ir.NewInt(1))
+			op.IncDec = true
+			inc = op
+		}
+		b.inc.List = []ir.Node{typecheck.Stmt(inc)}
+
+		start, end := base.Ctxt.PosTable.Pos(b.start), base.Ctxt.PosTable.Pos(b.end)
+		fmt.Fprintf(&meta, "%s:%d.%d,%d.%d %d\n", fileName(start), start.RelLine(), col(start), end.RelLine(), col(end)+1, b.nstmt)
+	}
+
+	lsym := typecheck.Lookup(".covmeta").Linksym()
+	off := objw.SymPtr(lsym, 0, ctrs.Linksym(), 0)
+	off = objw.Uintptr(lsym, off, uint64(len(blocks)))
+	for _, s := range []string{base.Flag.CoverMode, meta.String()} {
+		off = objw.SymPtr(lsym, off, staticdata.StringSym(src.NoXPos, s), 0)
+		off = objw.Uintptr(lsym, off, uint64(len(s)))
+	}
+	objw.Global(lsym, int32(off), obj.NOPTR)
+}
+
+// instrumentable reports whether fn should get counters. Functions
+// generated by cgo are left alone, as cmd/cover does, and so are
+// nosplit functions, which may not have room for the counter update.
+func instrumentable(fn *ir.Func) bool {
+	if len(fn.Body) == 0 || fn.Pragma&ir.Nosplit != 0 {
+		return false
+	}
+	p := base.Ctxt.PosTable.Pos(fn.Pos())
+	return p.IsKnown() && !strings.HasPrefix(filepath.Base(p.RelFilename()), "_cgo_")
+}
+
+// stmts adds a counter to each block of list, and to the blocks of the
+// statements nested in it, and returns the new list. The blocks are
+// appended to blocks.
+func stmts(list ir.Nodes, blocks *[]*block) ir.Nodes {
+	var out ir.Nodes
+	for i := 0; i < len(list); {
+		if list[i].Op() == ir.OLABEL {
+			// A label starts a new block, as it may be jumped to.
+			out.Append(list[i])
+			i++
+			continue
+		}
+		b := &block{
+			inc:   ir.NewBlockStmt(list[i].Pos(), nil),
+			start: list[i].Pos(),
+			end:   list[i].Pos(),
+		}
+		b.inc.SetTypecheck(1)
+		*blocks = append(*blocks, b)
+		out.Append(b.inc)
+		for ; i < len(list) && list[i].Op() != ir.OLABEL; i++ {
+			n := list[i]
+			b.add(n)
+			nested(n, blocks)
+			out.Append(n)
+			if endsBlock(n) {
+				i++
+				break
+			}
+		}
+	}
+	return out
+}
+
+// nested adds counters to the blocks of the statements nested in n.
+func nested(n ir.Node, blocks *[]*block) {
+	switch n := n.(type) {
+	case *ir.BlockStmt:
+		n.List = stmts(n.List, blocks)
+	case *ir.IfStmt:
+		n.Body = stmts(n.Body, blocks)
+		n.Else = stmts(n.Else, blocks)
+	case *ir.ForStmt:
+		n.Body = stmts(n.Body, blocks)
+	case *ir.RangeStmt:
+		n.Body = stmts(n.Body, blocks)
+	case *ir.SwitchStmt:
+		for _, cas := range n.Cases {
+			cas.Body = stmts(cas.Body, blocks)
+		}
+	case *ir.SelectStmt:
+		for _, cas := range n.Cases {
+			cas.Body = stmts(cas.Body, blocks)
+		}
+	}
+}
+
+// endsBlock reports whether the block containing n ends after n,
+// because n contains blocks of its own or does not fall through.
+func endsBlock(n ir.Node) bool {
+	switch n.Op() {
+	case ir.OBLOCK, ir.OIF, ir.OFOR, ir.OFORUNTIL, ir.ORANGE, ir.OSWITCH, ir.OSELECT,
+		ir.OBREAK, ir.OCONTINUE, ir.OFALL, ir.OGOTO, ir.ORETURN, ir.OPANIC:
+		return true
+	}
+	return false
+}
+
+// add adds the statement n to b. The blocks nested in n are not part
+// of b, so only the header of a compound statement extends b.
+func (b *block) add(n ir.Node) {
+	if n.Op() == ir.ODCL {
+		// The declared name comes before the position of the
+		// declaration, which is that of the := or =.
+		b.extend(n.(*ir.Decl).X.Pos())
+	} else {
+		b.nstmt++
+	}
+	var header []ir.Node
+	switch n := n.(type) {
+	case *ir.BlockStmt, *ir.SelectStmt:
+	case *ir.IfStmt:
+		header = []ir.Node{n.Cond}
+	case *ir.ForStmt:
+		header = []ir.Node{n.Cond, n.Post}
+	case *ir.RangeStmt:
+		header = []ir.Node{n.X, n.Key, n.Value}
+	case *ir.SwitchStmt:
+		header = []ir.Node{n.Tag}
+	default:
+		header = []ir.Node{n}
+	}
+	header = append(header, n.Init()...)
+	b.extend(n.Pos())
+	for _, x := range header {
+		if x == nil {
+			continue
+		}
+		ir.Visit(x, func(x ir.Node) {
+			switch x.Op() {
+			case ir.ONAME, ir.ONONAME, ir.OTYPE:
+				// Declared elsewhere.
+				return
+			case ir.OCLOSURE:
+				b.extend(x.(*ir.ClosureExpr).Func.Endlineno)
+			}
+			b.extend(x.Pos())
+		})
+	}
+}
+
+// extend extends b to include pos. Positions before the start of b
+// only count if they are on the same line, as the position of an
+// expression or statement may be that of its operator.
+func (b *block) extend(pos src.XPos) {
+	switch {
+	case !pos.SameFile(b.start):
+	case pos.After(b.end):
+		b.end = pos
+	case pos.Before(b.start) && pos.Line() == b.start.Line():
+		b.start = pos
+	}
+}
+
+// fileName returns the name of p's file in the coverage profile, which
+// is, as for cmd/cover, the package path followed by the base name.
+// The name comes from //line directives, if any, so that generated
+// code is reported at the position of its source.
+func fileName(p src.Pos) string {
+	return path.Join(base.Ctxt.Pkgpath, filepath.Base(p.RelFilename()))
+}
+
+// col returns the column of p, or 1 if it is not known.
+func col(p src.Pos) uint {
+	if c := p.RelCol(); c != 0 {
+		return c
+	}
+	return 1
+}
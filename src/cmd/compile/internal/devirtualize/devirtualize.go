@@ -10,6 +10,7 @@ package devirtualize
 import (
 	"cmd/compile/internal/base"
 	"cmd/compile/internal/ir"
+	"cmd/compile/internal/logopt"
 	"cmd/compile/internal/typecheck"
 	"cmd/compile/internal/types"
 )
@@ -24,20 +25,31 @@ func Func(fn *ir.Func) {
 	})
 }
 
-// Call devirtualizes the given call if possible.
-func Call(call *ir.CallExpr) {
-	if call.Op() != ir.OCALLINTER {
-		return
-	}
+// ConcreteType returns the concrete type of the receiver of the
+// interface method call, if it is statically known, and otherwise nil.
+func ConcreteType(call *ir.CallExpr) *types.Type {
 	sel := call.X.(*ir.SelectorExpr)
 	r := ir.StaticValue(sel.X)
 	if r.Op() != ir.OCONVIFACE {
-		return
+		return nil
 	}
 	recv := r.(*ir.ConvExpr)
 
 	typ := recv.X.Type()
 	if typ.IsInterface() {
+		return nil
+	}
+	return typ
+}
+
+// Call devirtualizes the given call if possible.
+func Call(call *ir.CallExpr) {
+	if call.Op() != ir.OCALLINTER {
+		return
+	}
+	sel := call.X.(*ir.SelectorExpr)
+	typ := ConcreteType(call)
+	if typ == nil {
 		return
 	}
 
@@ -50,6 +62,9 @@ func Call(call *ir.CallExpr) {
 		if base.Flag.LowerM != 0 {
 			base.WarnfAt(call.Pos(), "devirtualizing %v to %v", sel, typ)
 		}
+		if logopt.Enabled() {
+			logopt.LogOpt(call.Pos(), "devirtualizeCall", "devirtualize", ir.FuncName(ir.CurFunc), ir.MethodExprName(x).Sym().Name)
+		}
 		call.SetOp(ir.OCALLMETH)
 		call.X = x
 	case ir.ODOTINTER:
@@ -95,6 +95,9 @@ func Info(fnsym *obj.LSym, infosym *obj.LSym, curfn interface{}) ([]dwarf.Scope,
 	}
 
 	decls, dwarfVars := createDwarfVars(fnsym, isODCLFUNC, fn, apdecls)
+	if base.Debug.LocationQuality != 0 && isODCLFUNC {
+		reportLocationQuality(fnsym, fn, decls, dwarfVars)
+	}
 
 	// For each type referenced by the functions auto vars but not
 	// already referenced by a dwarf var, attach an R_USETYPE relocation to
@@ -146,6 +149,14 @@ func createDwarfVars(fnsym *obj.LSym, complexOK bool, fn *ir.Func, apDecls []*ir
 	dcl := apDecls
 	if fnsym.WasInlined() {
 		dcl = preInliningDcls(fnsym)
+	} else if debug, ok := fn.DebugInfo.(*ssa.FuncDebug); ok && complexOK {
+		// Variables the optimizer has removed from the stack frame
+		// still get an entry, even if it has no location.
+		dcl = append(dcl[:len(dcl):len(dcl)], debug.OptimizedOut...)
+	}
+	var stackParams ir.NameSet
+	if complexOK {
+		stackParams = stackPassedParams(fn)
 	}
 
 	// If optimization is enabled, the list above will typically be
@@ -180,6 +191,16 @@ func createDwarfVars(fnsym *obj.LSym, complexOK bool, fn *ir.Func, apDecls []*ir
 			decls = append(decls, n)
 			continue
 		}
+		if n.Class == ir.PPARAM && !fnsym.WasInlined() && stackParams.Has(n) &&
+			!n.Addrtaken() && n.Esc() != ir.EscHeap && !ir.Reassigned(n) {
+			// An SSA-able argument that is passed on the stack and
+			// never assigned to keeps its value in its argument slot
+			// for the entire call, even if the optimized code never
+			// looks at it.
+			vars = append(vars, createSimpleVar(fnsym, n))
+			decls = append(decls, n)
+			continue
+		}
 		typename := dwarf.InfoPrefix + types.TypeSymName(n.Type())
 		decls = append(decls, n)
 		abbrev := dwarf.DW_ABRV_AUTO_LOCLIST
@@ -221,6 +242,55 @@ func createDwarfVars(fnsym *obj.LSym, complexOK bool, fn *ir.Func, apDecls []*ir
 	return decls, vars
 }
 
+// stackPassedParams returns the parameters of fn that are passed on
+// the stack rather than in registers.
+func stackPassedParams(fn *ir.Func) ir.NameSet {
+	var params ir.NameSet
+	info := ssagen.AbiForFunc(fn).ABIAnalyzeFuncType(fn.Type().FuncType())
+	for _, a := range info.InParams() {
+		if n, ok := a.Name.(*ir.Name); ok && len(a.Registers) == 0 {
+			params.Add(n)
+		}
+	}
+	return params
+}
+
+// reportLocationQuality reports, for -d=locationquality, how much of
+// fn's code each of its DWARF variables has a location for, and the
+// average over all of them. Coverage is measured against the whole
+// function, not just the variable's scope. A variable made of several
+// pieces counts as covered wherever any of its pieces has a location.
+func reportLocationQuality(fnsym *obj.LSym, fn *ir.Func, decls []*ir.Name, vars []*dwarf.Var) {
+	size := fnsym.Size
+	if size == 0 || len(vars) == 0 {
+		return
+	}
+	debug, _ := fn.DebugInfo.(*ssa.FuncDebug)
+	var total int64
+	for i, v := range vars {
+		var covered int64
+		switch {
+		case v.Abbrev == dwarf.DW_ABRV_AUTO || v.Abbrev == dwarf.DW_ABRV_PARAM:
+			// A single home on the stack for the entire call.
+			covered = size
+		case v.PutLocationList != nil:
+			for varID, n := range debug.Vars {
+				if n == decls[i] {
+					covered = debug.LocationListCoverage(debug.LocationLists[varID], base.Ctxt)
+					break
+				}
+			}
+		}
+		if covered > size {
+			covered = size
+		}
+		total += covered
+		base.WarnfAt(decls[i].Pos(), "%s: %d%% location coverage", v.Name, covered*100/size)
+	}
+	base.WarnfAt(fn.Pos(), "%s: %d variables, %d%% average location coverage",
+		ir.FuncName(fn), len(vars), total*100/(size*int64(len(vars))))
+}
+
 // Given a function that was inlined at some point during the
 // compilation, return a sorted list of nodes corresponding to the
 // autos/locals in that function prior to inlining. If this is a
@@ -394,7 +464,7 @@ func createComplexVar(fnsym *obj.LSym, fn *ir.Func, varID ssa.VarID) *dwarf.Var
 }
 
 // RecordFlags records the specified command-line flags to be placed
-// in the DWARF info.
+// in the DWARF info and the build information of the linked binary.
 func RecordFlags(flags ...string) {
 	if base.Ctxt.Pkgpath == "" {
 		// We can't record the flags if we don't know what the
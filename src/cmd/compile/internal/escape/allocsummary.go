@@ -0,0 +1,84 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package escape
+
+import (
+	"fmt"
+	"sort"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+)
+
+// allocSites accumulates the heap allocation sites found by the
+// batches analyzed by Funcs for -d=allocsummary.
+var allocSites []ir.Node
+
+// addAllocSite records that n, a variable moved to the heap or an
+// expression that escapes, is allocated on the heap.
+func addAllocSite(n ir.Node) {
+	if base.Debug.AllocSummary != 0 {
+		allocSites = append(allocSites, n)
+	}
+}
+
+// allocType returns the type of the value allocated on the heap for
+// the allocation site n.
+func allocType(n ir.Node) *types.Type {
+	switch n.Op() {
+	case ir.ONEW, ir.OPTRLIT:
+		return n.Type().Elem()
+	case ir.OCONVIFACE:
+		n := n.(*ir.ConvExpr)
+		return n.X.Type()
+	}
+	return n.Type()
+}
+
+// reportAllocSummary prints the heap allocation sites of the package
+// grouped by allocated type, the types with the most sites first.
+func reportAllocSummary() {
+	type group struct {
+		t     *types.Type
+		sites []ir.Node
+	}
+	byType := make(map[string]*group)
+	var groups []*group
+	for _, n := range allocSites {
+		t := allocType(n)
+		g := byType[t.LongString()]
+		if g == nil {
+			g = &group{t: t}
+			byType[t.LongString()] = g
+			groups = append(groups, g)
+		}
+		g.sites = append(g.sites, n)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		ni, nj := len(groups[i].sites), len(groups[j].sites)
+		if ni != nj {
+			return ni > nj
+		}
+		return groups[i].t.LongString() < groups[j].t.LongString()
+	})
+	for _, g := range groups {
+		sort.SliceStable(g.sites, func(i, j int) bool {
+			return g.sites[i].Pos().Before(g.sites[j].Pos())
+		})
+		plural := "s"
+		if len(g.sites) == 1 {
+			plural = ""
+		}
+		fmt.Printf("%v: %d heap allocation site%s\n", g.t, len(g.sites), plural)
+		for _, n := range g.sites {
+			what := "moved to heap"
+			if n.Op() != ir.ONAME {
+				what = "escapes to heap"
+			}
+			fmt.Printf("\t%v: %v %s\n", base.FmtPos(n.Pos()), n, what)
+		}
+	}
+}
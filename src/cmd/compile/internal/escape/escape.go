@@ -7,6 +7,7 @@ package escape
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 
 	"cmd/compile/internal/base"
@@ -91,8 +92,14 @@ type batch struct {
 	allLocs  []*location
 	closures []closure
 
-	heapLoc  location
-	blankLoc location
+	heapLoc    location
+	blankLoc   location
+	mutatorLoc location
+
+	// zeroCopyStrs records []byte->string conversions that can
+	// share memory with the local array they convert, if the
+	// array does not escape. See zeroCopyStrArgs.
+	zeroCopyStrs []zeroCopyStr
 }
 
 // A closure holds a closure expression and its spill hole (i.e.,
@@ -156,6 +163,11 @@ type location struct {
 	// its storage can be immediately reused.
 	transient bool
 
+	// mutated reports whether the represented variable's memory
+	// may be written through a pointer; that is, whether its
+	// address flows to the mutator location.
+	mutated bool
+
 	// paramEsc records the represented parameter's leak set.
 	paramEsc leaks
 
@@ -215,6 +227,7 @@ func Batch(fns []*ir.Func, recursive bool) {
 
 	var b batch
 	b.heapLoc.escapes = true
+	b.mutatorLoc.transient = true
 
 	// Construct data-flow graph from syntax trees.
 	for _, fn := range fns {
@@ -241,11 +254,17 @@ func Batch(fns []*ir.Func, recursive bool) {
 
 	for _, loc := range b.allLocs {
 		if why := HeapAllocReason(loc.n); why != "" {
+			if why == "too large for stack" {
+				base.WarnAt(base.WarnLargeAlloc, loc.n.Pos(), "%v is allocated on the heap because it is too large for the stack", loc.n)
+			}
 			b.flow(b.heapHole().addr(loc.n, why), loc)
 		}
 	}
 
 	b.walkAll()
+	if escGraph != nil {
+		escGraph.add(&b)
+	}
 	b.finish(fns)
 }
 
@@ -431,11 +450,16 @@ func (e *escape) stmt(n ir.Node) {
 
 	case ir.OFOR, ir.OFORUNTIL:
 		n := n.(*ir.ForStmt)
-		e.loopDepth++
+		once := atMostOnce(n)
+		if !once {
+			e.loopDepth++
+		}
 		e.discard(n.Cond)
 		e.stmt(n.Post)
 		e.block(n.Body)
-		e.loopDepth--
+		if !once {
+			e.loopDepth--
+		}
 
 	case ir.ORANGE:
 		// for Key, Value = range X { Body }
@@ -896,10 +920,14 @@ func (e *escape) addr(n ir.Node) hole {
 		if n.X.Type().IsArray() {
 			k = e.addr(n.X)
 		} else {
-			e.discard(n.X)
+			e.mutate(n.X)
 		}
-	case ir.ODEREF, ir.ODOTPTR:
-		e.discard(n)
+	case ir.ODEREF:
+		n := n.(*ir.StarExpr)
+		e.mutate(n.X)
+	case ir.ODOTPTR:
+		n := n.(*ir.SelectorExpr)
+		e.mutate(n.X)
 	case ir.OINDEXMAP:
 		n := n.(*ir.IndexExpr)
 		e.discard(n.X)
@@ -909,6 +937,12 @@ func (e *escape) addr(n ir.Node) hole {
 	return k
 }
 
+// mutate evaluates pointer-like expression n, whose pointed-to
+// memory is written.
+func (e *escape) mutate(n ir.Node) {
+	e.expr(e.mutatorHole(), n)
+}
+
 func (e *escape) addrs(l ir.Nodes) []hole {
 	var ks []hole
 	for _, n := range l {
@@ -972,6 +1006,27 @@ func (e *escape) assignList(dsts, srcs []ir.Node, why string, where ir.Node) {
 	e.reassigned(ks, where)
 }
 
+// loopInvariantClosure reports whether call is a call, without
+// arguments, of a function literal whose captured variables are all
+// declared outside of any loop in the current function. Such a
+// literal evaluates to the same closure value each time a deferred
+// call to it is reached, so it need not be heap allocated.
+func (e *escape) loopInvariantClosure(call *ir.CallExpr) bool {
+	if call.X.Op() != ir.OCLOSURE || len(call.Args) != 0 {
+		return false
+	}
+	fn := call.X.(*ir.ClosureExpr).Func
+	if !fn.IsHiddenClosure() {
+		return false
+	}
+	for _, cv := range fn.ClosureVars {
+		if loc := e.oldLoc(cv); loc.curfn == e.curfn && loc.loopDepth != 1 {
+			return false
+		}
+	}
+	return true
+}
+
 func (e *escape) assignHeap(src ir.Node, why string, where ir.Node) {
 	e.expr(e.heapHole().note(where, why), src)
 }
@@ -1011,6 +1066,7 @@ func (e *escape) call(ks []hole, call, where ir.Node) {
 
 		// Pick out the function callee, if statically known.
 		var fn *ir.Name
+		methodValue := false
 		switch call.Op() {
 		case ir.OCALLFUNC:
 			switch v := ir.StaticValue(call.X); {
@@ -1018,6 +1074,15 @@ func (e *escape) call(ks []hole, call, where ir.Node) {
 				fn = v.(*ir.Name)
 			case v.Op() == ir.OCLOSURE:
 				fn = v.(*ir.ClosureExpr).Func.Nname
+			case v.Op() == ir.ONAME && v.(*ir.Name).Class == ir.PEXTERN:
+				fn = staticCallee(v.(*ir.Name))
+			case v.Op() == ir.OCALLPART && !v.(*ir.SelectorExpr).X.Type().IsInterface():
+				// Method value of a concrete type. The receiver
+				// was bound when the method value was created,
+				// but the arguments are passed straight through
+				// to the method, so we can use its tags.
+				fn = ir.MethodExprName(v)
+				methodValue = fn != nil
 			}
 		case ir.OCALLMETH:
 			fn = ir.MethodExprName(call.X)
@@ -1034,8 +1099,13 @@ func (e *escape) call(ks []hole, call, where ir.Node) {
 			}
 		}
 
-		if r := fntype.Recv(); r != nil {
+		if r := fntype.Recv(); r != nil && !methodValue {
 			argument(e.tagHole(ks, fn, r), call.X.(*ir.SelectorExpr).X)
+		} else if where != nil && where.Op() == ir.ODEFER && !topLevelDefer && e.loopInvariantClosure(call) {
+			// The closure value is identical on every
+			// iteration, so a single stack slot can hold it
+			// for all the deferred calls.
+			e.expr(e.later(e.discardHole()).note(call, "call parameter"), call.X)
 		} else {
 			// Evaluate callee function expression.
 			argument(e.discardHole(), call.X)
@@ -1046,6 +1116,10 @@ func (e *escape) call(ks []hole, call, where ir.Node) {
 			argument(e.tagHole(ks, fn, param), args[i])
 		}
 
+		if fn != nil && where == nil && !methodValue {
+			e.zeroCopyStrArgs(fn, call)
+		}
+
 	case ir.OAPPEND:
 		call := call.(*ir.CallExpr)
 		args := call.Args
@@ -1054,7 +1128,9 @@ func (e *escape) call(ks []hole, call, where ir.Node) {
 		// it has enough capacity. Alternatively, a new heap
 		// slice might be allocated, and all slice elements
 		// might flow to heap.
-		appendeeK := ks[0]
+		// The appended values are written into the appendee
+		// slice's backing array, if it has enough capacity.
+		appendeeK := e.teeHole(ks[0], e.mutatorHole())
 		if args[0].Type().Elem().HasPointers() {
 			appendeeK = e.teeHole(appendeeK, e.heapHole().deref(call, "appendee slice"))
 		}
@@ -1074,7 +1150,7 @@ func (e *escape) call(ks []hole, call, where ir.Node) {
 
 	case ir.OCOPY:
 		call := call.(*ir.BinaryExpr)
-		argument(e.discardHole(), call.X)
+		argument(e.mutatorHole(), call.X)
 
 		copiedK := e.discardHole()
 		if call.Y.Type().IsSlice() && call.Y.Type().Elem().HasPointers() {
@@ -1138,6 +1214,10 @@ func (e *escape) tagHole(ks []hole, fn *ir.Name, param *types.Field) hole {
 		}
 	}
 
+	if x := esc.Mutator(); x >= 0 {
+		tagKs = append(tagKs, e.mutatorHole().shift(x))
+	}
+
 	return e.teeHole(tagKs...)
 }
 
@@ -1184,7 +1264,7 @@ func (k hole) note(where ir.Node, why string) hole {
 	if where == nil || why == "" {
 		base.Fatalf("note: missing where/why")
 	}
-	if base.Flag.LowerM >= 2 || logopt.Enabled() {
+	if base.Flag.LowerM >= 2 || logopt.Enabled() || escGraph != nil {
 		k.notes = &note{
 			next:  k.notes,
 			where: where,
@@ -1332,13 +1412,15 @@ func (b *batch) flow(k hole, src *location) {
 			}
 			explanation := b.explainFlow(pos, dst, src, k.derefs, k.notes, []*logopt.LoggedOpt{})
 			if logopt.Enabled() {
-				var e_curfn *ir.Func // TODO(mdempsky): Fix.
-				logopt.LogOpt(src.n.Pos(), "escapes", "escape", ir.FuncName(e_curfn), fmt.Sprintf("%v escapes to heap", src.n), explanation)
+				logopt.LogOpt(src.n.Pos(), "escapes", "escape", ir.FuncName(src.curfn), fmt.Sprintf("%v escapes to heap", src.n), explanation)
 			}
 
 		}
 		src.escapes = true
-		return
+		if escGraph == nil {
+			return
+		}
+		// Keep the edge for -d=escapegraph, to show why src escapes.
 	}
 
 	// TODO(mdempsky): Deduplicate edges?
@@ -1347,6 +1429,7 @@ func (b *batch) flow(k hole, src *location) {
 
 func (b *batch) heapHole() hole    { return b.heapLoc.asHole() }
 func (b *batch) discardHole() hole { return b.blankLoc.asHole() }
+func (b *batch) mutatorHole() hole { return b.mutatorLoc.asHole() }
 
 // walkAll computes the minimal dereferences between all pairs of
 // locations.
@@ -1372,6 +1455,7 @@ func (b *batch) walkAll() {
 		enqueue(loc)
 	}
 	enqueue(&b.heapLoc)
+	enqueue(&b.mutatorLoc)
 
 	var walkgen uint32
 	for len(todo) > 0 {
@@ -1421,7 +1505,18 @@ func (b *batch) walkOne(root *location, walkgen uint32, enqueue func(*location))
 			}
 		}
 
-		if b.outlives(root, l) {
+		if root == &b.mutatorLoc {
+			// l's value is a pointer that is written
+			// through. If l's address flows to root, then
+			// l's memory may be mutated. Record mutation of
+			// parameters for tagging the function later.
+			if addressOf {
+				l.mutated = true
+			}
+			if l.isName(ir.PPARAM) {
+				l.paramEsc.AddMutator(derefs)
+			}
+		} else if b.outlives(root, l) {
 			// l's value flows to root. If l is a function
 			// parameter and root is the heap or a
 			// corresponding result parameter, then record
@@ -1434,8 +1529,7 @@ func (b *batch) walkOne(root *location, walkgen uint32, enqueue func(*location))
 					}
 					explanation := b.explainPath(root, l)
 					if logopt.Enabled() {
-						var e_curfn *ir.Func // TODO(mdempsky): Fix.
-						logopt.LogOpt(l.n.Pos(), "leak", "escape", ir.FuncName(e_curfn),
+						logopt.LogOpt(l.n.Pos(), "leak", "escape", ir.FuncName(l.curfn),
 							fmt.Sprintf("parameter %v leaks to %s with derefs=%d", l.n, b.explainLoc(root), derefs), explanation)
 					}
 				}
@@ -1452,8 +1546,7 @@ func (b *batch) walkOne(root *location, walkgen uint32, enqueue func(*location))
 					}
 					explanation := b.explainPath(root, l)
 					if logopt.Enabled() {
-						var e_curfn *ir.Func // TODO(mdempsky): Fix.
-						logopt.LogOpt(l.n.Pos(), "escape", "escape", ir.FuncName(e_curfn), fmt.Sprintf("%v escapes to heap", l.n), explanation)
+						logopt.LogOpt(l.n.Pos(), "escape", "escape", ir.FuncName(l.curfn), fmt.Sprintf("%v escapes to heap", l.n), explanation)
 					}
 				}
 				l.escapes = true
@@ -1655,6 +1748,8 @@ func (b *batch) finish(fns []*ir.Func) {
 		}
 	}
 
+	zeroCopy := b.zeroCopy()
+
 	for _, loc := range b.allLocs {
 		n := loc.n
 		if n == nil {
@@ -1675,20 +1770,24 @@ func (b *batch) finish(fns []*ir.Func) {
 				if base.Flag.LowerM != 0 {
 					base.WarnfAt(n.Pos(), "moved to heap: %v", n)
 				}
+				addAllocSite(n)
 			} else {
 				if base.Flag.LowerM != 0 {
 					base.WarnfAt(n.Pos(), "%v escapes to heap", n)
 				}
 				if logopt.Enabled() {
-					var e_curfn *ir.Func // TODO(mdempsky): Fix.
-					logopt.LogOpt(n.Pos(), "escape", "escape", ir.FuncName(e_curfn))
+					logopt.LogOpt(n.Pos(), "escape", "escape", ir.FuncName(loc.curfn))
 				}
+				addAllocSite(n)
 			}
 			n.SetEsc(ir.EscHeap)
 		} else {
 			if base.Flag.LowerM != 0 && n.Op() != ir.ONAME {
 				base.WarnfAt(n.Pos(), "%v does not escape", n)
 			}
+			if logopt.Enabled() && n.Op() != ir.ONAME {
+				logopt.LogOpt(n.Pos(), "stackAlloc", "escape", ir.FuncName(loc.curfn), fmt.Sprintf("%v does not escape", n))
+			}
 			n.SetEsc(ir.EscNone)
 			if loc.transient {
 				switch n.Op() {
@@ -1705,6 +1804,8 @@ func (b *batch) finish(fns []*ir.Func) {
 			}
 		}
 	}
+
+	setZeroCopy(zeroCopy)
 }
 
 func (l *location) isName(c ir.Class) bool {
@@ -1715,11 +1816,16 @@ const numEscResults = 7
 
 // An leaks represents a set of assignment flows from a parameter
 // to the heap or to any of its function's (first numEscResults)
-// result parameters.
-type leaks [1 + numEscResults]uint8
-
-// Empty reports whether l is an empty set (i.e., no assignment flows).
-func (l leaks) Empty() bool { return l == leaks{} }
+// result parameters. It also records whether memory pointed to by the
+// parameter may be written (see Mutator).
+type leaks [2 + numEscResults]uint8
+
+// Empty reports whether l is an empty set (i.e., no assignment
+// flows). Mutator flows are not assignment flows and are ignored.
+func (l leaks) Empty() bool {
+	l.setMutator(-1)
+	return l == leaks{}
+}
 
 // Heap returns the minimum deref count of any assignment flow from l
 // to the heap. If no such flows exist, Heap returns -1.
@@ -1739,6 +1845,16 @@ func (l *leaks) AddResult(i, derefs int) { l.add(1+i, derefs) }
 
 func (l *leaks) setResult(i, derefs int) { l.set(1+i, derefs) }
 
+// Mutator returns the minimum deref count of any flow from l to a
+// pointer that is written through. If no such flows exist, Mutator
+// returns -1.
+func (l leaks) Mutator() int { return l.get(1 + numEscResults) }
+
+// AddMutator adds a flow from l to a pointer that is written through.
+func (l *leaks) AddMutator(derefs int) { l.add(1+numEscResults, derefs) }
+
+func (l *leaks) setMutator(derefs int) { l.set(1+numEscResults, derefs) }
+
 func (l leaks) get(i int) int { return int(l[i]) - 1 }
 
 func (l *leaks) add(i, derefs int) {
@@ -1770,6 +1886,9 @@ func (l *leaks) Optimize() {
 				l.setResult(i, -1)
 			}
 		}
+		if l.Mutator() >= x {
+			l.setMutator(-1)
+		}
 	}
 }
 
@@ -1807,7 +1926,33 @@ func parseLeaks(s string) leaks {
 }
 
 func Funcs(all []ir.Node) {
+	staticFuncVars = findStaticFuncVars(all)
+	if len(staticFuncVars) > 0 {
+		// Analyze the functions held by static function variables
+		// first, so that calls through the variables can use their
+		// parameter tags.
+		var first []ir.Node
+		for _, fn := range staticFuncVars {
+			if fn.Defn != nil {
+				first = append(first, fn.Defn)
+			}
+		}
+		sort.Slice(first, func(i, j int) bool { return first[i].Pos().Before(first[j].Pos()) })
+		all = append(first, all...)
+	}
+	if base.Debug.EscapeGraph != "" {
+		escGraph = newGraph()
+	}
 	ir.VisitFuncsBottomUp(all, Batch)
+	if escGraph != nil {
+		escGraph.write()
+		escGraph = nil
+	}
+	if base.Debug.AllocSummary != 0 {
+		reportAllocSummary()
+		allocSites = nil
+	}
+	staticFuncVars = nil
 }
 
 const (
@@ -2020,7 +2165,16 @@ func HeapAllocReason(n ir.Node) string {
 			r = n.Len
 		}
 		if !ir.IsSmallIntConst(r) {
-			return "non-constant size"
+			// A non-constant size is fine if it has a small
+			// constant upper bound; see walkMakeSlice.
+			max, ok := MaxIntBound(r)
+			if !ok {
+				return "non-constant size"
+			}
+			if t := n.Type(); t.Elem().Width != 0 && max > ir.MaxBoundedStackSliceSize/t.Elem().Width {
+				return "non-constant size"
+			}
+			return ""
 		}
 		if t := n.Type(); t.Elem().Width != 0 && ir.Int64Val(r) >= ir.MaxImplicitStackVarSize/t.Elem().Width {
 			return "too large for stack"
@@ -2030,6 +2184,112 @@ func HeapAllocReason(n ir.Node) string {
 	return ""
 }
 
+// MaxIntBound returns an upper bound on the value of the integer
+// expression n, which must also be known to be non-negative.
+// It reports false if no such bound can be determined.
+//
+// Only simple syntactic forms are recognized: small constants,
+// values of small unsigned types, masking (x & c), unsigned
+// remainder (x % c), and unsigned right shifts (x >> c).
+func MaxIntBound(n ir.Node) (int64, bool) {
+	if n == nil || n.Type() == nil || !n.Type().IsInteger() {
+		return 0, false
+	}
+
+	max, ok := maxIntBound(n)
+	if !ok || max > maxTypeBound(n.Type()) {
+		return 0, false
+	}
+	return max, true
+}
+
+func maxIntBound(n ir.Node) (int64, bool) {
+	t := n.Type()
+	if t.IsUnsigned() && t.Size() <= 2 {
+		return maxTypeBound(t), true
+	}
+
+	switch n.Op() {
+	case ir.OLITERAL:
+		if ir.IsSmallIntConst(n) {
+			if v := ir.Int64Val(n); v >= 0 {
+				return v, true
+			}
+		}
+
+	case ir.OCONV, ir.OCONVNOP:
+		n := n.(*ir.ConvExpr)
+		if n.X.Type().IsInteger() {
+			return MaxIntBound(n.X)
+		}
+
+	case ir.OAND:
+		// x & y is non-negative and no larger than y
+		// if y is non-negative, and vice versa.
+		n := n.(*ir.BinaryExpr)
+		xmax, xok := MaxIntBound(n.X)
+		ymax, yok := MaxIntBound(n.Y)
+		switch {
+		case xok && yok:
+			if ymax < xmax {
+				return ymax, true
+			}
+			return xmax, true
+		case xok:
+			return xmax, true
+		case yok:
+			return ymax, true
+		}
+
+	case ir.OANDNOT:
+		n := n.(*ir.BinaryExpr)
+		return MaxIntBound(n.X)
+
+	case ir.OMOD:
+		n := n.(*ir.BinaryExpr)
+		xmax, xok := MaxIntBound(n.X)
+		if ir.IsSmallIntConst(n.Y) && (xok || t.IsUnsigned()) {
+			if c := ir.Int64Val(n.Y); c > 0 && (!xok || c-1 < xmax) {
+				return c - 1, true
+			}
+		}
+		if xok {
+			return xmax, true
+		}
+
+	case ir.ORSH:
+		n := n.(*ir.BinaryExpr)
+		if !ir.IsSmallIntConst(n.Y) {
+			break
+		}
+		c := ir.Int64Val(n.Y)
+		if c < 0 || c >= 64 {
+			break
+		}
+		if xmax, ok := MaxIntBound(n.X); ok {
+			return xmax >> c, true
+		}
+		if t.IsUnsigned() && t.Size()*8-c <= 31 {
+			return int64(uint64(1)<<(uint64(t.Size()*8)-uint64(c)) - 1), true
+		}
+	}
+
+	return 0, false
+}
+
+// maxTypeBound returns the largest value representable by the integer
+// type t, capped at the largest small (int32) constant.
+func maxTypeBound(t *types.Type) int64 {
+	bits := t.Size() * 8
+	if !t.IsUnsigned() {
+		bits--
+	}
+	if bits >= 31 {
+		return 1<<31 - 1
+	}
+	return 1<<bits - 1
+}
+
 // This special tag is applied to uintptr variables
 // that we believe may hold unsafe.Pointers for
 // calls into assembly functions.
@@ -2067,8 +2327,14 @@ func (b *batch) paramTag(fn *ir.Func, narg int, f *types.Field) string {
 
 		var esc leaks
 
+		// External functions may write through any pointer
+		// argument.
+		esc.AddMutator(0)
+
 		// External functions are assumed unsafe, unless
 		// //go:noescape is given before the declaration.
+		// Unlike for functions with bodies, the claim is
+		// trusted.
 		if fn.Pragma&ir.Noescape != 0 {
 			if base.Flag.LowerM != 0 && f.Sym != nil {
 				base.WarnfAt(f.Pos, "%v does not escape", name())
@@ -2114,6 +2380,22 @@ func (b *batch) paramTag(fn *ir.Func, narg int, f *types.Field) string {
 	esc := loc.paramEsc
 	esc.Optimize()
 
+	// For a function with a body, //go:noescape is a claim to be
+	// verified rather than trusted. The computed tag is exported
+	// either way.
+	if fn.Pragma&ir.Noescape != 0 {
+		if esc.Heap() >= 0 {
+			base.ErrorfAt(f.Pos, "%v leaks to heap, but %v is marked //go:noescape", name(), ir.FuncName(fn))
+		} else {
+			for i := 0; i < numEscResults; i++ {
+				if esc.Result(i) >= 0 {
+					base.ErrorfAt(f.Pos, "%v leaks to result %v, but %v is marked //go:noescape", name(), fn.Type().Results().Field(i).Sym, ir.FuncName(fn))
+					break
+				}
+			}
+		}
+	}
+
 	if base.Flag.LowerM != 0 && !loc.escapes {
 		if esc.Empty() {
 			base.WarnfAt(f.Pos, "%v does not escape", name())
@@ -0,0 +1,123 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package escape
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+)
+
+// Calls through function values.
+//
+// Calls through a local variable that is initialized to a known
+// function and never reassigned are already resolved by
+// ir.StaticValue. Here we extend that to package-level function
+// variables: if an unexported package-level variable of function
+// type is initialized to a declared function and is never assigned
+// or address-taken anywhere else in the package, then every call
+// through it must call that function, and we can use the callee's
+// parameter tags instead of assuming that all arguments escape.
+//
+// (Calls through interface methods with a single known concrete
+// receiver type are rewritten into static method calls by the
+// devirtualize pass before escape analysis runs.)
+
+// staticFuncVars maps package-level function variables to the
+// function they always hold. It is computed by Funcs.
+var staticFuncVars map[*ir.Name]*ir.Name
+
+// findStaticFuncVars returns the package-level function variables
+// that are only ever assigned by their declaration, along with the
+// function each one holds.
+func findStaticFuncVars(all []ir.Node) map[*ir.Name]*ir.Name {
+	// Assembly in the same package may write to any variable.
+	if !base.Flag.Complete {
+		return nil
+	}
+
+	var vars map[*ir.Name]*ir.Name
+	for _, n := range all {
+		if n.Op() != ir.OAS {
+			continue
+		}
+		as := n.(*ir.AssignStmt)
+		v, ok := as.X.(*ir.Name)
+		if !ok || v.Class != ir.PEXTERN || v.Defn != as || as.Y == nil {
+			continue
+		}
+		if v.Type().Kind() != types.TFUNC || types.IsExported(v.Sym().Name) || v.Sym().Linkname != "" {
+			continue
+		}
+		fn, ok := ir.StaticValue(as.Y).(*ir.Name)
+		if !ok || fn.Class != ir.PFUNC || fn.Type().Recv() != nil {
+			continue
+		}
+		if vars == nil {
+			vars = make(map[*ir.Name]*ir.Name)
+		}
+		vars[v] = fn
+	}
+	if vars == nil {
+		return nil
+	}
+
+	// Drop any variable that is assigned or has its address taken.
+	drop := func(x ir.Node) {
+		if v, ok := x.(*ir.Name); ok {
+			delete(vars, v)
+		}
+	}
+	var do func(n ir.Node) bool
+	do = func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.OAS:
+			n := n.(*ir.AssignStmt)
+			if v, ok := n.X.(*ir.Name); !ok || v.Defn != n {
+				drop(n.X)
+			}
+		case ir.OASOP:
+			n := n.(*ir.AssignOpStmt)
+			drop(n.X)
+		case ir.OAS2, ir.OAS2FUNC, ir.OAS2MAPR, ir.OAS2DOTTYPE, ir.OAS2RECV, ir.OSELRECV2:
+			n := n.(*ir.AssignListStmt)
+			for _, p := range n.Lhs {
+				drop(p)
+			}
+		case ir.ORANGE:
+			n := n.(*ir.RangeStmt)
+			drop(n.Key)
+			drop(n.Value)
+		case ir.OADDR:
+			n := n.(*ir.AddrExpr)
+			drop(ir.OuterValue(n.X))
+		case ir.OCLOSURE:
+			n := n.(*ir.ClosureExpr)
+			ir.Any(n.Func, do)
+		}
+		return false
+	}
+	// Package-level initializers, such as var p = &v, are
+	// in all along with the function declarations.
+	for _, n := range all {
+		ir.Any(n, do)
+	}
+	return vars
+}
+
+// staticCallee returns the function that the global function
+// variable v always holds, or nil if it is not known. The callee's
+// parameters must already be tagged by escape analysis.
+func staticCallee(v *ir.Name) *ir.Name {
+	fn := staticFuncVars[v]
+	if fn == nil {
+		return nil
+	}
+	if fn.Defn != nil && fn.Defn.Esc() != escFuncTagged {
+		// Not yet analyzed; see Funcs.
+		return nil
+	}
+	return fn
+}
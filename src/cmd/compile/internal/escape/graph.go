@@ -0,0 +1,176 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package escape
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+)
+
+// escGraph accumulates the location graphs of the batches analyzed by
+// Funcs for -d=escapegraph. It is nil if the flag is not set.
+var escGraph *graph
+
+// A graph is the escape analysis location graph of a package, in the
+// form written by -d=escapegraph.
+//
+// The heap and mutator locations of all batches are merged into the
+// first two nodes, so that the graph shows every way a value reaches
+// the heap.
+type graph struct {
+	Nodes []graphNode
+	Edges []graphEdge
+
+	ids map[*location]int
+}
+
+// A graphNode is a location.
+type graphNode struct {
+	ID        int
+	Name      string // the variable or expression, as in -m=2 output
+	Func      string `json:",omitempty"` // enclosing function
+	Pos       string `json:",omitempty"`
+	Escapes   bool   `json:",omitempty"`
+	Transient bool   `json:",omitempty"`
+}
+
+// A graphEdge is a flow from the location Src to the location Dst.
+// Derefs is the number of dereferences applied to the flowing value;
+// -1 means its address flows.
+type graphEdge struct {
+	Src, Dst int
+	Derefs   int
+	Notes    []graphNote `json:",omitempty"`
+}
+
+// A graphNote explains one step of an edge, as in -m=2 output.
+type graphNote struct {
+	Pos string
+	Why string
+}
+
+func newGraph() *graph {
+	g := &graph{ids: make(map[*location]int)}
+	g.Nodes = append(g.Nodes, graphNode{ID: 0, Name: "{heap}", Escapes: true})
+	g.Nodes = append(g.Nodes, graphNode{ID: 1, Name: "{mutator}"})
+	return g
+}
+
+// add adds the locations and edges of batch b to g, after the walk has
+// determined which locations escape.
+func (g *graph) add(b *batch) {
+	g.ids[&b.heapLoc] = 0
+	g.ids[&b.mutatorLoc] = 1
+	for _, loc := range b.allLocs {
+		id := len(g.Nodes)
+		g.ids[loc] = id
+		n := graphNode{
+			ID:        id,
+			Name:      b.explainLoc(loc),
+			Func:      ir.FuncName(loc.curfn),
+			Escapes:   loc.escapes,
+			Transient: loc.transient,
+		}
+		if loc.n != nil {
+			n.Pos = base.FmtPos(loc.n.Pos())
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+
+	addEdges := func(dst *location) {
+		for _, e := range dst.edges {
+			edge := graphEdge{Src: g.ids[e.src], Dst: g.ids[dst], Derefs: e.derefs}
+			for note := e.notes; note != nil; note = note.next {
+				edge.Notes = append(edge.Notes, graphNote{Pos: base.FmtPos(note.where.Pos()), Why: note.why})
+			}
+			g.Edges = append(g.Edges, edge)
+		}
+	}
+	addEdges(&b.heapLoc)
+	addEdges(&b.mutatorLoc)
+	for _, loc := range b.allLocs {
+		addEdges(loc)
+	}
+	delete(g.ids, &b.heapLoc)
+	delete(g.ids, &b.mutatorLoc)
+}
+
+// write writes g to the file named by -d=escapegraph, as JSON if the
+// name ends in ".json" and in Graphviz dot format otherwise.
+func (g *graph) write() {
+	name := base.Debug.EscapeGraph
+	f, err := os.Create(name)
+	if err != nil {
+		base.Fatalf("creating escape graph file: %v", err)
+	}
+	w := bufio.NewWriter(f)
+	if strings.HasSuffix(name, ".json") {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		err = enc.Encode(g)
+	} else {
+		g.writeDot(w)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		base.Fatalf("writing escape graph file: %v", err)
+	}
+}
+
+// writeDot writes g in Graphviz dot format. Edges point in the
+// direction of flow and are labeled with their dereferences: "&" for
+// an address, "*" for each dereference. Escaping locations are red.
+func (g *graph) writeDot(w io.Writer) {
+	fmt.Fprintf(w, "digraph %q {\n", "escape "+base.Ctxt.Pkgpath)
+	fmt.Fprintf(w, "\tnode [shape=box];\n")
+	for _, n := range g.Nodes {
+		label := n.Name
+		if n.Func != "" {
+			label += "\\n" + n.Func
+		}
+		if n.Pos != "" {
+			label += "\\n" + n.Pos
+		}
+		attrs := ""
+		if n.Escapes {
+			attrs = ", color=red"
+		}
+		fmt.Fprintf(w, "\tn%d [label=%s%s];\n", n.ID, dotQuote(label), attrs)
+	}
+	for _, e := range g.Edges {
+		label := "&"
+		if e.Derefs >= 0 {
+			label = strings.Repeat("*", e.Derefs)
+		}
+		var tooltip []string
+		for _, note := range e.Notes {
+			tooltip = append(tooltip, note.Pos+": "+note.Why)
+		}
+		fmt.Fprintf(w, "\tn%d -> n%d [label=%s", e.Src, e.Dst, dotQuote(label))
+		if len(tooltip) > 0 {
+			fmt.Fprintf(w, ", tooltip=%s", dotQuote(strings.Join(tooltip, "\\n")))
+		}
+		fmt.Fprintf(w, "];\n")
+	}
+	fmt.Fprintf(w, "}\n")
+}
+
+// dotQuote quotes s as a dot string. Backslash sequences in s, such
+// as the \n line breaks in labels, are kept.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
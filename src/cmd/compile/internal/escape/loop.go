@@ -0,0 +1,185 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package escape
+
+import (
+	"go/constant"
+	"go/token"
+
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+)
+
+// Loops that run at most once.
+//
+// Escape analysis tracks loop depth so that a value created in one
+// iteration of a loop is not assumed to be dead when the next
+// iteration starts. A for loop whose body can run at most once does
+// not need that treatment: its body is analyzed at the enclosing loop
+// depth. In particular, a defer statement in such a loop is treated
+// like a defer outside of any loop. It gets a stack-allocated defer
+// record and does not prevent open-coded defers from being used.
+//
+// Two shapes of loop are recognized:
+//
+//	for init; cond; post { ...; break }
+//
+// where the last statement of the body leaves the loop and no
+// continue statement in the body refers to the loop, and
+//
+//	for i := c1; i < c2; i++ { ... }
+//
+// where c2-c1 <= 1 (or the same with >, i-- and c1-c2 <= 1) and i is
+// not otherwise assigned or address-taken.
+
+// atMostOnce reports whether the body of loop n runs at most once.
+func atMostOnce(n *ir.ForStmt) bool {
+	if n.Op() != ir.OFOR {
+		return false
+	}
+	return (endsLoop(n.Body) && !continues(n.Body, n.Label)) || shortCount(n)
+}
+
+// endsLoop reports whether the last statement of the loop body list
+// unconditionally leaves the loop.
+func endsLoop(list ir.Nodes) bool {
+	if len(list) == 0 {
+		return false
+	}
+	switch n := list[len(list)-1]; n.Op() {
+	case ir.OBLOCK:
+		return endsLoop(n.(*ir.BlockStmt).List)
+	case ir.OBREAK, ir.ORETURN, ir.OPANIC:
+		// A break directly in the body refers either to this
+		// loop or to a statement enclosing it.
+		return true
+	}
+	return false
+}
+
+// continues reports whether list contains a continue statement that
+// refers to the loop labeled label (which may be nil).
+func continues(list ir.Nodes, label *types.Sym) bool {
+	depth := 0 // loops entered within list
+	var do func(ir.Node) bool
+	do = func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.OCONTINUE:
+			l := n.(*ir.BranchStmt).Label
+			if l != nil {
+				return l == label
+			}
+			return depth == 0
+		case ir.OFOR, ir.OFORUNTIL, ir.ORANGE:
+			depth++
+			found := ir.DoChildren(n, do)
+			depth--
+			return found
+		case ir.OCLOSURE:
+			return false
+		}
+		return ir.DoChildren(n, do)
+	}
+	for _, n := range list {
+		if do(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// shortCount reports whether n is a counting loop over a local
+// variable that runs at most once.
+func shortCount(n *ir.ForStmt) bool {
+	if n.Cond == nil || n.Post == nil || n.Cond.Op() != ir.OLT && n.Cond.Op() != ir.OGT {
+		return false
+	}
+	cond := n.Cond.(*ir.BinaryExpr)
+	i, ok := cond.X.(*ir.Name)
+	if !ok || i.Class != ir.PAUTO || i.Addrtaken() || !i.Type().IsInteger() || !ir.IsConstNode(cond.Y) {
+		return false
+	}
+
+	// The variable must start at a constant.
+	var start ir.Node
+	for _, init := range n.Init() {
+		if init.Op() == ir.OAS && init.(*ir.AssignStmt).X == i {
+			start = init.(*ir.AssignStmt).Y
+		}
+	}
+	if start == nil || !ir.IsConstNode(start) {
+		return false
+	}
+
+	// The post statement must be i++ (for <) or i-- (for >).
+	post, ok := n.Post.(*ir.AssignOpStmt)
+	if !ok || post.X != i || !ir.IsConstNode(post.Y) || constant.Compare(post.Y.Val(), token.NEQ, constant.MakeInt64(1)) {
+		return false
+	}
+	lo, hi := start.Val(), cond.Y.Val()
+	switch {
+	case cond.Op() == ir.OLT && post.AsOp == ir.OADD:
+	case cond.Op() == ir.OGT && post.AsOp == ir.OSUB:
+		lo, hi = hi, lo
+	default:
+		return false
+	}
+	if constant.Compare(constant.BinaryOp(hi, token.SUB, lo), token.GTR, constant.MakeInt64(1)) {
+		return false
+	}
+
+	return !assigns(n.Body, i)
+}
+
+// assigns reports whether list assigns to, takes the address of, or
+// captures in a closure the variable v.
+func assigns(list ir.Nodes, v *ir.Name) bool {
+	is := func(x ir.Node) bool {
+		n, ok := x.(*ir.Name)
+		return ok && n.Canonical() == v
+	}
+	var do func(ir.Node) bool
+	do = func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.OAS:
+			if is(n.(*ir.AssignStmt).X) {
+				return true
+			}
+		case ir.OASOP:
+			if is(n.(*ir.AssignOpStmt).X) {
+				return true
+			}
+		case ir.OAS2, ir.OAS2FUNC, ir.OAS2MAPR, ir.OAS2DOTTYPE, ir.OAS2RECV, ir.OSELRECV2:
+			for _, x := range n.(*ir.AssignListStmt).Lhs {
+				if is(x) {
+					return true
+				}
+			}
+		case ir.ORANGE:
+			n := n.(*ir.RangeStmt)
+			if is(n.Key) || is(n.Value) {
+				return true
+			}
+		case ir.OADDR:
+			if is(ir.OuterValue(n.(*ir.AddrExpr).X)) {
+				return true
+			}
+		case ir.OCLOSURE:
+			for _, cv := range n.(*ir.ClosureExpr).Func.ClosureVars {
+				if cv.Canonical() == v {
+					return true
+				}
+			}
+			return false
+		}
+		return ir.DoChildren(n, do)
+	}
+	for _, n := range list {
+		if do(n) {
+			return true
+		}
+	}
+	return false
+}
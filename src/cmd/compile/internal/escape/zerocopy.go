@@ -0,0 +1,148 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package escape
+
+import (
+	"go/constant"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+)
+
+// Zero-copy string conversions.
+//
+// A []byte(s) conversion normally copies the bytes of s, because
+// the result may be written. If the result is never written through
+// (its address never flows to the mutator location) and does not
+// outlive s's memory, then it can simply point at s's bytes instead.
+//
+// Similarly, string(a[:]) of a local array a can point at a's bytes
+// if a cannot be written while the string is in use. We only do this
+// for conversions passed directly to a parameter that doesn't leak,
+// in calls with no other arguments that could reach a or write to
+// memory, where a does not escape. Then the callee is the only code
+// that runs while the string is in use, and it has no way to reach a.
+
+// A zeroCopyStr is a []byte->string conversion of a local array
+// that can share the array's memory if the array does not escape.
+type zeroCopyStr struct {
+	conv  *ir.ConvExpr
+	array *ir.Name
+}
+
+// zeroCopyStrArgs records the []byte->string conversion passed to a
+// parameter of fn in call, if it is a candidate for sharing memory.
+func (e *escape) zeroCopyStrArgs(fn *ir.Name, call *ir.CallExpr) {
+	if fn.Func != nil && fn.Func.IsHiddenClosure() || e.inMutualBatch(fn) {
+		return
+	}
+
+	var z zeroCopyStr
+	var others []ir.Node
+	if call.Op() == ir.OCALLMETH {
+		others = append(others, call.X.(*ir.SelectorExpr).X)
+	}
+	params := fn.Type().Params().FieldSlice()
+	for i, arg := range call.Args {
+		if z.conv == nil && arg.Op() == ir.OBYTES2STR && parseLeaks(params[i].Note).Empty() {
+			if a := localArray(arg.(*ir.ConvExpr).X); a != nil {
+				z = zeroCopyStr{arg.(*ir.ConvExpr), a}
+				continue
+			}
+		}
+		others = append(others, arg)
+	}
+	if z.conv == nil {
+		return
+	}
+	for _, n := range others {
+		if n.Type().HasPointers() || mayAffectMemory(n) {
+			return
+		}
+	}
+	e.zeroCopyStrs = append(e.zeroCopyStrs, z)
+}
+
+// localArray returns the local array variable that n slices, if any.
+func localArray(n ir.Node) *ir.Name {
+	if n.Op() != ir.OSLICEARR {
+		return nil
+	}
+	addr, ok := n.(*ir.SliceExpr).X.(*ir.AddrExpr)
+	if !ok {
+		return nil
+	}
+	a, ok := addr.X.(*ir.Name)
+	if !ok || a.Op() != ir.ONAME || a.Class != ir.PAUTO || a.IsClosureVar() {
+		return nil
+	}
+	return a
+}
+
+// stableString reports whether the memory of string n is known to
+// outlive the current function: n is a constant, a global variable,
+// or a parameter that is never assigned.
+func (b *batch) stableString(n ir.Node) bool {
+	switch n.Op() {
+	case ir.OLITERAL:
+		return true
+	case ir.ONAME:
+		n := n.(*ir.Name)
+		switch n.Class {
+		case ir.PEXTERN:
+			return true
+		case ir.PPARAM:
+			loc := b.oldLoc(n)
+			return !loc.reassigned && !loc.addrtaken
+		}
+	}
+	return false
+}
+
+// zeroCopy returns the string conversions that can share memory
+// with their operand. It must be called before the locations of
+// variables are cleared.
+func (b *batch) zeroCopy() []*ir.ConvExpr {
+	var convs []*ir.ConvExpr
+	for _, loc := range b.allLocs {
+		if loc.n == nil || loc.n.Op() != ir.OSTR2BYTES || loc.escapes || loc.mutated {
+			continue
+		}
+		n := loc.n.(*ir.ConvExpr)
+		if !loc.transient && !b.stableString(n.X) {
+			continue
+		}
+		if base.Flag.LowerM != 0 {
+			base.WarnfAt(n.Pos(), "zero-copy string->[]byte conversion")
+		}
+		convs = append(convs, n)
+	}
+	for _, z := range b.zeroCopyStrs {
+		if b.oldLoc(z.array).escapes {
+			continue
+		}
+		if base.Flag.LowerM != 0 {
+			base.WarnfAt(z.conv.Pos(), "zero-copy []byte->string conversion")
+		}
+		convs = append(convs, z.conv)
+	}
+	return convs
+}
+
+// setZeroCopy rewrites the conversions returned by zeroCopy to share
+// memory with their operand.
+func setZeroCopy(convs []*ir.ConvExpr) {
+	for _, n := range convs {
+		switch n.Op() {
+		case ir.OSTR2BYTES:
+			n.SetOp(ir.OSTR2BYTESTMP)
+			if ir.IsConst(n.X, constant.String) && ir.StringVal(n.X) != "" {
+				n.MarkNonNil()
+			}
+		case ir.OBYTES2STR:
+			n.SetOp(ir.OBYTES2STRTMP)
+		}
+	}
+}
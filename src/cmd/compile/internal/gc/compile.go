@@ -83,11 +83,28 @@ func prepareFunc(fn *ir.Func) {
 
 	typecheck.DeclContext = ir.PAUTO
 	ir.CurFunc = fn
+	ssagen.InitMultiversion(fn)
 	walk.Walk(fn)
 	ir.CurFunc = nil // enforce no further uses of CurFunc
 	typecheck.DeclContext = ir.PEXTERN
 }
 
+// releaseBody drops the IR of fn's body once fn is compiled, so that
+// the bodies of compiled functions can be collected while the rest
+// of the package compiles instead of staying reachable from
+// typecheck.Target.Decls until the compiler exits. Inlining uses the
+// separate copy in fn.Inl.
+func releaseBody(fn *ir.Func) {
+	if base.Debug.TypeSizes != 0 || base.Debug.LayoutCompare != "" {
+		// reportTypeSizes and reportLayoutDiffs look for types
+		// declared in the bodies.
+		return
+	}
+	fn.Body = nil
+	fn.Enter = nil
+	fn.Exit = nil
+}
+
 // compileFunctions compiles all functions in compilequeue.
 // It fans out nBackendWorkers to do the work
 // and waits for them to complete.
@@ -129,6 +146,7 @@ func compileFunctions() {
 			worker := <-workerIDs
 			ssagen.Compile(fn, worker)
 			workerIDs <- worker
+			releaseBody(fn)
 
 			// Done compiling fn. Schedule it's closures for compilation.
 			for _, closure := range fn.Closures {
@@ -138,7 +156,6 @@ func compileFunctions() {
 		}()
 	}
 
-	types.CalcSizeDisabled = true // not safe to calculate sizes concurrently
 	base.Ctxt.InParallel = true
 	for _, fn := range compilequeue {
 		asyncCompile(fn)
@@ -146,5 +163,4 @@ func compileFunctions() {
 	compilequeue = nil
 	wg.Wait()
 	base.Ctxt.InParallel = false
-	types.CalcSizeDisabled = false
 }
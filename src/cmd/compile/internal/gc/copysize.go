@@ -0,0 +1,121 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gc
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+)
+
+// largeCopySize is the size above which -warn=largecopy reports
+// copies, unless -d=copysize sets another.
+const largeCopySize = 1 << 10
+
+// reportCopies reports, for -d=copysize=N, each place in fn where
+// an existing value larger than N bytes is copied by an assignment, a
+// range statement, or a function call argument or receiver. Without
+// -d=copysize, it reports the copies larger than largeCopySize as
+// -warn=largecopy warnings.
+func reportCopies(fn *ir.Func) {
+	if fn.Wrapper() || fn.Dupok() {
+		return
+	}
+	limit := int64(base.Debug.CopySize)
+	warn := func(pos src.XPos, format string, args ...interface{}) {
+		base.WarnfAt(pos, format, args...)
+	}
+	if limit == 0 {
+		limit = largeCopySize
+		warn = func(pos src.XPos, format string, args ...interface{}) {
+			base.WarnAt(base.WarnLargeCopy, pos, format, args...)
+		}
+	}
+
+	// size returns the size of values of type t if it exceeds the
+	// limit, and 0 otherwise.
+	size := func(t *types.Type) int64 {
+		if t == nil || t.Kind() == types.TFUNC {
+			return 0
+		}
+		types.CalcSize(t)
+		if t.Width <= limit {
+			return 0
+		}
+		return t.Width
+	}
+
+	ir.VisitList(fn.Body, func(n ir.Node) {
+		switch n.Op() {
+		case ir.OAS:
+			n := n.(*ir.AssignStmt)
+			if ir.IsBlank(n.X) || n.Y == nil || !isCopySource(n.Y) {
+				return
+			}
+			if w := size(n.Y.Type()); w != 0 {
+				warn(n.Pos(), "assignment copies %d bytes of %v", w, n.Y.Type())
+			}
+
+		case ir.OAS2:
+			n := n.(*ir.AssignListStmt)
+			for i, y := range n.Rhs {
+				if ir.IsBlank(n.Lhs[i]) || !isCopySource(y) {
+					continue
+				}
+				if w := size(y.Type()); w != 0 {
+					warn(n.Pos(), "assignment copies %d bytes of %v", w, y.Type())
+				}
+			}
+
+		case ir.ORANGE:
+			n := n.(*ir.RangeStmt)
+			if n.Value == nil || ir.IsBlank(n.Value) {
+				return
+			}
+			if t := n.X.Type(); t.IsArray() && isCopySource(n.X) {
+				if w := size(t); w != 0 {
+					warn(n.Pos(), "range copies %d bytes of %v", w, t)
+				}
+			}
+			if w := size(n.Value.Type()); w != 0 {
+				warn(n.Pos(), "range value copies %d bytes of %v per iteration", w, n.Value.Type())
+			}
+
+		case ir.OCALLFUNC, ir.OCALLMETH, ir.OCALLINTER:
+			n := n.(*ir.CallExpr)
+			if n.Op() == ir.OCALLMETH {
+				sel := n.X.(*ir.SelectorExpr)
+				if recv := sel.Type().Recv(); recv != nil && !recv.Type.IsPtr() && isCopySource(sel.X) {
+					if w := size(recv.Type); w != 0 {
+						warn(n.Pos(), "receiver copies %d bytes of %v", w, recv.Type)
+					}
+				}
+			}
+			for _, arg := range n.Args {
+				if !isCopySource(arg) {
+					continue
+				}
+				if w := size(arg.Type()); w != 0 {
+					warn(n.Pos(), "argument copies %d bytes of %v", w, arg.Type())
+				}
+			}
+		}
+	})
+}
+
+// isCopySource reports whether evaluating n yields a copy of a value
+// stored elsewhere, as opposed to a newly constructed value.
+func isCopySource(n ir.Node) bool {
+	switch n.Op() {
+	case ir.ONAME:
+		return n.(*ir.Name).Class != ir.PFUNC
+	case ir.ODOT, ir.ODOTPTR, ir.OINDEX, ir.OINDEXMAP, ir.ODEREF:
+		return true
+	case ir.OCONVNOP:
+		return isCopySource(n.(*ir.ConvExpr).X)
+	}
+	return false
+}
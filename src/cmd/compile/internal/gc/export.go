@@ -28,10 +28,16 @@ func dumpexport(bout *bio.Writer) {
 		// Must catch it here rather than Export(), because the type can be
 		// not fully set (still TFORW) when Export() is called.
 		if n.Type() != nil && n.Type().HasTParam() {
-			base.Fatalf("Cannot (yet) export a generic type: %v", n)
+			base.ErrorfAt(n.Pos(), "cannot export generic type %v: generic types cannot yet be used outside the package that declares them", n)
+			continue
+		}
+		if it := typecheck.InstantiatedType(n.Type()); it != nil {
+			base.ErrorfAt(n.Pos(), "cannot export %v: it refers to instantiated generic type %v, and generic types cannot yet be used outside the package that declares them", n.Sym(), it)
+			continue
 		}
 		p.markObject(n)
 	}
+	base.ExitIfErrors()
 
 	// The linker also looks for the $$ marker - use char after $$ to distinguish format.
 	exportf(bout, "\n$$B\n") // indicate binary export format
@@ -51,6 +57,7 @@ func dumpasmhdr() {
 		base.Fatalf("%v", err)
 	}
 	fmt.Fprintf(b, "// generated by compile -asmhdr from package %s\n\n", types.LocalPkg.Name)
+	done := make(map[string]bool)
 	for _, n := range typecheck.Target.Asms {
 		if n.Sym().IsBlank() {
 			continue
@@ -68,18 +75,43 @@ func dumpasmhdr() {
 			if !t.IsStruct() || t.StructType().Map != nil || t.IsFuncArgStruct() {
 				break
 			}
-			fmt.Fprintf(b, "#define %s__size %d\n", n.Sym().Name, int(t.Width))
-			for _, f := range t.Fields().Slice() {
-				if !f.Sym.IsBlank() {
-					fmt.Fprintf(b, "#define %s_%s %d\n", n.Sym().Name, f.Sym.Name, int(f.Offset))
-				}
-			}
+			asmhdrType(b, n.Sym().Name, t)
+			done[n.Sym().Name] = true
 		}
 	}
 
+	// Types named by //go:asmoffsets, which may be imported or
+	// not be structs. Imported names are prefixed by their
+	// package name, as in abi_RegArgs__size.
+	for _, n := range typecheck.Target.AsmTypes {
+		name := n.Sym().Name
+		if n.Sym().Pkg != types.LocalPkg {
+			name = n.Sym().Pkg.Name + "_" + name
+		}
+		if done[name] {
+			continue
+		}
+		asmhdrType(b, name, n.Type())
+		done[name] = true
+	}
+
 	b.Close()
 }
 
+// asmhdrType writes the size of t and, if t is a struct, the offsets
+// of its fields to the assembly header, using name as the prefix.
+func asmhdrType(b *bio.Writer, name string, t *types.Type) {
+	fmt.Fprintf(b, "#define %s__size %d\n", name, int(t.Width))
+	if !t.IsStruct() {
+		return
+	}
+	for _, f := range t.Fields().Slice() {
+		if !f.Sym.IsBlank() {
+			fmt.Fprintf(b, "#define %s_%s %d\n", name, f.Sym.Name, int(f.Offset))
+		}
+	}
+}
+
 type exporter struct {
 	marked map[*types.Type]bool // types already seen by markType
 }
@@ -0,0 +1,136 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gc
+
+import (
+	"fmt"
+	"go/layout"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/types"
+)
+
+// reportLayoutDiffs prints, for -d=layoutcompare=goarch, each type
+// declared in the package whose size, alignment or field offsets
+// differ between the target architecture and goarch, along with the
+// fields that move. A 64-bit integer field that is not 8-byte aligned
+// on goarch is flagged, since 64-bit atomic operations on it fail on
+// 32-bit systems.
+func reportLayoutDiffs(goarch string) {
+	l := &archLayout{arch: layout.ArchFor(goarch), sizes: make(map[*types.Type]archSize)}
+	for _, name := range declaredTypes() {
+		t := name.Type()
+		types.CalcSize(t)
+		s := l.size(t)
+		var moved []int // indexes of the fields whose offsets differ
+		if t.IsStruct() {
+			for i, f := range t.Fields().Slice() {
+				if f.Offset != s.offsets[i] {
+					moved = append(moved, i)
+				}
+			}
+		}
+		if s.size == t.Width && s.align == int64(t.Align) && len(moved) == 0 {
+			continue
+		}
+		fmt.Printf("%v: %v: size %d, align %d; on %s: size %d, align %d\n", base.FmtPos(name.Pos()), t, t.Width, t.Align, goarch, s.size, s.align)
+		for _, i := range moved {
+			f, o := t.Field(i), s.offsets[i]
+			note := ""
+			if k := f.Type.Kind(); (k == types.TINT64 || k == types.TUINT64) && o%8 != 0 {
+				note = ", not 8-byte aligned"
+			}
+			fmt.Printf("%v: %v.%v: offset %d; on %s: offset %d%s\n", base.FmtPos(f.Pos), t, f.Sym, f.Offset, goarch, o, note)
+		}
+	}
+}
+
+// An archLayout computes the layout of types on another architecture.
+type archLayout struct {
+	arch  *layout.Arch
+	sizes map[*types.Type]archSize
+}
+
+// An archSize is the layout of a type on the architecture of an
+// archLayout.
+type archSize struct {
+	size, align int64
+	offsets     []int64 // for structs, the offset of each field
+}
+
+func (l *archLayout) size(t *types.Type) archSize {
+	if s, ok := l.sizes[t]; ok {
+		return s
+	}
+	var s archSize
+	switch t.Kind() {
+	case types.TARRAY:
+		e := l.size(t.Elem())
+		s = archSize{size: layout.ArraySize(e.size, t.NumElem()), align: e.align}
+	case types.TSTRUCT:
+		var st layout.Struct
+		for _, f := range t.Fields().Slice() {
+			fs := l.size(f.Type)
+			s.offsets = append(s.offsets, st.Field(fs.size, fs.align))
+		}
+		s.size, s.align = st.Size(), st.Align()
+	default:
+		k := layoutKind(t)
+		s = archSize{size: l.arch.Sizeof(k), align: l.arch.Alignof(k)}
+	}
+	l.sizes[t] = s
+	return s
+}
+
+// layoutKind returns the layout.Kind of t, which must not be an array
+// or struct type.
+func layoutKind(t *types.Type) layout.Kind {
+	switch t.Kind() {
+	case types.TBOOL:
+		return layout.Bool
+	case types.TINT8:
+		return layout.Int8
+	case types.TUINT8:
+		return layout.Uint8
+	case types.TINT16:
+		return layout.Int16
+	case types.TUINT16:
+		return layout.Uint16
+	case types.TINT32:
+		return layout.Int32
+	case types.TUINT32:
+		return layout.Uint32
+	case types.TINT64:
+		return layout.Int64
+	case types.TUINT64:
+		return layout.Uint64
+	case types.TINT:
+		return layout.Int
+	case types.TUINT:
+		return layout.Uint
+	case types.TUINTPTR:
+		return layout.Uintptr
+	case types.TFLOAT32:
+		return layout.Float32
+	case types.TFLOAT64:
+		return layout.Float64
+	case types.TCOMPLEX64:
+		return layout.Complex64
+	case types.TCOMPLEX128:
+		return layout.Complex128
+	case types.TSTRING:
+		return layout.String
+	case types.TUNSAFEPTR:
+		return layout.UnsafePointer
+	case types.TPTR, types.TCHAN, types.TMAP, types.TFUNC:
+		return layout.Pointer
+	case types.TINTER:
+		return layout.Interface
+	case types.TSLICE:
+		return layout.Slice
+	}
+	base.Fatalf("layoutKind: unexpected type %v", t)
+	panic("unreachable")
+}
@@ -8,6 +8,7 @@ import (
 	"bufio"
 	"bytes"
 	"cmd/compile/internal/base"
+	"cmd/compile/internal/coverage"
 	"cmd/compile/internal/deadcode"
 	"cmd/compile/internal/devirtualize"
 	"cmd/compile/internal/dwarfgen"
@@ -20,8 +21,10 @@ import (
 	"cmd/compile/internal/reflectdata"
 	"cmd/compile/internal/ssa"
 	"cmd/compile/internal/ssagen"
+	"cmd/compile/internal/tailcall"
 	"cmd/compile/internal/typecheck"
 	"cmd/compile/internal/types"
+	"cmd/compile/internal/walk"
 	"cmd/internal/dwarf"
 	"cmd/internal/obj"
 	"cmd/internal/objabi"
@@ -104,8 +107,9 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 
 	// Record flags that affect the build result. (And don't
 	// record flags that don't, since that would cause spurious
-	// changes in the binary.)
-	dwarfgen.RecordFlags("B", "N", "l", "msan", "race", "shared", "dynlink", "dwarf", "dwarflocationlists", "dwarfbasentries", "smallframes", "spectre")
+	// changes in the binary.) The linker copies them into the
+	// binary's build information as well as its DWARF.
+	dwarfgen.RecordFlags("B", "N", "l", "msan", "race", "asan", "shared", "dynlink", "dwarf", "dwarflocationlists", "dwarfbasentries", "smallframes", "spectre", "stackprotect", "wb")
 
 	if !base.EnableTrace && base.Flag.LowerT {
 		log.Fatalf("compiler not built with support for -t")
@@ -146,11 +150,12 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	if base.Compiling(base.NoInstrumentPkgs) {
 		base.Flag.Race = false
 		base.Flag.MSan = false
+		base.Flag.ASan = false
 	}
 
 	ssagen.Arch.LinkArch.Init(base.Ctxt)
 	startProfile()
-	if base.Flag.Race || base.Flag.MSan {
+	if base.Flag.Race || base.Flag.MSan || base.Flag.ASan {
 		base.Flag.Cfg.Instrumenting = true
 	}
 	if base.Flag.Dwarf {
@@ -160,9 +165,12 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 		ssagen.Arch.SoftFloat = true
 	}
 
-	if base.Flag.JSON != "" { // parse version,destination from json logging optimization.
+	if base.Flag.JSON != "" && !base.JSONDiagnostics() { // parse version,destination from json logging optimization.
 		logopt.LogJsonOption(base.Flag.JSON)
 	}
+	if base.Debug.Remarks != "" {
+		logopt.LogRemarksOption(base.Debug.Remarks)
+	}
 
 	ir.EscFmt = escape.Fmt
 	ir.IsIntrinsicCall = ssagen.IsIntrinsicCall
@@ -176,7 +184,11 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 
 	typecheck.Target = new(ir.Package)
 
-	typecheck.NeedITab = func(t, iface *types.Type) { reflectdata.ITabAddr(t, iface) }
+	typecheck.NeedITab = func(t, iface *types.Type) {
+		if !reflectdata.LazyITabs() {
+			reflectdata.ITabAddr(t, iface)
+		}
+	}
 	typecheck.NeedRuntimeType = reflectdata.NeedRuntimeType // TODO(rsc): TypeSym for lock?
 
 	base.AutogeneratedPos = makePos(src.NewFileBase("<autogenerated>", "<autogenerated>"), 1, 0)
@@ -189,6 +201,14 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	dwarfgen.RecordPackageName()
 	ssagen.CgoSymABIs()
 
+	// Add coverage counters. Must happen after typechecking,
+	// and before deadcode and the init task, which may add code.
+	coverage.Instrument()
+
+	// Find the maps built as static tables, before their
+	// initialization is scheduled.
+	walk.FindStaticMaps(typecheck.Target.Decls)
+
 	// Build init task.
 	if initTask := pkginit.Task(); initTask != nil {
 		typecheck.Export(initTask)
@@ -213,17 +233,47 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	}
 	typecheck.IncrementalAddrtaken = true
 
+	if base.Debug.FuncHash != 0 {
+		for _, n := range typecheck.Target.Decls {
+			if n.Op() == ir.ODCLFUNC {
+				fn := n.(*ir.Func)
+				sum := ir.HashFunc(fn)
+				base.WarnfAt(fn.Pos(), "hash of %v: %x", fn, sum[:8])
+			}
+		}
+	}
+
+	if base.Debug.CopySize != 0 || base.WarnLargeCopy.Enabled() {
+		for _, n := range typecheck.Target.Decls {
+			if n.Op() == ir.ODCLFUNC {
+				reportCopies(n.(*ir.Func))
+			}
+		}
+	}
+	if base.WarnUnusedResult.Enabled() {
+		for _, n := range typecheck.Target.Decls {
+			if n.Op() == ir.ODCLFUNC {
+				checkUnusedResults(n.(*ir.Func))
+			}
+		}
+	}
+
 	if base.Debug.TypecheckInl != 0 {
 		// Typecheck imported function bodies if Debug.l > 1,
 		// otherwise lazily when used or re-exported.
 		typecheck.AllImportedBodies()
 	}
 
+	if base.Debug.CallGraph != "" {
+		inline.WriteCallGraph(typecheck.Target.Decls)
+	}
+
 	// Inlining
 	base.Timer.Start("fe", "inlining")
 	if base.Flag.LowerL != 0 {
 		inline.InlinePackage()
 	}
+	walk.DropStaticMapInits(typecheck.Target.Decls)
 
 	// Devirtualize.
 	for _, n := range typecheck.Target.Decls {
@@ -233,6 +283,14 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	}
 	ir.CurFunc = nil
 
+	// Eliminate self-recursive tail calls.
+	for _, n := range typecheck.Target.Decls {
+		if n.Op() == ir.ODCLFUNC {
+			tailcall.Func(n.(*ir.Func))
+		}
+	}
+	ir.CurFunc = nil
+
 	// Escape analysis.
 	// Required for moving heap allocations onto stack,
 	// which in turn is required by the closure implementation,
@@ -257,6 +315,7 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	// can trigger function compilation.
 	typecheck.InitRuntime()
 	ssagen.InitConfig()
+	ssagen.MarkNeverReturns(typecheck.Target.Decls)
 
 	// Just before compilation, compile itabs found on
 	// the right side of OCONVIFACE so that methods
@@ -264,6 +323,11 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	ir.CurFunc = nil
 	reflectdata.CompileITabs()
 
+	// Record the signatures of linknamed functions while the
+	// bodies that tell them apart are present: compiling a
+	// function releases its body.
+	ssagen.WriteLinknameSigs()
+
 	// Compile top level functions.
 	// Don't use range--walk can add functions to Target.Decls.
 	base.Timer.Start("be", "compilefuncs")
@@ -302,6 +366,15 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	}
 
 	ssagen.CheckLargeStacks()
+	ssagen.ReportFrameSizes()
+	ssagen.ReportCodeSizes()
+	ssagen.ReportWriteBarriers()
+	if base.Debug.TypeSizes != 0 {
+		reportTypeSizes()
+	}
+	if base.Debug.LayoutCompare != "" {
+		reportLayoutDiffs(base.Debug.LayoutCompare)
+	}
 	typecheck.CheckFuncStack()
 
 	if len(compilequeue) != 0 {
@@ -9,6 +9,7 @@ import (
 	"cmd/compile/internal/ir"
 	"cmd/compile/internal/objw"
 	"cmd/compile/internal/reflectdata"
+	"cmd/compile/internal/ssagen"
 	"cmd/compile/internal/staticdata"
 	"cmd/compile/internal/typecheck"
 	"cmd/compile/internal/types"
@@ -120,6 +121,10 @@ func dumpdata() {
 	reflectdata.WriteImportStrings()
 	reflectdata.WriteBasicTypes()
 	dumpembeds()
+	staticdata.WriteWasmExports()
+	if base.Flag.FieldTrack {
+		reflectdata.WriteTrackSyms()
+	}
 
 	// Calls to WriteRuntimeTypes can generate functions,
 	// like method wrappers and hash and equality routines.
@@ -151,6 +156,7 @@ func dumpdata() {
 	}
 
 	staticdata.WriteFuncSyms()
+	ssagen.WriteCallGraph()
 	addGCLocals()
 
 	if numExports != len(typecheck.Target.Exports) {
@@ -274,6 +280,9 @@ func ggloblnod(nam *ir.Name) {
 	if nam.LibfuzzerExtraCounter() {
 		s.Type = objabi.SLIBFUZZER_EXTRA_COUNTER
 	}
+	if nam.CoverageCounter() {
+		s.Type = objabi.SCOVERAGE_COUNTER
+	}
 	if nam.Sym().Linkname != "" {
 		// Make sure linkname'd symbol is non-package. When a symbol is
 		// both imported and linkname'd, s.Pkg may not set to "_" in
@@ -0,0 +1,64 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gc
+
+import (
+	"fmt"
+	"sort"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+)
+
+// reportTypeSizes prints, for -d=typesizes, the size and alignment of
+// each type declared in the package, at package level or inside a
+// function, largest first.
+func reportTypeSizes() {
+	names := declaredTypes()
+	for _, name := range names {
+		types.CalcSize(name.Type())
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		wi, wj := names[i].Type().Width, names[j].Type().Width
+		if wi != wj {
+			return wi > wj
+		}
+		return names[i].Pos().Before(names[j].Pos())
+	})
+	for _, name := range names {
+		t := name.Type()
+		fmt.Printf("%v: %v: size %d, align %d\n", base.FmtPos(name.Pos()), t, t.Width, t.Align)
+	}
+}
+
+// declaredTypes returns the defined types declared in the package, at
+// package level or inside a function, in declaration order. It skips
+// aliases and generic types.
+func declaredTypes() []*ir.Name {
+	var names []*ir.Name
+	add := func(n ir.Node) {
+		if n.Op() != ir.ODCLTYPE {
+			return
+		}
+		name := n.(*ir.Decl).X
+		if name.Alias() {
+			return
+		}
+		if t := name.Type(); t == nil || t.Broke() || t.HasTParam() {
+			return
+		}
+		names = append(names, name)
+	}
+	for _, n := range typecheck.Target.Decls {
+		add(n)
+		if n.Op() == ir.ODCLFUNC {
+			// Closures have their own entries in Decls.
+			ir.VisitList(n.(*ir.Func).Body, add)
+		}
+	}
+	return names
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gc
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+)
+
+// checkUnusedResults reports, for -warn=unusedresult, each call
+// statement in fn that calls a //go:pure function. Such a call has no
+// effect, so the compiler removes it.
+func checkUnusedResults(fn *ir.Func) {
+	if fn.Wrapper() || fn.Dupok() {
+		return
+	}
+	check := func(list ir.Nodes) {
+		for _, n := range list {
+			if n.Op() != ir.OCALLFUNC && n.Op() != ir.OCALLMETH {
+				continue
+			}
+			if callee := pureCallee(n.(*ir.CallExpr)); callee != nil {
+				base.WarnAt(base.WarnUnusedResult, n.Pos(), "result of pure function %v is not used", callee.Sym())
+			}
+		}
+	}
+	check(fn.Body)
+	ir.VisitList(fn.Body, func(n ir.Node) {
+		switch n := n.(type) {
+		case *ir.BlockStmt:
+			check(n.List)
+		case *ir.IfStmt:
+			check(n.Body)
+			check(n.Else)
+		case *ir.ForStmt:
+			check(n.Body)
+		case *ir.RangeStmt:
+			check(n.Body)
+		case *ir.CaseClause:
+			check(n.Body)
+		case *ir.CommClause:
+			check(n.Body)
+		}
+	})
+}
+
+// pureCallee returns the function called by n if it is marked
+// //go:pure, and nil otherwise.
+func pureCallee(n *ir.CallExpr) *ir.Name {
+	var fn *ir.Name
+	switch x := n.X; x.Op() {
+	case ir.ONAME:
+		if x := x.(*ir.Name); x.Class == ir.PFUNC {
+			fn = x
+		}
+	case ir.OMETHEXPR, ir.ODOTMETH:
+		fn = ir.MethodExprName(x)
+	}
+	if fn == nil {
+		return nil
+	}
+	if fn.Pragma()&ir.Pure != 0 || fn.Func != nil && fn.Func.Pragma&ir.Pure != 0 {
+		return fn
+	}
+	return nil
+}
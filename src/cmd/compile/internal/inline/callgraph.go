@@ -0,0 +1,199 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/devirtualize"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+)
+
+// A callGraph is the static call graph of a package, in the form
+// written by -d=callgraph. It is computed before inlining, with the
+// rules the inliner and devirtualizer use to resolve callees.
+type callGraph struct {
+	Funcs []callGraphFunc
+	Calls []callGraphCall
+
+	ids map[string]int
+}
+
+// A callGraphFunc is a function of the package or a function it calls.
+type callGraphFunc struct {
+	ID   int
+	Name string // the linker symbol name, with the package path
+	Pos  string `json:",omitempty"` // for functions of the package
+}
+
+// A callGraphCall is a call site. Kind is one of:
+//
+//	"direct": a call of a statically known function or closure
+//	"method": a call of a statically known method
+//	"devirtualized": an interface method call whose receiver has a
+//	    statically known concrete type
+//	"interface": any other interface method call
+//	"dynamic": a call of any other function value
+//
+// Callee is -1 for interface and dynamic calls, and Expr is then the
+// called expression.
+type callGraphCall struct {
+	Caller int
+	Callee int
+	Kind   string
+	Pos    string
+	Expr   string `json:",omitempty"`
+}
+
+// WriteCallGraph writes the static call graph of the functions in
+// decls to the file named by -d=callgraph, as JSON if the name ends in
+// ".json" and in Graphviz dot format otherwise.
+func WriteCallGraph(decls []ir.Node) {
+	g := &callGraph{ids: make(map[string]int)}
+	for _, n := range decls {
+		if n.Op() == ir.ODCLFUNC {
+			g.funcID(n.(*ir.Func))
+		}
+	}
+	for _, n := range decls {
+		if n.Op() == ir.ODCLFUNC {
+			g.addCalls(n.(*ir.Func))
+		}
+	}
+	g.write()
+}
+
+// funcID returns the ID of the node for fn, adding it if needed.
+func (g *callGraph) funcID(fn *ir.Func) int {
+	id := g.symID(fn.Sym())
+	if fn.Sym().Pkg == types.LocalPkg && g.Funcs[id].Pos == "" {
+		g.Funcs[id].Pos = base.FmtPos(fn.Pos())
+	}
+	return id
+}
+
+// symID returns the ID of the node for the function symbol s, adding
+// it if needed.
+func (g *callGraph) symID(s *types.Sym) int {
+	name := s.Name
+	if p := symPkgPath(s); p != "" {
+		name = p + "." + name
+	}
+	id, ok := g.ids[name]
+	if !ok {
+		id = len(g.Funcs)
+		g.ids[name] = id
+		g.Funcs = append(g.Funcs, callGraphFunc{ID: id, Name: name})
+	}
+	return id
+}
+
+// symPkgPath returns the path of the package of s, as ir.PkgFuncName
+// does.
+func symPkgPath(s *types.Sym) string {
+	if s.Pkg != nil && s.Pkg.Path != "" {
+		return s.Pkg.Path
+	}
+	return base.Ctxt.Pkgpath
+}
+
+// addCalls adds the call sites in the body of fn to g. The bodies of
+// the closures in fn are visited separately.
+func (g *callGraph) addCalls(fn *ir.Func) {
+	caller := g.funcID(fn)
+	ir.VisitList(fn.Body, func(n ir.Node) {
+		call, ok := n.(*ir.CallExpr)
+		if !ok {
+			return
+		}
+		c := callGraphCall{Caller: caller, Callee: -1, Pos: base.FmtPos(call.Pos())}
+		switch call.Op() {
+		case ir.OCALLFUNC:
+			if callee := staticCallee(call.X); callee != nil {
+				c.Kind = "direct"
+				c.Callee = g.funcID(callee)
+			} else {
+				c.Kind = "dynamic"
+			}
+		case ir.OCALLMETH:
+			c.Kind = "method"
+			c.Callee = g.funcID(ir.MethodExprName(call.X).Func)
+		case ir.OCALLINTER:
+			if typ := devirtualize.ConcreteType(call); typ != nil {
+				c.Kind = "devirtualized"
+				c.Callee = g.symID(ir.MethodSym(typ, call.X.(*ir.SelectorExpr).Sel))
+			} else {
+				c.Kind = "interface"
+			}
+		default:
+			return
+		}
+		if c.Callee < 0 {
+			c.Expr = fmt.Sprint(call.X)
+		}
+		g.Calls = append(g.Calls, c)
+	})
+}
+
+// write writes g to the file named by -d=callgraph.
+func (g *callGraph) write() {
+	name := base.Debug.CallGraph
+	f, err := os.Create(name)
+	if err != nil {
+		base.Fatalf("creating call graph file: %v", err)
+	}
+	w := bufio.NewWriter(f)
+	if strings.HasSuffix(name, ".json") {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		err = enc.Encode(g)
+	} else {
+		g.writeDot(w)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		base.Fatalf("writing call graph file: %v", err)
+	}
+}
+
+// writeDot writes g in Graphviz dot format. Functions of the package
+// are boxes and the functions they call from other packages are
+// ellipses. Each unresolved call site gets a node of its own, labeled
+// with the called expression. Edges are labeled with the call kind.
+func (g *callGraph) writeDot(w io.Writer) {
+	fmt.Fprintf(w, "digraph %q {\n", "callgraph "+base.Ctxt.Pkgpath)
+	for _, fn := range g.Funcs {
+		shape := "ellipse"
+		if fn.Pos != "" {
+			shape = "box"
+		}
+		fmt.Fprintf(w, "\tf%d [label=%q, shape=%s];\n", fn.ID, fn.Name, shape)
+	}
+	for i, c := range g.Calls {
+		callee := fmt.Sprintf("f%d", c.Callee)
+		if c.Callee < 0 {
+			callee = fmt.Sprintf("c%d", i)
+			fmt.Fprintf(w, "\t%s [label=%q, shape=plaintext];\n", callee, c.Expr)
+		}
+		style := "solid"
+		if c.Kind != "direct" && c.Kind != "method" {
+			style = "dashed"
+		}
+		fmt.Fprintf(w, "\tf%d -> %s [label=%q, tooltip=%q, style=%s];\n", c.Caller, callee, c.Kind, c.Pos, style)
+	}
+	fmt.Fprintf(w, "}\n")
+}
@@ -29,6 +29,8 @@ package inline
 import (
 	"fmt"
 	"go/constant"
+	"math"
+	"sort"
 	"strings"
 
 	"cmd/compile/internal/base"
@@ -40,19 +42,52 @@ import (
 	"cmd/internal/src"
 )
 
-// Inlining budget parameters, gathered in one place
+// Inlining budget parameters, gathered in one place.
+//
+// The budget itself and the limits for big functions are set by flags:
+// -inlinebudget is the maximum cost of an inlinable function (80 by
+// default), -inlinecallcost is the cost of a call that is not inlined
+// (57; the default is to inline if there's at most one call, and -l=4
+// overrides this by using 1 instead), and functions with at least
+// -inlinebigfunc nodes (5000) are considered "big", so that only
+// functions costing at most -inlinebigbudget (20) are inlined into them.
 const (
-	inlineMaxBudget       = 80
 	inlineExtraAppendCost = 0
-	// default is to inline if there's at most one call. -l=4 overrides this by using 1 instead.
-	inlineExtraCallCost  = 57              // 57 was benchmarked to provided most benefit with no bad surprises; see https://github.com/golang/go/issues/19348#issuecomment-439370742
-	inlineExtraPanicCost = 1               // do not penalize inlining panics.
-	inlineExtraThrowCost = inlineMaxBudget // with current (2018-05/1.11) code, inlining runtime.throw does not help.
-
-	inlineBigFunctionNodes   = 5000 // Functions with this many nodes are considered "big".
-	inlineBigFunctionMaxCost = 20   // Max cost of inlinee when inlining into a "big" function.
+	inlineExtraPanicCost  = 1 // do not penalize inlining panics.
+	inlineParamCallCost   = 8 // calls through a func parameter, expected to be bound to a small func literal; see funcArgCost.
+	inlineDeferCallCost   = 8 // the call deferred by a defer that mkinlcall open-codes, at most; see deferCallCost.
 )
 
+// inlineMaxBudget returns the maximum cost of an inlinable function.
+func inlineMaxBudget() int32 {
+	return int32(base.Flag.InlineBudget)
+}
+
+// inlineExtraCallCost returns the cost of a call that is not inlined.
+func inlineExtraCallCost() int32 {
+	return int32(base.Flag.InlineCallCost)
+}
+
+// inlineHint returns the hint for fn given in the -inlinehints file,
+// if any.
+func inlineHint(fn *ir.Func) (base.InlineHint, bool) {
+	if base.Flag.Cfg.InlineHints == nil {
+		return base.InlineHint{}, false
+	}
+	hint, ok := base.Flag.Cfg.InlineHints[ir.PkgFuncName(fn)]
+	return hint, ok
+}
+
+// hintBudget returns the inlining budget of a function with an inline
+// hint, which replaces both -inlinebudget and the budget for inlining
+// into big functions.
+func hintBudget(hint base.InlineHint) int32 {
+	if hint.Budget == 0 {
+		return math.MaxInt32
+	}
+	return int32(hint.Budget)
+}
+
 func InlinePackage() {
 	// Find functions that can be inlined and clone them before walk expands them.
 	ir.VisitFuncsBottomUp(typecheck.Target.Decls, func(list []*ir.Func, recursive bool) {
@@ -101,6 +136,13 @@ func CanInline(fn *ir.Func) {
 		return
 	}
 
+	// If named by a noinline hint, don't inline.
+	hint, hinted := inlineHint(fn)
+	if hinted && !hint.Inline {
+		reason = "marked noinline by -inlinehints"
+		return
+	}
+
 	// If marked "go:norace" and -race compilation, don't inline.
 	if base.Flag.Race && fn.Pragma&ir.Norace != 0 {
 		reason = "marked go:norace with -race compilation"
@@ -113,6 +155,13 @@ func CanInline(fn *ir.Func) {
 		return
 	}
 
+	// If marked "go:nocheckbounds", don't inline, since the checks
+	// are omitted only when compiling the function itself.
+	if fn.Pragma&ir.NoCheckBounds != 0 {
+		reason = "marked go:nocheckbounds"
+		return
+	}
+
 	// If marked "go:cgo_unsafe_args", don't inline, since the
 	// function makes assumptions about its argument frame layout.
 	if fn.Pragma&ir.CgoUnsafeArgs != 0 {
@@ -127,6 +176,13 @@ func CanInline(fn *ir.Func) {
 		return
 	}
 
+	// If marked as "go:multiversion", don't inline, since the
+	// inlined body would only ever run the baseline code.
+	if fn.Pragma&ir.Multiversion != 0 {
+		reason = "marked go:multiversion"
+		return
+	}
+
 	// The nowritebarrierrec checker currently works at function
 	// granularity, so inlining yeswritebarrierrec functions can
 	// confuse it (#22342). As a workaround, disallow inlining
@@ -152,7 +208,7 @@ func CanInline(fn *ir.Func) {
 	}
 	defer n.Func.SetInlinabilityChecked(true)
 
-	cc := int32(inlineExtraCallCost)
+	cc := inlineExtraCallCost()
 	if base.Flag.LowerL == 4 {
 		cc = 1 // this appears to yield better performance than 0.
 	}
@@ -166,28 +222,38 @@ func CanInline(fn *ir.Func) {
 	// locals, and we use this map to produce a pruned Inline.Dcl
 	// list. See issue 25249 for more context.
 
+	budget := inlineMaxBudget()
+	if hinted {
+		budget = hintBudget(hint)
+	}
 	visitor := hairyVisitor{
-		budget:        inlineMaxBudget,
+		curfn:         fn,
+		budget:        budget,
+		maxBudget:     budget,
 		extraCallCost: cc,
 	}
+	visitor.openDefer, visitor.noOpenDefer = simpleDefer(fn)
+	if base.Flag.LowerM > 1 || logopt.Enabled() {
+		visitor.lineCosts = make(map[uint]int32)
+	}
 	if visitor.tooHairy(fn) {
 		reason = visitor.reason
 		return
 	}
 
 	n.Func.Inl = &ir.Inline{
-		Cost: inlineMaxBudget - visitor.budget,
+		Cost: budget - visitor.budget,
 		Dcl:  pruneUnusedAutos(n.Defn.(*ir.Func).Dcl, &visitor),
 		Body: inlcopylist(fn.Body),
 	}
 
 	if base.Flag.LowerM > 1 {
-		fmt.Printf("%v: can inline %v with cost %d as: %v { %v }\n", ir.Line(fn), n, inlineMaxBudget-visitor.budget, fn.Type(), ir.Nodes(n.Func.Inl.Body))
+		fmt.Printf("%v: can inline %v with cost %d as: %v { %v }\n", ir.Line(fn), n, budget-visitor.budget, fn.Type(), ir.Nodes(n.Func.Inl.Body))
 	} else if base.Flag.LowerM != 0 {
 		fmt.Printf("%v: can inline %v\n", ir.Line(fn), n)
 	}
 	if logopt.Enabled() {
-		logopt.LogOpt(fn.Pos(), "canInlineFunction", "inline", ir.FuncName(fn), fmt.Sprintf("cost: %d", inlineMaxBudget-visitor.budget))
+		logopt.LogOpt(fn.Pos(), "canInlineFunction", "inline", ir.FuncName(fn), fmt.Sprintf("cost: %d", budget-visitor.budget))
 	}
 }
 
@@ -218,7 +284,7 @@ func Inline_Flood(n *ir.Name, exportsym func(*ir.Name)) {
 	var doFlood func(n ir.Node)
 	doFlood = func(n ir.Node) {
 		switch n.Op() {
-		case ir.OMETHEXPR, ir.ODOTMETH:
+		case ir.OMETHEXPR, ir.ODOTMETH, ir.OCALLPART:
 			Inline_Flood(ir.MethodExprName(n), exportsym)
 
 		case ir.ONAME:
@@ -231,9 +297,6 @@ func Inline_Flood(n *ir.Name, exportsym func(*ir.Name)) {
 				exportsym(n)
 			}
 
-		case ir.OCALLPART:
-			// Okay, because we don't yet inline indirect
-			// calls to method values.
 		case ir.OCLOSURE:
 			// VisitList doesn't visit closure bodies, so force a
 			// recursive call to VisitList on the body of the closure.
@@ -250,29 +313,175 @@ func Inline_Flood(n *ir.Name, exportsym func(*ir.Name)) {
 // hairyVisitor visits a function body to determine its inlining
 // hairiness and whether or not it can be inlined.
 type hairyVisitor struct {
+	curfn         *ir.Func
 	budget        int32
+	maxBudget     int32
 	reason        string
 	extraCallCost int32
 	usedLocals    ir.NameSet
 	do            func(ir.Node) bool
+	openDefer     *ir.GoDeferStmt // the defer mkinlcall can open-code, if any
+	noOpenDefer   string          // why fn's defer cannot be open-coded, if not
+
+	// If lineCosts is not nil, the cost of each node is added to
+	// the cost of its line, so that a function that is too
+	// complex can be reported along with its costliest lines.
+	lineCosts map[uint]int32
+	nested    int32 // cost of the children of the node being visited
 }
 
 func (v *hairyVisitor) tooHairy(fn *ir.Func) bool {
 	v.do = v.doNode // cache closure
+	if v.lineCosts != nil {
+		v.do = v.doNodeLineCost
+	}
 	if ir.DoChildren(fn, v.do) {
 		return true
 	}
 	if v.budget < 0 {
-		v.reason = fmt.Sprintf("function too complex: cost %d exceeds budget %d", inlineMaxBudget-v.budget, inlineMaxBudget)
+		v.reason = fmt.Sprintf("function too complex: cost %d exceeds budget %d by %d", v.maxBudget-v.budget, v.maxBudget, -v.budget)
+		if v.lineCosts != nil {
+			v.reason += "; costliest lines: " + v.costliestLines(3)
+		}
 		return true
 	}
 	return false
 }
 
+// doNodeLineCost is doNode, but also adds the cost of n, excluding
+// its children, to the cost of n's line. Names and constants are
+// shared by all their uses and have the position of their
+// declaration, so their cost goes to the line of their parent.
+func (v *hairyVisitor) doNodeLineCost(n ir.Node) bool {
+	nested := v.nested
+	v.nested = 0
+	budget := v.budget
+	stop := v.doNode(n)
+	cost := budget - v.budget
+	if n == nil {
+		v.nested = nested
+		return stop
+	}
+	switch n.Op() {
+	case ir.ONAME, ir.OLITERAL, ir.ONIL, ir.OTYPE:
+		v.nested = nested
+	default:
+		v.lineCosts[base.Ctxt.PosTable.Pos(n.Pos()).Line()] += cost - v.nested
+		v.nested = nested + cost
+	}
+	return stop
+}
+
+// costliestLines describes the at most max lines that contributed
+// the most to the cost of the function, in the form
+// "12 (cost 57), 10 (cost 9)".
+func (v *hairyVisitor) costliestLines(max int) string {
+	var lines []uint
+	for line, cost := range v.lineCosts {
+		if cost > 0 {
+			lines = append(lines, line)
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		ci, cj := v.lineCosts[lines[i]], v.lineCosts[lines[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return lines[i] < lines[j]
+	})
+	if len(lines) > max {
+		lines = lines[:max]
+	}
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%d (cost %d)", line, v.lineCosts[line])
+	}
+	return b.String()
+}
+
+// inlCallCost returns the cost of a call to the inlinable function fn.
+// A call is charged the cost of fn's body, as it will be inlined too,
+// but no more than a call that is not inlined. Otherwise a thin
+// wrapper around an inlinable function that is close to the budget,
+// whether in this package or an imported one, is never inlined and its
+// body never exported. Since a function within the budget has at most
+// one call charged less than its callee's cost, inlining such a
+// wrapper only adds the wrapper's own nodes.
+func inlCallCost(fn *ir.Func) int32 {
+	if cc := inlineExtraCallCost(); fn.Inl.Cost > cc {
+		return cc
+	}
+	return fn.Inl.Cost
+}
+
+// isFuncParamCall reports whether call is a call through one of fn's
+// own func-typed parameters.
+func isFuncParamCall(call *ir.CallExpr, fn *ir.Func) bool {
+	name, ok := call.X.(*ir.Name)
+	return ok && name.Class == ir.PPARAM && name.Curfn == fn
+}
+
+// funcArgCost returns how much the cost of inlining fn at call n
+// exceeds fn.Inl.Cost. CanInline charges each call in fn's body
+// through one of fn's func parameters only inlineParamCallCost,
+// expecting the argument to be an inlinable function or function
+// literal whose body replaces the call once fn is inlined. Here the
+// actual arguments are examined and each such call is charged what it
+// really costs.
+func funcArgCost(n *ir.CallExpr, fn *ir.Func) int32 {
+	if base.Flag.LowerL == 4 {
+		return 0
+	}
+	args := n.Args
+	if n.Op() == ir.OCALLFUNC && fn.Type().Recv() != nil {
+		// Method expression call; the receiver is the first argument.
+		args = args[1:]
+	}
+
+	var costs map[*ir.Name]int32
+	for i, param := range fn.Type().Params().FieldSlice() {
+		if i >= len(args) || param.Nname == nil || param.Type.Kind() != types.TFUNC {
+			continue
+		}
+		cost := inlineExtraCallCost()
+		if callee := inlCallee(args[i]); callee != nil && callee.Inl != nil {
+			cost = inlCallCost(callee)
+		}
+		if costs == nil {
+			costs = make(map[*ir.Name]int32)
+		}
+		costs[param.Nname.(*ir.Name)] = cost - inlineParamCallCost
+	}
+	if costs == nil {
+		return 0
+	}
+
+	var extra int32
+	ir.VisitList(fn.Inl.Body, func(n ir.Node) {
+		if n.Op() == ir.OCALLFUNC {
+			if name, ok := n.(*ir.CallExpr).X.(*ir.Name); ok {
+				extra += costs[name]
+			}
+		}
+	})
+	return extra
+}
+
 func (v *hairyVisitor) doNode(n ir.Node) bool {
 	if n == nil {
 		return false
 	}
+	if base.Flag.G > 0 && n.Type() != nil {
+		// Inline bodies are exported, and export data cannot
+		// describe instantiated generic types yet.
+		if it := typecheck.InstantiatedType(n.Type()); it != nil {
+			v.reason = fmt.Sprintf("mentions instantiated generic type %v", it)
+			return true
+		}
+	}
 	switch n.Op() {
 	// Call is okay if inlinable and we have the budget for the body.
 	case ir.OCALLFUNC:
@@ -290,7 +499,9 @@ func (v *hairyVisitor) doNode(n ir.Node) bool {
 					return true
 				}
 				if fn == "throw" {
-					v.budget -= inlineExtraThrowCost
+					// With current (2018-05/1.11) code,
+					// inlining runtime.throw does not help.
+					v.budget -= inlineMaxBudget()
 					break
 				}
 			}
@@ -302,7 +513,19 @@ func (v *hairyVisitor) doNode(n ir.Node) bool {
 		}
 
 		if fn := inlCallee(n.X); fn != nil && fn.Inl != nil {
-			v.budget -= fn.Inl.Cost
+			v.budget -= inlCallCost(fn)
+			break
+		}
+		if fn := methodCallee(n.X, v.curfn); fn != nil && fn.Inl != nil {
+			v.budget -= inlCallCost(fn)
+			break
+		}
+
+		// A call through a func parameter is charged as if the
+		// argument will be inlined along with fn. mkinlcall checks
+		// that against the actual arguments at each call site.
+		if v.extraCallCost > inlineParamCallCost && isFuncParamCall(n, v.curfn) {
+			v.budget -= inlineParamCallCost
 			break
 		}
 
@@ -326,7 +549,7 @@ func (v *hairyVisitor) doNode(n ir.Node) bool {
 			break
 		}
 		if fn.Inl != nil {
-			v.budget -= fn.Inl.Cost
+			v.budget -= inlCallCost(fn)
 			break
 		}
 		// Call cost for non-leaf inlining.
@@ -370,10 +593,27 @@ func (v *hairyVisitor) doNode(n ir.Node) bool {
 			return true
 		}
 
+	case ir.ODEFER:
+		if n != v.openDefer {
+			v.reason = v.noOpenDefer
+			if v.reason == "" {
+				// A defer in a closure.
+				v.reason = "unhandled op " + n.Op().String()
+			}
+			return true
+		}
+		// The defer costs what the call made at the end of the
+		// inlined body costs. Visit only the function or receiver
+		// and arguments the call evaluates, not the call itself.
+		n := n.(*ir.GoDeferStmt)
+		call := n.Call.(*ir.CallExpr)
+		cost, _ := deferCallCost(call)
+		v.budget -= cost
+		return doList(n.Init(), v.do) || ir.DoChildren(call, v.do)
+
 	case ir.ORANGE,
 		ir.OSELECT,
 		ir.OGO,
-		ir.ODEFER,
 		ir.ODCLTYPE, // can't print yet
 		ir.OTAILCALL:
 		v.reason = "unhandled op " + n.Op().String()
@@ -464,13 +704,230 @@ func (v *hairyVisitor) doNode(n ir.Node) bool {
 }
 
 func isBigFunc(fn *ir.Func) bool {
-	budget := inlineBigFunctionNodes
+	budget := base.Flag.InlineBigFunc
 	return ir.Any(fn, func(n ir.Node) bool {
 		budget--
 		return budget <= 0
 	})
 }
 
+// simpleDefer returns the defer statement of fn if mkinlcall can
+// open-code it by calling the deferred function at the end of the
+// inlined body, and otherwise nil and the reason it cannot. That is
+// the case if
+//
+//	- the defer is fn's only one and a statement of fn's body
+//	  itself, not nested in another statement, and no return
+//	  statement precedes it;
+//	- it defers a direct call of a function or concrete method;
+//	- the statements after it are assignments and a final return that
+//	  cannot panic, so the deferred call runs if and only if fn
+//	  returns normally.
+func simpleDefer(fn *ir.Func) (*ir.GoDeferStmt, string) {
+	defers := 0
+	ir.Visit(fn, func(n ir.Node) {
+		if n.Op() == ir.ODEFER {
+			defers++
+		}
+	})
+	switch {
+	case defers == 0:
+		return nil, ""
+	case defers > 1:
+		return nil, "more than one defer"
+	}
+	i := 0
+	for i < len(fn.Body) && fn.Body[i].Op() != ir.ODEFER {
+		i++
+	}
+	if i == len(fn.Body) {
+		return nil, "defer in a nested statement"
+	}
+	d := fn.Body[i].(*ir.GoDeferStmt)
+
+	// A goto could jump over the defer.
+	if ir.Any(fn, func(n ir.Node) bool { return n.Op() == ir.OGOTO || n.Op() == ir.OLABEL }) {
+		return nil, "defer in a function with labels"
+	}
+	for _, n := range fn.Body[:i] {
+		if ir.Any(n, func(n ir.Node) bool { return n.Op() == ir.ORETURN }) {
+			return nil, "return before defer"
+		}
+	}
+
+	// The deferred function and receiver are evaluated by the defer
+	// statement. Later expressions may indirect through the pointers
+	// it dereferences without risk of a nil pointer panic.
+	var args []ir.Node
+	switch call := d.Call; call.Op() {
+	case ir.OCALLFUNC:
+		call := call.(*ir.CallExpr)
+		if call.X.Op() != ir.ONAME || call.X.(*ir.Name).Class != ir.PFUNC || ir.IsIntrinsicCall(call) {
+			return nil, "defer of an indirect call"
+		}
+		args = call.Args
+	case ir.OCALLMETH:
+		call := call.(*ir.CallExpr)
+		args = append([]ir.Node{call.X.(*ir.SelectorExpr).X}, call.Args...)
+	default:
+		return nil, "defer of an indirect call"
+	}
+	derefs := make(map[*ir.Name]bool)
+	for _, arg := range args {
+		for {
+			if arg.Op() == ir.OADDR {
+				arg = arg.(*ir.AddrExpr).X
+			}
+			if arg.Op() != ir.ODOT {
+				break
+			}
+			arg = arg.(*ir.SelectorExpr).X
+		}
+		var x ir.Node
+		switch arg.Op() {
+		case ir.ODOTPTR:
+			x = arg.(*ir.SelectorExpr).X
+		case ir.ODEREF:
+			x = arg.(*ir.StarExpr).X
+		}
+		if x != nil && x.Op() == ir.ONAME {
+			if x := x.(*ir.Name); (x.Class == ir.PAUTO || x.Class == ir.PPARAM) && !ir.Reassigned(x) {
+				derefs[x] = true
+			}
+		}
+	}
+
+	rest := fn.Body[i+1:]
+	for j, n := range rest {
+		switch n.Op() {
+		case ir.ODCL:
+			continue
+		case ir.OAS:
+			n := n.(*ir.AssignStmt)
+			if cannotPanic(n.X, derefs) && (n.Y == nil || cannotPanic(n.Y, derefs)) {
+				continue
+			}
+		case ir.OASOP:
+			n := n.(*ir.AssignOpStmt)
+			if n.AsOp != ir.ODIV && n.AsOp != ir.OMOD && n.AsOp != ir.OLSH && n.AsOp != ir.ORSH &&
+				n.X.Type().IsInteger() && cannotPanic(n.X, derefs) && cannotPanic(n.Y, derefs) {
+				continue
+			}
+		case ir.ORETURN:
+			n := n.(*ir.ReturnStmt)
+			if j == len(rest)-1 && len(n.Init()) == 0 {
+				ok := true
+				for _, r := range n.Results {
+					ok = ok && cannotPanic(r, derefs)
+				}
+				if ok {
+					continue
+				}
+			}
+		}
+		return nil, "statement after defer may panic"
+	}
+	return d, ""
+}
+
+// cannotPanic reports whether evaluating n, or assigning to it, can
+// never panic, given that the pointers in derefs are not nil.
+func cannotPanic(n ir.Node, derefs map[*ir.Name]bool) bool {
+	if len(n.Init()) != 0 {
+		return false
+	}
+	scalar := func(t *types.Type) bool {
+		return t.IsInteger() || t.IsFloat() || t.IsComplex() || t.IsBoolean()
+	}
+	switch n.Op() {
+	case ir.ONAME, ir.OLITERAL, ir.ONIL:
+		return true
+	case ir.ODOT:
+		return cannotPanic(n.(*ir.SelectorExpr).X, derefs)
+	case ir.ODOTPTR:
+		x := n.(*ir.SelectorExpr).X
+		return x.Op() == ir.ONAME && derefs[x.(*ir.Name)]
+	case ir.ODEREF:
+		x := n.(*ir.StarExpr).X
+		return x.Op() == ir.ONAME && derefs[x.(*ir.Name)]
+	case ir.OLEN, ir.OCAP:
+		n := n.(*ir.UnaryExpr)
+		return !n.X.Type().IsMap() && !n.X.Type().IsChan() && cannotPanic(n.X, derefs)
+	case ir.ONEG, ir.OBITNOT, ir.ONOT, ir.OPLUS:
+		n := n.(*ir.UnaryExpr)
+		return scalar(n.X.Type()) && cannotPanic(n.X, derefs)
+	case ir.OCONV, ir.OCONVNOP:
+		n := n.(*ir.ConvExpr)
+		return scalar(n.Type()) && scalar(n.X.Type()) && cannotPanic(n.X, derefs)
+	case ir.OADD, ir.OSUB, ir.OMUL, ir.OOR, ir.OXOR, ir.OAND, ir.OANDNOT,
+		ir.OEQ, ir.ONE, ir.OLT, ir.OLE, ir.OGT, ir.OGE:
+		n := n.(*ir.BinaryExpr)
+		return scalar(n.X.Type()) && cannotPanic(n.X, derefs) && cannotPanic(n.Y, derefs)
+	case ir.OANDAND, ir.OOROR:
+		n := n.(*ir.LogicalExpr)
+		return cannotPanic(n.X, derefs) && cannotPanic(n.Y, derefs)
+	}
+	return false
+}
+
+// openDefer replaces the defer statement that simpleDefer found in an
+// inlined body by the evaluation of the deferred function's receiver
+// and arguments into temporaries, and returns the call to run at the
+// end of the body.
+func openDefer(body []ir.Node) ir.Node {
+	for i, n := range body {
+		if n.Op() != ir.ODEFER {
+			continue
+		}
+		d := n.(*ir.GoDeferStmt)
+		call := d.Call.(*ir.CallExpr)
+		init := d.Init()
+		temp := func(x ir.Node) ir.Node {
+			if x.Op() == ir.OLITERAL || x.Op() == ir.ONIL {
+				return x
+			}
+			tmp := typecheck.Temp(x.Type())
+			init.Append(typecheck.Stmt(ir.NewAssignStmt(d.Pos(), tmp, x)))
+			return tmp
+		}
+		if call.Op() == ir.OCALLMETH {
+			sel := call.X.(*ir.SelectorExpr)
+			sel.X = temp(sel.X)
+		}
+		for i, arg := range call.Args {
+			call.Args[i] = temp(arg)
+		}
+		body[i] = ir.NewBlockStmt(d.Pos(), init)
+		call.Use = ir.CallUseStmt
+		_, inline := deferCallCost(call)
+		call.NoInline = !inline
+		return call
+	}
+	return nil
+}
+
+// deferCallCost returns the cost CanInline charges for call, deferred
+// by the defer that simpleDefer found, and whether the call may be
+// inlined once mkinlcall makes it at the end of the inlined body.
+// A lock-guarded getter spends most of its budget on the lock, so the
+// deferred unlock is charged at most inlineDeferCallCost, and stays an
+// ordinary call if its callee costs more than that. Then, as with
+// inlCallCost, inlining the getter adds at most one call's worth of
+// nodes beyond the cost charged for it.
+func deferCallCost(call *ir.CallExpr) (cost int32, inline bool) {
+	var fn *ir.Func
+	switch call.Op() {
+	case ir.OCALLFUNC:
+		fn = inlCallee(call.X)
+	case ir.OCALLMETH:
+		fn = ir.MethodExprName(call.X).Func
+	}
+	if fn != nil && fn.Inl != nil && fn.Inl.Cost <= inlineDeferCallCost {
+		return fn.Inl.Cost, true
+	}
+	return inlineDeferCallCost, false
+}
+
 // inlcopylist (together with inlcopy) recursively copies a list of nodes, except
 // that it keeps the same ONAME, OTYPE, and OLITERAL nodes. It is used for copying
 // the body and dcls of an inlineable function.
@@ -522,9 +979,9 @@ func inlcopy(n ir.Node) ir.Node {
 func InlineCalls(fn *ir.Func) {
 	savefn := ir.CurFunc
 	ir.CurFunc = fn
-	maxCost := int32(inlineMaxBudget)
+	maxCost := inlineMaxBudget()
 	if isBigFunc(fn) {
-		maxCost = inlineBigFunctionMaxCost
+		maxCost = int32(base.Flag.InlineBigBudget)
 	}
 	// Map to keep track of functions that have been inlined at a particular
 	// call site, in order to stop inlining when we reach the beginning of a
@@ -649,6 +1106,11 @@ func inlnode(n ir.Node, maxCost int32, inlMap map[*ir.Func]bool, edit func(ir.No
 		}
 		if fn := inlCallee(call.X); fn != nil && fn.Inl != nil {
 			n = mkinlcall(call, fn, maxCost, inlMap, edit)
+		} else if fn := methodCallee(call.X, ir.CurFunc); fn != nil && fn.Inl != nil {
+			mcall := directMethodCall(call)
+			if res := mkinlcall(mcall, fn, maxCost, inlMap, edit); res != mcall {
+				n = res
+			}
 		}
 
 	case ir.OCALLMETH:
@@ -688,6 +1150,16 @@ func inlnode(n ir.Node, maxCost int32, inlMap map[*ir.Func]bool, edit func(ir.No
 // inlCallee takes a function-typed expression and returns the underlying function ONAME
 // that it refers to if statically known. Otherwise, it returns nil.
 func inlCallee(fn ir.Node) *ir.Func {
+	c := staticCallee(fn)
+	if c != nil && ir.StaticValue(fn).Op() == ir.OCLOSURE {
+		CanInline(c)
+	}
+	return c
+}
+
+// staticCallee returns the function called by a call to fn, if it is
+// statically known, and otherwise nil.
+func staticCallee(fn ir.Node) *ir.Func {
 	fn = ir.StaticValue(fn)
 	switch fn.Op() {
 	case ir.OMETHEXPR:
@@ -707,13 +1179,103 @@ func inlCallee(fn ir.Node) *ir.Func {
 		}
 	case ir.OCLOSURE:
 		fn := fn.(*ir.ClosureExpr)
-		c := fn.Func
-		CanInline(c)
-		return c
+		return fn.Func
 	}
 	return nil
 }
 
+// methodCallee returns the method called by a call to fn, if fn is a
+// method value x.M whose receiver x has the same value wherever it is
+// evaluated in curfn, or a method expression (*T).M of a method
+// declared on T. Otherwise, it returns nil. A call to fn can then be
+// replaced by directMethodCall.
+func methodCallee(fn ir.Node, curfn *ir.Func) *ir.Func {
+	fn = ir.StaticValue(fn)
+	switch fn.Op() {
+	case ir.OCALLPART:
+		fn := fn.(*ir.SelectorExpr)
+		n := ir.MethodExprName(fn)
+		if n == nil || !types.Identical(n.Type().Recv().Type, fn.X.Type()) || !fixedRecv(fn.X, curfn) {
+			return nil
+		}
+		return n.Func
+	case ir.OMETHEXPR:
+		fn := fn.(*ir.SelectorExpr)
+		n := ir.MethodExprName(fn)
+		if n == nil || !fn.X.Type().IsPtr() || !types.Identical(n.Type().Recv().Type, fn.X.Type().Elem()) {
+			return nil
+		}
+		return n.Func
+	}
+	return nil
+}
+
+// fixedRecv reports whether the receiver x of a method value x.M
+// has the same value wherever it is evaluated in curfn, so that a
+// call of the method value may evaluate x again instead of using
+// the receiver bound when the method value was created.
+func fixedRecv(x ir.Node, curfn *ir.Func) bool {
+	if x.Op() != ir.ONAME {
+		return false
+	}
+	name := x.(*ir.Name)
+	if name.Class != ir.PAUTO && name.Class != ir.PPARAM || name.Curfn != curfn || name.Addrtaken() {
+		return false
+	}
+	// The fields and elements of a struct or array receiver may be
+	// assigned individually, which Reassigned does not detect.
+	if t := name.Type(); t.IsStruct() || t.IsArray() || t.IsInterface() {
+		return false
+	}
+	if ir.Reassigned(name) {
+		return false
+	}
+	isName := func(x ir.Node) bool {
+		n, ok := x.(*ir.Name)
+		return ok && n.Canonical() == name
+	}
+	var updated func(n ir.Node) bool
+	updated = func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.OASOP:
+			return isName(n.(*ir.AssignOpStmt).X)
+		case ir.ORANGE:
+			n := n.(*ir.RangeStmt)
+			return isName(n.Key) || isName(n.Value)
+		case ir.OCLOSURE:
+			return ir.Any(n.(*ir.ClosureExpr).Func, updated)
+		}
+		return false
+	}
+	return !ir.Any(curfn, updated)
+}
+
+// directMethodCall returns a call of the method that call calls
+// through a method value or method expression, for which
+// methodCallee has returned the method.
+func directMethodCall(call *ir.CallExpr) *ir.CallExpr {
+	var mcall *ir.CallExpr
+	switch fn := ir.StaticValue(call.X).(*ir.SelectorExpr); fn.Op() {
+	case ir.OCALLPART:
+		dot := ir.NewSelectorExpr(call.Pos(), ir.ODOTMETH, fn.X, fn.Sel)
+		dot.Selection = fn.Selection
+		dot.SetType(fn.Selection.Type)
+		dot.SetTypecheck(1)
+		mcall = ir.NewCallExpr(call.Pos(), ir.OCALLMETH, dot, call.Args)
+	case ir.OMETHEXPR:
+		// Dereference the receiver, as the (*T).M wrapper would.
+		args := append([]ir.Node{typecheck.Expr(ir.NewStarExpr(call.Pos(), call.Args[0]))}, call.Args[1:]...)
+		mcall = ir.NewCallExpr(call.Pos(), ir.OCALLFUNC, call.X, args)
+	default:
+		base.Fatalf("directMethodCall: unexpected callee %v", fn)
+	}
+	mcall.IsDDD = call.IsDDD
+	mcall.Use = call.Use
+	mcall.SetType(call.Type())
+	mcall.SetTypecheck(1)
+	return mcall
+}
+
 func inlParam(t *types.Field, as ir.InitNode, inlvars map[*ir.Name]*ir.Name) ir.Node {
 	if t.Nname == nil {
 		return ir.BlankNode
@@ -752,9 +1314,18 @@ func mkinlcall(n *ir.CallExpr, fn *ir.Func, maxCost int32, inlMap map[*ir.Func]b
 		}
 		return n
 	}
+	budget := inlineMaxBudget()
+	if hint, ok := inlineHint(fn); ok && hint.Inline {
+		// The hint's budget replaces both budgets.
+		maxCost = hintBudget(hint)
+		budget = maxCost
+	}
 	if fn.Inl.Cost > maxCost {
 		// The inlined function body is too big. Typically we use this check to restrict
 		// inlining into very big functions.  See issue 26546 and 17566.
+		if base.Flag.LowerM > 1 {
+			fmt.Printf("%v: cannot inline %v into %v: cost %d exceeds budget %d for big function\n", ir.Line(n), fn, ir.FuncName(ir.CurFunc), fn.Inl.Cost, maxCost)
+		}
 		if logopt.Enabled() {
 			logopt.LogOpt(n.Pos(), "cannotInlineCall", "inline", ir.FuncName(ir.CurFunc),
 				fmt.Sprintf("cost %d of %s exceeds max large caller cost %d", fn.Inl.Cost, ir.PkgFuncName(fn), maxCost))
@@ -794,12 +1365,28 @@ func mkinlcall(n *ir.CallExpr, fn *ir.Func, maxCost int32, inlMap map[*ir.Func]b
 		typecheck.ImportedBody(fn)
 	}
 
+	if cost := fn.Inl.Cost + funcArgCost(n, fn); cost > maxCost || cost > budget {
+		// The function arguments at this call site will not be
+		// inlined, so the calls through them are not cheap.
+		if base.Flag.LowerM > 1 {
+			fmt.Printf("%v: cannot inline %v into %v: cost %d with its function arguments exceeds budget %d\n", ir.Line(n), fn, ir.FuncName(ir.CurFunc), cost, maxCost)
+		}
+		if logopt.Enabled() {
+			logopt.LogOpt(n.Pos(), "cannotInlineCall", "inline", ir.FuncName(ir.CurFunc),
+				fmt.Sprintf("cost %d of %s with its function arguments exceeds budget", cost, ir.PkgFuncName(fn)))
+		}
+		return n
+	}
+
 	// We have a function node, and it has an inlineable body.
 	if base.Flag.LowerM > 1 {
 		fmt.Printf("%v: inlining call to %v %v { %v }\n", ir.Line(n), fn.Sym(), fn.Type(), ir.Nodes(fn.Inl.Body))
 	} else if base.Flag.LowerM != 0 {
 		fmt.Printf("%v: inlining call to %v\n", ir.Line(n), fn)
 	}
+	if logopt.Enabled() {
+		logopt.LogOpt(n.Pos(), "inlineCall", "inline", ir.FuncName(ir.CurFunc), ir.PkgFuncName(fn))
+	}
 	if base.Flag.LowerM > 2 {
 		fmt.Printf("%v: Before inlining: %+v\n", ir.Line(n), n)
 	}
@@ -1009,6 +1596,9 @@ func mkinlcall(n *ir.CallExpr, fn *ir.Func, maxCost int32, inlMap map[*ir.Func]b
 
 	lab := ir.NewLabelStmt(base.Pos, retlabel)
 	body = append(body, lab)
+	if call := openDefer(body); call != nil {
+		body = append(body, call)
+	}
 
 	typecheck.Stmts(body)
 
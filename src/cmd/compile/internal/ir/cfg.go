@@ -18,6 +18,12 @@ var (
 	// Note: the flag smallframes can update this value.
 	MaxImplicitStackVarSize = int64(64 * 1024)
 
+	// maximum size of the backing array that we will allocate on the stack
+	// for make([]T, n) when n is not constant but has a small constant
+	// upper bound (for example n&63, or n of type uint8).
+	// The whole array is zeroed on every allocation, so this is kept small.
+	MaxBoundedStackSliceSize = int64(1024)
+
 	// MaxSmallArraySize is the maximum size of an array which is considered small.
 	// Small arrays will be initialized directly with a sequence of constant stores.
 	// Large arrays will be initialized by copying from a static temp.
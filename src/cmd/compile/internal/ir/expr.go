@@ -528,6 +528,13 @@ func (n *SelectorExpr) FuncName() *Name {
 	fn := NewNameAt(n.Selection.Pos, MethodSym(n.X.Type(), n.Sel))
 	fn.Class = PFUNC
 	fn.SetType(n.Type())
+	// Calls through fn are calls of the method itself,
+	// so they are pure if the method is.
+	if m, ok := n.Selection.Nname.(*Name); ok {
+		if m.Pragma()&Pure != 0 || m.Func != nil && m.Func.Pragma&Pure != 0 {
+			fn.SetPragma(Pure)
+		}
+	}
 	return fn
 }
 
@@ -820,22 +827,22 @@ FindRHS:
 		base.Fatalf("RHS is nil: %v", defn)
 	}
 
-	if reassigned(n) {
+	if Reassigned(n) {
 		return nil
 	}
 
 	return rhs
 }
 
-// reassigned takes an ONAME node, walks the function in which it is defined, and returns a boolean
+// Reassigned takes an ONAME node, walks the function in which it is defined, and returns a boolean
 // indicating whether the name has any assignments other than its declaration.
 // The second return value is the first such assignment encountered in the walk, if any. It is mostly
 // useful for -m output documenting the reason for inhibited optimizations.
 // NB: global variables are always considered to be re-assigned.
 // TODO: handle initial declaration not including an assignment and followed by a single assignment?
-func reassigned(name *Name) bool {
+func Reassigned(name *Name) bool {
 	if name.Op() != ONAME {
-		base.Fatalf("reassigned %v", name)
+		base.Fatalf("Reassigned %v", name)
 	}
 	// no way to reliably check for no-reassignment of globals, assume it can be
 	if name.Curfn == nil {
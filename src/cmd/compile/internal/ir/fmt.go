@@ -1107,7 +1107,7 @@ func dumpNodeHeader(w io.Writer, n Node) {
 		fmt.Fprintf(w, " %+v", n.Type())
 	}
 
-	if n.Pos().IsKnown() {
+	if _, noPos := w.(noPosWriter); !noPos && n.Pos().IsKnown() {
 		pfx := ""
 		switch n.Pos().IsStmt() {
 		case src.PosNotStmt:
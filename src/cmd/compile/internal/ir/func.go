@@ -8,6 +8,7 @@ import (
 	"cmd/compile/internal/base"
 	"cmd/compile/internal/types"
 	"cmd/internal/obj"
+	"cmd/internal/objabi"
 	"cmd/internal/src"
 )
 
@@ -97,6 +98,14 @@ type Func struct {
 
 	Inl *Inline
 
+	// Variant and Dispatch are set for functions marked
+	// //go:multiversion. Variant names the copy of the function
+	// that is compiled for newer CPUs, and Dispatch is the
+	// statement at the start of the body that calls it.
+	// See ssagen.InitMultiversion.
+	Variant  *Name
+	Dispatch *IfStmt
+
 	// Closgen tracks how many closures have been generated within
 	// this function. Used by closurename for creating unique
 	// function names.
@@ -172,7 +181,7 @@ const (
 	funcNilCheckDisabled         // disable nil checks when compiling this function
 	funcInlinabilityChecked      // inliner has already determined whether the function is inlinable
 	funcExportInline             // include inline body in export data
-	funcInstrumentBody           // add race/msan instrumentation during SSA construction
+	funcInstrumentBody           // add race/msan/asan instrumentation during SSA construction
 	funcOpenCodedDeferDisallowed // can't do open-coded defers
 	funcClosureCalled            // closure is only immediately called
 )
@@ -209,7 +218,7 @@ func (f *Func) SetOpenCodedDeferDisallowed(b bool) { f.flags.set(funcOpenCodedDe
 func (f *Func) SetClosureCalled(b bool)            { f.flags.set(funcClosureCalled, b) }
 
 func (f *Func) SetWBPos(pos src.XPos) {
-	if base.Debug.WB != 0 {
+	if base.Debug.WB == 1 {
 		base.WarnfAt(pos, "write barrier")
 	}
 	if !f.WBPos.IsKnown() {
@@ -252,6 +261,29 @@ func FuncSymName(s *types.Sym) string {
 	return s.Name + "·f"
 }
 
+// ABIBridgeSym returns the symbol of the wrapper through which function
+// values of the top-level function n call it, or nil if there is none.
+// A function needs a bridge when a //go:registerparams or
+// //go:noregisterparams directive gives it a different calling
+// convention than the one used to call function values.
+// The bridge is generated in the package that declares n.
+func ABIBridgeSym(n *Name) *types.Sym {
+	pragma := n.Pragma()
+	if n.Func != nil {
+		pragma |= n.Func.Pragma
+	}
+	var differs bool
+	if objabi.Experiment.RegabiArgs {
+		differs = pragma&NoRegisterParams != 0
+	} else {
+		differs = pragma&RegisterParams != 0
+	}
+	if !differs || n.Type() == nil || n.Type().NumRecvs() != 0 {
+		return nil
+	}
+	return n.Sym().Pkg.Lookup(n.Sym().Name + "·abibridge")
+}
+
 // MarkFunc marks a node as a function.
 func MarkFunc(n *Name) {
 	if n.Op() != ONAME || n.Class != Pxxx {
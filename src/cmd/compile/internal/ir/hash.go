@@ -0,0 +1,41 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ir
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+// HashFunc returns a hash of the typed IR of fn: its name, signature,
+// declarations and body. It is meant to be computed right after
+// typechecking, so that an unchanged function has the same hash across
+// builds of its package.
+//
+// The hash does not cover source positions, so that adding lines above
+// fn leaves it unchanged, nor the bodies of the functions that fn
+// inlines, the compiler configuration, or the declarations fn refers to
+// beyond the names of their types. A cache of compiled functions keyed
+// by it must account for those separately.
+func HashFunc(fn *Func) [sha256.Size]byte {
+	h := sha256.New()
+	fmt.Fprintf(h, "%v %+v\n", fn.Sym(), fn.Type())
+	dcl := make(Nodes, len(fn.Dcl))
+	for i, n := range fn.Dcl {
+		dcl[i] = n
+	}
+	FDumpList(noPosWriter{h}, "dcl", dcl)
+	FDumpList(noPosWriter{h}, "body", fn.Body)
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	return sum
+}
+
+// A noPosWriter is a writer to which FDumpList dumps nodes without
+// their source positions.
+type noPosWriter struct {
+	io.Writer
+}
@@ -436,7 +436,7 @@ func (s NameSet) Sorted(less func(*Name, *Name) bool) []*Name {
 	return res
 }
 
-type PragmaFlag int16
+type PragmaFlag uint32
 
 const (
 	// Func pragmas.
@@ -448,6 +448,7 @@ const (
 	NoCheckPtr                // func should not be instrumented by checkptr
 	CgoUnsafeArgs             // treat a pointer to one arg as a pointer to them all
 	UintptrEscapes            // pointers converted to uintptr escape
+	Multiversion              // func is also compiled for newer CPUs
 
 	// Runtime-only func pragmas.
 	// See ../../../../runtime/README.md for detailed descriptions.
@@ -462,8 +463,10 @@ const (
 	// Go command pragmas
 	GoBuildPragma
 
-	RegisterParams // TODO(register args) remove after register abi is working
-
+	RegisterParams   // TODO(register args) remove after register abi is working
+	NoRegisterParams // func uses the stack-based calling convention even when register ABI is the default
+	NoCheckBounds    // func omits bounds and nil checks; its indexing has been audited
+	Pure             // func results depend only on its arguments, and it has no side effects
 )
 
 func AsNode(n types.Object) Node {
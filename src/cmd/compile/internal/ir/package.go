@@ -4,7 +4,10 @@
 
 package ir
 
-import "cmd/compile/internal/types"
+import (
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+)
 
 // A Package holds information about the package being compiled.
 type Package struct {
@@ -24,15 +27,29 @@ type Package struct {
 	// Assembly function declarations.
 	Asms []*Name
 
+	// Types named by //go:asmoffsets lines.
+	AsmTypes []*Name
+
 	// Cgo directives.
 	CgoPragmas [][]string
 
 	// Variables with //go:embed lines.
 	Embeds []*Name
 
+	// Functions with //go:wasmexport lines.
+	WasmExports []*WasmExport
+
 	// Exported (or re-exported) symbols.
 	Exports []*Name
 
 	// Map from function names of stencils to already-created stencils.
 	Stencils map[*types.Sym]*Func
 }
+
+// A WasmExport is a function exported from the WebAssembly module
+// under Name by a //go:wasmexport directive at Pos.
+type WasmExport struct {
+	Pos  src.XPos
+	Name string
+	Func *Func
+}
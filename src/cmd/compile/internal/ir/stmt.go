@@ -247,10 +247,11 @@ func NewGoDeferStmt(pos src.XPos, op Op, call Node) *GoDeferStmt {
 // A IfStmt is a return statement: if Init; Cond { Then } else { Else }.
 type IfStmt struct {
 	miniStmt
-	Cond   Node
-	Body   Nodes
-	Else   Nodes
-	Likely bool // code layout hint
+	Cond     Node
+	Body     Nodes
+	Else     Nodes
+	Likely   bool // code layout hint
+	Unlikely bool // code layout hint
 }
 
 func NewIfStmt(pos src.XPos, cond Node, body, els []Node) *IfStmt {
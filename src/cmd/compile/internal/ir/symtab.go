@@ -15,6 +15,8 @@ var Syms struct {
 	AssertE2I2      *obj.LSym
 	AssertI2I       *obj.LSym
 	AssertI2I2      *obj.LSym
+	Asanread        *obj.LSym
+	Asanwrite       *obj.LSym
 	Deferproc       *obj.LSym
 	DeferprocStack  *obj.LSym
 	Deferreturn     *obj.LSym
@@ -39,18 +41,20 @@ var Syms struct {
 	Racewrite       *obj.LSym
 	Racewriterange  *obj.LSym
 	// Wasm
-	SigPanic        *obj.LSym
-	Staticuint64s   *obj.LSym
-	Typedmemclr     *obj.LSym
-	Typedmemmove    *obj.LSym
-	Udiv            *obj.LSym
-	WriteBarrier    *obj.LSym
-	Zerobase        *obj.LSym
-	ARM64HasATOMICS *obj.LSym
-	ARMHasVFPv4     *obj.LSym
-	X86HasFMA       *obj.LSym
-	X86HasPOPCNT    *obj.LSym
-	X86HasSSE41     *obj.LSym
+	SigPanic          *obj.LSym
+	StackProtectFail  *obj.LSym
+	StackProtectGuard *obj.LSym
+	Staticuint64s     *obj.LSym
+	Typedmemclr       *obj.LSym
+	Typedmemmove      *obj.LSym
+	Udiv              *obj.LSym
+	WriteBarrier      *obj.LSym
+	Zerobase          *obj.LSym
+	ARM64HasATOMICS   *obj.LSym
+	ARMHasVFPv4       *obj.LSym
+	X86HasFMA         *obj.LSym
+	X86HasPOPCNT      *obj.LSym
+	X86HasSSE41       *obj.LSym
 	// Wasm
 	WasmDiv *obj.LSym
 	// Wasm
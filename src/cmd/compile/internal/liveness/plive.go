@@ -17,6 +17,7 @@ package liveness
 import (
 	"crypto/md5"
 	"crypto/sha1"
+	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -1088,6 +1089,78 @@ func (lv *liveness) showlive(v *ssa.Value, live bitvec.BitVec) {
 	base.WarnfAt(pos, s)
 }
 
+// dumpStackMaps prints, for -live=json, one JSON object per safe
+// point of the function to standard output: the index of its stack
+// map and the variables live there, each with the pointer slots it
+// occupies in the args or locals bitmap of that stack map.
+func (lv *liveness) dumpStackMaps() {
+	type liveVar struct {
+		Name   string  `json:"name"`
+		Type   string  `json:"type"`
+		Offset int64   `json:"offset"`
+		Bitmap string  `json:"bitmap"`
+		Slots  []int32 `json:"slots"`
+	}
+	vars := make([]liveVar, len(lv.vars))
+	for i, n := range lv.vars {
+		v := liveVar{Name: n.Sym().Name, Type: n.Type().String(), Offset: n.FrameOffset()}
+		// Match the slot numbering of pointerMap.
+		off := n.FrameOffset() + lv.stkptrsize
+		v.Bitmap = "locals"
+		if n.Class != ir.PAUTO && !n.IsOutputParamInRegisters() {
+			off = n.FrameOffset()
+			v.Bitmap = "args"
+		}
+		bv := bitvec.New(int32(types.PtrDataSize(n.Type()) / int64(types.PtrSize)))
+		typebits.Set(n.Type(), 0, bv)
+		for j := bv.Next(0); j >= 0; j = bv.Next(j + 1) {
+			v.Slots = append(v.Slots, int32(off/int64(types.PtrSize))+j)
+		}
+		vars[i] = v
+	}
+
+	dump := func(v *ssa.Value, index int) {
+		pos := lv.fn.Nname.Pos()
+		call := ""
+		if v != nil {
+			pos = v.Pos
+			call = "indirect"
+			if sym, ok := v.Aux.(*ssa.AuxCall); ok && sym.Fn != nil {
+				call = sym.Fn.Name
+				if strings.HasPrefix(call, `"".`) {
+					call = strings.TrimPrefix(base.Ctxt.Pkgpath+".", ".") + call[len(`"".`):]
+				}
+			}
+		}
+		live := []liveVar{}
+		for j := range lv.vars {
+			if lv.stackMaps[index].Get(int32(j)) {
+				live = append(live, vars[j])
+			}
+		}
+		b, err := json.Marshal(struct {
+			Func     string    `json:"func"`
+			Pos      string    `json:"pos"`
+			Call     string    `json:"call,omitempty"`
+			StackMap int       `json:"stackmap"`
+			Live     []liveVar `json:"live"`
+		}{ir.PkgFuncName(lv.fn), base.FmtPos(pos), call, index, live})
+		if err != nil {
+			base.Fatalf("%v", err)
+		}
+		fmt.Printf("%s\n", b)
+	}
+
+	dump(nil, 0)
+	for _, b := range lv.f.Blocks {
+		for _, v := range b.Values {
+			if idx := lv.livenessMap.Get(v); idx.StackMapValid() {
+				dump(v, idx.StackMapIndex)
+			}
+		}
+	}
+}
+
 func (lv *liveness) printbvec(printed bool, name string, live bitvec.BitVec) bool {
 	if live.IsEmpty() {
 		return printed
@@ -1333,6 +1406,9 @@ func Compute(curfn *ir.Func, f *ssa.Func, stkptrsize int64, pp *objw.Progs) Map
 	if base.Flag.Live >= 2 {
 		lv.printDebug()
 	}
+	if base.Flag.Live == base.LiveJSON {
+		lv.dumpStackMaps()
+	}
 
 	// Update the function cache.
 	{
@@ -1404,7 +1480,7 @@ func (lv *liveness) emitStackObjects() *obj.LSym {
 		off = objw.SymPtr(x, off, reflectdata.TypeLinksym(v.Type()), 0)
 	}
 
-	if base.Flag.Live != 0 {
+	if base.Flag.Live > 0 {
 		for _, v := range vars {
 			base.WarnfAt(v.Pos(), "stack object %v %v", v, v.Type())
 		}
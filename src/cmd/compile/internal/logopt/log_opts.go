@@ -333,7 +333,7 @@ func NewLoggedOpt(pos src.XPos, what, pass, funcName string, args ...interface{}
 // Pos is the source position (including inlining), what is the message, pass is which pass created the message,
 // funcName is the name of the function
 func LogOpt(pos src.XPos, what, pass, funcName string, args ...interface{}) {
-	if Format == None {
+	if !Enabled() {
 		return
 	}
 	lo := NewLoggedOpt(pos, what, pass, funcName, args...)
@@ -347,7 +347,7 @@ func LogOpt(pos src.XPos, what, pass, funcName string, args ...interface{}) {
 func Enabled() bool {
 	switch Format {
 	case None:
-		return false
+		return remarksDest != ""
 	case Json0:
 		return true
 	}
@@ -413,11 +413,14 @@ func uprootedPath(filename string) string {
 
 // FlushLoggedOpts flushes all the accumulated optimization log entries.
 func FlushLoggedOpts(ctxt *obj.Link, slashPkgPath string) {
-	if Format == None {
+	if !Enabled() {
 		return
 	}
 
 	sort.Stable(byPos{ctxt, loggedOpts}) // Stable is necessary to preserve the per-function order, which is repeatable.
+	if remarksDest != "" {
+		writeRemarks(ctxt)
+	}
 	switch Format {
 
 	case Json0: // LSP 3.15
@@ -223,6 +223,72 @@ func s15a8(x *[15]int64) [15]int64 {
 			`{"location":{"uri":"file://tmpdir/file.go","range":{"start":{"line":9,"character":3},"end":{"line":9,"character":3}}},"message":"escflow:    flow: ~r2 = ~R0:"},`+
 			`{"location":{"uri":"file://tmpdir/file.go","range":{"start":{"line":9,"character":3},"end":{"line":9,"character":3}}},"message":"escflow:      from return (*int)(~R0) (return)"}]}`)
 	})
+
+	t.Run("Remarks", func(t *testing.T) {
+		const remarksCode = `package x
+
+type T struct{ p *int }
+
+func (T) M() {}
+
+type I interface{ M() }
+
+var g int
+var sink *T
+
+func add(x int) int { return x + 1 }
+
+func F(s []int) int {
+	var i I = T{}
+	i.M()
+	t := new(T)
+	t.p = &g
+	sink = t
+	p := new(int)
+	n := *p
+	for j := 0; j < len(s); j++ {
+		n += s[j]
+	}
+	return add(n)
+}
+`
+		src := filepath.Join(dir, "remarks.go")
+		if err := ioutil.WriteFile(src, []byte(remarksCode), 0644); err != nil {
+			t.Fatal(err)
+		}
+		outfile := filepath.Join(dir, "remarks.o")
+
+		_, err := testLogOptDir(t, dir, "-d=remarks=remarks.json", src, outfile)
+		if err != nil {
+			t.Fatal("-d=remarks=remarks.json should have succeeded")
+		}
+		logged, err := ioutil.ReadFile(filepath.Join(dir, "remarks.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		slogged := normalize(logged, dir, "tmpdir")
+		t.Logf("%s", slogged)
+		want(t, slogged, `{"kind":"passed","pass":"devirtualize","name":"devirtualizeCall","file":"tmpdir/remarks.go","line":16,"column":5,"function":"F","message":"T.M"}`)
+		want(t, slogged, `{"kind":"passed","pass":"writebarrier","name":"writeBarrierElided","file":"tmpdir/remarks.go","line":18,"column":6,"function":"F","message":"non-heap pointer stored into zeroed memory"}`)
+		want(t, slogged, `{"kind":"passed","pass":"escape","name":"stackAlloc","file":"tmpdir/remarks.go","line":20,"column":10,"function":"F","message":"new(int) does not escape"}`)
+		want(t, slogged, `{"kind":"passed","pass":"prove","name":"removedBoundsCheck","file":"tmpdir/remarks.go","line":23,"column":9,"function":"F","message":"IsInBounds"}`)
+		want(t, slogged, `{"kind":"passed","pass":"inline","name":"inlineCall","file":"tmpdir/remarks.go","line":25,"column":12,"function":"F","message":"x.add"}`)
+
+		_, err = testLogOptDir(t, dir, "-d=remarks=remarks.yaml", src, outfile)
+		if err != nil {
+			t.Fatal("-d=remarks=remarks.yaml should have succeeded")
+		}
+		logged, err = ioutil.ReadFile(filepath.Join(dir, "remarks.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		slogged = normalize(logged, dir, "tmpdir")
+		t.Logf("%s", slogged)
+		want(t, slogged, "--- !Passed\nPass: inline\nName: inlineCall\n"+
+			`DebugLoc: { File: "tmpdir/remarks.go", Line: 25, Column: 12 }`+"\n"+
+			`Function: "F"`+"\n"+
+			`Message: "x.add"`+"\n...\n")
+	})
 }
 
 func testLogOpt(t *testing.T, flag, src, outfile string) (string, error) {
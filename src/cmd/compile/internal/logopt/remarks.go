@@ -0,0 +1,196 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logopt
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"cmd/internal/obj"
+	"cmd/internal/src"
+)
+
+// This implements the -d=remarks=<file> option of the Go compiler,
+// which writes the logged optimizations of a package to a single file,
+// as a stream of remarks sorted by source position, in the manner of
+// LLVM's optimization records. Unlike the -json output, it is meant to
+// be read in one piece, for instance by an editor that overlays the
+// remarks on the source.
+//
+// If the file name ends in ".yaml" or ".yml", each remark is a YAML
+// document tagged with its kind:
+//
+//	--- !Passed
+//	Pass: inline
+//	Name: inlineCall
+//	DebugLoc: { File: "/home/gopher/p/p.go", Line: 12, Column: 3 }
+//	Function: "F"
+//	Message: "example.com/p.g"
+//	...
+//
+// Otherwise each remark is a JSON object on a line of its own, with the
+// same fields in lower case:
+//
+//	{"kind":"passed","pass":"inline","name":"inlineCall","file":"/home/gopher/p/p.go",
+//	 "line":12,"column":3,"function":"F","message":"example.com/p.g"}
+//
+// Kind is "passed" for an optimization that was performed, "missed" for
+// one that was not, and "analysis" for other information. If the remark
+// is about code inlined at the position, InlinedAt lists the inlined
+// positions, from outermost to innermost. Explanation lists the steps
+// explaining a remark, such as the flow by which a value escapes.
+
+var remarksDest string
+
+// LogRemarksOption sets the file to which -d=remarks writes the
+// optimization remarks.
+func LogRemarksOption(file string) {
+	remarksDest = file
+}
+
+// A Remark is an optimization remark, as written by -d=remarks.
+type Remark struct {
+	Kind string `json:"kind"`
+	Pass string `json:"pass"`
+	Name string `json:"name"`
+	RemarkLoc
+	Function    string           `json:"function,omitempty"`
+	Message     string           `json:"message,omitempty"`
+	InlinedAt   []RemarkLoc      `json:"inlinedAt,omitempty"`
+	Explanation []RemarkLocation `json:"explanation,omitempty"`
+}
+
+// A RemarkLoc is a source position of a Remark.
+type RemarkLoc struct {
+	File   string `json:"file"`
+	Line   uint   `json:"line"`
+	Column uint   `json:"column"`
+}
+
+// A RemarkLocation is a step of the explanation of a Remark.
+type RemarkLocation struct {
+	RemarkLoc
+	Message string `json:"message"`
+}
+
+// remarkKinds gives the kind of the remarks by name; the default is
+// "analysis".
+var remarkKinds = map[string]string{
+	"inlineCall":         "passed",
+	"devirtualizeCall":   "passed",
+	"removedBoundsCheck": "passed",
+	"stackAlloc":         "passed",
+	"writeBarrierElided": "passed",
+	"cannotInlineCall":   "missed",
+	"isInBounds":         "missed",
+	"isSliceInBounds":    "missed",
+	"escape":             "missed",
+	"escapes":            "missed",
+	"nilcheck":           "missed",
+}
+
+func newRemarkLoc(p src.Pos) RemarkLoc {
+	return RemarkLoc{File: uprootedPath(p.Filename()), Line: p.Line(), Column: p.Col()}
+}
+
+// remark converts x to a Remark.
+func (x *LoggedOpt) remark(ctxt *obj.Link, posTmp []src.Pos) ([]src.Pos, Remark) {
+	posTmp, p0 := x.parsePos(ctxt, posTmp)
+	r := Remark{
+		Kind:     remarkKinds[x.what],
+		Pass:     x.compilerPass,
+		Name:     x.what,
+		Function: x.functionName,
+	}
+	if r.Kind == "" {
+		r.Kind = "analysis"
+	}
+	r.RemarkLoc = newRemarkLoc(p0)
+	for _, p := range posTmp[1:] {
+		r.InlinedAt = append(r.InlinedAt, newRemarkLoc(p))
+	}
+	if len(x.target) > 0 {
+		r.Message = fmt.Sprint(x.target[0])
+	}
+	if len(x.target) > 1 {
+		if y, ok := x.target[1].([]*LoggedOpt); ok {
+			for _, z := range y {
+				var p src.Pos
+				posTmp, p = z.parsePos(ctxt, posTmp)
+				msg := z.what
+				if len(z.target) > 0 {
+					msg = msg + ": " + fmt.Sprint(z.target[0])
+				}
+				r.Explanation = append(r.Explanation, RemarkLocation{newRemarkLoc(p), msg})
+			}
+		}
+	}
+	return posTmp, r
+}
+
+// writeRemarks writes the logged optimizations to the file named by
+// -d=remarks. They must already be sorted.
+func writeRemarks(ctxt *obj.Link) {
+	f, err := os.Create(remarksDest)
+	if err != nil {
+		log.Fatalf("Could not create file %s for optimization remarks, %v", remarksDest, err)
+	}
+	w := bufio.NewWriter(f)
+	yaml := strings.HasSuffix(remarksDest, ".yaml") || strings.HasSuffix(remarksDest, ".yml")
+	enc := json.NewEncoder(w)
+	var posTmp []src.Pos
+	for _, x := range loggedOpts {
+		var r Remark
+		posTmp, r = x.remark(ctxt, posTmp)
+		if yaml {
+			r.writeYAML(w)
+		} else if err := enc.Encode(r); err != nil {
+			log.Fatalf("Could not write optimization remarks, %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("Could not write optimization remarks, %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("Could not write optimization remarks, %v", err)
+	}
+}
+
+// writeYAML writes r as a YAML document. Strings are written as
+// double-quoted scalars, whose escapes are a superset of Go's.
+func (r *Remark) writeYAML(w io.Writer) {
+	loc := func(l RemarkLoc) string {
+		return fmt.Sprintf("{ File: %s, Line: %d, Column: %d }", strconv.Quote(l.File), l.Line, l.Column)
+	}
+	fmt.Fprintf(w, "--- !%s%s\n", strings.ToUpper(r.Kind[:1]), r.Kind[1:])
+	fmt.Fprintf(w, "Pass: %s\n", r.Pass)
+	fmt.Fprintf(w, "Name: %s\n", r.Name)
+	fmt.Fprintf(w, "DebugLoc: %s\n", loc(r.RemarkLoc))
+	if r.Function != "" {
+		fmt.Fprintf(w, "Function: %s\n", strconv.Quote(r.Function))
+	}
+	if r.Message != "" {
+		fmt.Fprintf(w, "Message: %s\n", strconv.Quote(r.Message))
+	}
+	if len(r.InlinedAt) > 0 {
+		fmt.Fprintf(w, "InlinedAt:\n")
+		for _, l := range r.InlinedAt {
+			fmt.Fprintf(w, "  - %s\n", loc(l))
+		}
+	}
+	if len(r.Explanation) > 0 {
+		fmt.Fprintf(w, "Explanation:\n")
+		for _, l := range r.Explanation {
+			fmt.Fprintf(w, "  - { DebugLoc: %s, Message: %s }\n", loc(l.RemarkLoc), strconv.Quote(l.Message))
+		}
+	}
+	fmt.Fprintf(w, "...\n")
+}
@@ -86,10 +86,16 @@ func (g *irgen) funcDecl(out *ir.Nodes, decl *syntax.FuncDecl) {
 	fn.Nname.Func = fn
 	fn.Nname.Defn = fn
 
+	if p, ok := decl.Pragma.(*pragmas); ok {
+		funcWasmExport(g.makeXPos, fn, decl, p)
+	}
 	fn.Pragma = g.pragmaFlags(decl.Pragma, funcPragmas)
 	if fn.Pragma&ir.Systemstack != 0 && fn.Pragma&ir.Nosplit != 0 {
 		base.ErrorfAt(fn.Pos(), "go:nosplit and go:systemstack cannot be combined")
 	}
+	if fn.Pragma&ir.Pure != 0 && len(decl.Type.ResultList) == 0 {
+		base.ErrorfAt(fn.Pos(), "go:pure function must have results")
+	}
 
 	if decl.Name.Value == "init" && decl.Recv == nil {
 		g.target.Inits = append(g.target.Inits, fn)
@@ -126,6 +132,11 @@ func (g *irgen) typeDecl(out *ir.Nodes, decl *syntax.TypeDecl) {
 		ntyp.Vargen = typecheck.TypeGen
 	}
 
+	var checks []types.LayoutCheck
+	if p, ok := decl.Pragma.(*pragmas); ok {
+		checks = layoutChecks(g.makeXPos, p)
+		typeCgoLayout(g.makeXPos, name, p)
+	}
 	pragmas := g.pragmaFlags(decl.Pragma, typePragmas)
 	name.SetPragma(pragmas) // TODO(mdempsky): Is this still needed?
 
@@ -161,6 +172,13 @@ func (g *irgen) typeDecl(out *ir.Nodes, decl *syntax.TypeDecl) {
 		ntyp.SetHasTParam(true)
 	}
 	types.ResumeCheckSize()
+	if checks != nil {
+		if len(decl.TParamList) > 0 {
+			base.ErrorfAt(checks[0].Pos, "go:layoutcheck cannot apply to generic type")
+		} else {
+			types.AddLayoutChecks(ntyp, checks)
+		}
+	}
 
 	if otyp, ok := otyp.(*types2.Named); ok && otyp.NumMethods() != 0 {
 		methods := make([]*types.Field, otyp.NumMethods())
@@ -244,4 +262,19 @@ func (g *irgen) reportUnused(pragma *pragmas) {
 			base.ErrorfAt(g.makeXPos(e.Pos), "misplaced go:embed directive")
 		}
 	}
+	if pragma.EmbedCompress.IsKnown() {
+		base.ErrorfAt(g.makeXPos(pragma.EmbedCompress), "misplaced go:embedcompress directive")
+	}
+	if pragma.Branch != nil {
+		base.ErrorfAt(g.makeXPos(pragma.Branch.Pos), "misplaced compiler directive")
+	}
+	if pragma.WasmExport != nil {
+		base.ErrorfAt(g.makeXPos(pragma.WasmExport.Pos), "misplaced go:wasmexport directive")
+	}
+	if len(pragma.LayoutChecks) > 0 {
+		base.ErrorfAt(g.makeXPos(pragma.LayoutChecks[0].Pos), "misplaced go:layoutcheck directive")
+	}
+	if pragma.CgoLayout != nil {
+		base.ErrorfAt(g.makeXPos(pragma.CgoLayout.Pos), "misplaced go:cgolayout directive")
+	}
 }
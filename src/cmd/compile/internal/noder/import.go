@@ -116,6 +116,8 @@ func openPackage(path string) (*os.File, error) {
 			suffix = "_race"
 		} else if base.Flag.MSan {
 			suffix = "_msan"
+		} else if base.Flag.ASan {
+			suffix = "_asan"
 		}
 
 		if file, err := os.Open(fmt.Sprintf("%s/pkg/%s_%s%s/%s.a", objabi.GOROOT, objabi.GOOS, objabi.GOARCH, suffix, path)); err == nil {
@@ -374,6 +376,11 @@ func checkImportPath(path string, allowSpace bool) error {
 }
 
 func pkgnotused(lineno src.XPos, path string, name string) {
+	if base.SyntaxErrors() != 0 {
+		// The package may have been used in a function body
+		// dropped because of syntax errors.
+		return
+	}
 	// If the package was imported with a name other than the final
 	// import path element, show it explicitly in the error message.
 	// Note that this handles both renamed imports and imports of
@@ -385,9 +392,38 @@ func pkgnotused(lineno src.XPos, path string, name string) {
 		elem = elem[i+1:]
 	}
 	if name == "" || elem == name {
-		base.ErrorfAt(lineno, "imported and not used: %q", path)
+		base.ErrorfAtFix(lineno, base.CodeUnused, removeImportFix(lineno, path), "imported and not used: %q", path)
 	} else {
-		base.ErrorfAt(lineno, "imported and not used: %q as %s", path, name)
+		base.ErrorfAtFix(lineno, base.CodeUnused, removeImportFix(lineno, path), "imported and not used: %q as %s", path, name)
+	}
+}
+
+// removeImportFix returns a fix that deletes the line of the import of
+// path whose spec starts at pos, or nil if the line holds more than
+// the import, possibly followed by a line comment.
+func removeImportFix(pos src.XPos, path string) *base.Fix {
+	line, ok := base.SourceAt(pos.AtColumn1())
+	if !ok {
+		return nil
+	}
+	spec, _ := base.SourceAt(pos)
+	if prefix := strings.TrimSpace(line[:len(line)-len(spec)]); prefix != "" && prefix != "import" {
+		return nil
+	}
+	i := strings.IndexAny(spec, "\"`")
+	if i < 0 || strings.ContainsAny(spec[:i], ";()") {
+		return nil
+	}
+	j := strings.IndexByte(spec[i+1:], spec[i])
+	if j < 0 {
+		return nil
+	}
+	if rest := strings.TrimSpace(spec[i+1+j+1:]); rest != "" && !strings.HasPrefix(rest, "//") {
+		return nil
+	}
+	return &base.Fix{
+		Msg:   "remove import " + strconv.Quote(path),
+		Edits: []base.Edit{{Pos: pos.AtColumn1(), Len: len(line) + 1}},
 	}
 }
 
@@ -446,10 +482,11 @@ func clearImports() {
 }
 
 // CheckDotImports reports errors for any unused dot imports.
+// Like other unused imports, they are not reported after syntax errors.
 func CheckDotImports() {
 	for _, pack := range dotImports {
-		if !pack.Used {
-			base.ErrorfAt(pack.Pos(), "imported and not used: %q", pack.Pkg.Path)
+		if !pack.Used && base.SyntaxErrors() == 0 {
+			base.ErrorfAtFix(pack.Pos(), base.CodeUnused, removeImportFix(pack.Pos(), pack.Pkg.Path), "imported and not used: %q", pack.Pkg.Path)
 		}
 	}
 
@@ -469,12 +506,7 @@ func importDot(pack *ir.PkgName) {
 	}
 
 	opkg := pack.Pkg
-	for _, s := range opkg.Syms {
-		if s.Def == nil {
-			if _, ok := typecheck.DeclImporter[s]; !ok {
-				continue
-			}
-		}
+	for _, s := range typecheck.ImportedSyms(opkg) {
 		if !types.IsExported(s.Name) || strings.ContainsRune(s.Name, 0xb7) { // 0xb7 = center dot
 			continue
 		}
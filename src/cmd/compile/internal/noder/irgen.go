@@ -7,6 +7,7 @@ package noder
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"cmd/compile/internal/base"
 	"cmd/compile/internal/dwarfgen"
@@ -21,10 +22,6 @@ import (
 // check2 type checks a Go package using types2, and then generates IR
 // using the results.
 func check2(noders []*noder) {
-	if base.SyntaxErrors() != 0 {
-		base.ErrorExit()
-	}
-
 	// setup and syntax error reporting
 	var m posMap
 	files := make([]*syntax.File, len(noders))
@@ -41,6 +38,9 @@ func check2(noders []*noder) {
 		CompilerErrorMessages: true, // use error strings matching existing compiler errors
 		Error: func(err error) {
 			terr := err.(types2.Error)
+			if base.SyntaxErrors() != 0 && followsSyntaxErrors(terr.Msg) {
+				return
+			}
 			base.ErrorfAt(m.makeXPos(terr.Pos), "%s", terr.Msg)
 		},
 		Importer: &gcimports{
@@ -83,6 +83,13 @@ func check2(noders []*noder) {
 	}
 }
 
+// followsSyntaxErrors reports whether the types2 error msg may be
+// caused by dropBrokenBodies removing function bodies with syntax
+// errors, and so should not be reported.
+func followsSyntaxErrors(msg string) bool {
+	return msg == "missing function body" || strings.Contains(msg, "imported and not used") || strings.Contains(msg, "imported but not used")
+}
+
 type irgen struct {
 	target *ir.Package
 	self   *types2.Package
@@ -196,6 +203,9 @@ Outer:
 		}
 	}
 	g.target.Decls = g.target.Decls[:j]
+
+	checkPendingLayouts()
+	resolveAsmOffsets()
 }
 
 func (g *irgen) unhandled(what string, p poser) {
@@ -29,8 +29,12 @@ const (
 		ir.Noinline |
 		ir.NoCheckPtr |
 		ir.RegisterParams | // TODO(register args) remove after register abi is working
+		ir.NoRegisterParams |
 		ir.CgoUnsafeArgs |
 		ir.UintptrEscapes |
+		ir.Multiversion |
+		ir.NoCheckBounds |
+		ir.Pure |
 		ir.Systemstack |
 		ir.Nowritebarrier |
 		ir.Nowritebarrierrec |
@@ -80,8 +84,16 @@ func pragmaFlag(verb string) ir.PragmaFlag {
 		// in the argument list.
 		// Used in syscall/dll_windows.go.
 		return ir.UintptrEscapes
+	case "go:multiversion":
+		return ir.Multiversion
 	case "go:registerparams": // TODO(register args) remove after register abi is working
 		return ir.RegisterParams
+	case "go:noregisterparams":
+		return ir.NoRegisterParams
+	case "go:nocheckbounds":
+		return ir.NoCheckBounds
+	case "go:pure":
+		return ir.Pure
 	case "go:notinheap":
 		return ir.NotInHeap
 	}
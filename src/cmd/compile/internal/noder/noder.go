@@ -5,6 +5,7 @@
 package noder
 
 import (
+	"errors"
 	"fmt"
 	"go/constant"
 	"go/token"
@@ -64,9 +65,13 @@ func LoadPackage(filenames []string) {
 	}
 
 	var lines uint
-	for _, p := range noders {
+	syntaxErrs := make([][]syntax.Pos, len(noders))
+	for i, p := range noders {
 		for e := range p.err {
 			p.errorAt(e.Pos, "%s", e.Msg)
+			if strings.HasPrefix(e.Msg, "syntax error") {
+				syntaxErrs[i] = append(syntaxErrs[i], e.Pos)
+			}
 		}
 		if p.file == nil {
 			base.ErrorExit()
@@ -75,7 +80,28 @@ func LoadPackage(filenames []string) {
 	}
 	base.Timer.AddEvent(int64(lines), "lines")
 
+	// After syntax errors, the package is still type checked if they
+	// are all in function bodies, so that a typo in one function does
+	// not hide the type errors in the rest of the package. The bodies
+	// with syntax errors are not type checked.
+	bodiesOnly := true
+	for i, p := range noders {
+		if len(syntaxErrs[i]) > 0 {
+			var ok bool
+			p.brokenBodies, ok = brokenBodies(p.file, syntaxErrs[i])
+			bodiesOnly = bodiesOnly && ok
+		}
+	}
+
 	if base.Flag.G != 0 {
+		if !bodiesOnly {
+			base.ErrorExit()
+		}
+		for _, p := range noders {
+			for fn := range p.brokenBodies {
+				fn.Body = nil
+			}
+		}
 		// Use types2 to type-check and possibly generate IR.
 		check2(noders)
 		return
@@ -86,7 +112,7 @@ func LoadPackage(filenames []string) {
 		p.file = nil // release memory
 	}
 
-	if base.SyntaxErrors() != 0 {
+	if !bodiesOnly {
 		base.ErrorExit()
 	}
 	types.CheckDclstack()
@@ -137,7 +163,7 @@ func LoadPackage(filenames []string) {
 	var fcount int64
 	for i := 0; i < len(typecheck.Target.Decls); i++ {
 		n := typecheck.Target.Decls[i]
-		if n.Op() == ir.ODCLFUNC {
+		if n.Op() == ir.ODCLFUNC && !brokenFuncs[n.(*ir.Func)] {
 			if base.Flag.W > 1 {
 				s := fmt.Sprintf("\nbefore typecheck %v", n)
 				ir.Dump(s, n)
@@ -165,9 +191,139 @@ func LoadPackage(filenames []string) {
 	// With all user code typechecked, it's now safe to verify unused dot imports.
 	typecheck.CheckMapKeys()
 	CheckDotImports()
+	checkPendingLayouts()
+	resolveAsmOffsets()
 	base.ExitIfErrors()
 }
 
+// brokenFuncs holds the functions whose bodies have syntax errors.
+// Their bodies are not type checked.
+var brokenFuncs = make(map[*ir.Func]bool)
+
+// brokenBodies returns the function declarations in file whose bodies
+// contain the syntax errors at errs. It reports false if some error is
+// not in a function body, in which case the declarations themselves
+// may be broken and the package cannot be type checked.
+func brokenBodies(file *syntax.File, errs []syntax.Pos) (map[*syntax.FuncDecl]bool, bool) {
+	broken := make(map[*syntax.FuncDecl]bool)
+	for _, pos := range errs {
+		var fn *syntax.FuncDecl
+		for _, decl := range file.DeclList {
+			if decl, ok := decl.(*syntax.FuncDecl); ok && decl.Body != nil && inBody(pos, decl.Body) {
+				fn = decl
+				break
+			}
+		}
+		if fn == nil {
+			return nil, false
+		}
+		broken[fn] = true
+	}
+	return broken, true
+}
+
+// inBody reports whether pos is within the braces of body, which
+// extends to the end of the file if its closing brace is missing.
+func inBody(pos syntax.Pos, body *syntax.BlockStmt) bool {
+	before := func(a, b syntax.Pos) bool {
+		return a.Line() < b.Line() || a.Line() == b.Line() && a.Col() < b.Col()
+	}
+	start, end := body.Pos(), body.Rbrace
+	return !before(pos, start) && (!end.IsKnown() || !before(end, pos))
+}
+
+// A pendingLayoutCheck holds the //go:layoutcheck assertions about the
+// type declared by name until typechecking has determined the type.
+type pendingLayoutCheck struct {
+	name   *ir.Name
+	checks []types.LayoutCheck
+}
+
+var pendingLayoutChecks []pendingLayoutCheck
+
+// A pendingCgoLayout is a //go:cgolayout directive for the type
+// declared by name, which is checked once all the package's types,
+// including those written by cgo, have been typechecked.
+type pendingCgoLayout struct {
+	name  *ir.Name
+	pos   src.XPos
+	cname string
+}
+
+var pendingCgoLayouts []pendingCgoLayout
+
+// checkPendingLayouts verifies the //go:layoutcheck and //go:cgolayout
+// directives collected while noding.
+func checkPendingLayouts() {
+	for _, c := range pendingLayoutChecks {
+		if t := c.name.Type(); t != nil && !t.Broke() {
+			types.AddLayoutChecks(t, c.checks)
+		}
+	}
+	pendingLayoutChecks = nil
+
+	for _, c := range pendingCgoLayouts {
+		t := c.name.Type()
+		if t == nil || t.Broke() || t.HasTParam() {
+			continue
+		}
+		def, ok := types.LocalPkg.Lookup("_Ctype_" + c.cname).Def.(ir.Node)
+		if !ok || def.Op() != ir.OTYPE || def.Type() == nil {
+			base.ErrorfAt(c.pos, "go:cgolayout: C.%s is not a C type known to cgo", c.cname)
+			continue
+		}
+		types.CheckCgoLayout(c.pos, t, def.Type(), c.cname)
+	}
+	pendingCgoLayouts = nil
+}
+
+// A pendingAsmOffset is a type named by a //go:asmoffsets directive,
+// which is looked up once the package has been typechecked.
+type pendingAsmOffset struct {
+	pos  src.XPos
+	name string
+}
+
+var pendingAsmOffsets []pendingAsmOffset
+
+// resolveAsmOffsets looks up the types named by //go:asmoffsets
+// directives and records them in typecheck.Target.AsmTypes.
+func resolveAsmOffsets() {
+	for _, a := range pendingAsmOffsets {
+		pkg, name := types.LocalPkg, a.name
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			path := name[:i]
+			pkg, name = nil, name[i+1:]
+			for _, ipkg := range types.ImportedPkgList() {
+				if ipkg.Path == path {
+					pkg = ipkg
+					break
+				}
+			}
+			if pkg == nil {
+				base.ErrorfAt(a.pos, "go:asmoffsets: package %q is not imported", path)
+				continue
+			}
+		}
+		n := typecheck.Resolve(ir.NewIdent(a.pos, pkg.Lookup(name)))
+		if n.Op() != ir.OTYPE || n.Type() == nil {
+			base.ErrorfAt(a.pos, "go:asmoffsets: %s is not a type", a.name)
+			continue
+		}
+		t := n.Type()
+		if t.Broke() {
+			continue
+		}
+		if t.HasTParam() {
+			base.ErrorfAt(a.pos, "go:asmoffsets: %s is a generic type", a.name)
+			continue
+		}
+		types.CalcSize(t)
+		typecheck.Target.AsmTypes = append(typecheck.Target.AsmTypes, n.(*ir.Name))
+	}
+	pendingAsmOffsets = nil
+}
+
 func (p *noder) errorAt(pos syntax.Pos, format string, args ...interface{}) {
 	base.ErrorfAt(p.makeXPos(pos), format, args...)
 }
@@ -187,6 +343,8 @@ type noder struct {
 
 	file           *syntax.File
 	linknames      []linkname
+	nocheckbounds  []syntax.Pos // positions of //go:nocheckbounds directives
+	asmoffsets     []asmOffset
 	pragcgobuf     [][]string
 	err            chan syntax.Error
 	importedUnsafe bool
@@ -194,6 +352,8 @@ type noder struct {
 	trackScopes    bool
 
 	funcState *funcState
+
+	brokenBodies map[*syntax.FuncDecl]bool // function bodies with syntax errors
 }
 
 // funcState tracks all per-function state to make handling nested
@@ -270,6 +430,12 @@ type linkname struct {
 	remote string
 }
 
+// asmOffset records a type named by a //go:asmoffsets directive.
+type asmOffset struct {
+	pos  syntax.Pos
+	name string // local name or importpath.name
+}
+
 func (p *noder) node() {
 	p.importedUnsafe = false
 	p.importedEmbed = false
@@ -279,6 +445,10 @@ func (p *noder) node() {
 
 	if pragma, ok := p.file.Pragma.(*pragmas); ok {
 		pragma.Flag &^= ir.GoBuildPragma
+		if pragma.Lang != nil {
+			base.SetFileLang(p.makeXPos(pragma.Lang.Pos), pragma.Lang.Version)
+			pragma.Lang = nil
+		}
 		p.checkUnused(pragma)
 	}
 
@@ -306,6 +476,14 @@ func (p *noder) processPragmas() {
 		}
 		n.Sym().Linkname = l.remote
 	}
+	if !p.importedUnsafe {
+		for _, pos := range p.nocheckbounds {
+			p.errorAt(pos, "//go:nocheckbounds only allowed in Go files that import \"unsafe\"")
+		}
+	}
+	for _, a := range p.asmoffsets {
+		pendingAsmOffsets = append(pendingAsmOffsets, pendingAsmOffset{p.makeXPos(a.pos), a.name})
+	}
 	typecheck.Target.CgoPragmas = append(typecheck.Target.CgoPragmas, p.pragcgobuf...)
 }
 
@@ -519,12 +697,16 @@ func (p *noder) typeDecl(decl *syntax.TypeDecl) ir.Node {
 		if !decl.Alias {
 			n.SetPragma(pragma.Flag & typePragmas)
 			pragma.Flag &^= typePragmas
+			if checks := layoutChecks(p.makeXPos, pragma); checks != nil {
+				pendingLayoutChecks = append(pendingLayoutChecks, pendingLayoutCheck{n, checks})
+			}
+			typeCgoLayout(p.makeXPos, n, pragma)
 		}
 		p.checkUnused(pragma)
 	}
 
 	nod := ir.NewDecl(p.pos(decl), ir.ODCLTYPE, n)
-	if n.Alias() && !types.AllowsGoVersion(types.LocalPkg, 1, 9) {
+	if n.Alias() && !types.AllowsGoVersionAt(types.LocalPkg, nod.Pos(), 1, 9) {
 		base.ErrorfAt(nod.Pos(), "type aliases only supported as of -lang=go1.9")
 	}
 	return nod
@@ -576,7 +758,11 @@ func (p *noder) funcDecl(fun *syntax.FuncDecl) ir.Node {
 		if pragma.Flag&ir.Systemstack != 0 && pragma.Flag&ir.Nosplit != 0 {
 			base.ErrorfAt(f.Pos(), "go:nosplit and go:systemstack cannot be combined")
 		}
+		if pragma.Flag&ir.Pure != 0 && len(fun.Type.ResultList) == 0 {
+			base.ErrorfAt(f.Pos(), "go:pure function must have results")
+		}
 		pragma.Flag &^= funcPragmas
+		funcWasmExport(p.makeXPos, f, fun, pragma)
 		p.checkUnused(pragma)
 	}
 
@@ -585,12 +771,11 @@ func (p *noder) funcDecl(fun *syntax.FuncDecl) ir.Node {
 	}
 
 	p.funcBody(f, fun.Body)
+	if p.brokenBodies[fun] {
+		brokenFuncs[f] = true
+	}
 
-	if fun.Body != nil {
-		if f.Pragma&ir.Noescape != 0 {
-			base.ErrorfAt(f.Pos(), "can only use //go:noescape with external func implementations")
-		}
-	} else {
+	if fun.Body == nil {
 		if base.Flag.Complete || strings.HasPrefix(ir.FuncName(f), "init.") {
 			// Linknamed functions are allowed to have no body. Hopefully
 			// the linkname target has a body. See issue 23311.
@@ -1203,6 +1388,10 @@ func (p *noder) ifStmt(stmt *syntax.IfStmt) ir.Node {
 	if init != nil {
 		*n.PtrInit() = []ir.Node{init}
 	}
+	if pragma, ok := stmt.Pragma.(*pragmas); ok {
+		setBranchHint(n, pragma)
+		p.checkUnused(pragma)
+	}
 	if stmt.Else != nil {
 		e := p.stmt(stmt.Else)
 		if e.Op() == ir.OBLOCK {
@@ -1419,9 +1608,9 @@ func (p *noder) binOp(op syntax.Operator) ir.Op {
 
 // checkLangCompat reports an error if the representation of a numeric
 // literal is not compatible with the current language version.
-func checkLangCompat(lit *syntax.BasicLit) {
+func (p *noder) checkLangCompat(lit *syntax.BasicLit) {
 	s := lit.Value
-	if len(s) <= 2 || types.AllowsGoVersion(types.LocalPkg, 1, 13) {
+	if len(s) <= 2 || types.AllowsGoVersionAt(types.LocalPkg, p.pos(lit), 1, 13) {
 		return
 	}
 	// len(s) > 2
@@ -1457,7 +1646,7 @@ func (p *noder) basicLit(lit *syntax.BasicLit) constant.Value {
 
 	switch lit.Kind {
 	case syntax.IntLit, syntax.FloatLit, syntax.ImagLit:
-		checkLangCompat(lit)
+		p.checkLangCompat(lit)
 		// The max. mantissa precision for untyped numeric values
 		// is 512 bits, or 4048 bits for each of the two integer
 		// parts of a fraction for floating-point numbers that are
@@ -1538,6 +1727,7 @@ var allowedStdPragmas = map[string]bool{
 	"go:cgo_ldflag":         true,
 	"go:cgo_dynamic_linker": true,
 	"go:embed":              true,
+	"go:embedcompress":      true,
 	"go:generate":           true,
 }
 
@@ -1546,6 +1736,15 @@ type pragmas struct {
 	Flag   ir.PragmaFlag // collected bits
 	Pos    []pragmaPos   // position of each individual flag
 	Embeds []pragmaEmbed
+	Branch *pragmaBranch // go:likely or go:unlikely
+	Lang   *pragmaLang   // go:lang
+
+	EmbedCompress syntax.Pos // go:embedcompress
+
+	WasmExport *pragmaWasmExport
+
+	LayoutChecks []pragmaLayoutCheck // go:layoutcheck
+	CgoLayout    *pragmaCgoLayout
 }
 
 type pragmaPos struct {
@@ -1558,6 +1757,57 @@ type pragmaEmbed struct {
 	Patterns []string
 }
 
+// A pragmaWasmExport is a //go:wasmexport directive, which exports
+// the function that follows it from the WebAssembly module as Name.
+type pragmaWasmExport struct {
+	Pos  syntax.Pos
+	Name string
+}
+
+// A pragmaLayoutCheck is one assertion of a //go:layoutcheck
+// directive, which states the size or alignment of the struct type
+// declared next, or the offset of one of its fields.
+type pragmaLayoutCheck struct {
+	Pos   syntax.Pos
+	Kind  string // "size", "align" or "offset"
+	Field string // for "offset", the name of the field
+	Want  int64
+}
+
+// A pragmaCgoLayout is a //go:cgolayout directive, which states that
+// the struct type declared next mirrors the layout of the C type
+// C.Name, as cgo translates it.
+type pragmaCgoLayout struct {
+	Pos  syntax.Pos
+	Name string
+}
+
+// A pragmaBranch is a //go:likely or //go:unlikely directive, which
+// tells the compiler whether the condition of the if statement that
+// follows it is likely to be true.
+type pragmaBranch struct {
+	Pos    syntax.Pos
+	Likely bool
+}
+
+// A pragmaLang is a //go:lang directive, which sets the language
+// version used to check the file containing it, overriding the -lang
+// flag. It must precede the package clause.
+type pragmaLang struct {
+	Pos     syntax.Pos
+	Version string
+}
+
+// setBranchHint sets the code layout hint of n from the go:likely or
+// go:unlikely directive in pragma, if any.
+func setBranchHint(n *ir.IfStmt, pragma *pragmas) {
+	if b := pragma.Branch; b != nil {
+		n.Likely = b.Likely
+		n.Unlikely = !b.Likely
+		pragma.Branch = nil
+	}
+}
+
 func (p *noder) checkUnused(pragma *pragmas) {
 	for _, pos := range pragma.Pos {
 		if pos.Flag&pragma.Flag != 0 {
@@ -1569,6 +1819,24 @@ func (p *noder) checkUnused(pragma *pragmas) {
 			p.errorAt(e.Pos, "misplaced go:embed directive")
 		}
 	}
+	if pragma.EmbedCompress.IsKnown() {
+		p.errorAt(pragma.EmbedCompress, "misplaced go:embedcompress directive")
+	}
+	if pragma.Branch != nil {
+		p.errorAt(pragma.Branch.Pos, "misplaced compiler directive")
+	}
+	if pragma.WasmExport != nil {
+		p.errorAt(pragma.WasmExport.Pos, "misplaced go:wasmexport directive")
+	}
+	if pragma.Lang != nil {
+		p.errorAt(pragma.Lang.Pos, "misplaced go:lang directive")
+	}
+	if len(pragma.LayoutChecks) > 0 {
+		p.errorAt(pragma.LayoutChecks[0].Pos, "misplaced go:layoutcheck directive")
+	}
+	if pragma.CgoLayout != nil {
+		p.errorAt(pragma.CgoLayout.Pos, "misplaced go:cgolayout directive")
+	}
 }
 
 func (p *noder) checkUnusedDuringParse(pragma *pragmas) {
@@ -1582,6 +1850,24 @@ func (p *noder) checkUnusedDuringParse(pragma *pragmas) {
 			p.error(syntax.Error{Pos: e.Pos, Msg: "misplaced go:embed directive"})
 		}
 	}
+	if pragma.EmbedCompress.IsKnown() {
+		p.error(syntax.Error{Pos: pragma.EmbedCompress, Msg: "misplaced go:embedcompress directive"})
+	}
+	if pragma.Branch != nil {
+		p.error(syntax.Error{Pos: pragma.Branch.Pos, Msg: "misplaced compiler directive"})
+	}
+	if pragma.WasmExport != nil {
+		p.error(syntax.Error{Pos: pragma.WasmExport.Pos, Msg: "misplaced go:wasmexport directive"})
+	}
+	if pragma.Lang != nil {
+		p.error(syntax.Error{Pos: pragma.Lang.Pos, Msg: "misplaced go:lang directive"})
+	}
+	if len(pragma.LayoutChecks) > 0 {
+		p.error(syntax.Error{Pos: pragma.LayoutChecks[0].Pos, Msg: "misplaced go:layoutcheck directive"})
+	}
+	if pragma.CgoLayout != nil {
+		p.error(syntax.Error{Pos: pragma.CgoLayout.Pos, Msg: "misplaced go:cgolayout directive"})
+	}
 }
 
 // pragma is called concurrently if files are parsed concurrently.
@@ -1633,6 +1919,19 @@ func (p *noder) pragma(pos syntax.Pos, blankLine bool, text string, old syntax.P
 		}
 		p.linknames = append(p.linknames, linkname{pos, f[1], target})
 
+	case text == "go:asmoffsets", strings.HasPrefix(text, "go:asmoffsets "):
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i] // allow a trailing comment
+		}
+		f := strings.Fields(text)
+		if len(f) < 2 {
+			p.error(syntax.Error{Pos: pos, Msg: "usage: //go:asmoffsets [importpath.]name..."})
+			break
+		}
+		for _, name := range f[1:] {
+			p.asmoffsets = append(p.asmoffsets, asmOffset{pos, name})
+		}
+
 	case text == "go:embed", strings.HasPrefix(text, "go:embed "):
 		args, err := parseGoEmbed(text[len("go:embed"):])
 		if err != nil {
@@ -1644,6 +1943,74 @@ func (p *noder) pragma(pos syntax.Pos, blankLine bool, text string, old syntax.P
 		}
 		pragma.Embeds = append(pragma.Embeds, pragmaEmbed{pos, args})
 
+	case text == "go:embedcompress", strings.HasPrefix(text, "go:embedcompress "):
+		pragma.EmbedCompress = pos
+
+	case text == "go:wasmexport", strings.HasPrefix(text, "go:wasmexport "):
+		f := strings.Fields(text)
+		if len(f) != 2 {
+			p.error(syntax.Error{Pos: pos, Msg: "usage: //go:wasmexport name"})
+			break
+		}
+		if pragma.WasmExport != nil {
+			p.error(syntax.Error{Pos: pos, Msg: "multiple //go:wasmexport directives"})
+			break
+		}
+		pragma.WasmExport = &pragmaWasmExport{pos, f[1]}
+
+	case text == "go:layoutcheck", strings.HasPrefix(text, "go:layoutcheck "):
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i] // allow a trailing comment
+		}
+		checks, err := parseLayoutCheck(pos, text[len("go:layoutcheck"):])
+		if err != nil {
+			p.error(syntax.Error{Pos: pos, Msg: err.Error()})
+			break
+		}
+		pragma.LayoutChecks = append(pragma.LayoutChecks, checks...)
+
+	case text == "go:cgolayout", strings.HasPrefix(text, "go:cgolayout "):
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i] // allow a trailing comment
+		}
+		f := strings.Fields(text)
+		if len(f) != 2 {
+			p.error(syntax.Error{Pos: pos, Msg: "usage: //go:cgolayout C.typename"})
+			break
+		}
+		if pragma.CgoLayout != nil {
+			p.error(syntax.Error{Pos: pos, Msg: "multiple //go:cgolayout directives"})
+			break
+		}
+		pragma.CgoLayout = &pragmaCgoLayout{pos, strings.TrimPrefix(f[1], "C.")}
+
+	case text == "go:lang", strings.HasPrefix(text, "go:lang "):
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i] // allow a trailing comment
+		}
+		f := strings.Fields(text)
+		if len(f) != 2 {
+			p.error(syntax.Error{Pos: pos, Msg: "usage: //go:lang go1.N"})
+			break
+		}
+		if err := types.CheckLang(f[1]); err != nil {
+			p.error(syntax.Error{Pos: pos, Msg: fmt.Sprintf("invalid //go:lang version %q: %v", f[1], err)})
+			break
+		}
+		if pragma.Lang != nil {
+			p.error(syntax.Error{Pos: pos, Msg: "multiple //go:lang directives"})
+			break
+		}
+		pragma.Lang = &pragmaLang{pos, f[1]}
+
+	case text == "go:likely", strings.HasPrefix(text, "go:likely "),
+		text == "go:unlikely", strings.HasPrefix(text, "go:unlikely "):
+		if pragma.Branch != nil {
+			p.error(syntax.Error{Pos: pos, Msg: "multiple //go:likely and //go:unlikely directives"})
+			break
+		}
+		pragma.Branch = &pragmaBranch{pos, strings.HasPrefix(text, "go:likely")}
+
 	case strings.HasPrefix(text, "go:cgo_import_dynamic "):
 		// This is permitted for general use because Solaris
 		// code relies on it in golang.org/x/sys/unix and others.
@@ -1680,6 +2047,9 @@ func (p *noder) pragma(pos syntax.Pos, blankLine bool, text string, old syntax.P
 		if flag == 0 && !allowedStdPragmas[verb] && base.Flag.Std {
 			p.error(syntax.Error{Pos: pos, Msg: fmt.Sprintf("//%s is not allowed in the standard library", verb)})
 		}
+		if flag&ir.NoCheckBounds != 0 {
+			p.nocheckbounds = append(p.nocheckbounds, pos)
+		}
 		pragma.Flag |= flag
 		pragma.Pos = append(pragma.Pos, pragmaPos{flag, pos})
 	}
@@ -1843,13 +2213,97 @@ func oldname(s *types.Sym) ir.Node {
 	return n
 }
 
+func funcWasmExport(makeXPos func(syntax.Pos) src.XPos, fn *ir.Func, decl *syntax.FuncDecl, pragma *pragmas) {
+	x := pragma.WasmExport
+	if x == nil {
+		return
+	}
+	pragma.WasmExport = nil
+	pos := makeXPos(decl.Pos())
+
+	if decl.Recv != nil {
+		base.ErrorfAt(pos, "go:wasmexport cannot apply to method")
+		return
+	}
+	if len(decl.TParamList) > 0 {
+		base.ErrorfAt(pos, "go:wasmexport cannot apply to generic func")
+		return
+	}
+	if decl.Body == nil {
+		base.ErrorfAt(pos, "go:wasmexport cannot apply to func without body")
+		return
+	}
+	typecheck.Target.WasmExports = append(typecheck.Target.WasmExports, &ir.WasmExport{Pos: pos, Name: x.Name, Func: fn})
+}
+
+// parseLayoutCheck parses the arguments of a //go:layoutcheck
+// directive at pos: a list of size=N, align=N and offset.Field=N
+// assertions.
+func parseLayoutCheck(pos syntax.Pos, args string) ([]pragmaLayoutCheck, error) {
+	usage := errors.New("usage: //go:layoutcheck [size=N] [align=N] [offset.Field=N]...")
+	f := strings.Fields(args)
+	if len(f) == 0 {
+		return nil, usage
+	}
+	var checks []pragmaLayoutCheck
+	for _, arg := range f {
+		i := strings.Index(arg, "=")
+		if i < 0 {
+			return nil, usage
+		}
+		c := pragmaLayoutCheck{Pos: pos, Kind: arg[:i]}
+		if strings.HasPrefix(c.Kind, "offset.") {
+			c.Kind, c.Field = "offset", c.Kind[len("offset."):]
+			if c.Field == "" {
+				return nil, usage
+			}
+		} else if c.Kind != "size" && c.Kind != "align" {
+			return nil, usage
+		}
+		n, err := strconv.ParseInt(arg[i+1:], 0, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid //go:layoutcheck value %q", arg[i+1:])
+		}
+		c.Want = n
+		checks = append(checks, c)
+	}
+	return checks, nil
+}
+
+// layoutChecks returns the //go:layoutcheck assertions in pragma, if
+// any, and removes them from pragma.
+func layoutChecks(makeXPos func(syntax.Pos) src.XPos, pragma *pragmas) []types.LayoutCheck {
+	if len(pragma.LayoutChecks) == 0 {
+		return nil
+	}
+	checks := make([]types.LayoutCheck, len(pragma.LayoutChecks))
+	for i, c := range pragma.LayoutChecks {
+		checks[i] = types.LayoutCheck{Pos: makeXPos(c.Pos), Kind: c.Kind, Field: c.Field, Want: c.Want}
+	}
+	pragma.LayoutChecks = nil
+	return checks
+}
+
+// typeCgoLayout records the //go:cgolayout directive in pragma, if any,
+// for the type declared by name, and removes it from pragma.
+func typeCgoLayout(makeXPos func(syntax.Pos) src.XPos, name *ir.Name, pragma *pragmas) {
+	x := pragma.CgoLayout
+	if x == nil {
+		return
+	}
+	pragma.CgoLayout = nil
+	pendingCgoLayouts = append(pendingCgoLayouts, pendingCgoLayout{name, makeXPos(x.Pos), x.Name})
+}
+
 func varEmbed(makeXPos func(syntax.Pos) src.XPos, name *ir.Name, decl *syntax.VarDecl, pragma *pragmas, haveEmbed bool) {
 	if pragma.Embeds == nil {
 		return
 	}
 
 	pragmaEmbeds := pragma.Embeds
+	compress := pragma.EmbedCompress.IsKnown()
 	pragma.Embeds = nil
+	pragma.EmbedCompress = syntax.Pos{}
 	pos := makeXPos(pragmaEmbeds[0].Pos)
 
 	if !haveEmbed {
@@ -1876,7 +2330,7 @@ func varEmbed(makeXPos func(syntax.Pos) src.XPos, name *ir.Name, decl *syntax.Va
 
 	var embeds []ir.Embed
 	for _, e := range pragmaEmbeds {
-		embeds = append(embeds, ir.Embed{Pos: makeXPos(e.Pos), Patterns: e.Patterns})
+		embeds = append(embeds, ir.Embed{Pos: makeXPos(e.Pos), Patterns: e.Patterns, Compress: compress})
 	}
 	typecheck.Target.Embeds = append(typecheck.Target.Embeds, name)
 	name.Embed = &embeds
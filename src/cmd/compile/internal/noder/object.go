@@ -168,7 +168,8 @@ func (g *irgen) objFinish(name *ir.Name, class ir.Class, typ *types.Type) {
 		}
 		if types.IsExported(sym.Name) {
 			if name.Class == ir.PFUNC && name.Type().NumTParams() > 0 {
-				base.FatalfAt(name.Pos(), "Cannot export a generic function (yet): %v", name)
+				base.ErrorfAt(name.Pos(), "cannot export generic function %v: generic functions cannot yet be used outside the package that declares them", name)
+				break
 			}
 			typecheck.Export(name)
 		}
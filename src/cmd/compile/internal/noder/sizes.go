@@ -6,145 +6,99 @@ package noder
 
 import (
 	"fmt"
+	"go/layout"
 
 	"cmd/compile/internal/types"
 	"cmd/compile/internal/types2"
 )
 
-// Code below based on go/types.StdSizes.
-// Intentional differences are marked with "gc:".
-
+// gcSizes implements types2.Sizes using package go/layout, which also
+// lays out struct types for the rest of the compiler.
 type gcSizes struct{}
 
-func (s *gcSizes) Alignof(T types2.Type) int64 {
-	// For arrays and structs, alignment is defined in terms
-	// of alignment of the elements and fields, respectively.
+func (s *gcSizes) arch() *layout.Arch {
+	return &layout.Arch{PtrSize: int64(types.PtrSize), RegSize: int64(types.RegSize)}
+}
+
+var basicKinds = [...]layout.Kind{
+	types2.Bool:          layout.Bool,
+	types2.Int:           layout.Int,
+	types2.Int8:          layout.Int8,
+	types2.Int16:         layout.Int16,
+	types2.Int32:         layout.Int32,
+	types2.Int64:         layout.Int64,
+	types2.Uint:          layout.Uint,
+	types2.Uint8:         layout.Uint8,
+	types2.Uint16:        layout.Uint16,
+	types2.Uint32:        layout.Uint32,
+	types2.Uint64:        layout.Uint64,
+	types2.Uintptr:       layout.Uintptr,
+	types2.Float32:       layout.Float32,
+	types2.Float64:       layout.Float64,
+	types2.Complex64:     layout.Complex64,
+	types2.Complex128:    layout.Complex128,
+	types2.String:        layout.String,
+	types2.UnsafePointer: layout.UnsafePointer,
+}
+
+// kind returns the layout kind of T, which must not be an array or
+// struct type.
+func (s *gcSizes) kind(T types2.Type) layout.Kind {
 	switch t := T.Underlying().(type) {
-	case *types2.Array:
-		// spec: "For a variable x of array type: unsafe.Alignof(x)
-		// is the same as unsafe.Alignof(x[0]), but at least 1."
-		return s.Alignof(t.Elem())
-	case *types2.Struct:
-		// spec: "For a variable x of struct type: unsafe.Alignof(x)
-		// is the largest of the values unsafe.Alignof(x.f) for each
-		// field f of x, but at least 1."
-		max := int64(1)
-		for i, nf := 0, t.NumFields(); i < nf; i++ {
-			if a := s.Alignof(t.Field(i).Type()); a > max {
-				max = a
-			}
-		}
-		return max
-	case *types2.Slice, *types2.Interface:
-		// Multiword data structures are effectively structs
-		// in which each element has size PtrSize.
-		return int64(types.PtrSize)
 	case *types2.Basic:
-		// Strings are like slices and interfaces.
-		if t.Info()&types2.IsString != 0 {
-			return int64(types.PtrSize)
+		if k := t.Kind(); int(k) < len(basicKinds) && basicKinds[k] != layout.Invalid {
+			return basicKinds[k]
 		}
+		panic(fmt.Sprintf("unimplemented basic: %v (kind %v)", T, t.Kind()))
+	case *types2.Chan, *types2.Map, *types2.Pointer, *types2.Signature:
+		return layout.Pointer
+	case *types2.Interface:
+		return layout.Interface
+	case *types2.Slice:
+		return layout.Slice
+	default:
+		panic(fmt.Sprintf("unimplemented type: %T", t))
 	}
-	a := s.Sizeof(T) // may be 0
-	// spec: "For a variable x of any type: unsafe.Alignof(x) is at least 1."
-	if a < 1 {
-		return 1
-	}
-	// complex{64,128} are aligned like [2]float{32,64}.
-	if isComplex(T) {
-		a /= 2
-	}
-	if a > int64(types.RegSize) {
-		return int64(types.RegSize)
-	}
-	return a
 }
 
-func isComplex(T types2.Type) bool {
-	basic, ok := T.Underlying().(*types2.Basic)
-	return ok && basic.Info()&types2.IsComplex != 0
+func (s *gcSizes) Alignof(T types2.Type) int64 {
+	switch t := T.Underlying().(type) {
+	case *types2.Array:
+		return s.Alignof(t.Elem())
+	case *types2.Struct:
+		return s.layout(t).Align()
+	}
+	return s.arch().Alignof(s.kind(T))
 }
 
 func (s *gcSizes) Offsetsof(fields []*types2.Var) []int64 {
 	offsets := make([]int64, len(fields))
-	var o int64
+	var l layout.Struct
 	for i, f := range fields {
-		typ := f.Type()
-		a := s.Alignof(typ)
-		o = types.Rnd(o, a)
-		offsets[i] = o
-		o += s.Sizeof(typ)
+		offsets[i] = l.Field(s.Sizeof(f.Type()), s.Alignof(f.Type()))
 	}
 	return offsets
 }
 
 func (s *gcSizes) Sizeof(T types2.Type) int64 {
 	switch t := T.Underlying().(type) {
-	case *types2.Basic:
-		k := t.Kind()
-		if int(k) < len(basicSizes) {
-			if s := basicSizes[k]; s > 0 {
-				return int64(s)
-			}
-		}
-		switch k {
-		case types2.String:
-			return int64(types.PtrSize) * 2
-		case types2.Int, types2.Uint, types2.Uintptr, types2.UnsafePointer:
-			return int64(types.PtrSize)
-		}
-		panic(fmt.Sprintf("unimplemented basic: %v (kind %v)", T, k))
 	case *types2.Array:
-		n := t.Len()
-		if n <= 0 {
+		if t.Len() <= 0 {
 			return 0
 		}
-		// n > 0
-		// gc: Size includes alignment padding.
-		return s.Sizeof(t.Elem()) * n
-	case *types2.Slice:
-		return int64(types.PtrSize) * 3
+		return layout.ArraySize(s.Sizeof(t.Elem()), t.Len())
 	case *types2.Struct:
-		n := t.NumFields()
-		if n == 0 {
-			return 0
-		}
-		fields := make([]*types2.Var, n)
-		for i := range fields {
-			fields[i] = t.Field(i)
-		}
-		offsets := s.Offsetsof(fields)
-
-		// gc: The last field of a struct is not allowed to
-		// have size 0.
-		last := s.Sizeof(fields[n-1].Type())
-		if last == 0 {
-			last = 1
-		}
-
-		// gc: Size includes alignment padding.
-		return types.Rnd(offsets[n-1]+last, s.Alignof(t))
-	case *types2.Interface:
-		return int64(types.PtrSize) * 2
-	case *types2.Chan, *types2.Map, *types2.Pointer, *types2.Signature:
-		return int64(types.PtrSize)
-	default:
-		panic(fmt.Sprintf("unimplemented type: %T", t))
+		return s.layout(t).Size()
 	}
+	return s.arch().Sizeof(s.kind(T))
 }
 
-var basicSizes = [...]byte{
-	types2.Bool:       1,
-	types2.Int8:       1,
-	types2.Int16:      2,
-	types2.Int32:      4,
-	types2.Int64:      8,
-	types2.Uint8:      1,
-	types2.Uint16:     2,
-	types2.Uint32:     4,
-	types2.Uint64:     8,
-	types2.Float32:    4,
-	types2.Float64:    8,
-	types2.Complex64:  8,
-	types2.Complex128: 16,
+// layout lays out the fields of struct t.
+func (s *gcSizes) layout(t *types2.Struct) *layout.Struct {
+	var l layout.Struct
+	for i, n := 0, t.NumFields(); i < n; i++ {
+		typ := t.Field(i).Type()
+		l.Field(s.Sizeof(typ), s.Alignof(typ))
+	}
+	return &l
 }
@@ -221,6 +221,10 @@ func (g *irgen) blockStmt(stmt *syntax.BlockStmt) []ir.Node {
 func (g *irgen) ifStmt(stmt *syntax.IfStmt) ir.Node {
 	init := g.stmt(stmt.Init)
 	n := ir.NewIfStmt(g.pos(stmt), g.expr(stmt.Cond), g.blockStmt(stmt.Then), nil)
+	if pragma, ok := stmt.Pragma.(*pragmas); ok {
+		setBranchHint(n, pragma)
+		g.reportUnused(pragma)
+	}
 	if stmt.Else != nil {
 		e := g.stmt(stmt.Else)
 		if e.Op() == ir.OBLOCK {
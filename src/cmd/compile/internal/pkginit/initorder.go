@@ -5,7 +5,6 @@
 package pkginit
 
 import (
-	"bytes"
 	"container/heap"
 	"fmt"
 
@@ -240,14 +239,13 @@ func reportInitLoopAndExit(l []*ir.Name) {
 	// TODO(mdempsky): Method values are printed as "T.m-fm"
 	// rather than "T.m". Figure out how to avoid that.
 
-	var msg bytes.Buffer
-	fmt.Fprintf(&msg, "initialization loop:\n")
+	var notes []base.Note
 	for _, n := range l {
-		fmt.Fprintf(&msg, "\t%v: %v refers to\n", ir.Line(n), n)
+		notes = append(notes, base.Note{Pos: n.Pos(), Msg: fmt.Sprintf("%v refers to", n)})
 	}
-	fmt.Fprintf(&msg, "\t%v: %v", ir.Line(l[0]), l[0])
+	notes = append(notes, base.Note{Pos: l[0].Pos(), Msg: fmt.Sprint(l[0])})
 
-	base.ErrorfAt(l[0].Pos(), msg.String())
+	base.ErrorfAtNotes(l[0].Pos(), base.CodeInitLoop, notes, "initialization loop:")
 	base.ErrorExit()
 }
 
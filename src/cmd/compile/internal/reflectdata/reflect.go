@@ -17,6 +17,7 @@ import (
 	"cmd/compile/internal/inline"
 	"cmd/compile/internal/ir"
 	"cmd/compile/internal/objw"
+	"cmd/compile/internal/staticdata"
 	"cmd/compile/internal/typebits"
 	"cmd/compile/internal/typecheck"
 	"cmd/compile/internal/types"
@@ -794,6 +795,28 @@ func TrackSym(t *types.Type, f *types.Field) *obj.LSym {
 	return base.PkgLinksym("go.track", t.ShortString()+"."+f.Sym.Name, obj.ABI0)
 }
 
+// WriteTrackSyms defines the tracking symbol of each exported field
+// tagged go:"track" in the struct types declared at package level, so
+// that the linker can report the tracked fields that are never used as
+// well as the ones that are.
+func WriteTrackSyms() {
+	for _, n := range typecheck.Target.Decls {
+		if n.Op() != ir.ODCLTYPE {
+			continue
+		}
+		name := n.(*ir.Decl).X
+		t := name.Type()
+		if name.Alias() || t == nil || t.Broke() || !t.IsStruct() || t.HasTParam() {
+			continue
+		}
+		for _, f := range t.Fields().Slice() {
+			if types.IsExported(f.Sym.Name) && strings.Contains(f.Note, "go:\"track\"") {
+				objw.Global(TrackSym(t, f), 0, obj.DUPOK|obj.RODATA|obj.NOPTR)
+			}
+		}
+	}
+}
+
 func TypeSymPrefix(prefix string, t *types.Type) *types.Sym {
 	p := prefix + "." + t.ShortString()
 	s := types.TypeSymLookup(p)
@@ -854,6 +877,40 @@ func ITabAddr(t, itype *types.Type) *ir.AddrExpr {
 	return typecheck.Expr(typecheck.NodAddr(n)).(*ir.AddrExpr)
 }
 
+// LazyITabs reports whether conversions to non-empty interfaces use
+// itabs generated at run time, on first use, rather than static itabs,
+// as requested by -d=lazyitabs. Static initialization still uses
+// static itabs, which the runtime also returns for the pairs they
+// describe. The runtime itself always uses static itabs: it converts
+// to interfaces in code that must not call into the itab table.
+func LazyITabs() bool {
+	return base.Debug.LazyItabs != 0 && !base.Flag.CompilingRuntime
+}
+
+// ITabCache returns the first word of the itab cache for t and itype,
+// used by -d=lazyitabs. The word is nil until runtime.lazyitab fills
+// it in. Like static itabs, the caches are shared by the packages of
+// a program.
+func ITabCache(t, itype *types.Type) *ir.LinksymOffsetExpr {
+	if t == nil || (t.IsPtr() && t.Elem() == nil) || t.IsUntyped() || !itype.IsInterface() || itype.IsEmptyInterface() {
+		base.Fatalf("ITabCache(%v, %v)", t, itype)
+	}
+	inter, typ := TypeLinksym(itype), TypeLinksym(t)
+	lsym := base.Ctxt.LookupInit("go.itabcache."+t.ShortString()+","+itype.ShortString(), func(lsym *obj.LSym) {
+		// type itabCache struct {
+		//   tab   *itab
+		//   inter *interfacetype
+		//   _type *_type
+		// }
+		o := types.PtrSize
+		o = objw.SymPtr(lsym, o, inter, 0)
+		o = objw.SymPtr(lsym, o, typ, 0)
+		// Neither the itabs nor the types are in the heap.
+		objw.Global(lsym, int32(o), obj.DUPOK|obj.NOPTR)
+	})
+	return ir.NewLinksymExpr(base.Pos, lsym, types.NewPtr(types.Types[types.TUINT8]))
+}
+
 // needkeyupdate reports whether map updates with t as a key
 // need the key to be updated.
 func needkeyupdate(t *types.Type) bool {
@@ -1419,6 +1476,9 @@ func WriteBasicTypes() {
 		if base.Flag.MSan {
 			dimportpath(types.NewPkg("runtime/msan", ""))
 		}
+		if base.Flag.ASan {
+			dimportpath(types.NewPkg("runtime/asan", ""))
+		}
 
 		dimportpath(types.NewPkg("main", ""))
 	}
@@ -1496,14 +1556,36 @@ func dgcsym(t *types.Type) (lsym *obj.LSym, useGCProg bool, ptrdata int64) {
 	ptrdata = types.PtrDataSize(t)
 	if ptrdata/int64(types.PtrSize) <= maxPtrmaskBytes*8 {
 		lsym = dgcptrmask(t)
-		return
+	} else {
+		useGCProg = true
+		lsym, ptrdata = dgcprog(t)
+	}
+	if base.Debug.TypeBits != 0 {
+		printTypeBits(t, lsym, useGCProg, ptrdata)
 	}
-
-	useGCProg = true
-	lsym, ptrdata = dgcprog(t)
 	return
 }
 
+// printTypeBits prints, for -d=typebits, the pointer layout the
+// garbage collector is given for type t: lsym holds either its
+// pointer mask, printed one bit per word, or its GC program.
+func printTypeBits(t *types.Type, lsym *obj.LSym, useGCProg bool, ptrdata int64) {
+	if useGCProg {
+		// The first 4 bytes hold the program length.
+		fmt.Printf("%v: size %d, ptrdata %d, gcprog %x\n", t, t.Width, ptrdata, lsym.P[4:])
+		return
+	}
+	if ptrdata == 0 {
+		fmt.Printf("%v: size %d, ptrdata 0\n", t, t.Width)
+		return
+	}
+	var bits strings.Builder
+	for i := int64(0); i < ptrdata/int64(types.PtrSize); i++ {
+		bits.WriteByte('0' + lsym.P[i/8]>>(i%8)&1)
+	}
+	fmt.Printf("%v: size %d, ptrdata %d, ptrmask %s\n", t, t.Width, ptrdata, bits.String())
+}
+
 // dgcptrmask emits and returns the symbol containing a pointer mask for type t.
 func dgcptrmask(t *types.Type) *obj.LSym {
 	ptrmask := make([]byte, (types.PtrDataSize(t)/int64(types.PtrSize)+7)/8)
@@ -1840,3 +1922,12 @@ func MarkUsedIfaceMethod(n *ir.CallExpr) {
 	r.Add = InterfaceMethodOffset(ityp, midx)
 	r.Type = objabi.R_USEIFACEMETHOD
 }
+
+// MarkUsedNamedMethod marks that the current function looks up the
+// method named name through reflection, with a call such as
+// reflect.Type.MethodByName(name) whose argument is a constant.
+func MarkUsedNamedMethod(name string) {
+	r := obj.Addrel(ir.CurFunc.LSym)
+	r.Sym = staticdata.StringSym(src.NoXPos, name)
+	r.Type = objabi.R_USENAMEDMETHOD
+}
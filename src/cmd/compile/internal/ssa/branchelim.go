@@ -19,6 +19,9 @@ import "cmd/internal/src"
 //
 // where the intermediate blocks are mostly empty (with no side-effects);
 // rewrite Phis in the postdominator as CondSelects.
+// If the postdominator has other predecessors too, as the join of an
+// else-if chain does, the diamond is first split off into a block of
+// its own.
 func branchelim(f *Func) {
 	// FIXME: add support for lowering CondSelects on more architectures
 	switch f.Config.arch {
@@ -119,7 +122,7 @@ func elimIf(f *Func, loadAddr *sparseSet, dom *Block) bool {
 			break
 		}
 	}
-	if simple == nil || len(post.Preds) != 2 || post == dom {
+	if simple == nil || post == dom {
 		return false
 	}
 
@@ -142,15 +145,12 @@ func elimIf(f *Func, loadAddr *sparseSet, dom *Block) bool {
 		return false
 	}
 
-	// Pick some upper bound for the number of instructions
-	// we'd be willing to execute just to generate a dead
-	// argument to CondSelect. In the worst case, this is
-	// the number of useless instructions executed.
-	const maxfuseinsts = 2
-
-	if len(simple.Values) > maxfuseinsts || !canSpeculativelyExecute(simple) {
+	if !canIfConvert(f, simple, dom) || !shouldIfConvert(f.Config.arch, post, simple) {
 		return false
 	}
+	if len(post.Preds) > 2 {
+		post = splitJoin(f, post, dom, simple)
+	}
 
 	// Replace Phi instructions in b with CondSelect instructions
 	swap := (post.Preds[0].Block() == dom) != (dom.Succs[0].Block() == post)
@@ -305,10 +305,10 @@ func elimIfElse(f *Func, loadAddr *sparseSet, b *Block) bool {
 		return false
 	}
 	yes, no := b.Succs[0].Block(), b.Succs[1].Block()
-	if !isLeafPlain(yes) || len(yes.Values) > 1 || !canSpeculativelyExecute(yes) {
+	if !isLeafPlain(yes) || !canIfConvert(f, yes, b) {
 		return false
 	}
-	if !isLeafPlain(no) || len(no.Values) > 1 || !canSpeculativelyExecute(no) {
+	if !isLeafPlain(no) || !canIfConvert(f, no, b) {
 		return false
 	}
 	if b.Succs[0].Block().Succs[0].Block() != b.Succs[1].Block().Succs[0].Block() {
@@ -316,7 +316,7 @@ func elimIfElse(f *Func, loadAddr *sparseSet, b *Block) bool {
 	}
 	// block that postdominates the if/else
 	post := b.Succs[0].Block().Succs[0].Block()
-	if len(post.Preds) != 2 || post == b {
+	if post == b {
 		return false
 	}
 	hasphis := false
@@ -333,9 +333,12 @@ func elimIfElse(f *Func, loadAddr *sparseSet, b *Block) bool {
 	}
 
 	// Don't generate CondSelects if branch is cheaper.
-	if !shouldElimIfElse(no, yes, post, f.Config.arch) {
+	if !shouldIfConvert(f.Config.arch, post, yes, no) {
 		return false
 	}
+	if len(post.Preds) > 2 {
+		post = splitJoin(f, post, yes, no)
+	}
 
 	// now we're committed: rewrite each Phi as a CondSelect
 	swap := post.Preds[0].Block() != b.Succs[0].Block()
@@ -382,37 +385,154 @@ func elimIfElse(f *Func, loadAddr *sparseSet, b *Block) bool {
 	return true
 }
 
-// shouldElimIfElse reports whether estimated cost of eliminating branch
-// is lower than threshold.
-func shouldElimIfElse(no, yes, post *Block, arch string) bool {
-	switch arch {
-	default:
-		return true
-	case "amd64":
-		const maxcost = 2
-		phi := 0
-		other := 0
-		for _, v := range post.Values {
-			if v.Op == OpPhi {
-				// Each phi results in CondSelect, which lowers into CMOV,
-				// CMOV has latency >1 on most CPUs.
-				phi++
+// splitJoin moves the edges from a and b into post, which has other
+// predecessors too, to a new block between them and post, and returns
+// that block. Each Phi of post gets a Phi in the new block for the
+// values coming from a and b.
+func splitJoin(f *Func, post, a, b *Block) *Block {
+	ia, ib := -1, -1
+	for i, e := range post.Preds {
+		switch e.b {
+		case a:
+			ia = i
+		case b:
+			ib = i
+		}
+	}
+	join := f.NewBlock(BlockPlain)
+	join.Pos = post.Pos.WithNotStmt()
+	for _, v := range post.Values {
+		if v.Op == OpPhi {
+			v.SetArg(ia, join.NewValue2(v.Pos.WithNotStmt(), OpPhi, v.Type, v.Args[ia], v.Args[ib]))
+		}
+	}
+
+	ea, eb := post.Preds[ia], post.Preds[ib]
+	a.Succs[ea.i] = Edge{join, 0}
+	b.Succs[eb.i] = Edge{join, 1}
+	join.Preds = append(join.Preds, ea, eb)
+	join.Succs = append(join.Succs, Edge{post, ia})
+	post.Preds[ia] = Edge{join, 0}
+
+	// Drop the edge from b, which now goes to join.
+	post.removePred(ib)
+	n := len(post.Preds)
+	for _, v := range post.Values {
+		if v.Op != OpPhi {
+			continue
+		}
+		v.Args[ib].Uses--
+		v.Args[ib] = v.Args[n]
+		v.Args[n] = nil
+		v.Args = v.Args[:n]
+	}
+	return join
+}
+
+// shouldIfConvert reports whether executing the values of arms
+// unconditionally, and a CondSelect for each Phi of post, is estimated
+// to be cheaper than branching to the arms.
+//
+// If the branch is predicted well, the CondSelects and the values of
+// the arm not taken are wasted work; if it is mispredicted, it costs
+// 15 to 20 cycles on current cores. Without profile information we
+// cannot know which case is common, so we budget a fraction of a
+// mispredict for the converted code.
+func shouldIfConvert(arch string, post *Block, arms ...*Block) bool {
+	budget := 4
+	if arch == "arm64" {
+		// CSEL and its variants take both operands and the
+		// condition in registers, and often absorb the
+		// arithmetic of an arm (CSINC, CSINV, CSNEG).
+		budget = 5
+	}
+
+	cost := 0
+	for _, arm := range arms {
+		for _, v := range arm.Values {
+			switch v.Op {
+			case OpOffPtr, OpAddr, OpLocalAddr:
+				// Usually folded into the addressing mode of a load.
+			default:
+				cost++
 			}
-			for _, x := range v.Args {
-				if x.Block == no || x.Block == yes {
+		}
+	}
+	phis, other := 0, 0
+	for _, v := range post.Values {
+		if v.Op == OpPhi {
+			// Each Phi results in a CondSelect, which lowers into
+			// CMOV or CSEL.
+			phis++
+		}
+		for _, x := range v.Args {
+			for _, arm := range arms {
+				if x.Block == arm {
 					other++
 				}
 			}
 		}
-		cost := phi * 1
-		if phi > 1 {
-			// If we have more than 1 phi and some values in post have args
-			// in yes or no blocks, we may have to recalculate condition, because
-			// those args may clobber flags. For now assume that all operations clobber flags.
-			cost += other * 1
+	}
+	cost += phis
+	if arch == "amd64" && phis > 1 {
+		// If we have more than 1 phi and some values in post have args
+		// in the arms, we may have to recalculate the condition, because
+		// those args may clobber flags. For now assume that all operations
+		// clobber flags.
+		cost += other
+	}
+	return cost <= budget
+}
+
+// canIfConvert reports whether the values of arm, a successor of dom,
+// can be moved into dom: whether they can all be speculatively
+// executed, except for loads that cannot fault there.
+func canIfConvert(f *Func, arm, dom *Block) bool {
+	for _, v := range arm.Values {
+		if v.Op == OpLoad && loadCannotFault(f, v, dom) {
+			continue
+		}
+		if !canSpeculativelyExecuteValue(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// loadCannotFault reports whether the load v would not fault if it
+// were executed at the end of dom: whether it loads from the stack, a
+// global, or a field of an object whose pointer is nil checked on
+// every path to dom. A check in the arm itself, or one removed because
+// of the branch condition, doesn't count.
+func loadCannotFault(f *Func, v *Value, dom *Block) bool {
+	ptr := v.Args[0]
+	fields := true
+	for ptr.Op == OpOffPtr {
+		ptr = ptr.Args[0]
+		if !ptr.Type.IsPtr() || !ptr.Type.Elem().IsStruct() {
+			// An offset from a pointer to an array may come from
+			// unsafe code indexing past the end of the object.
+			fields = false
+		}
+	}
+	switch ptr.Op {
+	case OpSP, OpAddr, OpLocalAddr:
+		return true
+	}
+	if !fields {
+		return false
+	}
+	// Don't look too far up for the nil check.
+	const maxDepth = 8
+	idom := f.Idom()
+	for b, d := dom, 0; b != nil && d < maxDepth; b, d = idom[b.ID], d+1 {
+		for _, w := range b.Values {
+			if w.Op == OpNilCheck && w.Args[0] == ptr {
+				return true
+			}
 		}
-		return cost < maxcost
 	}
+	return false
 }
 
 // canSpeculativelyExecute reports whether every value in the block can
@@ -424,17 +544,23 @@ func shouldElimIfElse(no, yes, post *Block, arch string) bool {
 // instructions the execution of which need to be guarded with CPU
 // hardware feature checks. See issue #34950.
 func canSpeculativelyExecute(b *Block) bool {
-	// don't fuse memory ops, Phi ops, divides (can panic),
-	// or anything else with side-effects
 	for _, v := range b.Values {
-		if v.Op == OpPhi || isDivMod(v.Op) || v.Type.IsMemory() ||
-			v.MemoryArg() != nil || opcodeTable[v.Op].hasSideEffects {
+		if !canSpeculativelyExecuteValue(v) {
 			return false
 		}
 	}
 	return true
 }
 
+// canSpeculativelyExecuteValue reports whether v can be evaluated
+// without causing any observable side effects.
+func canSpeculativelyExecuteValue(v *Value) bool {
+	// don't fuse memory ops, Phi ops, divides (can panic),
+	// or anything else with side-effects
+	return v.Op != OpPhi && !isDivMod(v.Op) && !v.Type.IsMemory() &&
+		v.MemoryArg() == nil && !opcodeTable[v.Op].hasSideEffects
+}
+
 func isDivMod(op Op) bool {
 	switch op {
 	case OpDiv8, OpDiv8u, OpDiv16, OpDiv16u,
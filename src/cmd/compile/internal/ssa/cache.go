@@ -17,6 +17,15 @@ type Cache struct {
 	blocks [200]Block
 	locs   [2000]Location
 
+	// Storage for the values and blocks of large functions, beyond
+	// values and blocks, in chunks of the same size. Up to
+	// maxCachedChunks chunks of each are kept for the next function,
+	// so that large functions reuse the memory of earlier ones
+	// instead of leaving their values and blocks to the garbage
+	// collector.
+	valueChunks []*[2000]Value
+	blockChunks []*[200]Block
+
 	// Reusable stackAllocState.
 	// See stackalloc.go's {new,put}StackAllocState.
 	stackAllocState *stackAllocState
@@ -41,16 +50,73 @@ type Cache struct {
 	Liveness interface{} // *gc.livenessFuncCache
 }
 
+// maxCachedChunks is the number of chunks of values and of blocks
+// a Cache keeps between functions. Functions larger than that
+// allocate the rest of their values and blocks from the heap.
+const maxCachedChunks = 32
+
+// value returns the storage for the value with the given ID.
+func (c *Cache) value(id ID) *Value {
+	if int(id) < len(c.values) {
+		return &c.values[id]
+	}
+	i := int(id)/len(c.values) - 1
+	if i >= maxCachedChunks {
+		return new(Value)
+	}
+	for len(c.valueChunks) <= i {
+		c.valueChunks = append(c.valueChunks, new([len(c.values)]Value))
+	}
+	return &c.valueChunks[i][int(id)%len(c.values)]
+}
+
+// block returns the storage for the block with the given ID.
+func (c *Cache) block(id ID) *Block {
+	if int(id) < len(c.blocks) {
+		return &c.blocks[id]
+	}
+	i := int(id)/len(c.blocks) - 1
+	if i >= maxCachedChunks {
+		return new(Block)
+	}
+	for len(c.blockChunks) <= i {
+		c.blockChunks = append(c.blockChunks, new([len(c.blocks)]Block))
+	}
+	return &c.blockChunks[i][int(id)%len(c.blocks)]
+}
+
 func (c *Cache) Reset() {
-	nv := sort.Search(len(c.values), func(i int) bool { return c.values[i].ID == 0 })
-	xv := c.values[:nv]
-	for i := range xv {
-		xv[i] = Value{}
+	// Values and blocks are allocated in increasing ID order, so
+	// the used ones are a prefix of the arrays and chunks.
+	resetValues := func(values []Value) bool {
+		nv := sort.Search(len(values), func(i int) bool { return values[i].ID == 0 })
+		xv := values[:nv]
+		for i := range xv {
+			xv[i] = Value{}
+		}
+		return nv == len(values)
+	}
+	if resetValues(c.values[:]) {
+		for _, chunk := range c.valueChunks {
+			if !resetValues(chunk[:]) {
+				break
+			}
+		}
+	}
+	resetBlocks := func(blocks []Block) bool {
+		nb := sort.Search(len(blocks), func(i int) bool { return blocks[i].ID == 0 })
+		xb := blocks[:nb]
+		for i := range xb {
+			xb[i] = Block{}
+		}
+		return nb == len(blocks)
 	}
-	nb := sort.Search(len(c.blocks), func(i int) bool { return c.blocks[i].ID == 0 })
-	xb := c.blocks[:nb]
-	for i := range xb {
-		xb[i] = Block{}
+	if resetBlocks(c.blocks[:]) {
+		for _, chunk := range c.blockChunks {
+			if !resetBlocks(chunk[:]) {
+				break
+			}
+		}
 	}
 	nl := sort.Search(len(c.locs), func(i int) bool { return c.locs[i] == nil })
 	xl := c.locs[:nl]
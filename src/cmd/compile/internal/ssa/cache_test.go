@@ -0,0 +1,54 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"cmd/internal/src"
+	"testing"
+)
+
+// TestCacheChunks checks that the values of a large function come
+// from chunks that a Cache clears and reuses for the next function.
+func TestCacheChunks(t *testing.T) {
+	c := testConfig(t)
+	cache := new(Cache)
+	fill := func(n int) map[*Value]bool {
+		f := NewFunc(c.Frontend())
+		f.Config = c.config
+		f.Cache = cache
+		f.pass = &emptyPass
+		b := f.NewBlock(BlockPlain)
+		values := make(map[*Value]bool)
+		for i := 0; i < n; i++ {
+			values[b.NewValue0I(src.NoXPos, OpConst64, c.config.Types.Int64, int64(i))] = true
+		}
+		return values
+	}
+
+	n := 3*len(cache.values) + 10
+	first := fill(n)
+	if got, want := len(cache.valueChunks), 3; got != want {
+		t.Errorf("%d values use %d chunks, want %d", n, got, want)
+	}
+	cache.Reset()
+	for i, chunk := range cache.valueChunks {
+		for j := range chunk {
+			if chunk[j].ID != 0 || chunk[j].Block != nil {
+				t.Fatalf("chunk %d value %d not cleared by Reset: %v", i, j, chunk[j].LongString())
+			}
+		}
+	}
+	for v := range fill(n) {
+		if !first[v] {
+			t.Fatalf("value %v not reused from the previous function", v)
+		}
+	}
+
+	cache.Reset()
+	fill((maxCachedChunks + 2) * len(cache.values))
+	if got := len(cache.valueChunks); got != maxCachedChunks {
+		t.Errorf("cache kept %d chunks, want %d", got, maxCachedChunks)
+	}
+}
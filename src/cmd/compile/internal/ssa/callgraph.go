@@ -0,0 +1,47 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import "cmd/internal/obj"
+
+// CallWeights returns the functions that f calls directly, each with
+// an estimate of how often f calls it. A call outside any loop has
+// weight 1, and each enclosing loop, up to 4 of them, multiplies the
+// weight by 8. Calls in blocks that end the function with a panic are
+// not counted, since they are not worth keeping close to f.
+//
+// The linker uses these weights to place functions near their hot
+// callers.
+func (f *Func) CallWeights() map[*obj.LSym]int64 {
+	var ln *loopnest
+	var weights map[*obj.LSym]int64
+	for _, b := range f.Blocks {
+		if b.Kind == BlockExit {
+			continue
+		}
+		for _, v := range b.Values {
+			if !v.Op.IsCall() {
+				continue
+			}
+			aux, ok := v.Aux.(*AuxCall)
+			if !ok || aux.Fn == nil {
+				continue
+			}
+			if ln == nil {
+				ln = f.loopnest()
+				ln.calculateDepths()
+			}
+			d := ln.depth(b.ID)
+			if d > 4 {
+				d = 4
+			}
+			if weights == nil {
+				weights = make(map[*obj.LSym]int64)
+			}
+			weights[aux.Fn] += 1 << (3 * uint(d))
+		}
+	}
+	return weights
+}
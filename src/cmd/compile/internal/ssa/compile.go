@@ -6,13 +6,14 @@ package ssa
 
 import (
 	"bytes"
+	"cmd/compile/internal/base"
 	"cmd/internal/objabi"
 	"cmd/internal/src"
 	"fmt"
-	"hash/crc32"
+	"io/ioutil"
 	"log"
-	"math/rand"
 	"os"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"sort"
@@ -33,11 +34,8 @@ func Compile(f *Func) {
 		f.Logf("compiling %s\n", f.Name)
 	}
 
-	var rnd *rand.Rand
-	if checkEnabled {
-		seed := int64(crc32.ChecksumIEEE(([]byte)(f.Name))) ^ int64(checkRandSeed)
-		rnd = rand.New(rand.NewSource(seed))
-	}
+	rnd := stressRand(f)
+	stress := f.StressSeed != 0
 
 	// hook to print function & phase if panic happens
 	phaseName := "init"
@@ -54,6 +52,11 @@ func Compile(f *Func) {
 		}
 	}()
 
+	var nilChecks []src.XPos
+	if base.Debug.NilCheckReport != 0 {
+		nilChecks = nilCheckPositions(f)
+	}
+
 	// Run all the passes
 	if f.Log() {
 		printFunc(f)
@@ -62,12 +65,17 @@ func Compile(f *Func) {
 	if BuildDump != "" && BuildDump == f.Name {
 		f.dumpFile("build")
 	}
-	if checkEnabled {
+	if checkEnabled || stress {
 		checkFunc(f)
 	}
+	var dirBuf *bytes.Buffer
+	if base.Debug.SSADir != "" {
+		dirBuf = new(bytes.Buffer)
+	}
 	const logMemStats = false
+	var csvStats passStats
 	for _, p := range passes {
-		if !f.Config.optimize && !p.required || p.disabled {
+		if !f.Config.optimize && !p.required && !p.hook || p.disabled {
 			continue
 		}
 		f.pass = &p
@@ -77,19 +85,21 @@ func Compile(f *Func) {
 		}
 		// TODO: capture logging during this pass, add it to the HTML
 		var mStart runtime.MemStats
-		if logMemStats || p.mem {
+		if logMemStats || p.mem || statsCSV != nil {
 			runtime.ReadMemStats(&mStart)
 		}
 
-		if checkEnabled && !f.scheduled {
+		if rnd != nil && !f.scheduled {
 			// Test that we don't depend on the value order, by randomizing
 			// the order of values in each block. See issue 18169.
-			for _, b := range f.Blocks {
-				for i := 0; i < len(b.Values)-1; i++ {
-					j := i + rnd.Intn(len(b.Values)-i)
-					b.Values[i], b.Values[j] = b.Values[j], b.Values[i]
-				}
-			}
+			shuffleValues(f, rnd)
+		}
+		if stress && !f.laidout {
+			shuffleBlocks(f, rnd)
+		}
+
+		if stress && p.name == stressDigestPass {
+			f.StressDigest = stressDigest(f)
 		}
 
 		tStart := time.Now()
@@ -130,15 +140,39 @@ func Compile(f *Func) {
 				f.LogStat("TIME(ns):BYTES:ALLOCS", time, nBytes, nAllocs)
 			}
 		}
+		if statsCSV != nil {
+			var mEnd runtime.MemStats
+			runtime.ReadMemStats(&mEnd)
+			csvStats.add(f, p.name, tEnd.Sub(tStart), mEnd.Mallocs-mStart.Mallocs, mEnd.TotalAlloc-mStart.TotalAlloc)
+		}
 		if p.dump != nil && p.dump[f.Name] {
 			// Dump function to appropriately named file
 			f.dumpFile(phaseName)
 		}
-		if checkEnabled {
+		if dirBuf != nil && p.dirDump {
+			fmt.Fprintf(dirBuf, "# after %s\n", phaseName)
+			fprintFunc(stringFuncPrinter{w: dirBuf}, f)
+		}
+		if checkEnabled || stress {
 			checkFunc(f)
 		}
 	}
 
+	if statsCSV != nil {
+		csvStats.flush()
+	}
+
+	if base.Debug.NilCheckReport != 0 {
+		reportNilChecks(f, nilChecks)
+	}
+
+	if dirBuf != nil {
+		if !dirDumpAny {
+			fprintFunc(stringFuncPrinter{w: dirBuf}, f)
+		}
+		f.dumpDir(dirBuf.Bytes())
+	}
+
 	if f.HTMLWriter != nil {
 		// Ensure we write any pending phases to the html
 		f.HTMLWriter.flushPhases()
@@ -184,17 +218,39 @@ func (f *Func) dumpFile(phaseName string) {
 	fi.Close()
 }
 
+// dumpDir writes text, the SSA dumped while compiling f, to the file
+// for f in the -d=ssadir directory. Files are laid out as
+// <dir>/<package path>/<function name>.ssa, so the output of two
+// compilers can be compared with a recursive diff.
+func (f *Func) dumpDir(text []byte) {
+	dir := filepath.Join(base.Debug.SSADir, filepath.FromSlash(base.Ctxt.Pkgpath))
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		f.Warnl(src.NoXPos, "Unable to create SSA dump directory %s: %v", dir, err)
+		return
+	}
+	fname := filepath.Join(dir, dumpDirReplacer.Replace(f.Name)+".ssa")
+	if err := ioutil.WriteFile(fname, text, 0666); err != nil {
+		f.Warnl(src.NoXPos, "Unable to write SSA dump file %s: %v", fname, err)
+	}
+}
+
+// dumpDirReplacer maps characters that are not portable in file names
+// to underscores.
+var dumpDirReplacer = strings.NewReplacer(" ", "_", "/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_", "<", "_", ">", "_", "|", "_")
+
 type pass struct {
 	name     string
 	fn       func(*Func)
 	required bool
 	disabled bool
+	hook     bool            // registered with RegisterPassHook; runs regardless of optimization level
 	time     bool            // report time to run pass
 	mem      bool            // report mem stats to run pass
 	stats    int             // pass reports own "stats" (e.g., branches removed)
 	debug    int             // pass performs some debugging. =1 should be in error-testing-friendly Warnl format.
 	test     int             // pass-specific ad-hoc option, perhaps useful in development
 	dump     map[string]bool // dump if function name matches
+	dirDump  bool            // dump to the -d=ssadir directory after this pass
 }
 
 func (p *pass) addDump(s string) {
@@ -226,6 +282,10 @@ var BuildTest int
 var BuildStats int
 var BuildDump string // name of function to dump after initial build of ssa
 
+// dirDumpAny reports whether any pass has the "dir" flag set.
+// If not, -d=ssadir dumps only the final SSA of each function.
+var dirDumpAny bool
+
 // PhaseOption sets the specified flag in the specified ssa phase,
 // returning empty string if this was successful or a string explaining
 // the error if it was not.
@@ -269,19 +329,31 @@ where:
 ` + phasenames + `
 
 - <flag> is one of:
-    on, off, debug, mem, time, test, stats, dump, seed
+    on, off, debug, mem, time, test, stats, dump, dir, seed
 
 - <value> defaults to 1
 
 - <function_name> is required for the "dump" flag, and specifies the
   name of function to dump after <phase>
 
-Phase "all" supports flags "time", "mem", and "dump".
+Phase "all" supports flags "time", "mem", "dump", "dir", and "stats".
+Phases added with ssa.RegisterPassHook are off unless enabled with "on".
 Phase "intrinsics" supports flags "on", "off", and "debug".
 
 If the "dump" flag is specified, the output is written on a file named
 <phase>__<function_name>_<seq>.dump; otherwise it is directed to stdout.
 
+The "dir" flag, used with -d=ssadir=<directory>, adds the SSA of every
+function after <phase> to <directory>/<package>/<function_name>.ssa.
+Without it, -d=ssadir writes only the final SSA of each function.
+
+The "stats" flag of phase "all" takes a value csv:<file>, and appends
+to <file> one row per pass per function, with columns package,
+function, pass, values, blocks, allocs, bytes and ns: the number of
+values and blocks after the pass, and the allocations and time the
+pass took. Allocations are counted process-wide, so use -c=1 for
+exact figures.
+
 Examples:
 
     -d=ssa/check/on
@@ -297,6 +369,9 @@ enables time reporting for all phases
     -d=ssa/prove/debug=2
 sets debugging level to 2 in the prove pass
 
+    -d=ssa/all/stats=csv:/tmp/ssa.csv
+appends per-pass statistics for every function to /tmp/ssa.csv
+
 Multiple flags can be passed at once, by separating them with
 commas. For example:
 
@@ -325,17 +400,31 @@ commas. For example:
 	alltime := false
 	allmem := false
 	alldump := false
+	alldir := false
 	if phase == "all" {
 		switch flag {
 		case "time":
 			alltime = val != 0
 		case "mem":
 			allmem = val != 0
+		case "dir":
+			alldir = val != 0
 		case "dump":
 			alldump = val != 0
 			if alldump {
 				BuildDump = valString
 			}
+		case "stats":
+			name := strings.TrimPrefix(valString, "csv:")
+			if name == valString || name == "" {
+				return "-d=ssa/all/stats requires a value of the form csv:<file>"
+			}
+			c, err := openStatsCSV(name)
+			if err != nil {
+				return fmt.Sprintf("-d=ssa/all/stats: %v", err)
+			}
+			statsCSV = c
+			return ""
 		default:
 			return fmt.Sprintf("Did not find a flag matching %s in -d=ssa/%s debug option", flag, phase)
 		}
@@ -387,6 +476,9 @@ commas. For example:
 			if alldump {
 				p.addDump(valString)
 			}
+			if flag == "dir" {
+				p.dirDump = alldir
+			}
 			passes[i] = p
 			matchedOne = true
 		} else if p.name == phase || p.name == underphase || re != nil && re.MatchString(p.name) {
@@ -407,6 +499,8 @@ commas. For example:
 				p.test = val
 			case "dump":
 				p.addDump(valString)
+			case "dir":
+				p.dirDump = val != 0
 			default:
 				return fmt.Sprintf("Did not find a flag matching %s in -d=ssa/%s debug option", flag, phase)
 			}
@@ -418,13 +512,18 @@ commas. For example:
 		}
 	}
 	if matchedOne {
+		dirDumpAny = false
+		for _, p := range passes {
+			dirDumpAny = dirDumpAny || p.dirDump
+		}
 		return ""
 	}
 	return fmt.Sprintf("Did not find a phase matching %s in -d=ssa/... debug option", phase)
 }
 
 // list of passes for the compiler
-var passes = [...]pass{
+// RegisterPassHook may insert additional passes.
+var passes = []pass{
 	// TODO: combine phielim and copyelim into a single pass?
 	{name: "number lines", fn: numberLines, required: true},
 	{name: "early phielim", fn: phielim},
@@ -436,11 +535,14 @@ var passes = [...]pass{
 	{name: "opt", fn: opt, required: true},               // NB: some generic rules know the name of the opt pass. TODO: split required rules and optimizing rules
 	{name: "zero arg cse", fn: zcse, required: true},     // required to merge OpSB values
 	{name: "opt deadcode", fn: deadcode, required: true}, // remove any blocks orphaned during opt
+	{name: "sroa", fn: sroa},
 	{name: "generic cse", fn: cse},
+	{name: "pure calls", fn: pureCalls},
 	{name: "phiopt", fn: phiopt},
 	{name: "gcse deadcode", fn: deadcode, required: true}, // clean out after cse and phiopt
 	{name: "nilcheckelim", fn: nilcheckelim},
 	{name: "prove", fn: prove},
+	{name: "unroll", fn: unroll},
 	{name: "early fuse", fn: fuseEarly},
 	{name: "decompose builtin", fn: decomposeBuiltIn, required: true},
 	{name: "expand calls", fn: expandCalls, required: true},
@@ -448,9 +550,12 @@ var passes = [...]pass{
 	{name: "late opt", fn: opt, required: true}, // TODO: split required rules and optimizing rules
 	{name: "dead auto elim", fn: elimDeadAutosGeneric},
 	{name: "generic deadcode", fn: deadcode, required: true}, // remove dead stores, which otherwise mess up store chain
+	{name: "licm", fn: licm},                                 // hoist loop-invariant values out of loops
+	{name: "divreduce", fn: divreduce},                       // multiply by reciprocals of loop-invariant divisors
 	{name: "check bce", fn: checkbce},
 	{name: "branchelim", fn: branchelim},
 	{name: "late fuse", fn: fuseLate},
+	{name: "memcombine", fn: memcombine}, // combine adjacent narrow loads and stores
 	{name: "dse", fn: dse},
 	{name: "writebarrier", fn: writebarrier, required: true}, // expand write barrier ops
 	{name: "insert resched checks", fn: insertLoopReschedChecks,
@@ -495,8 +600,16 @@ var passOrder = [...]constraint{
 	{"insert resched checks", "lower"},
 	{"insert resched checks", "tighten"},
 
+	// sroa needs opt to decompose loads and stores of SSA-able aggregates.
+	{"opt", "sroa"},
+	// sroa can expose more common subexpressions.
+	{"sroa", "generic cse"},
 	// prove relies on common-subexpression elimination for maximum benefits.
 	{"generic cse", "prove"},
+	// pure calls compares the arguments of calls, which must be CSEd first
+	{"generic cse", "pure calls"},
+	// pure calls works on the calls before their arguments are expanded
+	{"pure calls", "expand calls"},
 	// deadcode after prove to eliminate all new dead blocks.
 	{"prove", "generic deadcode"},
 	// common-subexpression before dead-store elim, so that we recognize
@@ -508,6 +621,10 @@ var passOrder = [...]constraint{
 	{"nilcheckelim", "generic deadcode"},
 	// nilcheckelim generates sequences of plain basic blocks
 	{"nilcheckelim", "late fuse"},
+	// memcombine needs the loads and stores of a run in one block
+	{"late fuse", "memcombine"},
+	// memcombine must see stores before they get write barriers
+	{"memcombine", "writebarrier"},
 	// nilcheckelim relies on opt to rewrite user nil checks
 	{"opt", "nilcheckelim"},
 	// tighten will be most effective when as many values have been removed as possible
@@ -48,6 +48,8 @@ type Config struct {
 	Race           bool        // race detector enabled
 	BigEndian      bool        //
 	UseFMA         bool        // Use hardware FMA operation
+	unalignedOK    bool        // Unaligned loads and stores are allowed and fast
+	haveBswap      bool        // Has Bswap32 and Bswap64 operations
 }
 
 type (
@@ -204,6 +206,8 @@ func NewConfig(arch string, types Types, ctxt *obj.Link, optimize bool) *Config
 		c.FPReg = framepointerRegAMD64
 		c.LinkReg = linkRegAMD64
 		c.hasGReg = objabi.Experiment.RegabiG
+		c.unalignedOK = true
+		c.haveBswap = true
 	case "386":
 		c.PtrSize = 4
 		c.RegSize = 4
@@ -238,6 +242,8 @@ func NewConfig(arch string, types Types, ctxt *obj.Link, optimize bool) *Config
 		c.FPReg = framepointerRegARM64
 		c.LinkReg = linkRegARM64
 		c.hasGReg = true
+		c.unalignedOK = true
+		c.haveBswap = true
 		c.noDuffDevice = objabi.GOOS == "darwin" || objabi.GOOS == "ios" // darwin linker cannot handle BR26 reloc with non-zero addend
 	case "ppc64":
 		c.BigEndian = true
@@ -254,6 +260,7 @@ func NewConfig(arch string, types Types, ctxt *obj.Link, optimize bool) *Config
 		c.LinkReg = linkRegPPC64
 		c.noDuffDevice = true // TODO: Resolve PPC64 DuffDevice (has zero, but not copy)
 		c.hasGReg = true
+		c.unalignedOK = true
 	case "mips64":
 		c.BigEndian = true
 		fallthrough
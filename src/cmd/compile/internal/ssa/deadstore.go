@@ -8,13 +8,17 @@ import (
 	"cmd/compile/internal/ir"
 	"cmd/compile/internal/types"
 	"cmd/internal/src"
+	"sort"
 )
 
 // dse does dead-store elimination on the Function.
 // Dead stores are those which are unconditionally followed by
 // another store to the same location, with no intervening load.
-// This implementation only works within a basic block. TODO: use something more global.
+// This implementation only works within a basic block;
+// dseAutos handles stores to autos across blocks.
 func dse(f *Func) {
+	dseAutos(f)
+
 	var stores []*Value
 	loadUse := f.newSparseSet(f.NumValues())
 	defer f.retSparseSet(loadUse)
@@ -132,6 +136,361 @@ func dse(f *Func) {
 	}
 }
 
+// maxDSEAutoFields is the maximum number of fields of an auto
+// tracked by dseAutos.
+const maxDSEAutoFields = 64
+
+// dseAutos removes stores to autos that are overwritten on every path
+// before they are read. It tracks the liveness of the fields of each
+// auto across blocks, so it removes, for example, the zeroing of a
+// composite literal whose fields are all assigned in both arms of a
+// later conditional.
+//
+// A field here is a range of the auto between the boundaries of the
+// loads and stores that access it at constant offsets. Reads through
+// any other pointer are assumed to read all of every auto whose
+// address escapes (including all address-taken autos). Writes through
+// other pointers are ignored, as they might not write the auto.
+// Calls, and other operations that might reach a safepoint, are also
+// assumed to read the pointer fields of every auto, since the garbage
+// collector may scan them there.
+func dseAutos(f *Func) {
+	type auto struct {
+		n       *ir.Name
+		bounds  []int64 // field i is [bounds[i], bounds[i+1])
+		escaped bool    // accessed through unknown pointers
+		keep    bool    // used by VarLive; see elimDeadAutosGeneric
+	}
+	var autos []*auto
+	index := map[*ir.Name]int32{}
+	lookup := func(n *ir.Name) *auto {
+		if n.Class != ir.PAUTO {
+			return nil
+		}
+		i, ok := index[n]
+		if !ok {
+			i = int32(len(autos))
+			index[n] = i
+			autos = append(autos, &auto{n: n, escaped: n.Addrtaken()})
+		}
+		return autos[i]
+	}
+
+	// addr maps pointers to the auto they point into.
+	type addrInfo struct {
+		a   *auto
+		off int64
+	}
+	addr := map[*Value]addrInfo{}
+	var addrOf func(v *Value) (addrInfo, bool)
+	addrOf = func(v *Value) (addrInfo, bool) {
+		if p, ok := addr[v]; ok {
+			return p, p.a != nil
+		}
+		var p addrInfo
+		switch v.Op {
+		case OpLocalAddr:
+			if n, ok := v.Aux.(*ir.Name); ok {
+				if a := lookup(n); a != nil {
+					p = addrInfo{a: a}
+				}
+			}
+		case OpOffPtr:
+			if q, ok := addrOf(v.Args[0]); ok {
+				p = addrInfo{a: q.a, off: q.off + v.AuxInt}
+			}
+		}
+		addr[v] = p
+		return p, p.a != nil
+	}
+
+	// Find the field boundaries of each auto, and the autos whose
+	// address is used in some other way.
+	access := func(p addrInfo, size int64) {
+		p.a.bounds = append(p.a.bounds, p.off, p.off+size)
+	}
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			switch v.Op {
+			case OpVarDef, OpVarKill, OpVarLive:
+				if n, ok := v.Aux.(*ir.Name); ok {
+					if a := lookup(n); a != nil && v.Op == OpVarLive {
+						a.keep = true
+					}
+				}
+				continue
+			case OpLocalAddr:
+				continue
+			}
+			if n, ok := v.Aux.(*ir.Name); ok {
+				if a := lookup(n); a != nil {
+					a.escaped = true
+				}
+			}
+			for i, x := range v.Args {
+				p, ok := addrOf(x)
+				if !ok {
+					continue
+				}
+				switch {
+				case v.Op == OpOffPtr:
+				case i == 0 && v.Op == OpLoad:
+					access(p, v.Type.Size())
+				case i == 0 && v.Op == OpStore:
+					access(p, v.Aux.(*types.Type).Size())
+				case i == 0 && v.Op == OpZero, i <= 1 && v.Op == OpMove:
+					access(p, v.AuxInt)
+				default:
+					p.a.escaped = true
+				}
+			}
+		}
+		for _, c := range b.ControlValues() {
+			if p, ok := addrOf(c); ok {
+				p.a.escaped = true
+			}
+		}
+	}
+	if len(autos) == 0 || len(autos)*f.NumBlocks() > 1<<20 {
+		return
+	}
+	for _, a := range autos {
+		a.bounds = append(a.bounds, 0, a.n.Type().Size())
+		sort.Slice(a.bounds, func(i, j int) bool { return a.bounds[i] < a.bounds[j] })
+		j := 0
+		for _, x := range a.bounds {
+			if j > 0 && x == a.bounds[j-1] {
+				continue
+			}
+			a.bounds[j] = x
+			j++
+		}
+		a.bounds = a.bounds[:j]
+	}
+	all := func(a *auto) uint64 {
+		return 1<<uint(len(a.bounds)-1) - 1
+	}
+	fields := func(p addrInfo, size int64) uint64 {
+		a := p.a
+		var m uint64
+		for i := 0; i < len(a.bounds)-1; i++ {
+			if a.bounds[i] < p.off+size && p.off < a.bounds[i+1] {
+				m |= 1 << uint(i)
+			}
+		}
+		return m
+	}
+
+	// An effect is a read or write of some fields of an auto.
+	// Effects are listed in reverse execution order for each block.
+	type effect struct {
+		a     int32  // index of the auto, or -1 for an unknown read
+		mask  uint64 // fields read or written
+		write bool
+		v     *Value // the store, if it may be removed
+	}
+	effects := make([][]effect, f.NumBlocks())
+	var list []effect
+	read := func(p addrInfo, size int64, ok bool) {
+		if !ok {
+			list = append(list, effect{a: -1})
+			return
+		}
+		if len(p.a.bounds)-1 > maxDSEAutoFields {
+			p.a.escaped = true
+			list = append(list, effect{a: -1})
+			return
+		}
+		list = append(list, effect{a: index[p.a.n], mask: fields(p, size)})
+	}
+	write := func(v *Value, p addrInfo, size int64, ok bool) {
+		if ok && !p.a.keep && len(p.a.bounds)-1 <= maxDSEAutoFields {
+			list = append(list, effect{a: index[p.a.n], mask: fields(p, size), write: true, v: v})
+		}
+	}
+	// readers lists the values that read each memory state,
+	// other than the memory operations that produce the next state.
+	readers := map[*Value][]*Value{}
+	next := f.newSparseSet(f.NumValues())
+	defer f.retSparseSet(next)
+	prev := func(v *Value) *Value {
+		switch v.Op {
+		case OpPhi:
+			return nil
+		case OpSelect1, OpSelectN:
+			if c := v.Args[0]; !c.Type.IsMemory() {
+				return c.MemoryArg()
+			}
+		}
+		return v.MemoryArg()
+	}
+	for _, b := range f.Blocks {
+		for k := range readers {
+			delete(readers, k)
+		}
+		next.clear()
+		var entry []*Value
+		var last *Value
+		for _, v := range b.Values {
+			if v.Type.IsMemory() {
+				if m := prev(v); m != nil && m.Block == b {
+					next.add(m.ID)
+				}
+				continue
+			}
+			if v.Op == OpPhi || v.Op == OpLocalAddr || v.Op == OpInlMark {
+				continue
+			}
+			m := v.MemoryArg()
+			if m == nil {
+				continue
+			}
+			if m.Block == b {
+				readers[m] = append(readers[m], v)
+			} else {
+				entry = append(entry, v)
+			}
+		}
+		for _, v := range b.Values {
+			if v.Type.IsMemory() && !next.contains(v.ID) {
+				last = v
+			}
+		}
+
+		list = list[:0]
+		reads := func(r *Value) {
+			if r.Op == OpLoad {
+				p, ok := addrOf(r.Args[0])
+				read(p, r.Type.Size(), ok)
+				return
+			}
+			list = append(list, effect{a: -1})
+		}
+		for v := last; v != nil && v.Block == b; v = prev(v) {
+			for _, r := range readers[v] {
+				reads(r)
+			}
+			switch v.Op {
+			case OpPhi, OpCopy, OpSelect1, OpSelectN, OpVarKill:
+				// Loads may be scheduled after a VarKill,
+				// so it does not end the life of the auto.
+			case OpStore:
+				p, ok := addrOf(v.Args[0])
+				write(v, p, v.Aux.(*types.Type).Size(), ok)
+			case OpZero:
+				p, ok := addrOf(v.Args[0])
+				write(v, p, v.AuxInt, ok)
+			case OpMove:
+				p, ok := addrOf(v.Args[0])
+				write(v, p, v.AuxInt, ok)
+				p, ok = addrOf(v.Args[1])
+				read(p, v.AuxInt, ok)
+			case OpVarDef, OpVarLive:
+				n, ok := v.Aux.(*ir.Name)
+				if !ok || n.Class != ir.PAUTO {
+					break
+				}
+				a := autos[index[n]]
+				if len(a.bounds)-1 > maxDSEAutoFields {
+					break
+				}
+				list = append(list, effect{a: index[n], mask: all(a), write: v.Op != OpVarLive})
+			default:
+				// Calls and other operations that may
+				// read memory.
+				list = append(list, effect{a: -1})
+			}
+		}
+		for _, r := range entry {
+			reads(r)
+		}
+		effects[b.ID] = append([]effect(nil), list...)
+	}
+
+	var escaped []int32
+	ptrs := make([]uint64, len(autos)) // fields that may contain pointers
+	for i, a := range autos {
+		if a.escaped || len(a.bounds)-1 > maxDSEAutoFields {
+			escaped = append(escaped, int32(i))
+			continue
+		}
+		if size := types.PtrDataSize(a.n.Type()); size > 0 {
+			ptrs[i] = fields(addrInfo{a: a}, size)
+		}
+	}
+
+	// transfer computes the live fields at the start of b from the
+	// live fields at its end. If dead is not nil, it is called for
+	// each removable store of fields that are not live.
+	transfer := func(b *Block, live []uint64, dead func(v *Value, a int32)) {
+		for _, e := range effects[b.ID] {
+			switch {
+			case e.a < 0:
+				for i, m := range ptrs {
+					live[i] |= m
+				}
+				for _, i := range escaped {
+					live[i] = all(autos[i])
+				}
+			case e.write:
+				if e.v != nil && live[e.a]&e.mask == 0 && dead != nil {
+					dead(e.v, e.a)
+				}
+				live[e.a] &^= e.mask
+			default:
+				live[e.a] |= e.mask
+			}
+		}
+	}
+
+	// Compute the live fields at the start of each block.
+	n := len(autos)
+	liveIn := make([]uint64, f.NumBlocks()*n)
+	in := func(b *Block) []uint64 {
+		return liveIn[int(b.ID)*n : int(b.ID+1)*n]
+	}
+	live := make([]uint64, n)
+	out := func(b *Block) {
+		for i := range live {
+			live[i] = 0
+		}
+		for _, e := range b.Succs {
+			for i, x := range in(e.b) {
+				live[i] |= x
+			}
+		}
+	}
+	po := f.postorder()
+	for changed := true; changed; {
+		changed = false
+		for _, b := range po {
+			out(b)
+			transfer(b, live, nil)
+			old := in(b)
+			for i, x := range live {
+				if x != old[i] {
+					old[i] = x
+					changed = true
+				}
+			}
+		}
+	}
+
+	// Remove the dead stores.
+	for _, b := range po {
+		out(b)
+		transfer(b, live, func(v *Value, a int32) {
+			if f.pass.debug > 0 {
+				f.Warnl(v.Pos, "removed dead store to %v", autos[a].n)
+			}
+			v.SetArgs1(v.MemoryArg())
+			v.Aux = nil
+			v.AuxInt = 0
+			v.Op = OpCopy
+		})
+	}
+}
+
 // elimDeadAutosGeneric deletes autos that are never accessed. To achieve this
 // we track the operations that the address of each auto reaches and if it only
 // reaches stores then we delete all the stores. The other operations will then
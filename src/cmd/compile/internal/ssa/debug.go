@@ -30,6 +30,10 @@ type FuncDebug struct {
 	VarSlots [][]SlotID
 	// The location list data, indexed by VarID. Must be processed by PutLocationList.
 	LocationLists [][]byte
+	// User variables that were optimized into registers, or away
+	// entirely, and so are not in the function's declarations.
+	// Filled in by the user.
+	OptimizedOut []*ir.Name
 
 	// Filled in by the user. Translates Block and Value ID to PC.
 	GetPC func(ID, ID) int64
@@ -1080,6 +1084,11 @@ func (state *debugState) writePendingEntry(varID VarID, endBlock, endValue ID) {
 func (debugInfo *FuncDebug) PutLocationList(list []byte, ctxt *obj.Link, listSym, startPC *obj.LSym) {
 	getPC := debugInfo.GetPC
 
+	if dwarf.UseDWARF5() {
+		debugInfo.putLocationList5(list, ctxt, listSym, startPC)
+		return
+	}
+
 	if ctxt.UseBASEntries {
 		listSym.WriteInt(ctxt, listSym.Size, ctxt.Arch.PtrSize, ^0)
 		listSym.WriteAddr(ctxt, listSym.Size, ctxt.Arch.PtrSize, startPC, 0)
@@ -1118,6 +1127,55 @@ func (debugInfo *FuncDebug) PutLocationList(list []byte, ctxt *obj.Link, listSym
 	listSym.WriteInt(ctxt, listSym.Size, ctxt.Arch.PtrSize, 0)
 }
 
+// putLocationList5 is like PutLocationList, but writes a DWARF 5
+// location list: a base address entry for startPC, followed by offset
+// pair entries relative to it.
+func (debugInfo *FuncDebug) putLocationList5(list []byte, ctxt *obj.Link, listSym, startPC *obj.LSym) {
+	getPC := debugInfo.GetPC
+
+	listSym.WriteInt(ctxt, listSym.Size, 1, dwarf.DW_LLE_base_address)
+	listSym.WriteAddr(ctxt, listSym.Size, ctxt.Arch.PtrSize, startPC, 0)
+
+	var buf []byte
+	for i := 0; i < len(list); {
+		begin := getPC(decodeValue(ctxt, readPtr(ctxt, list[i:])))
+		end := getPC(decodeValue(ctxt, readPtr(ctxt, list[i+ctxt.Arch.PtrSize:])))
+		if begin == 0 && end == 0 {
+			end = 1 // see PutLocationList
+		}
+		i += 2 * ctxt.Arch.PtrSize
+		datalen := int(ctxt.Arch.ByteOrder.Uint16(list[i:]))
+		i += 2
+
+		buf = append(buf[:0], dwarf.DW_LLE_offset_pair)
+		buf = dwarf.AppendUleb128(buf, uint64(begin))
+		buf = dwarf.AppendUleb128(buf, uint64(end))
+		buf = dwarf.AppendUleb128(buf, uint64(datalen))
+		buf = append(buf, list[i:i+datalen]...)
+		listSym.WriteBytes(ctxt, listSym.Size, buf)
+		i += datalen
+	}
+	listSym.WriteInt(ctxt, listSym.Size, 1, dwarf.DW_LLE_end_of_list)
+}
+
+// LocationListCoverage returns the number of bytes of code covered by
+// the entries of list, a location list in its intermediate representation.
+func (debugInfo *FuncDebug) LocationListCoverage(list []byte, ctxt *obj.Link) int64 {
+	getPC := debugInfo.GetPC
+	var covered int64
+	for i := 0; i < len(list); {
+		begin := getPC(decodeValue(ctxt, readPtr(ctxt, list[i:])))
+		end := getPC(decodeValue(ctxt, readPtr(ctxt, list[i+ctxt.Arch.PtrSize:])))
+		if begin == 0 && end == 0 {
+			end = 1 // see PutLocationList
+		}
+		covered += end - begin
+		i += 2 * ctxt.Arch.PtrSize
+		i += 2 + int(ctxt.Arch.ByteOrder.Uint16(list[i:]))
+	}
+	return covered
+}
+
 // Pack a value and block ID into an address-sized uint, returning ~0 if they
 // don't fit.
 func encodeValue(ctxt *obj.Link, b, v ID) (uint64, bool) {
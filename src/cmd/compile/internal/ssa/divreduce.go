@@ -0,0 +1,166 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+)
+
+// divreduce replaces integer divisions in loops whose divisor is
+// loop-invariant, but not constant, with a multiplication by a
+// reciprocal computed once before the loop.
+//
+// For a divisor d, the loop's preheader computes
+//
+//	l = bits.Len64(d-1)
+//	m = 2**64*(2**l-d)/d + 1
+//	s1 = min(l, 1)
+//	s2 = l - s1
+//
+// and each division n/d in the loop becomes
+//
+//	t = (m*n) >> 64
+//	q = (t + (n-t)>>s1) >> s2
+//
+// (see Granlund and Montgomery, "Division by Invariant Integers using
+// Multiplication", figure 4.1). Signed divisions divide the absolute
+// values and fix up the sign of the quotient. Remainders are computed
+// as n - q*d. 32-bit divisions are done in 64 bits.
+//
+// Computing m needs a 128-by-64 bit division, so this is only done on
+// amd64. A zero divisor is replaced by 1 in the preheader; the check
+// for it stays in the loop.
+func divreduce(f *Func) {
+	if f.Config.arch != "amd64" {
+		return
+	}
+	var divs []*Value
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			switch v.Op {
+			case OpDiv64, OpDiv64u, OpMod64, OpMod64u, OpDiv32, OpDiv32u, OpMod32, OpMod32u:
+				if !v.Args[1].isGenericIntConst() {
+					divs = append(divs, v)
+				}
+			}
+		}
+	}
+	if len(divs) == 0 {
+		return
+	}
+	ln := f.loopnest()
+	if ln.hasIrreducible {
+		return
+	}
+
+	type key struct {
+		d   *Value
+		pre *Block
+	}
+	type recip struct {
+		d, m, s1, s2 *Value // d is the absolute value of the divisor
+	}
+	recips := map[key]recip{}
+	typ := &f.Config.Types
+	for _, v := range divs {
+		d := v.Args[1]
+		// Find the outermost loop in which d is invariant
+		// and that has a preheader.
+		var pre *Block
+		for l := ln.b2l[v.Block.ID]; l != nil; l = l.outer {
+			if ln.b2l[d.Block.ID].isWithinOrEq(l) {
+				break
+			}
+			if p := ln.preheader(l); p != nil {
+				pre = p
+			}
+		}
+		if pre == nil {
+			continue
+		}
+		signed := false
+		switch v.Op {
+		case OpDiv64, OpMod64, OpDiv32, OpMod32:
+			signed = true
+		}
+
+		r, ok := recips[key{d, pre}]
+		if !ok {
+			pos := pre.Pos
+			x := extendDivArg(pre, pos, d, signed)
+			if signed {
+				x = absDivArg(pre, pos, x)
+			}
+			zero := f.ConstInt64(typ.UInt64, 0)
+			one := f.ConstInt64(typ.UInt64, 1)
+			isZero := pre.NewValue2(pos, OpEq64, typ.Bool, x, zero)
+			x = pre.NewValue2(pos, OpOr64, typ.UInt64, x, boolToUint64(pre, pos, isZero))
+			l := pre.NewValue1(pos, OpBitLen64, typ.Int, pre.NewValue2(pos, OpSub64, typ.UInt64, x, one))
+			hi := pre.NewValue2(pos, OpSub64, typ.UInt64, pre.NewValue2(pos, OpLsh64x64, typ.UInt64, one, l), x)
+			div := pre.NewValue3(pos, OpDiv128u, types.NewTuple(typ.UInt64, typ.UInt64), hi, zero, x)
+			m := pre.NewValue2(pos, OpAdd64, typ.UInt64, pre.NewValue1(pos, OpSelect0, typ.UInt64, div), one)
+			s1 := boolToUint64(pre, pos, pre.NewValue2(pos, OpNeq64, typ.Bool, l, f.ConstInt64(typ.Int, 0)))
+			s2 := pre.NewValue2(pos, OpSub64, typ.UInt64, l, s1)
+			r = recip{d: x, m: m, s1: s1, s2: s2}
+			recips[key{d, pre}] = r
+		}
+
+		if f.pass.debug > 0 {
+			f.Warnl(v.Pos, "reduced %v by loop-invariant divisor", v.Op)
+		}
+		b, pos := v.Block, v.Pos
+		n := extendDivArg(b, pos, v.Args[0], signed)
+		a := n
+		if signed {
+			a = absDivArg(b, pos, n)
+		}
+		t := b.NewValue2(pos, OpHmul64u, typ.UInt64, r.m, a)
+		q := b.NewValue2I(pos, OpRsh64Ux64, typ.UInt64, 1, b.NewValue2(pos, OpSub64, typ.UInt64, a, t), r.s1)
+		q = b.NewValue2I(pos, OpRsh64Ux64, typ.UInt64, 1, b.NewValue2(pos, OpAdd64, typ.UInt64, t, q), r.s2)
+		if signed {
+			// The quotient is negative if exactly one of
+			// the operands is.
+			c63 := f.ConstInt64(typ.UInt64, 63)
+			dd := extendDivArg(b, pos, d, true)
+			s := b.NewValue2I(pos, OpRsh64x64, typ.Int64, 1, b.NewValue2(pos, OpXor64, typ.Int64, n, dd), c63)
+			q = b.NewValue2(pos, OpSub64, typ.Int64, b.NewValue2(pos, OpXor64, typ.Int64, q, s), s)
+		}
+		switch v.Op {
+		case OpMod64, OpMod64u, OpMod32, OpMod32u:
+			dd := extendDivArg(b, pos, d, signed)
+			q = b.NewValue2(pos, OpSub64, q.Type, n, b.NewValue2(pos, OpMul64, q.Type, q, dd))
+		}
+		if v.Type.Size() == 4 {
+			q = b.NewValue1(pos, OpTrunc64to32, v.Type, q)
+		}
+		v.copyOf(q)
+	}
+}
+
+// extendDivArg returns the 64-bit extension of the division operand x.
+func extendDivArg(b *Block, pos src.XPos, x *Value, signed bool) *Value {
+	typ := &b.Func.Config.Types
+	switch {
+	case x.Type.Size() == 8:
+		return x
+	case signed:
+		return b.NewValue1(pos, OpSignExt32to64, typ.Int64, x)
+	}
+	return b.NewValue1(pos, OpZeroExt32to64, typ.UInt64, x)
+}
+
+// absDivArg returns the absolute value of the 64-bit signed integer x.
+func absDivArg(b *Block, pos src.XPos, x *Value) *Value {
+	typ := &b.Func.Config.Types
+	s := b.NewValue2I(pos, OpRsh64x64, typ.Int64, 1, x, b.Func.ConstInt64(typ.UInt64, 63))
+	return b.NewValue2(pos, OpSub64, typ.UInt64, b.NewValue2(pos, OpXor64, typ.UInt64, x, s), s)
+}
+
+// boolToUint64 returns 1 if the boolean x is true and 0 otherwise.
+func boolToUint64(b *Block, pos src.XPos, x *Value) *Value {
+	typ := &b.Func.Config.Types
+	return b.NewValue1(pos, OpZeroExt8to64, typ.UInt64, b.NewValue1(pos, OpCvtBoolToUint8, typ.UInt8, x))
+}
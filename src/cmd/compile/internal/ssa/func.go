@@ -49,6 +49,12 @@ type Func struct {
 	ABISelf        *abi.ABIConfig // ABI for function being compiled
 	ABIDefault     *abi.ABIConfig // ABI for rtcall and other no-parsed-signature/pragma functions.
 
+	// StressSeed, if nonzero, stress-tests the compilation of this
+	// function with that seed, and StressDigest receives a summary of
+	// the result to compare across seeds. See -d=ssastress.
+	StressSeed   int64
+	StressDigest string
+
 	scheduled   bool  // Values in Blocks are in final order
 	laidout     bool  // Blocks are ordered
 	NoSplit     bool  // true if function is marked as nosplit.  Used by schedule check pass.
@@ -74,6 +80,10 @@ type Func struct {
 	// below the two successors of this block.
 	WBLoads []*Block
 
+	// WBStores lists the stores that received write barriers,
+	// for -d=wb=json.
+	WBStores []WBStore
+
 	freeValues *Value // free Values linked by argstorage[0].  All other fields except ID are 0/nil.
 	freeBlocks *Block // free Blocks linked by succstorage[0].b.  All other fields except ID are 0/nil.
 
@@ -202,12 +212,8 @@ func (f *Func) newValue(op Op, t *types.Type, b *Block, pos src.XPos) *Value {
 		v.argstorage[0] = nil
 	} else {
 		ID := f.vid.get()
-		if int(ID) < len(f.Cache.values) {
-			v = &f.Cache.values[ID]
-			v.ID = ID
-		} else {
-			v = &Value{ID: ID}
-		}
+		v = f.Cache.value(ID)
+		v.ID = ID
 	}
 	v.Op = op
 	v.Type = t
@@ -232,12 +238,8 @@ func (f *Func) newValueNoBlock(op Op, t *types.Type, pos src.XPos) *Value {
 		v.argstorage[0] = nil
 	} else {
 		ID := f.vid.get()
-		if int(ID) < len(f.Cache.values) {
-			v = &f.Cache.values[ID]
-			v.ID = ID
-		} else {
-			v = &Value{ID: ID}
-		}
+		v = f.Cache.value(ID)
+		v.ID = ID
 	}
 	v.Op = op
 	v.Type = t
@@ -341,12 +343,8 @@ func (f *Func) NewBlock(kind BlockKind) *Block {
 		b.succstorage[0].b = nil
 	} else {
 		ID := f.bid.get()
-		if int(ID) < len(f.Cache.blocks) {
-			b = &f.Cache.blocks[ID]
-			b.ID = ID
-		} else {
-			b = &Block{ID: ID}
-		}
+		b = f.Cache.block(ID)
+		b.ID = ID
 	}
 	b.Kind = kind
 	b.Func = f
@@ -166,19 +166,32 @@ func isEmpty(b *Block) bool {
 	return true
 }
 
+// fuseBlockPlain joins b, a plain block, and its successor if b is
+// its only predecessor. It joins the whole chain of such blocks b is
+// part of at once: joining them one pair at a time copies the values
+// of the growing block each time, which is quadratic in the length
+// of the chain, and long chains come up in the initialization of
+// huge composite literals.
 func fuseBlockPlain(b *Block) bool {
 	if b.Kind != BlockPlain {
 		return false
 	}
 
 	c := b.Succs[0].b
-	if len(c.Preds) != 1 {
+	if len(c.Preds) != 1 || c == b {
 		return false
 	}
 
+	chain := plainChain(b)
+	last := chain[len(chain)-1]
+
 	// If a block happened to end in a statement marker,
 	// try to preserve it.
-	if b.Pos.IsStmt() == src.PosIsStmt {
+	for i, b := range chain[:len(chain)-1] {
+		if b.Pos.IsStmt() != src.PosIsStmt {
+			continue
+		}
+		c := chain[i+1]
 		l := b.Pos.Line()
 		for _, v := range c.Values {
 			if v.Pos.IsStmt() == src.PosNotStmt {
@@ -195,56 +208,92 @@ func fuseBlockPlain(b *Block) bool {
 		}
 	}
 
-	// move all of b's values to c.
-	for _, v := range b.Values {
-		v.Block = c
-	}
-	// Use whichever value slice is larger, in the hopes of avoiding growth.
-	// However, take care to avoid c.Values pointing to b.valstorage.
-	// See golang.org/issue/18602.
-	// It's important to keep the elements in the same order; maintenance of
-	// debugging information depends on the order of *Values in Blocks.
-	// This can also cause changes in the order (which may affect other
-	// optimizations and possibly compiler output) for 32-vs-64 bit compilation
-	// platforms (word size affects allocation bucket size affects slice capacity).
-	if cap(c.Values) >= cap(b.Values) || len(b.Values) <= len(b.valstorage) {
-		bl := len(b.Values)
-		cl := len(c.Values)
-		var t []*Value // construct t = b.Values followed-by c.Values, but with attention to allocation.
-		if cap(c.Values) < bl+cl {
-			// reallocate
-			t = make([]*Value, bl+cl)
-		} else {
-			// in place.
-			t = c.Values[0 : bl+cl]
+	// Move all the values to last, keeping them in the same order;
+	// maintenance of debugging information depends on the order of
+	// *Values in Blocks.
+	n := 0
+	for _, b := range chain {
+		n += len(b.Values)
+		if b != last {
+			for _, v := range b.Values {
+				v.Block = last
+			}
 		}
-		copy(t[bl:], c.Values) // possibly in-place
-		c.Values = t
-		copy(c.Values, b.Values)
-	} else {
-		c.Values = append(b.Values, c.Values...)
+	}
+	head := chain[0]
+	switch {
+	case cap(last.Values) >= n:
+		// In place.
+		t := last.Values[:n]
+		copy(t[n-len(last.Values):], last.Values)
+		i := 0
+		for _, b := range chain[:len(chain)-1] {
+			i += copy(t[i:], b.Values)
+		}
+		last.Values = t
+	case len(head.Values) > len(head.valstorage):
+		// Grow the values of head, taking care to avoid
+		// last.Values pointing to head.valstorage.
+		// See golang.org/issue/18602.
+		t := head.Values
+		for _, b := range chain[1:] {
+			t = append(t, b.Values...)
+		}
+		last.Values = t
+	default:
+		t := make([]*Value, 0, n)
+		for _, b := range chain {
+			t = append(t, b.Values...)
+		}
+		last.Values = t
 	}
 
-	// replace b->c edge with preds(b) -> c
-	c.predstorage[0] = Edge{}
-	if len(b.Preds) > len(b.predstorage) {
-		c.Preds = b.Preds
+	// replace the chain by last: preds(head) -> last
+	last.predstorage[0] = Edge{}
+	if len(head.Preds) > len(head.predstorage) {
+		last.Preds = head.Preds
 	} else {
-		c.Preds = append(c.predstorage[:0], b.Preds...)
+		last.Preds = append(last.predstorage[:0], head.Preds...)
 	}
-	for i, e := range c.Preds {
+	for i, e := range last.Preds {
 		p := e.b
-		p.Succs[e.i] = Edge{c, i}
+		p.Succs[e.i] = Edge{last, i}
 	}
 	f := b.Func
-	if f.Entry == b {
-		f.Entry = c
-	}
+	for _, b := range chain[:len(chain)-1] {
+		if f.Entry == b {
+			f.Entry = last
+		}
 
-	// trash b, just in case
-	b.Kind = BlockInvalid
-	b.Values = nil
-	b.Preds = nil
-	b.Succs = nil
+		// trash b, just in case
+		b.Kind = BlockInvalid
+		b.Values = nil
+		b.Preds = nil
+		b.Succs = nil
+	}
 	return true
 }
+
+// plainChain returns the longest chain of blocks containing b in
+// which each block but the last is a plain block whose successor is
+// the next block and has no other predecessor.
+func plainChain(b *Block) []*Block {
+	head := b
+	for len(head.Preds) == 1 {
+		p := head.Preds[0].b
+		if p.Kind != BlockPlain || p == b {
+			break
+		}
+		head = p
+	}
+	chain := []*Block{head}
+	for last := head; last.Kind == BlockPlain; {
+		c := last.Succs[0].b
+		if len(c.Preds) != 1 || c == head {
+			break
+		}
+		chain = append(chain, c)
+		last = c
+	}
+	return chain
+}
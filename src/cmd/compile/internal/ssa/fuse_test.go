@@ -263,6 +263,58 @@ func TestFuseSideEffects(t *testing.T) {
 	}
 }
 
+// TestFuseChain checks that a chain of plain blocks is joined into its
+// last block, with the values in order, whatever the block order.
+func TestFuseChain(t *testing.T) {
+	c := testConfig(t)
+	const n = 100
+	for _, reversed := range []bool{false, true} {
+		blocks := make([]bloc, 0, n+1)
+		for i := 0; i < n; i++ {
+			name := fmt.Sprintf("b%d", i)
+			next := fmt.Sprintf("b%d", i+1)
+			var valus []interface{}
+			if i == 0 {
+				valus = append(valus, Valu("mem", OpInitMem, types.TypeMem, 0, nil))
+			}
+			valus = append(valus, Valu(fmt.Sprintf("v%d", i), OpConst64, c.config.Types.Int64, int64(i), nil), Goto(next))
+			blocks = append(blocks, Bloc(name, valus...))
+		}
+		blocks = append(blocks, Bloc(fmt.Sprintf("b%d", n), Exit("mem")))
+		if reversed {
+			for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+				blocks[i], blocks[j] = blocks[j], blocks[i]
+			}
+		}
+		fun := c.Fun("b0", blocks...)
+		CheckFunc(fun.f)
+		fuseLate(fun.f)
+		CheckFunc(fun.f)
+
+		last := fun.blocks[fmt.Sprintf("b%d", n)]
+		if fun.f.Entry != last {
+			t.Errorf("reversed=%v: entry is %v, want %v", reversed, fun.f.Entry, last)
+		}
+		var consts []int64
+		for _, v := range last.Values {
+			if v.Block != last {
+				t.Errorf("reversed=%v: %v is in %v, want %v", reversed, v, v.Block, last)
+			}
+			if v.Op == OpConst64 {
+				consts = append(consts, v.AuxInt)
+			}
+		}
+		for i, x := range consts {
+			if x != int64(i) {
+				t.Fatalf("reversed=%v: values out of order: %v", reversed, consts)
+			}
+		}
+		if len(consts) != n {
+			t.Errorf("reversed=%v: got %d values, want %d", reversed, len(consts), n)
+		}
+	}
+}
+
 func BenchmarkFuse(b *testing.B) {
 	for _, n := range [...]int{1, 10, 100, 1000, 10000} {
 		b.Run(strconv.Itoa(n), func(b *testing.B) {
@@ -46,7 +46,7 @@ var regNamesPPC64 = []string{
 	"R26",
 	"R27",
 	"R28",
-	"R29",
+	"R29", // REGFP, frame pointer, not used
 	"g",   // REGG.  Using name "g" and setting Config.hasGReg makes it "just happen".
 	"R31", // REGTMP
 
@@ -122,7 +122,7 @@ func init() {
 	}
 
 	var (
-		gp = buildReg("R3 R4 R5 R6 R7 R8 R9 R10 R11 R12 R14 R15 R16 R17 R18 R19 R20 R21 R22 R23 R24 R25 R26 R27 R28 R29")
+		gp = buildReg("R3 R4 R5 R6 R7 R8 R9 R10 R11 R12 R14 R15 R16 R17 R18 R19 R20 R21 R22 R23 R24 R25 R26 R27 R28")
 		fp = buildReg("F1 F2 F3 F4 F5 F6 F7 F8 F9 F10 F11 F12 F13 F14 F15 F16 F17 F18 F19 F20 F21 F22 F23 F24 F25 F26")
 		sp = buildReg("SP")
 		sb = buildReg("SB")
@@ -395,6 +395,12 @@ func init() {
 		{name: "ISEL", argLength: 3, reg: crgp21, asm: "ISEL", aux: "Int32", typ: "Int32"},  // see above
 		{name: "ISELB", argLength: 2, reg: crgp11, asm: "ISEL", aux: "Int32", typ: "Int32"}, // see above
 
+		// SETBC auxInt values 0=LT 1=GT 2=EQ   arg0 has the condition ? 1 : 0
+		// SETBCR auxInt values 0=LT 1=GT 2=EQ  arg0 has the condition ? 0 : 1
+		// Only with GOPPC64=power10 (ISA 3.1).
+		{name: "SETBC", argLength: 1, reg: crgp, asm: "SETBC", aux: "Int32", typ: "Int32"},   // see above
+		{name: "SETBCR", argLength: 1, reg: crgp, asm: "SETBCR", aux: "Int32", typ: "Int32"}, // see above
+
 		// pseudo-ops
 		{name: "Equal", argLength: 1, reg: crgp},         // bool, true flags encode x==y false otherwise.
 		{name: "NotEqual", argLength: 1, reg: crgp},      // bool, true flags encode x!=y false otherwise.
@@ -0,0 +1,60 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import "log"
+
+// A PassHook describes an analysis or instrumentation pass that is
+// supplied from outside the list of built-in passes.
+//
+// Hooks are linked into the compiler at build time: a package that
+// wants to observe or transform SSA calls RegisterPassHook from an
+// init function, and the package is imported for its side effects by
+// the compiler's main package. Registered hooks are disabled by
+// default and are enabled per compilation with -d=ssa/<name>/on, so a
+// compiler carrying extra hooks behaves exactly like a stock one
+// unless asked otherwise. Once enabled, a hook also accepts the other
+// per-phase flags (debug, dump, time, ...) and shows up in GOSSAFUNC
+// output like any other pass.
+type PassHook struct {
+	// Name identifies the hook in -d=ssa/<name>/... options.
+	// Underscores in the option are matched against spaces in Name,
+	// as for built-in passes.
+	Name string
+
+	// After is the name of the pass the hook runs after.
+	// Several hooks registered after the same pass run in
+	// registration order.
+	After string
+
+	// Fn is called with each function being compiled.
+	// It may rewrite f, but must leave it in a form that is valid
+	// for the passes that follow After.
+	Fn func(f *Func)
+}
+
+// RegisterPassHook adds h to the list of passes.
+// It must be called before any function is compiled, typically
+// from an init function, and panics if h is malformed, if its name
+// is already in use, or if h.After does not name a pass.
+func RegisterPassHook(h PassHook) {
+	if h.Name == "" || h.Fn == nil {
+		log.Panicf("pass hook %q: missing name or function", h.Name)
+	}
+	if passIdxByName(h.Name) >= 0 {
+		log.Panicf("pass hook %s: pass already exists", h.Name)
+	}
+	i := passIdxByName(h.After)
+	if i < 0 {
+		log.Panicf("pass hook %s: pass %s not found", h.Name, h.After)
+	}
+	i++
+	for i < len(passes) && passes[i].hook {
+		i++
+	}
+	passes = append(passes, pass{})
+	copy(passes[i+1:], passes[i:])
+	passes[i] = pass{name: h.Name, fn: h.Fn, disabled: true, hook: true}
+}
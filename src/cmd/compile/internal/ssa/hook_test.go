@@ -0,0 +1,56 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import "testing"
+
+func TestRegisterPassHook(t *testing.T) {
+	saved := append([]pass(nil), passes...)
+	defer func() { passes = saved }()
+
+	nop := func(*Func) {}
+	RegisterPassHook(PassHook{Name: "test hook a", After: "prove", Fn: nop})
+	RegisterPassHook(PassHook{Name: "test hook b", After: "prove", Fn: nop})
+
+	i := passIdxByName("prove")
+	if got := passes[i+1].name; got != "test hook a" {
+		t.Errorf("pass after prove is %q, want %q", got, "test hook a")
+	}
+	if got := passes[i+2].name; got != "test hook b" {
+		t.Errorf("second pass after prove is %q, want %q", got, "test hook b")
+	}
+	if got, want := len(passes), len(saved)+2; got != want {
+		t.Errorf("got %d passes, want %d", got, want)
+	}
+
+	a := passIdxByName("test hook a")
+	if !passes[a].disabled {
+		t.Errorf("hook enabled by default")
+	}
+	if msg := PhaseOption("test_hook_a", "on", 1, ""); msg != "" {
+		t.Fatalf("PhaseOption: %s", msg)
+	}
+	if passes[a].disabled {
+		t.Errorf("hook not enabled by -d=ssa/test_hook_a/on")
+	}
+	if msg := PhaseOption("test_hook_a", "off", 1, ""); msg != "" {
+		t.Fatalf("PhaseOption: %s", msg)
+	}
+	if !passes[a].disabled {
+		t.Errorf("hook not disabled by -d=ssa/test_hook_a/off")
+	}
+}
+
+func TestRegisterPassHookUnknownPass(t *testing.T) {
+	saved := append([]pass(nil), passes...)
+	defer func() { passes = saved }()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterPassHook with unknown pass did not panic")
+		}
+	}()
+	RegisterPassHook(PassHook{Name: "test hook", After: "no such pass", Fn: func(*Func) {}})
+}
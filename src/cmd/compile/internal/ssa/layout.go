@@ -9,6 +9,22 @@ package ssa
 // in which those blocks will appear in the assembly output.
 func layout(f *Func) {
 	f.Blocks = layoutOrder(f)
+	if f.pass.debug > 0 {
+		// Report the entries of the cold parts of f.
+		cold := coldBlocks(f)
+		for _, b := range f.Blocks {
+			if !cold.contains(b.ID) {
+				continue
+			}
+			for _, e := range b.Preds {
+				if !cold.contains(e.b.ID) {
+					f.Warnl(b.Pos, "cold block")
+					break
+				}
+			}
+		}
+		f.retSparseSet(cold)
+	}
 }
 
 // Register allocation may use a different order which has constraints
@@ -48,47 +64,27 @@ func layoutOrder(f *Func) []*Block {
 	// encounter loops, we choose to schedule the successor block of the most recently
 	// scheduled block.
 	var succs []ID
-	exit := f.newSparseSet(f.NumBlocks()) // exit blocks
-	defer f.retSparseSet(exit)
 
-	// Populate idToBlock and find exit blocks.
+	// Populate idToBlock.
 	for _, b := range f.Blocks {
 		idToBlock[b.ID] = b
-		if b.Kind == BlockExit {
-			exit.add(b.ID)
-		}
 	}
+	cold := coldBlocks(f)
+	defer f.retSparseSet(cold)
+	nhot := len(f.Blocks) - cold.size() // number of hot blocks left to schedule
+	// Cold successors of scheduled blocks. LIFO queue.
+	var coldSuccs []ID
 
-	// Expand exit to include blocks post-dominated by exit blocks.
-	for {
-		changed := false
-		for _, id := range exit.contents() {
-			b := idToBlock[id]
-		NextPred:
-			for _, pe := range b.Preds {
-				p := pe.b
-				if exit.contains(p.ID) {
-					continue
-				}
-				for _, s := range p.Succs {
-					if !exit.contains(s.b.ID) {
-						continue NextPred
-					}
-				}
-				// All Succs are in exit; add p.
-				exit.add(p.ID)
-				changed = true
-			}
-		}
-		if !changed {
-			break
-		}
+	// next reports whether id may be scheduled next. Cold blocks
+	// can't be, as long as there are hot blocks left.
+	next := func(id ID) bool {
+		return !scheduled[id] && (nhot == 0 || !cold.contains(id))
 	}
 
 	// Initialize indegree of each block
 	for _, b := range f.Blocks {
-		if exit.contains(b.ID) {
-			// exit blocks are always scheduled last
+		if cold.contains(b.ID) {
+			// cold blocks are always scheduled last
 			continue
 		}
 		indegree[b.ID] = len(b.Preds)
@@ -107,6 +103,9 @@ blockloop:
 		b := idToBlock[bid]
 		order = append(order, b)
 		scheduled[bid] = true
+		if !cold.contains(bid) {
+			nhot--
+		}
 		if len(order) == len(f.Blocks) {
 			break
 		}
@@ -123,6 +122,9 @@ blockloop:
 		// Note: You need to consider both layout and register allocation when testing performance.
 		for i := len(b.Succs) - 1; i >= 0; i-- {
 			c := b.Succs[i].b
+			if cold.contains(c.ID) {
+				coldSuccs = append(coldSuccs, c.ID)
+			}
 			indegree[c.ID]--
 			if indegree[c.ID] == 0 {
 				posdegree.remove(c.ID)
@@ -143,7 +145,7 @@ blockloop:
 		case BranchUnlikely:
 			likely = b.Succs[1].b
 		}
-		if likely != nil && !scheduled[likely.ID] {
+		if likely != nil && next(likely.ID) {
 			bid = likely.ID
 			continue
 		}
@@ -168,13 +170,13 @@ blockloop:
 			// Pop an element from the tail of the queue.
 			cid := succs[len(succs)-1]
 			succs = succs[:len(succs)-1]
-			if !scheduled[cid] {
+			if next(cid) {
 				bid = cid
 				continue blockloop
 			}
 		}
 
-		// Still nothing, pick any non-exit block.
+		// Still nothing, pick any hot block.
 		for posdegree.size() > 0 {
 			cid := posdegree.pop()
 			if !scheduled[cid] {
@@ -182,10 +184,11 @@ blockloop:
 				continue blockloop
 			}
 		}
-		// Pick any exit block.
+		// Pick the cold successor block encountered most recently.
 		// TODO: Order these to minimize jump distances?
 		for {
-			cid := exit.pop()
+			cid := coldSuccs[len(coldSuccs)-1]
+			coldSuccs = coldSuccs[:len(coldSuccs)-1]
 			if !scheduled[cid] {
 				bid = cid
 				continue blockloop
@@ -196,3 +199,110 @@ blockloop:
 	return order
 	//f.Blocks = order
 }
+
+// coldBlocks returns the blocks of f that are statically cold: exit
+// blocks, blocks post-dominated by exit blocks, and blocks that can only
+// be reached through unlikely branches or from other cold blocks.
+// layoutOrder places them after all the other blocks, so that the hot
+// code of f is packed together.
+// The caller must return the set with f.retSparseSet.
+func coldBlocks(f *Func) *sparseSet {
+	cold := f.newSparseSet(f.NumBlocks())
+	idToBlock := make([]*Block, f.NumBlocks())
+	for _, b := range f.Blocks {
+		idToBlock[b.ID] = b
+		if b.Kind == BlockExit {
+			cold.add(b.ID)
+		}
+	}
+
+	// Expand cold to include blocks post-dominated by exit blocks.
+	for {
+		changed := false
+		for _, id := range cold.contents() {
+			b := idToBlock[id]
+		NextPred:
+			for _, pe := range b.Preds {
+				p := pe.b
+				if cold.contains(p.ID) {
+					continue
+				}
+				for _, s := range p.Succs {
+					if !cold.contains(s.b.ID) {
+						continue NextPred
+					}
+				}
+				// All Succs are cold; add p.
+				cold.add(p.ID)
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	// Expand cold to include blocks that are only entered from cold
+	// blocks or through unlikely branches. Loop exits don't count:
+	// they are unlikely for each iteration, not for each execution
+	// of the loop. Cold loops that are entered through an unlikely
+	// branch stay hot, as their back edges come from blocks that are
+	// not cold yet.
+	var ln *loopnest
+	for {
+		changed := false
+	NextBlock:
+		for _, b := range f.Blocks {
+			if b == f.Entry || cold.contains(b.ID) {
+				continue
+			}
+			for _, e := range b.Preds {
+				p := e.b
+				if cold.contains(p.ID) {
+					continue
+				}
+				if p.Likely == BranchLikely && e.i == 1 || p.Likely == BranchUnlikely && e.i == 0 {
+					if ln == nil {
+						ln = f.loopnest()
+					}
+					if l := ln.b2l[p.ID]; l == nil || ln.b2l[b.ID].isWithinOrEq(l) {
+						continue
+					}
+				}
+				continue NextBlock
+			}
+			cold.add(b.ID)
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+
+	// Blocks that can't be reached from the entry without going
+	// through cold blocks are cold too, so that each hot block is
+	// laid out after one of its predecessors.
+	if cold.contains(f.Entry.ID) {
+		return cold
+	}
+	reached := f.newSparseSet(f.NumBlocks())
+	defer f.retSparseSet(reached)
+	reached.add(f.Entry.ID)
+	for q := []*Block{f.Entry}; len(q) > 0; {
+		b := q[len(q)-1]
+		q = q[:len(q)-1]
+		for _, e := range b.Succs {
+			c := e.b
+			if !cold.contains(c.ID) && !reached.contains(c.ID) {
+				reached.add(c.ID)
+				q = append(q, c)
+			}
+		}
+	}
+	for _, b := range f.Blocks {
+		if !reached.contains(b.ID) {
+			cold.add(b.ID)
+		}
+	}
+	return cold
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import "sort"
+
+// licm performs loop-invariant code motion. Values computed in a loop
+// whose arguments are all defined outside of the loop are moved to the
+// loop's preheader, so that they are computed only once.
+//
+// Only values that can be computed speculatively are moved: the
+// preheader runs even if the loop body (or the part of the body
+// containing the value) does not. Pure operations that cannot fault
+// are always moved. Integer divisions are moved only if the divisor
+// is a nonzero constant, as the check for a zero divisor stays in
+// the loop. Loads are moved if no memory is written in the loop
+// before them, they run on every iteration of the loop, and their
+// address is known to be non-nil in the preheader. A load guarded by
+// a branch or bounds check in the loop is not moved, as its address
+// may be out of bounds even if it is not nil. Pointer arithmetic is
+// moved under the same conditions, so that the garbage collector
+// never sees an invalid pointer.
+//
+// The preheader is the single predecessor of the loop header from
+// outside the loop. Loops without one are left alone.
+func licm(f *Func) {
+	ln := f.loopnest()
+	if ln.hasIrreducible || len(ln.loops) == 0 {
+		return
+	}
+	ln.calculateDepths()
+	sdom := f.Sdom()
+
+	// Process inner loops first, so that values can move
+	// out of several levels of loops.
+	loops := append([]*loop(nil), ln.loops...)
+	sort.SliceStable(loops, func(i, j int) bool {
+		return loops[i].depth > loops[j].depth
+	})
+
+	// checked maps pointers to the blocks in which they are
+	// dereferenced or nil checked.
+	checked := map[*Value][]*Block{}
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			switch v.Op {
+			case OpNilCheck, OpLoad, OpStore, OpZero, OpMove:
+				p := v.Args[0]
+				for p.Op == OpOffPtr && p.AuxInt >= 0 && p.AuxInt < minZeroPage {
+					p = p.Args[0]
+				}
+				checked[p] = append(checked[p], b)
+			}
+		}
+	}
+	// nonNil reports whether the pointer p is known to be non-nil
+	// at the end of block b.
+	nonNil := func(p *Value, b *Block) bool {
+		for p.Op == OpOffPtr {
+			p = p.Args[0]
+		}
+		switch p.Op {
+		case OpSP, OpSB, OpAddr:
+			return true
+		}
+		for _, c := range checked[p] {
+			if sdom.IsAncestorEq(c, b) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, l := range loops {
+		pre := ln.preheader(l)
+		if pre == nil {
+			continue
+		}
+		inLoop := func(b *Block) bool {
+			return ln.b2l[b.ID].isWithinOrEq(l)
+		}
+		invariant := func(v *Value) bool {
+			for _, a := range v.Args {
+				if inLoop(a.Block) {
+					return false
+				}
+			}
+			return true
+		}
+		// exits are the blocks that leave l or jump back to its
+		// header. A block that dominates all of them runs on every
+		// iteration.
+		var exits []*Block
+		for _, b := range f.Blocks {
+			if !inLoop(b) {
+				continue
+			}
+			if len(b.Succs) == 0 {
+				exits = append(exits, b)
+				continue
+			}
+			for _, e := range b.Succs {
+				if e.b == l.header || !inLoop(e.b) {
+					exits = append(exits, b)
+					break
+				}
+			}
+		}
+		everyIteration := func(b *Block) bool {
+			for _, e := range exits {
+				if !sdom.IsAncestorEq(b, e) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for changed := true; changed; {
+			changed = false
+			for _, b := range f.Blocks {
+				if !inLoop(b) {
+					continue
+				}
+				for i := 0; i < len(b.Values); i++ {
+					v := b.Values[i]
+					if !canHoist(v) || !invariant(v) {
+						continue
+					}
+					if (v.Op == OpLoad || v.Op == OpOffPtr) && (!everyIteration(b) || !nonNil(v.Args[0], pre)) {
+						// A load from a nil or out of bounds
+						// pointer faults, and the garbage
+						// collector must not see such a pointer.
+						continue
+					}
+					if f.pass.debug > 0 {
+						f.Warnl(v.Pos, "hoisted %v out of loop", v.Op)
+					}
+					pre.Values = append(pre.Values, v)
+					v.Block = pre
+					last := len(b.Values) - 1
+					b.Values[i] = b.Values[last]
+					b.Values[last] = nil
+					b.Values = b.Values[:last]
+					i--
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+// preheader returns the single predecessor of l's header from outside
+// of l, or nil if there is no such block or it does not end in a plain
+// jump to the header.
+func (ln *loopnest) preheader(l *loop) *Block {
+	var pre *Block
+	for _, e := range l.header.Preds {
+		if ln.b2l[e.b.ID].isWithinOrEq(l) {
+			continue
+		}
+		if pre != nil {
+			return nil
+		}
+		pre = e.b
+	}
+	if pre == nil || pre.Kind != BlockPlain {
+		return nil
+	}
+	return pre
+}
+
+// canHoist reports whether v may be computed speculatively, ahead of
+// the conditions that guard it.
+func canHoist(v *Value) bool {
+	switch v.Op {
+	case OpPhi, OpCopy, OpSelectN,
+		OpGetG, OpGetClosurePtr, OpGetCallerPC, OpGetCallerSP:
+		return false
+	case OpLoad, OpOffPtr:
+		return true
+	case OpAddPtr, OpPtrIndex:
+		// The result may point outside of any object
+		// if the index is checked in the loop.
+		return false
+	case OpDiv8, OpDiv8u, OpDiv16, OpDiv16u, OpDiv32, OpDiv32u, OpDiv64, OpDiv64u, OpDiv128u,
+		OpMod8, OpMod8u, OpMod16, OpMod16u, OpMod32, OpMod32u, OpMod64, OpMod64u:
+		// Division by zero traps.
+		d := v.Args[len(v.Args)-1]
+		return d.isGenericIntConst() && d.AuxInt != 0
+	}
+	if len(v.Args) == 0 || v.MemoryArg() != nil || v.Type.IsMemory() {
+		return false
+	}
+	info := &opcodeTable[v.Op]
+	return !info.hasSideEffects && !info.call && !info.nilCheck &&
+		!info.faultOnNilArg0 && !info.faultOnNilArg1 && info.symEffect == SymNone
+}
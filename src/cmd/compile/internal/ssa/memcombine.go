@@ -0,0 +1,508 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"cmd/compile/internal/types"
+	"sort"
+)
+
+// memcombine combines adjacent narrow loads and stores into wider
+// ones. For instance
+//
+//	p[0] = byte(x)
+//	p[1] = byte(x >> 8)
+//	p[2] = byte(x >> 16)
+//	p[3] = byte(x >> 24)
+//
+// becomes a single 32-bit store of x, and
+//
+//	uint32(p[0]) | uint32(p[1])<<8 | uint32(p[2])<<16 | uint32(p[3])<<24
+//
+// a single 32-bit load. Accesses in the opposite byte order are
+// combined too, with a byte swap, if the architecture has one.
+//
+// The combined access need not be aligned, so this is only done on
+// architectures where unaligned loads and stores are allowed and fast.
+func memcombine(f *Func) {
+	if !f.Config.unalignedOK {
+		return
+	}
+	memcombineLoads(f)
+	if memcombineStores(f) {
+		// Remove the stores made dead by combining, which would
+		// otherwise look like extra final stores to dse.
+		deadcode(f)
+	}
+}
+
+// splitPtr splits the address ptr into a base pointer, an optional
+// index added to it, and a constant offset.
+func splitPtr(ptr *Value) (base, idx *Value, off int64) {
+	for ptr.Op == OpOffPtr {
+		off += ptr.AuxInt
+		ptr = ptr.Args[0]
+	}
+	if ptr.Op != OpAddPtr {
+		return ptr, nil, off
+	}
+	base, idx = ptr.Args[0], ptr.Args[1]
+	if idx.Op == OpAdd64 || idx.Op == OpAdd32 {
+		for i := 0; i < 2; i++ {
+			if c := idx.Args[i]; c.Op == OpConst64 || c.Op == OpConst32 {
+				off += c.AuxInt
+				idx = idx.Args[i^1]
+				break
+			}
+		}
+	}
+	for base.Op == OpOffPtr {
+		off += base.AuxInt
+		base = base.Args[0]
+	}
+	return base, idx, off
+}
+
+// A memPiece is one of the narrow accesses to combine.
+type memPiece struct {
+	access *Value // the Load or Store
+	off    int64  // offset of the access from the common base and index
+	shift  int64  // bit position of the accessed bytes in the combined value
+}
+
+// memPieceOrder reports whether pieces, sorted by offset, cover
+// consecutive bytes of size n each, and whether they are in little
+// or big endian order in the combined value, if either.
+func memPieceOrder(pieces []memPiece, n int64) (contiguous, little, big bool) {
+	sort.Slice(pieces, func(i, j int) bool { return pieces[i].off < pieces[j].off })
+	little, big = true, true
+	last := len(pieces) - 1
+	for i, p := range pieces {
+		if p.off != pieces[0].off+int64(i)*n {
+			return false, false, false
+		}
+		if p.shift != pieces[0].shift+int64(i)*n*8 {
+			little = false
+		}
+		if p.shift != pieces[last].shift+int64(last-i)*n*8 {
+			big = false
+		}
+	}
+	return true, little, big
+}
+
+// needSwap reports whether a combined access of size bytes, with its
+// bytes in little endian order if little is set and in big endian
+// order otherwise, needs a byte swap on f's architecture, and ok
+// if the access can be combined at all.
+func needSwap(f *Func, size int64, little bool) (swap, ok bool) {
+	if little != f.Config.BigEndian {
+		return false, true
+	}
+	return true, f.Config.haveBswap && (size == 4 || size == 8)
+}
+
+// bswap returns the generic op for swapping the bytes of a value of
+// the given size.
+func bswap(size int64) Op {
+	if size == 8 {
+		return OpBswap64
+	}
+	return OpBswap32
+}
+
+// uintType returns the unsigned integer type of the given size.
+func uintType(f *Func, size int64) *types.Type {
+	switch size {
+	case 1:
+		return f.Config.Types.UInt8
+	case 2:
+		return f.Config.Types.UInt16
+	case 4:
+		return f.Config.Types.UInt32
+	}
+	return f.Config.Types.UInt64
+}
+
+// memcombineLoads replaces trees of ORs of shifted, zero-extended
+// loads of adjacent memory with single wider loads.
+func memcombineLoads(f *Func) {
+	// Find the ORs that are operands of other ORs in the same tree,
+	// so we only start at the roots of trees.
+	inner := f.newSparseSet(f.NumValues())
+	defer f.retSparseSet(inner)
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			if !isOr(v.Op) {
+				continue
+			}
+			for _, a := range v.Args {
+				if a.Op == v.Op && a.Uses == 1 && a.Block == b {
+					inner.add(a.ID)
+				}
+			}
+		}
+	}
+
+	var leaves []*Value
+	pieces := make([]memPiece, 0, 8)
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			if !isOr(v.Op) || inner.contains(v.ID) {
+				continue
+			}
+			leaves = collectOrLeaves(v, leaves[:0])
+			combineLoads(v, leaves, pieces)
+		}
+	}
+}
+
+func isOr(op Op) bool {
+	switch op {
+	case OpOr16, OpOr32, OpOr64:
+		return true
+	}
+	return false
+}
+
+// collectOrLeaves appends to leaves the operands of the tree of ORs
+// rooted at v, looking through ORs of the same op used only in the tree.
+func collectOrLeaves(v *Value, leaves []*Value) []*Value {
+	for _, a := range v.Args {
+		if a.Op == v.Op && a.Uses == 1 && a.Block == v.Block {
+			leaves = collectOrLeaves(a, leaves)
+		} else {
+			leaves = append(leaves, a)
+		}
+	}
+	return leaves
+}
+
+// combineLoads replaces root, an OR of leaves, with a single load if
+// the leaves are shifted zero extensions of adjacent loads.
+func combineLoads(root *Value, leaves []*Value, pieces []memPiece) bool {
+	f := root.Block.Func
+	if len(leaves) != 2 && len(leaves) != 4 && len(leaves) != 8 {
+		return false
+	}
+	var base, idx, mem *Value
+	var n int64
+	for i, l := range leaves {
+		shift := int64(0)
+		if isLeftShift(l.Op) && l.Args[1].isGenericIntConst() && l.Uses == 1 {
+			shift = l.Args[1].AuxInt
+			l = l.Args[0]
+		}
+		if !isZeroExt(l.Op) || l.Uses != 1 {
+			return false
+		}
+		load := l.Args[0]
+		if load.Op != OpLoad || load.Uses != 1 || load.Block != root.Block || !load.Type.IsInteger() {
+			return false
+		}
+		b, x, off := splitPtr(load.Args[0])
+		if i == 0 {
+			base, idx, mem, n = b, x, load.Args[1], load.Type.Size()
+		} else if b != base || x != idx || load.Args[1] != mem || load.Type.Size() != n {
+			return false
+		}
+		pieces = append(pieces, memPiece{access: load, off: off, shift: shift})
+	}
+	size := n * int64(len(pieces))
+	if size > f.Config.RegSize || size > root.Type.Size() {
+		return false
+	}
+	contiguous, little, big := memPieceOrder(pieces, n)
+	if !contiguous || !little && !big {
+		return false
+	}
+	first := pieces[0]
+	if !little {
+		first = pieces[len(pieces)-1]
+	}
+	shift := first.shift
+	if shift < 0 || shift+size*8 > root.Type.Size()*8 {
+		return false
+	}
+	swap, ok := needSwap(f, size, little)
+	if !ok {
+		return false
+	}
+
+	// Load all bytes at once from the lowest address, with the memory
+	// state of the narrow loads.
+	b, pos := root.Block, root.Pos
+	t := uintType(f, size)
+	v := b.NewValue2(pieces[0].access.Pos, OpLoad, t, pieces[0].access.Args[0], mem)
+	if swap {
+		v = b.NewValue1(pos, bswap(size), t, v)
+	}
+	if size < root.Type.Size() {
+		v = b.NewValue1(pos, zeroExtOp(size, root.Type.Size()), root.Type, v)
+	}
+	if shift != 0 {
+		v = b.NewValue2(pos, leftShiftOp(root.Type.Size()), root.Type, v, b.NewValue0I(pos, OpConst64, f.Config.Types.UInt64, shift))
+	}
+	root.reset(OpCopy)
+	root.AddArg(v)
+	return true
+}
+
+func isLeftShift(op Op) bool {
+	switch op {
+	case OpLsh16x64, OpLsh32x64, OpLsh64x64,
+		OpLsh16x32, OpLsh32x32, OpLsh64x32,
+		OpLsh16x16, OpLsh32x16, OpLsh64x16,
+		OpLsh16x8, OpLsh32x8, OpLsh64x8:
+		return true
+	}
+	return false
+}
+
+func isZeroExt(op Op) bool {
+	switch op {
+	case OpZeroExt8to16, OpZeroExt8to32, OpZeroExt8to64,
+		OpZeroExt16to32, OpZeroExt16to64, OpZeroExt32to64:
+		return true
+	}
+	return false
+}
+
+func zeroExtOp(from, to int64) Op {
+	switch 10*from + to {
+	case 24:
+		return OpZeroExt16to32
+	case 28:
+		return OpZeroExt16to64
+	case 48:
+		return OpZeroExt32to64
+	}
+	panic("bad zero extension")
+}
+
+func leftShiftOp(size int64) Op {
+	switch size {
+	case 2:
+		return OpLsh16x64
+	case 4:
+		return OpLsh32x64
+	}
+	return OpLsh64x64
+}
+
+// memcombineStores replaces runs of stores to adjacent memory, of
+// constants or of the parts of a single value, with single wider stores.
+// It reports whether it combined any.
+func memcombineStores(f *Func) bool {
+	changed := false
+	pieces := make([]memPiece, 0, 8)
+	for _, b := range f.Blocks {
+		// Visit the last store of each run first, so the whole run
+		// is combined rather than only its beginning.
+		for i := len(b.Values) - 1; i >= 0; i-- {
+			v := b.Values[i]
+			if v.Op != OpStore {
+				continue
+			}
+			for _, cnt := range [...]int{8, 4, 2} {
+				if combineStores(v, cnt, pieces[:0]) {
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return changed
+}
+
+// combineStores combines root and the cnt-1 stores before it in its
+// memory chain into a single store, if they store adjacent memory.
+func combineStores(root *Value, cnt int, pieces []memPiece) bool {
+	f := root.Block.Func
+	n := root.Aux.(*types.Type).Size()
+	if n*int64(cnt) > f.Config.RegSize {
+		return false
+	}
+	var base, idx *Value
+	v := root
+	for i := 0; i < cnt; i++ {
+		if v.Op != OpStore || v.Block != root.Block || i > 0 && v.Uses != 1 {
+			return false
+		}
+		t := v.Aux.(*types.Type)
+		if t.Size() != n || !t.IsInteger() && !t.IsBoolean() {
+			return false
+		}
+		b, x, off := splitPtr(v.Args[0])
+		if i == 0 {
+			base, idx = b, x
+		} else if b != base || x != idx {
+			return false
+		}
+		pieces = append(pieces, memPiece{access: v, off: off})
+		v = v.Args[2]
+	}
+	mem := v
+	size := n * int64(cnt)
+	contiguous, _, _ := memPieceOrder(pieces, n)
+	if !contiguous {
+		return false
+	}
+
+	val := combinedConst(f, pieces, n)
+	if val == nil {
+		val = combinedValue(f, root, pieces, n)
+	}
+	if val == nil {
+		return false
+	}
+
+	// Store all bytes at once at the lowest address, in place of root.
+	t := uintType(f, size)
+	root.Aux = t
+	root.SetArgs3(pieces[0].access.Args[0], val, mem)
+	for _, p := range pieces {
+		if p.access != root {
+			p.access.reset(OpInvalid)
+		}
+	}
+	return true
+}
+
+// combinedConst returns the constant stored by the stores of size n
+// in pieces, sorted by offset, if they all store constants.
+func combinedConst(f *Func, pieces []memPiece, n int64) *Value {
+	var c uint64
+	for i, p := range pieces {
+		x := p.access.Args[1]
+		if !x.isGenericIntConst() && x.Op != OpConstBool {
+			return nil
+		}
+		bits := uint64(x.AuxInt) & (1<<(uint(n)*8) - 1)
+		if f.Config.BigEndian {
+			i = len(pieces) - 1 - i
+		}
+		c |= bits << (uint(i) * uint(n) * 8)
+	}
+	size := n * int64(len(pieces))
+	root := pieces[0].access
+	t := uintType(f, size)
+	switch size {
+	case 2:
+		return root.Block.NewValue0I(root.Pos, OpConst16, t, int64(int16(c)))
+	case 4:
+		return root.Block.NewValue0I(root.Pos, OpConst32, t, int64(int32(c)))
+	}
+	return root.Block.NewValue0I(root.Pos, OpConst64, t, int64(c))
+}
+
+// combinedValue returns the value stored by the stores of size n in
+// pieces, sorted by offset, if they store the parts of a single value.
+func combinedValue(f *Func, root *Value, pieces []memPiece, n int64) *Value {
+	var x *Value
+	for i := range pieces {
+		p := &pieces[i]
+		v := p.access.Args[1]
+		if isTrunc(v.Op) {
+			v = v.Args[0]
+		}
+		if isRightShift(v.Op) && v.Args[1].isGenericIntConst() {
+			p.shift = v.Args[1].AuxInt
+			v = v.Args[0]
+		}
+		if x == nil {
+			x = v
+		} else if v != x {
+			return nil
+		}
+	}
+	if !x.Type.IsInteger() {
+		return nil
+	}
+	size := n * int64(len(pieces))
+	_, little, big := memPieceOrder(pieces, n)
+	if !little && !big {
+		return nil
+	}
+	shift := pieces[0].shift
+	if !little {
+		shift = pieces[len(pieces)-1].shift
+	}
+	if shift < 0 || shift+size*8 > x.Type.Size()*8 {
+		return nil
+	}
+	swap, ok := needSwap(f, size, little)
+	if !ok {
+		return nil
+	}
+
+	b, pos := root.Block, root.Pos
+	t := uintType(f, size)
+	v := x
+	if shift != 0 {
+		v = b.NewValue2(pos, rightShiftOp(x.Type.Size()), x.Type, v, b.NewValue0I(pos, OpConst64, f.Config.Types.UInt64, shift))
+	}
+	if size < x.Type.Size() {
+		v = b.NewValue1(pos, truncOp(x.Type.Size(), size), t, v)
+	}
+	if swap {
+		v = b.NewValue1(pos, bswap(size), t, v)
+	}
+	return v
+}
+
+func isRightShift(op Op) bool {
+	switch op {
+	case OpRsh16Ux64, OpRsh32Ux64, OpRsh64Ux64,
+		OpRsh16Ux32, OpRsh32Ux32, OpRsh64Ux32,
+		OpRsh16Ux16, OpRsh32Ux16, OpRsh64Ux16,
+		OpRsh16Ux8, OpRsh32Ux8, OpRsh64Ux8,
+		OpRsh16x64, OpRsh32x64, OpRsh64x64,
+		OpRsh16x32, OpRsh32x32, OpRsh64x32,
+		OpRsh16x16, OpRsh32x16, OpRsh64x16,
+		OpRsh16x8, OpRsh32x8, OpRsh64x8:
+		return true
+	}
+	return false
+}
+
+func isTrunc(op Op) bool {
+	switch op {
+	case OpTrunc16to8, OpTrunc32to8, OpTrunc64to8,
+		OpTrunc32to16, OpTrunc64to16, OpTrunc64to32:
+		return true
+	}
+	return false
+}
+
+func truncOp(from, to int64) Op {
+	switch 10*from + to {
+	case 21:
+		return OpTrunc16to8
+	case 41:
+		return OpTrunc32to8
+	case 42:
+		return OpTrunc32to16
+	case 81:
+		return OpTrunc64to8
+	case 82:
+		return OpTrunc64to16
+	case 84:
+		return OpTrunc64to32
+	}
+	panic("bad truncation")
+}
+
+func rightShiftOp(size int64) Op {
+	switch size {
+	case 1:
+		return OpRsh8Ux64
+	case 2:
+		return OpRsh16Ux64
+	case 4:
+		return OpRsh32Ux64
+	}
+	return OpRsh64Ux64
+}
@@ -335,3 +335,45 @@ func nilcheckelim2(f *Func) {
 		// more unnecessary nil checks.  Would fix test/nilptr3.go:159.
 	}
 }
+
+// nilCheckPositions returns the positions of the nil checks in f.
+func nilCheckPositions(f *Func) []src.XPos {
+	var pos []src.XPos
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			if v.Op == OpNilCheck || opcodeTable[v.Op].nilCheck {
+				pos = append(pos, v.Pos.WithNotStmt())
+			}
+		}
+	}
+	return pos
+}
+
+// reportNilChecks reports, for -d=nilcheckreport, which of the nil
+// checks that were in f when it was built have been eliminated and
+// which remain, followed by a summary for f. Nil checks are matched up
+// by position. Checks made implicit by a memory operation that faults
+// on nil count as eliminated.
+func reportNilChecks(f *Func, inserted []src.XPos) {
+	if len(inserted) == 0 {
+		return
+	}
+	left := map[src.XPos]int{}
+	for _, p := range nilCheckPositions(f) {
+		left[p]++
+	}
+	remaining := 0
+	for _, p := range inserted {
+		msg := "nil check eliminated"
+		if left[p] > 0 {
+			left[p]--
+			remaining++
+			msg = "nil check remains"
+		}
+		if p.Line() > 1 { // not in generated code
+			f.Warnl(p, msg)
+		}
+	}
+	f.Warnl(f.Entry.Pos, "%s: %d nil checks inserted, %d eliminated, %d remaining",
+		f.Name, len(inserted), len(inserted)-remaining, remaining)
+}
@@ -104,6 +104,7 @@ func (a *AuxNameOffset) String() string {
 
 type AuxCall struct {
 	Fn      *obj.LSym
+	Pure    bool     // Fn is marked //go:pure; see pureCalls
 	reg     *regInfo // regInfo for this call
 	abiInfo *abi.ABIParamResultInfo
 }
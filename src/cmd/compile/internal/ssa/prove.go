@@ -5,6 +5,7 @@
 package ssa
 
 import (
+	"cmd/compile/internal/logopt"
 	"cmd/internal/src"
 	"fmt"
 	"math"
@@ -249,6 +250,13 @@ func (ft *factsTable) update(parent *Block, v, w *Value, d domain, r relation) {
 			ft.unsat = true
 			return
 		}
+		if !ft.limitsAllow(v, w, d, r) {
+			if parent.Func.pass.debug > 2 {
+				parent.Func.Warnl(parent.Pos, "unsat by limits %s %s %s", v, w, r)
+			}
+			ft.unsat = true
+			return
+		}
 	} else {
 		if lessByID(w, v) {
 			v, w = w, v
@@ -615,6 +623,60 @@ func (ft *factsTable) isNonNegative(v *Value) bool {
 	return ft.orderS.OrderedOrEqual(ft.zero, v)
 }
 
+// limitsAllow reports whether v and w can be related by r in domain d
+// (signed or unsigned), given the limits known for both.
+func (ft *factsTable) limitsAllow(v, w *Value, d domain, r relation) bool {
+	lv, ok := ft.limits[v.ID]
+	if !ok {
+		return true
+	}
+	lw, ok := ft.limits[w.ID]
+	if !ok {
+		return true
+	}
+	var possible relation
+	if d == signed {
+		if lv.min < lw.max {
+			possible |= lt
+		}
+		if lv.min <= lw.max && lw.min <= lv.max {
+			possible |= eq
+		}
+		if lv.max > lw.min {
+			possible |= gt
+		}
+	} else {
+		if lv.umin < lw.umax {
+			possible |= lt
+		}
+		if lv.umin <= lw.umax && lw.umin <= lv.umax {
+			possible |= eq
+		}
+		if lv.umax > lw.umin {
+			possible |= gt
+		}
+	}
+	return possible&r != 0
+}
+
+// restrictLimit records that v lies within lim, in addition to what
+// is already known about it.
+func (ft *factsTable) restrictLimit(v *Value, lim limit) {
+	old, ok := ft.limits[v.ID]
+	if !ok {
+		old = noLimit
+	}
+	lim = old.intersect(lim)
+	if lim == old {
+		return
+	}
+	ft.limitStack = append(ft.limitStack, limitFact{v.ID, old})
+	ft.limits[v.ID] = lim
+	if lim.min > lim.max || lim.umin > lim.umax {
+		ft.unsat = true
+	}
+}
+
 // checkpoint saves the current state of known relations.
 // Called when descending on a branch.
 func (ft *factsTable) checkpoint() {
@@ -898,6 +960,9 @@ func prove(f *Func) {
 			// Add inductive facts for phis in this block.
 			addLocalInductiveFacts(ft, node.block)
 
+			// Add facts about the arithmetic in this block.
+			addLocalFacts(ft, node.block)
+
 			work = append(work, bp{
 				block: node.block,
 				state: simplify,
@@ -1046,6 +1111,89 @@ func addRestrictions(parent *Block, ft *factsTable, t domain, v, w *Value, r rel
 	}
 }
 
+// addLocalFacts adds facts about the results of masking, modulo and
+// multiplication by a constant in b, which let prove show that
+// indexes computed as i&mask, i%len(s) or i*c are in bounds.
+// The facts depend on those already established for b, such as the
+// signs of the operands, which is why this happens when visiting b.
+func addLocalFacts(ft *factsTable, b *Block) {
+	for _, v := range b.Values {
+		switch v.Op {
+		case OpAnd64, OpAnd32, OpAnd16, OpAnd8:
+			// x&y <= x and x&y <= y, unsigned. If y >= 0,
+			// also 0 <= x&y <= y, signed.
+			for _, x := range v.Args {
+				ft.update(b, v, x, unsigned, lt|eq)
+				if !ft.isNonNegative(x) {
+					continue
+				}
+				ft.update(b, v, ft.zero, signed, gt|eq)
+				ft.update(b, v, x, signed, lt|eq)
+				if x.isGenericIntConst() {
+					ft.restrictLimit(v, limit{0, x.AuxInt, 0, uint64(x.AuxInt)})
+				}
+				if w, delta := isConstDelta(x); w != nil && delta == -1 {
+					// x&(n-1) < n, as for s[i&(len(s)-1)].
+					addRestrictions(b, ft, signed|unsigned, v, w, lt)
+				}
+			}
+
+		case OpMod64u, OpMod32u, OpMod16u, OpMod8u:
+			// x%y < y, unsigned. Division by zero panics
+			// before v is computed, so y != 0 here.
+			ft.update(b, v, v.Args[1], unsigned, lt)
+			ft.update(b, v, v.Args[0], unsigned, lt|eq)
+
+		case OpMod64, OpMod32, OpMod16, OpMod8:
+			// 0 <= x%y < y if x >= 0 and y > 0.
+			x, y := v.Args[0], v.Args[1]
+			if ft.isNonNegative(x) && ft.isNonNegative(y) {
+				ft.update(b, v, ft.zero, signed, gt|eq)
+				addRestrictions(b, ft, signed|unsigned, v, y, lt)
+				addRestrictions(b, ft, signed|unsigned, v, x, lt|eq)
+			}
+
+		case OpMul64, OpMul32:
+			x, c := v.Args[0], v.Args[1]
+			if x.isGenericIntConst() {
+				x, c = c, x
+			}
+			if c.isGenericIntConst() {
+				addScaledLimit(ft, v, x, c.AuxInt)
+			}
+
+		case OpLsh64x64, OpLsh32x64:
+			// Multiplications by powers of two have become shifts
+			// by the time prove runs.
+			if c := v.Args[1]; c.Op == OpConst64 && c.AuxInt >= 0 && c.AuxInt < v.Type.Size()*8-1 {
+				addScaledLimit(ft, v, v.Args[0], 1<<uint(c.AuxInt))
+			}
+		}
+	}
+}
+
+// addScaledLimit records that v, which is x*c, lies within the limits
+// known for x scaled by c, provided x and c are non-negative and the
+// multiplication cannot overflow.
+func addScaledLimit(ft *factsTable, v, x *Value, c int64) {
+	if c < 0 {
+		return
+	}
+	lx, ok := ft.limits[x.ID]
+	if !ok || lx.min < 0 {
+		return
+	}
+	max := int64(math.MaxInt64)
+	if v.Type.Size() == 4 {
+		max = math.MaxInt32
+	}
+	if c != 0 && lx.max > max/c {
+		return
+	}
+	lo, hi := lx.min*c, lx.max*c
+	ft.restrictLimit(v, limit{lo, hi, uint64(lo), uint64(hi)})
+}
+
 // addLocalInductiveFacts adds inductive facts when visiting b, where
 // b is a join point in a loop. In contrast with findIndVar, this
 // depends on facts established for b, which is why it happens when
@@ -1321,6 +1469,9 @@ func simplifyBlock(sdom SparseTree, ft *factsTable, b *Block) {
 
 func removeBranch(b *Block, branch branch) {
 	c := b.Controls[0]
+	if c != nil && (c.Op == OpIsInBounds || c.Op == OpIsSliceInBounds) && branch == negative && logopt.Enabled() {
+		logopt.LogOpt(c.Pos, "removedBoundsCheck", "prove", b.Func.Name, c.Op.String())
+	}
 	if b.Func.pass.debug > 0 {
 		verb := "Proved"
 		if branch == positive {
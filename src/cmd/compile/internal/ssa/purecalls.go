@@ -0,0 +1,175 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import "sort"
+
+// pureCalls removes calls to //go:pure functions that are not needed.
+// The results of such a function depend only on its arguments and
+// calling it has no side effects, so
+//
+//   - a call whose results are all unused can be removed, and
+//   - a call that is always preceded by a call of the same function
+//     with the same arguments can reuse the results of that call.
+//
+// A call is removed by taking it out of the memory chain; deadcode
+// does the rest. This pass runs before expand calls, while the
+// arguments of a call are still its own SSA values, and after cse,
+// so that equal arguments are the same values.
+func pureCalls(f *Func) {
+	// Find the pure calls and their selectors.
+	var calls []*Value
+	sels := make(map[*Value][]*Value)
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			if v.Op == OpStaticLECall && v.Aux.(*AuxCall).Pure {
+				calls = append(calls, v)
+			}
+		}
+	}
+	if len(calls) == 0 {
+		return
+	}
+	unsafeCalls := make(map[*Value]bool)
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			for _, a := range v.Args {
+				if a.Op != OpStaticLECall || !a.Aux.(*AuxCall).Pure {
+					continue
+				}
+				if v.Op == OpSelectN {
+					sels[a] = append(sels[a], v)
+				} else {
+					// Results in memory, through OpSelectNAddr, are
+					// overwritten by the next call.
+					unsafeCalls[a] = true
+				}
+			}
+		}
+	}
+
+	// Visit the calls in dominator tree order, and the calls in a
+	// block in memory order, so that a call is visited after the
+	// calls that precede it.
+	sdom := f.Sdom()
+	memIdx := make(map[*Value]int32)
+	for _, c := range calls {
+		memIndex(c, memIdx)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		a, b := calls[i], calls[j]
+		if a.Block != b.Block {
+			return sdom[a.Block.ID].entry < sdom[b.Block.ID].entry
+		}
+		return memIdx[a] < memIdx[b]
+	})
+	var prev []*Value // kept calls, for reuse
+	for _, c := range calls {
+		if unsafeCalls[c] {
+			continue
+		}
+		nres := c.Aux.(*AuxCall).NResults()
+		var mem *Value // the memory selector of c
+		used := false
+		for _, s := range sels[c] {
+			if s.AuxInt == nres {
+				mem = s
+			} else {
+				used = true
+			}
+		}
+		if mem == nil {
+			continue
+		}
+
+		var dom *Value
+		if used {
+			for _, p := range prev {
+				if samePureCall(p, c) && callPrecedes(sdom, memIdx, p, c) {
+					dom = p
+					break
+				}
+			}
+			if dom == nil {
+				prev = append(prev, c)
+				continue
+			}
+			for _, s := range sels[c] {
+				if s == mem {
+					continue
+				}
+				s.copyOf(pureResult(dom, sels, s))
+			}
+		}
+		if f.pass.debug > 0 {
+			if dom != nil {
+				f.Warnl(c.Pos, "reused result of pure call to %s", c.Aux.(*AuxCall).Fn.Name)
+			} else {
+				f.Warnl(c.Pos, "removed unused pure call to %s", c.Aux.(*AuxCall).Fn.Name)
+			}
+		}
+		mem.copyOf(c.MemoryArg())
+		c.reset(OpInvalid)
+	}
+}
+
+// samePureCall reports whether the calls a and b call the same
+// function with the same arguments, not counting memory.
+func samePureCall(a, b *Value) bool {
+	if a.Aux.(*AuxCall).Fn != b.Aux.(*AuxCall).Fn || len(a.Args) != len(b.Args) {
+		return false
+	}
+	for i := range a.Args[:len(a.Args)-1] {
+		if a.Args[i] != b.Args[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// callPrecedes reports whether the call a is executed before every
+// execution of the call b.
+func callPrecedes(sdom SparseTree, memIdx map[*Value]int32, a, b *Value) bool {
+	if a.Block != b.Block {
+		return sdom.IsAncestorEq(a.Block, b.Block)
+	}
+	return memIdx[a] < memIdx[b]
+}
+
+// memIndex returns the position of the memory operation v in the
+// memory chain of its block, recording it and the positions of the
+// operations before it in idx. Calls stand for their memory results.
+func memIndex(v *Value, idx map[*Value]int32) int32 {
+	var chain []*Value
+	n := int32(0)
+	for m := v; m != nil && m.Block == v.Block && m.Op != OpPhi; m = m.MemoryArg() {
+		if m.Op == OpSelectN {
+			m = m.Args[0] // the call
+		}
+		if i, ok := idx[m]; ok {
+			n = i + 1
+			break
+		}
+		chain = append(chain, m)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		idx[chain[i]] = n
+		n++
+	}
+	return idx[v]
+}
+
+// pureResult returns the selector of call c for the same result as s,
+// adding one if c has none.
+func pureResult(c *Value, sels map[*Value][]*Value, s *Value) *Value {
+	for _, r := range sels[c] {
+		if r.AuxInt == s.AuxInt {
+			return r
+		}
+	}
+	r := c.Block.NewValue1I(c.Pos, OpSelectN, s.Type, s.AuxInt, c)
+	sels[c] = append(sels[c], r)
+	return r
+}
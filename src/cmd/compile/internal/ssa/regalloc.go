@@ -121,6 +121,7 @@ import (
 	"cmd/internal/src"
 	"cmd/internal/sys"
 	"fmt"
+	"math"
 	"math/bits"
 	"unsafe"
 )
@@ -406,27 +407,9 @@ func (s *regAllocState) allocReg(mask regMask, v *Value) register {
 	// farthest-in-the-future use.
 	// TODO: Prefer registers with already spilled Values?
 	// TODO: Modify preference using affinity graph.
-	// TODO: if a single value is in multiple registers, spill one of them
-	// before spilling a value in just a single register.
 
-	// Find a register to spill. We spill the register containing the value
-	// whose next use is as far in the future as possible.
-	// https://en.wikipedia.org/wiki/Page_replacement_algorithm#The_theoretically_optimal_page_replacement_algorithm
-	var r register
-	maxuse := int32(-1)
-	for t := register(0); t < s.numRegs; t++ {
-		if mask>>t&1 == 0 {
-			continue
-		}
-		v := s.regs[t].v
-		if n := s.values[v.ID].uses.dist; n > maxuse {
-			// v's next use is farther in the future than any value
-			// we've seen so far. A new best spill candidate.
-			r = t
-			maxuse = n
-		}
-	}
-	if maxuse == -1 {
+	r, _ := s.spillCandidate(mask)
+	if r == noRegister {
 		s.f.Fatalf("couldn't find register to spill")
 	}
 
@@ -762,6 +745,48 @@ func (s *regAllocState) advanceUses(v *Value) {
 	}
 }
 
+// spillCandidate returns the register in mask containing the value
+// whose next use is as far in the future as possible, and the distance
+// to that use, or noRegister if no register in mask holds a value.
+// A value in several registers is dropped from one of them first.
+// https://en.wikipedia.org/wiki/Page_replacement_algorithm#The_theoretically_optimal_page_replacement_algorithm
+func (s *regAllocState) spillCandidate(mask regMask) (register, int32) {
+	r := noRegister
+	maxuse := int32(-1)
+	for t := register(0); t < s.numRegs; t++ {
+		if mask>>t&1 == 0 || s.regs[t].v == nil {
+			continue
+		}
+		v := s.regs[t].v
+		if countRegs(s.values[v.ID].regs) > 1 {
+			// v is in another register too, dropping this copy is free.
+			return t, math.MaxInt32
+		}
+		n := s.values[v.ID].uses.dist
+		if n > maxuse {
+			// v's next use is farther in the future than any value
+			// we've seen so far. A new best spill candidate.
+			r = t
+			maxuse = n
+		}
+	}
+	return r, maxuse
+}
+
+// nextUseAfterCurrentInstruction returns the distance to the first use
+// of v after the current instruction, or math.MaxInt32 if there is none.
+func (s *regAllocState) nextUseAfterCurrentInstruction(v *Value) int32 {
+	u := s.values[v.ID].uses
+	d := u.dist
+	for u != nil && u.dist == d {
+		u = u.next
+	}
+	if u == nil {
+		return math.MaxInt32
+	}
+	return u.dist
+}
+
 // liveAfterCurrentInstruction reports whether v is live after
 // the current instruction is completed.  v must be used by the
 // current instruction.
@@ -1440,11 +1465,15 @@ func (s *regAllocState) regalloc(f *Func) {
 				// Possible new registers to copy into.
 				m = s.compatRegs(v.Args[0].Type) &^ s.used
 				if m == 0 {
-					// No free registers.  In this case we'll just clobber
-					// an input and future uses of that input must use a restore.
-					// TODO(khr): We should really do this like allocReg does it,
-					// spilling the value with the most distant next use.
-					goto ok
+					// No free registers. Like allocReg, kick out the value
+					// with the most distant next use, if it is used later
+					// than the input. Otherwise we'll just clobber the input
+					// and its future uses must use a restore.
+					r, dist := s.spillCandidate(s.compatRegs(v.Args[0].Type) &^ s.nospill)
+					if r == noRegister || dist <= s.nextUseAfterCurrentInstruction(v.Args[0]) {
+						goto ok
+					}
+					m = regMask(1) << r
 				}
 
 				// Try to move an input to the desired output.
@@ -1813,6 +1842,36 @@ func (s *regAllocState) regalloc(f *Func) {
 		}
 		b.Values = b.Values[:i]
 	}
+
+	if f.pass.stats > 0 {
+		s.logSpillStats()
+	}
+}
+
+// logSpillStats logs the number of spills and restores in f, and how
+// many of them are inside loops, where they are executed repeatedly.
+// It is enabled with -d=ssa/regalloc/stats=1.
+func (s *regAllocState) logSpillStats() {
+	var spills, restores, loopSpills, loopRestores int
+	for _, b := range s.f.Blocks {
+		inLoop := s.loopnest.b2l[b.ID] != nil
+		for _, v := range b.Values {
+			switch v.Op {
+			case OpStoreReg:
+				spills++
+				if inLoop {
+					loopSpills++
+				}
+			case OpLoadReg:
+				restores++
+				if inLoop {
+					loopRestores++
+				}
+			}
+		}
+	}
+	s.f.LogStat("spill_stats", spills, "spills", restores, "restores",
+		loopSpills, "loop_spills", loopRestores, "loop_restores")
 }
 
 func (s *regAllocState) placeSpills() {
@@ -7,6 +7,7 @@ package ssa
 import (
 	"cmd/compile/internal/types"
 	"cmd/internal/src"
+	"fmt"
 	"testing"
 )
 
@@ -219,6 +220,45 @@ func TestSpillMove2(t *testing.T) {
 
 }
 
+// TestClobberedInputStaysInRegister checks that when an instruction
+// that overwrites its input needs a copy of it and no register is
+// free, the value with the most distant next use is kicked out instead
+// of the input, which is used again right away.
+func TestClobberedInputStaysInRegister(t *testing.T) {
+	c := testConfig(t)
+	const n = 16 // more than the number of registers
+	i64 := c.config.Types.Int64
+	entries := []interface{}{
+		Valu("mem", OpInitMem, types.TypeMem, 0, nil),
+		Valu("p", OpArg, i64.PtrTo(), 0, c.Frontend().Auto(src.NoXPos, i64.PtrTo())),
+		Valu("x", OpAMD64MOVQload, i64, 0, nil, "p", "mem"),
+	}
+	for i := 0; i < n; i++ {
+		entries = append(entries, Valu(fmt.Sprintf("k%d", i), OpAMD64MOVQload, i64, int64(8*(i+1)), nil, "p", "mem"))
+	}
+	mem := "mem"
+	for i := 0; i < n; i++ {
+		// x is used again by the next SUBQ, the k's only at the end.
+		entries = append(entries,
+			Valu(fmt.Sprintf("a%d", i), OpAMD64SUBQ, i64, 0, nil, "x", fmt.Sprintf("k%d", i)),
+			Valu(fmt.Sprintf("s%d", i), OpAMD64MOVQstore, types.TypeMem, 0, nil, "p", fmt.Sprintf("a%d", i), mem))
+		mem = fmt.Sprintf("s%d", i)
+	}
+	for i := 0; i < n; i++ {
+		entries = append(entries, Valu(fmt.Sprintf("t%d", i), OpAMD64MOVQstore, types.TypeMem, int64(8*(i+1)), nil, "p", fmt.Sprintf("k%d", i), mem))
+		mem = fmt.Sprintf("t%d", i)
+	}
+	entries = append(entries, Exit(mem))
+	f := c.Fun("entry", Bloc("entry", entries...))
+	regalloc(f.f)
+	checkFunc(f.f)
+	for i := 0; i < n; i++ {
+		if a := f.values[fmt.Sprintf("a%d", i)]; a.Args[0].Op == OpLoadReg {
+			t.Errorf("x restored for %s", a.LongString())
+		}
+	}
+}
+
 func numSpills(b *Block) int {
 	n := 0
 	for _, v := range b.Values {
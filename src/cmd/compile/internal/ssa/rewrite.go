@@ -401,6 +401,30 @@ func ntz32(x int32) int { return bits.TrailingZeros32(uint32(x)) }
 func ntz16(x int16) int { return bits.TrailingZeros16(uint16(x)) }
 func ntz8(x int8) int   { return bits.TrailingZeros8(uint8(x)) }
 
+// bitlenX returns the minimum number of bits required to represent x.
+func bitlen64(x int64) int { return bits.Len64(uint64(x)) }
+func bitlen32(x int32) int { return bits.Len32(uint32(x)) }
+func bitlen16(x int16) int { return bits.Len16(uint16(x)) }
+func bitlen8(x int8) int   { return bits.Len8(uint8(x)) }
+
+// popcntX returns the number of one bits in x.
+func popcnt64(x int64) int { return bits.OnesCount64(uint64(x)) }
+func popcnt32(x int32) int { return bits.OnesCount32(uint32(x)) }
+func popcnt16(x int16) int { return bits.OnesCount16(uint16(x)) }
+func popcnt8(x int8) int   { return bits.OnesCount8(uint8(x)) }
+
+// sqrt32 returns the single precision square root of x.
+// Computing it in double precision and rounding gives the
+// correctly rounded result.
+func sqrt32(x float32) float32 { return float32(math.Sqrt(float64(x))) }
+
+// isFoldableFloat reports whether x, the result of folding a
+// floating-point operation, may be used as a constant. NaNs cannot
+// be constants, and, as for negation, we don't fold to negative zero.
+func isFoldableFloat(x float64) bool {
+	return !math.IsNaN(x) && !(x == 0 && math.Signbit(x))
+}
+
 func oneBit(x int64) bool   { return x&(x-1) == 0 && x != 0 }
 func oneBit8(x int8) bool   { return x&(x-1) == 0 && x != 0 }
 func oneBit16(x int16) bool { return x&(x-1) == 0 && x != 0 }
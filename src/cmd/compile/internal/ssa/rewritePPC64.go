@@ -602,6 +602,10 @@ func rewriteValuePPC64(v *Value) bool {
 		return rewriteValuePPC64_OpPPC64ROTLW(v)
 	case OpPPC64ROTLWconst:
 		return rewriteValuePPC64_OpPPC64ROTLWconst(v)
+	case OpPPC64SETBC:
+		return rewriteValuePPC64_OpPPC64SETBC(v)
+	case OpPPC64SETBCR:
+		return rewriteValuePPC64_OpPPC64SETBCR(v)
 	case OpPPC64SLD:
 		return rewriteValuePPC64_OpPPC64SLD(v)
 	case OpPPC64SLDconst:
@@ -5231,6 +5235,19 @@ func rewriteValuePPC64_OpPPC64Equal(v *Value) bool {
 		return true
 	}
 	// match: (Equal cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (SETBC [2] cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64SETBC)
+		v.AuxInt = int32ToAuxInt(2)
+		v.AddArg(cmp)
+		return true
+	}
+	// match: (Equal cmp)
 	// result: (ISELB [2] (MOVDconst [1]) cmp)
 	for {
 		cmp := v_0
@@ -5334,6 +5351,24 @@ func rewriteValuePPC64_OpPPC64FGreaterEqual(v *Value) bool {
 	b := v.Block
 	typ := &b.Func.Config.Types
 	// match: (FGreaterEqual cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (ISEL [2] (MOVDconst [1]) (SETBC [1] cmp) cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64ISEL)
+		v.AuxInt = int32ToAuxInt(2)
+		v0 := b.NewValue0(v.Pos, OpPPC64MOVDconst, typ.Int64)
+		v0.AuxInt = int64ToAuxInt(1)
+		v1 := b.NewValue0(v.Pos, OpPPC64SETBC, typ.Int32)
+		v1.AuxInt = int32ToAuxInt(1)
+		v1.AddArg(cmp)
+		v.AddArg3(v0, v1, cmp)
+		return true
+	}
+	// match: (FGreaterEqual cmp)
 	// result: (ISEL [2] (MOVDconst [1]) (ISELB [1] (MOVDconst [1]) cmp) cmp)
 	for {
 		cmp := v_0
@@ -5353,6 +5388,19 @@ func rewriteValuePPC64_OpPPC64FGreaterThan(v *Value) bool {
 	b := v.Block
 	typ := &b.Func.Config.Types
 	// match: (FGreaterThan cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (SETBC [1] cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64SETBC)
+		v.AuxInt = int32ToAuxInt(1)
+		v.AddArg(cmp)
+		return true
+	}
+	// match: (FGreaterThan cmp)
 	// result: (ISELB [1] (MOVDconst [1]) cmp)
 	for {
 		cmp := v_0
@@ -5369,6 +5417,24 @@ func rewriteValuePPC64_OpPPC64FLessEqual(v *Value) bool {
 	b := v.Block
 	typ := &b.Func.Config.Types
 	// match: (FLessEqual cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (ISEL [2] (MOVDconst [1]) (SETBC [0] cmp) cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64ISEL)
+		v.AuxInt = int32ToAuxInt(2)
+		v0 := b.NewValue0(v.Pos, OpPPC64MOVDconst, typ.Int64)
+		v0.AuxInt = int64ToAuxInt(1)
+		v1 := b.NewValue0(v.Pos, OpPPC64SETBC, typ.Int32)
+		v1.AuxInt = int32ToAuxInt(0)
+		v1.AddArg(cmp)
+		v.AddArg3(v0, v1, cmp)
+		return true
+	}
+	// match: (FLessEqual cmp)
 	// result: (ISEL [2] (MOVDconst [1]) (ISELB [0] (MOVDconst [1]) cmp) cmp)
 	for {
 		cmp := v_0
@@ -5388,6 +5454,19 @@ func rewriteValuePPC64_OpPPC64FLessThan(v *Value) bool {
 	b := v.Block
 	typ := &b.Func.Config.Types
 	// match: (FLessThan cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (SETBC [0] cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64SETBC)
+		v.AuxInt = int32ToAuxInt(0)
+		v.AddArg(cmp)
+		return true
+	}
+	// match: (FLessThan cmp)
 	// result: (ISELB [0] (MOVDconst [1]) cmp)
 	for {
 		cmp := v_0
@@ -5777,6 +5856,19 @@ func rewriteValuePPC64_OpPPC64GreaterEqual(v *Value) bool {
 		return true
 	}
 	// match: (GreaterEqual cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (SETBCR [0] cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64SETBCR)
+		v.AuxInt = int32ToAuxInt(0)
+		v.AddArg(cmp)
+		return true
+	}
+	// match: (GreaterEqual cmp)
 	// result: (ISELB [4] (MOVDconst [1]) cmp)
 	for {
 		cmp := v_0
@@ -5834,6 +5926,19 @@ func rewriteValuePPC64_OpPPC64GreaterThan(v *Value) bool {
 		return true
 	}
 	// match: (GreaterThan cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (SETBC [1] cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64SETBC)
+		v.AuxInt = int32ToAuxInt(1)
+		v.AddArg(cmp)
+		return true
+	}
+	// match: (GreaterThan cmp)
 	// result: (ISELB [1] (MOVDconst [1]) cmp)
 	for {
 		cmp := v_0
@@ -6484,6 +6589,19 @@ func rewriteValuePPC64_OpPPC64LessEqual(v *Value) bool {
 		return true
 	}
 	// match: (LessEqual cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (SETBCR [1] cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64SETBCR)
+		v.AuxInt = int32ToAuxInt(1)
+		v.AddArg(cmp)
+		return true
+	}
+	// match: (LessEqual cmp)
 	// result: (ISELB [5] (MOVDconst [1]) cmp)
 	for {
 		cmp := v_0
@@ -6541,6 +6659,19 @@ func rewriteValuePPC64_OpPPC64LessThan(v *Value) bool {
 		return true
 	}
 	// match: (LessThan cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (SETBC [0] cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64SETBC)
+		v.AuxInt = int32ToAuxInt(0)
+		v.AddArg(cmp)
+		return true
+	}
+	// match: (LessThan cmp)
 	// result: (ISELB [0] (MOVDconst [1]) cmp)
 	for {
 		cmp := v_0
@@ -11406,6 +11537,19 @@ func rewriteValuePPC64_OpPPC64NotEqual(v *Value) bool {
 		return true
 	}
 	// match: (NotEqual cmp)
+	// cond: objabi.GOPPC64 >= 10
+	// result: (SETBCR [2] cmp)
+	for {
+		cmp := v_0
+		if !(objabi.GOPPC64 >= 10) {
+			break
+		}
+		v.reset(OpPPC64SETBCR)
+		v.AuxInt = int32ToAuxInt(2)
+		v.AddArg(cmp)
+		return true
+	}
+	// match: (NotEqual cmp)
 	// result: (ISELB [6] (MOVDconst [1]) cmp)
 	for {
 		cmp := v_0
@@ -13101,6 +13245,266 @@ func rewriteValuePPC64_OpPPC64ROTLWconst(v *Value) bool {
 	}
 	return false
 }
+func rewriteValuePPC64_OpPPC64SETBC(v *Value) bool {
+	v_0 := v.Args[0]
+	// match: (SETBC [0] (FlagLT))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 0 || v_0.Op != OpPPC64FlagLT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBC [0] (FlagGT))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 0 || v_0.Op != OpPPC64FlagGT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBC [0] (FlagEQ))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 0 || v_0.Op != OpPPC64FlagEQ {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBC [1] (FlagGT))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 1 || v_0.Op != OpPPC64FlagGT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBC [1] (FlagLT))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 1 || v_0.Op != OpPPC64FlagLT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBC [1] (FlagEQ))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 1 || v_0.Op != OpPPC64FlagEQ {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBC [2] (FlagEQ))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 2 || v_0.Op != OpPPC64FlagEQ {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBC [2] (FlagLT))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 2 || v_0.Op != OpPPC64FlagLT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBC [2] (FlagGT))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 2 || v_0.Op != OpPPC64FlagGT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBC [0] (InvertFlags bool))
+	// result: (SETBC [1] bool)
+	for {
+		if auxIntToInt32(v.AuxInt) != 0 || v_0.Op != OpPPC64InvertFlags {
+			break
+		}
+		bool := v_0.Args[0]
+		v.reset(OpPPC64SETBC)
+		v.AuxInt = int32ToAuxInt(1)
+		v.AddArg(bool)
+		return true
+	}
+	// match: (SETBC [1] (InvertFlags bool))
+	// result: (SETBC [0] bool)
+	for {
+		if auxIntToInt32(v.AuxInt) != 1 || v_0.Op != OpPPC64InvertFlags {
+			break
+		}
+		bool := v_0.Args[0]
+		v.reset(OpPPC64SETBC)
+		v.AuxInt = int32ToAuxInt(0)
+		v.AddArg(bool)
+		return true
+	}
+	// match: (SETBC [2] (InvertFlags bool))
+	// result: (SETBC [2] bool)
+	for {
+		if auxIntToInt32(v.AuxInt) != 2 || v_0.Op != OpPPC64InvertFlags {
+			break
+		}
+		bool := v_0.Args[0]
+		v.reset(OpPPC64SETBC)
+		v.AuxInt = int32ToAuxInt(2)
+		v.AddArg(bool)
+		return true
+	}
+	return false
+}
+func rewriteValuePPC64_OpPPC64SETBCR(v *Value) bool {
+	v_0 := v.Args[0]
+	// match: (SETBCR [0] (FlagLT))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 0 || v_0.Op != OpPPC64FlagLT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBCR [0] (FlagGT))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 0 || v_0.Op != OpPPC64FlagGT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBCR [0] (FlagEQ))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 0 || v_0.Op != OpPPC64FlagEQ {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBCR [1] (FlagGT))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 1 || v_0.Op != OpPPC64FlagGT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBCR [1] (FlagLT))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 1 || v_0.Op != OpPPC64FlagLT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBCR [1] (FlagEQ))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 1 || v_0.Op != OpPPC64FlagEQ {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBCR [2] (FlagEQ))
+	// result: (MOVDconst [0])
+	for {
+		if auxIntToInt32(v.AuxInt) != 2 || v_0.Op != OpPPC64FlagEQ {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(0)
+		return true
+	}
+	// match: (SETBCR [2] (FlagLT))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 2 || v_0.Op != OpPPC64FlagLT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBCR [2] (FlagGT))
+	// result: (MOVDconst [1])
+	for {
+		if auxIntToInt32(v.AuxInt) != 2 || v_0.Op != OpPPC64FlagGT {
+			break
+		}
+		v.reset(OpPPC64MOVDconst)
+		v.AuxInt = int64ToAuxInt(1)
+		return true
+	}
+	// match: (SETBCR [0] (InvertFlags bool))
+	// result: (SETBCR [1] bool)
+	for {
+		if auxIntToInt32(v.AuxInt) != 0 || v_0.Op != OpPPC64InvertFlags {
+			break
+		}
+		bool := v_0.Args[0]
+		v.reset(OpPPC64SETBCR)
+		v.AuxInt = int32ToAuxInt(1)
+		v.AddArg(bool)
+		return true
+	}
+	// match: (SETBCR [1] (InvertFlags bool))
+	// result: (SETBCR [0] bool)
+	for {
+		if auxIntToInt32(v.AuxInt) != 1 || v_0.Op != OpPPC64InvertFlags {
+			break
+		}
+		bool := v_0.Args[0]
+		v.reset(OpPPC64SETBCR)
+		v.AuxInt = int32ToAuxInt(0)
+		v.AddArg(bool)
+		return true
+	}
+	// match: (SETBCR [2] (InvertFlags bool))
+	// result: (SETBCR [2] bool)
+	for {
+		if auxIntToInt32(v.AuxInt) != 2 || v_0.Op != OpPPC64InvertFlags {
+			break
+		}
+		bool := v_0.Args[0]
+		v.reset(OpPPC64SETBCR)
+		v.AuxInt = int32ToAuxInt(2)
+		v.AddArg(bool)
+		return true
+	}
+	return false
+}
 func rewriteValuePPC64_OpPPC64SLD(v *Value) bool {
 	v_1 := v.Args[1]
 	v_0 := v.Args[0]
@@ -0,0 +1,528 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+	"fmt"
+	"sort"
+)
+
+const (
+	// maxSROAPieces is the maximum number of pieces a variable
+	// may be split into by sroa.
+	maxSROAPieces = 16
+
+	// maxSROASize is the size in bytes of the largest variable
+	// that sroa will split. Larger variables are usually accessed
+	// with variable indexes, and are left for the frame size check.
+	maxSROASize = 1024
+)
+
+// sroa performs scalar replacement of aggregates.
+//
+// Local variables whose type is too large or too complex to be
+// represented as a single SSA value (see ssagen.TypeOK) live in
+// memory, and every access is a load or store through the variable's
+// address. If such a variable never has its address taken, and every
+// access to it loads or stores a piece of it at a constant offset,
+// then the variable can be replaced by an independent SSA value for
+// each piece. The pieces can then live in registers, and pieces that
+// are written but never read disappear entirely.
+//
+// Whole-variable zeroing and copies into the variable (OpZero and
+// OpMove with the variable as destination) are supported, as are
+// copies out of the variable (OpMove with the variable as source)
+// provided that the pieces cover all of the copied bytes.
+func sroa(f *Func) {
+	// addr maps pointer values to the auto they point into.
+	type addrInfo struct {
+		n   *ir.Name
+		off int64
+	}
+	addr := map[*Value]addrInfo{}
+	var addrOf func(v *Value) (addrInfo, bool)
+	addrOf = func(v *Value) (addrInfo, bool) {
+		if a, ok := addr[v]; ok {
+			return a, a.n != nil
+		}
+		var a addrInfo
+		switch v.Op {
+		case OpLocalAddr:
+			if n, ok := v.Aux.(*ir.Name); ok && n.Class == ir.PAUTO && !n.Addrtaken() {
+				a = addrInfo{n: n}
+			}
+		case OpOffPtr:
+			if b, ok := addrOf(v.Args[0]); ok {
+				a = addrInfo{n: b.n, off: b.off + v.AuxInt}
+			}
+		}
+		addr[v] = a
+		return a, a.n != nil
+	}
+
+	// Collect the memory operations on each auto, and find the autos
+	// whose address is used in any other way.
+	var names []*ir.Name // in order of appearance, for determinism
+	ops := map[*ir.Name][]*Value{}
+	var bad ir.NameSet
+	use := func(n *ir.Name, v *Value) {
+		if _, ok := ops[n]; !ok {
+			names = append(names, n)
+		}
+		ops[n] = append(ops[n], v)
+	}
+	for _, b := range f.Blocks {
+		for _, v := range b.Values {
+			switch v.Op {
+			case OpVarDef, OpVarKill:
+				if n, ok := v.Aux.(*ir.Name); ok && n.Class == ir.PAUTO {
+					use(n, v)
+				}
+				continue
+			case OpVarLive:
+				if n, ok := v.Aux.(*ir.Name); ok {
+					bad.Add(n)
+				}
+				continue
+			}
+			for i, a := range v.Args {
+				p, ok := addrOf(a)
+				if !ok {
+					continue
+				}
+				switch {
+				case v.Op == OpOffPtr:
+					// Handled by addrOf.
+				case i == 0 && (v.Op == OpLoad || v.Op == OpStore || v.Op == OpZero || v.Op == OpMove):
+					use(p.n, v)
+				case i == 1 && v.Op == OpMove:
+					if d, ok := addrOf(v.Args[0]); ok && d.n == p.n {
+						bad.Add(p.n)
+					}
+					use(p.n, v)
+				default:
+					bad.Add(p.n)
+				}
+			}
+		}
+		for _, c := range b.ControlValues() {
+			if p, ok := addrOf(c); ok {
+				bad.Add(p.n)
+			}
+		}
+	}
+
+	// A piece of an auto that is loaded and stored as a unit.
+	type piece struct {
+		off int64
+		t   *types.Type
+	}
+	// A range of an auto that is zeroed or copied as a whole.
+	type span struct {
+		off, size int64
+		src       bool // auto is the source of a copy
+	}
+
+	// pieces computes the pieces of n, or reports false if n cannot
+	// be split.
+	pieces := func(n *ir.Name) ([]piece, bool) {
+		var ps []piece
+		var spans []span
+		for _, v := range ops[n] {
+			switch v.Op {
+			case OpLoad:
+				ps = append(ps, piece{addr[v.Args[0]].off, v.Type})
+			case OpStore:
+				ps = append(ps, piece{addr[v.Args[0]].off, v.Aux.(*types.Type)})
+			case OpZero:
+				spans = append(spans, span{off: addr[v.Args[0]].off, size: v.AuxInt})
+			case OpMove:
+				if d, ok := addrOf(v.Args[0]); ok && d.n == n {
+					spans = append(spans, span{off: d.off, size: v.AuxInt})
+				} else {
+					spans = append(spans, span{off: addr[v.Args[1]].off, size: v.AuxInt, src: true})
+				}
+			}
+		}
+		if len(ps) == 0 {
+			// Nothing to split. Dead stores to the auto are
+			// removed by dead auto elimination.
+			return nil, false
+		}
+
+		// Pieces must be identical or disjoint.
+		sort.Slice(ps, func(i, j int) bool {
+			if ps[i].off != ps[j].off {
+				return ps[i].off < ps[j].off
+			}
+			return ps[i].t.Size() < ps[j].t.Size()
+		})
+		j := 0
+		for i, p := range ps {
+			if p.off < 0 || p.off+p.t.Size() > n.Type().Size() || !canSROA(p.t) {
+				return nil, false
+			}
+			if i > 0 {
+				q := ps[j-1]
+				if p.off == q.off && p.t.Compare(q.t) == types.CMPeq {
+					continue
+				}
+				if p.off < q.off+q.t.Size() {
+					return nil, false
+				}
+			}
+			ps[j] = p
+			j++
+		}
+		ps = ps[:j]
+		if len(ps) > maxSROAPieces {
+			return nil, false
+		}
+
+		// Each piece must be entirely inside or outside of each
+		// zeroed or copied span. Copies out of the auto must be
+		// entirely covered by pieces.
+		for _, s := range spans {
+			covered := s.off
+			for _, p := range ps {
+				end := p.off + p.t.Size()
+				if end <= s.off || p.off >= s.off+s.size {
+					continue
+				}
+				if p.off < s.off || end > s.off+s.size {
+					return nil, false
+				}
+				if p.off == covered {
+					covered = end
+				}
+			}
+			if s.src && covered != s.off+s.size {
+				return nil, false
+			}
+		}
+		return ps, true
+	}
+
+	changed := false
+	for _, n := range names {
+		if bad.Has(n) || n.Type().Size() > maxSROASize {
+			continue
+		}
+		ps, ok := pieces(n)
+		if !ok {
+			continue
+		}
+
+		type key struct {
+			piece int
+			mem   *Value
+		}
+		memo := map[key]*Value{}
+
+		// load returns a load of piece i of n from the source of the
+		// copy m into n. If at is not nil, at is turned into that load.
+		load := func(i int, m, at *Value) *Value {
+			p := ps[i]
+			d, _ := addrOf(m.Args[0])
+			b, pos := m.Block, m.Pos
+			if at != nil {
+				b, pos = at.Block, at.Pos
+			}
+			ptr := m.Args[1]
+			if o := p.off - d.off; o != 0 {
+				ptr = b.NewValue1I(pos, OpOffPtr, p.t.PtrTo(), o, ptr)
+			}
+			v := at
+			if v == nil {
+				v = b.NewValue2(pos, OpLoad, p.t, ptr, m.Args[2])
+			} else {
+				v.SetArgs2(ptr, m.Args[2])
+			}
+			if s, ok := addrOf(ptr); ok {
+				use(s.n, v)
+			}
+			return v
+		}
+
+		// own reports whether the memory operation v only affects n,
+		// and so will be removed.
+		own := func(v *Value) bool {
+			switch v.Op {
+			case OpCopy:
+				return true
+			case OpVarDef, OpVarKill:
+				return v.Aux.(*ir.Name) == n
+			case OpStore, OpZero, OpMove:
+				d, ok := addrOf(v.Args[0])
+				return ok && d.n == n
+			}
+			return false
+		}
+
+		// value returns the value of piece i of n in memory state mem.
+		// If at is not nil, it is the load of piece i being replaced.
+		var value func(i int, mem, at *Value) *Value
+		value = func(i int, mem, at *Value) *Value {
+			p := ps[i]
+			var visited []*Value
+			var v *Value
+		Chain:
+			for {
+				if x, ok := memo[key{i, mem}]; ok {
+					v = x
+					break
+				}
+				if at != nil && !own(mem) {
+					at = nil
+				}
+				visited = append(visited, mem)
+				switch mem.Op {
+				case OpPhi:
+					phi := mem.Block.NewValue0(mem.Pos, OpPhi, p.t)
+					memo[key{i, mem}] = phi
+					for _, a := range mem.Args {
+						phi.AddArg(value(i, a, nil))
+					}
+					v = phi
+					break Chain
+				case OpInitMem:
+					v = zeroValue(f, p.t)
+					break Chain
+				case OpCopy:
+					mem = mem.Args[0]
+					continue
+				case OpVarDef:
+					if mem.Aux.(*ir.Name) == n {
+						// Start of n's lifetime. There are no
+						// reads before the first write, so any
+						// value will do.
+						v = zeroValue(f, p.t)
+						break Chain
+					}
+				case OpStore:
+					if d, ok := addrOf(mem.Args[0]); ok && d.n == n && d.off == p.off {
+						v = mem.Args[1]
+						break Chain
+					}
+				case OpZero:
+					if d, ok := addrOf(mem.Args[0]); ok && d.n == n && d.off <= p.off && p.off < d.off+mem.AuxInt {
+						v = zeroValue(f, p.t)
+						break Chain
+					}
+				case OpMove:
+					if d, ok := addrOf(mem.Args[0]); ok && d.n == n && d.off <= p.off && p.off < d.off+mem.AuxInt {
+						if at != nil {
+							// Nothing but n is written between the copy
+							// and the load being replaced, so load
+							// directly from the source of the copy. The
+							// load stays in place, where it may still be
+							// combined with its use.
+							return load(i, mem, at)
+						}
+						v = load(i, mem, nil)
+						break Chain
+					}
+				case OpSelect1, OpSelectN:
+					mem = mem.Args[0].MemoryArg()
+					continue
+				}
+				mem = mem.MemoryArg()
+				if mem == nil {
+					f.Fatalf("sroa: memory chain of %v ends at %v", n, visited[len(visited)-1].LongString())
+				}
+			}
+			for _, m := range visited {
+				memo[key{i, m}] = v
+			}
+			return v
+		}
+
+		// Replace loads of n with the values of its pieces.
+		index := func(off int64) int {
+			return sort.Search(len(ps), func(i int) bool { return ps[i].off >= off })
+		}
+		for _, v := range ops[n] {
+			if v.Op != OpLoad {
+				continue
+			}
+			if d, ok := addrOf(v.Args[0]); ok && d.n == n {
+				if x := value(index(d.off), v.Args[1], v); x != v {
+					v.copyOf(x)
+				}
+			}
+		}
+
+		// Replace copies out of n with stores of its pieces.
+		var moves []*Value
+		for _, v := range ops[n] {
+			if v.Op != OpMove {
+				continue
+			}
+			if d, ok := addrOf(v.Args[0]); ok && d.n == n {
+				continue
+			}
+			moves = append(moves, v)
+		}
+		for _, m := range moves {
+			s, _ := addrOf(m.Args[1])
+			dst, mem := m.Args[0], m.Args[2]
+			var vals []*Value
+			var idx []int
+			for i, p := range ps {
+				if s.off <= p.off && p.off < s.off+m.AuxInt {
+					vals = append(vals, value(i, mem, nil))
+					idx = append(idx, i)
+				}
+			}
+			for k, i := range idx {
+				p := ps[i]
+				ptr := dst
+				if o := p.off - s.off; o != 0 {
+					ptr = m.Block.NewValue1I(m.Pos, OpOffPtr, p.t.PtrTo(), o, dst)
+				}
+				if k == len(idx)-1 {
+					// Reuse m for the last store, so that its uses
+					// see the final memory state. If the destination
+					// is another auto, m is already among its uses.
+					m.reset(OpStore)
+					m.Aux = p.t
+					m.AddArgs(ptr, vals[k], mem)
+					break
+				}
+				mem = m.Block.NewValue3A(m.Pos, OpStore, types.TypeMem, p.t, ptr, vals[k], mem)
+				if d, ok := addrOf(ptr); ok {
+					use(d.n, mem)
+				}
+			}
+		}
+
+		// Record the stored values as the values of n's pieces for
+		// debug info. This must happen before the stores are removed.
+		if !ir.IsAutoTmp(n) {
+			parent := &LocalSlot{N: n, Type: n.Type()}
+			slots := make([]*LocalSlot, len(ps))
+			for _, v := range ops[n] {
+				if v.Op != OpStore {
+					continue
+				}
+				d, ok := addrOf(v.Args[0])
+				if !ok || d.n != n {
+					continue
+				}
+				i := index(d.off)
+				if slots[i] == nil {
+					p := ps[i]
+					s := f.fe.SplitSlot(parent, pieceSuffix(n.Type(), p.off), p.off, p.t)
+					slots[i] = &s
+					f.Names = append(f.Names, s)
+				}
+				f.NamedValues[*slots[i]] = append(f.NamedValues[*slots[i]], v.Args[1])
+			}
+		}
+
+		// Remove the remaining memory operations on n.
+		for _, v := range ops[n] {
+			switch v.Op {
+			case OpStore, OpZero, OpMove:
+				if d, ok := addrOf(v.Args[0]); !ok || d.n != n {
+					continue // not (or no longer) a write to n
+				}
+			case OpVarDef, OpVarKill:
+			default:
+				continue
+			}
+			v.copyOf(v.MemoryArg())
+		}
+
+		if f.pass.debug > 0 {
+			f.Warnl(n.Pos(), "scalar replaced %v (%d pieces)", n, len(ps))
+		}
+		changed = true
+	}
+
+	if changed {
+		phielim(f)
+		copyelim(f)
+	}
+}
+
+// canSROA reports whether a piece of type t can be
+// represented as an SSA value by sroa.
+func canSROA(t *types.Type) bool {
+	switch {
+	case t.IsBoolean(), t.IsInteger(), t.IsFloat(), t.IsPtrShaped(),
+		t.IsString(), t.IsSlice(), t.IsInterface():
+		return true
+	}
+	return false
+}
+
+// zeroValue returns the zero value of type t, which must satisfy canSROA.
+func zeroValue(f *Func, t *types.Type) *Value {
+	switch {
+	case t.IsBoolean():
+		return f.ConstBool(t, false)
+	case t.IsInteger():
+		switch t.Size() {
+		case 1:
+			return f.ConstInt8(t, 0)
+		case 2:
+			return f.ConstInt16(t, 0)
+		case 4:
+			return f.ConstInt32(t, 0)
+		case 8:
+			return f.ConstInt64(t, 0)
+		}
+	case t.IsFloat():
+		switch t.Size() {
+		case 4:
+			return f.ConstFloat32(t, 0)
+		case 8:
+			return f.ConstFloat64(t, 0)
+		}
+	case t.IsPtrShaped():
+		return f.ConstNil(t)
+	}
+
+	// sroa runs after opt, so build the decomposed forms that
+	// opt would produce for ConstString, ConstSlice, and ConstInterface.
+	typ := &f.Config.Types
+	zero := func() *Value {
+		if f.Config.PtrSize == 4 {
+			return f.ConstInt32(typ.Int, 0)
+		}
+		return f.ConstInt64(typ.Int, 0)
+	}
+	switch {
+	case t.IsString():
+		return f.Entry.NewValue2(src.NoXPos, OpStringMake, t, f.ConstNil(typ.BytePtr), zero())
+	case t.IsSlice():
+		return f.Entry.NewValue3(src.NoXPos, OpSliceMake, t, f.ConstNil(t.Elem().PtrTo()), zero(), zero())
+	case t.IsInterface():
+		return f.Entry.NewValue2(src.NoXPos, OpIMake, t, f.ConstNil(typ.Uintptr), f.ConstNil(typ.BytePtr))
+	}
+	f.Fatalf("sroa: no zero value for %v", t)
+	return nil
+}
+
+// pieceSuffix returns a name suffix, such as ".f" or "[2].g",
+// describing the field or element of type t at offset off.
+func pieceSuffix(t *types.Type, off int64) string {
+	switch {
+	case t.IsStruct():
+		for _, f := range t.FieldSlice() {
+			if f.Offset <= off && off < f.Offset+f.Type.Size() {
+				return "." + f.Sym.Name + pieceSuffix(f.Type, off-f.Offset)
+			}
+		}
+	case t.IsArray() && t.Elem().Size() > 0:
+		i := off / t.Elem().Size()
+		return fmt.Sprintf("[%d]", i) + pieceSuffix(t.Elem(), off-i*t.Elem().Size())
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"bytes"
+	"cmd/compile/internal/base"
+	"encoding/csv"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// statsCSV is the file -d=ssa/all/stats=csv:<file> writes per-pass
+// statistics to, or nil.
+var statsCSV *csvFile
+
+// statsCSVHeader is the first line of a new statistics file.
+const statsCSVHeader = "package,function,pass,values,blocks,allocs,bytes,ns\n"
+
+// A csvFile is a file that compile workers, and compiles of other
+// packages, append rows to. Each Write is a single append, so rows
+// from different writers are not interleaved.
+type csvFile struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// openStatsCSV opens name for appending per-pass statistics, writing
+// the header if the file is empty.
+func openStatsCSV(name string) (*csvFile, error) {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	if fi, err := f.Stat(); err == nil && fi.Size() == 0 {
+		f.WriteString(statsCSVHeader)
+	}
+	return &csvFile{f: f}, nil
+}
+
+func (c *csvFile) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.f.Write(b)
+}
+
+// passStats accumulates the statistics rows for one function.
+type passStats struct {
+	buf bytes.Buffer
+	w   *csv.Writer
+}
+
+// add records the state of f after pass, which ran for d and
+// allocated allocs objects totaling nbytes bytes.
+func (s *passStats) add(f *Func, pass string, d time.Duration, allocs, nbytes uint64) {
+	if s.w == nil {
+		s.w = csv.NewWriter(&s.buf)
+	}
+	nv := 0
+	for _, b := range f.Blocks {
+		nv += len(b.Values)
+	}
+	s.w.Write([]string{
+		base.Ctxt.Pkgpath,
+		f.Name,
+		pass,
+		strconv.Itoa(nv),
+		strconv.Itoa(len(f.Blocks)),
+		strconv.FormatUint(allocs, 10),
+		strconv.FormatUint(nbytes, 10),
+		strconv.FormatInt(d.Nanoseconds(), 10),
+	})
+}
+
+// flush writes the accumulated rows to statsCSV.
+func (s *passStats) flush() {
+	if s.w == nil {
+		return
+	}
+	s.w.Flush()
+	statsCSV.Write(s.buf.Bytes())
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// The -d=ssastress mode compiles each function twice, with different
+// seeds. Each compile randomizes decisions that must not affect the
+// result: the order of the values in each block and, until layout,
+// the order of the blocks in f.Blocks. The SSA is checked after every
+// pass, and the digests of the two compiles are compared afterwards.
+
+// stressDigestPass is the pass before which the stress digest is
+// taken. Whether a store needs a typedmemmove call depends on how
+// earlier rules combined stores, so the digest precedes that pass.
+const stressDigestPass = "writebarrier"
+
+// stressRand returns the random source for compiling f, or nil if
+// neither -d=ssastress nor -d=ssa/check is enabled.
+func stressRand(f *Func) *rand.Rand {
+	if !checkEnabled && f.StressSeed == 0 {
+		return nil
+	}
+	seed := int64(crc32.ChecksumIEEE(([]byte)(f.Name))) ^ int64(checkRandSeed) ^ f.StressSeed
+	return rand.New(rand.NewSource(seed))
+}
+
+// shuffleValues randomizes the order of the values in each block.
+// Passes must not depend on the value order before scheduling.
+// See issue 18169.
+func shuffleValues(f *Func, rnd *rand.Rand) {
+	for _, b := range f.Blocks {
+		for i := 0; i < len(b.Values)-1; i++ {
+			j := i + rnd.Intn(len(b.Values)-i)
+			b.Values[i], b.Values[j] = b.Values[j], b.Values[i]
+		}
+	}
+}
+
+// shuffleBlocks randomizes the order of the blocks in f.Blocks,
+// keeping the entry block first. Passes must not depend on the
+// block order before layout.
+func shuffleBlocks(f *Func, rnd *rand.Rand) {
+	if len(f.Blocks) == 0 || f.Blocks[0] != f.Entry {
+		return
+	}
+	for i := 1; i < len(f.Blocks)-1; i++ {
+		j := i + rnd.Intn(len(f.Blocks)-i)
+		f.Blocks[i], f.Blocks[j] = f.Blocks[j], f.Blocks[i]
+	}
+	f.invalidateCFG()
+}
+
+// stressDigest returns a summary of f that must not depend on the
+// seed: the sorted list of the calls f makes on its non-panicking
+// paths and of the ways it returns. Finer summaries are not stable,
+// since rewrite rules such as store combining, and the bounds checks
+// prove removes, legitimately depend on the value order.
+func stressDigest(f *Func) string {
+	var calls []string
+	for _, b := range f.Blocks {
+		if b.Kind == BlockExit {
+			continue
+		}
+		for _, v := range b.Values {
+			if v.Op.IsCall() {
+				calls = append(calls, fmt.Sprintf("%s%s", v.Op, v.auxString()))
+			}
+		}
+		if b.Kind == BlockRet || b.Kind == BlockRetJmp {
+			calls = append(calls, b.Kind.String())
+		}
+	}
+	sort.Strings(calls)
+	return strings.Join(calls, "\n")
+}
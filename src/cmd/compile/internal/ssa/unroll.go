@@ -0,0 +1,347 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"cmd/compile/internal/base"
+	"math"
+)
+
+// maxUnrollValues is the maximum number of values that
+// fully unrolling a single loop may create.
+const maxUnrollValues = 128
+
+// unroll fully unrolls innermost loops with a small constant trip count.
+//
+// A loop is unrolled if it has the form
+//
+//	header:
+//	  i = Phi(min, next)
+//	  if i < max (or i <= max) goto body else goto exit
+//	body:
+//	  ...
+//	  next = i + step
+//	  goto header
+//
+// where min, max, and step are constants, step is positive, and the
+// number of iterations is at most base.Debug.Unroll (set by -d=unroll).
+// The body may be made of several blocks, and may leave the loop early.
+//
+// Each iteration gets its own copy of the loop blocks, in which the
+// header's phis are replaced by the values from the previous copy. The
+// header copies branch straight to the body, and the original header,
+// entered from the last copy, branches straight to the exit. Later
+// passes fold the now-constant induction variable into the body, which
+// removes bounds checks and other tests that depend on it.
+func unroll(f *Func) {
+	if base.Debug.Unroll <= 0 {
+		return
+	}
+	for unrollOne(f) {
+		f.invalidateCFG()
+	}
+}
+
+// unrollOne unrolls one loop of f, and reports whether it did.
+func unrollOne(f *Func) bool {
+	ln := f.loopnest()
+	if ln.hasIrreducible {
+		return false
+	}
+	for _, l := range ln.loops {
+		if !l.isInner {
+			continue
+		}
+		h := l.header
+		if h.Kind != BlockIf || len(h.Preds) != 2 {
+			continue
+		}
+		inLoop := func(b *Block) bool {
+			return ln.b2l[b.ID] == l
+		}
+		if !inLoop(h.Succs[0].b) || inLoop(h.Succs[1].b) {
+			continue
+		}
+		// pre and latch are the indexes of the header's
+		// predecessors from outside and inside the loop.
+		pre, latch := 0, 1
+		if inLoop(h.Preds[0].b) {
+			pre, latch = 1, 0
+		}
+		if !inLoop(h.Preds[latch].b) || inLoop(h.Preds[pre].b) {
+			continue
+		}
+		trip := tripCount(h, latch)
+		if trip <= 0 || trip > int64(base.Debug.Unroll) {
+			continue
+		}
+
+		// Collect the loop blocks, header first, and the panic
+		// blocks reached only from the loop, which are copied too.
+		blocks := []*Block{h}
+		copied := map[*Block]bool{h: true}
+		for _, b := range f.Blocks {
+			if b != h && inLoop(b) {
+				blocks = append(blocks, b)
+				copied[b] = true
+			}
+		}
+		for _, b := range f.Blocks {
+			if b.Kind != BlockExit || inLoop(b) || len(b.Preds) == 0 {
+				continue
+			}
+			all := true
+			for _, e := range b.Preds {
+				all = all && inLoop(e.b)
+			}
+			if all {
+				blocks = append(blocks, b)
+				copied[b] = true
+			}
+		}
+		size := 0
+		for _, b := range blocks {
+			size += len(b.Values)
+		}
+		if int64(size)*trip > maxUnrollValues || !unrollable(f, copied, h) {
+			continue
+		}
+
+		if f.pass.debug > 0 {
+			f.Warnl(h.Pos, "unrolled loop (%d iterations)", trip)
+		}
+		unrollLoop(f, blocks, pre, latch, trip)
+		return true
+	}
+	return false
+}
+
+// unrollable reports whether the blocks in copied, with header h, can
+// be copied without breaking the uses of their values elsewhere.
+//
+// Blocks outside the loop that are reached from the copies get a new
+// predecessor for each copy. Such blocks may use values from the loop
+// only as phi arguments for edges from the loop. Other blocks may use
+// values from the header only, since the header still dominates them.
+func unrollable(f *Func, copied map[*Block]bool, h *Block) bool {
+	exits := false // whether blocks other than h exit the loop
+	for b := range copied {
+		if b == h {
+			continue
+		}
+		for _, e := range b.Succs {
+			if !copied[e.b] {
+				exits = true
+			}
+		}
+	}
+	for _, b := range f.Blocks {
+		if copied[b] {
+			continue
+		}
+		for _, v := range b.Values {
+			for i, a := range v.Args {
+				if !copied[a.Block] {
+					continue
+				}
+				if v.Op == OpPhi && copied[b.Preds[i].b] {
+					continue
+				}
+				if exits || a.Block != h {
+					return false
+				}
+			}
+		}
+		for _, c := range b.ControlValues() {
+			if copied[c.Block] && (exits || c.Block != h) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// tripCount returns the number of times the loop with header h
+// runs, or -1 if it is not a known constant. latch is the index of
+// h's predecessor from inside the loop.
+func tripCount(h *Block, latch int) int64 {
+	c := h.Controls[0]
+	if c.Op != OpLess64 && c.Op != OpLeq64 {
+		return -1
+	}
+	ind, max := c.Args[0], c.Args[1]
+	if ind.Op != OpPhi || ind.Block != h || max.Op != OpConst64 {
+		return -1
+	}
+	min, next := ind.Args[1-latch], ind.Args[latch]
+	if min.Op != OpConst64 || next.Op != OpAdd64 {
+		return -1
+	}
+	var inc *Value
+	switch ind {
+	case next.Args[0]:
+		inc = next.Args[1]
+	case next.Args[1]:
+		inc = next.Args[0]
+	default:
+		return -1
+	}
+	if inc.Op != OpConst64 || inc.AuxInt <= 0 {
+		return -1
+	}
+	lo, hi, step := min.AuxInt, max.AuxInt, inc.AuxInt
+	if c.Op == OpLeq64 {
+		if hi == math.MaxInt64 {
+			return -1
+		}
+		hi++
+	}
+	if hi <= lo {
+		return 0
+	}
+	if hi-lo < 0 || hi > math.MaxInt64-step {
+		// Overflow.
+		return -1
+	}
+	return (hi - lo + step - 1) / step
+}
+
+// unrollLoop replaces the loop made of blocks, whose header is
+// blocks[0], by trip copies of its blocks. blocks may also include
+// panic blocks outside the loop, which are copied along with it.
+func unrollLoop(f *Func, blocks []*Block, pre, latch int, trip int64) {
+	h := blocks[0]
+	n := f.NumValues()
+
+	// clone maps loop blocks to their copy in the current iteration.
+	clone := make(map[*Block]*Block, len(blocks))
+	// val maps loop values to their copy in the current iteration,
+	// and the header's phis to their value in the current iteration.
+	val := make([]*Value, n)
+	get := func(v *Value) *Value {
+		if int(v.ID) < n && val[v.ID] != nil {
+			return val[v.ID]
+		}
+		return v
+	}
+	for _, v := range h.Values {
+		if v.Op == OpPhi {
+			val[v.ID] = v.Args[pre]
+		}
+	}
+
+	// from is the edge into the header of the current copy.
+	from := h.Preds[pre]
+	for k := int64(0); k < trip; k++ {
+		// Copy the blocks and values.
+		for _, b := range blocks {
+			c := f.NewBlock(b.Kind)
+			c.Pos = b.Pos
+			c.Likely = b.Likely
+			if b == h {
+				c.Kind = BlockPlain
+				c.Likely = BranchUnknown
+			}
+			c.AuxInt = b.AuxInt
+			c.Aux = b.Aux
+			clone[b] = c
+			for _, v := range b.Values {
+				if b == h && v.Op == OpPhi {
+					continue
+				}
+				w := c.NewValue0(v.Pos, v.Op, v.Type)
+				w.AuxInt = v.AuxInt
+				w.Aux = v.Aux
+				val[v.ID] = w
+			}
+		}
+		for _, b := range blocks {
+			for _, v := range b.Values {
+				if b == h && v.Op == OpPhi {
+					continue
+				}
+				w := val[v.ID]
+				for _, a := range v.Args {
+					w.AddArg(get(a))
+				}
+			}
+			if b == h {
+				continue
+			}
+			c := clone[b]
+			for _, v := range b.ControlValues() {
+				c.AddControl(get(v))
+			}
+		}
+
+		// Copy the edges.
+		for _, b := range blocks {
+			c := clone[b]
+			c.Preds = make([]Edge, len(b.Preds))
+		}
+		hc := clone[h]
+		hc.Preds = hc.Preds[:1]
+		hc.Preds[0] = from
+		from.b.Succs[from.i] = Edge{hc, 0}
+		for _, b := range blocks {
+			c := clone[b]
+			succs := b.Succs
+			if b == h {
+				succs = succs[:1]
+			}
+			c.Succs = make([]Edge, len(succs))
+			for i, e := range succs {
+				t := e.b
+				switch {
+				case t == h:
+					// The back edge leads to the next copy.
+					from = Edge{c, i}
+				case clone[t] != nil:
+					c.Succs[i] = Edge{clone[t], e.i}
+					clone[t].Preds[e.i] = Edge{c, i}
+				default:
+					// A loop exit.
+					c.Succs[i] = Edge{t, len(t.Preds)}
+					t.Preds = append(t.Preds, Edge{c, i})
+					for _, v := range t.Values {
+						if v.Op == OpPhi {
+							v.AddArg(get(v.Args[e.i]))
+						}
+					}
+				}
+			}
+		}
+
+		// The header's phis take the values from the back edge.
+		var next []*Value
+		for _, v := range h.Values {
+			if v.Op == OpPhi {
+				next = append(next, get(v.Args[latch]))
+			}
+		}
+		i := 0
+		for _, v := range h.Values {
+			if v.Op == OpPhi {
+				val[v.ID] = next[i]
+				i++
+			}
+		}
+	}
+
+	// The last copy leads to the original header, which now always
+	// exits the loop. The rest of the original loop is unreachable.
+	h.Preds[pre] = from
+	from.b.Succs[from.i] = Edge{h, pre}
+	for _, v := range h.Values {
+		if v.Op == OpPhi {
+			v.SetArg(pre, val[v.ID])
+		}
+	}
+	h.removeEdge(0)
+	h.Kind = BlockPlain
+	h.ResetControls()
+	h.Likely = BranchUnknown
+	deadcode(f)
+}
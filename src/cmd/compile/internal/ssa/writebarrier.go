@@ -5,6 +5,8 @@
 package ssa
 
 import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/logopt"
 	"cmd/compile/internal/reflectdata"
 	"cmd/compile/internal/types"
 	"cmd/internal/obj"
@@ -24,25 +26,38 @@ type ZeroRegion struct {
 	mask uint64
 }
 
+// A WBStore describes a store that received a write barrier.
+type WBStore struct {
+	Pos    src.XPos
+	Op     Op          // OpStore, OpMove or OpZero
+	Type   *types.Type // type of the value stored
+	Reason string      // why the write barrier could not be elided
+}
+
 // needwb reports whether we need write barrier for store op v.
 // v must be Store/Move/Zero.
 // zeroes provides known zero information (keyed by ID of memory-type values).
-func needwb(v *Value, zeroes map[ID]ZeroRegion) bool {
+// For a store of pointers that is not obviously to the stack, why says
+// why no write barrier is needed, or why one could not be elided.
+func needwb(v *Value, zeroes map[ID]ZeroRegion) (need bool, why string) {
 	t, ok := v.Aux.(*types.Type)
 	if !ok {
 		v.Fatalf("store aux is not a type: %s", v.LongString())
 	}
 	if !t.HasPointers() {
-		return false
+		return false, ""
 	}
 	if IsStackAddr(v.Args[0]) {
-		return false // write on stack doesn't need write barrier
+		return false, "" // write on stack doesn't need write barrier
+	}
+	if pointsToStack(v.Args[0], nil) {
+		return false, "stack destination"
 	}
 	if v.Op == OpMove && IsReadOnlyGlobalAddr(v.Args[1]) && IsNewObject(v.Args[0], v.MemoryArg()) {
 		// Copying data from readonly memory into a fresh object doesn't need a write barrier.
-		return false
+		return false, "read-only data copied into new object"
 	}
-	if v.Op == OpStore && IsGlobalAddr(v.Args[1]) {
+	if v.Op == OpStore && isNonHeapPtr(v.Args[1], nil) {
 		// Storing pointers to non-heap locations into zeroed memory doesn't need a write barrier.
 		ptr := v.Args[0]
 		var off int64
@@ -57,21 +72,27 @@ func needwb(v *Value, zeroes map[ID]ZeroRegion) bool {
 		}
 		if off < 0 || off+size > 64*ptrSize {
 			// write goes off end of tracked offsets
-			return true
+			return true, "destination beyond tracked zeroed memory"
 		}
 		z := zeroes[v.MemoryArg().ID]
 		if ptr != z.base {
-			return true
+			return true, "destination not known to be zeroed"
 		}
 		for i := off; i < off+size; i += ptrSize {
 			if z.mask>>uint(i/ptrSize)&1 == 0 {
-				return true // not known to be zero
+				return true, "destination not known to be zeroed"
 			}
 		}
 		// All written locations are known to be zero - write barrier not needed.
-		return false
+		return false, "non-heap pointer stored into zeroed memory"
 	}
-	return true
+	switch {
+	case v.Op == OpStore:
+		return true, "stored pointer may point to the heap"
+	case v.Op == OpMove && IsReadOnlyGlobalAddr(v.Args[1]):
+		return true, "read-only data copied into memory that is not a new object"
+	}
+	return true, "destination may be in the heap"
 }
 
 // writebarrier pass inserts write barriers for store ops (Store, Move, Zero)
@@ -98,6 +119,14 @@ func writebarrier(f *Func) {
 	var storeNumber []int32
 
 	zeroes := f.computeZeroMap()
+	var nWB, nElided int // for -d=wbreport
+	if base.Debug.WBReport != 0 {
+		defer func() {
+			if nWB+nElided > 0 {
+				f.Warnl(f.Entry.Pos, "%s: %d pointer stores, %d write barriers, %d elided", f.Name, nWB+nElided, nWB, nElided)
+			}
+		}()
+	}
 	for _, b := range f.Blocks { // range loop is safe since the blocks we added contain no stores to expand
 		// first, identify all the stores that need to insert a write barrier.
 		// mark them with WB ops temporarily. record presence of WB ops.
@@ -105,7 +134,21 @@ func writebarrier(f *Func) {
 		for _, v := range b.Values {
 			switch v.Op {
 			case OpStore, OpMove, OpZero:
-				if needwb(v, zeroes) {
+				need, why := needwb(v, zeroes)
+				if !need && why != "" {
+					nElided++
+					if base.Debug.WBReport != 0 {
+						f.Warnl(v.Pos, "write barrier elided: %s", why)
+					}
+					if logopt.Enabled() {
+						logopt.LogOpt(v.Pos, "writeBarrierElided", "writebarrier", f.Name, why)
+					}
+				}
+				if need {
+					nWB++
+					if base.Debug.WB == 2 {
+						f.WBStores = append(f.WBStores, WBStore{v.Pos, v.Op, v.Aux.(*types.Type), why})
+					}
 					switch v.Op {
 					case OpStore:
 						v.Op = OpStoreWB
@@ -534,6 +577,80 @@ func IsStackAddr(v *Value) bool {
 	return false
 }
 
+// pointsToStack reports whether v is known to point into the stack.
+// Unlike IsStackAddr, it also looks through phis, conditional selects,
+// and slices, which is where pointers to variables that escape
+// analysis has kept on the stack often end up. visited holds the phis
+// being looked through, which are assumed to point into the stack; a
+// phi that depends on itself does so only through its other arguments.
+func pointsToStack(v *Value, visited map[*Value]bool) bool {
+	for {
+		switch v.Op {
+		case OpOffPtr, OpAddPtr, OpPtrIndex, OpCopy:
+			v = v.Args[0]
+			continue
+		case OpSlicePtr:
+			if v.Args[0].Op == OpSliceMake {
+				v = v.Args[0].Args[0]
+				continue
+			}
+		}
+		break
+	}
+	if v.Op != OpPhi && v.Op != OpCondSelect {
+		return IsStackAddr(v)
+	}
+	if visited[v] {
+		return true
+	}
+	if visited == nil {
+		visited = map[*Value]bool{}
+	}
+	visited[v] = true
+	for _, a := range selectedPtrs(v) {
+		if !pointsToStack(a, visited) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNonHeapPtr reports whether v is known to be nil or the address of
+// a global or stack location, looking through phis like pointsToStack.
+func isNonHeapPtr(v *Value, visited map[*Value]bool) bool {
+	for v.Op == OpOffPtr || v.Op == OpCopy {
+		v = v.Args[0]
+	}
+	if IsGlobalAddr(v) || IsStackAddr(v) {
+		return true
+	}
+	if v.Op != OpPhi && v.Op != OpCondSelect {
+		return false
+	}
+	if visited[v] {
+		return true
+	}
+	if visited == nil {
+		visited = map[*Value]bool{}
+	}
+	visited[v] = true
+	for _, a := range selectedPtrs(v) {
+		if !isNonHeapPtr(a, visited) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectedPtrs returns the pointers that the Phi or CondSelect v
+// chooses from.
+func selectedPtrs(v *Value) []*Value {
+	if v.Op == OpCondSelect {
+		return v.Args[:2]
+	}
+	return v.Args
+}
+
 // IsGlobalAddr reports whether v is known to be an address of a global (or nil).
 func IsGlobalAddr(v *Value) bool {
 	if v.Op == OpAddr && v.Args[0].Op == OpSB {
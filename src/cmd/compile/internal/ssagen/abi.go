@@ -148,6 +148,11 @@ func InitLSym(f *ir.Func, hasBody bool) {
 	if hasBody {
 		setupTextLSym(f, 0)
 	}
+	if f.OClosure == nil && !ir.IsBlank(f.Nname) {
+		if sym := ir.ABIBridgeSym(f.Nname); sym != nil {
+			makeABIBridge(f, sym)
+		}
+	}
 }
 
 // selectLSym sets up the LSym for a given function, and
@@ -200,6 +205,13 @@ func selectLSym(f *ir.Func, hasBody bool) {
 
 		if needABIWrapper {
 			if !useABIWrapGen(f) {
+				if wrapperABI == obj.ABIInternal {
+					// Without a wrapper, every call reaches the
+					// assembly directly, so callers, including
+					// those in other packages, must pass the
+					// arguments on the stack.
+					f.Pragma &^= ir.RegisterParams | ir.NoRegisterParams
+				}
 				// Fallback: use alias instead. FIXME.
 
 				// These LSyms have the same name as the
@@ -255,6 +267,11 @@ func makeABIWrapper(f *ir.Func, wrapperABI obj.ABI) {
 	fn := typecheck.DeclFunc(f.Nname.Sym(), tfn)
 	fn.SetDupok(true)
 	fn.SetWrapper(true) // ignore frame for panic+recover matching
+	if wrapperABI == obj.ABIInternal {
+		// Go callers call the wrapper with the calling convention
+		// chosen for f's Go declaration.
+		fn.Pragma |= f.Pragma & (ir.RegisterParams | ir.NoRegisterParams)
+	}
 
 	// Select LSYM now.
 	asym := base.Ctxt.LookupABI(f.LSym.Name, wrapperABI)
@@ -347,6 +364,53 @@ func makeABIWrapper(f *ir.Func, wrapperABI obj.ABI) {
 	ir.CurFunc = savedcurfn
 }
 
+// makeABIBridge creates the function sym that function values of f
+// call instead of f, because f's //go:registerparams or
+// //go:noregisterparams directive gives f a calling convention that
+// differs from the one used for function values. The bridge uses the
+// default calling convention and calls f directly.
+func makeABIBridge(f *ir.Func, sym *types.Sym) {
+	savepos := base.Pos
+	savedclcontext := typecheck.DeclContext
+	savedcurfn := ir.CurFunc
+
+	base.Pos = base.AutogeneratedPos
+	typecheck.DeclContext = ir.PEXTERN
+
+	ft := f.Nname.Type()
+	tfn := ir.NewFuncType(base.Pos,
+		nil,
+		typecheck.NewFuncParams(ft.Params(), true),
+		typecheck.NewFuncParams(ft.Results(), false))
+
+	fn := typecheck.DeclFunc(sym, tfn)
+	fn.SetWrapper(true) // ignore frame for panic+recover matching and tracebacks
+
+	call := ir.NewCallExpr(base.Pos, ir.OCALL, f.Nname, nil)
+	call.Args = ir.ParamNames(tfn.Type())
+	call.IsDDD = tfn.Type().IsVariadic()
+	var tail ir.Node = call
+	if tfn.Type().NumResults() > 0 {
+		n := ir.NewReturnStmt(base.Pos, nil)
+		n.Results = []ir.Node{call}
+		tail = n
+	}
+	fn.Body.Append(tail)
+
+	typecheck.FinishFuncBody()
+	typecheck.Func(fn)
+	ir.CurFunc = fn
+	typecheck.Stmts(fn.Body)
+
+	escape.Batch([]*ir.Func{fn}, false)
+
+	typecheck.Target.Decls = append(typecheck.Target.Decls, fn)
+
+	base.Pos = savepos
+	typecheck.DeclContext = savedclcontext
+	ir.CurFunc = savedcurfn
+}
+
 // setupTextLsym initializes the LSym for a with-body text symbol.
 func setupTextLSym(f *ir.Func, flag int) {
 	if f.Dupok() {
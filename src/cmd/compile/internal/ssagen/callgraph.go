@@ -0,0 +1,69 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssagen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/objw"
+	"cmd/compile/internal/ssa"
+	"cmd/internal/obj"
+)
+
+var (
+	callGraphMu sync.Mutex // protects callGraph
+	callGraph   = make(map[*obj.LSym]map[*obj.LSym]int64)
+)
+
+// recordCalls records the static calls made by f, compiled from the
+// function whose symbol is fn, for WriteCallGraph.
+func recordCalls(fn *obj.LSym, f *ssa.Func) {
+	weights := f.CallWeights()
+	if len(weights) == 0 {
+		return
+	}
+	callGraphMu.Lock()
+	callGraph[fn] = weights
+	callGraphMu.Unlock()
+}
+
+// WriteCallGraph records the static calls made by each function
+// compiled in this package, so that the linker can lay out functions
+// next to the functions they call most often (see cmd/link's
+// -callgraphorder flag).
+//
+// The calls made by function f are written as the value of the local
+// symbol go.callgraph.f, which holds one line per callee of the form
+// "weight name", as computed by ssa.Func.CallWeights. Symbol names
+// in this package keep their `"".` prefix, which the linker expands.
+func WriteCallGraph() {
+	callers := make([]*obj.LSym, 0, len(callGraph))
+	for fn := range callGraph {
+		callers = append(callers, fn)
+	}
+	sort.Slice(callers, func(i, j int) bool { return callers[i].Name < callers[j].Name })
+
+	for _, fn := range callers {
+		weights := callGraph[fn]
+		callees := make([]*obj.LSym, 0, len(weights))
+		for callee := range weights {
+			callees = append(callees, callee)
+		}
+		sort.Slice(callees, func(i, j int) bool { return callees[i].Name < callees[j].Name })
+
+		var b strings.Builder
+		for _, callee := range callees {
+			fmt.Fprintf(&b, "%d %s\n", weights[callee], callee.Name)
+		}
+		data := b.String()
+		lsym := base.Ctxt.Lookup("go.callgraph." + fn.Name)
+		lsym.WriteString(base.Ctxt, 0, len(data), data)
+		objw.Global(lsym, int32(len(data)), obj.RODATA|obj.LOCAL)
+	}
+}
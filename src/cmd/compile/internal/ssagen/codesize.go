@@ -0,0 +1,139 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"cmd/compile/internal/base"
+	"cmd/internal/obj"
+)
+
+// codeSize is the machine code a source function contributes to the
+// package, for -d=codesize.
+type codeSize struct {
+	own     int64 // bytes of its own body
+	inlined int64 // bytes of its copies inlined into other functions
+	copies  int   // number of times it was inlined
+}
+
+var (
+	codeSizesMu sync.Mutex // protects codeSizes
+	codeSizes   map[*obj.LSym]*codeSize
+)
+
+// recordCodeSize attributes each instruction of the assembled
+// function fnsym to the source function it came from: the innermost
+// function inlined at its position, or fnsym itself.
+func recordCodeSize(fnsym *obj.LSym) {
+	if base.Ctxt.Errors > 0 {
+		return
+	}
+	type contrib struct {
+		size   int64
+		copies map[int]bool // inlining indexes of its copies
+	}
+	contribs := make(map[*obj.LSym]*contrib)
+	for p := fnsym.Func().Text; p != nil; p = p.Link {
+		end := fnsym.Size
+		if p.Link != nil {
+			end = p.Link.Pc
+		}
+		size := end - p.Pc
+		if size <= 0 {
+			continue
+		}
+		fn, ix := fnsym, -1
+		if b := base.Ctxt.PosTable.Pos(p.Pos).Base(); b != nil && b.InliningIndex() >= 0 {
+			ix = b.InliningIndex()
+			fn = base.Ctxt.InlTree.InlinedFunction(ix)
+		}
+		c := contribs[fn]
+		if c == nil {
+			c = &contrib{copies: make(map[int]bool)}
+			contribs[fn] = c
+		}
+		c.size += size
+		if ix >= 0 {
+			c.copies[ix] = true
+		}
+	}
+
+	codeSizesMu.Lock()
+	defer codeSizesMu.Unlock()
+	if codeSizes == nil {
+		codeSizes = make(map[*obj.LSym]*codeSize)
+	}
+	for fn, c := range contribs {
+		cs := codeSizes[fn]
+		if cs == nil {
+			cs = new(codeSize)
+			codeSizes[fn] = cs
+		}
+		if fn == fnsym {
+			cs.own += c.size
+		} else {
+			cs.inlined += c.size
+			cs.copies += len(c.copies)
+		}
+	}
+}
+
+// ReportCodeSizes prints, for -d=codesize, the machine code each
+// source function contributes to the package after inlining, the
+// largest first. Code inlined through several levels counts toward
+// the innermost function only, so the sizes add up to the size of
+// the package's code. With -d=codesize=2, it prints one JSON object
+// per function to standard output.
+func ReportCodeSizes() {
+	if base.Debug.CodeSize == 0 {
+		return
+	}
+	type entry struct {
+		name string
+		*codeSize
+	}
+	var entries []entry
+	for fn, cs := range codeSizes {
+		name := fn.Name
+		if strings.HasPrefix(name, `"".`) {
+			name = base.Ctxt.Pkgpath + name[len(`""`):]
+		}
+		entries = append(entries, entry{name, cs})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		ti, tj := entries[i].own+entries[i].inlined, entries[j].own+entries[j].inlined
+		if ti != tj {
+			return ti > tj
+		}
+		return entries[i].name < entries[j].name
+	})
+	for _, e := range entries {
+		if base.Debug.CodeSize < 2 {
+			plural := "ies"
+			if e.copies == 1 {
+				plural = "y"
+			}
+			fmt.Printf("%s: %d bytes (%d own, %d inlined in %d cop%s)\n", e.name, e.own+e.inlined, e.own, e.inlined, e.copies, plural)
+			continue
+		}
+		b, err := json.Marshal(struct {
+			Func    string `json:"func"`
+			Total   int64  `json:"total"`
+			Own     int64  `json:"own"`
+			Inlined int64  `json:"inlined"`
+			Copies  int    `json:"copies"`
+		}{e.name, e.own + e.inlined, e.own, e.inlined, e.copies})
+		if err != nil {
+			base.Fatalf("%v", err)
+		}
+		fmt.Printf("%s\n", b)
+	}
+	codeSizes = nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssagen
+
+import (
+	"fmt"
+	"strings"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/objw"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+	"cmd/internal/obj"
+	"cmd/internal/objabi"
+)
+
+// WriteLinknameSigs records the signature of each function that is
+// linked by name to a function possibly defined in another package,
+// so that the linker can check that both sides of the link agree.
+//
+// Such a function is either given a //go:linkname directive, or
+// declared without a body and not implemented in assembly in this
+// package, in which case another package must supply it with a
+// //go:linkname directive. The signature of function f linked to
+// symbol sym is written as the value of the local symbol
+// go.linkname.sym, which holds the package path, the type of f, and
+// the offset and size of each of f's parameters and results as laid
+// out by types.CalcSize, separated by NUL bytes.
+//
+// Functions in this package that are linked to the same symbol are
+// checked against each other here.
+//
+// WriteLinknameSigs must be called before the functions are compiled,
+// as compiling a function releases its body.
+func WriteLinknameSigs() {
+	type linked struct {
+		fn     *ir.Func
+		layout string
+	}
+	seen := make(map[string]linked)
+
+	for _, n := range typecheck.Target.Decls {
+		if n.Op() != ir.ODCLFUNC {
+			continue
+		}
+		fn := n.(*ir.Func)
+		if fn.OClosure != nil || fn.Wrapper() || ir.IsBlank(fn.Nname) {
+			continue
+		}
+		t := fn.Type()
+		if t.Recv() != nil || t.HasTParam() {
+			continue
+		}
+		sym := fn.Sym()
+		if sym.Linkname == "" && (len(fn.Body) != 0 || hasAssemblyDef(fn.Nname)) {
+			continue
+		}
+
+		target := fn.Linksym().Name
+		if prefix := objabi.PathToPrefix(base.Ctxt.Pkgpath) + "."; base.Ctxt.Pkgpath != "" && strings.HasPrefix(target, prefix) {
+			target = `"".` + target[len(prefix):]
+		}
+		layout := linknameLayout(fn)
+		if prev, ok := seen[target]; ok {
+			if prev.layout != layout {
+				base.ErrorfAt(fn.Pos(), "%v has signature %v, which does not match %v at %v; both are linked to %s",
+					fn.Nname, t, prev.fn.Type(), base.FmtPos(prev.fn.Pos()), target)
+			}
+			continue
+		}
+		seen[target] = linked{fn, layout}
+
+		pkg := base.Ctxt.Pkgpath
+		if pkg == "" {
+			pkg = types.LocalPkg.Name
+		}
+		data := pkg + "\x00" + t.String() + "\x00" + layout
+		lsym := base.Ctxt.Lookup("go.linkname." + target)
+		lsym.WriteString(base.Ctxt, 0, len(data), data)
+		objw.Global(lsym, int32(len(data)), obj.RODATA|obj.LOCAL)
+	}
+}
+
+// hasAssemblyDef reports whether the function named n is implemented
+// in assembly in this package.
+func hasAssemblyDef(n *ir.Name) bool {
+	abi, ok := symabiDefs[n.Linksym().Name]
+	return ok && abi == obj.ABI0
+}
+
+// linknameLayout describes the memory layout of the arguments of fn,
+// in the form "(off+size,...)(off+size,...)width", listing the
+// parameters and then the results. The width is the size of fn's
+// argument area under the calling convention fn uses, which is also
+// what the linker sees as the argument size of fn.
+func linknameLayout(fn *ir.Func) string {
+	t := fn.Type()
+	types.CalcSize(t)
+	var b strings.Builder
+	for _, fields := range []*types.Type{t.Params(), t.Results()} {
+		b.WriteByte('(')
+		for i, f := range fields.FieldSlice() {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(&b, "%d+%d", f.Offset, f.Type.Width)
+		}
+		b.WriteByte(')')
+	}
+	width := AbiForFunc(fn).ABIAnalyze(t, false).ArgWidth()
+	fmt.Fprintf(&b, "%d", types.Rnd(width, int64(types.RegSize)))
+	return b.String()
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssagen
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+	"cmd/internal/obj"
+	"cmd/internal/objabi"
+	"cmd/internal/sys"
+)
+
+// multiversionFeatures are the runtime variables reporting the CPU
+// features that the variant of a //go:multiversion function assumes.
+// They are the features that the amd64 intrinsics otherwise check for
+// before using the instructions that need them.
+var multiversionFeatures = []string{"x86HasPOPCNT", "x86HasSSE41", "x86HasFMA"}
+
+// InitMultiversion prepares fn, if it is marked //go:multiversion, to
+// be compiled a second time as a variant for CPUs that have all of
+// multiversionFeatures. It sets up the variant's LSym, and adds to the
+// start of fn's body
+//
+//	if x86HasPOPCNT && x86HasSSE41 && x86HasFMA {
+//		return fn.multiversion(params...)
+//	}
+//
+// which Compile leaves out of the variant.
+//
+// InitMultiversion must be called after fn's LSym is set up and
+// before fn is walked, with ir.CurFunc set to fn.
+func InitMultiversion(fn *ir.Func) {
+	if fn.Pragma&ir.Multiversion == 0 || base.Ctxt.Arch.Family != sys.AMD64 || len(fn.Body) == 0 {
+		return
+	}
+	if fn.LSym.ABI() != obj.ABIInternal || objabi.GOAMD64 >= 3 {
+		// With GOAMD64=v3 or later, fn already assumes
+		// multiversionFeatures.
+		return
+	}
+	if ir.Any(fn, func(n ir.Node) bool { return n.Op() == ir.ORECOVER }) {
+		// The variant's caller would not be the deferred function,
+		// so recover would return nil there.
+		base.ErrorfAt(fn.Pos(), "//go:multiversion function %v cannot call recover", fn.Sym())
+		return
+	}
+
+	pos := fn.Pos()
+	sym := fn.Sym().Pkg.Lookup(fn.Sym().Name + ".multiversion")
+	sym.SetFunc(true)
+	fn.Variant = ir.NewNameAt(pos, sym)
+	fn.Variant.Class = ir.PFUNC
+	fn.Variant.SetType(fn.Type())
+
+	// The variant gets the same symbol attributes as fn.
+	lsym := fn.LSym
+	fn.LSym = fn.Variant.Linksym()
+	setupTextLSym(fn, 0)
+	fn.LSym = lsym
+
+	// The variant takes the receiver, if any, as its first parameter.
+	callee := ir.NewNameAt(pos, sym)
+	callee.Class = ir.PFUNC
+	callee.SetType(fn.Type())
+	if recv := fn.Type().Recv(); recv != nil {
+		callee.SetType(typecheck.NewMethodType(fn.Type(), recv.Type))
+	}
+	callee.SetPragma(fn.Pragma)
+
+	var body []ir.Node
+	var args []ir.Node
+	for _, fs := range []*types.Type{fn.Type().Recvs(), fn.Type().Params()} {
+		for _, f := range fs.FieldSlice() {
+			if n := ir.AsNode(f.Nname); n != nil && !ir.IsBlank(n) {
+				args = append(args, n)
+				continue
+			}
+			// The variant can't use an unnamed or blank
+			// parameter either, so any value will do.
+			tmp := typecheck.Temp(f.Type)
+			body = append(body, ir.NewAssignStmt(pos, tmp, nil))
+			args = append(args, tmp)
+		}
+	}
+	call := ir.NewCallExpr(pos, ir.OCALL, callee, args)
+	call.IsDDD = fn.Type().IsVariadic()
+	if fn.Type().NumResults() > 0 {
+		body = append(body, ir.NewReturnStmt(pos, []ir.Node{call}))
+	} else {
+		body = append(body, call, ir.NewReturnStmt(pos, nil))
+	}
+
+	var cond ir.Node
+	for _, name := range multiversionFeatures {
+		x := ir.Node(typecheck.LookupRuntime(name))
+		if cond != nil {
+			x = ir.NewLogicalExpr(pos, ir.OANDAND, cond, x)
+		}
+		cond = x
+	}
+
+	fn.Dispatch = ir.NewIfStmt(pos, cond, body, nil)
+	fn.Dispatch.Likely = true
+	typecheck.Stmt(fn.Dispatch)
+	fn.Body.Prepend(fn.Dispatch)
+}
+
+// multiversionVariant returns the variant of fn, which is marked
+// //go:multiversion. The variant is a copy of fn, after walk, under
+// the variant's name and without the dispatch to the variant.
+func multiversionVariant(fn *ir.Func) *ir.Func {
+	v := *fn
+	v.Nname = ir.NewNameAt(fn.Variant.Pos(), fn.Variant.Sym())
+	v.Nname.Class = ir.PFUNC
+	v.Nname.Func = &v
+	v.Nname.SetType(fn.Type())
+	v.LSym = v.Nname.Linksym()
+	v.Body = nil
+	for _, n := range fn.Body {
+		if n != fn.Dispatch {
+			v.Body.Append(n)
+		}
+	}
+	v.Variant = nil
+	v.Dispatch = nil
+	return &v
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssagen
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/typecheck"
+)
+
+// neverReturns is the set of functions in this package that are known
+// never to return to their caller, such as helpers that format a
+// message and panic. It is computed by MarkNeverReturns before any
+// function is compiled and is read-only afterwards.
+//
+// A call to such a function ends its block, just like a panic, so
+// that the prove pass can use the guard around the call: after
+//
+//	if i >= len(b) {
+//		fail("index out of range")
+//	}
+//
+// b[i] needs no bounds check.
+var neverReturns map[*ir.Func]bool
+
+// MarkNeverReturns finds the functions in decls that never return.
+//
+// A function never returns if its body contains no return statement,
+// contains no defer statement (a deferred call to recover could make
+// it return after a panic), and ends in a statement that cannot
+// complete normally. Calls to functions already found never to return
+// are such statements, so the analysis is repeated until nothing new
+// is found.
+func MarkNeverReturns(decls []ir.Node) {
+	var cands []*ir.Func
+	for _, n := range decls {
+		if n.Op() != ir.ODCLFUNC {
+			continue
+		}
+		fn := n.(*ir.Func)
+		if len(fn.Body) == 0 || fn.Type().HasTParam() {
+			continue
+		}
+		if ir.AnyList(fn.Body, func(n ir.Node) bool {
+			switch n.Op() {
+			case ir.ORETURN, ir.OTAILCALL, ir.ODEFER:
+				return true
+			}
+			return false
+		}) {
+			continue
+		}
+		// Only functions with results have had their breaks marked.
+		typecheck.MarkBreak(fn)
+		cands = append(cands, fn)
+	}
+
+	neverReturns = make(map[*ir.Func]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range cands {
+			if !neverReturns[fn] && isTermNodesCall(fn.Body) {
+				neverReturns[fn] = true
+				changed = true
+			}
+		}
+	}
+}
+
+// callNeverReturns reports whether n is a call to a function that is
+// known never to return.
+func callNeverReturns(n *ir.CallExpr) bool {
+	if n.Op() != ir.OCALLFUNC || n.X.Op() != ir.ONAME {
+		return false
+	}
+	fn := n.X.(*ir.Name)
+	return fn.Class == ir.PFUNC && fn.Func != nil && neverReturns[fn.Func]
+}
+
+// isTermNodesCall reports whether the statement list l ends with a
+// terminating statement, as in typecheck's checks for missing returns,
+// also counting calls to functions that never return as terminating.
+func isTermNodesCall(l ir.Nodes) bool {
+	if len(l) == 0 {
+		return false
+	}
+	return isTermNodeCall(l[len(l)-1])
+}
+
+// isTermNodeCall reports whether n, the last statement in a list,
+// is a terminating statement.
+func isTermNodeCall(n ir.Node) bool {
+	switch n.Op() {
+	case ir.OBLOCK:
+		return isTermNodesCall(n.(*ir.BlockStmt).List)
+
+	case ir.OGOTO, ir.OPANIC, ir.OFALL:
+		return true
+
+	case ir.OCALLFUNC:
+		return callNeverReturns(n.(*ir.CallExpr))
+
+	case ir.OFOR, ir.OFORUNTIL:
+		n := n.(*ir.ForStmt)
+		return n.Cond == nil && !n.HasBreak
+
+	case ir.OIF:
+		n := n.(*ir.IfStmt)
+		return isTermNodesCall(n.Body) && isTermNodesCall(n.Else)
+
+	case ir.OSWITCH:
+		n := n.(*ir.SwitchStmt)
+		if n.HasBreak {
+			return false
+		}
+		def := false
+		for _, cas := range n.Cases {
+			if !isTermNodesCall(cas.Body) {
+				return false
+			}
+			if len(cas.List) == 0 { // default
+				def = true
+			}
+		}
+		return def
+
+	case ir.OSELECT:
+		n := n.(*ir.SelectStmt)
+		if n.HasBreak {
+			return false
+		}
+		for _, cas := range n.Cases {
+			if !isTermNodesCall(cas.Body) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -5,9 +5,12 @@
 package ssagen
 
 import (
+	"encoding/json"
+	"fmt"
 	"internal/race"
 	"math/rand"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -70,11 +73,35 @@ func (s byStackVar) Len() int           { return len(s) }
 func (s byStackVar) Less(i, j int) bool { return cmpstackvarlt(s[i], s[j]) }
 func (s byStackVar) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
+// moveCanaryFirst moves the stack canary ahead of the other autos in
+// the sorted dcl, so that it is allocated at the top of the frame.
+func moveCanaryFirst(dcl []*ir.Name, canary *ir.Name) {
+	first := -1
+	for i, n := range dcl {
+		if first < 0 && n.Class == ir.PAUTO {
+			first = i
+		}
+		if n == canary {
+			copy(dcl[first+1:i+1], dcl[first:i])
+			dcl[first] = canary
+			return
+		}
+	}
+}
+
 func (s *ssafn) AllocFrame(f *ssa.Func) {
 	s.stksize = 0
 	s.stkptrsize = 0
+	s.spillsize = 0
 	fn := s.curfn
 
+	// For -d=framesize, the slots that only hold values spilled by
+	// the register allocator.
+	var spills map[*ir.Name]bool
+	if base.Debug.FrameSize != 0 {
+		spills = make(map[*ir.Name]bool)
+	}
+
 	// Mark the PAUTO's unused.
 	for _, ln := range fn.Dcl {
 		if ln.Class == ir.PAUTO {
@@ -85,6 +112,9 @@ func (s *ssafn) AllocFrame(f *ssa.Func) {
 	for _, l := range f.RegAlloc {
 		if ls, ok := l.(ssa.LocalSlot); ok {
 			ls.N.SetUsed(true)
+			if spills != nil {
+				spills[ls.N] = true
+			}
 		}
 	}
 
@@ -94,12 +124,16 @@ func (s *ssafn) AllocFrame(f *ssa.Func) {
 				switch n.Class {
 				case ir.PPARAM, ir.PPARAMOUT, ir.PAUTO:
 					n.SetUsed(true)
+					delete(spills, n)
 				}
 			}
 		}
 	}
 
 	sort.Sort(byStackVar(fn.Dcl))
+	if s.canary != nil && s.canary.Used() {
+		moveCanaryFirst(fn.Dcl, s.canary)
+	}
 
 	// Reassign stack offsets of the locals that are used.
 	lastHasPtr := false
@@ -110,6 +144,15 @@ func (s *ssafn) AllocFrame(f *ssa.Func) {
 			continue
 		}
 		if !n.Used() {
+			// Remember the user variables that live only in
+			// registers, or not at all, so that the debug info
+			// can still list them.
+			s.optimizedOut = s.optimizedOut[:0]
+			for _, n := range fn.Dcl[i:] {
+				if n.Class == ir.PAUTO && n.Esc() != ir.EscHeap && !ir.IsAutoTmp(n) && !ir.IsSynthetic(n) && n.Sym().Name[0] != '&' {
+					s.optimizedOut = append(s.optimizedOut, n)
+				}
+			}
 			fn.Dcl = fn.Dcl[:i]
 			break
 		}
@@ -126,8 +169,12 @@ func (s *ssafn) AllocFrame(f *ssa.Func) {
 			// to be scanned when it shouldn't be). See issue 24993.
 			w = 1
 		}
+		start := s.stksize
 		s.stksize += w
 		s.stksize = types.Rnd(s.stksize, int64(n.Type().Align))
+		if spills[n] {
+			s.spillsize += s.stksize - start
+		}
 		if n.Type().HasPointers() {
 			s.stkptrsize = s.stksize
 			lastHasPtr = true
@@ -148,7 +195,39 @@ const maxStackSize = 1 << 30
 // and flushes that plist to machine code.
 // worker indicates which of the backend workers is doing the processing.
 func Compile(fn *ir.Func, worker int) {
-	f := buildssa(fn, worker)
+	if fn.Dispatch != nil {
+		compile(multiversionVariant(fn), worker, true)
+	}
+	compile(fn, worker, false)
+}
+
+// stressDigest compiles a copy of fn with the next -d=ssastress seed
+// and returns the digest of the result. The copy has its own list of
+// declarations, so that the temporaries created for it are dropped.
+func stressDigest(fn *ir.Func, worker int, multiversion bool) string {
+	c := *fn
+	c.Dcl = append([]*ir.Name(nil), fn.Dcl...)
+	f := buildssa(&c, worker, multiversion, int64(base.Debug.SSAStress)+1)
+	return f.StressDigest
+}
+
+// compile compiles fn. multiversion reports whether fn is the variant
+// of a //go:multiversion function, for which the CPU features the
+// variant assumes need not be checked.
+func compile(fn *ir.Func, worker int, multiversion bool) {
+	var digest string
+	if base.Debug.SSAStress != 0 {
+		digest = stressDigest(fn, worker, multiversion)
+	}
+	f := buildssa(fn, worker, multiversion, 0)
+	if base.Debug.SSAStress != 0 && f.StressDigest != digest {
+		base.FatalfAt(fn.Pos(), "-d=ssastress: %v compiles differently with seeds %d and %d:\n%s\n--- vs ---\n%s",
+			fn, base.Debug.SSAStress, base.Debug.SSAStress+1, f.StressDigest, digest)
+	}
+	if base.Debug.WB == 2 {
+		recordWriteBarriers(fn, f)
+	}
+	recordCalls(fn.LSym, f)
 	// Note: check arg size to fix issue 25507.
 	if f.Frontend().(*ssafn).stksize >= maxStackSize || f.OwnAux.ArgWidth() >= maxStackSize {
 		largeStackFramesMu.Lock()
@@ -173,7 +252,32 @@ func Compile(fn *ir.Func, worker int) {
 		return
 	}
 
+	outargs := pp.Text.To.Offset - f.Frontend().(*ssafn).stksize
 	pp.Flush() // assemble, fill in boilerplate, etc.
+	if base.Debug.CodeSize != 0 {
+		recordCodeSize(pp.Text.From.Sym)
+	}
+	if base.Debug.FrameSize != 0 {
+		e := f.Frontend().(*ssafn)
+		frameSizesMu.Lock()
+		frameSizes = append(frameSizes, frameSize{
+			name:    ir.FuncName(fn),
+			pos:     fn.Pos(),
+			frame:   int64(pp.Text.From.Sym.Func().Locals),
+			locals:  e.stksize,
+			spills:  e.spillsize,
+			outargs: outargs,
+			args:    f.OwnAux.ArgWidth(),
+		})
+		frameSizesMu.Unlock()
+	}
+	if max := int64(base.Flag.MaxStackFrame); max > 0 {
+		if frame := int64(pp.Text.From.Sym.Func().Locals); frame > max {
+			largeStackFramesMu.Lock()
+			largeStackFrames = append(largeStackFrames, largeStack{frame: frame, vars: largestLocals(fn, 5), pos: fn.Pos()})
+			largeStackFramesMu.Unlock()
+		}
+	}
 	// fieldtrack must be called after pp.Flush. See issue 20014.
 	fieldtrack(pp.Text.From.Sym, fn.FieldTrack)
 }
@@ -215,7 +319,7 @@ func fieldtrack(fnsym *obj.LSym, tracked map[*obj.LSym]struct{}) {
 	if fnsym == nil {
 		return
 	}
-	if !objabi.Experiment.FieldTrack || len(tracked) == 0 {
+	if !base.Flag.FieldTrack || len(tracked) == 0 {
 		return
 	}
 
@@ -237,6 +341,29 @@ type largeStack struct {
 	args   int64
 	callee int64
 	pos    src.XPos
+
+	// For frames larger than -maxstackframe, the size of the frame
+	// and its largest local variables.
+	frame int64
+	vars  []*ir.Name
+}
+
+// largestLocals returns up to n of the largest local variables of fn
+// that have stack slots, largest first.
+func largestLocals(fn *ir.Func, n int) []*ir.Name {
+	var vars []*ir.Name
+	for _, v := range fn.Dcl {
+		if v.Class == ir.PAUTO && v.Type().Width > 0 {
+			vars = append(vars, v)
+		}
+	}
+	sort.SliceStable(vars, func(i, j int) bool {
+		return vars[i].Type().Width > vars[j].Type().Width
+	})
+	if len(vars) > n {
+		vars = vars[:n]
+	}
+	return vars
 }
 
 var (
@@ -250,10 +377,72 @@ func CheckLargeStacks() {
 		return largeStackFrames[i].pos.Before(largeStackFrames[j].pos)
 	})
 	for _, large := range largeStackFrames {
-		if large.callee != 0 {
+		if large.frame != 0 {
+			var msg strings.Builder
+			fmt.Fprintf(&msg, "stack frame too large (%d bytes > -maxstackframe=%d)", large.frame, base.Flag.MaxStackFrame)
+			for _, v := range large.vars {
+				name := v.Sym().Name
+				if ir.IsAutoTmp(v) {
+					name = "compiler temporary"
+				}
+				fmt.Fprintf(&msg, "\n\t%v: %s %v (%d bytes)", base.FmtPos(v.Pos()), name, v.Type(), v.Type().Width)
+			}
+			base.ErrorfAt(large.pos, "%s", msg.String())
+		} else if large.callee != 0 {
 			base.ErrorfAt(large.pos, "stack frame too large (>1GB): %d MB locals + %d MB args + %d MB callee", large.locals>>20, large.args>>20, large.callee>>20)
 		} else {
 			base.ErrorfAt(large.pos, "stack frame too large (>1GB): %d MB locals + %d MB args", large.locals>>20, large.args>>20)
 		}
 	}
 }
+
+// frameSize is the stack frame layout of a function, for -d=framesize.
+type frameSize struct {
+	name    string
+	pos     src.XPos
+	frame   int64 // size of the final frame, including locals, outargs and the saved frame pointer
+	locals  int64 // size of the local variables, including spills
+	spills  int64 // size of the slots for values spilled by regalloc
+	outargs int64 // size of the arguments area for callees
+	args    int64 // size of the function's own arguments and results
+}
+
+var (
+	frameSizesMu sync.Mutex // protects frameSizes
+	frameSizes   []frameSize
+)
+
+// ReportFrameSizes prints the stack frame layout of the functions
+// compiled, for -d=framesize. With -d=framesize=2, it prints one JSON
+// object per function to standard output.
+func ReportFrameSizes() {
+	if base.Debug.FrameSize == 0 {
+		return
+	}
+	sort.Slice(frameSizes, func(i, j int) bool {
+		if frameSizes[i].pos != frameSizes[j].pos {
+			return frameSizes[i].pos.Before(frameSizes[j].pos)
+		}
+		return frameSizes[i].name < frameSizes[j].name
+	})
+	for _, fs := range frameSizes {
+		if base.Debug.FrameSize < 2 {
+			base.WarnfAt(fs.pos, "frame size of %s: %d bytes (%d locals, %d spills, %d outargs, %d args)", fs.name, fs.frame, fs.locals, fs.spills, fs.outargs, fs.args)
+			continue
+		}
+		b, err := json.Marshal(struct {
+			Pos     string `json:"pos"`
+			Func    string `json:"func"`
+			Frame   int64  `json:"frame"`
+			Locals  int64  `json:"locals"`
+			Spills  int64  `json:"spills"`
+			Outargs int64  `json:"outargs"`
+			Args    int64  `json:"args"`
+		}{base.FmtPos(fs.pos), fs.name, fs.frame, fs.locals, fs.spills, fs.outargs, fs.args})
+		if err != nil {
+			base.Fatalf("%v", err)
+		}
+		fmt.Printf("%s\n", b)
+	}
+	frameSizes = nil
+}
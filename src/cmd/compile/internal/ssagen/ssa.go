@@ -97,6 +97,8 @@ func InitConfig() {
 	ir.Syms.AssertE2I2 = typecheck.LookupRuntimeFunc("assertE2I2")
 	ir.Syms.AssertI2I = typecheck.LookupRuntimeFunc("assertI2I")
 	ir.Syms.AssertI2I2 = typecheck.LookupRuntimeFunc("assertI2I2")
+	ir.Syms.Asanread = typecheck.LookupRuntimeFunc("asanread")
+	ir.Syms.Asanwrite = typecheck.LookupRuntimeFunc("asanwrite")
 	ir.Syms.Deferproc = typecheck.LookupRuntimeFunc("deferproc")
 	ir.Syms.DeferprocStack = typecheck.LookupRuntimeFunc("deferprocStack")
 	ir.Syms.Deferreturn = typecheck.LookupRuntimeFunc("deferreturn")
@@ -120,6 +122,8 @@ func InitConfig() {
 	ir.Syms.Racereadrange = typecheck.LookupRuntimeFunc("racereadrange")
 	ir.Syms.Racewrite = typecheck.LookupRuntimeFunc("racewrite")
 	ir.Syms.Racewriterange = typecheck.LookupRuntimeFunc("racewriterange")
+	ir.Syms.StackProtectFail = typecheck.LookupRuntimeFunc("stackprotectfail")
+	ir.Syms.StackProtectGuard = typecheck.LookupRuntimeVar("stackProtectGuard")
 	ir.Syms.X86HasPOPCNT = typecheck.LookupRuntimeVar("x86HasPOPCNT")       // bool
 	ir.Syms.X86HasSSE41 = typecheck.LookupRuntimeVar("x86HasSSE41")         // bool
 	ir.Syms.X86HasFMA = typecheck.LookupRuntimeVar("x86HasFMA")             // bool
@@ -232,6 +236,9 @@ func abiForFunc(fn *ir.Func, abi0, abi1 *abi.ABIConfig) *abi.ABIConfig {
 	if fn != nil {
 		name := ir.FuncName(fn)
 		magicName := strings.HasSuffix(name, magicNameDotSuffix)
+		if fn.Pragma&ir.RegisterParams != 0 && fn.Pragma&ir.NoRegisterParams != 0 {
+			base.ErrorfAt(fn.Pos(), "function %s cannot be both //go:registerparams and //go:noregisterparams", name)
+		}
 		if fn.Pragma&ir.RegisterParams != 0 { // TODO(register args) remove after register abi is working
 			if strings.Contains(name, ".") {
 				if !magicName {
@@ -239,6 +246,11 @@ func abiForFunc(fn *ir.Func, abi0, abi1 *abi.ABIConfig) *abi.ABIConfig {
 				}
 			}
 			a = abi1
+		} else if fn.Pragma&ir.NoRegisterParams != 0 {
+			if strings.Contains(name, ".") {
+				base.ErrorfAt(fn.Pos(), "//go:noregisterparams is not supported on method %s", name)
+			}
+			a = abi0
 		} else if magicName {
 			if base.FmtPos(fn.Pos()) == "<autogenerated>:1" {
 				// no way to put a pragma here, and it will error out in the real source code if they did not do it there.
@@ -381,7 +393,9 @@ func okOffset(offset int64) int64 {
 
 // buildssa builds an SSA function for fn.
 // worker indicates which of the backend workers is doing the processing.
-func buildssa(fn *ir.Func, worker int) *ssa.Func {
+// shadowSeed, if nonzero, builds fn only to be compared with the real
+// compile under -d=ssastress, using that seed; nothing is emitted for it.
+func buildssa(fn *ir.Func, worker int, multiversion bool, shadowSeed int64) *ssa.Func {
 	name := ir.FuncName(fn)
 	printssa := false
 	if ssaDump != "" { // match either a simple name e.g. "(*Reader).Reset", or a package.name e.g. "compress/gzip.(*Reader).Reset"
@@ -404,6 +418,7 @@ func buildssa(fn *ir.Func, worker int) *ssa.Func {
 	defer s.popLine()
 
 	s.hasdefer = fn.HasDefer()
+	s.multiversion = multiversion
 	if fn.Pragma&ir.CgoUnsafeArgs != 0 {
 		s.cgoUnsafeArgs = true
 	}
@@ -423,6 +438,10 @@ func buildssa(fn *ir.Func, worker int) *ssa.Func {
 	s.f.Name = name
 	s.f.DebugTest = s.f.DebugHashMatch("GOSSAHASH")
 	s.f.PrintOrHtmlSSA = printssa
+	s.f.StressSeed = int64(base.Debug.SSAStress)
+	if shadowSeed != 0 {
+		s.f.StressSeed = shadowSeed
+	}
 	if fn.Pragma&ir.Nosplit != 0 {
 		s.f.NoSplit = true
 	}
@@ -515,6 +534,18 @@ func buildssa(fn *ir.Func, worker int) *ssa.Func {
 		// unconditional.
 		s.vars[memVar] = s.newValue1Apos(ssa.OpVarLive, types.TypeMem, deferBitsTemp, s.mem(), false)
 	}
+	if base.Flag.StackProtect && needStackProtect(fn) {
+		// Create the stack canary slot and store the guard value in it.
+		// AllocFrame places it at the top of the frame, so that an
+		// overflow of a local array clobbers it before reaching the
+		// return address. It is checked at each exit.
+		canary := typecheck.TempAt(src.NoXPos, s.curfn, types.Types[types.TUINTPTR])
+		canary.SetAddrtaken(true)
+		s.canary = canary
+		fe.canary = canary
+		s.vars[memVar] = s.newValue1A(ssa.OpVarDef, types.TypeMem, canary, s.mem())
+		s.store(types.Types[types.TUINTPTR], s.addr(canary), s.stackProtectGuard())
+	}
 
 	var params *abi.ABIParamResultInfo
 	params = s.f.ABISelf.ABIAnalyze(fn.Type(), true)
@@ -625,7 +656,7 @@ func buildssa(fn *ir.Func, worker int) *ssa.Func {
 	// Main call to ssa package to compile function
 	ssa.Compile(s.f)
 
-	if s.hasOpenDefers {
+	if s.hasOpenDefers && shadowSeed == 0 {
 		s.emitOpenDeferInfo()
 	}
 
@@ -885,6 +916,8 @@ type state struct {
 	// value representing address of where deferBits autotmp is stored
 	deferBitsAddr *ssa.Value
 	deferBitsTemp *ir.Name
+	// stack canary autotmp, for -stackprotect
+	canary *ir.Name
 
 	// line number stack. The current line number is top of stack
 	line []src.XPos
@@ -899,6 +932,7 @@ type state struct {
 	hasdefer      bool // whether the function contains a defer statement
 	softFloat     bool
 	hasOpenDefers bool // whether we are doing open-coded defers
+	multiversion  bool // whether the CPU has the features of a go:multiversion variant
 
 	// If doing open-coded defers, list of info about the defer calls in
 	// scanning order. Hence, at exit we should run these defers in reverse
@@ -1277,6 +1311,20 @@ func (s *state) instrument2(t *types.Type, addr, addr2 *ssa.Value, kind instrume
 		return
 	}
 
+	if base.Flag.Race && base.Debug.RaceFields != 0 && t.IsStruct() && t.NumComponents(types.CountBlankFields) > 1 {
+		// Instrument each field at its own offset and width, so
+		// that a race report gives the address and size of the
+		// field involved rather than those of the whole struct.
+		for _, f := range t.Fields().Slice() {
+			if f.Sym.IsBlank() {
+				continue
+			}
+			offptr := s.newValue1I(ssa.OpOffPtr, types.NewPtr(f.Type), abi.FieldOffsetOf(f), addr)
+			s.instrument2(f.Type, offptr, nil, kind)
+		}
+		return
+	}
+
 	var fn *obj.LSym
 	needWidth := false
 
@@ -1296,6 +1344,16 @@ func (s *state) instrument2(t *types.Type, addr, addr2 *ssa.Value, kind instrume
 			panic("unreachable")
 		}
 		needWidth = true
+	} else if base.Flag.ASan {
+		switch kind {
+		case instrumentRead:
+			fn = ir.Syms.Asanread
+		case instrumentWrite:
+			fn = ir.Syms.Asanwrite
+		default:
+			panic("unreachable")
+		}
+		needWidth = true
 	} else if base.Flag.Race && t.NumComponents(types.CountBlankFields) > 1 {
 		// for composite objects we have to write every address
 		// because a write might happen to any subobject.
@@ -1416,6 +1474,13 @@ func (s *state) stmt(n ir.Node) {
 				// go through SSA.
 			}
 		}
+		if s.curBlock != nil && callNeverReturns(n) {
+			// A local function that always panics or loops forever.
+			m := s.mem()
+			b := s.endBlock()
+			b.Kind = ssa.BlockExit
+			b.SetControl(m)
+		}
 	case ir.ODEFER:
 		n := n.(*ir.GoDeferStmt)
 		if base.Debug.Defer > 0 {
@@ -1646,6 +1711,8 @@ func (s *state) stmt(n ir.Node) {
 		var likely int8
 		if n.Likely {
 			likely = 1
+		} else if n.Unlikely {
+			likely = -1
 		}
 		var bThen *ssa.Block
 		if len(n.Body) != 0 {
@@ -1917,6 +1984,10 @@ func (s *state) exit() *ssa.Block {
 		}
 	}
 
+	if s.canary != nil {
+		s.checkCanary()
+	}
+
 	var b *ssa.Block
 	var m *ssa.Value
 	// Do actual return.
@@ -2355,6 +2426,13 @@ func (s *state) expr(n ir.Node) *ssa.Value {
 		n := n.(*ir.ConvExpr)
 		str := s.expr(n.X)
 		ptr := s.newValue1(ssa.OpStringPtr, s.f.Config.Types.BytePtr, str)
+		if !n.NonNil() {
+			// []byte("") is not nil, but the data pointer
+			// of an empty string may be.
+			cond := s.newValue2(ssa.OpNeqPtr, types.Types[types.TBOOL], ptr, s.constNil(ptr.Type))
+			zerobase := s.newValue1A(ssa.OpAddr, ptr.Type, ir.Syms.Zerobase, s.sb)
+			ptr = s.ternary(cond, ptr, zerobase)
+		}
 		len := s.newValue1(ssa.OpStringLen, types.Types[types.TINT], str)
 		return s.newValue3(ssa.OpSliceMake, n.Type(), ptr, len, len)
 	case ir.OCFUNC:
@@ -3881,6 +3959,15 @@ func InitTables() {
 	makeAtomicGuardedIntrinsicARM64 := func(op0, op1 ssa.Op, typ, rtyp types.Kind, emit atomicOpEmitter) intrinsicBuilder {
 
 		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			if objabi.GOARM64.LSE {
+				// The target is known to have the atomic instructions.
+				emit(s, n, args, op1, typ)
+				if rtyp == types.TNIL {
+					return nil
+				}
+				return s.variable(n, types.Types[rtyp])
+			}
+
 			// Target Atomic feature is identified by dynamic detection
 			addr := s.entryNewValue1A(ssa.OpAddr, types.Types[types.TBOOL].PtrTo(), ir.Syms.ARM64HasATOMICS, s.sb)
 			v := s.load(types.Types[types.TBOOL], addr)
@@ -3988,25 +4075,25 @@ func InitTables() {
 			s.vars[memVar] = s.newValue3(ssa.OpAtomicAnd8, types.TypeMem, args[0], args[1], s.mem())
 			return nil
 		},
-		sys.AMD64, sys.MIPS, sys.PPC64, sys.RISCV64, sys.S390X)
+		sys.AMD64, sys.MIPS, sys.MIPS64, sys.PPC64, sys.RISCV64, sys.S390X)
 	addF("runtime/internal/atomic", "And",
 		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 			s.vars[memVar] = s.newValue3(ssa.OpAtomicAnd32, types.TypeMem, args[0], args[1], s.mem())
 			return nil
 		},
-		sys.AMD64, sys.MIPS, sys.PPC64, sys.RISCV64, sys.S390X)
+		sys.AMD64, sys.MIPS, sys.MIPS64, sys.PPC64, sys.RISCV64, sys.S390X)
 	addF("runtime/internal/atomic", "Or8",
 		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 			s.vars[memVar] = s.newValue3(ssa.OpAtomicOr8, types.TypeMem, args[0], args[1], s.mem())
 			return nil
 		},
-		sys.AMD64, sys.ARM64, sys.MIPS, sys.PPC64, sys.RISCV64, sys.S390X)
+		sys.AMD64, sys.ARM64, sys.MIPS, sys.MIPS64, sys.PPC64, sys.RISCV64, sys.S390X)
 	addF("runtime/internal/atomic", "Or",
 		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 			s.vars[memVar] = s.newValue3(ssa.OpAtomicOr32, types.TypeMem, args[0], args[1], s.mem())
 			return nil
 		},
-		sys.AMD64, sys.MIPS, sys.PPC64, sys.RISCV64, sys.S390X)
+		sys.AMD64, sys.MIPS, sys.MIPS64, sys.PPC64, sys.RISCV64, sys.S390X)
 
 	atomicAndOrEmitterARM64 := func(s *state, n *ir.CallExpr, args []*ssa.Value, op ssa.Op, typ types.Kind) {
 		s.vars[memVar] = s.newValue3(op, types.TypeMem, args[0], args[1], s.mem())
@@ -4107,6 +4194,9 @@ func InitTables() {
 				s.vars[n] = s.callResult(n, callNormal) // types.Types[TFLOAT64]
 				return s.variable(n, types.Types[types.TFLOAT64])
 			}
+			if s.multiversion || objabi.GOAMD64 >= 3 {
+				return s.newValue3(ssa.OpFMA, types.Types[types.TFLOAT64], args[0], args[1], args[2])
+			}
 			v := s.entryNewValue0A(ssa.OpHasCPUFeature, types.Types[types.TBOOL], ir.Syms.X86HasFMA)
 			b := s.endBlock()
 			b.Kind = ssa.BlockIf
@@ -4169,6 +4259,9 @@ func InitTables() {
 
 	makeRoundAMD64 := func(op ssa.Op) func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			if s.multiversion || objabi.GOAMD64 >= 2 {
+				return s.newValue1(op, types.Types[types.TFLOAT64], args[0])
+			}
 			v := s.entryNewValue0A(ssa.OpHasCPUFeature, types.Types[types.TBOOL], ir.Syms.X86HasSSE41)
 			b := s.endBlock()
 			b.Kind = ssa.BlockIf
@@ -4375,6 +4468,13 @@ func InitTables() {
 
 	makeOnesCountAMD64 := func(op64 ssa.Op, op32 ssa.Op) func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			if s.multiversion || objabi.GOAMD64 >= 2 {
+				op := op64
+				if s.config.PtrSize == 4 {
+					op = op32
+				}
+				return s.newValue1(op, types.Types[types.TINT], args[0])
+			}
 			v := s.entryNewValue0A(ssa.OpHasCPUFeature, types.Types[types.TBOOL], ir.Syms.X86HasPOPCNT)
 			b := s.endBlock()
 			b.Kind = ssa.BlockIf
@@ -4441,20 +4541,20 @@ func InitTables() {
 		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 			return s.newValue2(ssa.OpMul64uhilo, types.NewTuple(types.Types[types.TUINT64], types.Types[types.TUINT64]), args[0], args[1])
 		},
-		sys.AMD64, sys.ARM64, sys.PPC64, sys.S390X, sys.MIPS64)
-	alias("math/bits", "Mul", "math/bits", "Mul64", sys.ArchAMD64, sys.ArchARM64, sys.ArchPPC64, sys.ArchS390X, sys.ArchMIPS64, sys.ArchMIPS64LE)
+		sys.AMD64, sys.ARM64, sys.PPC64, sys.S390X, sys.MIPS64, sys.RISCV64)
+	alias("math/bits", "Mul", "math/bits", "Mul64", sys.ArchAMD64, sys.ArchARM64, sys.ArchPPC64, sys.ArchS390X, sys.ArchMIPS64, sys.ArchMIPS64LE, sys.ArchRISCV64)
 	addF("math/bits", "Add64",
 		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 			return s.newValue3(ssa.OpAdd64carry, types.NewTuple(types.Types[types.TUINT64], types.Types[types.TUINT64]), args[0], args[1], args[2])
 		},
-		sys.AMD64, sys.ARM64, sys.PPC64, sys.S390X)
-	alias("math/bits", "Add", "math/bits", "Add64", sys.ArchAMD64, sys.ArchARM64, sys.ArchPPC64, sys.ArchS390X)
+		sys.AMD64, sys.ARM64, sys.PPC64, sys.S390X, sys.MIPS64, sys.RISCV64)
+	alias("math/bits", "Add", "math/bits", "Add64", sys.ArchAMD64, sys.ArchARM64, sys.ArchPPC64, sys.ArchS390X, sys.ArchMIPS64, sys.ArchMIPS64LE, sys.ArchRISCV64)
 	addF("math/bits", "Sub64",
 		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 			return s.newValue3(ssa.OpSub64borrow, types.NewTuple(types.Types[types.TUINT64], types.Types[types.TUINT64]), args[0], args[1], args[2])
 		},
-		sys.AMD64, sys.ARM64, sys.S390X)
-	alias("math/bits", "Sub", "math/bits", "Sub64", sys.ArchAMD64, sys.ArchARM64, sys.ArchS390X)
+		sys.AMD64, sys.ARM64, sys.S390X, sys.MIPS64, sys.RISCV64)
+	alias("math/bits", "Sub", "math/bits", "Sub64", sys.ArchAMD64, sys.ArchARM64, sys.ArchS390X, sys.ArchMIPS64, sys.ArchMIPS64LE, sys.ArchRISCV64)
 	addF("math/bits", "Div64",
 		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
 			// check for divide-by-zero/overflow and panic with appropriate message
@@ -4514,6 +4614,18 @@ func InitTables() {
 	alias("sync/atomic", "AddUintptr", "runtime/internal/atomic", "Xadd", p4...)
 	alias("sync/atomic", "AddUintptr", "runtime/internal/atomic", "Xadd64", p8...)
 
+	/******** arch ********/
+	makePrefetchFunc := func(op ssa.Op) func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			s.vars[memVar] = s.newValue2(op, types.TypeMem, args[0], s.mem())
+			return nil
+		}
+	}
+	addF("arch", "Prefetch", makePrefetchFunc(ssa.OpPrefetchCache),
+		sys.AMD64, sys.ARM64)
+	addF("arch", "PrefetchStreamed", makePrefetchFunc(ssa.OpPrefetchCacheStreamed),
+		sys.AMD64, sys.ARM64)
+
 	/******** math/big ********/
 	add("math/big", "mulWW",
 		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
@@ -4834,6 +4946,7 @@ func (s *state) call(n *ir.CallExpr, k callKind, returnResultAddr bool) *ssa.Val
 	var ACResults []*types.Type // AuxCall results
 	var callArgs []*ssa.Value   // For late-expansion, the args themselves (not stored, args to the call instead).
 	inRegisters := false
+	onStack := false // callee is //go:noregisterparams
 
 	var magicFnNameSym *types.Sym
 	if fn.Name() != nil {
@@ -4860,6 +4973,7 @@ func (s *state) call(n *ir.CallExpr, k callKind, returnResultAddr bool) *ssa.Val
 			inRegistersImported := fn.Pragma()&ir.RegisterParams != 0
 			inRegistersSamePackage := fn.Func != nil && fn.Func.Pragma&ir.RegisterParams != 0
 			inRegisters = inRegisters || inRegistersImported || inRegistersSamePackage
+			onStack = fn.Pragma()&ir.NoRegisterParams != 0 || fn.Func != nil && fn.Func.Pragma&ir.NoRegisterParams != 0
 			break
 		}
 		closure = s.expr(fn)
@@ -4889,8 +5003,15 @@ func (s *state) call(n *ir.CallExpr, k callKind, returnResultAddr bool) *ssa.Val
 		inRegisters = true
 	}
 
-	callABI := s.f.ABI1
-	if !inRegisters {
+	callABI := s.f.ABIDefault
+	switch {
+	case callee != nil && callTargetLSym(callee, s.curfn.LSym).ABI() == obj.ABI0:
+		// Assembly functions take their arguments on the stack,
+		// whatever the pragmas on their Go declarations say.
+		callABI = s.f.ABI0
+	case inRegisters:
+		callABI = s.f.ABI1
+	case onStack:
 		callABI = s.f.ABI0
 	}
 
@@ -5027,6 +5148,7 @@ func (s *state) call(n *ir.CallExpr, k callKind, returnResultAddr bool) *ssa.Val
 			call = s.newValue1A(ssa.OpInterLECall, aux.LateExpansionResultType(), aux, codeptr)
 		case callee != nil:
 			aux := ssa.StaticAuxCall(callTargetLSym(callee, s.curfn.LSym), params)
+			aux.Pure = callee.Pragma()&ir.Pure != 0 || callee.Func != nil && callee.Func.Pragma&ir.Pure != 0
 			call = s.newValue0A(ssa.OpStaticLECall, aux.LateExpansionResultType(), aux)
 		default:
 			s.Fatalf("bad call type %v %v", n.Op(), n)
@@ -5320,12 +5442,23 @@ func (s *state) exprPtr(n ir.Node, bounded bool, lineno src.XPos) *ssa.Value {
 // Used only for automatically inserted nil checks,
 // not for user code like 'x != nil'.
 func (s *state) nilCheck(ptr *ssa.Value) {
-	if base.Debug.DisableNil != 0 || s.curfn.NilCheckDisabled() {
+	if base.Debug.DisableNil != 0 || s.curfn.NilCheckDisabled() || s.checksDisabled() {
 		return
 	}
 	s.newValue2(ssa.OpNilCheck, types.TypeVoid, ptr, s.mem())
 }
 
+// checksDisabled reports whether bounds and nil checks are omitted at
+// the current position, which is the case in the code written in a
+// //go:nocheckbounds function, but not in code inlined into it.
+// The directive is ignored when checking with -race or -d=checkptr.
+func (s *state) checksDisabled() bool {
+	if s.curfn.Pragma&ir.NoCheckBounds == 0 || base.Flag.Race || base.Debug.Checkptr != 0 {
+		return false
+	}
+	return base.Ctxt.PosTable.Pos(s.peekPos()).Base().InliningIndex() < 0
+}
+
 // boundsCheck generates bounds checking code. Checks if 0 <= idx <[=] len, branches to exit if not.
 // Starts a new block on return.
 // On input, len must be converted to full int width and be nonnegative.
@@ -5335,8 +5468,8 @@ func (s *state) nilCheck(ptr *ssa.Value) {
 func (s *state) boundsCheck(idx, len *ssa.Value, kind ssa.BoundsKind, bounded bool) *ssa.Value {
 	idx = s.extendIndex(idx, len, kind, bounded)
 
-	if bounded || base.Flag.B != 0 {
-		// If bounded or bounds checking is flag-disabled, then no check necessary,
+	if bounded || base.Flag.B != 0 || s.checksDisabled() {
+		// If bounded or bounds checking is disabled, then no check necessary,
 		// just return the extended index.
 		//
 		// Here, bounded == true if the compiler generated the index itself,
@@ -5356,6 +5489,14 @@ func (s *state) boundsCheck(idx, len *ssa.Value, kind ssa.BoundsKind, bounded bo
 		// In theory the prove pass could potentially remove certain
 		// Spectre masks, but it's very delicate and probably better
 		// to be conservative and leave them all in.
+		//
+		// In Spectre load mode, the index is masked anyway: an index
+		// known to be in bounds, such as that of a range loop, is only
+		// in bounds if the branches that establish it are predicted
+		// correctly.
+		if base.Flag.Cfg.SpectreLoad {
+			idx = s.spectreMask(idx, len, kind)
+		}
 		return idx
 	}
 
@@ -5409,16 +5550,22 @@ func (s *state) boundsCheck(idx, len *ssa.Value, kind ssa.BoundsKind, bounded bo
 
 	// In Spectre index mode, apply an appropriate mask to avoid speculative out-of-bounds accesses.
 	if base.Flag.Cfg.SpectreIndex {
-		op := ssa.OpSpectreIndex
-		if kind != ssa.BoundsIndex && kind != ssa.BoundsIndexU {
-			op = ssa.OpSpectreSliceIndex
-		}
-		idx = s.newValue2(op, types.Types[types.TINT], idx, len)
+		idx = s.spectreMask(idx, len, kind)
 	}
 
 	return idx
 }
 
+// spectreMask returns idx masked so that it is 0 unless it is within
+// the bounds given by len and kind, even under speculation.
+func (s *state) spectreMask(idx, len *ssa.Value, kind ssa.BoundsKind) *ssa.Value {
+	op := ssa.OpSpectreIndex
+	if kind != ssa.BoundsIndex && kind != ssa.BoundsIndexU {
+		op = ssa.OpSpectreSliceIndex
+	}
+	return s.newValue2(op, types.Types[types.TINT], idx, len)
+}
+
 // If cmp (a bool) is false, panic using the given function.
 func (s *state) check(cmp *ssa.Value, fn *obj.LSym) {
 	b := s.endBlock()
@@ -5443,6 +5590,41 @@ func (s *state) check(cmp *ssa.Value, fn *obj.LSym) {
 	s.startBlock(bNext)
 }
 
+// needStackProtect reports whether fn gets a stack canary under
+// -stackprotect, because its frame contains an address-taken byte array.
+// The runtime and nosplit functions are never protected.
+func needStackProtect(fn *ir.Func) bool {
+	if base.Flag.CompilingRuntime || fn.Pragma&ir.Nosplit != 0 {
+		return false
+	}
+	for _, n := range fn.Dcl {
+		if n.Class != ir.PAUTO || !n.Addrtaken() || !n.Type().IsArray() {
+			continue
+		}
+		switch n.Type().Elem().Kind() {
+		case types.TUINT8, types.TINT8:
+			return true
+		}
+	}
+	return false
+}
+
+// stackProtectGuard returns the value stored in stack canaries.
+func (s *state) stackProtectGuard() *ssa.Value {
+	addr := s.entryNewValue1A(ssa.OpAddr, types.Types[types.TUINTPTR].PtrTo(), ir.Syms.StackProtectGuard, s.sb)
+	return s.load(types.Types[types.TUINTPTR], addr)
+}
+
+// checkCanary checks that the stack canary of the current function
+// still holds the guard value, and aborts the program if not.
+func (s *state) checkCanary() {
+	// The VarLive keeps the canary store from being forwarded to the load.
+	s.vars[memVar] = s.newValue1Apos(ssa.OpVarLive, types.TypeMem, s.canary, s.mem(), false)
+	v := s.load(types.Types[types.TUINTPTR], s.addr(s.canary))
+	cmp := s.newValue2(s.ssaOp(ir.OEQ, types.Types[types.TUINTPTR]), types.Types[types.TBOOL], v, s.stackProtectGuard())
+	s.check(cmp, ir.Syms.StackProtectFail)
+}
+
 func (s *state) intDivide(n ir.Node, a, b *ssa.Value) *ssa.Value {
 	needcheck := true
 	switch b.Op {
@@ -5983,6 +6165,36 @@ func (s *state) referenceTypeBuiltin(n *ir.UnaryExpr, x *ssa.Value) *ssa.Value {
 	return s.variable(n, lenType)
 }
 
+// ternary emits code to evaluate cond ? x : y.
+func (s *state) ternary(cond, x, y *ssa.Value) *ssa.Value {
+	// A new marker is needed each time, because the result may
+	// have a different type every time.
+	ternaryVar := ssaMarker("ternary")
+
+	bThen := s.f.NewBlock(ssa.BlockPlain)
+	bElse := s.f.NewBlock(ssa.BlockPlain)
+	bEnd := s.f.NewBlock(ssa.BlockPlain)
+
+	b := s.endBlock()
+	b.Kind = ssa.BlockIf
+	b.SetControl(cond)
+	b.AddEdgeTo(bThen)
+	b.AddEdgeTo(bElse)
+
+	s.startBlock(bThen)
+	s.vars[ternaryVar] = x
+	s.endBlock().AddEdgeTo(bEnd)
+
+	s.startBlock(bElse)
+	s.vars[ternaryVar] = y
+	s.endBlock().AddEdgeTo(bEnd)
+
+	s.startBlock(bEnd)
+	r := s.variable(ternaryVar, x.Type)
+	delete(s.vars, ternaryVar)
+	return r
+}
+
 type f2uCvtTab struct {
 	ltf, cvt2U, subf, or ssa.Op
 	floatValue           func(*state, *types.Type, float64) *ssa.Value
@@ -6197,13 +6409,38 @@ func (s *state) dottype(n *ir.TypeAssertExpr, commaok bool) (res, resok *ssa.Val
 	if base.Debug.TypeAssert > 0 {
 		base.WarnfAt(n.Pos(), "type assertion inlined")
 	}
+	typeword := itab
 	var targetITab *ssa.Value
 	if n.X.Type().IsEmptyInterface() {
 		// Looking for pointer to target type.
 		targetITab = target
-	} else {
+	} else if n.Itab != nil {
 		// Looking for pointer to itab for target type and source interface.
 		targetITab = s.expr(n.Itab)
+	} else {
+		// With -d=lazyitabs, the itab may have been generated at run
+		// time. Looking for pointer to target type in the itab, if any.
+		cond := s.newValue2(ssa.OpNeqPtr, types.Types[types.TBOOL], itab, s.constNil(byteptr))
+		s.vars[typVar] = itab
+		b := s.endBlock()
+		b.Kind = ssa.BlockIf
+		b.SetControl(cond)
+		b.Likely = ssa.BranchLikely
+		bLoad := s.f.NewBlock(ssa.BlockPlain)
+		bEnd := s.f.NewBlock(ssa.BlockPlain)
+		b.AddEdgeTo(bLoad)
+		b.AddEdgeTo(bEnd)
+
+		s.startBlock(bLoad)
+		off := s.newValue1I(ssa.OpOffPtr, byteptr, int64(types.PtrSize), itab)
+		s.vars[typVar] = s.load(byteptr, off)
+		s.endBlock()
+		bLoad.AddEdgeTo(bEnd)
+
+		s.startBlock(bEnd)
+		typeword = s.variable(typVar, byteptr)
+		delete(s.vars, typVar)
+		targetITab = target
 	}
 
 	var tmp ir.Node     // temporary for use with large types
@@ -6214,7 +6451,7 @@ func (s *state) dottype(n *ir.TypeAssertExpr, commaok bool) (res, resok *ssa.Val
 		tmp, addr = s.temp(n.Pos(), n.Type())
 	}
 
-	cond := s.newValue2(ssa.OpEqPtr, types.Types[types.TBOOL], itab, targetITab)
+	cond := s.newValue2(ssa.OpEqPtr, types.Types[types.TBOOL], typeword, targetITab)
 	b := s.endBlock()
 	b.Kind = ssa.BlockIf
 	b.SetControl(cond)
@@ -6700,6 +6937,7 @@ func genssa(f *ssa.Func, pp *objw.Progs) {
 
 	if base.Ctxt.Flag_locationlists {
 		debugInfo := ssa.BuildFuncDebug(base.Ctxt, f, base.Debug.LocationLists > 1, StackOffset)
+		debugInfo.OptimizedOut = e.optimizedOut
 		e.curfn.DebugInfo = debugInfo
 		bstart := s.bstart
 		// Note that at this moment, Prog.Pc is a sequence number; it's
@@ -6935,7 +7173,7 @@ func (s *state) extendIndex(idx, len *ssa.Value, kind ssa.BoundsKind, bounded bo
 		} else {
 			lo = s.newValue1(ssa.OpInt64Lo, types.Types[types.TUINT], idx)
 		}
-		if bounded || base.Flag.B != 0 {
+		if bounded || base.Flag.B != 0 || s.checksDisabled() {
 			return lo
 		}
 		bNext := s.f.NewBlock(ssa.BlockPlain)
@@ -7165,11 +7403,14 @@ func fieldIdx(n *ir.SelectorExpr) int {
 // ssafn holds frontend information about a function that the backend is processing.
 // It also exports a bunch of compiler services for the ssa backend.
 type ssafn struct {
-	curfn      *ir.Func
-	strings    map[string]*obj.LSym // map from constant string to data symbols
-	stksize    int64                // stack size for current frame
-	stkptrsize int64                // prefix of stack containing pointers
-	log        bool                 // print ssa debug to the stdout
+	curfn        *ir.Func
+	strings      map[string]*obj.LSym // map from constant string to data symbols
+	stksize      int64                // stack size for current frame
+	stkptrsize   int64                // prefix of stack containing pointers
+	spillsize    int64                // part of stksize holding regalloc spill slots
+	log          bool                 // print ssa debug to the stdout
+	optimizedOut []*ir.Name           // user variables with no stack slot, for debug info
+	canary       *ir.Name             // stack canary for -stackprotect, or nil
 }
 
 // StringData returns a symbol which
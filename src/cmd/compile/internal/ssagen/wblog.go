@@ -0,0 +1,82 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/ssa"
+	"cmd/internal/src"
+)
+
+// wbFunc records the write barriers of a function for -d=wb=json.
+type wbFunc struct {
+	name   string
+	pos    src.XPos
+	stores []ssa.WBStore
+}
+
+var (
+	wbFuncsMu sync.Mutex // protects wbFuncs
+	wbFuncs   []wbFunc
+)
+
+// recordWriteBarriers records the write barrier stores of f, compiled
+// from fn, for ReportWriteBarriers.
+func recordWriteBarriers(fn *ir.Func, f *ssa.Func) {
+	if len(f.WBStores) == 0 {
+		return
+	}
+	wbFuncsMu.Lock()
+	wbFuncs = append(wbFuncs, wbFunc{ir.FuncName(fn), fn.Pos(), f.WBStores})
+	wbFuncsMu.Unlock()
+}
+
+// ReportWriteBarriers prints, for -d=wb=json, one JSON object per
+// function with write barriers, listing each store that received a
+// write barrier, the type stored, and why the barrier could not be
+// elided.
+func ReportWriteBarriers() {
+	if base.Debug.WB != 2 {
+		return
+	}
+	sort.Slice(wbFuncs, func(i, j int) bool {
+		if wbFuncs[i].pos != wbFuncs[j].pos {
+			return wbFuncs[i].pos.Before(wbFuncs[j].pos)
+		}
+		return wbFuncs[i].name < wbFuncs[j].name
+	})
+	type store struct {
+		Pos    string `json:"pos"`
+		Op     string `json:"op"`
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	for _, wf := range wbFuncs {
+		sort.SliceStable(wf.stores, func(i, j int) bool {
+			return wf.stores[i].Pos.Before(wf.stores[j].Pos)
+		})
+		stores := make([]store, len(wf.stores))
+		for i, s := range wf.stores {
+			stores[i] = store{base.FmtPos(s.Pos), strings.ToLower(s.Op.String()), s.Type.String(), s.Reason}
+		}
+		b, err := json.Marshal(struct {
+			Pos    string  `json:"pos"`
+			Func   string  `json:"func"`
+			Stores []store `json:"stores"`
+		}{base.FmtPos(wf.pos), wf.name, stores})
+		if err != nil {
+			base.Fatalf("%v", err)
+		}
+		fmt.Printf("%s\n", b)
+	}
+	wbFuncs = nil
+}
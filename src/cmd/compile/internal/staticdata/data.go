@@ -291,7 +291,13 @@ func WriteFuncSyms() {
 	})
 	for _, s := range funcsyms {
 		sf := s.Pkg.Lookup(ir.FuncSymName(s)).Linksym()
-		objw.SymPtr(sf, 0, s.Linksym(), 0)
+		target := s.Linksym()
+		if n, ok := s.Def.(*ir.Name); ok && n.Class == ir.PFUNC {
+			if b := ir.ABIBridgeSym(n); b != nil {
+				target = b.Linksym()
+			}
+		}
+		objw.SymPtr(sf, 0, target, 0)
 		objw.Global(sf, int32(types.PtrSize), obj.DUPOK|obj.RODATA)
 	}
 }
@@ -5,6 +5,12 @@
 package staticdata
 
 import (
+	"bytes"
+	"compress/flate"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
 	"path"
 	"sort"
 	"strings"
@@ -14,6 +20,7 @@ import (
 	"cmd/compile/internal/objw"
 	"cmd/compile/internal/types"
 	"cmd/internal/obj"
+	"cmd/internal/src"
 )
 
 const (
@@ -102,13 +109,53 @@ func embedFileLess(x, y string) bool {
 	return xdir < ydir || xdir == ydir && xelem < yelem
 }
 
+// compressedFileSym returns a symbol for the contents of file compressed
+// with DEFLATE, the size of the compressed contents, and the size of file.
+// If compression does not make the contents smaller, it returns a symbol
+// for the contents themselves, their size, and 0.
+// The content hash of file is copied into hash.
+func compressedFileSym(pos src.XPos, file string, hash []byte) (sym *obj.LSym, size, usize int64, err error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, 0, 0, fmt.Errorf("not a regular file")
+	}
+	if info.Size() > 2e9 {
+		return nil, 0, 0, fmt.Errorf("file too large")
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	sum := sha256.Sum256(data)
+	copy(hash, sum[:])
+
+	var buf bytes.Buffer
+	zw, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if _, err := zw.Write(data); err != nil {
+		return nil, 0, 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, 0, 0, err
+	}
+	if buf.Len() >= len(data) {
+		return StringSym(pos, string(data)), int64(len(data)), 0, nil
+	}
+	return StringSym(pos, buf.String()), int64(buf.Len()), int64(len(data)), nil
+}
+
 // WriteEmbed emits the init data for a //go:embed variable,
 // which is either a string, a []byte, or an embed.FS.
 func WriteEmbed(v *ir.Name) {
 	// TODO(mdempsky): User errors should be reported by the frontend.
 
 	commentPos := (*v.Embed)[0].Pos
-	if !types.AllowsGoVersion(types.LocalPkg, 1, 16) {
+	if !types.AllowsGoVersionAt(types.LocalPkg, commentPos, 1, 16) {
 		prevPos := base.Pos
 		base.Pos = commentPos
 		base.ErrorfVers("go1.16", "go:embed")
@@ -125,6 +172,12 @@ func WriteEmbed(v *ir.Name) {
 		return
 	}
 
+	compress := (*v.Embed)[0].Compress
+	if compress && kind != embedFiles {
+		base.ErrorfAt(v.Pos(), "go:embedcompress cannot apply to var of type %v", v.Type())
+		return
+	}
+
 	files := embedFileList(v, kind)
 	switch kind {
 	case embedString, embedBytes:
@@ -153,6 +206,7 @@ func WriteEmbed(v *ir.Name) {
 		//	name string
 		//	data string
 		//	hash [16]byte
+		//	size int
 		// Emit one of these per file in the set.
 		const hashSize = 16
 		hash := make([]byte, hashSize)
@@ -164,14 +218,23 @@ func WriteEmbed(v *ir.Name) {
 				off = objw.Uintptr(slicedata, off, 0)
 				off = objw.Uintptr(slicedata, off, 0)
 				off += hashSize
+				off = objw.Uintptr(slicedata, off, 0)
 			} else {
-				fsym, size, err := fileStringSym(v.Pos(), base.Flag.Cfg.Embed.Files[file], true, hash)
+				var fsym *obj.LSym
+				var size, usize int64
+				var err error
+				if compress {
+					fsym, size, usize, err = compressedFileSym(v.Pos(), base.Flag.Cfg.Embed.Files[file], hash)
+				} else {
+					fsym, size, err = fileStringSym(v.Pos(), base.Flag.Cfg.Embed.Files[file], true, hash)
+				}
 				if err != nil {
 					base.ErrorfAt(v.Pos(), "embed %s: %v", file, err)
 				}
 				off = objw.SymPtr(slicedata, off, fsym, 0) // data string
 				off = objw.Uintptr(slicedata, off, uint64(size))
 				off = int(slicedata.WriteBytes(base.Ctxt, int64(off), hash))
+				off = objw.Uintptr(slicedata, off, uint64(usize)) // decompressed size, if compressed
 			}
 		}
 		objw.Global(slicedata, int32(off), obj.RODATA|obj.LOCAL)
@@ -0,0 +1,139 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package staticdata
+
+import (
+	"encoding/binary"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/objw"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+	"cmd/internal/obj"
+	"cmd/internal/objabi"
+	"cmd/internal/src"
+	"cmd/internal/sys"
+)
+
+// WriteWasmExports emits the data describing the functions of the
+// package that are marked //go:wasmexport, from which the linker
+// generates the exported WebAssembly functions.
+//
+// The pkg..wasmexports symbol holds for each function a
+// runtime.wasmExport, followed by the frame in which the exported
+// WebAssembly function passes the arguments and results. The
+// pkg..wasmexportinfo symbol tells the linker the export names and
+// the layout of the frames; see cmd/internal/objabi/wasmexport.go.
+//
+// On other architectures, the directive has no effect.
+func WriteWasmExports() {
+	if base.Ctxt.Arch.Family != sys.Wasm || len(typecheck.Target.WasmExports) == 0 {
+		return
+	}
+
+	lsym := typecheck.Lookup(".wasmexports").Linksym()
+	var info []byte
+	off := 0
+	for _, x := range typecheck.Target.WasmExports {
+		fn := x.Func
+		t := fn.Type()
+		types.CalcSize(t)
+		params, ok := wasmExportValues(x.Pos, t.Params(), true)
+		if !ok {
+			continue
+		}
+		if t.NumResults() > 1 {
+			base.ErrorfAt(x.Pos, "go:wasmexport cannot apply to func with more than one result")
+			continue
+		}
+		results, ok := wasmExportValues(x.Pos, t.Results(), false)
+		if !ok {
+			continue
+		}
+
+		frameSize := t.ArgWidth()
+		retOffset := frameSize
+		if t.NumResults() > 0 {
+			retOffset = t.Results().Field(0).Offset
+		}
+
+		info = appendUvarint(info, uint64(len(x.Name)))
+		info = append(info, x.Name...)
+		info = appendUvarint(info, uint64(off))
+		info = append(info, params...)
+		info = append(info, results...)
+
+		// runtime.wasmExport and its frame.
+		off = objw.SymPtr(lsym, off, FuncLinksym(fn.Nname), 0)
+		off = objw.Uintptr(lsym, off, uint64(frameSize))
+		off = objw.Uintptr(lsym, off, uint64(retOffset))
+		off += int(types.Rnd(frameSize, int64(types.PtrSize)))
+	}
+	if off == 0 {
+		return
+	}
+	objw.Global(lsym, int32(off), obj.NOPTR)
+
+	ilsym := typecheck.Lookup(".wasmexportinfo").Linksym()
+	ilsym.WriteBytes(base.Ctxt, 0, info)
+	objw.Global(ilsym, int32(len(info)), obj.RODATA|obj.NOPTR)
+}
+
+// wasmExportValues returns the encoding of the kinds and frame offsets
+// of the parameters or results of a //go:wasmexport function, which
+// are the fields of fields. It reports an error at pos and returns
+// false if one of them has a type that cannot be exported.
+func wasmExportValues(pos src.XPos, fields *types.Type, param bool) ([]byte, bool) {
+	var b []byte
+	b = appendUvarint(b, uint64(fields.NumFields()))
+	for _, f := range fields.FieldSlice() {
+		kind := wasmExportKind(f.Type, param)
+		if kind == 0 {
+			what := "result"
+			if param {
+				what = "parameter"
+			}
+			base.ErrorfAt(pos, "go:wasmexport: unsupported %s type %v", what, f.Type)
+			return nil, false
+		}
+		b = append(b, kind)
+		b = appendUvarint(b, uint64(f.Offset))
+	}
+	return b, true
+}
+
+// wasmExportKind returns the objabi.WasmExport kind of a parameter or
+// result of type t, or 0 if t cannot be passed to or from the host.
+// A host reference arrives as a syscall/js.Externref, which can only
+// be a parameter.
+func wasmExportKind(t *types.Type, param bool) byte {
+	if s := t.Sym(); s != nil && s.Name == "Externref" && s.Pkg.Path == "syscall/js" {
+		if param {
+			return objabi.WasmExportExternref
+		}
+		return 0
+	}
+	switch t.Kind() {
+	case types.TINT32, types.TUINT32:
+		return objabi.WasmExportI32
+	case types.TINT64, types.TUINT64, types.TINT, types.TUINT:
+		return objabi.WasmExportI64
+	case types.TFLOAT32:
+		return objabi.WasmExportF32
+	case types.TFLOAT64:
+		return objabi.WasmExportF64
+	case types.TBOOL:
+		return objabi.WasmExportBool
+	case types.TUINTPTR, types.TUNSAFEPTR:
+		return objabi.WasmExportPtr
+	}
+	return 0
+}
+
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	return append(b, buf[:n]...)
+}
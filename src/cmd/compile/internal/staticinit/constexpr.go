@@ -0,0 +1,1258 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package staticinit
+
+import (
+	"go/constant"
+	"math"
+	"unicode/utf8"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/staticdata"
+	"cmd/compile/internal/types"
+)
+
+// This file implements compile-time evaluation of calls to small,
+// pure functions with constant arguments, so that
+//
+//	var squares = makeSquares(16)
+//
+// is initialized statically instead of by running makeSquares at
+// program start.
+//
+// The evaluator interprets the typechecked IR of the callee. It
+// knows only local variables of boolean, numeric, string, array,
+// struct and slice type, and the statements and operators on them.
+// Anything else, such as reading a global variable, taking an
+// address, or calling a function it cannot evaluate in turn, stops
+// evaluation, as does a run-time panic or running out of fuel. The
+// call is then left to run at program start as before.
+
+const (
+	// maxEvalNodes is the size, in IR nodes, of the largest
+	// function body the evaluator interprets.
+	maxEvalNodes = 400
+
+	// maxEvalSteps is the fuel for evaluating one call: the number
+	// of statements, expressions and allocated elements it may
+	// evaluate before giving up.
+	maxEvalSteps = 100000
+
+	// maxEvalDepth is the maximum depth of nested calls.
+	maxEvalDepth = 100
+)
+
+// A value is the compile-time value of an expression: a bool, an
+// int64 (signed integers), a uint64 (unsigned integers), a float64
+// (floating-point numbers, rounded to float32 as needed), a string,
+// an aggregate (arrays and structs) or a slice.
+type value interface{}
+
+// An aggregate holds the elements of an array or the fields of a
+// struct. Every variable, array element and struct field of
+// aggregate type has its own aggregate, which stores update in place;
+// reading one yields a copy.
+type aggregate []value
+
+// A slice refers to elements [off, off+len) of a backing array, and
+// has capacity up to element off+cap. The nil slice has a nil b.
+type slice struct {
+	b             *backing
+	off, len, cap int64
+}
+
+type backing struct {
+	elems []value
+}
+
+// evalFailed is panicked to abandon an evaluation.
+type evalFailed struct{}
+
+// ctl says how control leaves a statement.
+type ctl int
+
+const (
+	ctlNext ctl = iota
+	ctlBreak
+	ctlContinue
+	ctlFallthrough
+	ctlReturn
+)
+
+type evaluator struct {
+	steps int
+	depth int
+	f     *frame
+}
+
+// A frame holds the variables of a call in progress.
+type frame struct {
+	fn      *ir.Func
+	vars    map[*ir.Name]*value
+	results []value
+}
+
+// evalOK caches the result of evaluable for each callee.
+var evalOK = map[*ir.Func]bool{}
+
+// evalCall evaluates the function call n at compile time, if all its
+// arguments are constants and the callee is one the evaluator can
+// run, and reports whether it succeeded.
+func evalCall(n *ir.CallExpr) (v value, ok bool) {
+	if base.Flag.N != 0 || n.IsDDD || len(n.Init()) != 0 {
+		return nil, false
+	}
+	for _, arg := range n.Args {
+		if arg.Op() != ir.OLITERAL {
+			return nil, false
+		}
+	}
+	if evalCallee(n) == nil {
+		return nil, false
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if _, failed := r.(evalFailed); !failed {
+				panic(r)
+			}
+			v, ok = nil, false
+		}
+	}()
+	e := new(evaluator)
+	v = e.expr(n)
+	if !isStatic(v, map[*backing]bool{}) {
+		return nil, false
+	}
+	return v, true
+}
+
+// evalCallee returns the function called by n, if the evaluator can
+// run it, and otherwise nil.
+func evalCallee(n *ir.CallExpr) *ir.Func {
+	if n.Op() != ir.OCALLFUNC || n.X.Op() != ir.ONAME {
+		return nil
+	}
+	name := n.X.(*ir.Name)
+	if name.Class != ir.PFUNC || name.Func == nil || name.Sym().Pkg != types.LocalPkg {
+		return nil
+	}
+	fn := name.Func
+	ok, cached := evalOK[fn]
+	if !cached {
+		ok = evaluable(fn)
+		evalOK[fn] = ok
+	}
+	if !ok {
+		return nil
+	}
+	return fn
+}
+
+// evaluable reports whether fn is a candidate for evaluation: a
+// small, non-generic, non-variadic function with a body.
+func evaluable(fn *ir.Func) bool {
+	t := fn.Type()
+	if len(fn.Body) == 0 || t.HasTParam() || t.IsVariadic() || t.Recv() != nil {
+		return false
+	}
+	size := 0
+	return !ir.Any(fn, func(n ir.Node) bool {
+		size++
+		return size > maxEvalNodes || n.Op() == ir.OCLOSURE
+	})
+}
+
+func (e *evaluator) fail() {
+	panic(evalFailed{})
+}
+
+func (e *evaluator) step() {
+	e.steps++
+	if e.steps > maxEvalSteps {
+		e.fail()
+	}
+}
+
+// call runs fn with arguments args and returns its results.
+func (e *evaluator) call(fn *ir.Func, args []value) []value {
+	e.depth++
+	if e.depth > maxEvalDepth {
+		e.fail()
+	}
+	defer func(f *frame) {
+		e.f = f
+		e.depth--
+	}(e.f)
+
+	f := &frame{fn: fn, vars: make(map[*ir.Name]*value)}
+	e.f = f
+	for i, p := range fn.Type().Params().FieldSlice() {
+		if n, ok := p.Nname.(*ir.Name); ok && !ir.IsBlank(n) {
+			store(e.newVar(n), args[i])
+		}
+	}
+	results := fn.Type().Results().FieldSlice()
+	for _, r := range results {
+		if n, ok := r.Nname.(*ir.Name); ok && !ir.IsBlank(n) {
+			e.newVar(n)
+		}
+	}
+	if e.stmts(fn.Body) != ctlReturn && len(results) != 0 {
+		e.fail()
+	}
+	return f.results
+}
+
+// newVar declares the variable n in the current frame and returns
+// its storage, holding the zero value.
+func (e *evaluator) newVar(n *ir.Name) *value {
+	v := e.zero(n.Type())
+	e.f.vars[n] = &v
+	return &v
+}
+
+func (e *evaluator) stmts(l ir.Nodes) ctl {
+	for _, n := range l {
+		if c := e.stmt(n); c != ctlNext {
+			return c
+		}
+	}
+	return ctlNext
+}
+
+func (e *evaluator) stmt(n ir.Node) ctl {
+	e.step()
+	if c := e.stmts(n.Init()); c != ctlNext {
+		return c
+	}
+	switch n.Op() {
+	default:
+		e.fail()
+
+	case ir.OBLOCK:
+		n := n.(*ir.BlockStmt)
+		return e.stmts(n.List)
+
+	case ir.ODCL:
+		n := n.(*ir.Decl)
+		e.newVar(n.X)
+
+	case ir.OCALLFUNC:
+		e.call(e.callee(n.(*ir.CallExpr)))
+
+	case ir.OCOPY:
+		e.expr(n)
+
+	case ir.OAS:
+		n := n.(*ir.AssignStmt)
+		if n.Y == nil {
+			if !ir.IsBlank(n.X) {
+				store(e.addr(n.X), e.zero(n.X.Type()))
+			}
+			break
+		}
+		if ir.IsBlank(n.X) {
+			e.expr(n.Y)
+			break
+		}
+		p := e.addr(n.X)
+		store(p, e.expr(n.Y))
+
+	case ir.OAS2:
+		n := n.(*ir.AssignListStmt)
+		ps := e.addrs(n.Lhs)
+		vs := make([]value, len(n.Rhs))
+		for i, r := range n.Rhs {
+			vs[i] = e.expr(r)
+		}
+		for i, p := range ps {
+			if p != nil {
+				store(p, vs[i])
+			}
+		}
+
+	case ir.OAS2FUNC:
+		n := n.(*ir.AssignListStmt)
+		ps := e.addrs(n.Lhs)
+		call, ok := n.Rhs[0].(*ir.CallExpr)
+		if !ok || len(call.Init()) != 0 {
+			e.fail()
+		}
+		vs := e.call(e.callee(call))
+		for i, p := range ps {
+			if p != nil {
+				store(p, vs[i])
+			}
+		}
+
+	case ir.OASOP:
+		n := n.(*ir.AssignOpStmt)
+		p := e.addr(n.X)
+		y := e.expr(n.Y)
+		store(p, e.binary(n.AsOp, n.X.Type(), *p, y))
+
+	case ir.OIF:
+		n := n.(*ir.IfStmt)
+		if e.cond(n.Cond) {
+			return e.stmts(n.Body)
+		}
+		return e.stmts(n.Else)
+
+	case ir.OFOR:
+		n := n.(*ir.ForStmt)
+		if n.Label != nil || len(n.Late) != 0 {
+			e.fail()
+		}
+		for n.Cond == nil || e.cond(n.Cond) {
+			switch c := e.stmts(n.Body); c {
+			case ctlBreak:
+				return ctlNext
+			case ctlReturn:
+				return c
+			}
+			if n.Post != nil {
+				e.stmt(n.Post)
+			}
+		}
+
+	case ir.ORANGE:
+		return e.rangeStmt(n.(*ir.RangeStmt))
+
+	case ir.OSWITCH:
+		return e.switchStmt(n.(*ir.SwitchStmt))
+
+	case ir.OBREAK, ir.OCONTINUE:
+		n := n.(*ir.BranchStmt)
+		if n.Label != nil {
+			e.fail()
+		}
+		if n.Op() == ir.OBREAK {
+			return ctlBreak
+		}
+		return ctlContinue
+
+	case ir.OFALL:
+		return ctlFallthrough
+
+	case ir.ORETURN:
+		n := n.(*ir.ReturnStmt)
+		var vs []value
+		switch {
+		case len(n.Results) == 0:
+			for _, r := range e.f.fn.Type().Results().FieldSlice() {
+				n, ok := r.Nname.(*ir.Name)
+				if !ok || ir.IsBlank(n) {
+					e.fail()
+				}
+				vs = append(vs, *e.f.vars[n])
+			}
+		case len(n.Results) == 1 && n.Results[0].Type().IsFuncArgStruct():
+			call, ok := n.Results[0].(*ir.CallExpr)
+			if !ok || len(call.Init()) != 0 {
+				e.fail()
+			}
+			vs = e.call(e.callee(call))
+		default:
+			for _, r := range n.Results {
+				vs = append(vs, e.expr(r))
+			}
+		}
+		e.f.results = vs
+		return ctlReturn
+	}
+	return ctlNext
+}
+
+func (e *evaluator) rangeStmt(n *ir.RangeStmt) ctl {
+	if n.Label != nil {
+		e.fail()
+	}
+	t := n.X.Type()
+	x := e.expr(n.X)
+	var elems []value
+	switch {
+	case t.IsArray():
+		// Range over a copy of the array.
+		a := e.zero(t)
+		store(&a, x)
+		elems = a.(aggregate)
+	case t.IsSlice():
+		s := x.(slice)
+		if s.b != nil {
+			elems = s.b.elems[s.off : s.off+s.len]
+		}
+	case t.IsString():
+		s := x.(string)
+		for i := 0; i < len(s); {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			e.assign(n.Key, int64(i))
+			e.assign(n.Value, int64(r))
+			i += size
+			switch c := e.stmts(n.Body); c {
+			case ctlBreak:
+				return ctlNext
+			case ctlReturn:
+				return c
+			}
+		}
+		return ctlNext
+	default:
+		e.fail()
+	}
+	for i := range elems {
+		e.assign(n.Key, int64(i))
+		e.assign(n.Value, elems[i])
+		switch c := e.stmts(n.Body); c {
+		case ctlBreak:
+			return ctlNext
+		case ctlReturn:
+			return c
+		}
+	}
+	return ctlNext
+}
+
+func (e *evaluator) switchStmt(n *ir.SwitchStmt) ctl {
+	if n.Label != nil || n.Tag != nil && n.Tag.Op() == ir.OTYPESW {
+		e.fail()
+	}
+	var tag value = true
+	if n.Tag != nil {
+		tag = e.expr(n.Tag)
+	}
+	match, def := -1, -1
+cases:
+	for i, cas := range n.Cases {
+		if len(cas.List) == 0 {
+			def = i
+			continue
+		}
+		for _, v := range cas.List {
+			if equal(tag, e.expr(v)) {
+				match = i
+				break cases
+			}
+		}
+	}
+	if match < 0 {
+		match = def
+	}
+	if match < 0 {
+		return ctlNext
+	}
+	for _, cas := range n.Cases[match:] {
+		switch c := e.stmts(cas.Body); c {
+		case ctlFallthrough:
+			continue
+		case ctlBreak:
+			return ctlNext
+		default:
+			return c
+		}
+	}
+	return ctlNext
+}
+
+// assign stores v in n, which may be nil or blank.
+func (e *evaluator) assign(n ir.Node, v value) {
+	if n != nil && !ir.IsBlank(n) {
+		store(e.addr(n), v)
+	}
+}
+
+// addrs returns the storage of each of l, or nil for blanks.
+// Like an assignment, it evaluates all the index operands before
+// any variable is stored to.
+func (e *evaluator) addrs(l ir.Nodes) []*value {
+	ps := make([]*value, len(l))
+	for i, n := range l {
+		if !ir.IsBlank(n) {
+			ps[i] = e.addr(n)
+		}
+	}
+	return ps
+}
+
+// addr returns the storage of the variable, array or slice element,
+// or struct field n.
+func (e *evaluator) addr(n ir.Node) *value {
+	e.step()
+	switch n.Op() {
+	case ir.ONAME:
+		n := n.(*ir.Name)
+		if p := e.f.vars[n]; p != nil {
+			return p
+		}
+		// A variable declared by := without an ODCL.
+		if n.Class == ir.PAUTO && n.Curfn == e.f.fn {
+			return e.newVar(n)
+		}
+
+	case ir.OPAREN:
+		return e.addr(n.(*ir.ParenExpr).X)
+
+	case ir.OINDEX:
+		n := n.(*ir.IndexExpr)
+		switch t := n.X.Type(); {
+		case t.IsArray():
+			a := (*e.addr(n.X)).(aggregate)
+			return &a[e.index(n.Index, int64(len(a)))]
+		case t.IsSlice():
+			s := e.expr(n.X).(slice)
+			return &s.b.elems[s.off+e.index(n.Index, s.len)]
+		}
+
+	case ir.ODOT:
+		n := n.(*ir.SelectorExpr)
+		a := (*e.addr(n.X)).(aggregate)
+		return &a[e.field(n)]
+	}
+	e.fail()
+	panic("unreachable")
+}
+
+// store stores v in p. Aggregates are copied into the aggregate
+// already at p, so that the storage of their elements stays put.
+func store(p *value, v value) {
+	if a, ok := (*p).(aggregate); ok {
+		b := v.(aggregate)
+		for i := range a {
+			store(&a[i], b[i])
+		}
+		return
+	}
+	*p = v
+}
+
+// callee evaluates the arguments of call and returns them along with
+// the function to call.
+func (e *evaluator) callee(call *ir.CallExpr) (*ir.Func, []value) {
+	fn := evalCallee(call)
+	if fn == nil || call.IsDDD {
+		e.fail()
+	}
+	args := make([]value, len(call.Args))
+	for i, arg := range call.Args {
+		args[i] = e.expr(arg)
+	}
+	return fn, args
+}
+
+func (e *evaluator) cond(n ir.Node) bool {
+	return e.expr(n).(bool)
+}
+
+// index evaluates the index n and checks it against the bound n.
+func (e *evaluator) index(n ir.Node, bound int64) int64 {
+	var i int64
+	switch x := e.expr(n).(type) {
+	case int64:
+		i = x
+	case uint64:
+		if x > math.MaxInt64 {
+			e.fail()
+		}
+		i = int64(x)
+	default:
+		e.fail()
+	}
+	if i < 0 || i >= bound {
+		e.fail()
+	}
+	return i
+}
+
+// field returns the index in its struct of the field selected by n.
+func (e *evaluator) field(n *ir.SelectorExpr) int {
+	for i, f := range n.X.Type().FieldSlice() {
+		if f == n.Selection {
+			return i
+		}
+	}
+	e.fail()
+	panic("unreachable")
+}
+
+func (e *evaluator) expr(n ir.Node) value {
+	e.step()
+	if len(n.Init()) != 0 {
+		e.fail()
+	}
+	t := n.Type()
+	switch n.Op() {
+	case ir.OLITERAL:
+		return e.constant(t, n.Val())
+
+	case ir.ONIL:
+		if !t.IsSlice() {
+			e.fail()
+		}
+		return slice{}
+
+	case ir.ONAME:
+		n := n.(*ir.Name)
+		if p := e.f.vars[n]; p != nil {
+			return e.copy(*p)
+		}
+
+	case ir.OPAREN:
+		return e.expr(n.(*ir.ParenExpr).X)
+
+	case ir.OCONVNOP:
+		n := n.(*ir.ConvExpr)
+		if !types.Identical(t.Underlying(), n.X.Type().Underlying()) {
+			e.fail()
+		}
+		return e.expr(n.X)
+
+	case ir.OCONV:
+		n := n.(*ir.ConvExpr)
+		return e.convert(t, n.X.Type(), e.expr(n.X))
+
+	case ir.ORUNESTR:
+		n := n.(*ir.ConvExpr)
+		switch x := e.expr(n.X).(type) {
+		case int64:
+			if x >= math.MinInt32 && x <= math.MaxInt32 {
+				return string(rune(x))
+			}
+		case uint64:
+			if x <= math.MaxInt32 {
+				return string(rune(x))
+			}
+		}
+		return string(utf8.RuneError)
+
+	case ir.OSTR2BYTES, ir.OSTR2RUNES:
+		n := n.(*ir.ConvExpr)
+		s := e.expr(n.X).(string)
+		var elems []value
+		if n.Op() == ir.OSTR2BYTES {
+			for i := 0; i < len(s); i++ {
+				elems = append(elems, uint64(s[i]))
+			}
+		} else {
+			for _, r := range s {
+				elems = append(elems, int64(r))
+			}
+		}
+		e.steps += len(elems)
+		e.step()
+		return slice{&backing{elems}, 0, int64(len(elems)), int64(len(elems))}
+
+	case ir.OBYTES2STR, ir.ORUNES2STR:
+		n := n.(*ir.ConvExpr)
+		s := e.expr(n.X).(slice)
+		if s.b == nil {
+			return ""
+		}
+		elems := s.b.elems[s.off : s.off+s.len]
+		e.steps += len(elems)
+		e.step()
+		var b []byte
+		for _, x := range elems {
+			if n.Op() == ir.OBYTES2STR {
+				b = append(b, byte(x.(uint64)))
+			} else {
+				b = append(b, string(rune(x.(int64)))...)
+			}
+		}
+		return string(b)
+
+	case ir.OADDSTR:
+		n := n.(*ir.AddStringExpr)
+		var s string
+		for _, x := range n.List {
+			s += e.expr(x).(string)
+		}
+		e.steps += len(s)
+		return s
+
+	case ir.OANDAND, ir.OOROR:
+		n := n.(*ir.LogicalExpr)
+		x := e.cond(n.X)
+		if x == (n.Op() == ir.OOROR) {
+			return x
+		}
+		return e.cond(n.Y)
+
+	case ir.ONOT:
+		n := n.(*ir.UnaryExpr)
+		return !e.cond(n.X)
+
+	case ir.OPLUS:
+		n := n.(*ir.UnaryExpr)
+		return e.expr(n.X)
+
+	case ir.ONEG, ir.OBITNOT:
+		n := n.(*ir.UnaryExpr)
+		switch x := e.expr(n.X).(type) {
+		case int64:
+			if n.Op() == ir.ONEG {
+				return normInt(t, uint64(-x))
+			}
+			return normInt(t, uint64(^x))
+		case uint64:
+			if n.Op() == ir.ONEG {
+				return normInt(t, -x)
+			}
+			return normInt(t, ^x)
+		case float64:
+			if n.Op() == ir.ONEG {
+				return -x
+			}
+		}
+
+	case ir.OEQ, ir.ONE, ir.OLT, ir.OLE, ir.OGT, ir.OGE:
+		n := n.(*ir.BinaryExpr)
+		return e.compare(n.Op(), e.expr(n.X), e.expr(n.Y))
+
+	case ir.OADD, ir.OSUB, ir.OMUL, ir.ODIV, ir.OMOD,
+		ir.OAND, ir.OOR, ir.OXOR, ir.OANDNOT, ir.OLSH, ir.ORSH:
+		n := n.(*ir.BinaryExpr)
+		return e.binary(n.Op(), t, e.expr(n.X), e.expr(n.Y))
+
+	case ir.OLEN, ir.OCAP:
+		n := n.(*ir.UnaryExpr)
+		switch x := e.expr(n.X).(type) {
+		case string:
+			return int64(len(x))
+		case aggregate:
+			return int64(len(x))
+		case slice:
+			if n.Op() == ir.OLEN {
+				return x.len
+			}
+			return x.cap
+		}
+
+	case ir.OINDEX:
+		n := n.(*ir.IndexExpr)
+		switch x := e.expr(n.X).(type) {
+		case string:
+			return uint64(x[e.index(n.Index, int64(len(x)))])
+		case aggregate:
+			return x[e.index(n.Index, int64(len(x)))]
+		case slice:
+			return e.copy(x.b.elems[x.off+e.index(n.Index, x.len)])
+		}
+
+	case ir.ODOT:
+		n := n.(*ir.SelectorExpr)
+		return e.expr(n.X).(aggregate)[e.field(n)]
+
+	case ir.OSLICE, ir.OSLICESTR:
+		n := n.(*ir.SliceExpr)
+		x := e.expr(n.X)
+		var length, capacity int64
+		switch x := x.(type) {
+		case string:
+			length, capacity = int64(len(x)), int64(len(x))
+		case slice:
+			length, capacity = x.len, x.cap
+		}
+		low, high := int64(0), length
+		if n.Low != nil {
+			low = e.index(n.Low, capacity+1)
+		}
+		if n.High != nil {
+			high = e.index(n.High, capacity+1)
+		}
+		if low > high || n.Op() == ir.OSLICESTR && high > length {
+			e.fail()
+		}
+		switch x := x.(type) {
+		case string:
+			return x[low:high]
+		case slice:
+			if x.b == nil {
+				return x
+			}
+			return slice{x.b, x.off + low, high - low, x.cap - low}
+		}
+
+	case ir.OMAKESLICE:
+		n := n.(*ir.MakeExpr)
+		length := e.index(n.Len, math.MaxInt32)
+		capacity := length
+		if n.Cap != nil {
+			capacity = e.index(n.Cap, math.MaxInt32)
+		}
+		if length > capacity {
+			e.fail()
+		}
+		return e.newSlice(t, length, capacity)
+
+	case ir.OSLICELIT:
+		n := n.(*ir.CompLitExpr)
+		s := e.newSlice(t, n.Len, n.Len)
+		e.elems(n, s.b.elems)
+		return s
+
+	case ir.OARRAYLIT:
+		n := n.(*ir.CompLitExpr)
+		a := e.zero(t).(aggregate)
+		e.elems(n, a)
+		return a
+
+	case ir.OSTRUCTLIT:
+		n := n.(*ir.CompLitExpr)
+		a := e.zero(t).(aggregate)
+		for _, k := range n.List {
+			k := k.(*ir.StructKeyExpr)
+			for i, f := range t.FieldSlice() {
+				if f.Sym == k.Field {
+					store(&a[i], e.expr(k.Value))
+				}
+			}
+		}
+		return a
+
+	case ir.OCOPY:
+		n := n.(*ir.BinaryExpr)
+		dst := e.expr(n.X).(slice)
+		var src []value
+		switch y := e.expr(n.Y).(type) {
+		case string:
+			for i := 0; i < len(y) && int64(i) < dst.len; i++ {
+				src = append(src, uint64(y[i]))
+			}
+		case slice:
+			for i := int64(0); i < y.len && i < dst.len; i++ {
+				src = append(src, y.b.elems[y.off+i])
+			}
+		}
+		e.steps += len(src)
+		for i, v := range src {
+			store(&dst.b.elems[dst.off+int64(i)], v)
+		}
+		return int64(len(src))
+
+	case ir.OCALLFUNC:
+		vs := e.call(e.callee(n.(*ir.CallExpr)))
+		if len(vs) != 1 {
+			e.fail()
+		}
+		return vs[0]
+	}
+	e.fail()
+	panic("unreachable")
+}
+
+// elems stores the elements of the array or slice literal n in elems.
+func (e *evaluator) elems(n *ir.CompLitExpr, elems []value) {
+	var i int64
+	for _, x := range n.List {
+		if x.Op() == ir.OKEY {
+			k := x.(*ir.KeyExpr)
+			i = e.index(k.Key, int64(len(elems)))
+			x = k.Value
+		}
+		store(&elems[i], e.expr(x))
+		i++
+	}
+}
+
+// copy returns a copy of the value v, read from a variable, array or
+// slice element, or struct field. An aggregate is stored in place when
+// its variable is assigned, so its value must be copied when read, as
+// in a swap like a[0], a[1] = a[1], a[0]. Slices share their backing
+// array, as in Go.
+func (e *evaluator) copy(v value) value {
+	a, ok := v.(aggregate)
+	if !ok {
+		return v
+	}
+	e.steps += len(a)
+	e.step()
+	c := make(aggregate, len(a))
+	for i := range a {
+		c[i] = e.copy(a[i])
+	}
+	return c
+}
+
+// newSlice returns a slice of type t with a new backing array.
+func (e *evaluator) newSlice(t *types.Type, length, capacity int64) slice {
+	elems := make([]value, capacity)
+	for i := range elems {
+		elems[i] = e.zero(t.Elem())
+	}
+	return slice{&backing{elems}, 0, length, capacity}
+}
+
+// zero returns the zero value of type t.
+func (e *evaluator) zero(t *types.Type) value {
+	e.step()
+	switch {
+	case t.IsBoolean():
+		return false
+	case t.IsInteger():
+		return normInt(t, 0)
+	case t.IsFloat():
+		return float64(0)
+	case t.IsString():
+		return ""
+	case t.IsSlice():
+		return slice{}
+	case t.IsArray():
+		a := make(aggregate, t.NumElem())
+		for i := range a {
+			a[i] = e.zero(t.Elem())
+		}
+		return a
+	case t.IsStruct() && !t.IsFuncArgStruct():
+		fields := t.FieldSlice()
+		a := make(aggregate, len(fields))
+		for i, f := range fields {
+			a[i] = e.zero(f.Type)
+		}
+		return a
+	}
+	e.fail()
+	panic("unreachable")
+}
+
+// constant returns the value of the constant c of type t.
+func (e *evaluator) constant(t *types.Type, c constant.Value) value {
+	switch {
+	case t.IsBoolean():
+		return constant.BoolVal(c)
+	case t.IsInteger():
+		return normInt(t, uint64(ir.IntVal(t, c)))
+	case t.IsFloat():
+		f, _ := constant.Float64Val(c)
+		return roundFloat(t, f)
+	case t.IsString():
+		return constant.StringVal(c)
+	}
+	e.fail()
+	panic("unreachable")
+}
+
+// normInt returns the integer of type t whose bits are the low bits
+// of x, as an int64 if t is signed and a uint64 if not.
+func normInt(t *types.Type, x uint64) value {
+	shift := uint(64 - 8*t.Size())
+	if t.IsSigned() {
+		return int64(x<<shift) >> shift
+	}
+	return x << shift >> shift
+}
+
+// roundFloat rounds f to the precision of the floating-point type t.
+func roundFloat(t *types.Type, f float64) float64 {
+	if t.Kind() == types.TFLOAT32 {
+		return float64(float32(f))
+	}
+	return f
+}
+
+// convert converts x from type from to type to.
+func (e *evaluator) convert(to, from *types.Type, x value) value {
+	switch {
+	case from.IsInteger() && to.IsInteger():
+		switch x := x.(type) {
+		case int64:
+			return normInt(to, uint64(x))
+		case uint64:
+			return normInt(to, x)
+		}
+	case from.IsInteger() && to.IsFloat():
+		switch x := x.(type) {
+		case int64:
+			return roundFloat(to, float64(x))
+		case uint64:
+			return roundFloat(to, float64(x))
+		}
+	case from.IsFloat() && to.IsFloat():
+		return roundFloat(to, x.(float64))
+	case from.IsFloat() && to.IsInteger():
+		// Out-of-range conversions are implementation-specific;
+		// leave them to the target.
+		f := math.Trunc(x.(float64))
+		bits := 8 * to.Size()
+		if to.IsSigned() {
+			limit := math.Ldexp(1, int(bits-1))
+			if f >= -limit && f < limit {
+				return normInt(to, uint64(int64(f)))
+			}
+		} else if f >= 0 && f < math.Ldexp(1, int(bits)) {
+			return normInt(to, uint64(f))
+		}
+	case from.IsBoolean() && to.IsBoolean(), from.IsString() && to.IsString():
+		return x
+	}
+	e.fail()
+	panic("unreachable")
+}
+
+// binary returns x op y, where x has type t.
+func (e *evaluator) binary(op ir.Op, t *types.Type, x, y value) value {
+	if op == ir.OLSH || op == ir.ORSH {
+		var s uint64
+		switch y := y.(type) {
+		case int64:
+			if y < 0 {
+				e.fail()
+			}
+			s = uint64(y)
+		case uint64:
+			s = y
+		}
+		switch x := x.(type) {
+		case int64:
+			if op == ir.OLSH {
+				return normInt(t, uint64(x<<s))
+			}
+			return x >> s
+		case uint64:
+			if op == ir.OLSH {
+				return normInt(t, x<<s)
+			}
+			return x >> s
+		}
+		e.fail()
+	}
+
+	switch x := x.(type) {
+	case int64:
+		y := y.(int64)
+		var r int64
+		switch op {
+		case ir.OADD:
+			r = x + y
+		case ir.OSUB:
+			r = x - y
+		case ir.OMUL:
+			r = x * y
+		case ir.ODIV, ir.OMOD:
+			if y == 0 {
+				e.fail()
+			}
+			if op == ir.ODIV {
+				r = x / y
+			} else {
+				r = x % y
+			}
+		case ir.OAND:
+			r = x & y
+		case ir.OOR:
+			r = x | y
+		case ir.OXOR:
+			r = x ^ y
+		case ir.OANDNOT:
+			r = x &^ y
+		default:
+			e.fail()
+		}
+		return normInt(t, uint64(r))
+
+	case uint64:
+		y := y.(uint64)
+		var r uint64
+		switch op {
+		case ir.OADD:
+			r = x + y
+		case ir.OSUB:
+			r = x - y
+		case ir.OMUL:
+			r = x * y
+		case ir.ODIV, ir.OMOD:
+			if y == 0 {
+				e.fail()
+			}
+			if op == ir.ODIV {
+				r = x / y
+			} else {
+				r = x % y
+			}
+		case ir.OAND:
+			r = x & y
+		case ir.OOR:
+			r = x | y
+		case ir.OXOR:
+			r = x ^ y
+		case ir.OANDNOT:
+			r = x &^ y
+		default:
+			e.fail()
+		}
+		return normInt(t, r)
+
+	case float64:
+		y := y.(float64)
+		switch op {
+		case ir.OADD:
+			return roundFloat(t, x+y)
+		case ir.OSUB:
+			return roundFloat(t, x-y)
+		case ir.OMUL:
+			return roundFloat(t, x*y)
+		case ir.ODIV:
+			return roundFloat(t, x/y)
+		}
+
+	case string:
+		if op == ir.OADD {
+			s := x + y.(string)
+			e.steps += len(s)
+			return s
+		}
+	}
+	e.fail()
+	panic("unreachable")
+}
+
+// compare returns x op y for a comparison operator op.
+func (e *evaluator) compare(op ir.Op, x, y value) bool {
+	switch op {
+	case ir.OEQ:
+		return equal(x, y)
+	case ir.ONE:
+		return !equal(x, y)
+	}
+	var c int
+	switch x := x.(type) {
+	case int64:
+		y := y.(int64)
+		c = cmp(x < y, x > y)
+	case uint64:
+		y := y.(uint64)
+		c = cmp(x < y, x > y)
+	case float64:
+		y := y.(float64)
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return false
+		}
+		c = cmp(x < y, x > y)
+	case string:
+		y := y.(string)
+		c = cmp(x < y, x > y)
+	default:
+		e.fail()
+	}
+	switch op {
+	case ir.OLT:
+		return c < 0
+	case ir.OLE:
+		return c <= 0
+	case ir.OGT:
+		return c > 0
+	}
+	return c >= 0
+}
+
+func cmp(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return +1
+	}
+	return 0
+}
+
+// equal reports whether x == y. A slice can only be compared to nil.
+func equal(x, y value) bool {
+	switch x := x.(type) {
+	case aggregate:
+		y := y.(aggregate)
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case slice:
+		return (x.b == nil) == (y.(slice).b == nil)
+	}
+	return x == y
+}
+
+// isStatic reports whether v can be written to static data: its
+// floating-point numbers are finite and not negative zero, which
+// constants cannot represent, and none of its slices share a backing
+// array, which would need to be preserved. seen holds the backing
+// arrays encountered so far.
+func isStatic(v value, seen map[*backing]bool) bool {
+	switch v := v.(type) {
+	case float64:
+		return !math.IsInf(v, 0) && !math.IsNaN(v) && !(v == 0 && math.Signbit(v))
+	case aggregate:
+		for _, x := range v {
+			if !isStatic(x, seen) {
+				return false
+			}
+		}
+	case slice:
+		if v.b == nil {
+			return true
+		}
+		if seen[v.b] {
+			return false
+		}
+		seen[v.b] = true
+		for _, x := range v.b.elems[v.off : v.off+v.cap] {
+			if !isStatic(x, seen) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// initValue statically initializes l+loff, of type t, to v.
+func (s *Schedule) initValue(l *ir.Name, loff int64, t *types.Type, v value) {
+	types.CalcSize(t)
+	switch v := v.(type) {
+	case aggregate:
+		if t.IsArray() {
+			for i, x := range v {
+				s.initValue(l, loff+int64(i)*t.Elem().Width, t.Elem(), x)
+			}
+			return
+		}
+		for i, f := range t.FieldSlice() {
+			s.initValue(l, loff+f.Offset, f.Type, v[i])
+		}
+
+	case slice:
+		if v.b == nil {
+			return
+		}
+		ta := types.NewArray(t.Elem(), v.cap)
+		ta.SetNoalg(true)
+		a := StaticName(ta)
+		types.CalcSize(ta)
+		s.initValue(a, 0, ta, aggregate(v.b.elems[v.off:v.off+v.cap]))
+		staticdata.InitAddr(l, loff, a.Linksym())
+		lsym := l.Linksym()
+		lsym.WriteInt(base.Ctxt, loff+types.SliceLenOffset, types.PtrSize, v.len)
+		lsym.WriteInt(base.Ctxt, loff+types.SliceCapOffset, types.PtrSize, v.cap)
+
+	default:
+		var c constant.Value
+		switch v := v.(type) {
+		case bool:
+			c = constant.MakeBool(v)
+		case int64:
+			c = constant.MakeInt64(v)
+		case uint64:
+			c = constant.MakeUint64(v)
+		case float64:
+			c = constant.MakeFloat64(v)
+		case string:
+			c = constant.MakeString(v)
+		}
+		lit := ir.NewBasicLit(base.Pos, c)
+		lit.SetType(t)
+		if !ir.IsZero(lit) {
+			staticdata.InitConst(l, loff, lit, int(t.Width))
+		}
+	}
+}
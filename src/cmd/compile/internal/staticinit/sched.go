@@ -298,6 +298,20 @@ func (s *Schedule) StaticAssign(l *ir.Name, loff int64, r ir.Node, typ *types.Ty
 	case ir.OMAPLIT:
 		break
 
+	case ir.OCALLFUNC:
+		r := r.(*ir.CallExpr)
+		v, ok := evalCall(r)
+		if !ok {
+			break
+		}
+		if base.Debug.ConstExpr != 0 {
+			base.WarnfAt(r.Pos(), "evaluated call to %v at compile time", r.X)
+		}
+		if !ir.IsBlank(l) {
+			s.initValue(l, loff, r.Type(), v)
+		}
+		return true
+
 	case ir.OCLOSURE:
 		r := r.(*ir.ClosureExpr)
 		if ir.IsTrivialClosure(r) {
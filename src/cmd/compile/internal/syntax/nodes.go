@@ -395,10 +395,11 @@ type (
 	}
 
 	IfStmt struct {
-		Init SimpleStmt
-		Cond Expr
-		Then *BlockStmt
-		Else Stmt // either nil, *IfStmt, or *BlockStmt
+		Pragma Pragma
+		Init   SimpleStmt
+		Cond   Expr
+		Then   *BlockStmt
+		Else   Stmt // either nil, *IfStmt, or *BlockStmt
 		stmt
 	}
 
@@ -2350,6 +2350,15 @@ func (p *parser) stmtOrNil() Stmt {
 
 	case _Type:
 		return p.declStmt(p.typeDecl)
+
+	case _If:
+		// The pragma must be taken before parsing the
+		// if statement, which may contain statements
+		// of its own.
+		pragma := p.takePragma()
+		s := p.ifStmt()
+		s.Pragma = pragma
+		return s
 	}
 
 	p.clearPragma()
@@ -2378,9 +2387,6 @@ func (p *parser) stmtOrNil() Stmt {
 	case _Select:
 		return p.selectStmt()
 
-	case _If:
-		return p.ifStmt()
-
 	case _Fallthrough:
 		s := new(BranchStmt)
 		s.pos = p.pos()
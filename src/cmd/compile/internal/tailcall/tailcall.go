@@ -0,0 +1,198 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tailcall implements self-recursive tail call elimination,
+// which turns calls of a function to itself in tail position into
+// jumps back to the start of the function.
+package tailcall
+
+import (
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+)
+
+// Func rewrites the self-recursive tail calls in fn into loops, so
+// that
+//
+//	func f(n, acc int) int {
+//		if n == 0 {
+//			return acc
+//		}
+//		return f(n-1, acc*n)
+//	}
+//
+// becomes
+//
+//	func f(n, acc int) int {
+//	top:
+//		if n == 0 {
+//			return acc
+//		}
+//		n, acc = n-1, acc*n
+//		goto top
+//	}
+//
+// A call is in tail position if it is the operand of a return
+// statement, or, in a function without results, if it is followed by
+// a return statement or ends the function body.
+//
+// Func must run before escape analysis, which must see the loop.
+// Functions that defer calls or contain function literals are left
+// alone, as are functions that take the address of a parameter or
+// local variable, whose storage the loop would reuse while a pointer
+// from an earlier iteration may still refer to it.
+func Func(fn *ir.Func) {
+	if base.Flag.N != 0 || base.Flag.CompilingRuntime || fn.Pragma&ir.UintptrEscapes != 0 {
+		return
+	}
+	for _, n := range fn.Dcl {
+		if n.Addrtaken() {
+			return
+		}
+	}
+	if ir.Any(fn, func(n ir.Node) bool {
+		switch n.Op() {
+		case ir.ODEFER, ir.OCLOSURE:
+			return true
+		}
+		return false
+	}) {
+		return
+	}
+
+	t := &tailCalls{fn: fn}
+	if r := fn.Type().Recv(); r != nil {
+		t.params = append(t.params, r)
+	}
+	t.params = append(t.params, fn.Type().Params().FieldSlice()...)
+	ir.CurFunc = fn
+	t.stmts(fn.Body, true)
+	if t.label != nil {
+		fn.Body.Prepend(ir.NewLabelStmt(fn.Pos(), t.label))
+	}
+}
+
+type tailCalls struct {
+	fn     *ir.Func
+	params []*types.Field // including the receiver
+	label  *types.Sym     // start of the function, if needed
+}
+
+// stmts rewrites the tail calls in list. last reports whether the end
+// of list is the end of the function body.
+func (t *tailCalls) stmts(list ir.Nodes, last bool) {
+	for i, n := range list {
+		switch n.Op() {
+		case ir.ORETURN:
+			n := n.(*ir.ReturnStmt)
+			if len(n.Results) != 1 {
+				break
+			}
+			if call := t.selfCall(n.Results[0]); call != nil {
+				list[i] = t.jump(n.Init(), call)
+			}
+
+		case ir.OCALLFUNC, ir.OCALLMETH:
+			if t.fn.Type().NumResults() != 0 {
+				break
+			}
+			if i+1 < len(list) && list[i+1].Op() == ir.ORETURN || i+1 == len(list) && last {
+				if call := t.selfCall(n); call != nil {
+					list[i] = t.jump(nil, call)
+				}
+			}
+
+		case ir.OBLOCK:
+			n := n.(*ir.BlockStmt)
+			t.stmts(n.List, last && i+1 == len(list))
+		case ir.OIF:
+			n := n.(*ir.IfStmt)
+			t.stmts(n.Body, false)
+			t.stmts(n.Else, false)
+		case ir.OFOR, ir.OFORUNTIL:
+			n := n.(*ir.ForStmt)
+			t.stmts(n.Body, false)
+		case ir.ORANGE:
+			n := n.(*ir.RangeStmt)
+			t.stmts(n.Body, false)
+		case ir.OSWITCH:
+			n := n.(*ir.SwitchStmt)
+			for _, c := range n.Cases {
+				t.stmts(c.Body, false)
+			}
+		case ir.OSELECT:
+			n := n.(*ir.SelectStmt)
+			for _, c := range n.Cases {
+				t.stmts(c.Body, false)
+			}
+		}
+	}
+}
+
+// selfCall returns n if it is a call of t.fn to itself.
+func (t *tailCalls) selfCall(n ir.Node) *ir.CallExpr {
+	var callee *ir.Name
+	switch n.Op() {
+	case ir.OCALLFUNC:
+		n := n.(*ir.CallExpr)
+		switch n.X.Op() {
+		case ir.ONAME:
+			callee = n.X.(*ir.Name)
+		case ir.OMETHEXPR:
+			callee = ir.MethodExprName(n.X)
+		}
+	case ir.OCALLMETH:
+		n := n.(*ir.CallExpr)
+		callee = ir.MethodExprName(n.X)
+	}
+	if callee == nil || callee != t.fn.Nname {
+		return nil
+	}
+	return n.(*ir.CallExpr)
+}
+
+// jump returns the statements that replace the tail call: the
+// assignment of the arguments to the parameters, the zeroing of the
+// results, and the jump back to the start of the function.
+func (t *tailCalls) jump(init ir.Nodes, call *ir.CallExpr) ir.Node {
+	if base.Debug.TailCall != 0 {
+		base.WarnfAt(call.Pos(), "converted tail call to loop")
+	}
+	if t.label == nil {
+		t.label = typecheck.AutoLabel(".tail")
+	}
+	pos := call.Pos()
+
+	typecheck.FixVariadicCall(call)
+	var args []ir.Node
+	if call.Op() == ir.OCALLMETH {
+		args = append(args, call.X.(*ir.SelectorExpr).X)
+	}
+	args = append(args, call.Args...)
+	if len(args) != len(t.params) {
+		base.FatalfAt(pos, "tail call has %d arguments, want %d", len(args), len(t.params))
+	}
+
+	stmts := append([]ir.Node(nil), init...)
+	stmts = append(stmts, call.Init()...)
+	if len(args) > 0 {
+		lhs := make([]ir.Node, len(args))
+		for i, p := range t.params {
+			lhs[i] = ir.BlankNode
+			if p.Nname != nil && !ir.IsBlank(p.Nname.(*ir.Name)) {
+				lhs[i] = p.Nname.(*ir.Name)
+			}
+		}
+		stmts = append(stmts, ir.NewAssignListStmt(pos, ir.OAS2, lhs, args))
+	}
+	for _, r := range t.fn.Type().Results().FieldSlice() {
+		if r.Nname != nil && !ir.IsBlank(r.Nname.(*ir.Name)) {
+			stmts = append(stmts, ir.NewAssignStmt(pos, r.Nname.(*ir.Name), nil))
+		}
+	}
+	stmts = append(stmts, ir.NewBranchStmt(pos, ir.OGOTO, t.label))
+	return typecheck.Stmt(ir.NewBlockStmt(pos, stmts))
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAsmOffsets checks that -asmhdr writes the sizes and field
+// offsets of the types named by //go:asmoffsets.
+func TestAsmOffsets(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import "sync"
+
+//go:asmoffsets sync.WaitGroup Small
+
+type Small uint16
+
+type S struct {
+	A int64
+	B int32
+	_ int8
+}
+
+var _ sync.WaitGroup
+`
+	hdr := filepath.Join(t.TempDir(), "go_asm.h")
+	compileSource(t, src, false, "-asmhdr", hdr)
+	out, err := ioutil.ReadFile(hdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"#define S__size 16",
+		"#define S_A 0",
+		"#define S_B 8",
+		"#define Small__size 2",
+		"#define sync_WaitGroup__size 12",
+		"#define sync_WaitGroup_noCopy 0",
+		"#define sync_WaitGroup_state1 0",
+	}
+	lines := strings.Split(string(out), "\n")
+	for _, w := range want {
+		found := false
+		for _, l := range lines {
+			if l == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing %q in header:\n%s", w, out)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestAsmJSON checks that -S=json lists the instructions of each
+// function with their encodings and inlining stacks.
+func TestAsmJSON(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+//go:noinline
+func sink(x int) {}
+
+func small(x int) { sink(x) }
+
+func F(a int) { small(a) }
+`
+	_, out := compileSource(t, src, false, "-S=json")
+
+	type inst struct {
+		PC    int64
+		Op    string
+		Text  string
+		Pos   string
+		Bytes string
+		Inl   []struct {
+			Func string
+			Pos  string
+		}
+	}
+	type listing struct {
+		Func  string
+		Size  int64
+		Insts []inst
+	}
+	funcs := make(map[string]listing)
+	for _, line := range bytes.Split(bytes.TrimSpace(out), []byte("\n")) {
+		var l listing
+		if err := json.Unmarshal(line, &l); err != nil {
+			t.Fatalf("bad output line %q: %v", line, err)
+		}
+		funcs[l.Func] = l
+	}
+
+	f, ok := funcs["p.F"]
+	if !ok {
+		t.Fatalf("no listing of p.F in\n%s", out)
+	}
+	var size int64
+	inlined := false
+	for i, in := range f.Insts {
+		if i > 0 && in.PC < f.Insts[i-1].PC {
+			t.Errorf("instruction %d (%s) at pc %d before pc %d of the previous one", i, in.Text, in.PC, f.Insts[i-1].PC)
+		}
+		size += int64(len(in.Bytes) / 2)
+		if in.Op == "CALL" && len(in.Inl) == 1 && in.Inl[0].Func == "p.small" {
+			inlined = true
+		}
+	}
+	if size != f.Size {
+		t.Errorf("p.F: instructions have %d bytes, want size %d", size, f.Size)
+	}
+	if !inlined {
+		t.Errorf("p.F: no call inlined from p.small")
+	}
+}
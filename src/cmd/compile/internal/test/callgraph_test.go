@@ -0,0 +1,101 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCallGraph checks that -d=callgraph writes the calls of each kind
+// with the callees the compiler resolves.
+func TestCallGraph(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import "strings"
+
+type T struct{}
+
+func (T) M() {}
+
+type I interface{ M() }
+
+func F(i I, f func()) {
+	var j I = T{}
+	j.M()
+	T{}.M()
+	strings.ToUpper("x")
+	func() { G() }()
+	f()
+	i.M()
+}
+
+func G() {}
+`
+	dir := t.TempDir()
+	compile := func(out string) []byte {
+		compileSource(t, src, false, "-p=example.com/p", "-d=callgraph="+out)
+		b, err := ioutil.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	var g struct {
+		Funcs []struct {
+			ID   int
+			Name string
+			Pos  string
+		}
+		Calls []struct {
+			Caller, Callee int
+			Kind           string
+			Expr           string
+		}
+	}
+	b := compile(filepath.Join(dir, "g.json"))
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatalf("bad JSON output: %v\n%s", err, b)
+	}
+
+	name := func(id int) string {
+		if id < 0 {
+			return ""
+		}
+		return g.Funcs[id].Name
+	}
+	var got []string
+	for _, c := range g.Calls {
+		got = append(got, name(c.Caller)+" -> "+c.Kind+" "+name(c.Callee)+c.Expr)
+	}
+	want := []string{
+		"example.com/p.F -> devirtualized example.com/p.T.M",
+		"example.com/p.F -> method example.com/p.T.M",
+		"example.com/p.F -> direct strings.ToUpper",
+		"example.com/p.F -> direct example.com/p.F.func1",
+		"example.com/p.F -> dynamic f",
+		"example.com/p.F -> interface i.M",
+		"example.com/p.F.func1 -> direct example.com/p.G",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got calls:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	for _, f := range g.Funcs {
+		if local := strings.HasPrefix(f.Name, "example.com/p."); local != (f.Pos != "") {
+			t.Errorf("function %s has position %q", f.Name, f.Pos)
+		}
+	}
+
+	dot := string(compile(filepath.Join(dir, "g.dot")))
+	if !strings.HasPrefix(dot, "digraph ") || !strings.Contains(dot, `label="devirtualized"`) {
+		t.Errorf("bad dot output:\n%s", dot)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCaretColor checks that -color=always colors the -caret
+// underline. The uncolored output is tested by $GOROOT/test/caret.go.
+func TestCaretColor(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+func f() int {
+	return undefinedName
+}
+`
+	_, out := compileSource(t, src, true, "-caret", "-color=always")
+	want := "\treturn undefinedName\n" +
+		"\t       \x1b[1;31m^~~~~~~~~~~~~\x1b[0m\n"
+	if !strings.Contains(string(out), want) {
+		t.Errorf("got:\n%q\nwant to contain:\n%q", out, want)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestCodeSize checks that -d=codesize=2 attributes the code of
+// inlined calls to the inlined function.
+func TestCodeSize(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+//go:noinline
+func sink(x int) {}
+
+func small(x int) { sink(x) }
+
+func F(a, b int) {
+	small(a)
+	small(b)
+}
+
+func G(a int) { small(a) }
+`
+	_, out := compileSource(t, src, false, "-d=codesize=2")
+
+	type codeSize struct {
+		Func    string
+		Total   int64
+		Own     int64
+		Inlined int64
+		Copies  int
+	}
+	sizes := make(map[string]codeSize)
+	for _, line := range bytes.Split(bytes.TrimSpace(out), []byte("\n")) {
+		var cs codeSize
+		if err := json.Unmarshal(line, &cs); err != nil {
+			t.Fatalf("bad output line %q: %v", line, err)
+		}
+		if cs.Total != cs.Own+cs.Inlined {
+			t.Errorf("%s: total %d != own %d + inlined %d", cs.Func, cs.Total, cs.Own, cs.Inlined)
+		}
+		sizes[cs.Func] = cs
+	}
+
+	if cs := sizes["p.small"]; cs.Own == 0 || cs.Inlined == 0 || cs.Copies != 3 {
+		t.Errorf("p.small: got %+v, want own and inlined code in 3 copies", cs)
+	}
+	for _, name := range []string{"p.F", "p.G", "p.sink"} {
+		if cs := sizes[name]; cs.Own == 0 || cs.Inlined != 0 || cs.Copies != 0 {
+			t.Errorf("%s: got %+v, want only own code", name, cs)
+		}
+	}
+}
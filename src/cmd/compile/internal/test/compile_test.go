@@ -0,0 +1,52 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"internal/testenv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// compile runs the compiler with args and returns its combined output.
+// It compiles for GOARCH=amd64, so that sizes and offsets do not depend
+// on the host. The compilation must fail if wantErr is set, and succeed
+// otherwise.
+//
+// Tests of debugging flags whose output has source positions belong in
+// $GOROOT/test as errorcheck tests, and those whose output is the same
+// for every compilation as compile tests with a .out file. compile is
+// for the remaining ones.
+func compile(t *testing.T, wantErr bool, args ...string) []byte {
+	t.Helper()
+	testenv.MustHaveGoBuild(t)
+	cmd := exec.Command(testenv.GoToolPath(t), append([]string{"tool", "compile"}, args...)...)
+	cmd.Env = append(os.Environ(), "GOARCH=amd64")
+	out, err := cmd.CombinedOutput()
+	if wantErr && err == nil {
+		t.Fatalf("%v succeeded unexpectedly:\n%s", cmd, out)
+	}
+	if !wantErr && err != nil {
+		t.Fatalf("%v: %v\n%s", cmd, err, out)
+	}
+	return out
+}
+
+// compileSource writes src to p.go in a new temporary directory and
+// compiles it as package p with flags, as compile does. It returns the
+// path of p.go and the compiler's output.
+func compileSource(t *testing.T, src string, wantErr bool, flags ...string) (string, []byte) {
+	t.Helper()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "p.go")
+	if err := ioutil.WriteFile(file, []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	args := append([]string{"-p=p", "-o", filepath.Join(dir, "p.o")}, flags...)
+	return file, compile(t, wantErr, append(args, file)...)
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"internal/testenv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const coverSrc = `package main
+
+func f(n int) int {
+	if n > 5 {
+		return 1
+	}
+	for i := 0; i < n; i++ {
+		n--
+	}
+	return 0
+}
+
+func main() {
+	f(3)
+	f(10)
+}
+`
+
+func TestCoverMode(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	if runtime.GOOS != "linux" {
+		t.Skip("coverage profiles are only written on linux")
+	}
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "x.go")
+	if err := ioutil.WriteFile(src, []byte(coverSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The counts of the blocks starting at each line.
+	want := map[string]map[string]string{
+		"set":    {"4": "1", "5": "1", "7": "1", "8": "1", "10": "1", "14": "1"},
+		"count":  {"4": "2", "5": "1", "7": "1", "8": "2", "10": "1", "14": "1"},
+		"atomic": {"4": "2", "5": "1", "7": "1", "8": "2", "10": "1", "14": "1"},
+	}
+	for mode, counts := range want {
+		exe := filepath.Join(tmpdir, mode+".exe")
+		cmd := exec.Command(testenv.GoToolPath(t), "build", "-gcflags=-covermode="+mode, "-o", exe, src)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go build failed: %v\n%s", err, out)
+		}
+		profile := filepath.Join(tmpdir, mode+".out")
+		cmd = exec.Command(exe)
+		cmd.Env = append(os.Environ(), "GOCOVERPROFILE="+profile)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%s failed: %v\n%s", exe, err, out)
+		}
+		data, err := ioutil.ReadFile(profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		if lines[0] != "mode: "+mode {
+			t.Errorf("%s: got %q, want %q", mode, lines[0], "mode: "+mode)
+		}
+		got := map[string]string{}
+		for _, line := range lines[1:] {
+			// main/x.go:4.2,4.10 1 2
+			f := strings.Fields(line)
+			if len(f) != 3 || !strings.HasPrefix(f[0], "main/x.go:") {
+				t.Fatalf("%s: bad profile line %q", mode, line)
+			}
+			pos := strings.TrimPrefix(f[0], "main/x.go:")
+			got[pos[:strings.Index(pos, ".")]] = f[2]
+		}
+		for line, c := range counts {
+			if got[line] != c {
+				t.Errorf("%s: block at line %s ran %s times, want %s\n%s", mode, line, got[line], c, data)
+			}
+		}
+		if len(got) != len(counts) {
+			t.Errorf("%s: got %d blocks, want %d\n%s", mode, len(got), len(counts), data)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestEscapeGraph checks that -d=escapegraph writes a location graph
+// in which a variable that escapes through a chain of helpers can be
+// followed to the heap.
+func TestEscapeGraph(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+type T struct{ p *int }
+
+var sink *T
+
+func leaf(t *T) { sink = t }
+
+func mid(t *T) { leaf(t) }
+
+func F() {
+	x := 1
+	t := &T{p: &x}
+	mid(t)
+}
+`
+	dir := t.TempDir()
+	compile := func(out string) []byte {
+		compileSource(t, src, false, "-p=example.com/p", "-d=escapegraph="+out)
+		b, err := ioutil.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	var g struct {
+		Nodes []struct {
+			ID      int
+			Name    string
+			Func    string
+			Escapes bool
+		}
+		Edges []struct {
+			Src, Dst int
+			Derefs   int
+			Notes    []struct{ Pos, Why string }
+		}
+	}
+	b := compile(filepath.Join(dir, "g.json"))
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatalf("bad JSON output: %v\n%s", err, b)
+	}
+
+	// Follow the edges back from the heap to x in F.
+	x := -1
+	for _, n := range g.Nodes {
+		if n.Name == "x" && n.Func == "F" {
+			x = n.ID
+			if !n.Escapes {
+				t.Errorf("x is not marked as escaping")
+			}
+		}
+	}
+	if x < 0 {
+		t.Fatalf("no node for x in F:\n%s", b)
+	}
+	reached := map[int]bool{0: true}
+	for changed := true; changed; {
+		changed = false
+		for _, e := range g.Edges {
+			if reached[e.Dst] && !reached[e.Src] {
+				reached[e.Src] = true
+				changed = true
+			}
+			if e.Src == x && len(e.Notes) == 0 {
+				t.Errorf("edge from x has no notes")
+			}
+		}
+	}
+	if !reached[x] {
+		t.Errorf("x does not flow to the heap:\n%s", b)
+	}
+
+	dot := string(compile(filepath.Join(dir, "g.dot")))
+	for _, want := range []string{`digraph "escape example.com/p" {`, `[label="x\nF\n`, `label="&"`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot output does not contain %q:\n%s", want, dot)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestExplain checks that -explain prints the explanation of a code
+// and lists the known codes for an unknown one. The codes themselves
+// are tested by $GOROOT/test/errorcodes.go.
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	const want = `type-loop:
+
+A type refers to itself in a way that would make its values infinitely
+large. The notes list the types in the loop:
+
+	type T struct {
+		next T  // invalid recursive type T
+	}
+
+Break the loop with an indirection such as a pointer, slice or map:
+
+	type T struct {
+		next *T
+	}
+`
+	if out := compile(t, false, "-explain=type-loop"); string(out) != want {
+		t.Errorf("-explain=type-loop: got:\n%s\nwant:\n%s", out, want)
+	}
+
+	out := string(compile(t, true, "-explain=no-such-code"))
+	if !strings.HasPrefix(out, "compile: unknown diagnostic code \"no-such-code\"\n") {
+		t.Errorf("-explain=no-such-code: got:\n%s", out)
+	}
+	for _, code := range []string{"duplicate-method", "interface-too-large", "type-loop"} {
+		if !strings.Contains(out, "\n\t"+code+"\n") {
+			t.Errorf("-explain=no-such-code: %s not listed in:\n%s", code, out)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+// TestFuncHash checks that editing a function changes its -d=funchash
+// hash but not the hashes of the other functions of the package, and
+// that moving functions down by adding lines above them changes none.
+func TestFuncHash(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+%s
+func f(x int) int {
+	return g(x) + 1
+}
+
+func g(x int) int {
+	return x * %s
+}
+
+type T struct{ a int }
+
+func (t *T) M() int { return f(t.a) }
+`
+	re := regexp.MustCompile(`hash of (\S+): ([0-9a-f]+)`)
+	hashes := func(above, mul string) map[string]string {
+		_, out := compileSource(t, fmt.Sprintf(src, above, mul), false, "-d=funchash")
+		m := map[string]string{}
+		for _, match := range re.FindAllStringSubmatch(string(out), -1) {
+			m[match[1]] = match[2]
+		}
+		if len(m) != 3 {
+			t.Fatalf("want hashes of 3 functions, got:\n%s", out)
+		}
+		return m
+	}
+
+	h2, h3 := hashes("", "2"), hashes("", "3")
+	for _, fn := range []string{"f", "(*T).M"} {
+		if h2[fn] != h3[fn] {
+			t.Errorf("hash of %s changed from %s to %s", fn, h2[fn], h3[fn])
+		}
+	}
+	if h2["g"] == h3["g"] {
+		t.Errorf("hash of g did not change: %s", h2["g"])
+	}
+
+	moved := hashes("\nvar v int\n", "2")
+	for _, fn := range []string{"f", "g", "(*T).M"} {
+		if h2[fn] != moved[fn] {
+			t.Errorf("hash of %s changed from %s to %s by adding lines above it", fn, h2[fn], moved[fn])
+		}
+	}
+}
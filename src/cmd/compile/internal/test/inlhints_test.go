@@ -0,0 +1,81 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestInlineHints checks that the -inlinehints file can keep a function
+// from being inlined and can raise the budget of another one.
+func TestInlineHints(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+func Small(x int) int { return x + 1 }
+
+type T struct{ a [16]int }
+
+func (t *T) Sum() (s int) {
+	s += t.a[0] + t.a[1] + t.a[2] + t.a[3] + t.a[4] + t.a[5] + t.a[6] + t.a[7]
+	s += t.a[8] + t.a[9] + t.a[10] + t.a[11] + t.a[12] + t.a[13] + t.a[14] + t.a[15]
+	return s
+}
+
+func Large(t *T) int {
+	return t.a[0] + t.a[1] + t.a[2] + t.a[3] + t.a[4] + t.a[5] + t.a[6] + t.a[7] +
+		t.a[8] + t.a[9] + t.a[10] + t.a[11] + t.a[12] + t.a[13] + t.a[14] + t.a[15] + 1
+}
+
+func Use(t *T) int {
+	return Small(1) + t.Sum() + Large(t)
+}
+`
+	const hints = `# Hints for example.com/p.
+noinline example.com/p.Small
+inline example.com/p.(*T).Sum
+inline example.com/p.Large 90
+`
+	hintsFile := filepath.Join(t.TempDir(), "hints")
+	if err := ioutil.WriteFile(hintsFile, []byte(hints), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	compile := func(flags ...string) string {
+		_, out := compileSource(t, src, false, append([]string{"-p=example.com/p", "-m=2"}, flags...)...)
+		return string(out)
+	}
+
+	out := compile()
+	for _, want := range []string{
+		"can inline Small",
+		"cannot inline (*T).Sum: function too complex",
+		"cannot inline Large: function too complex",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("without hints, output does not contain %q:\n%s", want, out)
+		}
+	}
+
+	out = compile("-inlinehints=" + hintsFile)
+	for _, want := range []string{
+		"cannot inline Small: marked noinline by -inlinehints",
+		"can inline (*T).Sum with cost 84",
+		"can inline Large with cost 82",
+		"inlining call to (*T).Sum",
+		"inlining call to Large",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("with hints, output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "inlining call to Small") {
+		t.Errorf("with hints, Small was inlined:\n%s", out)
+	}
+}
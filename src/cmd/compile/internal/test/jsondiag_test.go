@@ -0,0 +1,218 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"sort"
+	"testing"
+)
+
+// TestJSONDiagnostics checks that -json=diagnostics prints each error
+// as a JSON object, with a code and related positions where known.
+func TestJSONDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import "os"
+
+type A struct{ b B }
+type B struct{ a A }
+
+func f() {
+	undefinedFunc()
+}
+`
+	_, out := compileSource(t, src, true, "-G=0", "-json=diagnostics")
+
+	type position struct {
+		Line, Col uint
+	}
+	type diag struct {
+		Severity string
+		Code     string
+		Range    struct{ Start, End position }
+		Message  string
+		Related  []struct {
+			Range   struct{ Start position }
+			Message string
+		}
+	}
+	var got []diag
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		var d diag
+		if err := json.Unmarshal(s.Bytes(), &d); err != nil {
+			t.Fatalf("bad output line %q: %v", s.Bytes(), err)
+		}
+		got = append(got, d)
+	}
+
+	want := []struct {
+		line    uint
+		code    string
+		message string
+		related []string
+	}{
+		{3, "unused", `imported and not used: "os"`, nil},
+		{5, "type-loop", "invalid recursive type A", []string{"A refers to", "B refers to", "A"}},
+		{9, "undefined", "undefined: undefinedFunc", nil},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d diagnostics, want %d:\n%s", len(got), len(want), out)
+	}
+	for i, w := range want {
+		d := got[i]
+		if d.Severity != "error" || d.Code != w.code || d.Message != w.message || d.Range.Start.Line != w.line || d.Range.End != d.Range.Start {
+			t.Errorf("diagnostic %d is %+v, want error %q at line %d with code %q", i, d, w.message, w.line, w.code)
+		}
+		if len(d.Related) != len(w.related) {
+			t.Errorf("diagnostic %d has %d related positions, want %d", i, len(d.Related), len(w.related))
+			continue
+		}
+		for j, r := range d.Related {
+			if r.Message != w.related[j] || r.Range.Start.Line == 0 {
+				t.Errorf("diagnostic %d: related %d is %+v, want %q", i, j, r, w.related[j])
+			}
+		}
+	}
+}
+
+// TestJSONSuggestedFixes checks that -json=diagnostics suggests fixes
+// for mechanical errors, and that applying them yields a valid
+// program.
+func TestJSONSuggestedFixes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		src, want string
+	}{
+		{
+			src: `package p
+
+import (
+	"fmt"
+	"os" // for Exit
+)
+
+import "strings"
+`,
+			want: `package p
+
+import (
+)
+
+`,
+		},
+		{
+			src: `package p
+
+func f(i interface{}) {
+	x := 1
+	var y int
+	a, b := 1, 2
+	_ = a
+	for k, v := range []int{} {
+		_ = v
+	}
+	switch z := i.(type) {
+	case int:
+	}
+}
+`,
+			want: `package p
+
+func f(i interface{}) {
+	_ = 1
+	var _ int
+	a, _ := 1, 2
+	_ = a
+	for _, v := range []int{} {
+		_ = v
+	}
+	switch i.(type) {
+	case int:
+	}
+}
+`,
+		},
+		{
+			src: `package p
+
+type Celsius float64
+
+type T struct{ Name string }
+
+func (T) Hello() {}
+
+func f(f float64) Celsius {
+	var t T
+	t.hello()
+	_ = T{name: "x"}
+	return f
+}
+`,
+			want: `package p
+
+type Celsius float64
+
+type T struct{ Name string }
+
+func (T) Hello() {}
+
+func f(f float64) Celsius {
+	var t T
+	t.Hello()
+	_ = T{Name: "x"}
+	return Celsius(f)
+}
+`,
+		},
+	}
+	for i, test := range tests {
+		file, out := compileSource(t, test.src, true, "-G=0", "-json=diagnostics")
+
+		type edit struct {
+			File       string
+			Start, End int
+			NewText    string
+		}
+		var edits []edit
+		s := bufio.NewScanner(bytes.NewReader(out))
+		for s.Scan() {
+			var d struct {
+				Message        string
+				SuggestedFixes []struct{ Edits []edit }
+			}
+			if err := json.Unmarshal(s.Bytes(), &d); err != nil {
+				t.Fatalf("%d: bad output line %q: %v", i, s.Bytes(), err)
+			}
+			if len(d.SuggestedFixes) != 1 {
+				t.Errorf("%d: got %d fixes for %q, want 1", i, len(d.SuggestedFixes), d.Message)
+				continue
+			}
+			edits = append(edits, d.SuggestedFixes[0].Edits...)
+		}
+
+		// Apply the edits from the end of the file, so that each
+		// leaves the offsets of the others unchanged.
+		sort.Slice(edits, func(i, j int) bool { return edits[i].Start > edits[j].Start })
+		src := test.src
+		for _, e := range edits {
+			if e.File != file || e.Start > e.End || e.End > len(src) {
+				t.Fatalf("%d: bad edit %+v", i, e)
+			}
+			src = src[:e.Start] + e.NewText + src[e.End:]
+		}
+		if src != test.want {
+			t.Errorf("%d: fixed source is:\n%s\nwant:\n%s", i, src, test.want)
+			continue
+		}
+		compileSource(t, src, false, "-G=0")
+	}
+}
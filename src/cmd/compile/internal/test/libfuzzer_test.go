@@ -0,0 +1,67 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestLibfuzzerFakePC checks that the fake PCs passed to the libfuzzer
+// comparison hooks do not change between builds of the same source,
+// and that they tell the comparisons apart.
+func TestLibfuzzerFakePC(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+func F(a, b string, x, y int) bool {
+	return a == b || x < y || x > 3
+}
+`
+	immRE := regexp.MustCompile(`\tMOV[LQ]\t\$(\d+), `)
+	hookRE := regexp.MustCompile(`\tCALL\truntime\.libfuzzer(HookStrCmp|Trace(Const)?Cmp8)\(SB\)`)
+	fakePCs := func() []string {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "p.go")
+		if err := ioutil.WriteFile(file, []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+		out := compile(t, false, "-p=p", "-o", filepath.Join(dir, "p.o"), "-trimpath="+dir, "-d=libfuzzer", "-S", file)
+		// The fake PC is the last argument to each hook, so it is
+		// the last constant loaded before the call.
+		var pcs []string
+		var last string
+		for _, line := range strings.Split(string(out), "\n") {
+			if m := immRE.FindStringSubmatch(line); m != nil {
+				last = m[1]
+			}
+			if hookRE.MatchString(line) {
+				pcs = append(pcs, last)
+			}
+		}
+		if len(pcs) != 4 {
+			t.Fatalf("found fake PCs %q, want 4 (hook for a == b, len compare, x < y, x > 3); output:\n%s", pcs, out)
+		}
+		return pcs
+	}
+
+	pcs1 := fakePCs()
+	pcs2 := fakePCs()
+	if !reflect.DeepEqual(pcs1, pcs2) {
+		t.Errorf("fake PCs differ between builds: %q and %q", pcs1, pcs2)
+	}
+	seen := make(map[string]bool)
+	for _, pc := range pcs1 {
+		if seen[pc] {
+			t.Errorf("fake PC %s used for more than one comparison: %q", pc, pcs1)
+		}
+		seen[pc] = true
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"internal/testenv"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// TestLinknameSigs checks that the compiler records the signatures
+// of linknamed functions, and only those, for the linker to check.
+func TestLinknameSigs(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import _ "unsafe"
+
+func A() int { return 1 }
+
+func B(x int) int { return x + A() }
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+`
+	file, _ := compileSource(t, src, false)
+	out, err := exec.Command(testenv.GoToolPath(t), "tool", "nm", filepath.Join(filepath.Dir(file), "p.o")).CombinedOutput()
+	if err != nil {
+		t.Fatalf("go tool nm: %v\n%s", err, out)
+	}
+	var syms []string
+	for _, m := range regexp.MustCompile(`(?m) R (go\.linkname\..*)$`).FindAllSubmatch(out, -1) {
+		syms = append(syms, string(m[1]))
+	}
+	if len(syms) != 1 || syms[0] != "go.linkname.runtime.nanotime" {
+		t.Errorf("got linkname signatures %q, want only go.linkname.runtime.nanotime; nm output:\n%s", syms, out)
+	}
+}
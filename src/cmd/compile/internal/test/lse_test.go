@@ -0,0 +1,51 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"internal/testenv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestARM64LSE checks that with a GOARM64 setting that selects the
+// LSE atomic instructions, atomic operations use them directly rather
+// than checking for them at run time.
+func TestARM64LSE(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	const src = `package p
+
+import "sync/atomic"
+
+func add(p *int32) int32 { return atomic.AddInt32(p, 1) }
+`
+	dir := t.TempDir()
+	file := filepath.Join(dir, "p.go")
+	if err := ioutil.WriteFile(file, []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	for _, goarm64 := range []string{"v8.0", "v8.0,lse", "v8.1"} {
+		cmd := exec.Command(testenv.GoToolPath(t), "build", "-gcflags=-S", "-o", os.DevNull, file)
+		cmd.Env = append(os.Environ(), "GOOS=linux", "GOARCH=arm64", "GOARM64="+goarm64)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("GOARM64=%s: build failed: %v\n%s", goarm64, err, out)
+		}
+		lse := goarm64 != "v8.0"
+		checked := strings.Contains(string(out), "arm64HasATOMICS")
+		if !strings.Contains(string(out), "LDADDALW") {
+			t.Errorf("GOARM64=%s: no LDADDALW instruction in:\n%s", goarm64, out)
+		}
+		if checked == lse {
+			t.Errorf("GOARM64=%s: run-time check for atomic instructions is %v, want %v:\n%s", goarm64, checked, !lse, out)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMaxErrors checks the default limit on the number of errors,
+// which errorcheck tests cannot see because they always pass -e, and
+// that -errorsort orders errors by file name rather than by the order
+// of the files on the command line. An explicit -maxerrors is tested
+// by $GOROOT/test/maxerrors.go.
+func TestMaxErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := func(name string) string { return filepath.Join(dir, name+".go") }
+	var files []string
+	for _, name := range []string{"c", "b", "a"} {
+		var src strings.Builder
+		src.WriteString("package p\n")
+		for i := 0; i < 5; i++ {
+			fmt.Fprintf(&src, "var _ = %s%d\n", name, i)
+		}
+		if err := ioutil.WriteFile(path(name), []byte(src.String()), 0666); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, path(name))
+	}
+	// undefined returns the errors in the named files, in order.
+	undefined := func(names ...string) []string {
+		var errs []string
+		for _, name := range names {
+			for i := 0; i < 5; i++ {
+				errs = append(errs, fmt.Sprintf("%s:%d:9: undefined: %s%d", path(name), i+2, name, i))
+			}
+		}
+		return errs
+	}
+
+	for _, test := range []struct {
+		flags []string
+		want  []string
+	}{
+		{nil, append(undefined("c", "b"), path("b")+":6:9: too many errors")},
+		{[]string{"-e"}, undefined("c", "b", "a")},
+		{[]string{"-maxerrors=0", "-errorsort"}, undefined("a", "b", "c")},
+	} {
+		args := append([]string{"-p=p", "-o", filepath.Join(dir, "p.o")}, test.flags...)
+		out := compile(t, true, append(args, files...)...)
+		want := strings.Join(test.want, "\n") + "\n"
+		if string(out) != want {
+			t.Errorf("%v: got:\n%s\nwant:\n%s", test.flags, out, want)
+		}
+	}
+}
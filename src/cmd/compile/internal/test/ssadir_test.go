@@ -0,0 +1,78 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const ssaDirSrc = `
+package p
+
+func Add(x, y int) int { return x + y }
+
+type T struct{ n int }
+
+func (t *T) Inc() { t.n++ }
+`
+
+// TestSSADir checks that -d=ssadir writes the final SSA of each
+// function to its own file, and with ssa/PASS/dir, the SSA after PASS.
+func TestSSADir(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		flags string
+		want  map[string][]string // function -> regexps, in order
+	}{
+		{
+			flags: "",
+			want: map[string][]string{
+				"Add":      {`^Add func\(int, int\) int$`, `= ADDQ <int> v\d+ v\d+ : AX$`},
+				"(_T).Inc": {`= ADDQconstmodify <mem> \[val=1,off=0\] v\d+ v\d+$`},
+			},
+		},
+		{
+			flags: ",ssa/lower/dir,ssa/regalloc/dir",
+			want: map[string][]string{
+				"Add": {
+					`^# after lower$`, `= ADDQ <int> v\d+ v\d+$`,
+					`^# after regalloc$`, `= ADDQ <int> v\d+ v\d+ : AX$`,
+				},
+				"(_T).Inc": {
+					`^# after lower$`, `= ADDQconstmodify <mem> \[val=1,off=0\] v\d+ v\d+$`,
+					`^# after regalloc$`,
+				},
+			},
+		},
+	} {
+		dir := t.TempDir()
+		compileSource(t, ssaDirSrc, false, "-p=example.com/p", "-d=ssadir="+dir+tc.flags)
+		for fn, res := range tc.want {
+			b, err := ioutil.ReadFile(filepath.Join(dir, "example.com", "p", fn+".ssa"))
+			if err != nil {
+				t.Errorf("-d=ssadir%s: %v", tc.flags, err)
+				continue
+			}
+			text := string(b)
+			if got := strings.Count(text, "# after "); tc.flags == "" && got != 0 {
+				t.Errorf("-d=ssadir%s: %s has %d pass headers, want none:\n%s", tc.flags, fn, got, text)
+			}
+			rest := text
+			for _, re := range res {
+				loc := regexp.MustCompile("(?m)" + re).FindStringIndex(rest)
+				if loc == nil {
+					t.Errorf("-d=ssadir%s: %s does not match %#q after the previous patterns:\n%s", tc.flags, fn, re, text)
+					break
+				}
+				rest = rest[loc[1]:]
+			}
+		}
+	}
+}
@@ -195,10 +195,93 @@ func testLoadHitStore(t *testing.T) {
 	}
 }
 
+type memCombineBytes struct {
+	a, b, c, d, e, f, g, h byte
+}
+
+//go:noinline
+func storeLE32_ssa(p *memCombineBytes, x uint32) {
+	p.a, p.b, p.c, p.d = byte(x), byte(x>>8), byte(x>>16), byte(x>>24)
+}
+
+//go:noinline
+func storeBE32_ssa(p *memCombineBytes, x uint32) {
+	p.a, p.b, p.c, p.d = byte(x>>24), byte(x>>16), byte(x>>8), byte(x)
+}
+
+//go:noinline
+func storeHigh_ssa(p *memCombineBytes, x int64) {
+	p.e, p.f, p.g, p.h = byte(x>>32), byte(x>>40), byte(x>>48), byte(x>>56)
+}
+
+//go:noinline
+func storeConst_ssa(p *memCombineBytes) {
+	p.c, p.b, p.a, p.d = 0xc, 0xb, 0xa, 0xd
+}
+
+//go:noinline
+func storeRead_ssa(p *memCombineBytes, x uint16) byte {
+	p.a = byte(x)
+	r := p.a
+	p.b = byte(x >> 8)
+	return r
+}
+
+//go:noinline
+func loadLE64_ssa(p *memCombineBytes) uint64 {
+	return uint64(p.a) | uint64(p.b)<<8 | uint64(p.c)<<16 | uint64(p.d)<<24 |
+		uint64(p.e)<<32 | uint64(p.f)<<40 | uint64(p.g)<<48 | uint64(p.h)<<56
+}
+
+//go:noinline
+func loadBE32_ssa(p *memCombineBytes) uint32 {
+	return uint32(p.d) | uint32(p.c)<<8 | uint32(p.b)<<16 | uint32(p.a)<<24
+}
+
+//go:noinline
+func loadShifted_ssa(p *memCombineBytes) uint64 {
+	return uint64(p.b)<<16 | uint64(p.c)<<24
+}
+
+func testMemCombine(t *testing.T) {
+	var p memCombineBytes
+	storeLE32_ssa(&p, 0x04030201)
+	if want := (memCombineBytes{a: 1, b: 2, c: 3, d: 4}); p != want {
+		t.Errorf("storeLE32 = %v, want %v", p, want)
+	}
+	storeBE32_ssa(&p, 0x04030201)
+	if want := (memCombineBytes{a: 4, b: 3, c: 2, d: 1}); p != want {
+		t.Errorf("storeBE32 = %v, want %v", p, want)
+	}
+	storeHigh_ssa(&p, -0x0102030405060708)
+	if want := (memCombineBytes{a: 4, b: 3, c: 2, d: 1, e: 0xfb, f: 0xfc, g: 0xfd, h: 0xfe}); p != want {
+		t.Errorf("storeHigh = %v, want %v", p, want)
+	}
+	storeConst_ssa(&p)
+	if want := (memCombineBytes{a: 0xa, b: 0xb, c: 0xc, d: 0xd, e: 0xfb, f: 0xfc, g: 0xfd, h: 0xfe}); p != want {
+		t.Errorf("storeConst = %v, want %v", p, want)
+	}
+	if got := storeRead_ssa(&p, 0x1234); got != 0x34 || p.a != 0x34 || p.b != 0x12 {
+		t.Errorf("storeRead = %#x, %v, want 0x34", got, p)
+	}
+
+	p = memCombineBytes{1, 2, 3, 4, 5, 6, 7, 8}
+	if got, want := loadLE64_ssa(&p), uint64(0x0807060504030201); got != want {
+		t.Errorf("loadLE64 = %#x, want %#x", got, want)
+	}
+	if got, want := loadBE32_ssa(&p), uint32(0x01020304); got != want {
+		t.Errorf("loadBE32 = %#x, want %#x", got, want)
+	}
+	if got, want := loadShifted_ssa(&p), uint64(0x03020000); got != want {
+		t.Errorf("loadShifted = %#x, want %#x", got, want)
+	}
+}
+
 func TestLoadStore(t *testing.T) {
 	testLoadStoreOrder(t)
 	testStoreSize(t)
 	testExtStore(t)
 	testDeadStorePanic(t)
 	testLoadHitStore(t)
+	testMemCombine(t)
 }
@@ -80,124 +80,135 @@ var runtimeDecls = [...]struct {
 	{"convT2Enoptr", funcTag, 59},
 	{"convT2I", funcTag, 59},
 	{"convT2Inoptr", funcTag, 59},
-	{"assertE2I", funcTag, 60},
+	{"lazyitab", funcTag, 61},
+	{"assertE2I", funcTag, 62},
 	{"assertE2I2", funcTag, 57},
-	{"assertI2I", funcTag, 60},
+	{"assertI2I", funcTag, 62},
 	{"assertI2I2", funcTag, 57},
-	{"panicdottypeE", funcTag, 61},
-	{"panicdottypeI", funcTag, 61},
-	{"panicnildottype", funcTag, 62},
-	{"ifaceeq", funcTag, 64},
-	{"efaceeq", funcTag, 64},
-	{"fastrand", funcTag, 66},
-	{"makemap64", funcTag, 68},
-	{"makemap", funcTag, 69},
-	{"makemap_small", funcTag, 70},
-	{"mapaccess1", funcTag, 71},
-	{"mapaccess1_fast32", funcTag, 72},
-	{"mapaccess1_fast64", funcTag, 72},
-	{"mapaccess1_faststr", funcTag, 72},
-	{"mapaccess1_fat", funcTag, 73},
-	{"mapaccess2", funcTag, 74},
-	{"mapaccess2_fast32", funcTag, 75},
-	{"mapaccess2_fast64", funcTag, 75},
-	{"mapaccess2_faststr", funcTag, 75},
-	{"mapaccess2_fat", funcTag, 76},
-	{"mapassign", funcTag, 71},
-	{"mapassign_fast32", funcTag, 72},
-	{"mapassign_fast32ptr", funcTag, 72},
-	{"mapassign_fast64", funcTag, 72},
-	{"mapassign_fast64ptr", funcTag, 72},
-	{"mapassign_faststr", funcTag, 72},
-	{"mapiterinit", funcTag, 77},
-	{"mapdelete", funcTag, 77},
-	{"mapdelete_fast32", funcTag, 78},
-	{"mapdelete_fast64", funcTag, 78},
-	{"mapdelete_faststr", funcTag, 78},
-	{"mapiternext", funcTag, 79},
-	{"mapclear", funcTag, 80},
-	{"makechan64", funcTag, 82},
-	{"makechan", funcTag, 83},
-	{"chanrecv1", funcTag, 85},
-	{"chanrecv2", funcTag, 86},
-	{"chansend1", funcTag, 88},
+	{"panicdottypeE", funcTag, 63},
+	{"panicdottypeI", funcTag, 63},
+	{"panicnildottype", funcTag, 64},
+	{"ifaceeq", funcTag, 66},
+	{"efaceeq", funcTag, 66},
+	{"fastrand", funcTag, 68},
+	{"makemap64", funcTag, 70},
+	{"makemap", funcTag, 71},
+	{"makemap_small", funcTag, 72},
+	{"mapaccess1", funcTag, 73},
+	{"mapaccess1_fast32", funcTag, 74},
+	{"mapaccess1_fast64", funcTag, 74},
+	{"mapaccess1_faststr", funcTag, 74},
+	{"mapaccess1_fat", funcTag, 75},
+	{"mapaccess2", funcTag, 76},
+	{"mapaccess2_fast32", funcTag, 77},
+	{"mapaccess2_fast64", funcTag, 77},
+	{"mapaccess2_faststr", funcTag, 77},
+	{"mapaccess2_fat", funcTag, 78},
+	{"mapassign", funcTag, 73},
+	{"mapassign_fast32", funcTag, 74},
+	{"mapassign_fast32ptr", funcTag, 74},
+	{"mapassign_fast64", funcTag, 74},
+	{"mapassign_fast64ptr", funcTag, 74},
+	{"mapassign_faststr", funcTag, 74},
+	{"mapiterinit", funcTag, 79},
+	{"mapdelete", funcTag, 79},
+	{"mapdelete_fast32", funcTag, 80},
+	{"mapdelete_fast64", funcTag, 80},
+	{"mapdelete_faststr", funcTag, 80},
+	{"mapiternext", funcTag, 81},
+	{"mapclear", funcTag, 82},
+	{"mapinitbulk", funcTag, 83},
+	{"staticmapindex", funcTag, 85},
+	{"makechan64", funcTag, 87},
+	{"makechan", funcTag, 88},
+	{"chanrecv1", funcTag, 90},
+	{"chanrecv2", funcTag, 91},
+	{"chansend1", funcTag, 93},
 	{"closechan", funcTag, 30},
-	{"writeBarrier", varTag, 90},
-	{"typedmemmove", funcTag, 91},
-	{"typedmemclr", funcTag, 92},
-	{"typedslicecopy", funcTag, 93},
-	{"selectnbsend", funcTag, 94},
-	{"selectnbrecv", funcTag, 95},
-	{"selectsetpc", funcTag, 96},
-	{"selectgo", funcTag, 97},
+	{"writeBarrier", varTag, 95},
+	{"typedmemmove", funcTag, 96},
+	{"typedmemclr", funcTag, 97},
+	{"typedslicecopy", funcTag, 98},
+	{"selectnbsend", funcTag, 99},
+	{"selectnbrecv", funcTag, 100},
+	{"selectsetpc", funcTag, 101},
+	{"selectgo", funcTag, 102},
 	{"block", funcTag, 9},
-	{"makeslice", funcTag, 98},
-	{"makeslice64", funcTag, 99},
-	{"makeslicecopy", funcTag, 100},
-	{"growslice", funcTag, 102},
-	{"memmove", funcTag, 103},
-	{"memclrNoHeapPointers", funcTag, 104},
-	{"memclrHasPointers", funcTag, 104},
-	{"memequal", funcTag, 105},
-	{"memequal0", funcTag, 106},
-	{"memequal8", funcTag, 106},
-	{"memequal16", funcTag, 106},
-	{"memequal32", funcTag, 106},
-	{"memequal64", funcTag, 106},
-	{"memequal128", funcTag, 106},
-	{"f32equal", funcTag, 107},
-	{"f64equal", funcTag, 107},
-	{"c64equal", funcTag, 107},
-	{"c128equal", funcTag, 107},
-	{"strequal", funcTag, 107},
-	{"interequal", funcTag, 107},
-	{"nilinterequal", funcTag, 107},
-	{"memhash", funcTag, 108},
-	{"memhash0", funcTag, 109},
-	{"memhash8", funcTag, 109},
-	{"memhash16", funcTag, 109},
-	{"memhash32", funcTag, 109},
-	{"memhash64", funcTag, 109},
-	{"memhash128", funcTag, 109},
-	{"f32hash", funcTag, 109},
-	{"f64hash", funcTag, 109},
-	{"c64hash", funcTag, 109},
-	{"c128hash", funcTag, 109},
-	{"strhash", funcTag, 109},
-	{"interhash", funcTag, 109},
-	{"nilinterhash", funcTag, 109},
-	{"int64div", funcTag, 110},
-	{"uint64div", funcTag, 111},
-	{"int64mod", funcTag, 110},
-	{"uint64mod", funcTag, 111},
-	{"float64toint64", funcTag, 112},
-	{"float64touint64", funcTag, 113},
-	{"float64touint32", funcTag, 114},
-	{"int64tofloat64", funcTag, 115},
-	{"uint64tofloat64", funcTag, 116},
-	{"uint32tofloat64", funcTag, 117},
-	{"complex128div", funcTag, 118},
-	{"getcallerpc", funcTag, 119},
-	{"getcallersp", funcTag, 119},
+	{"makeslice", funcTag, 103},
+	{"makeslice64", funcTag, 104},
+	{"makeslicecopy", funcTag, 105},
+	{"growslice", funcTag, 107},
+	{"memmove", funcTag, 108},
+	{"memclrNoHeapPointers", funcTag, 109},
+	{"memclrHasPointers", funcTag, 109},
+	{"loopmove", funcTag, 110},
+	{"loopset8", funcTag, 112},
+	{"loopxor8", funcTag, 113},
+	{"loopsum8", funcTag, 114},
+	{"memequal", funcTag, 115},
+	{"memequal0", funcTag, 116},
+	{"memequal8", funcTag, 116},
+	{"memequal16", funcTag, 116},
+	{"memequal32", funcTag, 116},
+	{"memequal64", funcTag, 116},
+	{"memequal128", funcTag, 116},
+	{"f32equal", funcTag, 117},
+	{"f64equal", funcTag, 117},
+	{"c64equal", funcTag, 117},
+	{"c128equal", funcTag, 117},
+	{"strequal", funcTag, 117},
+	{"interequal", funcTag, 117},
+	{"nilinterequal", funcTag, 117},
+	{"memhash", funcTag, 118},
+	{"memhash0", funcTag, 119},
+	{"memhash8", funcTag, 119},
+	{"memhash16", funcTag, 119},
+	{"memhash32", funcTag, 119},
+	{"memhash64", funcTag, 119},
+	{"memhash128", funcTag, 119},
+	{"f32hash", funcTag, 119},
+	{"f64hash", funcTag, 119},
+	{"c64hash", funcTag, 119},
+	{"c128hash", funcTag, 119},
+	{"strhash", funcTag, 119},
+	{"interhash", funcTag, 119},
+	{"nilinterhash", funcTag, 119},
+	{"int64div", funcTag, 120},
+	{"uint64div", funcTag, 121},
+	{"int64mod", funcTag, 120},
+	{"uint64mod", funcTag, 121},
+	{"float64toint64", funcTag, 122},
+	{"float64touint64", funcTag, 123},
+	{"float64touint32", funcTag, 124},
+	{"int64tofloat64", funcTag, 125},
+	{"uint64tofloat64", funcTag, 126},
+	{"uint32tofloat64", funcTag, 127},
+	{"complex128div", funcTag, 128},
+	{"getcallerpc", funcTag, 129},
+	{"getcallersp", funcTag, 129},
 	{"racefuncenter", funcTag, 31},
 	{"racefuncexit", funcTag, 9},
 	{"raceread", funcTag, 31},
 	{"racewrite", funcTag, 31},
-	{"racereadrange", funcTag, 120},
-	{"racewriterange", funcTag, 120},
-	{"msanread", funcTag, 120},
-	{"msanwrite", funcTag, 120},
-	{"msanmove", funcTag, 121},
-	{"checkptrAlignment", funcTag, 122},
-	{"checkptrArithmetic", funcTag, 124},
-	{"libfuzzerTraceCmp1", funcTag, 126},
-	{"libfuzzerTraceCmp2", funcTag, 128},
-	{"libfuzzerTraceCmp4", funcTag, 129},
-	{"libfuzzerTraceCmp8", funcTag, 130},
-	{"libfuzzerTraceConstCmp1", funcTag, 126},
-	{"libfuzzerTraceConstCmp2", funcTag, 128},
-	{"libfuzzerTraceConstCmp4", funcTag, 129},
-	{"libfuzzerTraceConstCmp8", funcTag, 130},
+	{"racereadrange", funcTag, 130},
+	{"racewriterange", funcTag, 130},
+	{"msanread", funcTag, 130},
+	{"msanwrite", funcTag, 130},
+	{"msanmove", funcTag, 131},
+	{"asanread", funcTag, 130},
+	{"asanwrite", funcTag, 130},
+	{"checkptrAlignment", funcTag, 132},
+	{"checkptrArithmetic", funcTag, 134},
+	{"libfuzzerTraceCmp1", funcTag, 135},
+	{"libfuzzerTraceCmp2", funcTag, 137},
+	{"libfuzzerTraceCmp4", funcTag, 138},
+	{"libfuzzerTraceCmp8", funcTag, 139},
+	{"libfuzzerTraceConstCmp1", funcTag, 135},
+	{"libfuzzerTraceConstCmp2", funcTag, 137},
+	{"libfuzzerTraceConstCmp4", funcTag, 138},
+	{"libfuzzerTraceConstCmp8", funcTag, 139},
+	{"libfuzzerHookStrCmp", funcTag, 140},
+	{"coverIncAtomic", funcTag, 142},
 	{"x86HasPOPCNT", varTag, 6},
 	{"x86HasSSE41", varTag, 6},
 	{"x86HasFMA", varTag, 6},
@@ -220,7 +231,7 @@ func params(tlist ...*types.Type) []*types.Field {
 }
 
 func runtimeTypes() []*types.Type {
-	var typs [131]*types.Type
+	var typs [143]*types.Type
 	typs[0] = types.ByteType
 	typs[1] = types.NewPtr(typs[0])
 	typs[2] = types.Types[types.TANY]
@@ -281,76 +292,88 @@ func runtimeTypes() []*types.Type {
 	typs[57] = newSig(params(typs[1], typs[2]), params(typs[2]))
 	typs[58] = newSig(params(typs[2]), params(typs[7]))
 	typs[59] = newSig(params(typs[1], typs[3]), params(typs[2]))
-	typs[60] = newSig(params(typs[1], typs[1]), params(typs[1]))
-	typs[61] = newSig(params(typs[1], typs[1], typs[1]), nil)
-	typs[62] = newSig(params(typs[1]), nil)
-	typs[63] = types.NewPtr(typs[5])
-	typs[64] = newSig(params(typs[63], typs[7], typs[7]), params(typs[6]))
-	typs[65] = types.Types[types.TUINT32]
-	typs[66] = newSig(nil, params(typs[65]))
-	typs[67] = types.NewMap(typs[2], typs[2])
-	typs[68] = newSig(params(typs[1], typs[22], typs[3]), params(typs[67]))
-	typs[69] = newSig(params(typs[1], typs[15], typs[3]), params(typs[67]))
-	typs[70] = newSig(nil, params(typs[67]))
-	typs[71] = newSig(params(typs[1], typs[67], typs[3]), params(typs[3]))
-	typs[72] = newSig(params(typs[1], typs[67], typs[2]), params(typs[3]))
-	typs[73] = newSig(params(typs[1], typs[67], typs[3], typs[1]), params(typs[3]))
-	typs[74] = newSig(params(typs[1], typs[67], typs[3]), params(typs[3], typs[6]))
-	typs[75] = newSig(params(typs[1], typs[67], typs[2]), params(typs[3], typs[6]))
-	typs[76] = newSig(params(typs[1], typs[67], typs[3], typs[1]), params(typs[3], typs[6]))
-	typs[77] = newSig(params(typs[1], typs[67], typs[3]), nil)
-	typs[78] = newSig(params(typs[1], typs[67], typs[2]), nil)
-	typs[79] = newSig(params(typs[3]), nil)
-	typs[80] = newSig(params(typs[1], typs[67]), nil)
-	typs[81] = types.NewChan(typs[2], types.Cboth)
-	typs[82] = newSig(params(typs[1], typs[22]), params(typs[81]))
-	typs[83] = newSig(params(typs[1], typs[15]), params(typs[81]))
-	typs[84] = types.NewChan(typs[2], types.Crecv)
-	typs[85] = newSig(params(typs[84], typs[3]), nil)
-	typs[86] = newSig(params(typs[84], typs[3]), params(typs[6]))
-	typs[87] = types.NewChan(typs[2], types.Csend)
-	typs[88] = newSig(params(typs[87], typs[3]), nil)
-	typs[89] = types.NewArray(typs[0], 3)
-	typs[90] = types.NewStruct(types.NoPkg, []*types.Field{types.NewField(src.NoXPos, Lookup("enabled"), typs[6]), types.NewField(src.NoXPos, Lookup("pad"), typs[89]), types.NewField(src.NoXPos, Lookup("needed"), typs[6]), types.NewField(src.NoXPos, Lookup("cgo"), typs[6]), types.NewField(src.NoXPos, Lookup("alignme"), typs[24])})
-	typs[91] = newSig(params(typs[1], typs[3], typs[3]), nil)
-	typs[92] = newSig(params(typs[1], typs[3]), nil)
-	typs[93] = newSig(params(typs[1], typs[3], typs[15], typs[3], typs[15]), params(typs[15]))
-	typs[94] = newSig(params(typs[87], typs[3]), params(typs[6]))
-	typs[95] = newSig(params(typs[3], typs[84]), params(typs[6], typs[6]))
-	typs[96] = newSig(params(typs[63]), nil)
-	typs[97] = newSig(params(typs[1], typs[1], typs[63], typs[15], typs[15], typs[6]), params(typs[15], typs[6]))
-	typs[98] = newSig(params(typs[1], typs[15], typs[15]), params(typs[7]))
-	typs[99] = newSig(params(typs[1], typs[22], typs[22]), params(typs[7]))
-	typs[100] = newSig(params(typs[1], typs[15], typs[15], typs[7]), params(typs[7]))
-	typs[101] = types.NewSlice(typs[2])
-	typs[102] = newSig(params(typs[1], typs[101], typs[15]), params(typs[101]))
-	typs[103] = newSig(params(typs[3], typs[3], typs[5]), nil)
-	typs[104] = newSig(params(typs[7], typs[5]), nil)
-	typs[105] = newSig(params(typs[3], typs[3], typs[5]), params(typs[6]))
-	typs[106] = newSig(params(typs[3], typs[3]), params(typs[6]))
-	typs[107] = newSig(params(typs[7], typs[7]), params(typs[6]))
-	typs[108] = newSig(params(typs[7], typs[5], typs[5]), params(typs[5]))
-	typs[109] = newSig(params(typs[7], typs[5]), params(typs[5]))
-	typs[110] = newSig(params(typs[22], typs[22]), params(typs[22]))
-	typs[111] = newSig(params(typs[24], typs[24]), params(typs[24]))
-	typs[112] = newSig(params(typs[20]), params(typs[22]))
-	typs[113] = newSig(params(typs[20]), params(typs[24]))
-	typs[114] = newSig(params(typs[20]), params(typs[65]))
-	typs[115] = newSig(params(typs[22]), params(typs[20]))
-	typs[116] = newSig(params(typs[24]), params(typs[20]))
-	typs[117] = newSig(params(typs[65]), params(typs[20]))
-	typs[118] = newSig(params(typs[26], typs[26]), params(typs[26]))
-	typs[119] = newSig(nil, params(typs[5]))
-	typs[120] = newSig(params(typs[5], typs[5]), nil)
-	typs[121] = newSig(params(typs[5], typs[5], typs[5]), nil)
-	typs[122] = newSig(params(typs[7], typs[1], typs[5]), nil)
-	typs[123] = types.NewSlice(typs[7])
-	typs[124] = newSig(params(typs[7], typs[123]), nil)
-	typs[125] = types.Types[types.TUINT8]
-	typs[126] = newSig(params(typs[125], typs[125]), nil)
-	typs[127] = types.Types[types.TUINT16]
-	typs[128] = newSig(params(typs[127], typs[127]), nil)
-	typs[129] = newSig(params(typs[65], typs[65]), nil)
-	typs[130] = newSig(params(typs[24], typs[24]), nil)
+	typs[60] = types.NewPtr(typs[1])
+	typs[61] = newSig(params(typs[60]), params(typs[1]))
+	typs[62] = newSig(params(typs[1], typs[1]), params(typs[1]))
+	typs[63] = newSig(params(typs[1], typs[1], typs[1]), nil)
+	typs[64] = newSig(params(typs[1]), nil)
+	typs[65] = types.NewPtr(typs[5])
+	typs[66] = newSig(params(typs[65], typs[7], typs[7]), params(typs[6]))
+	typs[67] = types.Types[types.TUINT32]
+	typs[68] = newSig(nil, params(typs[67]))
+	typs[69] = types.NewMap(typs[2], typs[2])
+	typs[70] = newSig(params(typs[1], typs[22], typs[3]), params(typs[69]))
+	typs[71] = newSig(params(typs[1], typs[15], typs[3]), params(typs[69]))
+	typs[72] = newSig(nil, params(typs[69]))
+	typs[73] = newSig(params(typs[1], typs[69], typs[3]), params(typs[3]))
+	typs[74] = newSig(params(typs[1], typs[69], typs[2]), params(typs[3]))
+	typs[75] = newSig(params(typs[1], typs[69], typs[3], typs[1]), params(typs[3]))
+	typs[76] = newSig(params(typs[1], typs[69], typs[3]), params(typs[3], typs[6]))
+	typs[77] = newSig(params(typs[1], typs[69], typs[2]), params(typs[3], typs[6]))
+	typs[78] = newSig(params(typs[1], typs[69], typs[3], typs[1]), params(typs[3], typs[6]))
+	typs[79] = newSig(params(typs[1], typs[69], typs[3]), nil)
+	typs[80] = newSig(params(typs[1], typs[69], typs[2]), nil)
+	typs[81] = newSig(params(typs[3]), nil)
+	typs[82] = newSig(params(typs[1], typs[69]), nil)
+	typs[83] = newSig(params(typs[1], typs[69], typs[7], typs[7], typs[15]), nil)
+	typs[84] = types.NewSlice(typs[67])
+	typs[85] = newSig(params(typs[28], typs[38], typs[84]), params(typs[15]))
+	typs[86] = types.NewChan(typs[2], types.Cboth)
+	typs[87] = newSig(params(typs[1], typs[22]), params(typs[86]))
+	typs[88] = newSig(params(typs[1], typs[15]), params(typs[86]))
+	typs[89] = types.NewChan(typs[2], types.Crecv)
+	typs[90] = newSig(params(typs[89], typs[3]), nil)
+	typs[91] = newSig(params(typs[89], typs[3]), params(typs[6]))
+	typs[92] = types.NewChan(typs[2], types.Csend)
+	typs[93] = newSig(params(typs[92], typs[3]), nil)
+	typs[94] = types.NewArray(typs[0], 3)
+	typs[95] = types.NewStruct(types.NoPkg, []*types.Field{types.NewField(src.NoXPos, Lookup("enabled"), typs[6]), types.NewField(src.NoXPos, Lookup("pad"), typs[94]), types.NewField(src.NoXPos, Lookup("needed"), typs[6]), types.NewField(src.NoXPos, Lookup("cgo"), typs[6]), types.NewField(src.NoXPos, Lookup("alignme"), typs[24])})
+	typs[96] = newSig(params(typs[1], typs[3], typs[3]), nil)
+	typs[97] = newSig(params(typs[1], typs[3]), nil)
+	typs[98] = newSig(params(typs[1], typs[3], typs[15], typs[3], typs[15]), params(typs[15]))
+	typs[99] = newSig(params(typs[92], typs[3]), params(typs[6]))
+	typs[100] = newSig(params(typs[3], typs[89]), params(typs[6], typs[6]))
+	typs[101] = newSig(params(typs[65]), nil)
+	typs[102] = newSig(params(typs[1], typs[1], typs[65], typs[15], typs[15], typs[6]), params(typs[15], typs[6]))
+	typs[103] = newSig(params(typs[1], typs[15], typs[15]), params(typs[7]))
+	typs[104] = newSig(params(typs[1], typs[22], typs[22]), params(typs[7]))
+	typs[105] = newSig(params(typs[1], typs[15], typs[15], typs[7]), params(typs[7]))
+	typs[106] = types.NewSlice(typs[2])
+	typs[107] = newSig(params(typs[1], typs[106], typs[15]), params(typs[106]))
+	typs[108] = newSig(params(typs[3], typs[3], typs[5]), nil)
+	typs[109] = newSig(params(typs[7], typs[5]), nil)
+	typs[110] = newSig(params(typs[7], typs[7], typs[15], typs[15], typs[5]), params(typs[6]))
+	typs[111] = types.Types[types.TUINT8]
+	typs[112] = newSig(params(typs[7], typs[15], typs[111]), nil)
+	typs[113] = newSig(params(typs[7], typs[7], typs[7], typs[15], typs[15], typs[15]), params(typs[6]))
+	typs[114] = newSig(params(typs[7], typs[15]), params(typs[17]))
+	typs[115] = newSig(params(typs[3], typs[3], typs[5]), params(typs[6]))
+	typs[116] = newSig(params(typs[3], typs[3]), params(typs[6]))
+	typs[117] = newSig(params(typs[7], typs[7]), params(typs[6]))
+	typs[118] = newSig(params(typs[7], typs[5], typs[5]), params(typs[5]))
+	typs[119] = newSig(params(typs[7], typs[5]), params(typs[5]))
+	typs[120] = newSig(params(typs[22], typs[22]), params(typs[22]))
+	typs[121] = newSig(params(typs[24], typs[24]), params(typs[24]))
+	typs[122] = newSig(params(typs[20]), params(typs[22]))
+	typs[123] = newSig(params(typs[20]), params(typs[24]))
+	typs[124] = newSig(params(typs[20]), params(typs[67]))
+	typs[125] = newSig(params(typs[22]), params(typs[20]))
+	typs[126] = newSig(params(typs[24]), params(typs[20]))
+	typs[127] = newSig(params(typs[67]), params(typs[20]))
+	typs[128] = newSig(params(typs[26], typs[26]), params(typs[26]))
+	typs[129] = newSig(nil, params(typs[5]))
+	typs[130] = newSig(params(typs[5], typs[5]), nil)
+	typs[131] = newSig(params(typs[5], typs[5], typs[5]), nil)
+	typs[132] = newSig(params(typs[7], typs[1], typs[5], typs[28]), nil)
+	typs[133] = types.NewSlice(typs[7])
+	typs[134] = newSig(params(typs[7], typs[133], typs[28]), nil)
+	typs[135] = newSig(params(typs[111], typs[111], typs[17]), nil)
+	typs[136] = types.Types[types.TUINT16]
+	typs[137] = newSig(params(typs[136], typs[136], typs[17]), nil)
+	typs[138] = newSig(params(typs[67], typs[67], typs[17]), nil)
+	typs[139] = newSig(params(typs[24], typs[24], typs[17]), nil)
+	typs[140] = newSig(params(typs[28], typs[28], typs[17]), nil)
+	typs[141] = types.NewPtr(typs[67])
+	typs[142] = newSig(params(typs[141]), nil)
 	return typs[:]
 }
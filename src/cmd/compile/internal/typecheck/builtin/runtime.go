@@ -101,6 +101,9 @@ func convT2Enoptr(typ *byte, elem *any) (ret any)
 func convT2I(tab *byte, elem *any) (ret any)
 func convT2Inoptr(tab *byte, elem *any) (ret any)
 
+// Itab lookup filling an itab cache, for -d=lazyitabs.
+func lazyitab(cache **byte) *byte
+
 // interface type assertions x.(T)
 func assertE2I(inter *byte, typ *byte) *byte
 func assertE2I2(inter *byte, eface any) (ret any)
@@ -144,6 +147,8 @@ func mapdelete_fast64(mapType *byte, hmap map[any]any, key any)
 func mapdelete_faststr(mapType *byte, hmap map[any]any, key any)
 func mapiternext(hiter *any)
 func mapclear(mapType *byte, hmap map[any]any)
+func mapinitbulk(mapType *byte, hmap map[any]any, keys unsafe.Pointer, elems unsafe.Pointer, n int)
+func staticmapindex(key string, keys []string, disp []uint32) int
 
 // *byte is really *runtime.Type
 func makechan64(chanType *byte, size int64) (hchan chan any)
@@ -181,6 +186,11 @@ func memmove(to *any, frm *any, length uintptr)
 func memclrNoHeapPointers(ptr unsafe.Pointer, n uintptr)
 func memclrHasPointers(ptr unsafe.Pointer, n uintptr)
 
+func loopmove(dst, src unsafe.Pointer, n, srclen int, size uintptr) bool
+func loopset8(p unsafe.Pointer, n int, c uint8)
+func loopxor8(dst, x, y unsafe.Pointer, n, xlen, ylen int) bool
+func loopsum8(p unsafe.Pointer, n int) uint
+
 func memequal(x, y *any, size uintptr) bool
 func memequal0(x, y *any) bool
 func memequal8(x, y *any) bool
@@ -241,17 +251,25 @@ func msanread(addr, size uintptr)
 func msanwrite(addr, size uintptr)
 func msanmove(dst, src, size uintptr)
 
-func checkptrAlignment(unsafe.Pointer, *byte, uintptr)
-func checkptrArithmetic(unsafe.Pointer, []unsafe.Pointer)
-
-func libfuzzerTraceCmp1(uint8, uint8)
-func libfuzzerTraceCmp2(uint16, uint16)
-func libfuzzerTraceCmp4(uint32, uint32)
-func libfuzzerTraceCmp8(uint64, uint64)
-func libfuzzerTraceConstCmp1(uint8, uint8)
-func libfuzzerTraceConstCmp2(uint16, uint16)
-func libfuzzerTraceConstCmp4(uint32, uint32)
-func libfuzzerTraceConstCmp8(uint64, uint64)
+// address sanitizer
+func asanread(addr, size uintptr)
+func asanwrite(addr, size uintptr)
+
+func checkptrAlignment(unsafe.Pointer, *byte, uintptr, string)
+func checkptrArithmetic(unsafe.Pointer, []unsafe.Pointer, string)
+
+func libfuzzerTraceCmp1(uint8, uint8, uint)
+func libfuzzerTraceCmp2(uint16, uint16, uint)
+func libfuzzerTraceCmp4(uint32, uint32, uint)
+func libfuzzerTraceCmp8(uint64, uint64, uint)
+func libfuzzerTraceConstCmp1(uint8, uint8, uint)
+func libfuzzerTraceConstCmp2(uint16, uint16, uint)
+func libfuzzerTraceConstCmp4(uint32, uint32, uint)
+func libfuzzerTraceConstCmp8(uint64, uint64, uint)
+func libfuzzerHookStrCmp(string, string, uint)
+
+// coverage
+func coverIncAtomic(p *uint32)
 
 // architecture variants
 var x86HasPOPCNT bool
@@ -72,3 +72,71 @@ func importtype(ipkg *types.Pkg, pos src.XPos, s *types.Sym) *ir.Name {
 func importvar(ipkg *types.Pkg, pos src.XPos, s *types.Sym, t *types.Type) *ir.Name {
 	return importobj(ipkg, pos, s, ir.ONAME, ir.PEXTERN, t)
 }
+
+// InstantiatedType returns an instantiated generic type that t refers
+// to, or nil. It looks through composite types and through the
+// underlying types and methods of the local defined types that t
+// refers to, since the export data for t would describe those too.
+//
+// TODO: The export data format cannot yet describe type parameters
+// or type arguments, so declarations and inline bodies that refer to
+// instantiated types must not be exported.
+func InstantiatedType(t *types.Type) *types.Type {
+	return instantiatedType(t, make(map[*types.Type]bool))
+}
+
+func instantiatedType(t *types.Type, seen map[*types.Type]bool) *types.Type {
+	if t == nil || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	if t.Sym() != nil {
+		if len(t.RParams()) > 0 && !t.HasTParam() {
+			return t
+		}
+		if t.Sym().Pkg != types.LocalPkg {
+			return nil
+		}
+		if t.Kind() != types.TINTER {
+			for _, m := range t.Methods().Slice() {
+				if it := instantiatedType(m.Type, seen); it != nil {
+					return it
+				}
+			}
+		}
+	}
+
+	switch t.Kind() {
+	case types.TPTR, types.TARRAY, types.TSLICE, types.TCHAN:
+		return instantiatedType(t.Elem(), seen)
+
+	case types.TMAP:
+		if it := instantiatedType(t.Key(), seen); it != nil {
+			return it
+		}
+		return instantiatedType(t.Elem(), seen)
+
+	case types.TSTRUCT:
+		for _, f := range t.FieldSlice() {
+			if it := instantiatedType(f.Type, seen); it != nil {
+				return it
+			}
+		}
+
+	case types.TFUNC:
+		for _, fs := range &types.RecvsParamsResults {
+			if it := instantiatedType(fs(t), seen); it != nil {
+				return it
+			}
+		}
+
+	case types.TINTER:
+		for _, m := range t.AllMethods().Slice() {
+			if it := instantiatedType(m.Type, seen); it != nil {
+				return it
+			}
+		}
+	}
+	return nil
+}
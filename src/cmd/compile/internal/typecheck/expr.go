@@ -58,7 +58,7 @@ func tcShift(n, l, r ir.Node) (ir.Node, ir.Node, *types.Type) {
 		base.Errorf("invalid operation: %v (shift count type %v, must be integer)", n, r.Type())
 		return l, r, nil
 	}
-	if t.IsSigned() && !types.AllowsGoVersion(curpkg(), 1, 13) {
+	if t.IsSigned() && !types.AllowsGoVersionAt(curpkg(), n.Pos(), 1, 13) {
 		base.ErrorfVers("go1.13", "invalid operation: %v (signed shift count type %v)", n, r.Type())
 		return l, r, nil
 	}
@@ -370,7 +370,7 @@ func tcCompLit(n *ir.CompLitExpr) (res ir.Node) {
 				if f == nil {
 					if ci := Lookdot1(nil, l.Field, t, t.Fields(), 2); ci != nil { // Case-insensitive lookup.
 						if visible(ci.Sym) {
-							base.Errorf("unknown field '%v' in struct literal of type %v (but does have %v)", l.Field, t, ci.Sym)
+							base.ErrorfAtFix(base.Pos, "", fieldFix(l.Pos(), l.Field.Name, ci.Sym.Name), "unknown field '%v' in struct literal of type %v (but does have %v)", l.Field, t, ci.Sym)
 						} else if nonexported(l.Field) && l.Field.Name == ci.Sym.Name { // Ensure exactness before the suggestion.
 							base.Errorf("cannot refer to unexported field '%v' in struct literal of type %v", l.Field, t)
 						} else {
@@ -512,7 +512,9 @@ func tcDot(n *ir.SelectorExpr, top int) ir.Node {
 
 		default:
 			if mt := Lookdot(n, t, 2); mt != nil && visible(mt.Sym) { // Case-insensitive lookup.
-				base.Errorf("%v undefined (type %v has no field or method %v, but does have %v)", n, n.X.Type(), n.Sel, mt.Sym)
+				base.ErrorfAtFix(base.Pos, "", selectorFix(n.Pos(), n.Sel.Name, mt.Sym.Name), "%v undefined (type %v has no field or method %v, but does have %v)", n, n.X.Type(), n.Sel, mt.Sym)
+			} else if s := closestSym(n.Sel.Name, dotCandidates(t)); s != nil {
+				base.Errorf("%v undefined (type %v has no field or method %v, did you mean %v?)", n, n.X.Type(), n.Sel, s)
 			} else {
 				base.Errorf("%v undefined (type %v has no field or method %v)", n, n.X.Type(), n.Sel)
 			}
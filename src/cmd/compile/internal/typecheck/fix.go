@@ -0,0 +1,166 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typecheck
+
+import (
+	"strings"
+	"unicode"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"cmd/internal/src"
+)
+
+// Suggested fixes for mechanical errors.
+//
+// Some errors have an obvious fix, such as renaming an unused
+// variable to _ or correcting the case of a method name. For those,
+// the error carries a base.Fix, which -json=diagnostics prints as
+// edits for editors and other tools to apply. A fix is only suggested
+// if the source text at the error is exactly what the fix expects, so
+// that applying it always yields the intended program.
+
+// unusedVarFix returns a fix for the unused local variable ln, or nil.
+// The variable is renamed to _ if that keeps its declaration valid,
+// and a short variable declaration of ln alone becomes an assignment
+// to _.
+func unusedVarFix(ln *ir.Name) *base.Fix {
+	name := ln.Sym().Name
+	if !base.IdentAt(ln.Pos(), name) {
+		return nil
+	}
+	blank := &base.Fix{
+		Msg:   "rename " + name + " to _",
+		Edits: []base.Edit{{Pos: ln.Pos(), Len: len(name), New: "_"}},
+	}
+	switch defn := ln.Defn.(type) {
+	case nil:
+		return blank
+	case *ir.AssignStmt:
+		if !defn.Def {
+			return blank
+		}
+		n := defineLen(ln.Pos(), name)
+		if n == 0 {
+			return nil
+		}
+		return &base.Fix{
+			Msg:   "assign to _ instead of declaring " + name,
+			Edits: []base.Edit{{Pos: ln.Pos(), Len: n, New: "_ ="}},
+		}
+	case *ir.AssignListStmt:
+		if !defn.Def || declaresUsed(defn, ln, defn.Lhs...) {
+			return blank
+		}
+	case *ir.RangeStmt:
+		if declaresUsed(defn, ln, defn.Key, defn.Value) {
+			return blank
+		}
+	}
+	return nil
+}
+
+// declaresUsed reports whether a variable other than ln among lhs is
+// declared by defn and used, so that defn still declares a variable
+// if ln is renamed to _.
+func declaresUsed(defn ir.Node, ln *ir.Name, lhs ...ir.Node) bool {
+	for _, n := range lhs {
+		if n, ok := n.(*ir.Name); ok && n != ln && n.Defn == defn && !ir.IsBlank(n) && n.Used() {
+			return true
+		}
+	}
+	return false
+}
+
+// typeSwitchVarFix returns a fix that removes the unused variable
+// name declared by the type switch guard at pos, or nil.
+func typeSwitchVarFix(pos src.XPos, name string) *base.Fix {
+	n := defineLen(pos, name)
+	if n == 0 {
+		return nil
+	}
+	text, _ := base.SourceAt(pos)
+	n += len(text[n:]) - len(strings.TrimLeft(text[n:], " \t"))
+	return &base.Fix{
+		Msg:   "remove " + name,
+		Edits: []base.Edit{{Pos: pos, Len: n}},
+	}
+}
+
+// defineLen returns the length of the source text "name :=" at pos,
+// or 0 if the text at pos is something else.
+func defineLen(pos src.XPos, name string) int {
+	if !base.IdentAt(pos, name) {
+		return 0
+	}
+	text, _ := base.SourceAt(pos)
+	rest := strings.TrimLeft(text[len(name):], " \t")
+	if !strings.HasPrefix(rest, ":=") {
+		return 0
+	}
+	return len(text) - len(rest) + len(":=")
+}
+
+// fieldFix returns a fix that replaces the field name old in a
+// struct literal key, found on the line of pos, with new, or nil.
+func fieldFix(pos src.XPos, old, new string) *base.Fix {
+	pos, ok := base.FindIdent(pos, old)
+	if !ok {
+		return nil
+	}
+	return &base.Fix{
+		Msg:   "change " + old + " to " + new,
+		Edits: []base.Edit{{Pos: pos, Len: len(old), New: new}},
+	}
+}
+
+// selectorFix returns a fix that replaces the name old in the
+// selector expression whose dot is at pos with new, or nil.
+func selectorFix(pos src.XPos, old, new string) *base.Fix {
+	text, ok := base.SourceAt(pos)
+	if !ok || !strings.HasPrefix(text, ".") {
+		return nil
+	}
+	rest := strings.TrimLeft(text[1:], " \t")
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if end < 0 {
+		end = len(rest)
+	}
+	if rest[:end] != old {
+		return nil
+	}
+	n := len(text) - len(rest)
+	return &base.Fix{
+		Msg:   "change " + old + " to " + new,
+		Edits: []base.Edit{{Pos: pos, Len: n + len(old), New: text[:n] + new}},
+	}
+}
+
+// conversionFix returns a fix that converts the variable n, used at
+// pos, to type t, or nil. The fix is only offered if the conversion is
+// valid and t can be written as it is printed.
+func conversionFix(pos src.XPos, n ir.Node, t *types.Type) *base.Fix {
+	if n.Op() != ir.ONAME || n.Sym() == nil || n.Type().IsInterface() || t.Sym() == nil {
+		return nil
+	}
+	if pkg := t.Sym().Pkg; pkg != types.LocalPkg && pkg != types.BuiltinPkg {
+		return nil
+	}
+	if op, _ := Convertop(false, n.Type(), t); op == ir.OXXX {
+		return nil
+	}
+	name := n.Sym().Name
+	pos, ok := base.FindIdent(pos, name)
+	if !ok {
+		return nil
+	}
+	return &base.Fix{
+		Msg:   "convert " + name + " to " + t.String(),
+		Edits: []base.Edit{{Pos: pos, Len: len(name), New: t.String() + "(" + name + ")"}},
+	}
+}
@@ -35,6 +35,24 @@
 //         }
 //     }
 //
+//     InlineIndex []struct{
+//         PkgPath stringOff
+//
+//         Bodies []struct{
+//             Name   stringOff
+//             Offset declOff
+//         }
+//     }
+//
+//     LayoutIndex []struct{
+//         PkgPath stringOff
+//
+//         Types []struct{
+//             Name   stringOff
+//             Offset declOff // of a Layout
+//         }
+//     }
+//
 //     Fingerprint [8]byte
 //
 // uvarint means a uint64 written out using uvarint encoding.
@@ -93,6 +111,18 @@
 //         Type typeOff
 //     }
 //
+// The inline bodies are only read by the compiler. The layouts record
+// the size, alignment and field offsets the compiler computed for the
+// defined types, for tools that want to present them. Tools that only
+// need the declarations can stop reading after MainIndex; the version
+// does not change when indices are added after it.
+//
+//     type Layout struct {
+//         Size    int64   // -1 if the type has no layout (e.g., it is generic)
+//         Align   int64
+//         Offsets []int64 // field offsets, if Underlying is a struct type
+//     }
+//
 //
 // typeOff means a uvarint that either indicates a predeclared type,
 // or an offset into the Data section. If the uvarint is less than
@@ -257,6 +287,7 @@ func WriteExports(out *bufio.Writer) {
 		stringIndex: map[string]uint64{},
 		declIndex:   map[*types.Sym]uint64{},
 		inlineIndex: map[*types.Sym]uint64{},
+		layoutIndex: map[*types.Sym]uint64{},
 		typIndex:    map[*types.Type]uint64{},
 	}
 
@@ -284,6 +315,7 @@ func WriteExports(out *bufio.Writer) {
 	w := p.newWriter()
 	w.writeIndex(p.declIndex, true)
 	w.writeIndex(p.inlineIndex, false)
+	w.writeIndex(p.layoutIndex, false)
 	w.flush()
 
 	if *base.Flag.LowerV {
@@ -378,6 +410,7 @@ type iexporter struct {
 	data0       intWriter
 	declIndex   map[*types.Sym]uint64
 	inlineIndex map[*types.Sym]uint64
+	layoutIndex map[*types.Sym]uint64
 	typIndex    map[*types.Type]uint64
 }
 
@@ -504,6 +537,7 @@ func (p *iexporter) doDecl(n *ir.Name) {
 		w.typ(underlying)
 
 		t := n.Type()
+		p.doLayout(n)
 		if t.IsInterface() {
 			w.typeExt(t)
 			break
@@ -530,6 +564,34 @@ func (p *iexporter) doDecl(n *ir.Name) {
 	w.finish("dcl", p.declIndex, n.Sym())
 }
 
+// doLayout writes the layout of the defined type n.
+func (p *iexporter) doLayout(n *ir.Name) {
+	w := p.newWriter()
+	w.layout(n.Type())
+	w.finish("lay", p.layoutIndex, n.Sym())
+}
+
+// layout writes the size, alignment and field offsets of t.
+func (w *exportWriter) layout(t *types.Type) {
+	if t.HasTParam() {
+		w.int64(-1)
+		w.int64(0)
+		w.uint64(0)
+		return
+	}
+	w.int64(t.Size())
+	w.int64(t.Alignment())
+	if !t.IsStruct() {
+		w.uint64(0)
+		return
+	}
+	fs := t.FieldSlice()
+	w.uint64(uint64(len(fs)))
+	for _, f := range fs {
+		w.int64(f.Offset)
+	}
+}
+
 func (w *exportWriter) tag(tag byte) {
 	w.data.WriteByte(tag)
 }
@@ -14,6 +14,7 @@ import (
 	"io"
 	"math/big"
 	"os"
+	"sort"
 	"strings"
 
 	"cmd/compile/internal/base"
@@ -35,13 +36,70 @@ type iimporterAndOffset struct {
 var (
 	// DeclImporter maps from imported identifiers to an importer
 	// and offset where that identifier's declaration can be read.
-	DeclImporter = map[*types.Sym]iimporterAndOffset{}
+	DeclImporter = importIndex{}
 
 	// inlineImporter is like DeclImporter, but for inline bodies
 	// for function and method symbols.
-	inlineImporter = map[*types.Sym]iimporterAndOffset{}
+	inlineImporter = importIndex{}
 )
 
+// An importIndex maps from imported identifiers to an importer and
+// offset, as read from the index sections of the export data.
+//
+// Export data lists the identifiers of each package sorted by name.
+// Rather than creating a Sym and a map entry for each of them up front,
+// although most are never referred to, ReadImports only keeps the lists,
+// and lookup searches them when an identifier is first resolved.
+type importIndex map[*types.Pkg][]indexList
+
+// An indexList is the list of the identifiers of a package in the
+// export data read by p.
+type indexList struct {
+	p       *iimporter
+	entries []indexEntry
+}
+
+type indexEntry struct {
+	name uint64 // offset of the identifier in p.stringData
+	off  uint64 // offset of its declaration or inline body in p.declData
+}
+
+// lookup returns the importer and offset for sym. If several imports
+// list sym, the first one read wins.
+func (idx importIndex) lookup(sym *types.Sym) (iimporterAndOffset, bool) {
+	for _, l := range idx[sym.Pkg] {
+		i := sort.Search(len(l.entries), func(i int) bool {
+			return l.p.stringAt(l.entries[i].name) >= sym.Name
+		})
+		if i < len(l.entries) && l.p.stringAt(l.entries[i].name) == sym.Name {
+			return iimporterAndOffset{l.p, l.entries[i].off}, true
+		}
+	}
+	return iimporterAndOffset{}, false
+}
+
+// ImportedSyms returns the identifiers declared by the imported
+// package pkg, whether their declarations have been read yet or not.
+func ImportedSyms(pkg *types.Pkg) []*types.Sym {
+	var syms []*types.Sym
+	for _, s := range pkg.Syms {
+		if s.Def != nil {
+			syms = append(syms, s)
+		}
+	}
+	seen := map[*types.Sym]bool{}
+	for _, l := range DeclImporter[pkg] {
+		for _, e := range l.entries {
+			s := pkg.Lookup(l.p.stringAt(e.name))
+			if s.Def == nil && !seen[s] {
+				seen[s] = true
+				syms = append(syms, s)
+			}
+		}
+	}
+	return syms
+}
+
 func expandDecl(n ir.Node) ir.Node {
 	if n, ok := n.(*ir.Name); ok {
 		return n
@@ -79,8 +137,8 @@ func ImportBody(fn *ir.Func) {
 	inimport = false
 }
 
-func importReaderFor(sym *types.Sym, importers map[*types.Sym]iimporterAndOffset) *importReader {
-	x, ok := importers[sym]
+func importReaderFor(sym *types.Sym, importers importIndex) *importReader {
+	x, ok := importers.lookup(sym)
 	if !ok {
 		return nil
 	}
@@ -172,27 +230,21 @@ func ReadImports(pkg *types.Pkg, in *bio.Reader) (fingerprint goobj.FingerprintT
 			}
 		}
 
-		for nSyms := ird.uint64(); nSyms > 0; nSyms-- {
-			s := pkg.Lookup(p.stringAt(ird.uint64()))
-			off := ird.uint64()
-
-			if _, ok := DeclImporter[s]; !ok {
-				DeclImporter[s] = iimporterAndOffset{p, off}
-			}
-		}
+		DeclImporter.read(ird, pkg, p)
 	}
 
 	// Inline body index.
 	for nPkgs := ird.uint64(); nPkgs > 0; nPkgs-- {
 		pkg := p.pkgAt(ird.uint64())
+		inlineImporter.read(ird, pkg, p)
+	}
 
-		for nSyms := ird.uint64(); nSyms > 0; nSyms-- {
-			s := pkg.Lookup(p.stringAt(ird.uint64()))
-			off := ird.uint64()
-
-			if _, ok := inlineImporter[s]; !ok {
-				inlineImporter[s] = iimporterAndOffset{p, off}
-			}
+	// Layout index. The layouts are for tools; we compute our own.
+	for nPkgs := ird.uint64(); nPkgs > 0; nPkgs-- {
+		ird.uint64() // package path
+		for n := ird.uint64(); n > 0; n-- {
+			ird.uint64() // name
+			ird.uint64() // offset
 		}
 	}
 
@@ -205,6 +257,20 @@ func ReadImports(pkg *types.Pkg, in *bio.Reader) (fingerprint goobj.FingerprintT
 	return fingerprint
 }
 
+// read reads the list of the identifiers of pkg in the index section
+// read by ird.
+func (idx importIndex) read(ird *intReader, pkg *types.Pkg, p *iimporter) {
+	n := ird.uint64()
+	if n == 0 {
+		return
+	}
+	entries := make([]indexEntry, n)
+	for i := range entries {
+		entries[i] = indexEntry{name: ird.uint64(), off: ird.uint64()}
+	}
+	idx[pkg] = append(idx[pkg], indexList{p, entries})
+}
+
 type iimporter struct {
 	ipkg *types.Pkg
 
@@ -558,7 +558,7 @@ func tcSwitchType(n *ir.SwitchStmt) {
 	// declaration itself. So if there are no cases, we won't
 	// notice that it went unused.
 	if v := guard.Tag; v != nil && !ir.IsBlank(v) && len(n.Cases) == 0 {
-		base.ErrorfAt(v.Pos(), "%v declared but not used", v.Sym())
+		base.ErrorfAtFix(v.Pos(), base.CodeUnused, typeSwitchVarFix(v.Pos(), v.Sym().Name), "%v declared but not used", v.Sym())
 	}
 
 	var defCase, nilCase ir.Node
@@ -319,7 +319,7 @@ func assignconvfn(n ir.Node, t *types.Type, context func() string) ir.Node {
 
 	op, why := Assignop(n.Type(), t)
 	if op == ir.OXXX {
-		base.Errorf("cannot use %L as type %v in %s%s", n, t, context(), why)
+		base.ErrorfAtFix(base.Pos, "", conversionFix(base.Pos, n, t), "cannot use %L as type %v in %s%s", n, t, context(), why)
 		op = ir.OCONV
 	}
 
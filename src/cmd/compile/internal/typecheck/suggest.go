@@ -0,0 +1,145 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typecheck
+
+import (
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+)
+
+// Suggestions for misspelled names.
+//
+// When a selector or identifier cannot be resolved, the error
+// message may include a "did you mean" note naming the closest
+// declared name by edit distance. Candidates are collected only on
+// the error path, so none of this costs anything for correct code.
+
+// closestSym returns the symbol among candidates whose name is
+// closest to name, or nil if no candidate is close enough to be a
+// plausible misspelling. Only symbols visible from the current
+// package are considered.
+func closestSym(name string, candidates []*types.Sym) *types.Sym {
+	// Allow roughly one edit for every three characters, so that
+	// short names don't produce spurious suggestions.
+	best, bestDist := (*types.Sym)(nil), len(name)/3+1
+	for _, s := range candidates {
+		if s == nil || s.IsBlank() || s.Name == name || s.Name[0] == '.' || s.Name[0] == '~' {
+			continue
+		}
+		if !visible(s) && s.Pkg != types.BuiltinPkg {
+			continue
+		}
+		d := editDistance(name, s.Name)
+		if d < bestDist || d == bestDist && best != nil && s.Name < best.Name {
+			best, bestDist = s, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the optimal string alignment distance between
+// a and b: the number of single-byte insertions, deletions,
+// substitutions, and transpositions of adjacent bytes needed to turn
+// a into b.
+func editDistance(a, b string) int {
+	// d[i][j] is the distance between a[:i] and b[:j].
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func min3(x, y, z int) int {
+	if y < x {
+		x = y
+	}
+	if z < x {
+		x = z
+	}
+	return x
+}
+
+// dotCandidates returns the names of the fields and methods that a
+// selector x.f could refer to when x has type t, including those
+// promoted through embedded fields and, for interfaces, the full
+// method set computed by expandiface.
+func dotCandidates(t *types.Type) []*types.Sym {
+	var syms []*types.Sym
+	var walk func(t *types.Type)
+	walk = func(t *types.Type) {
+		if t == nil || t.Recur() {
+			return
+		}
+		t.SetRecur(true)
+		defer t.SetRecur(false)
+
+		if mt := types.ReceiverBaseType(t); mt != nil {
+			for _, f := range mt.Methods().Slice() {
+				syms = append(syms, f.Sym)
+			}
+		}
+		u := t
+		if u.IsPtr() {
+			u = u.Elem()
+		}
+		if u == nil || !u.IsStruct() && !u.IsInterface() {
+			return
+		}
+		for _, f := range u.Fields().Slice() {
+			syms = append(syms, f.Sym)
+			if f.Embedded != 0 && u.IsStruct() {
+				walk(f.Type)
+			}
+		}
+	}
+	walk(t)
+	return syms
+}
+
+// scopeCandidates returns the names an unresolved identifier sym
+// could have been meant to refer to. For a qualified identifier
+// pkg.Name, these are the names declared by pkg. Otherwise they are
+// the local variables of the current function, the package-level
+// declarations, and the predeclared identifiers.
+func scopeCandidates(sym *types.Sym) []*types.Sym {
+	if sym.Pkg != types.LocalPkg {
+		return ImportedSyms(sym.Pkg)
+	}
+
+	var syms []*types.Sym
+	if ir.CurFunc != nil {
+		for _, n := range ir.CurFunc.Dcl {
+			syms = append(syms, n.Sym())
+		}
+		for _, n := range ir.CurFunc.ClosureVars {
+			syms = append(syms, n.Sym())
+		}
+	}
+	for _, pkg := range []*types.Pkg{types.LocalPkg, types.BuiltinPkg} {
+		for _, s := range pkg.Syms {
+			if n := ir.AsNode(s.Def); n != nil && n.Op() != ir.ONONAME {
+				syms = append(syms, s)
+			}
+		}
+	}
+	return syms
+}
@@ -500,7 +500,11 @@ func typecheck1(n ir.Node, top int) ir.Node {
 		if !n.Diag() {
 			// Note: adderrorname looks for this string and
 			// adds context about the outer expression
-			base.ErrorfAt(n.Pos(), "undefined: %v", n.Sym())
+			if s := closestSym(n.Sym().Name, scopeCandidates(n.Sym())); s != nil {
+				base.ErrorfAt(n.Pos(), "undefined: %v (did you mean %v?)", n.Sym(), s)
+			} else {
+				base.ErrorfAt(n.Pos(), "undefined: %v", n.Sym())
+			}
 			n.SetDiag(true)
 		}
 		n.SetType(nil)
@@ -1928,8 +1932,8 @@ func checkmake(t *types.Type, arg string, np *ir.Node) bool {
 	return true
 }
 
-// markBreak marks control statements containing break statements with SetHasBreak(true).
-func markBreak(fn *ir.Func) {
+// MarkBreak marks control statements containing break statements with SetHasBreak(true).
+func MarkBreak(fn *ir.Func) {
 	var labels map[*types.Sym]ir.Node
 	var implicit ir.Node
 
@@ -2110,10 +2114,10 @@ func CheckUnused(fn *ir.Func) {
 			if defn.Used {
 				continue
 			}
-			base.ErrorfAt(defn.Tag.Pos(), "%v declared but not used", ln.Sym())
+			base.ErrorfAtFix(defn.Tag.Pos(), base.CodeUnused, typeSwitchVarFix(defn.Tag.Pos(), ln.Sym().Name), "%v declared but not used", ln.Sym())
 			defn.Used = true // suppress repeats
 		} else {
-			base.ErrorfAt(ln.Pos(), "%v declared but not used", ln.Sym())
+			base.ErrorfAtFix(ln.Pos(), base.CodeUnused, unusedVarFix(ln), "%v declared but not used", ln.Sym())
 		}
 	}
 }
@@ -2121,7 +2125,7 @@ func CheckUnused(fn *ir.Func) {
 // CheckReturn makes sure that fn terminates appropriately.
 func CheckReturn(fn *ir.Func) {
 	if fn.Type() != nil && fn.Type().NumResults() != 0 && len(fn.Body) != 0 {
-		markBreak(fn)
+		MarkBreak(fn)
 		if !isTermNodes(fn.Body) {
 			base.ErrorfAt(fn.Endlineno, "missing return at end of function")
 		}
@@ -12,6 +12,7 @@ import (
 	"strconv"
 
 	"cmd/compile/internal/base"
+	"cmd/internal/src"
 )
 
 // A lang is a language version broken into major and minor numbers.
@@ -40,7 +41,26 @@ func AllowsGoVersion(pkg *Pkg, major, minor int) bool {
 	if langWant.major == 0 && langWant.minor == 0 {
 		return true
 	}
-	return langWant.major > major || (langWant.major == major && langWant.minor >= minor)
+	return langWant.allows(major, minor)
+}
+
+// AllowsGoVersionAt is like AllowsGoVersion, but if pos is in a
+// file of the local package with a //go:lang directive, the version
+// named by the directive takes the place of the -lang flag.
+func AllowsGoVersionAt(pkg *Pkg, pos src.XPos, major, minor int) bool {
+	if pkg == nil || pkg == LocalPkg {
+		if s := base.FileLang(pos); s != "" {
+			if want, err := parseLang(s); err == nil {
+				return want.allows(major, minor)
+			}
+		}
+	}
+	return AllowsGoVersion(pkg, major, minor)
+}
+
+// allows reports whether language version l includes Go version major.minor.
+func (l lang) allows(major, minor int) bool {
+	return l.major > major || (l.major == major && l.minor >= minor)
 }
 
 // ParseLangFlag verifies that the -lang flag holds a valid value, and
@@ -50,21 +70,29 @@ func ParseLangFlag() {
 		return
 	}
 
-	var err error
-	langWant, err = parseLang(base.Flag.Lang)
-	if err != nil {
+	if err := CheckLang(base.Flag.Lang); err != nil {
 		log.Fatalf("invalid value %q for -lang: %v", base.Flag.Lang, err)
 	}
+	langWant, _ = parseLang(base.Flag.Lang)
+}
 
-	if def := currentLang(); base.Flag.Lang != def {
+// CheckLang reports an error if s is not a language version, such
+// as "go1.12", that is known to this compiler.
+func CheckLang(s string) error {
+	want, err := parseLang(s)
+	if err != nil {
+		return err
+	}
+	if def := currentLang(); s != def {
 		defVers, err := parseLang(def)
 		if err != nil {
 			log.Fatalf("internal error parsing default lang %q: %v", def, err)
 		}
-		if langWant.major > defVers.major || (langWant.major == defVers.major && langWant.minor > defVers.minor) {
-			log.Fatalf("invalid value %q for -lang: max known version is %q", base.Flag.Lang, def)
+		if !defVers.allows(want.major, want.minor) {
+			return fmt.Errorf("max known version is %q", def)
 		}
 	}
+	return nil
 }
 
 // parseLang parses a -lang option into a langVer.
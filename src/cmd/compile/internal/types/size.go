@@ -5,9 +5,11 @@
 package types
 
 import (
-	"bytes"
 	"fmt"
+	"go/layout"
 	"sort"
+	"strings"
+	"sync"
 
 	"cmd/compile/internal/base"
 	"cmd/internal/src"
@@ -57,19 +59,39 @@ func typePos(t *Type) src.XPos {
 // MaxWidth is the maximum size of a value on the target architecture.
 var MaxWidth int64
 
-// CalcSizeDisabled indicates whether it is safe
-// to calculate Types' widths and alignments. See CalcSize.
-var CalcSizeDisabled bool
+// sizes holds the state of size calculations.
+var sizes struct {
+	// mu serializes size calculations while the back end compiles
+	// functions concurrently (base.Ctxt.InParallel), so that the
+	// back end can create types and calculate their sizes late.
+	mu sync.Mutex
+
+	// deferDepth is the nesting depth of DeferCheckSize calls,
+	// and deferred holds the types whose calculation CheckSize
+	// deferred until the outermost ResumeCheckSize.
+	deferDepth int
+	deferred   []*Type
+
+	// layoutChecks holds the //go:layoutcheck assertions to verify
+	// when the layout of each struct type is calculated.
+	layoutChecks map[*Type][]LayoutCheck
+}
 
-// machine size and rounding alignment is dictated around
-// the size of a pointer, set in betypeinit (see ../amd64/galign.go).
-var defercalc int
+// lockSizes locks sizes.mu if the back end is compiling functions
+// concurrently. It returns the function that unlocks it.
+func lockSizes() (unlock func()) {
+	if base.Ctxt == nil || !base.Ctxt.InParallel {
+		return func() {}
+	}
+	sizes.mu.Lock()
+	return sizes.mu.Unlock
+}
 
 func Rnd(o int64, r int64) int64 {
 	if r < 1 || r > 8 || r&(r-1) != 0 {
 		base.Fatalf("rnd %d", r)
 	}
-	return (o + r - 1) &^ (r - 1)
+	return layout.Rnd(o, r)
 }
 
 // expandiface computes the method set for interface type t by
@@ -85,7 +107,7 @@ func expandiface(t *Type) {
 		case AllowsGoVersion(t.Pkg(), 1, 14) && !explicit && Identical(m.Type, prev.Type):
 			return
 		default:
-			base.ErrorfAt(m.Pos, "duplicate method %s", m.Sym.Name)
+			base.ErrorfAtCode(m.Pos, base.CodeDuplicateMethod, "duplicate method %s", m.Sym.Name)
 		}
 		methods = append(methods, m)
 	}
@@ -95,7 +117,7 @@ func expandiface(t *Type) {
 			continue
 		}
 
-		CheckSize(m.Type)
+		checkSize(m.Type)
 		addMethod(m, true)
 	}
 
@@ -105,7 +127,7 @@ func expandiface(t *Type) {
 		}
 
 		if !m.Type.IsInterface() {
-			base.ErrorfAt(m.Pos, "interface contains embedded non-interface %v", m.Type)
+			base.ErrorfAtCode(m.Pos, base.CodeEmbeddedNonIface, "interface contains embedded non-interface %v", m.Type)
 			m.SetBroke(true)
 			t.SetBroke(true)
 			// Add to fields so that error messages
@@ -119,7 +141,8 @@ func expandiface(t *Type) {
 		// Embedded interface: duplicate all methods
 		// (including broken ones, if any) and add to t's
 		// method set.
-		for _, t1 := range m.Type.Fields().Slice() {
+		calcSize(m.Type)
+		for _, t1 := range m.Type.Extra.(*Interface).Fields.Slice() {
 			// Use m.Pos rather than t1.Pos to preserve embedding position.
 			f := NewField(m.Pos, t1.Sym, t1.Type)
 			addMethod(f, false)
@@ -129,7 +152,7 @@ func expandiface(t *Type) {
 	sort.Sort(MethodsByName(methods))
 
 	if int64(len(methods)) >= MaxWidth/int64(PtrSize) {
-		base.ErrorfAt(typePos(t), "interface too large")
+		base.ErrorfAtCode(typePos(t), base.CodeInterfaceTooLarge, "interface too large")
 	}
 	for i, m := range methods {
 		m.Offset = int64(i) * int64(PtrSize)
@@ -142,13 +165,14 @@ func expandiface(t *Type) {
 
 func calcStructOffset(errtype *Type, t *Type, o int64, flag int) int64 {
 	// flag is 0 (receiver), 1 (actual struct), or RegSize (in/out parameters)
-	isStruct := flag == 1
+	if flag == 1 {
+		return calcStructLayout(errtype, t)
+	}
 	starto := o
 	maxalign := int32(flag)
 	if maxalign < 1 {
 		maxalign = 1
 	}
-	lastzero := int64(0)
 	for _, f := range t.Fields().Slice() {
 		if f.Type == nil {
 			// broken field, just skip it so that other valid fields
@@ -156,45 +180,25 @@ func calcStructOffset(errtype *Type, t *Type, o int64, flag int) int64 {
 			continue
 		}
 
-		CalcSize(f.Type)
+		calcSize(f.Type)
 		if int32(f.Type.Align) > maxalign {
 			maxalign = int32(f.Type.Align)
 		}
 		if f.Type.Align > 0 {
 			o = Rnd(o, int64(f.Type.Align))
 		}
-		if isStruct { // For receiver/args/results, do not set, it depends on ABI
-			f.Offset = o
-		}
 
 		w := f.Type.Width
 		if w < 0 {
 			base.Fatalf("invalid width %d", f.Type.Width)
 		}
-		if w == 0 {
-			lastzero = o
-		}
 		o += w
-		maxwidth := MaxWidth
-		// On 32-bit systems, reflect tables impose an additional constraint
-		// that each field start offset must fit in 31 bits.
-		if maxwidth < 1<<32 {
-			maxwidth = 1<<31 - 1
-		}
-		if o >= maxwidth {
-			base.ErrorfAt(typePos(errtype), "type %L too large", errtype)
+		if o >= maxFieldOffset() {
+			base.ErrorfAtCode(typePos(errtype), base.CodeTypeTooLarge, "type %L too large", errtype)
 			o = 8 // small but nonzero
 		}
 	}
 
-	// For nonzero-sized structs which end in a zero-sized thing, we add
-	// an extra byte of padding to the type. This padding ensures that
-	// taking the address of the zero-sized thing can't manufacture a
-	// pointer to the next object in the heap. See issue 9401.
-	if flag == 1 && o > starto && o == lastzero {
-		o++
-	}
-
 	// final width is rounded
 	if flag != 0 {
 		o = Rnd(o, int64(maxalign))
@@ -207,103 +211,291 @@ func calcStructOffset(errtype *Type, t *Type, o int64, flag int) int64 {
 	return o
 }
 
-// findTypeLoop searches for an invalid type declaration loop involving
-// type t and reports whether one is found. If so, path contains the
-// loop.
-//
-// path points to a slice used for tracking the sequence of types
-// visited. Using a pointer to a slice allows the slice capacity to
-// grow and limit reallocations.
-func findTypeLoop(t *Type, path *[]*Type) bool {
-	// We implement a simple DFS loop-finding algorithm. This
-	// could be faster, but type cycles are rare.
+// calcStructLayout computes the field offsets, width and alignment of
+// the struct type t, using the rules implemented by package go/layout,
+// and returns the width.
+func calcStructLayout(errtype *Type, t *Type) int64 {
+	var l layout.Struct
+	for _, f := range t.Fields().Slice() {
+		if f.Type == nil {
+			// broken field, just skip it so that other valid fields
+			// get a width.
+			continue
+		}
+
+		calcSize(f.Type)
+		w := f.Type.Width
+		if w < 0 {
+			base.Fatalf("invalid width %d", f.Type.Width)
+		}
+		f.Offset = l.Field(w, int64(f.Type.Align))
+		if l.End() >= maxFieldOffset() {
+			base.ErrorfAtCode(typePos(errtype), base.CodeTypeTooLarge, "type %L too large", errtype)
+			l = layout.Struct{}
+			l.Field(8, 1) // small but nonzero
+		}
+	}
 
-	if t.Sym() != nil {
-		// Declared type. Check for loops and otherwise
-		// recurse on the type expression used in the type
-		// declaration.
+	t.Align = uint8(l.Align())
+	t.Width = l.Size()
+	if checks, ok := sizes.layoutChecks[t]; ok {
+		delete(sizes.layoutChecks, t)
+		checkLayout(t, checks)
+	}
+	return t.Width
+}
 
-		// Type imported from package, so it can't be part of
-		// a type loop (otherwise that package should have
-		// failed to compile).
-		if t.Sym().Pkg != LocalPkg {
-			return false
+// A LayoutCheck is an assertion from a //go:layoutcheck directive
+// about the layout of a struct type.
+type LayoutCheck struct {
+	Pos   src.XPos
+	Kind  string // "size", "align" or "offset"
+	Field string // for "offset", the name of the field
+	Want  int64
+}
+
+// AddLayoutChecks arranges for the layout of the struct type t to be
+// verified against checks when its size is calculated, or right away
+// if it already has been.
+func AddLayoutChecks(t *Type, checks []LayoutCheck) {
+	if !t.IsStruct() {
+		base.ErrorfAtCode(checks[0].Pos, base.CodeLayoutCheck, "go:layoutcheck applies only to struct types, not %v", t)
+		return
+	}
+	defer lockSizes()()
+	if t.WidthCalculated() {
+		checkLayout(t, checks)
+		return
+	}
+	if sizes.layoutChecks == nil {
+		sizes.layoutChecks = make(map[*Type][]LayoutCheck)
+	}
+	sizes.layoutChecks[t] = append(sizes.layoutChecks[t], checks...)
+}
+
+// CheckCgoLayout reports an error at pos for each way in which the
+// layout of the struct type t, which a //go:cgolayout directive
+// declares to mirror the C type C.cname, differs from that of ct, the
+// Go type cgo generated for C.cname. Each named field of t must have
+// the size and offset of the field of ct with the same name. cgo
+// omits bitfields and fields it cannot place, such as misaligned
+// fields of packed structs, so t must cover those with blank fields.
+func CheckCgoLayout(pos src.XPos, t, ct *Type, cname string) {
+	if !t.IsStruct() {
+		base.ErrorfAtCode(pos, base.CodeLayoutCheck, "go:cgolayout applies only to struct types, not %v", t)
+		return
+	}
+	if !ct.IsStruct() {
+		base.ErrorfAtCode(pos, base.CodeLayoutCheck, "go:cgolayout: C.%s is not a struct type", cname)
+		return
+	}
+	CalcSize(t)
+	CalcSize(ct)
+	if t.Width != ct.Width {
+		base.ErrorfAtCode(pos, base.CodeLayoutCheck, "go:cgolayout: size of %v is %d, but size of C.%s is %d", t, t.Width, cname, ct.Width)
+	}
+	if t.Align != ct.Align {
+		base.ErrorfAtCode(pos, base.CodeLayoutCheck, "go:cgolayout: alignment of %v is %d, but alignment of C.%s is %d", t, t.Align, cname, ct.Align)
+	}
+	for _, f := range t.Fields().Slice() {
+		if f.Sym == nil || f.Sym.IsBlank() {
+			continue
+		}
+		cf := cgoField(ct, f.Sym.Name)
+		if cf == nil {
+			base.ErrorfAtCode(pos, base.CodeLayoutCheck, "go:cgolayout: C.%s has no field %s (cgo omits bitfields and misaligned fields)", cname, f.Sym.Name)
+			continue
+		}
+		if f.Offset != cf.Offset {
+			base.ErrorfAtCode(pos, base.CodeLayoutCheck, "go:cgolayout: offset of %v.%s is %d, but offset of C.%s.%s is %d", t, f.Sym.Name, f.Offset, cname, cf.Sym.Name, cf.Offset)
+		}
+		if f.Type.Width != cf.Type.Width {
+			base.ErrorfAtCode(pos, base.CodeLayoutCheck, "go:cgolayout: size of %v.%s is %d, but size of C.%s.%s is %d", t, f.Sym.Name, f.Type.Width, cname, cf.Sym.Name, cf.Type.Width)
+		}
+	}
+}
+
+// cgoField returns the field of the cgo-generated struct type ct that
+// corresponds to the Go field name, or nil. cgo prefixes C field names
+// that are Go keywords with an underscore, and Go mirrors often
+// capitalize C field names, so the match ignores both.
+func cgoField(ct *Type, name string) *Field {
+	var fold *Field
+	for _, f := range ct.Fields().Slice() {
+		if f.Sym == nil || f.Sym.IsBlank() {
+			continue
 		}
+		if f.Sym.Name == name {
+			return f
+		}
+		if fold == nil && strings.EqualFold(strings.TrimPrefix(f.Sym.Name, "_"), name) {
+			fold = f
+		}
+	}
+	return fold
+}
 
-		for i, x := range *path {
-			if x == t {
-				*path = (*path)[i:]
-				return true
+// checkLayout reports an error for each of checks that the layout of
+// struct type t does not satisfy.
+func checkLayout(t *Type, checks []LayoutCheck) {
+	for _, c := range checks {
+		what, got := c.Kind, int64(0)
+		switch c.Kind {
+		case "size":
+			got = t.Width
+		case "align":
+			got = int64(t.Align)
+		case "offset":
+			var field *Field
+			for _, f := range t.Fields().Slice() {
+				if f.Sym != nil && f.Sym.Name == c.Field {
+					field = f
+					break
+				}
+			}
+			if field == nil {
+				base.ErrorfAtCode(c.Pos, base.CodeLayoutCheck, "go:layoutcheck: %v has no field %s", t, c.Field)
+				continue
 			}
+			what, got = "offset of "+c.Field, field.Offset
+		default:
+			base.Fatalf("unknown layout check %q", c.Kind)
+		}
+		if got != c.Want {
+			base.ErrorfAtCode(c.Pos, base.CodeLayoutCheck, "go:layoutcheck failed: %s of %v is %d, not %d", what, t, got, c.Want)
 		}
+	}
+}
+
+// maxFieldOffset returns the limit on the end offset of a field.
+func maxFieldOffset() int64 {
+	maxwidth := MaxWidth
+	// On 32-bit systems, reflect tables impose an additional constraint
+	// that each field start offset must fit in 31 bits.
+	if maxwidth < 1<<32 {
+		maxwidth = 1<<31 - 1
+	}
+	return maxwidth
+}
+
+// findTypeLoops returns the invalid type declaration loops that can
+// be reached from type t, one for each type declaration that refers
+// back to a type on the current search path.
+func findTypeLoops(t *Type) [][]*Type {
+	// We implement a simple DFS loop-finding algorithm. This
+	// could be faster, but type cycles are rare.
+	var loops [][]*Type
+	var path []*Type
+	done := make(map[*Type]bool)
+
+	var visit func(t *Type)
+	visit = func(t *Type) {
+		if t.Sym() != nil {
+			// Declared type. Check for loops and otherwise
+			// recurse on the type expression used in the type
+			// declaration.
+
+			// Type imported from package, so it can't be part of
+			// a type loop (otherwise that package should have
+			// failed to compile).
+			if t.Sym().Pkg != LocalPkg || done[t] {
+				return
+			}
 
-		*path = append(*path, t)
-		if findTypeLoop(t.Obj().(TypeObject).TypeDefn(), path) {
-			return true
+			for i, x := range path {
+				if x == t {
+					loops = append(loops, append([]*Type(nil), path[i:]...))
+					return
+				}
+			}
+
+			path = append(path, t)
+			visit(t.Obj().(TypeObject).TypeDefn())
+			path = path[:len(path)-1]
+			done[t] = true
+			return
 		}
-		*path = (*path)[:len(*path)-1]
-	} else {
-		// Anonymous type. Recurse on contained types.
 
+		// Anonymous type. Recurse on contained types.
 		switch t.Kind() {
 		case TARRAY:
-			if findTypeLoop(t.Elem(), path) {
-				return true
-			}
+			visit(t.Elem())
 		case TSTRUCT:
 			for _, f := range t.Fields().Slice() {
-				if findTypeLoop(f.Type, path) {
-					return true
-				}
+				visit(f.Type)
 			}
 		case TINTER:
 			for _, m := range t.Methods().Slice() {
 				if m.Type.IsInterface() { // embedded interface
-					if findTypeLoop(m.Type, path) {
-						return true
-					}
+					visit(m.Type)
 				}
 			}
 		}
 	}
-
-	return false
+	visit(t)
+	return loops
 }
 
+// reportTypeLoop reports each invalid type declaration loop that can
+// be reached from type t, so that independent loops sharing some of
+// their types are all reported at once, and marks their types broken.
+// Loops whose types are all broken have been reported already.
 func reportTypeLoop(t *Type) {
 	if t.Broke() {
 		return
 	}
 
-	var l []*Type
-	if !findTypeLoop(t, &l) {
+	loops := findTypeLoops(t)
+	if len(loops) == 0 {
 		base.Fatalf("failed to find type loop for: %v", t)
 	}
 
-	// Rotate loop so that the earliest type declaration is first.
-	i := 0
-	for j, t := range l[1:] {
-		if typePos(t).Before(typePos(l[i])) {
-			i = j + 1
+	for _, l := range loops {
+		if allBroken(l) {
+			continue
+		}
+
+		// Rotate loop so that the earliest type declaration is first.
+		i := 0
+		for j, t := range l[1:] {
+			if typePos(t).Before(typePos(l[i])) {
+				i = j + 1
+			}
+		}
+		l = append(l[i:], l[:i]...)
+
+		var notes []base.Note
+		for _, t := range l {
+			notes = append(notes, base.Note{Pos: typePos(t), Msg: fmt.Sprintf("%v refers to", t)})
+		}
+		notes = append(notes, base.Note{Pos: typePos(l[0]), Msg: fmt.Sprint(l[0])})
+		base.ErrorfAtNotes(typePos(l[0]), base.CodeTypeLoop, notes, "invalid recursive type %v", l[0])
+	}
+	for _, l := range loops {
+		for _, t := range l {
+			t.SetBroke(true)
 		}
 	}
-	l = append(l[i:], l[:i]...)
+}
 
-	var msg bytes.Buffer
-	fmt.Fprintf(&msg, "invalid recursive type %v\n", l[0])
+// allBroken reports whether all of the types in l are marked broken.
+func allBroken(l []*Type) bool {
 	for _, t := range l {
-		fmt.Fprintf(&msg, "\t%v: %v refers to\n", base.FmtPos(typePos(t)), t)
-		t.SetBroke(true)
+		if !t.Broke() {
+			return false
+		}
 	}
-	fmt.Fprintf(&msg, "\t%v: %v", base.FmtPos(typePos(l[0])), l[0])
-	base.ErrorfAt(typePos(l[0]), msg.String())
+	return true
 }
 
 // CalcSize calculates and stores the size and alignment for t.
-// If CalcSizeDisabled is set, and the size/alignment
-// have not already been calculated, it calls Fatal.
-// This is used to prevent data races in the back end.
+// It is safe to call from concurrent back end workers.
 func CalcSize(t *Type) {
+	defer lockSizes()()
+	calcSize(t)
+}
+
+func calcSize(t *Type) {
 	// Calling CalcSize when typecheck tracing enabled is not safe.
 	// See issue #33658.
 	if base.EnableTrace && SkipSizeForTracing {
@@ -319,6 +511,7 @@ func CalcSize(t *Type) {
 	}
 
 	if t.Width == -2 {
+		traceSize(1, t, "calc %v: invalid recursive type", t)
 		reportTypeLoop(t)
 		t.Width = 0
 		t.Align = 1
@@ -329,15 +522,6 @@ func CalcSize(t *Type) {
 		return
 	}
 
-	if CalcSizeDisabled {
-		if t.Broke() {
-			// break infinite recursion from Fatal call below
-			return
-		}
-		t.SetBroke(true)
-		base.Fatalf("width not calculated: %v", t)
-	}
-
 	// break infinite recursion if the broken recursive type
 	// is referenced again
 	if t.Broke() && t.Width == 0 {
@@ -345,10 +529,13 @@ func CalcSize(t *Type) {
 	}
 
 	// defer CheckSize calls until after we're done
-	DeferCheckSize()
+	deferCheckSize()
 
+	// Concurrent back end workers may read base.Pos, so leave it
+	// alone then. Errors are reported at typePos anyway.
+	parallel := base.Ctxt != nil && base.Ctxt.InParallel
 	lno := base.Pos
-	if pos := t.Pos(); pos.IsKnown() {
+	if pos := t.Pos(); pos.IsKnown() && !parallel {
 		base.Pos = pos
 	}
 
@@ -397,7 +584,7 @@ func CalcSize(t *Type) {
 
 	case TPTR:
 		w = int64(PtrSize)
-		CheckSize(t.Elem())
+		checkSize(t.Elem())
 
 	case TUNSAFEPTR:
 		w = int64(PtrSize)
@@ -410,25 +597,25 @@ func CalcSize(t *Type) {
 	case TCHAN: // implemented as pointer
 		w = int64(PtrSize)
 
-		CheckSize(t.Elem())
+		checkSize(t.Elem())
 
 		// make fake type to check later to
 		// trigger channel argument check.
 		t1 := NewChanArgs(t)
-		CheckSize(t1)
+		checkSize(t1)
 
 	case TCHANARGS:
 		t1 := t.ChanArgs()
-		CalcSize(t1) // just in case
+		calcSize(t1) // just in case
 		if t1.Elem().Width >= 1<<16 {
-			base.ErrorfAt(typePos(t1), "channel element type too large (>64kB)")
+			base.ErrorfAtCode(typePos(t1), base.CodeTypeTooLarge, "channel element type too large (>64kB)")
 		}
 		w = 1 // anything will do
 
 	case TMAP: // implemented as pointer
 		w = int64(PtrSize)
-		CheckSize(t.Elem())
-		CheckSize(t.Key())
+		checkSize(t.Elem())
+		checkSize(t.Key())
 
 	case TFORW: // should have been filled in
 		reportTypeLoop(t)
@@ -450,11 +637,11 @@ func CalcSize(t *Type) {
 			break
 		}
 
-		CalcSize(t.Elem())
+		calcSize(t.Elem())
 		if t.Elem().Width != 0 {
 			cap := (uint64(MaxWidth) - 1) / uint64(t.Elem().Width)
 			if uint64(t.NumElem()) > cap {
-				base.ErrorfAt(typePos(t), "type %L larger than address space", t)
+				base.ErrorfAtCode(typePos(t), base.CodeTypeTooLarge, "type %L larger than address space", t)
 			}
 		}
 		w = t.NumElem() * t.Elem().Width
@@ -465,7 +652,7 @@ func CalcSize(t *Type) {
 			break
 		}
 		w = SliceSize
-		CheckSize(t.Elem())
+		checkSize(t.Elem())
 		t.Align = uint8(PtrSize)
 
 	case TSTRUCT:
@@ -478,7 +665,7 @@ func CalcSize(t *Type) {
 	// trigger function argument computation.
 	case TFUNC:
 		t1 := NewFuncArgs(t)
-		CheckSize(t1)
+		checkSize(t1)
 		w = int64(PtrSize) // width of func type is pointer
 
 	// function is 3 cated structures;
@@ -501,7 +688,7 @@ func CalcSize(t *Type) {
 	}
 
 	if PtrSize == 4 && w != int64(int32(w)) {
-		base.ErrorfAt(typePos(t), "type %v too large", t)
+		base.ErrorfAtCode(typePos(t), base.CodeTypeTooLarge, "type %v too large", t)
 	}
 
 	t.Width = w
@@ -512,15 +699,19 @@ func CalcSize(t *Type) {
 		t.Align = uint8(w)
 	}
 
-	base.Pos = lno
+	if !parallel {
+		base.Pos = lno
+	}
 
-	ResumeCheckSize()
+	traceSize(1, t, "calc %v: width=%d align=%d", t, t.Width, t.Align)
+	resumeCheckSize()
 }
 
 // CalcStructSize calculates the size of s,
 // filling in s.Width and s.Align,
 // even if size calculation is otherwise disabled.
 func CalcStructSize(s *Type) {
+	defer lockSizes()()
 	s.Width = calcStructOffset(s, s, 0, 1) // sets align
 }
 
@@ -540,9 +731,12 @@ func CalcStructSize(s *Type) {
 // is needed immediately.  CheckSize makes sure the
 // size is evaluated eventually.
 
-var deferredTypeStack []*Type
-
 func CheckSize(t *Type) {
+	defer lockSizes()()
+	checkSize(t)
+}
+
+func checkSize(t *Type) {
 	if t == nil {
 		return
 	}
@@ -553,33 +747,65 @@ func CheckSize(t *Type) {
 		base.Fatalf("CheckSize %v", t)
 	}
 
-	if defercalc == 0 {
-		CalcSize(t)
+	if sizes.deferDepth == 0 {
+		traceSize(2, t, "check %v", t)
+		calcSize(t)
 		return
 	}
 
-	// if type has not yet been pushed on deferredTypeStack yet, do it now
+	// if type has not yet been pushed on sizes.deferred yet, do it now
 	if !t.Deferwidth() {
+		traceSize(2, t, "check %v: deferred", t)
 		t.SetDeferwidth(true)
-		deferredTypeStack = append(deferredTypeStack, t)
+		sizes.deferred = append(sizes.deferred, t)
 	}
 }
 
 func DeferCheckSize() {
-	defercalc++
+	defer lockSizes()()
+	traceSize(2, nil, "defer checks")
+	deferCheckSize()
+}
+
+func deferCheckSize() {
+	sizes.deferDepth++
 }
 
 func ResumeCheckSize() {
-	if defercalc == 1 {
-		for len(deferredTypeStack) > 0 {
-			t := deferredTypeStack[len(deferredTypeStack)-1]
-			deferredTypeStack = deferredTypeStack[:len(deferredTypeStack)-1]
+	defer lockSizes()()
+	traceSize(2, nil, "resume checks, %d deferred", len(sizes.deferred))
+	resumeCheckSize()
+}
+
+func resumeCheckSize() {
+	if sizes.deferDepth == 1 {
+		for len(sizes.deferred) > 0 {
+			t := sizes.deferred[len(sizes.deferred)-1]
+			sizes.deferred = sizes.deferred[:len(sizes.deferred)-1]
 			t.SetDeferwidth(false)
-			CalcSize(t)
+			calcSize(t)
 		}
 	}
 
-	defercalc--
+	sizes.deferDepth--
+}
+
+// traceSize prints a trace message for -d=align, if it is at least
+// level. The message is indented by the CheckSize deferral depth and
+// positioned at t, if known, or else at the current position.
+func traceSize(level int, t *Type, format string, args ...interface{}) {
+	if base.Debug.Align < level {
+		return
+	}
+	pos := base.Pos
+	if t != nil && t.Pos().IsKnown() {
+		pos = t.Pos()
+	}
+	prefix := ""
+	if pos.IsKnown() {
+		prefix = base.FmtPos(pos) + ": "
+	}
+	fmt.Printf("%s%s%s\n", prefix, strings.Repeat(". ", sizes.deferDepth), fmt.Sprintf(format, args...))
 }
 
 // PtrDataSize returns the length in bytes of the prefix of t
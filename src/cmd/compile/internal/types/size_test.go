@@ -0,0 +1,62 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"go/layout"
+	"sync"
+	"testing"
+
+	"cmd/compile/internal/base"
+	"cmd/internal/obj"
+	"cmd/internal/src"
+	"cmd/internal/sys"
+)
+
+// TestCalcSizeParallel checks that concurrent back end workers can
+// calculate the sizes of types they create, which refer to a type
+// whose size has not been calculated yet.
+func TestCalcSizeParallel(t *testing.T) {
+	defer func(ptrSize, regSize int, maxWidth int64, ctxt *obj.Link) {
+		PtrSize, RegSize, MaxWidth, base.Ctxt = ptrSize, regSize, maxWidth, ctxt
+	}(PtrSize, RegSize, MaxWidth, base.Ctxt)
+	PtrSize, RegSize, MaxWidth = 8, 8, 1<<50
+	base.Ctxt = &obj.Link{InParallel: true}
+
+	i64 := New(TINT64)
+	shared := NewStruct(NoPkg, []*Field{
+		NewField(src.NoXPos, nil, i64),
+		NewField(src.NoXPos, nil, NewPtr(i64)),
+	})
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(n int64) {
+			defer wg.Done()
+			a := NewArray(shared, n)
+			if got, want := a.Size(), 16*n; got != want {
+				t.Errorf("size of %v = %d, want %d", a, got, want)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+}
+
+// TestLayoutArchs checks that go/layout, which computes struct layout
+// for the compiler and for go/types, agrees with the architecture
+// table the compiler is configured from.
+func TestLayoutArchs(t *testing.T) {
+	for _, arch := range sys.Archs {
+		a := layout.ArchFor(arch.Name)
+		if a == nil {
+			t.Errorf("layout.ArchFor(%q) = nil", arch.Name)
+			continue
+		}
+		if a.PtrSize != int64(arch.PtrSize) || a.RegSize != int64(arch.RegSize) {
+			t.Errorf("layout.ArchFor(%q) = %+v, want PtrSize %d, RegSize %d", arch.Name, *a, arch.PtrSize, arch.RegSize)
+		}
+	}
+}
@@ -195,7 +195,7 @@ const (
 	typeNotInHeap  = 1 << iota // type cannot be heap allocated
 	typeBroke                  // broken type definition
 	typeNoalg                  // suppress hash and eq algorithm generation
-	typeDeferwidth             // width computation has been deferred and type is on deferredTypeStack
+	typeDeferwidth             // width computation has been deferred and type is on sizes.deferred
 	typeRecur
 	typeHasTParam // there is a typeparam somewhere in the type (generic function or type)
 )
@@ -163,11 +163,24 @@ func TestStdTest(t *testing.T) {
 	}
 
 	testTestDir(t, filepath.Join(runtime.GOROOT(), "test"),
-		"cmplxdivide.go", // also needs file cmplxdivide1.go - ignore
-		"directive.go",   // tests compiler rejection of bad directive placement - ignore
-		"embedfunc.go",   // tests //go:embed
-		"embedvers.go",   // tests //go:embed
-		"linkname2.go",   // types2 doesn't check validity of //go:xxx directives
+		"cmplxdivide.go",     // also needs file cmplxdivide1.go - ignore
+		"cgolayout.go",       // types2 doesn't check validity of //go:xxx directives
+		"directive.go",       // tests compiler rejection of bad directive placement - ignore
+		"directive2.go",      // tests compiler rejection of bad directive placement - ignore
+		"embedfunc.go",       // tests //go:embed
+		"embedvers.go",       // tests //go:embed
+		"embedcompress.go",   // tests //go:embedcompress
+		"linkname2.go",       // types2 doesn't check validity of //go:xxx directives
+		"linknamesig.go",     // types2 doesn't check //go:linkname signatures
+		"langdirective2.go",  // types2 doesn't check validity of //go:xxx directives
+		"asmoffsets.go",      // types2 doesn't check validity of //go:xxx directives
+		"layoutcheck.go",     // types2 doesn't check validity of //go:xxx directives
+		"layoutcheck2.go",    // types2 doesn't check validity of //go:xxx directives
+		"nocheckbounds2.go",  // types2 doesn't check validity of //go:xxx directives
+		"purecalls2.go",      // types2 doesn't check validity of //go:xxx directives
+		"wasmexport.go",      // types2 doesn't check validity of //go:xxx directives
+		"warn2.go",           // types2 doesn't report compiler warnings
+		"escape_noescape.go", // types2 doesn't check validity of //go:xxx directives
 	)
 }
 
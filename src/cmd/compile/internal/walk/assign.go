@@ -27,6 +27,9 @@ func walkAssign(init *ir.Nodes, n ir.Node) ir.Node {
 	case ir.OASOP:
 		n := n.(*ir.AssignOpStmt)
 		left, right = n.X, n.Y
+		if isAtomicCoverageUpdate(n) {
+			return mkcall("coverIncAtomic", nil, init, typecheck.NodAddr(left))
+		}
 	}
 
 	// Recognize m[k] = append(m[k], ...) so we can reuse
@@ -115,6 +118,16 @@ func walkAssign(init *ir.Nodes, n ir.Node) ir.Node {
 	return as
 }
 
+// isAtomicCoverageUpdate reports whether n updates one of the counters
+// added by -covermode=atomic, and so must be done atomically.
+func isAtomicCoverageUpdate(n *ir.AssignOpStmt) bool {
+	if base.Flag.CoverMode != "atomic" || n.X.Op() != ir.OINDEX {
+		return false
+	}
+	x := n.X.(*ir.IndexExpr).X
+	return x.Op() == ir.ONAME && x.(*ir.Name).CoverageCounter()
+}
+
 // walkAssignDotType walks an OAS2DOTTYPE node.
 func walkAssignDotType(n *ir.AssignListStmt, init *ir.Nodes) ir.Node {
 	walkExprListSafe(n.Lhs, init)
@@ -151,6 +164,9 @@ func walkAssignMapRead(init *ir.Nodes, n *ir.AssignListStmt) ir.Node {
 	init.Append(ir.TakeInit(n)...)
 
 	r := n.Rhs[0].(*ir.IndexExpr)
+	if sm := staticMapOf(r.X); sm != nil {
+		return walkStaticMapRead(init, n, sm)
+	}
 	walkExprListSafe(n.Lhs, init)
 	r.X = walkExpr(r.X, init)
 	r.Index = walkExpr(r.Index, init)
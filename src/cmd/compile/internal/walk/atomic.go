@@ -0,0 +1,111 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"go/constant"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/types"
+	"cmd/internal/objabi"
+)
+
+// atomic64Funcs is the set of functions in sync/atomic that operate
+// on the 64-bit word their first argument points to.
+var atomic64Funcs = map[string]bool{
+	"AddInt64":             true,
+	"AddUint64":            true,
+	"CompareAndSwapInt64":  true,
+	"CompareAndSwapUint64": true,
+	"LoadInt64":            true,
+	"LoadUint64":           true,
+	"StoreInt64":           true,
+	"StoreUint64":          true,
+	"SwapInt64":            true,
+	"SwapUint64":           true,
+}
+
+// checkAtomicAlign reports the calls in fn to 64-bit sync/atomic
+// operations on words that are known not to be 8-byte aligned.
+//
+// On 32-bit systems, 64-bit words are only 4-byte aligned, and the
+// 64-bit atomic operations panic on a word that is not 8-byte aligned.
+// Only the first word of a variable or of an allocated struct, array,
+// or slice is known to be 8-byte aligned, so reordering the fields of
+// a struct can break code that was correct. Words at a constant offset
+// from such a first word are checked here, at compile time.
+func checkAtomicAlign(fn *ir.Func) {
+	if types.RegSize != 4 {
+		return
+	}
+	ir.VisitList(fn.Body, func(n ir.Node) {
+		if n.Op() != ir.OCALLFUNC {
+			return
+		}
+		call := n.(*ir.CallExpr)
+		if call.X.Op() != ir.ONAME || len(call.Args) == 0 {
+			return
+		}
+		name := call.X.(*ir.Name)
+		if name.Class != ir.PFUNC || name.Sym().Pkg.Path != "sync/atomic" || !atomic64Funcs[name.Sym().Name] {
+			return
+		}
+		if base.Ctxt.PosTable.Pos(call.Pos()).Base().InliningIndex() >= 0 {
+			// Checked when compiling the inlined function.
+			return
+		}
+		arg := call.Args[0]
+		if arg.Op() != ir.OADDR {
+			return
+		}
+		x := arg.(*ir.AddrExpr).X
+		if off, ok := alignedOffset(x); ok && off%8 != 0 {
+			base.ErrorfAt(call.Pos(), "atomic.%s: %v is at offset %d from an 8-byte aligned word, but 64-bit atomic operations on GOARCH=%s require 8-byte alignment", name.Sym().Name, x, off, objabi.GOARCH)
+		}
+	})
+}
+
+// alignedOffset returns the offset of the addressable expression n
+// from the start of the variable or allocated object containing it,
+// which is known to be 8-byte aligned. It reports false if the offset
+// is not known at compile time.
+func alignedOffset(n ir.Node) (int64, bool) {
+	switch n.Op() {
+	case ir.ONAME:
+		return 0, true
+
+	case ir.ODEREF:
+		return 0, true
+
+	case ir.ODOTPTR:
+		n := n.(*ir.SelectorExpr)
+		types.CalcSize(n.X.Type().Elem())
+		return n.Offset(), true
+
+	case ir.ODOT:
+		n := n.(*ir.SelectorExpr)
+		types.CalcSize(n.X.Type())
+		off, ok := alignedOffset(n.X)
+		return off + n.Offset(), ok
+
+	case ir.OINDEX:
+		n := n.(*ir.IndexExpr)
+		if !ir.IsConst(n.Index, constant.Int) {
+			return 0, false
+		}
+		i := ir.Int64Val(n.Index)
+		t := n.X.Type()
+		types.CalcSize(t)
+		switch {
+		case t.IsSlice():
+			return i * t.Elem().Width, true
+		case t.IsArray():
+			off, ok := alignedOffset(n.X)
+			return off + i*t.Elem().Width, ok
+		}
+	}
+	return 0, false
+}
@@ -228,6 +228,12 @@ func walkLenCap(n *ir.UnaryExpr, init *ir.Nodes) ir.Node {
 		return mkcall("countrunes", n.Type(), init, typecheck.Conv(n.X.(*ir.ConvExpr).X, types.Types[types.TSTRING]))
 	}
 
+	if sm := staticMapOf(n.X); sm != nil {
+		con := typecheck.OrigInt(n, sm.count)
+		con.SetTypecheck(1)
+		return con
+	}
+
 	n.X = walkExpr(n.X, init)
 
 	// replace len(*[10]int) with 10.
@@ -379,6 +385,9 @@ func walkMakeSlice(n *ir.MakeExpr, init *ir.Nodes) ir.Node {
 		if why := escape.HeapAllocReason(n); why != "" {
 			base.Fatalf("%v has EscNone, but %v", n, why)
 		}
+		if !ir.IsConst(r, constant.Int) {
+			return walkMakeSliceBounded(n, l, r, init)
+		}
 		// var arr [r]T
 		// n = arr[:l]
 		i := typecheck.IndexConst(r)
@@ -436,6 +445,48 @@ func walkMakeSlice(n *ir.MakeExpr, init *ir.Nodes) ir.Node {
 	return walkExpr(typecheck.Expr(m), init)
 }
 
+// walkMakeSliceBounded walks a non-escaping OMAKESLICE node whose
+// capacity r is not constant but has a small constant upper bound
+// (see escape.MaxIntBound).
+func walkMakeSliceBounded(n *ir.MakeExpr, l, r ir.Node, init *ir.Nodes) ir.Node {
+	c := n.Cap
+	if c == nil {
+		c = n.Len
+	}
+	max, ok := escape.MaxIntBound(c)
+	if !ok {
+		base.Fatalf("walkMakeSlice: unbounded cap %v", c)
+	}
+	if l == r {
+		l = cheapExpr(l, init)
+		r = l
+	} else {
+		l = cheapExpr(l, init)
+		r = cheapExpr(r, init)
+	}
+
+	// r is known to be in [0, max], so only len needs checking:
+	//
+	// if uint64(len) > uint64(cap) {
+	//     if len < 0 { panicmakeslicelen() }
+	//     panicmakeslicecap()
+	// }
+	nif := ir.NewIfStmt(base.Pos, ir.NewBinaryExpr(base.Pos, ir.OGT, typecheck.Conv(l, types.Types[types.TUINT64]), typecheck.Conv(r, types.Types[types.TUINT64])), nil, nil)
+	niflen := ir.NewIfStmt(base.Pos, ir.NewBinaryExpr(base.Pos, ir.OLT, l, ir.NewInt(0)), nil, nil)
+	niflen.Body = []ir.Node{mkcall("panicmakeslicelen", nil, init)}
+	nif.Body.Append(niflen, mkcall("panicmakeslicecap", nil, init))
+	init.Append(typecheck.Stmt(nif))
+
+	// var arr [max]T
+	// n = arr[:l:r]
+	t := types.NewArray(n.Type().Elem(), max)
+	var_ := typecheck.Temp(t)
+	appendWalkStmt(init, ir.NewAssignStmt(base.Pos, var_, nil)) // zero temp
+	s := ir.NewSliceExpr(base.Pos, ir.OSLICE3, var_, nil, l, r) // arr[:l:r]
+	// The conv is necessary in case n.Type is named.
+	return walkExpr(typecheck.Expr(typecheck.Conv(s, n.Type())), init)
+}
+
 // walkMakeSliceCopy walks an OMAKESLICECOPY node.
 func walkMakeSliceCopy(n *ir.MakeExpr, init *ir.Nodes) ir.Node {
 	if n.Esc() == ir.EscNone {
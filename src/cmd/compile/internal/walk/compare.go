@@ -6,7 +6,10 @@ package walk
 
 import (
 	"encoding/binary"
+	"fmt"
 	"go/constant"
+	"hash/fnv"
+	"io"
 
 	"cmd/compile/internal/base"
 	"cmd/compile/internal/ir"
@@ -89,7 +92,9 @@ func walkCompare(n *ir.BinaryExpr, init *ir.Nodes) ir.Node {
 
 	switch t.Kind() {
 	default:
-		if base.Debug.Libfuzzer != 0 && t.IsInteger() {
+		// Don't trace the comparisons of the coverage counters
+		// that edge adds.
+		if base.Debug.Libfuzzer != 0 && t.IsInteger() && (n.X.Name() == nil || !n.X.Name().LibfuzzerExtraCounter()) {
 			n.X = cheapExpr(n.X, init)
 			n.Y = cheapExpr(n.Y, init)
 
@@ -133,7 +138,7 @@ func walkCompare(n *ir.BinaryExpr, init *ir.Nodes) ir.Node {
 			default:
 				base.Fatalf("unexpected integer size %d for %v", t.Size(), t)
 			}
-			init.Append(mkcall(fn, nil, init, tracecmpArg(l, paramType, init), tracecmpArg(r, paramType, init)))
+			init.Append(mkcall(fn, nil, init, tracecmpArg(l, paramType, init), tracecmpArg(r, paramType, init), fakePC(n)))
 		}
 		return n
 	case types.TARRAY:
@@ -283,6 +288,15 @@ func walkCompareInterface(n *ir.BinaryExpr, init *ir.Nodes) ir.Node {
 }
 
 func walkCompareString(n *ir.BinaryExpr, init *ir.Nodes) ir.Node {
+	if base.Debug.Libfuzzer != 0 && !(ir.IsConst(n.X, constant.String) && ir.IsConst(n.Y, constant.String)) {
+		// Tell libfuzzer about the strings being compared,
+		// so it can try them as inputs.
+		n.X = cheapExpr(n.X, init)
+		n.Y = cheapExpr(n.Y, init)
+		t := types.Types[types.TSTRING]
+		init.Append(mkcall("libfuzzerHookStrCmp", nil, init, tracecmpArg(n.X, t, init), tracecmpArg(n.Y, t, init), fakePC(n)))
+	}
+
 	// Rewrite comparisons to short constant strings as length+byte-wise comparisons.
 	var cs, ncs ir.Node // const string, non-const string
 	switch {
@@ -491,6 +505,24 @@ func tracecmpArg(n ir.Node, t *types.Type, init *ir.Nodes) ir.Node {
 	return typecheck.Conv(n, t)
 }
 
+// fakePC returns a pseudo program counter for the comparison n, for
+// libfuzzer to tell comparisons apart in its value profile. The
+// comparisons are all made from the same few runtime functions, so
+// their real PCs can't be used. The value depends only on the position
+// of n and on n itself, which keeps it stable across builds.
+func fakePC(n ir.Node) ir.Node {
+	h := fnv.New32a()
+	pos := base.Ctxt.PosTable.Pos(n.Pos())
+	io.WriteString(h, base.Ctxt.Pkgpath)
+	io.WriteString(h, pos.AbsFilename())
+	binary.Write(h, binary.LittleEndian, int64(pos.Line()))
+	binary.Write(h, binary.LittleEndian, int64(pos.Col()))
+	// Compiler-generated comparisons share the position of the
+	// expression they come from.
+	fmt.Fprintf(h, "%v", n)
+	return ir.NewInt(int64(h.Sum32()))
+}
+
 // canMergeLoads reports whether the backend optimization passes for
 // the current architecture can combine adjacent loads into a single
 // larger, possibly unaligned, load. Note that currently the
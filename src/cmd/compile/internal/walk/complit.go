@@ -7,6 +7,7 @@ package walk
 import (
 	"cmd/compile/internal/base"
 	"cmd/compile/internal/ir"
+	"cmd/compile/internal/reflectdata"
 	"cmd/compile/internal/ssagen"
 	"cmd/compile/internal/staticdata"
 	"cmd/compile/internal/staticinit"
@@ -433,8 +434,9 @@ func maplit(n *ir.CompLitExpr, m ir.Node, init *ir.Nodes) {
 		}
 	}
 
-	if len(entries) > 25 {
-		// For a large number of entries, put them in an array and loop.
+	if len(entries) > 8 {
+		// For a large number of entries, put them in read-only
+		// arrays and insert them all with one runtime call.
 
 		// build types [count]Tindex and [count]Tvalue
 		tk := types.NewArray(n.Type().Key(), int64(len(entries)))
@@ -460,28 +462,13 @@ func maplit(n *ir.CompLitExpr, m ir.Node, init *ir.Nodes) {
 		fixedlit(inInitFunction, initKindStatic, datak, vstatk, init)
 		fixedlit(inInitFunction, initKindStatic, datae, vstate, init)
 
-		// loop adding structure elements to map
-		// for i = 0; i < len(vstatk); i++ {
-		//	map[vstatk[i]] = vstate[i]
-		// }
-		i := typecheck.Temp(types.Types[types.TINT])
-		rhs := ir.NewIndexExpr(base.Pos, vstate, i)
-		rhs.SetBounded(true)
-
-		kidx := ir.NewIndexExpr(base.Pos, vstatk, i)
-		kidx.SetBounded(true)
-		lhs := ir.NewIndexExpr(base.Pos, m, kidx)
-
-		zero := ir.NewAssignStmt(base.Pos, i, ir.NewInt(0))
-		cond := ir.NewBinaryExpr(base.Pos, ir.OLT, i, ir.NewInt(tk.NumElem()))
-		incr := ir.NewAssignStmt(base.Pos, i, ir.NewBinaryExpr(base.Pos, ir.OADD, i, ir.NewInt(1)))
-		body := ir.NewAssignStmt(base.Pos, lhs, rhs)
-
-		loop := ir.NewForStmt(base.Pos, nil, cond, incr, nil)
-		loop.Body = []ir.Node{body}
-		*loop.PtrInit() = []ir.Node{zero}
-
-		appendWalkStmt(init, loop)
+		// mapinitbulk(maptype, m, &vstatk, &vstate, count)
+		t := m.Type()
+		fn := typecheck.LookupRuntime("mapinitbulk")
+		fn = typecheck.SubstArgTypes(fn, t.Key(), t.Elem())
+		keys := typecheck.ConvNop(typecheck.NodAddr(vstatk), types.Types[types.TUNSAFEPTR])
+		elems := typecheck.ConvNop(typecheck.NodAddr(vstate), types.Types[types.TUNSAFEPTR])
+		appendWalkStmt(init, mkcallstmt1(fn, reflectdata.TypePtr(t), m, keys, elems, ir.NewInt(tk.NumElem())))
 		return
 	}
 	// For a small number of entries, just add them directly.
@@ -6,6 +6,7 @@ package walk
 
 import (
 	"encoding/binary"
+	"fmt"
 	"go/constant"
 
 	"cmd/compile/internal/base"
@@ -19,16 +20,21 @@ import (
 
 // walkConv walks an OCONV or OCONVNOP (but not OCONVIFACE) node.
 func walkConv(n *ir.ConvExpr, init *ir.Nodes) ir.Node {
+	var site string
+	if n.Op() == ir.OCONVNOP && ir.ShouldCheckPtr(ir.CurFunc, 1) {
+		// Describe the conversion before walking its operand.
+		site = checkptrSite(n)
+	}
 	n.X = walkExpr(n.X, init)
 	if n.Op() == ir.OCONVNOP && n.Type() == n.X.Type() {
 		return n.X
 	}
 	if n.Op() == ir.OCONVNOP && ir.ShouldCheckPtr(ir.CurFunc, 1) {
 		if n.Type().IsPtr() && n.X.Type().IsUnsafePtr() { // unsafe.Pointer to *T
-			return walkCheckPtrAlignment(n, init, nil)
+			return walkCheckPtrAlignment(n, init, nil, site)
 		}
 		if n.Type().IsUnsafePtr() && n.X.Type().IsUintptr() { // uintptr to unsafe.Pointer
-			return walkCheckPtrArithmetic(n, init)
+			return walkCheckPtrArithmetic(n, init, site)
 		}
 	}
 	param, result := rtconvfn(n.X.Type(), n.Type())
@@ -55,6 +61,9 @@ func walkConvInterface(n *ir.ConvExpr, init *ir.Nodes) ir.Node {
 		if toType.IsEmptyInterface() {
 			return reflectdata.TypePtr(fromType)
 		}
+		if reflectdata.LazyITabs() {
+			return lazyITab(fromType, toType, init)
+		}
 		return reflectdata.ITabAddr(fromType, toType)
 	}
 
@@ -185,6 +194,26 @@ func walkConvInterface(n *ir.ConvExpr, init *ir.Nodes) ir.Node {
 	return walkExpr(typecheck.Expr(call), init)
 }
 
+// lazyITab returns an expression for the itab of fromType and toType
+// under -d=lazyitabs. It appends to init the code that loads the itab
+// from its cache, filling the cache on first use:
+//
+//	tab := cache.tab
+//	if tab == nil {
+//		tab = lazyitab(&cache)
+//	}
+func lazyITab(fromType, toType *types.Type, init *ir.Nodes) ir.Node {
+	cache := reflectdata.ITabCache(fromType, toType)
+	tab := typecheck.Temp(cache.Type())
+	init.Append(typecheck.Stmt(ir.NewAssignStmt(base.Pos, tab, cache)))
+
+	nif := ir.NewIfStmt(base.Pos, typecheck.Expr(ir.NewBinaryExpr(base.Pos, ir.OEQ, tab, typecheck.NodNil())), nil, nil)
+	call := mkcall("lazyitab", tab.Type(), &nif.Body, typecheck.NodAddr(cache))
+	nif.Body.Append(typecheck.Stmt(ir.NewAssignStmt(base.Pos, tab, call)))
+	init.Append(nif)
+	return tab
+}
+
 // walkBytesRunesToString walks an OBYTES2STR or ORUNES2STR node.
 func walkBytesRunesToString(n *ir.ConvExpr, init *ir.Nodes) ir.Node {
 	a := typecheck.NodNil()
@@ -276,8 +305,10 @@ func walkStringToBytesTemp(n *ir.ConvExpr, init *ir.Nodes) ir.Node {
 	// This conversion is handled later by the backend and
 	// is only for use by internal compiler optimizations
 	// that know that the slice won't be mutated.
-	// The only such case today is:
+	// The cases today are:
 	// for i, c := range []byte(string)
+	// and conversions that escape analysis finds are never
+	// written through.
 	n.X = walkExpr(n.X, init)
 	return n
 }
@@ -403,7 +434,18 @@ func byteindex(n ir.Node) ir.Node {
 	return n
 }
 
-func walkCheckPtrAlignment(n *ir.ConvExpr, init *ir.Nodes, count ir.Node) ir.Node {
+// checkptrSite returns the description of the conversion n passed to
+// the checkptr runtime functions: with -d=checkptrsite, its position
+// and text, and otherwise the empty string.
+func checkptrSite(n *ir.ConvExpr) string {
+	if base.Debug.CheckptrSite == 0 {
+		return ""
+	}
+	pos := base.Ctxt.InnermostPos(n.Pos())
+	return fmt.Sprintf("%s:%d:%d: %v", pos.AbsFilename(), pos.Line(), pos.Col(), n)
+}
+
+func walkCheckPtrAlignment(n *ir.ConvExpr, init *ir.Nodes, count ir.Node, site string) ir.Node {
 	if !n.Type().IsPtr() {
 		base.Fatalf("expected pointer type: %v", n.Type())
 	}
@@ -425,11 +467,11 @@ func walkCheckPtrAlignment(n *ir.ConvExpr, init *ir.Nodes, count ir.Node) ir.Nod
 	}
 
 	n.X = cheapExpr(n.X, init)
-	init.Append(mkcall("checkptrAlignment", nil, init, typecheck.ConvNop(n.X, types.Types[types.TUNSAFEPTR]), reflectdata.TypePtr(elem), typecheck.Conv(count, types.Types[types.TUINTPTR])))
+	init.Append(mkcall("checkptrAlignment", nil, init, typecheck.ConvNop(n.X, types.Types[types.TUNSAFEPTR]), reflectdata.TypePtr(elem), typecheck.Conv(count, types.Types[types.TUINTPTR]), ir.NewString(site)))
 	return n
 }
 
-func walkCheckPtrArithmetic(n *ir.ConvExpr, init *ir.Nodes) ir.Node {
+func walkCheckPtrArithmetic(n *ir.ConvExpr, init *ir.Nodes, site string) ir.Node {
 	// Calling cheapExpr(n, init) below leads to a recursive call to
 	// walkExpr, which leads us back here again. Use n.Checkptr to
 	// prevent infinite loops.
@@ -482,7 +524,7 @@ func walkCheckPtrArithmetic(n *ir.ConvExpr, init *ir.Nodes) ir.Node {
 	slice := typecheck.MakeDotArgs(types.NewSlice(types.Types[types.TUNSAFEPTR]), originals)
 	slice.SetEsc(ir.EscNone)
 
-	init.Append(mkcall("checkptrArithmetic", nil, init, typecheck.ConvNop(cheap, types.Types[types.TUNSAFEPTR]), slice))
+	init.Append(mkcall("checkptrArithmetic", nil, init, typecheck.ConvNop(cheap, types.Types[types.TUNSAFEPTR]), slice, ir.NewString(site)))
 	// TODO(khr): Mark backing store of slice as dead. This will allow us to reuse
 	// the backing store for multiple calls to checkptrArithmetic.
 
@@ -16,7 +16,6 @@ import (
 	"cmd/compile/internal/typecheck"
 	"cmd/compile/internal/types"
 	"cmd/internal/obj"
-	"cmd/internal/objabi"
 )
 
 // The result of walkExpr MUST be assigned back to n, e.g.
@@ -626,7 +625,8 @@ func walkDot(n *ir.SelectorExpr, init *ir.Nodes) ir.Node {
 func walkDotType(n *ir.TypeAssertExpr, init *ir.Nodes) ir.Node {
 	n.X = walkExpr(n.X, init)
 	// Set up interface type addresses for back end.
-	if !n.Type().IsInterface() && !n.X.Type().IsEmptyInterface() {
+	// With -d=lazyitabs, the back end compares the type in the itab.
+	if !n.Type().IsInterface() && !n.X.Type().IsEmptyInterface() && !reflectdata.LazyITabs() {
 		n.Itab = reflectdata.ITabAddr(n.Type(), n.X.Type())
 	}
 	return n
@@ -679,6 +679,10 @@ func walkIndex(n *ir.IndexExpr, init *ir.Nodes) ir.Node {
 
 // walkIndexMap walks an OINDEXMAP node.
 func walkIndexMap(n *ir.IndexExpr, init *ir.Nodes) ir.Node {
+	if sm := staticMapOf(n.X); sm != nil && !n.Assigned {
+		return walkStaticMapIndex(n, sm, init)
+	}
+
 	// Replace m[k] with *map{access1,assign}(maptype, m, &k)
 	n.X = walkExpr(n.X, init)
 	n.Index = walkExpr(n.Index, init)
@@ -746,8 +750,10 @@ func walkSend(n *ir.SendStmt, init *ir.Nodes) ir.Node {
 func walkSlice(n *ir.SliceExpr, init *ir.Nodes) ir.Node {
 
 	checkSlice := ir.ShouldCheckPtr(ir.CurFunc, 1) && n.Op() == ir.OSLICE3ARR && n.X.Op() == ir.OCONVNOP && n.X.(*ir.ConvExpr).X.Type().IsUnsafePtr()
+	var site string
 	if checkSlice {
 		conv := n.X.(*ir.ConvExpr)
+		site = checkptrSite(conv)
 		conv.X = walkExpr(conv.X, init)
 	} else {
 		n.X = walkExpr(n.X, init)
@@ -761,7 +767,7 @@ func walkSlice(n *ir.SliceExpr, init *ir.Nodes) ir.Node {
 	n.High = walkExpr(n.High, init)
 	n.Max = walkExpr(n.Max, init)
 	if checkSlice {
-		n.X = walkCheckPtrAlignment(n.X.(*ir.ConvExpr), init, n.Max)
+		n.X = walkCheckPtrAlignment(n.X.(*ir.ConvExpr), init, n.Max, site)
 	}
 
 	if n.Op().IsSlice3() {
@@ -874,8 +880,13 @@ func bounded(n ir.Node, max int64) bool {
 	return false
 }
 
-// usemethod checks interface method calls for uses of reflect.Type.Method.
+// usemethod checks interface method calls for uses of reflect.Type.Method,
+// and method calls for uses of reflect.Value.Method.
 func usemethod(n *ir.CallExpr) {
+	if n.Op() == ir.OCALLMETH && usevaluemethod(n) {
+		return
+	}
+
 	t := n.X.Type()
 
 	// Looking for either of:
@@ -924,14 +935,56 @@ func usemethod(n *ir.CallExpr) {
 	//       (including global variables such as numImports - was issue #19028).
 	// Also need to check for reflect package itself (see Issue #38515).
 	if s := res0.Type.Sym(); s != nil && s.Name == "Method" && types.IsReflectPkg(s.Pkg) {
-		ir.CurFunc.SetReflectMethod(true)
-		// The LSym is initialized at this point. We need to set the attribute on the LSym.
-		ir.CurFunc.LSym.Set(obj.AttrReflectMethod, true)
+		markReflectMethod(n, res1 != nil)
+	}
+}
+
+// usevaluemethod checks whether n is a call of reflect.Value.Method or
+// reflect.Value.MethodByName and if so, marks the current function
+// as looking up methods through reflection.
+//
+// The linker normally gives up on dead method elimination as soon as
+// either method is reachable, but with -prunemethods it relies on
+// these marks instead.
+func usevaluemethod(n *ir.CallExpr) bool {
+	dot := n.X.(*ir.SelectorExpr)
+	t := dot.X.Type()
+	if t.IsPtr() {
+		t = t.Elem()
+	}
+	if s := t.Sym(); s == nil || s.Name != "Value" || !types.IsReflectPkg(s.Pkg) {
+		return false
+	}
+	// The reflect package's own calls, such as the one from
+	// MethodByName to Method, are accounted for by their callers.
+	if base.Ctxt.Pkgpath == "reflect" {
+		return true
+	}
+	switch dot.Sel.Name {
+	case "Method":
+		markReflectMethod(n, false)
+	case "MethodByName":
+		markReflectMethod(n, true)
+	}
+	return true
+}
+
+// markReflectMethod marks the current function as calling n, which
+// looks up a method through reflection either by index or, if byName
+// is set, by name. A lookup by constant name only makes the methods
+// with that name reachable.
+func markReflectMethod(n *ir.CallExpr, byName bool) {
+	if byName && len(n.Args) == 1 && ir.IsConst(n.Args[0], constant.String) {
+		reflectdata.MarkUsedNamedMethod(constant.StringVal(n.Args[0].Val()))
+		return
 	}
+	ir.CurFunc.SetReflectMethod(true)
+	// The LSym is initialized at this point. We need to set the attribute on the LSym.
+	ir.CurFunc.LSym.Set(obj.AttrReflectMethod, true)
 }
 
 func usefield(n *ir.SelectorExpr) {
-	if !objabi.Experiment.FieldTrack {
+	if !base.Flag.FieldTrack {
 		return
 	}
 
@@ -409,9 +409,19 @@ func (o *orderState) edge() {
 	counter := staticinit.StaticName(types.Types[types.TUINT8])
 	counter.SetLibfuzzerExtraCounter(true)
 
-	// counter += 1
-	incr := ir.NewAssignOpStmt(base.Pos, ir.OADD, counter, ir.NewInt(1))
-	o.append(incr)
+	// Once the edge has been taken, the counter must not wrap
+	// around to 0, which libfuzzer would take to mean that it has
+	// never been taken:
+	//
+	//	if counter == 0xff {
+	//		counter = 1
+	//	} else {
+	//		counter += 1
+	//	}
+	o.append(ir.NewIfStmt(base.Pos,
+		ir.NewBinaryExpr(base.Pos, ir.OEQ, counter, ir.NewInt(0xff)),
+		[]ir.Node{ir.NewAssignStmt(base.Pos, counter, ir.NewInt(1))},
+		[]ir.Node{ir.NewAssignOpStmt(base.Pos, ir.OADD, counter, ir.NewInt(1))}))
 }
 
 // orderBlock orders the block of statements in n into a new slice,
@@ -605,7 +615,7 @@ func (o *orderState) stmt(n ir.Node) {
 		n.X = o.expr(n.X, nil)
 		n.Y = o.expr(n.Y, nil)
 
-		if base.Flag.Cfg.Instrumenting || n.X.Op() == ir.OINDEXMAP && (n.AsOp == ir.ODIV || n.AsOp == ir.OMOD) {
+		if base.Flag.Cfg.Instrumenting && !isAtomicCoverageUpdate(n) || n.X.Op() == ir.OINDEXMAP && (n.AsOp == ir.ODIV || n.AsOp == ir.OMOD) {
 			// Rewrite m[k] op= r into m[k] = m[k] op r so
 			// that we can ensure that if op panics
 			// because r is zero, the panic happens before
@@ -75,6 +75,7 @@ func walkRange(nrange *ir.RangeStmt) ir.Node {
 	}
 
 	var ifGuard *ir.IfStmt
+	var vec *ir.IfStmt // runs the loop in the runtime; see arrayLoop
 
 	var body []ir.Node
 	var init []ir.Node
@@ -87,6 +88,15 @@ func walkRange(nrange *ir.RangeStmt) ir.Node {
 			base.Pos = lno
 			return nn
 		}
+		if nn, fallback := arrayLoop(nrange, v1, v2, a); nn != nil {
+			nn.PtrInit().Prepend(nfor.Init()...)
+			if !fallback {
+				base.Pos = lno
+				return walkStmt(nn)
+			}
+			nfor.SetInit(nil)
+			vec = nn
+		}
 
 		// order.stmt arranged for a copy of the array/slice variable if needed.
 		ha := a
@@ -317,6 +327,10 @@ func walkRange(nrange *ir.RangeStmt) ir.Node {
 		ifGuard.Body = []ir.Node{n}
 		n = ifGuard
 	}
+	if vec != nil {
+		vec.Else = []ir.Node{n}
+		n = vec
+	}
 
 	n = walkStmt(n)
 
@@ -470,6 +484,175 @@ func arrayClear(loop *ir.RangeStmt, v1, v2, a ir.Node) ir.Node {
 	return walkStmt(n)
 }
 
+// arrayLoop recognizes simple loops over slices that the runtime can
+// run with vector instructions:
+//
+//	for i := range a { a[i] = b[i] }         // elements without pointers
+//	for i := range a { a[i] = v }            // 1-byte integer elements
+//	for i := range a { a[i] = b[i] ^ c[i] }  // 1-byte integer elements
+//	for i := range a { a[i] ^= b[i] }
+//	for _, x := range a { s += T(x) }        // a []byte, s of integer type T
+//
+// where a, b, and c are variables, and v and s are constants or local
+// variables, so that the loop body cannot change them.
+//
+// It returns an if statement that calls the runtime to do the work
+// and then sets i to its final value. If fallback is true, the
+// runtime may give up (see runtime/vecloop.go), and the caller must
+// put the loop itself in the else branch of the if statement.
+func arrayLoop(loop *ir.RangeStmt, v1, v2, a ir.Node) (n *ir.IfStmt, fallback bool) {
+	if base.Flag.N != 0 || base.Flag.Cfg.Instrumenting || base.Flag.CompilingRuntime {
+		return nil, false
+	}
+	switch ssagen.Arch.LinkArch.Family {
+	case sys.AMD64, sys.ARM64:
+	default:
+		return nil, false
+	}
+	if a.Op() != ir.ONAME || !a.Type().IsSlice() || len(loop.Body) != 1 || loop.Body[0] == nil {
+		return nil, false
+	}
+	elem := a.Type().Elem()
+	byteElem := elem.Kind() == types.TUINT8 || elem.Kind() == types.TINT8
+
+	// local reports whether x is a local variable that the
+	// loop body cannot assign.
+	local := func(x ir.Node) bool {
+		if x.Op() != ir.ONAME {
+			return false
+		}
+		nx := x.(*ir.Name)
+		return (nx.Class == ir.PAUTO || nx.Class == ir.PPARAM || nx.Class == ir.PPARAMOUT) && !nx.Addrtaken() && !nx.IsClosureVar()
+	}
+	// index returns the slice x indexes with v1, if x is b[v1]
+	// for a slice variable b. The loop body cannot change b,
+	// as it only assigns elements without pointers.
+	index := func(x ir.Node) ir.Node {
+		if x.Op() != ir.OINDEX {
+			return nil
+		}
+		ix := x.(*ir.IndexExpr)
+		if !ir.SameSafeExpr(ix.Index, v1) || ix.X.Op() != ir.ONAME || !ix.X.Type().IsSlice() {
+			return nil
+		}
+		return ix.X
+	}
+	ptr := func(x ir.Node) ir.Node {
+		p, _ := backingArrayPtrLen(x)
+		return typecheck.ConvNop(p, types.Types[types.TUNSAFEPTR])
+	}
+	length := func(x ir.Node) ir.Node {
+		return ir.NewUnaryExpr(base.Pos, ir.OLEN, x)
+	}
+
+	// for _, x := range a { s += T(x) }
+	if v2 != nil {
+		if v1 != nil && !ir.IsBlank(v1) || !loop.Def || elem.Kind() != types.TUINT8 {
+			return nil, false
+		}
+		if loop.Body[0].Op() != ir.OASOP {
+			return nil, false
+		}
+		stmt := loop.Body[0].(*ir.AssignOpStmt)
+		if stmt.AsOp != ir.OADD || !local(stmt.X) || !stmt.X.Type().IsInteger() {
+			return nil, false
+		}
+		y := stmt.Y
+		if y.Op() == ir.OCONV {
+			y = y.(*ir.ConvExpr).X
+		}
+		if y != v2 {
+			return nil, false
+		}
+		// s += T(loopsum8(&a[0], len(a)))
+		n = ir.NewIfStmt(base.Pos, ir.NewBinaryExpr(base.Pos, ir.ONE, length(a), ir.NewInt(0)), nil, nil)
+		sum := ir.NewCallExpr(base.Pos, ir.OCALL, typecheck.LookupRuntime("loopsum8"), []ir.Node{ptr(a), length(a)})
+		n.Body.Append(ir.NewAssignOpStmt(base.Pos, ir.OADD, stmt.X, typecheck.Conv(sum, stmt.X.Type())))
+		return typecheck.Stmt(n).(*ir.IfStmt), false
+	}
+
+	if v1 == nil || v1.Op() != ir.ONAME {
+		return nil, false
+	}
+	var lhs, rhs ir.Node
+	switch stmt := loop.Body[0]; stmt.Op() {
+	case ir.OAS:
+		stmt := stmt.(*ir.AssignStmt)
+		lhs, rhs = stmt.X, stmt.Y
+	case ir.OASOP:
+		// a[i] ^= b[i] is a[i] = a[i] ^ b[i].
+		stmt := stmt.(*ir.AssignOpStmt)
+		if stmt.AsOp != ir.OXOR {
+			return nil, false
+		}
+		lhs = stmt.X
+		rhs = ir.NewBinaryExpr(base.Pos, ir.OXOR, stmt.X, stmt.Y)
+		rhs.SetType(stmt.X.Type())
+	default:
+		return nil, false
+	}
+	if lhs.Op() != ir.OINDEX || !ir.SameSafeExpr(lhs.(*ir.IndexExpr).X, a) || index(lhs) == nil {
+		return nil, false
+	}
+
+	var call ir.Node
+	switch {
+	case index(rhs) != nil:
+		// for i := range a { a[i] = b[i] }
+		b := index(rhs)
+		if elem.HasPointers() || elem.Width <= 0 || !types.Identical(b.Type().Elem(), elem) {
+			return nil, false
+		}
+		fn := typecheck.LookupRuntime("loopmove")
+		call = ir.NewCallExpr(base.Pos, ir.OCALL, fn, []ir.Node{ptr(a), ptr(b), length(a), length(b), ir.NewInt(elem.Width)})
+		fallback = true
+
+	case byteElem && rhs.Op() == ir.OXOR:
+		// for i := range a { a[i] = b[i] ^ c[i] }
+		rhs := rhs.(*ir.BinaryExpr)
+		b, c := index(rhs.X), index(rhs.Y)
+		if b == nil || c == nil {
+			return nil, false
+		}
+		fn := typecheck.LookupRuntime("loopxor8")
+		call = ir.NewCallExpr(base.Pos, ir.OCALL, fn, []ir.Node{ptr(a), ptr(b), ptr(c), length(a), length(b), length(c)})
+		fallback = true
+
+	case byteElem && (rhs.Op() == ir.OLITERAL || local(rhs)):
+		// for i := range a { a[i] = v }
+		// (arrayClear handles v == 0.)
+		v := rhs
+		if v.Op() == ir.OLITERAL {
+			v = ir.NewInt(ir.Int64Val(v) & 0xff)
+		}
+		fn := typecheck.LookupRuntime("loopset8")
+		call = ir.NewCallExpr(base.Pos, ir.OCALL, fn, []ir.Node{ptr(a), length(a), typecheck.Conv(v, types.Types[types.TUINT8])})
+
+	default:
+		return nil, false
+	}
+
+	// if loopxxx(...) {
+	// 	i = len(a) - 1
+	// }
+	//
+	// or, if the runtime cannot fail,
+	//
+	// if len(a) != 0 {
+	// 	loopxxx(...)
+	// 	i = len(a) - 1
+	// }
+	n = ir.NewIfStmt(base.Pos, nil, nil, nil)
+	if fallback {
+		n.Cond = call
+	} else {
+		n.Cond = ir.NewBinaryExpr(base.Pos, ir.ONE, length(a), ir.NewInt(0))
+		n.Body.Append(call)
+	}
+	n.Body.Append(ir.NewAssignStmt(base.Pos, v1, ir.NewBinaryExpr(base.Pos, ir.OSUB, length(a), ir.NewInt(1))))
+	return typecheck.Stmt(n).(*ir.IfStmt), fallback
+}
+
 // addptr returns (*T)(uintptr(p) + n).
 func addptr(p ir.Node, n int64) ir.Node {
 	t := p.Type()
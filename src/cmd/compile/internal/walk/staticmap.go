@@ -0,0 +1,344 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"sort"
+
+	"cmd/compile/internal/base"
+	"cmd/compile/internal/ir"
+	"cmd/compile/internal/staticdata"
+	"cmd/compile/internal/typecheck"
+	"cmd/compile/internal/types"
+)
+
+// A staticMap is a map variable that -d=staticmaps builds as a
+// read-only perfect hash table instead of a runtime map.
+// See runtime/map_static.go for the layout of the tables.
+type staticMap struct {
+	def   *ir.AssignStmt // initialization of the map
+	count int64          // number of entries
+	keys  *ir.Name       // [nslots]string
+	disp  *ir.Name       // [nbuckets]uint32
+	elems *ir.Name       // [nslots+1]elem
+}
+
+// staticMaps records the static maps of the package.
+var staticMaps map[*ir.Name]*staticMap
+
+// staticMapMaxTries bounds the number of seeds tried while laying out
+// a static map. Maps that cannot be laid out remain runtime maps.
+const staticMapMaxTries = 1 << 20
+
+// FindStaticMaps finds the package-level maps that -d=staticmaps can
+// build as static tables, and builds their tables. These are the
+// unexported maps with string keys that are initialized by a map
+// literal with constant keys and elements and only used by reads and
+// len elsewhere.
+//
+// FindStaticMaps must run before the package initialization
+// function is built, and DropStaticMapInits after inlining.
+func FindStaticMaps(decls []ir.Node) {
+	if base.Debug.StaticMaps == 0 {
+		return
+	}
+
+	cands := make(map[*ir.Name]*ir.AssignStmt)
+	for _, n := range decls {
+		if n.Op() != ir.OAS {
+			continue
+		}
+		as := n.(*ir.AssignStmt)
+		if name := staticMapCandidate(as); name != nil {
+			cands[name] = as
+		}
+	}
+	if len(cands) == 0 {
+		return
+	}
+
+	// Count the references to each candidate, and how many of
+	// them are map reads or len.
+	refs := make(map[*ir.Name]int)
+	reads := make(map[*ir.Name]int)
+	isCand := func(n ir.Node) (*ir.Name, bool) {
+		name, ok := n.(*ir.Name)
+		return name, ok && cands[name] != nil
+	}
+	var visit func(n ir.Node)
+	visit = func(n ir.Node) {
+		switch n.Op() {
+		case ir.ONAME:
+			if name, ok := isCand(n); ok {
+				refs[name]++
+			}
+		case ir.OINDEXMAP:
+			n := n.(*ir.IndexExpr)
+			if name, ok := isCand(n.X); ok && !n.Assigned {
+				reads[name]++
+			}
+		case ir.OLEN:
+			n := n.(*ir.UnaryExpr)
+			if name, ok := isCand(n.X); ok {
+				reads[name]++
+			}
+		case ir.OCLOSURE:
+			ir.VisitList(n.(*ir.ClosureExpr).Func.Body, visit)
+		}
+	}
+	for _, n := range decls {
+		if as, ok := n.(*ir.AssignStmt); ok && as.Op() == ir.OAS {
+			if name, ok := isCand(as.X); ok && cands[name] == as {
+				ir.Visit(as.Y, visit)
+				continue
+			}
+		}
+		ir.Visit(n, visit)
+	}
+
+	for name, as := range cands {
+		if refs[name] == 0 || refs[name] != reads[name] {
+			continue
+		}
+		if sm := buildStaticMap(as); sm != nil {
+			if staticMaps == nil {
+				staticMaps = make(map[*ir.Name]*staticMap)
+			}
+			staticMaps[name] = sm
+		}
+	}
+}
+
+// staticMapCandidate returns the map variable initialized by as if it
+// may be a static map, or else nil.
+func staticMapCandidate(as *ir.AssignStmt) *ir.Name {
+	name, ok := as.X.(*ir.Name)
+	if !ok || name.Class != ir.PEXTERN || types.IsExported(name.Sym().Name) || name.Sym().Linkname != "" {
+		return nil
+	}
+	t := name.Type()
+	if !t.IsMap() || t.Key().Kind() != types.TSTRING {
+		return nil
+	}
+	if as.Y == nil || as.Y.Op() != ir.OMAPLIT {
+		return nil
+	}
+	lit := as.Y.(*ir.CompLitExpr)
+	if len(lit.List) == 0 {
+		return nil
+	}
+	for _, r := range lit.List {
+		r := r.(*ir.KeyExpr)
+		if r.Key.Op() != ir.OLITERAL || r.Value.Op() != ir.OLITERAL {
+			return nil
+		}
+	}
+	return name
+}
+
+// buildStaticMap lays out the static map initialized by as and
+// writes its tables. It returns nil if no layout was found.
+func buildStaticMap(as *ir.AssignStmt) *staticMap {
+	lit := as.Y.(*ir.CompLitExpr)
+	keys := make([]string, len(lit.List))
+	for i, r := range lit.List {
+		keys[i] = ir.StringVal(r.(*ir.KeyExpr).Key)
+	}
+	slots, disp := staticMapLayout(keys)
+	if slots == nil {
+		return nil
+	}
+	nslots := int64(1)
+	for nslots < int64(len(keys)) {
+		nslots <<= 1
+	}
+
+	sm := &staticMap{def: as, count: int64(len(keys))}
+
+	// Unused key slots hold the first key, which belongs in
+	// another slot, so lookups that reach them fail.
+	sm.keys = readonlystaticname(types.NewArray(types.Types[types.TSTRING], nslots))
+	keyWidth := types.Types[types.TSTRING].Width
+	for s := int64(0); s < nslots; s++ {
+		staticdata.InitConst(sm.keys, s*keyWidth, lit.List[0].(*ir.KeyExpr).Key, int(keyWidth))
+	}
+	elem := as.X.Type().Elem()
+	sm.elems = readonlystaticname(types.NewArray(elem, nslots+1))
+	for i, r := range lit.List {
+		r := r.(*ir.KeyExpr)
+		staticdata.InitConst(sm.keys, slots[i]*keyWidth, r.Key, int(keyWidth))
+		staticdata.InitConst(sm.elems, slots[i]*elem.Width, r.Value, int(elem.Width))
+	}
+	sm.disp = readonlystaticname(types.NewArray(types.Types[types.TUINT32], int64(len(disp))))
+	for b, seed := range disp {
+		sm.disp.Linksym().WriteInt(base.Ctxt, int64(b)*4, 4, int64(seed))
+	}
+	return sm
+}
+
+// staticMapLayout finds a perfect hash of keys using hash and
+// displace: the keys are hashed into buckets of about two keys, and
+// for each bucket, largest first, a seed is chosen that hashes its keys
+// into unused slots. It returns the slot of each key and the seed of
+// each bucket, or nil, nil if it gives up.
+func staticMapLayout(keys []string) (slots []int64, disp []uint32) {
+	nslots := 1
+	for nslots < len(keys) {
+		nslots <<= 1
+	}
+	nbuckets := 1
+	for nbuckets*2 < len(keys) {
+		nbuckets <<= 1
+	}
+
+	buckets := make([][]int, nbuckets)
+	for i, k := range keys {
+		b := staticMapHash(k, 0) & uint32(nbuckets-1)
+		buckets[b] = append(buckets[b], i)
+	}
+	order := make([]int, nbuckets)
+	for b := range order {
+		order[b] = b
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(buckets[order[i]]) > len(buckets[order[j]])
+	})
+
+	slots = make([]int64, len(keys))
+	disp = make([]uint32, nbuckets)
+	used := make([]bool, nslots)
+	tries := 0
+	for _, b := range order {
+		bucket := buckets[b]
+		if len(bucket) == 0 {
+			break
+		}
+		for seed := uint32(1); ; seed++ {
+			if tries++; tries > staticMapMaxTries {
+				return nil, nil
+			}
+			placed := 0
+			for ; placed < len(bucket); placed++ {
+				s := int64(staticMapHash(keys[bucket[placed]], seed) & uint32(nslots-1))
+				if used[s] {
+					break
+				}
+				used[s] = true
+				slots[bucket[placed]] = s
+			}
+			if placed == len(bucket) {
+				disp[b] = seed
+				break
+			}
+			for _, k := range bucket[:placed] {
+				used[slots[k]] = false
+			}
+		}
+	}
+	return slots, disp
+}
+
+// staticMapHash returns the hash of s with the given seed.
+// It must match runtime.staticmaphash.
+func staticMapHash(s string, seed uint32) uint32 {
+	h := seed ^ 2166136261
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+// DropStaticMapInits removes the initialization of the static maps
+// from the package initialization function. The maps themselves are
+// no longer used, except by other packages inlining a function of
+// this package that reads one, so the maps read by inlinable functions
+// are still initialized.
+func DropStaticMapInits(decls []ir.Node) {
+	if len(staticMaps) == 0 {
+		return
+	}
+
+	inlined := make(map[*ir.Name]bool)
+	if base.Ctxt.Pkgpath != "main" {
+		var visit func(n ir.Node)
+		visit = func(n ir.Node) {
+			switch n.Op() {
+			case ir.ONAME:
+				if name := n.(*ir.Name); staticMaps[name] != nil {
+					inlined[name] = true
+				}
+			case ir.OCLOSURE:
+				ir.VisitList(n.(*ir.ClosureExpr).Func.Body, visit)
+			}
+		}
+		for _, n := range decls {
+			if fn, ok := n.(*ir.Func); ok && fn.Inl != nil {
+				ir.VisitList(fn.Inl.Body, visit)
+			}
+		}
+	}
+
+	drop := make(map[ir.Node]bool)
+	for name, sm := range staticMaps {
+		if !inlined[name] {
+			drop[sm.def] = true
+		}
+	}
+	for _, n := range decls {
+		if fn, ok := n.(*ir.Func); ok {
+			for i, stmt := range fn.Body {
+				if drop[stmt] {
+					fn.Body[i] = ir.NewBlockStmt(stmt.Pos(), nil)
+				}
+			}
+		}
+	}
+}
+
+// staticMapOf returns the static map n refers to, if any.
+func staticMapOf(n ir.Node) *staticMap {
+	if name, ok := n.(*ir.Name); ok {
+		return staticMaps[name]
+	}
+	return nil
+}
+
+// staticMapIndex returns the slot of key in the static map sm, or the
+// number of slots if key is not in it.
+func staticMapIndex(sm *staticMap, key ir.Node, init *ir.Nodes) ir.Node {
+	slice := func(n *ir.Name) ir.Node {
+		return typecheck.Expr(ir.NewSliceExpr(base.Pos, ir.OSLICE, typecheck.NodAddr(n), nil, nil, nil))
+	}
+	key = typecheck.Conv(key, types.Types[types.TSTRING])
+	return mkcall("staticmapindex", types.Types[types.TINT], init, key, slice(sm.keys), slice(sm.disp))
+}
+
+// staticMapElem returns the element of the static map sm in slot i.
+func staticMapElem(sm *staticMap, i ir.Node) ir.Node {
+	elem := ir.NewIndexExpr(base.Pos, sm.elems, i)
+	elem.SetBounded(true)
+	return typecheck.Expr(elem)
+}
+
+// walkStaticMapIndex walks m[k], a read of the static map sm.
+func walkStaticMapIndex(n *ir.IndexExpr, sm *staticMap, init *ir.Nodes) ir.Node {
+	return walkExpr(staticMapElem(sm, staticMapIndex(sm, n.Index, init)), init)
+}
+
+// walkStaticMapRead walks a, b = m[k], a read of the static map sm.
+func walkStaticMapRead(init *ir.Nodes, n *ir.AssignListStmt, sm *staticMap) ir.Node {
+	r := n.Rhs[0].(*ir.IndexExpr)
+	i := copyExpr(staticMapIndex(sm, r.Index, init), types.Types[types.TINT], init)
+	nslots := sm.elems.Type().NumElem() - 1
+	ok := ir.NewBinaryExpr(base.Pos, ir.OLT, i, ir.NewInt(nslots))
+	as := ir.NewAssignListStmt(n.Pos(), ir.OAS2, n.Lhs, []ir.Node{staticMapElem(sm, i), ok})
+	return walkExpr(typecheck.Stmt(as), init)
+}
@@ -190,7 +190,7 @@ func (s *exprSwitch) flush() {
 			func(i int, nif *ir.IfStmt) {
 				run := runs[i]
 				nif.Cond = ir.NewBinaryExpr(base.Pos, ir.OEQ, ir.NewUnaryExpr(base.Pos, ir.OLEN, s.exprname), ir.NewInt(runLen(run)))
-				s.search(run, &nif.Body)
+				s.searchBytes(run, &nif.Body)
 			},
 		)
 		return
@@ -235,6 +235,81 @@ func (s *exprSwitch) search(cc []exprClause, out *ir.Nodes) {
 	)
 }
 
+// searchBytes is like search, but for string clauses that all have
+// the same length as s.exprname. Rather than ordering the strings by
+// runtime comparisons, it dispatches on the byte at the position that
+// best tells the strings apart, recursing on any strings that share
+// that byte. Each case is thus reached with byte comparisons alone
+// and confirmed with a single string comparison.
+func (s *exprSwitch) searchBytes(cc []exprClause, out *ir.Nodes) {
+	if len(cc) < 4 {
+		// Few enough for search to compare them in sequence.
+		s.search(cc, out)
+		return
+	}
+
+	i := bestBytePos(cc)
+	byteAt := func(c exprClause) byte { return ir.StringVal(c.lo)[i] }
+	sort.Slice(cc, func(a, b int) bool { return byteAt(cc[a]) < byteAt(cc[b]) })
+
+	var groups [][]exprClause
+	start := 0
+	for j := 1; j < len(cc); j++ {
+		if byteAt(cc[start]) != byteAt(cc[j]) {
+			groups = append(groups, cc[start:j])
+			start = j
+		}
+	}
+	groups = append(groups, cc[start:])
+	if len(groups) == 1 {
+		// Only possible with duplicate cases.
+		s.search(cc, out)
+		return
+	}
+
+	// The length of s.exprname is known to be larger than i here.
+	idx := ir.NewIndexExpr(base.Pos, s.exprname, ir.NewInt(int64(i)))
+	idx.SetBounded(true)
+	b := typecheck.Temp(types.Types[types.TUINT8])
+	out.Append(typecheck.Stmt(ir.NewAssignStmt(base.Pos, b, idx)))
+
+	binarySearch(len(groups), out,
+		func(j int) ir.Node {
+			return ir.NewBinaryExpr(base.Pos, ir.OLE, b, ir.NewInt(int64(byteAt(groups[j-1][0]))))
+		},
+		func(j int, nif *ir.IfStmt) {
+			nif.Cond = ir.NewBinaryExpr(base.Pos, ir.OEQ, b, ir.NewInt(int64(byteAt(groups[j][0]))))
+			s.searchBytes(groups[j], &nif.Body)
+		},
+	)
+}
+
+// bestBytePos returns the byte position at which the case strings of
+// cc, which all have the same length, take the most distinct values,
+// preferring positions that leave the smallest largest group of
+// strings sharing a byte.
+func bestBytePos(cc []exprClause) int {
+	best, bestDistinct, bestMax := 0, 0, 0
+	for i, n := 0, len(ir.StringVal(cc[0].lo)); i < n; i++ {
+		var count [256]int
+		distinct, max := 0, 0
+		for _, c := range cc {
+			k := &count[ir.StringVal(c.lo)[i]]
+			if *k == 0 {
+				distinct++
+			}
+			*k++
+			if *k > max {
+				max = *k
+			}
+		}
+		if distinct > bestDistinct || distinct == bestDistinct && max < bestMax {
+			best, bestDistinct, bestMax = i, distinct, max
+		}
+	}
+	return best
+}
+
 func (c *exprClause) test(exprname ir.Node) ir.Node {
 	// Integer range.
 	if c.hi != c.lo {
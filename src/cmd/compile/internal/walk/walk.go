@@ -24,6 +24,7 @@ const zeroValSize = 1024 // must match value of runtime/map.go:maxZero
 func Walk(fn *ir.Func) {
 	ir.CurFunc = fn
 	errorsBefore := base.Errors()
+	checkAtomicAlign(fn)
 	order(fn)
 	if base.Errors() > errorsBefore {
 		return
@@ -37,6 +37,11 @@ Display coverage percentages to stdout for each function:
 Finally, to generate modified source code with coverage annotations
 (what go test -cover does):
 	go tool cover -mode=set -var=CoverageVariableName program.go
+
+The -granularity flag selects what the annotations count: each function
+(func), each basic block (block, the default), or each basic block and
+each branch of an if or switch statement that is taken implicitly, that
+is, the missing else of an if and the missing default of a switch (edge).
 `
 
 func usage() {
@@ -48,11 +53,12 @@ func usage() {
 }
 
 var (
-	mode    = flag.String("mode", "", "coverage mode: set, count, atomic")
-	varVar  = flag.String("var", "GoCover", "name of coverage variable to generate")
-	output  = flag.String("o", "", "file for output; default: stdout")
-	htmlOut = flag.String("html", "", "generate HTML representation of coverage profile")
-	funcOut = flag.String("func", "", "output coverage profile information for each function")
+	mode        = flag.String("mode", "", "coverage mode: set, count, atomic")
+	granularity = flag.String("granularity", "block", "coverage granularity: func, block, edge")
+	varVar      = flag.String("var", "GoCover", "name of coverage variable to generate")
+	output      = flag.String("o", "", "file for output; default: stdout")
+	htmlOut     = flag.String("html", "", "generate HTML representation of coverage profile")
+	funcOut     = flag.String("func", "", "output coverage profile information for each function")
 )
 
 var profile string // The profile to read; the value of -html or -func
@@ -131,6 +137,12 @@ func parseFlags() error {
 			return fmt.Errorf("unknown -mode %v", *mode)
 		}
 
+		switch *granularity {
+		case "func", "block", "edge":
+		default:
+			return fmt.Errorf("unknown -granularity %v", *granularity)
+		}
+
 		if flag.NArg() == 0 {
 			return fmt.Errorf("missing source file")
 		} else if flag.NArg() == 1 {
@@ -200,15 +212,26 @@ func (f *File) findText(pos token.Pos, text string) int {
 
 // Visit implements the ast.Visitor interface.
 func (f *File) Visit(node ast.Node) ast.Visitor {
+	if *granularity == "func" {
+		return f.visitFunc(node)
+	}
 	switch n := node.(type) {
 	case *ast.BlockStmt:
 		// If it's a switch or select, the body is a list of case clauses; don't tag the block itself.
 		if len(n.List) > 0 {
 			switch n.List[0].(type) {
 			case *ast.CaseClause: // switch
+				hasDefault := false
 				for _, n := range n.List {
 					clause := n.(*ast.CaseClause)
 					f.addCounters(clause.Colon+1, clause.Colon+1, clause.End(), clause.Body, false)
+					if clause.List == nil {
+						hasDefault = true
+					}
+				}
+				if *granularity == "edge" && !hasDefault {
+					// Count the switches that match no case.
+					f.edit.Insert(f.offset(n.Rbrace), "default: "+f.newCounter(n.Rbrace, n.Rbrace, 0)+";")
 				}
 				return f
 			case *ast.CommClause: // select
@@ -227,6 +250,11 @@ func (f *File) Visit(node ast.Node) ast.Visitor {
 		ast.Walk(f, n.Cond)
 		ast.Walk(f, n.Body)
 		if n.Else == nil {
+			if *granularity == "edge" {
+				// Count the times the condition is false.
+				end := n.Body.End()
+				f.edit.Insert(f.offset(end), " else {"+f.newCounter(end, end, 0)+"}")
+			}
 			return nil
 		}
 		// The elses are special, because if we have
@@ -245,7 +273,7 @@ func (f *File) Visit(node ast.Node) ast.Visitor {
 			panic("lost else")
 		}
 		f.edit.Insert(elseOffset+4, "{")
-		f.edit.Insert(f.offset(n.Else.End()), "}")
+		elseEnd := f.offset(n.Else.End())
 
 		// We just created a block, now walk it.
 		// Adjust the position of the new block to start after
@@ -266,6 +294,10 @@ func (f *File) Visit(node ast.Node) ast.Visitor {
 			panic("unexpected node type in if")
 		}
 		ast.Walk(f, n.Else)
+		// Close the hidden block after walking it, so that the
+		// closing brace follows any text inserted at the end of
+		// the else branch.
+		f.edit.Insert(elseEnd, "}")
 		return nil
 	case *ast.SelectStmt:
 		// Don't annotate an empty select - creates a syntax error.
@@ -301,6 +333,55 @@ func (f *File) Visit(node ast.Node) ast.Visitor {
 	return f
 }
 
+// visitFunc is the ast.Visitor used for -granularity=func. It adds
+// one counter to the body of each function declaration and of each
+// function literal outside one; the function literals inside a
+// function are counted as part of it.
+func (f *File) visitFunc(node ast.Node) ast.Visitor {
+	var body *ast.BlockStmt
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		// Don't annotate functions with blank names - they cannot be executed.
+		if n.Name.Name == "_" {
+			return nil
+		}
+		body = n.Body
+	case *ast.FuncLit:
+		body = n.Body
+	default:
+		return f
+	}
+	if body != nil {
+		f.edit.Insert(f.offset(body.Lbrace+1), f.newCounter(body.Lbrace, body.Rbrace+1, countStmts(body))+";")
+	}
+	return nil
+}
+
+// countStmts returns the number of statements in body, counted as
+// the basic blocks do: the statements of each statement list, but not
+// the case clauses of switch and select statements.
+func countStmts(body *ast.BlockStmt) int {
+	n := 0
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.BlockStmt:
+			for _, s := range node.List {
+				switch s.(type) {
+				case *ast.CaseClause, *ast.CommClause:
+				default:
+					n++
+				}
+			}
+		case *ast.CaseClause:
+			n += len(node.Body)
+		case *ast.CommClause:
+			n += len(node.Body)
+		}
+		return true
+	})
+	return n
+}
+
 func annotate(name string) {
 	fset := token.NewFileSet()
 	content, err := os.ReadFile(name)
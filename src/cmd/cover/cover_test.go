@@ -560,6 +560,82 @@ func TestFuncWithDuplicateLines(t *testing.T) {
 	run(cmd, t)
 }
 
+// granularityContents is annotated and run in TestGranularity.
+const granularityContents = `
+package main
+
+import "fmt"
+
+func f(x int) int {
+	if x > 0 {
+		x++
+	}
+	switch x {
+	case 1:
+		x--
+	}
+	if x == 5 {
+		return 0
+	} else if x == 6 {
+		return 1
+	}
+	return x
+}
+
+var g = func() {}
+
+func main() {
+	f(0)
+	f(1)
+	g()
+	fmt.Println(GoCover.Count)
+}
+`
+
+// Test the counters added by each -granularity.
+func TestGranularity(t *testing.T) {
+	t.Parallel()
+	testenv.MustHaveGoRun(t)
+	buildCover(t)
+
+	dir := filepath.Join(testTempDir, "granularity")
+	if err := os.Mkdir(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(dir, "granularity.go")
+	if err := os.WriteFile(src, []byte(granularityContents), 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		granularity string
+		want        string
+	}{
+		// f, g, main.
+		{"func", "[2 1 1]"},
+		{"block", "[2 2 2 2 1 0 0 2 0 1 1]"},
+		// As block, with counters for the false branch of
+		// x > 0, the default of the switch, and the false
+		// branch of x == 6.
+		{"edge", "[2 2 2 2 1 1 0 2 0 2 0 2 1 1]"},
+	} {
+		// testcover -mode=count -granularity=... -o TMPDIR/granularity/x.go TMPDIR/granularity/granularity.go
+		out := filepath.Join(dir, tt.granularity+".go")
+		cmd := exec.Command(testcover, "-mode=count", "-granularity="+tt.granularity, "-o", out, src)
+		run(cmd, t)
+
+		// go run TMPDIR/granularity/x.go
+		cmd = exec.Command(testenv.GoToolPath(t), "run", out)
+		got, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("go run %s: %v\n%s", out, err, got)
+		}
+		if got := strings.TrimSpace(string(got)); got != tt.want {
+			t.Errorf("-granularity=%s: got counts %s, want %s", tt.granularity, got, tt.want)
+		}
+	}
+}
+
 func run(c *exec.Cmd, t *testing.T) {
 	t.Helper()
 	t.Log("running", c.Args)
@@ -29,11 +29,14 @@ var (
 	gohostarch       string
 	gohostos         string
 	goos             string
+	goamd64          string
 	goarm            string
+	goarm64          string
 	go386            string
 	gomips           string
 	gomips64         string
 	goppc64          string
+	goriscv64        string
 	goroot           string
 	goroot_final     string
 	goextlinkenabled string
@@ -134,12 +137,24 @@ func xinit() {
 		fatalf("unknown $GOOS %s", goos)
 	}
 
+	b = os.Getenv("GOAMD64")
+	if b == "" {
+		b = "v1"
+	}
+	goamd64 = b
+
 	b = os.Getenv("GOARM")
 	if b == "" {
 		b = xgetgoarm()
 	}
 	goarm = b
 
+	b = os.Getenv("GOARM64")
+	if b == "" {
+		b = "v8.0"
+	}
+	goarm64 = b
+
 	b = os.Getenv("GO386")
 	if b == "" {
 		b = "sse2"
@@ -164,6 +179,12 @@ func xinit() {
 	}
 	goppc64 = b
 
+	b = os.Getenv("GORISCV64")
+	if b == "" {
+		b = "rv64g"
+	}
+	goriscv64 = b
+
 	if p := pathf("%s/src/all.bash", goroot); !isfile(p) {
 		fatalf("$GOROOT is not set correctly or not exported\n"+
 			"\tGOROOT=%s\n"+
@@ -221,14 +242,17 @@ func xinit() {
 
 	// For tools being invoked but also for os.ExpandEnv.
 	os.Setenv("GO386", go386)
+	os.Setenv("GOAMD64", goamd64)
 	os.Setenv("GOARCH", goarch)
 	os.Setenv("GOARM", goarm)
+	os.Setenv("GOARM64", goarm64)
 	os.Setenv("GOHOSTARCH", gohostarch)
 	os.Setenv("GOHOSTOS", gohostos)
 	os.Setenv("GOOS", goos)
 	os.Setenv("GOMIPS", gomips)
 	os.Setenv("GOMIPS64", gomips64)
 	os.Setenv("GOPPC64", goppc64)
+	os.Setenv("GORISCV64", goriscv64)
 	os.Setenv("GOROOT", goroot)
 	os.Setenv("GOROOT_FINAL", goroot_final)
 
@@ -827,6 +851,14 @@ func runInstall(pkg string, ch chan struct{}) {
 		"-D", "GOOS_GOARCH_" + goos + "_" + goarch,
 		"-p", pkg,
 	}
+	if goarch == "amd64" {
+		// Define GOAMD64_value from goamd64.
+		asmArgs = append(asmArgs, "-D", "GOAMD64_"+goamd64)
+	}
+	if goarch == "arm64" && goarm64LSE(goarm64) {
+		// Define GOARM64_LSE if the LSE atomic instructions are available.
+		asmArgs = append(asmArgs, "-D", "GOARM64_LSE")
+	}
 	if goarch == "mips" || goarch == "mipsle" {
 		// Define GOMIPS_value from gomips.
 		asmArgs = append(asmArgs, "-D", "GOMIPS_"+gomips)
@@ -835,6 +867,10 @@ func runInstall(pkg string, ch chan struct{}) {
 		// Define GOMIPS64_value from gomips64.
 		asmArgs = append(asmArgs, "-D", "GOMIPS64_"+gomips64)
 	}
+	if goarch == "riscv64" {
+		// Define GORISCV64_value from goriscv64.
+		asmArgs = append(asmArgs, "-D", "GORISCV64_"+goriscv64)
+	}
 	goasmh := pathf("%s/go_asm.h", workdir)
 	if IsRuntimePackagePath(pkg) {
 		asmArgs = append(asmArgs, "-compiling-runtime")
@@ -1165,9 +1201,15 @@ func cmdenv() {
 	xprintf(format, "GOROOT", goroot)
 	xprintf(format, "GOTMPDIR", os.Getenv("GOTMPDIR"))
 	xprintf(format, "GOTOOLDIR", tooldir)
+	if goarch == "amd64" {
+		xprintf(format, "GOAMD64", goamd64)
+	}
 	if goarch == "arm" {
 		xprintf(format, "GOARM", goarm)
 	}
+	if goarch == "arm64" {
+		xprintf(format, "GOARM64", goarm64)
+	}
 	if goarch == "386" {
 		xprintf(format, "GO386", go386)
 	}
@@ -1180,6 +1222,9 @@ func cmdenv() {
 	if goarch == "ppc64" || goarch == "ppc64le" {
 		xprintf(format, "GOPPC64", goppc64)
 	}
+	if goarch == "riscv64" {
+		xprintf(format, "GORISCV64", goriscv64)
+	}
 
 	if *path {
 		sep := ":"
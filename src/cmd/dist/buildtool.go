@@ -58,10 +58,13 @@ var bootstrapDirs = []string{
 	"debug/macho",
 	"debug/pe",
 	"go/constant",
+	"go/layout",
 	"internal/goversion",
+	"internal/profile",
 	"internal/race",
 	"internal/unsafeheader",
 	"internal/xcoff",
+	"internal/zstd",
 	"math/big",
 	"math/bits",
 	"sort",
@@ -175,6 +178,11 @@ func bootstrapBuildTools() {
 	defer os.Setenv("GOBIN", os.Getenv("GOBIN"))
 	os.Setenv("GOBIN", "")
 
+	// The bootstrap toolchain may give GORISCV64 a different meaning
+	// and reject our value for it. It is not used for the host anyway.
+	defer os.Setenv("GORISCV64", os.Getenv("GORISCV64"))
+	os.Setenv("GORISCV64", "")
+
 	os.Setenv("GOOS", "")
 	os.Setenv("GOHOSTOS", "")
 	os.Setenv("GOARCH", "")
@@ -414,6 +414,13 @@ func xgetgoarm() string {
 	return "5"
 }
 
+// goarm64LSE reports whether the GOARM64 setting v selects the LSE
+// atomic instructions, which are mandatory from ARMv8.1 on.
+// The tools reject invalid settings.
+func goarm64LSE(v string) bool {
+	return strings.HasSuffix(v, ",lse") || !strings.HasPrefix(v, "v8.0")
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -121,6 +121,10 @@
 // 		Supported only on linux/amd64, linux/arm64
 // 		and only with Clang/LLVM as the host C compiler.
 // 		On linux/arm64, pie build mode will be used.
+// 	-asan
+// 		enable interoperation with address sanitizer.
+// 		Supported only on linux/amd64, linux/arm64
+// 		and only with GCC 7 and higher or Clang/LLVM 9 and higher.
 // 	-v
 // 		print the names of packages as they are compiled.
 // 	-work
@@ -144,7 +148,7 @@
 // 		in order to keep output separate from default builds.
 // 		If using the -race flag, the install suffix is automatically set to race
 // 		or, if set explicitly, has _race appended to it. Likewise for the -msan
-// 		flag. Using a -buildmode option that requires non-default compile flags
+// 		and -asan flags. Using a -buildmode option that requires non-default compile flags
 // 		has a similar effect.
 // 	-ldflags '[pattern=]arg list'
 // 		arguments to pass on each go tool link invocation.
@@ -1496,7 +1500,11 @@
 // The -m flag causes go version to print each executable's embedded
 // module version information, when available. In the output, the module
 // information consists of multiple lines following the version line, each
-// indented by a leading tab character.
+// indented by a leading tab character. The module information is followed
+// by the flags that affected the build, such as -race or -gcflags=-N,
+// as recorded by the compiler and linker: a "compile" line for each
+// distinct set of compiler flags, listing the affected packages unless
+// all packages were compiled alike, and a "link" line for the linker flags.
 //
 // See also: go doc runtime/debug.BuildInfo.
 //
@@ -1848,9 +1856,18 @@
 //
 // Architecture-specific environment variables:
 //
+// 	GOAMD64
+// 		For GOARCH=amd64, the microarchitecture level for which to compile.
+// 		Valid values are v1 (default), v2, v3, v4.
+// 		See https://en.wikipedia.org/wiki/X86-64#Microarchitecture_levels.
 // 	GOARM
 // 		For GOARCH=arm, the ARM architecture for which to compile.
 // 		Valid values are 5, 6, 7.
+// 	GOARM64
+// 		For GOARCH=arm64, the ARM architecture version for which to compile.
+// 		Valid values are v8.0 (default) through v8.9 and v9.0 through v9.5,
+// 		optionally followed by ",lse" to use the LSE atomic instructions
+// 		with v8.0. From v8.1 on, the LSE atomic instructions are always used.
 // 	GO386
 // 		For GOARCH=386, how to implement floating point instructions.
 // 		Valid values are sse2 (default), softfloat.
@@ -1860,6 +1877,15 @@
 // 	GOMIPS64
 // 		For GOARCH=mips64{,le}, whether to use floating point instructions.
 // 		Valid values are hardfloat (default), softfloat.
+// 	GOPPC64
+// 		For GOARCH=ppc64{,le}, the target ISA (Instruction Set Architecture).
+// 		Valid values are power8 (default), power9, power10. With power10,
+// 		the assembler uses prefixed instructions for PC-relative addressing
+// 		and large offsets and constants.
+// 	GORISCV64
+// 		For GOARCH=riscv64, the instruction set extensions for which to compile.
+// 		Valid values are rv64g (default), rv64gc. With rv64gc, the assembler
+// 		uses the compressed instructions of the C extension where it can.
 // 	GOWASM
 // 		For GOARCH=wasm, comma-separated list of experimental WebAssembly features to use.
 // 		Valid values are satconv, signext.
@@ -26,6 +26,7 @@ import (
 // These are general "build flags" used by build and other commands.
 var (
 	BuildA                 bool   // -a flag
+	BuildASan              bool   // -asan flag
 	BuildBuildmode         string // -buildmode flag
 	BuildContext           = defaultContext()
 	BuildMod               string                  // -mod flag
@@ -252,12 +253,15 @@ var (
 	GOMODCACHE   = envOr("GOMODCACHE", gopathDir("pkg/mod"))
 
 	// Used in envcmd.MkEnv and build ID computations.
-	GOARM    = envOr("GOARM", fmt.Sprint(objabi.GOARM))
-	GO386    = envOr("GO386", objabi.GO386)
-	GOMIPS   = envOr("GOMIPS", objabi.GOMIPS)
-	GOMIPS64 = envOr("GOMIPS64", objabi.GOMIPS64)
-	GOPPC64  = envOr("GOPPC64", fmt.Sprintf("%s%d", "power", objabi.GOPPC64))
-	GOWASM   = envOr("GOWASM", fmt.Sprint(objabi.GOWASM))
+	GOAMD64   = envOr("GOAMD64", fmt.Sprintf("%s%d", "v", objabi.GOAMD64))
+	GOARM     = envOr("GOARM", fmt.Sprint(objabi.GOARM))
+	GOARM64   = envOr("GOARM64", objabi.GOARM64.String())
+	GO386     = envOr("GO386", objabi.GO386)
+	GOMIPS    = envOr("GOMIPS", objabi.GOMIPS)
+	GOMIPS64  = envOr("GOMIPS64", objabi.GOMIPS64)
+	GOPPC64   = envOr("GOPPC64", fmt.Sprintf("%s%d", "power", objabi.GOPPC64))
+	GORISCV64 = envOr("GORISCV64", objabi.GORISCV64)
+	GOWASM    = envOr("GOWASM", fmt.Sprint(objabi.GOWASM))
 
 	GOPROXY    = envOr("GOPROXY", "https://proxy.golang.org,direct")
 	GOSUMDB    = envOr("GOSUMDB", "sum.golang.org")
@@ -276,8 +280,12 @@ var SumdbDir = gopathDir("pkg/sumdb")
 // GetArchEnv returns empty key and value.
 func GetArchEnv() (key, val string) {
 	switch Goarch {
+	case "amd64":
+		return "GOAMD64", GOAMD64
 	case "arm":
 		return "GOARM", GOARM
+	case "arm64":
+		return "GOARM64", GOARM64
 	case "386":
 		return "GO386", GO386
 	case "mips", "mipsle":
@@ -286,6 +294,8 @@ func GetArchEnv() (key, val string) {
 		return "GOMIPS64", GOMIPS64
 	case "ppc64", "ppc64le":
 		return "GOPPC64", GOPPC64
+	case "riscv64":
+		return "GORISCV64", GORISCV64
 	case "wasm":
 		return "GOWASM", GOWASM
 	}
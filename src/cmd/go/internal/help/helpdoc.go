@@ -586,9 +586,18 @@ Environment variables for use with cgo:
 
 Architecture-specific environment variables:
 
+	GOAMD64
+		For GOARCH=amd64, the microarchitecture level for which to compile.
+		Valid values are v1 (default), v2, v3, v4.
+		See https://en.wikipedia.org/wiki/X86-64#Microarchitecture_levels.
 	GOARM
 		For GOARCH=arm, the ARM architecture for which to compile.
 		Valid values are 5, 6, 7.
+	GOARM64
+		For GOARCH=arm64, the ARM architecture version for which to compile.
+		Valid values are v8.0 (default) through v8.9 and v9.0 through v9.5,
+		optionally followed by ",lse" to use the LSE atomic instructions
+		with v8.0. From v8.1 on, the LSE atomic instructions are always used.
 	GO386
 		For GOARCH=386, how to implement floating point instructions.
 		Valid values are sse2 (default), softfloat.
@@ -598,6 +607,15 @@ Architecture-specific environment variables:
 	GOMIPS64
 		For GOARCH=mips64{,le}, whether to use floating point instructions.
 		Valid values are hardfloat (default), softfloat.
+	GOPPC64
+		For GOARCH=ppc64{,le}, the target ISA (Instruction Set Architecture).
+		Valid values are power8 (default), power9, power10. With power10,
+		the assembler uses prefixed instructions for PC-relative addressing
+		and large offsets and constants.
+	GORISCV64
+		For GOARCH=riscv64, the instruction set extensions for which to compile.
+		Valid values are rv64g (default), rv64gc. With rv64gc, the assembler
+		uses the compressed instructions of the C extension where it can.
 	GOWASM
 		For GOARCH=wasm, comma-separated list of experimental WebAssembly features to use.
 		Valid values are satconv, signext.
@@ -119,10 +119,12 @@ func (s *Span) Done() {
 }
 
 type tracer struct {
-	file chan traceFile // 1-buffered
-
+	// nextTID and nextFlowID are accessed atomically and must come
+	// first to be 64-bit aligned on 32-bit systems.
 	nextTID    uint64
 	nextFlowID uint64
+
+	file chan traceFile // 1-buffered
 }
 
 func (t *tracer) writeEvent(ev *traceviewer.Event) error {
@@ -38,7 +38,11 @@ during a directory scan. The -v flag causes it to report unrecognized files.
 The -m flag causes go version to print each executable's embedded
 module version information, when available. In the output, the module
 information consists of multiple lines following the version line, each
-indented by a leading tab character.
+indented by a leading tab character. The module information is followed
+by the flags that affected the build, such as -race or -gcflags=-N,
+as recorded by the compiler and linker: a "compile" line for each
+distinct set of compiler flags, listing the affected packages unless
+all packages were compiled alike, and a "link" line for the linker flags.
 
 See also: go doc runtime/debug.BuildInfo.
 `,
@@ -144,7 +148,7 @@ func scanFile(file string, info fs.FileInfo, mustPrint bool) {
 	}
 	defer x.Close()
 
-	vers, mod := findVers(x)
+	vers, mod, flags := findVers(x)
 	if vers == "" {
 		if mustPrint {
 			fmt.Fprintf(os.Stderr, "%s: go version not found\n", file)
@@ -156,17 +160,21 @@ func scanFile(file string, info fs.FileInfo, mustPrint bool) {
 	if *versionM && mod != "" {
 		fmt.Printf("\t%s\n", strings.ReplaceAll(mod[:len(mod)-1], "\n", "\n\t"))
 	}
+	if *versionM && flags != "" {
+		fmt.Printf("\t%s\n", strings.ReplaceAll(flags[:len(flags)-1], "\n", "\n\t"))
+	}
 }
 
 // The build info blob left by the linker is identified by
 // a 16-byte header, consisting of buildInfoMagic (14 bytes),
 // the binary's pointer size (1 byte),
-// and whether the binary is big endian (1 byte).
+// and a flags byte: bit 0 is set if the binary is big endian,
+// and bit 1 if the blob holds a pointer to the build flags.
 var buildInfoMagic = []byte("\xff Go buildinf:")
 
-// findVers finds and returns the Go version and module version information
-// in the executable x.
-func findVers(x exe) (vers, mod string) {
+// findVers finds and returns the Go version, module version information
+// and build flags in the executable x.
+func findVers(x exe) (vers, mod, flags string) {
 	// Read the first 64kB of text to find the build info blob.
 	text := x.DataStart()
 	data, err := x.ReadData(text, 64*1024)
@@ -181,7 +189,7 @@ func findVers(x exe) (vers, mod string) {
 
 	// Decode the blob.
 	ptrSize := int(data[14])
-	bigEndian := data[15] != 0
+	bigEndian := data[15]&1 != 0
 	var bo binary.ByteOrder
 	if bigEndian {
 		bo = binary.BigEndian
@@ -205,6 +213,9 @@ func findVers(x exe) (vers, mod string) {
 	} else {
 		mod = ""
 	}
+	if data[15]&2 != 0 && len(data) >= 16+3*ptrSize {
+		flags = readString(x, ptrSize, readPtr, readPtr(data[16+2*ptrSize:]))
+	}
 	return
 }
 
@@ -199,6 +199,8 @@ type Context interface {
 	RecordDclReference(from Sym, to Sym, dclIdx int, inlIndex int)
 	RecordChildDieOffsets(s Sym, vars []*Var, offsets []int32)
 	AddString(s Sym, v string)
+	AddStringIndex(s Sym, v string)
+	AddAddressIndex(s Sym, t interface{}, ofs int64)
 	AddFileRef(s Sym, f interface{})
 	Logf(format string, args ...interface{})
 }
@@ -387,6 +389,20 @@ func expandPseudoForm(form uint8) uint8 {
 	return uint8(expandedForm)
 }
 
+// UseDWARF5 reports whether DWARF version 5 is generated for the
+// target. It is enabled by GOEXPERIMENT=dwarf5, and only for ELF
+// targets, as the Mach-O, PE and XCOFF tools don't accept it yet.
+func UseDWARF5() bool {
+	if !objabi.Experiment.Dwarf5 {
+		return false
+	}
+	switch objabi.GOOS {
+	case "aix", "darwin", "ios", "js", "plan9", "windows":
+		return false
+	}
+	return true
+}
+
 // Abbrevs() returns the finalized abbrev array for the platform,
 // expanding any DW_FORM pseudo-ops to real values.
 func Abbrevs() []dwAbbrev {
@@ -398,10 +414,49 @@ func Abbrevs() []dwAbbrev {
 			abbrevs[i].attr[j].form = expandPseudoForm(abbrevs[i].attr[j].form)
 		}
 	}
+	if UseDWARF5() {
+		useDWARF5Forms()
+	}
 	abbrevsFinalized = true
 	return abbrevs[:]
 }
 
+// useDWARF5Forms changes the abbrevs of the DIEs written by the linker
+// (compilation units and types) to refer to their strings through
+// .debug_str_offsets, and to the compilation unit's base address
+// through .debug_addr. The DIEs written by the compiler keep their
+// strings inline, as each function's DIEs are written independently.
+func useDWARF5Forms() {
+	for i := 1; i < DW_NABRV; i++ {
+		switch {
+		case i == DW_ABRV_COMPUNIT, i == DW_ABRV_COMPUNIT_TEXTLESS:
+		case i >= DW_ABRV_STRUCTFIELD:
+		default:
+			continue
+		}
+		a := &abbrevs[i]
+		for j := range a.attr {
+			switch a.attr[j].form {
+			case DW_FORM_string:
+				a.attr[j].form = DW_FORM_strx
+			case DW_FORM_addr:
+				if a.attr[j].attr == DW_AT_low_pc {
+					a.attr[j].form = DW_FORM_addrx
+				}
+			}
+		}
+		if a.tag == DW_TAG_compile_unit {
+			// Some readers need the bases before the attributes
+			// that use them.
+			bases := []dwAttrForm{{DW_AT_str_offsets_base, DW_FORM_sec_offset}}
+			if i == DW_ABRV_COMPUNIT {
+				bases = append(bases, dwAttrForm{DW_AT_addr_base, DW_FORM_sec_offset})
+			}
+			a.attr = append(bases, a.attr...)
+		}
+	}
+}
+
 // abbrevs is a raw table of abbrev entries; it needs to be post-processed
 // by the Abbrevs() function above prior to being consumed, to expand
 // the 'pseudo-form' entries below to real DWARF form values.
@@ -968,6 +1023,12 @@ func putattr(ctxt Context, s Sym, abbrev int, form int, cls int, value int64, da
 	case DW_FORM_udata: // constant
 		Uleb128put(ctxt, s, value)
 
+	case DW_FORM_strx: // string
+		ctxt.AddStringIndex(s, data.(string))
+
+	case DW_FORM_addrx: // address
+		ctxt.AddAddressIndex(s, data, value)
+
 	case DW_FORM_string: // string
 		str := data.(string)
 		ctxt.AddString(s, str)
@@ -1054,6 +1115,10 @@ func PutGlobal(ctxt Context, info, typ, gvar Sym, name string) {
 // relative to some base address, which must be arranged by the caller
 // (e.g., with a DW_AT_low_pc attribute, or in a BASE-prefixed range).
 func PutBasedRanges(ctxt Context, sym Sym, ranges []Range) {
+	if UseDWARF5() {
+		putRangeList(ctxt, sym, ranges)
+		return
+	}
 	ps := ctxt.PtrSize()
 	// Write ranges.
 	for _, r := range ranges {
@@ -1071,6 +1136,13 @@ func (s *FnState) PutRanges(ctxt Context, ranges []Range) {
 	ps := ctxt.PtrSize()
 	sym, base := s.Ranges, s.StartPC
 
+	if UseDWARF5() {
+		ctxt.AddInt(sym, 1, DW_RLE_base_address)
+		ctxt.AddAddress(sym, base, 0)
+		putRangeList(ctxt, sym, ranges)
+		return
+	}
+
 	if s.UseBASEntries {
 		// Using a Base Address Selection Entry reduces the number of relocations, but
 		// this is not done on macOS because it is not supported by dsymutil/dwarfdump/lldb
@@ -1090,6 +1162,17 @@ func (s *FnState) PutRanges(ctxt Context, ranges []Range) {
 	ctxt.AddInt(sym, ps, 0)
 }
 
+// putRangeList writes ranges to sym as DWARF 5 offset pair entries
+// relative to the current base address, followed by the end of the list.
+func putRangeList(ctxt Context, sym Sym, ranges []Range) {
+	for _, r := range ranges {
+		ctxt.AddInt(sym, 1, DW_RLE_offset_pair)
+		Uleb128put(ctxt, sym, r.Start)
+		Uleb128put(ctxt, sym, r.End)
+	}
+	ctxt.AddInt(sym, 1, DW_RLE_end_of_list)
+}
+
 // Return TRUE if the inlined call in the specified slot is empty,
 // meaning it has a zero-length range (no instructions), and all
 // of its children are empty.
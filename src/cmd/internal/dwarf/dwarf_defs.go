@@ -187,6 +187,9 @@ const (
 	DW_AT_elemental      = 0x66 // flag
 	DW_AT_pure           = 0x67 // flag
 	DW_AT_recursive      = 0x68 // flag
+	// Dwarf5
+	DW_AT_str_offsets_base = 0x72 // stroffsetsptr
+	DW_AT_addr_base        = 0x73 // addrptr
 
 	DW_AT_lo_user = 0x2000 // ---
 	DW_AT_hi_user = 0x3fff // ---
@@ -220,6 +223,9 @@ const (
 	DW_FORM_exprloc      = 0x18 // exprloc
 	DW_FORM_flag_present = 0x19 // flag
 	DW_FORM_ref_sig8     = 0x20 // reference
+	// Dwarf5
+	DW_FORM_strx  = 0x1a // string
+	DW_FORM_addrx = 0x1b // address
 	// Pseudo-form: expanded to data4 on IOS, udata elsewhere.
 	DW_FORM_udata_pseudo = 0x99
 )
@@ -446,6 +452,31 @@ const (
 	DW_LNE_hi_user      = 0xff
 )
 
+// Dwarf5 Table 7.2
+const (
+	DW_UT_compile = 0x01
+)
+
+// Dwarf5 Table 7.27
+const (
+	DW_LNCT_path            = 0x1
+	DW_LNCT_directory_index = 0x2
+)
+
+// Dwarf5 Table 7.29
+const (
+	DW_LLE_end_of_list  = 0x00
+	DW_LLE_offset_pair  = 0x04
+	DW_LLE_base_address = 0x06
+)
+
+// Dwarf5 Table 7.30
+const (
+	DW_RLE_end_of_list  = 0x00
+	DW_RLE_offset_pair  = 0x04
+	DW_RLE_base_address = 0x05
+)
+
 // Table 39
 const (
 	DW_MACINFO_define     = 0x01
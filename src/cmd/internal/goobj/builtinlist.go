@@ -74,6 +74,7 @@ var builtins = [...]struct {
 	{"runtime.convT2Enoptr", 1},
 	{"runtime.convT2I", 1},
 	{"runtime.convT2Inoptr", 1},
+	{"runtime.lazyitab", 1},
 	{"runtime.assertE2I", 1},
 	{"runtime.assertE2I2", 1},
 	{"runtime.assertI2I", 1},
@@ -110,6 +111,8 @@ var builtins = [...]struct {
 	{"runtime.mapdelete_faststr", 1},
 	{"runtime.mapiternext", 1},
 	{"runtime.mapclear", 1},
+	{"runtime.mapinitbulk", 1},
+	{"runtime.staticmapindex", 1},
 	{"runtime.makechan64", 1},
 	{"runtime.makechan", 1},
 	{"runtime.chanrecv1", 1},
@@ -132,6 +135,10 @@ var builtins = [...]struct {
 	{"runtime.memmove", 1},
 	{"runtime.memclrNoHeapPointers", 1},
 	{"runtime.memclrHasPointers", 1},
+	{"runtime.loopmove", 1},
+	{"runtime.loopset8", 1},
+	{"runtime.loopxor8", 1},
+	{"runtime.loopsum8", 1},
 	{"runtime.memequal", 1},
 	{"runtime.memequal0", 1},
 	{"runtime.memequal8", 1},
@@ -182,6 +189,8 @@ var builtins = [...]struct {
 	{"runtime.msanread", 1},
 	{"runtime.msanwrite", 1},
 	{"runtime.msanmove", 1},
+	{"runtime.asanread", 1},
+	{"runtime.asanwrite", 1},
 	{"runtime.checkptrAlignment", 1},
 	{"runtime.checkptrArithmetic", 1},
 	{"runtime.libfuzzerTraceCmp1", 1},
@@ -192,6 +201,8 @@ var builtins = [...]struct {
 	{"runtime.libfuzzerTraceConstCmp2", 1},
 	{"runtime.libfuzzerTraceConstCmp4", 1},
 	{"runtime.libfuzzerTraceConstCmp8", 1},
+	{"runtime.libfuzzerHookStrCmp", 1},
+	{"runtime.coverIncAtomic", 1},
 	{"runtime.x86HasPOPCNT", 0},
 	{"runtime.x86HasSSE41", 0},
 	{"runtime.x86HasFMA", 0},
@@ -258,6 +258,16 @@ func (c dwCtxt) AddDWARFAddrSectionOffset(s dwarf.Sym, t interface{}, ofs int64)
 	r.Type = objabi.R_DWARFSECREF
 }
 
+// The string and address tables of DWARF 5 are only written by the linker.
+
+func (c dwCtxt) AddStringIndex(s dwarf.Sym, v string) {
+	panic("should be used only in the linker")
+}
+
+func (c dwCtxt) AddAddressIndex(s dwarf.Sym, t interface{}, ofs int64) {
+	panic("should be used only in the linker")
+}
+
 func (c dwCtxt) AddFileRef(s dwarf.Sym, f interface{}) {
 	ls := s.(*LSym)
 	rsym := f.(*LSym)
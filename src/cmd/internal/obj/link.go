@@ -820,12 +820,16 @@ type RegSpill struct {
 	Spill, Unspill As
 }
 
+// DebugasmJSON is the value of Link.Debugasm that prints the assembly
+// listing of each function as a line of JSON.
+const DebugasmJSON = -1
+
 // Link holds the context for writing object code from a compiler
 // to be linker input or for reading that input into the linker.
 type Link struct {
 	Headtype           objabi.HeadType
 	Arch               *LinkArch
-	Debugasm           int
+	Debugasm           int // assembly listing level; DebugasmJSON prints it as JSON
 	Debugvlog          bool
 	Debugpcln          string
 	Flag_shared        bool
@@ -834,6 +838,7 @@ type Link struct {
 	Flag_optimize      bool
 	Flag_locationlists bool
 	Retpoline          bool // emit use of retpoline stubs for indirect jmp/call
+	RetThunk           bool // emit jumps to the return thunk in place of returns
 	Bso                *bufio.Writer
 	Pathname           string
 	Pkgpath            string           // the current package's import path, "" if unknown
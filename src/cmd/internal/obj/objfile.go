@@ -11,9 +11,11 @@ import (
 	"cmd/internal/bio"
 	"cmd/internal/goobj"
 	"cmd/internal/objabi"
+	"cmd/internal/src"
 	"cmd/internal/sys"
 	"crypto/sha1"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -750,6 +752,9 @@ func writeAuxSymDebug(ctxt *Link, par *LSym, aux *LSym) {
 }
 
 func debugAsmEmit(ctxt *Link) {
+	if ctxt.Debugasm == DebugasmJSON {
+		ctxt.traverseSyms(traverseDefs, ctxt.writeSymJSON)
+	}
 	if ctxt.Debugasm > 0 {
 		ctxt.traverseSyms(traverseDefs, ctxt.writeSymDebug)
 		if ctxt.Debugasm > 1 {
@@ -851,6 +856,74 @@ func (ctxt *Link) writeSymDebugNamed(s *LSym, name string) {
 	}
 }
 
+// writeSymJSON writes the instructions of the function s as a line of
+// JSON, for tools that would otherwise parse the -S listing.
+func (ctxt *Link) writeSymJSON(s *LSym) {
+	if s.Type != objabi.STEXT {
+		return
+	}
+	type inlCall struct {
+		Func string `json:"func"` // the inlined function
+		Pos  string `json:"pos"`  // the position of the call
+	}
+	type inst struct {
+		PC    int64     `json:"pc"`
+		Op    string    `json:"op"`
+		Text  string    `json:"text"`
+		Pos   string    `json:"pos"`
+		Stmt  bool      `json:"stmt,omitempty"`
+		Bytes string    `json:"bytes,omitempty"`
+		Inl   []inlCall `json:"inl,omitempty"` // innermost first
+	}
+	fn := s.Func()
+	var insts []inst
+	for p := fn.Text; p != nil; p = p.Link {
+		pos := ctxt.InnermostPos(p.Pos)
+		in := inst{
+			PC:   p.Pc,
+			Op:   p.As.String(),
+			Text: p.InstructionString(),
+			Pos:  pos.Format(true, false),
+			Stmt: p.Pos.IsStmt() == src.PosIsStmt,
+		}
+		end := s.Size
+		if p.Link != nil {
+			end = p.Link.Pc
+		}
+		if p.Pc < end && end <= int64(len(s.P)) {
+			in.Bytes = fmt.Sprintf("%x", s.P[p.Pc:end])
+		}
+		for ix := pos.Base().InliningIndex(); ix >= 0; {
+			call := ctxt.InlTree.nodes[ix]
+			in.Inl = append(in.Inl, inlCall{ctxt.jsonSymName(call.Func.Name), ctxt.PosTable.Pos(call.Pos).Format(true, false)})
+			ix = call.Parent
+		}
+		insts = append(insts, in)
+	}
+	b, err := json.Marshal(struct {
+		Func   string `json:"func"`
+		ABI    int    `json:"abi"`
+		Size   int64  `json:"size"`
+		Args   int32  `json:"args"`
+		Locals int32  `json:"locals"`
+		Leaf   bool   `json:"leaf,omitempty"`
+		Insts  []inst `json:"insts"`
+	}{ctxt.jsonSymName(s.Name), int(s.ABI()), s.Size, fn.Args, fn.Locals, s.Leaf(), insts})
+	if err != nil {
+		log.Fatalf("writing JSON listing of %s: %v", s.Name, err)
+	}
+	fmt.Fprintf(ctxt.Bso, "%s\n", b)
+}
+
+// jsonSymName returns name with the current package's path in place of
+// the "". placeholder.
+func (ctxt *Link) jsonSymName(name string) string {
+	if strings.HasPrefix(name, `"".`) {
+		return strings.TrimPrefix(ctxt.Pkgpath+".", ".") + name[len(`"".`):]
+	}
+	return name
+}
+
 // relocByOff sorts relocations by their offsets.
 type relocByOff []Reloc
 
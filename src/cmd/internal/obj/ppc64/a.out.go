@@ -246,6 +246,7 @@ const (
 	REGCTXT = REG_R11 /* context for closures */
 	REGTLS  = REG_R13 /* C ABI TLS base pointer */
 	REGMAX  = REG_R27
+	REGFP   = REG_R29 /* frame pointer */
 	REGEXT  = REG_R30 /* external registers allocated from here down */
 	REGG    = REG_R30 /* G */
 	REGTMP  = REG_R31 /* used by the linker */
@@ -756,6 +757,12 @@ const (
 	AMADDHDU
 	AMADDLD
 
+	/* set boolean from condition register bit, ISA 3.1 */
+	ASETBC
+	ASETBCR
+	ASETNBC
+	ASETNBCR
+
 	/* Vector */
 	ALV
 	ALVEBX
@@ -351,6 +351,10 @@ var Anames = []string{
 	"MADDHD",
 	"MADDHDU",
 	"MADDLD",
+	"SETBC",
+	"SETBCR",
+	"SETNBC",
+	"SETNBCR",
 	"LV",
 	"LVEBX",
 	"LVEHX",
@@ -47,6 +47,7 @@ type ctxt9 struct {
 	newprog    obj.ProgAlloc
 	cursym     *obj.LSym
 	autosize   int32
+	extrasize  int32
 	instoffset int64
 	pc         int64
 }
@@ -364,6 +365,7 @@ var optab = []Optab{
 	{as: AFTSQRT, a1: C_FREG, a6: C_SCON, type_: 93, size: 4},                     /* floating test for sw square root, x-form */
 	{as: ACOPY, a1: C_REG, a6: C_REG, type_: 92, size: 4},                         /* copy/paste facility, x-form */
 	{as: ADARN, a1: C_SCON, a6: C_REG, type_: 92, size: 4},                        /* deliver random number, x-form */
+	{as: ASETBC, a1: C_SCON, a6: C_REG, type_: 92, size: 4},                       /* set boolean from condition register bit, x-form, ISA 3.1 */
 	{as: ALDMX, a1: C_SOREG, a6: C_REG, type_: 45, size: 4},                       /* load doubleword monitored, x-form */
 	{as: AMADDHD, a1: C_REG, a2: C_REG, a3: C_REG, a6: C_REG, type_: 83, size: 4}, /* multiply-add high/low doubleword, va-form */
 	{as: AADDEX, a1: C_REG, a2: C_REG, a3: C_SCON, a6: C_REG, type_: 94, size: 4}, /* add extended using alternate carry, z23-form */
@@ -645,7 +647,8 @@ func span9(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 		ctxt.Diag("ppc64 ops not initialized, call ppc64.buildop first")
 	}
 
-	c := ctxt9{ctxt: ctxt, newprog: newprog, cursym: cursym, autosize: int32(p.To.Offset)}
+	c := ctxt9{ctxt: ctxt, newprog: newprog, cursym: cursym, autosize: int32(p.To.Offset & 0xffffffff), extrasize: int32(p.To.Offset >> 32)}
+	p.To.Offset &= 0xffffffff // extrasize is no longer needed
 
 	pc := int64(0)
 	p.Pc = pc
@@ -656,6 +659,13 @@ func span9(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 		p.Pc = pc
 		o = c.oplook(p)
 		m = int(o.size)
+		if c.isPrefixed(p, o) && cursym.Func().Align < 64 {
+			// Padding to keep prefixed instructions from
+			// crossing a 64-byte boundary relies on the
+			// function being aligned to 64 bytes.
+			cursym.Func().Align = 64
+		}
+		m += c.prefixPad(p, o, pc)
 		if m == 0 {
 			if p.As == obj.APCALIGN {
 				a := c.vregoff(&p.From)
@@ -713,7 +723,7 @@ func span9(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 				}
 			}
 
-			m = int(o.size)
+			m = int(o.size) + c.prefixPad(p, o, pc)
 			if m == 0 {
 				if p.As == obj.APCALIGN {
 					a := c.vregoff(&p.From)
@@ -766,6 +776,11 @@ func span9(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 				}
 			}
 		} else {
+			if pad := c.prefixPad(p, o, p.Pc); pad > 0 {
+				c.ctxt.Arch.ByteOrder.PutUint32(bp, LOP_RRR(OP_OR, REGZERO, REGZERO, REGZERO))
+				bp = bp[pad:]
+				c.pc += int64(pad)
+			}
 			c.asmout(p, o, out[:])
 			for i = 0; i < int32(o.size/4); i++ {
 				c.ctxt.Arch.ByteOrder.PutUint32(bp, out[i])
@@ -857,7 +872,7 @@ func (c *ctxt9) aclass(a *obj.Addr) int {
 			return C_TOCADDR
 
 		case obj.NAME_AUTO:
-			c.instoffset = int64(c.autosize) + a.Offset
+			c.instoffset = int64(c.autosize) + a.Offset - int64(c.extrasize)
 			if c.instoffset >= -BIG && c.instoffset < BIG {
 				return C_SOREG
 			}
@@ -926,7 +941,7 @@ func (c *ctxt9) aclass(a *obj.Addr) int {
 			return C_LCON
 
 		case obj.NAME_AUTO:
-			c.instoffset = int64(c.autosize) + a.Offset
+			c.instoffset = int64(c.autosize) + a.Offset - int64(c.extrasize)
 			if c.instoffset >= -BIG && c.instoffset < BIG {
 				return C_SACON
 			}
@@ -1815,6 +1830,11 @@ func buildop(ctxt *obj.Link) {
 		case AISEL:
 			opset(AISEL, r0)
 
+		case ASETBC:
+			opset(ASETBCR, r0)
+			opset(ASETNBC, r0)
+			opset(ASETNBCR, r0)
+
 		case AMTFSB0:
 			opset(AMTFSB0CC, r0)
 			opset(AMTFSB1, r0)
@@ -2267,6 +2287,109 @@ func (c *ctxt9) opform(insn uint32) int {
 	return 0
 }
 
+// Prefix words of the prefixed instructions of ISA 3.1 (Power10).
+// An 8LS prefix is followed by a load or store with its own opcode, an
+// MLS prefix by the D-form instruction it extends.
+const (
+	PFX_8LS = 1<<26 | 0<<24
+	PFX_MLS = 1<<26 | 2<<24
+	PFX_R   = 1 << 20 // the displacement is relative to the address of the prefix
+)
+
+// prefixedOp returns the prefix and instruction words of the prefixed
+// form, with a 34-bit displacement, of the D-form or DS-form instruction
+// op, and whether it has one.
+func prefixedOp(op uint32) (pfx, o uint32, ok bool) {
+	switch op {
+	case OP_ADDI, // paddi
+		OPVCC(32, 0, 0, 0), // plwz
+		OPVCC(34, 0, 0, 0), // plbz
+		OPVCC(40, 0, 0, 0), // plhz
+		OPVCC(42, 0, 0, 0), // plha
+		OPVCC(48, 0, 0, 0), // plfs
+		OPVCC(50, 0, 0, 0), // plfd
+		OPVCC(36, 0, 0, 0), // pstw
+		OPVCC(38, 0, 0, 0), // pstb
+		OPVCC(44, 0, 0, 0), // psth
+		OPVCC(52, 0, 0, 0), // pstfs
+		OPVCC(54, 0, 0, 0): // pstfd
+		return PFX_MLS, op, true
+	case OPVCC(58, 0, 0, 0): // ld
+		return PFX_8LS, OPVCC(57, 0, 0, 0), true // pld
+	case OPVCC(58, 0, 0, 0) | 1<<1: // lwa
+		return PFX_8LS, OPVCC(41, 0, 0, 0), true // plwa
+	case OPVCC(62, 0, 0, 0): // std
+		return PFX_8LS, OPVCC(61, 0, 0, 0), true // pstd
+	}
+	return 0, 0, false
+}
+
+// AOP_PFX returns the prefix and instruction words of a prefixed
+// instruction with register operands d and a and the 34-bit
+// displacement simm.
+func AOP_PFX(pfx uint32, op uint32, d uint32, a uint32, simm int64) (uint32, uint32) {
+	return pfx | uint32(simm>>16)&0x3FFFF, AOP_IRR(op, d, a, uint32(simm))
+}
+
+// usePrefixed reports whether the prefixed instructions of ISA 3.1
+// are used in place of two-instruction sequences.
+func (c *ctxt9) usePrefixed() bool {
+	return objabi.GOPPC64 >= 10 && c.ctxt.Headtype != objabi.Haix
+}
+
+// usePCrel reports whether symbols are addressed relative to the PC
+// with prefixed instructions, rather than by their absolute address
+// or relative to the TOC.
+func (c *ctxt9) usePCrel() bool {
+	return c.usePrefixed() && !c.ctxt.Flag_shared && !c.ctxt.Flag_dynlink
+}
+
+// isPrefixed reports whether p, which matched o, assembles to a
+// prefixed instruction.
+func (c *ctxt9) isPrefixed(p *obj.Prog, o *Optab) bool {
+	if !c.usePrefixed() {
+		return false
+	}
+	var op uint32
+	switch o.type_ {
+	case 19:
+		if p.From.Sym != nil && !c.usePCrel() {
+			return false
+		}
+		op = OP_ADDI
+	case 26:
+		op = OP_ADDI
+	case 35:
+		op = c.opstore(p.As)
+	case 36, 37:
+		op = c.opload(p.As)
+	case 74:
+		if !c.usePCrel() {
+			return false
+		}
+		op = c.opstore(p.As)
+	case 75, 76:
+		if !c.usePCrel() {
+			return false
+		}
+		op = c.opload(p.As)
+	default:
+		return false
+	}
+	_, _, ok := prefixedOp(op)
+	return ok
+}
+
+// prefixPad returns the number of bytes of padding needed before p,
+// which matched o, at pc. A prefixed instruction must not cross a
+// 64-byte boundary.
+func (c *ctxt9) prefixPad(p *obj.Prog, o *Optab, pc int64) int {
+	if pc&63 == 60 && c.isPrefixed(p, o) {
+		return 4
+	}
+	return 0
+}
+
 // Encode instructions and create relocation for accessing s+d according to the
 // instruction op with source or destination (as appropriate) register reg.
 func (c *ctxt9) symbolAccess(s *obj.LSym, d int64, reg int16, op uint32) (o1, o2 uint32) {
@@ -2274,6 +2397,16 @@ func (c *ctxt9) symbolAccess(s *obj.LSym, d int64, reg int16, op uint32) (o1, o2
 		// Every symbol access must be made via a TOC anchor.
 		c.ctxt.Diag("symbolAccess called for %s", s.Name)
 	}
+	if pfx, pop, ok := prefixedOp(op); ok && c.usePCrel() {
+		o1, o2 = AOP_PFX(pfx|PFX_R, pop, uint32(reg), 0, 0)
+		rel := obj.Addrel(c.cursym)
+		rel.Off = int32(c.pc)
+		rel.Siz = 8
+		rel.Sym = s
+		rel.Add = d
+		rel.Type = objabi.R_ADDRPOWER_PCREL34
+		return
+	}
 	var base uint32
 	form := c.opform(op)
 	if c.ctxt.Flag_shared {
@@ -2848,7 +2981,10 @@ func (c *ctxt9) asmout(p *obj.Prog, o *Optab, out []uint32) {
 	case 19: /* mov $lcon,r ==> cau+or */
 		d := c.vregoff(&p.From)
 
-		if p.From.Sym == nil {
+		if p.From.Sym == nil && c.usePrefixed() {
+			// pli
+			o1, o2 = AOP_PFX(PFX_MLS, OP_ADDI, uint32(p.To.Reg), 0, d)
+		} else if p.From.Sym == nil {
 			o1 = loadu32(int(p.To.Reg), d)
 			o2 = LOP_IRR(OP_ORI, uint32(p.To.Reg), uint32(p.To.Reg), uint32(int32(d)))
 		} else {
@@ -2971,14 +3107,19 @@ func (c *ctxt9) asmout(p *obj.Prog, o *Optab, out []uint32) {
 		}
 
 	case 26: /* mov $lsext/auto/oreg,,r2 ==> addis+addi */
-		if p.To.Reg == REGTMP {
-			c.ctxt.Diag("can't synthesize large constant\n%v", p)
-		}
 		v := c.regoff(&p.From)
 		r := int(p.From.Reg)
 		if r == 0 {
 			r = c.getimpliedreg(&p.From, p)
 		}
+		if c.usePrefixed() {
+			// paddi
+			o1, o2 = AOP_PFX(PFX_MLS, OP_ADDI, uint32(p.To.Reg), uint32(r), int64(v))
+			break
+		}
+		if p.To.Reg == REGTMP {
+			c.ctxt.Diag("can't synthesize large constant\n%v", p)
+		}
 		o1 = AOP_IRR(OP_ADDIS, REGTMP, uint32(r), uint32(high16adjusted(v)))
 		o2 = AOP_IRR(OP_ADDI, uint32(p.To.Reg), REGTMP, uint32(v))
 
@@ -3123,8 +3264,12 @@ func (c *ctxt9) asmout(p *obj.Prog, o *Optab, out []uint32) {
 		if r == 0 {
 			r = c.getimpliedreg(&p.To, p)
 		}
-		// Offsets in DS form stores must be a multiple of 4
 		inst := c.opstore(p.As)
+		if pfx, pinst, ok := prefixedOp(inst); ok && c.usePrefixed() {
+			o1, o2 = AOP_PFX(pfx, pinst, uint32(p.From.Reg), uint32(r), int64(v))
+			break
+		}
+		// Offsets in DS form stores must be a multiple of 4
 		if c.opform(inst) == DS_FORM && v&0x3 != 0 {
 			log.Fatalf("invalid offset for DS form load/store %v", p)
 		}
@@ -3138,8 +3283,13 @@ func (c *ctxt9) asmout(p *obj.Prog, o *Optab, out []uint32) {
 		if r == 0 {
 			r = c.getimpliedreg(&p.From, p)
 		}
+		inst := c.opload(p.As)
+		if pfx, pinst, ok := prefixedOp(inst); ok && c.usePrefixed() {
+			o1, o2 = AOP_PFX(pfx, pinst, uint32(p.To.Reg), uint32(r), int64(v))
+			break
+		}
 		o1 = AOP_IRR(OP_ADDIS, REGTMP, uint32(r), uint32(high16adjusted(v)))
-		o2 = AOP_IRR(c.opload(p.As), uint32(p.To.Reg), REGTMP, uint32(v))
+		o2 = AOP_IRR(inst, uint32(p.To.Reg), REGTMP, uint32(v))
 
 	case 37: /* movb lext/lauto/lreg,r ==> lbz o(reg),r; extsb r */
 		v := c.regoff(&p.From)
@@ -3148,8 +3298,13 @@ func (c *ctxt9) asmout(p *obj.Prog, o *Optab, out []uint32) {
 		if r == 0 {
 			r = c.getimpliedreg(&p.From, p)
 		}
-		o1 = AOP_IRR(OP_ADDIS, REGTMP, uint32(r), uint32(high16adjusted(v)))
-		o2 = AOP_IRR(c.opload(p.As), uint32(p.To.Reg), REGTMP, uint32(v))
+		inst := c.opload(p.As)
+		if pfx, pinst, ok := prefixedOp(inst); ok && c.usePrefixed() {
+			o1, o2 = AOP_PFX(pfx, pinst, uint32(p.To.Reg), uint32(r), int64(v))
+		} else {
+			o1 = AOP_IRR(OP_ADDIS, REGTMP, uint32(r), uint32(high16adjusted(v)))
+			o2 = AOP_IRR(inst, uint32(p.To.Reg), REGTMP, uint32(v))
+		}
 		o3 = LOP_RRR(OP_EXTSB, uint32(p.To.Reg), uint32(p.To.Reg), 0)
 
 	case 40: /* word */
@@ -4962,6 +5117,14 @@ func (c *ctxt9) opirr(a obj.As) uint32 {
 		return OPVCC(31, 902, 0, 1) /* paste. - v3.00 */
 	case ADARN:
 		return OPVCC(31, 755, 0, 0) /* darn - v3.00 */
+	case ASETBC:
+		return OPVCC(31, 384, 0, 0) /* setbc - v3.1 */
+	case ASETBCR:
+		return OPVCC(31, 416, 0, 0) /* setbcr - v3.1 */
+	case ASETNBC:
+		return OPVCC(31, 448, 0, 0) /* setnbc - v3.1 */
+	case ASETNBCR:
+		return OPVCC(31, 480, 0, 0) /* setnbcr - v3.1 */
 
 	case AMULLW, AMULLD:
 		return OPVCC(7, 0, 0, 0) /* mulli works with MULLW or MULLD */
@@ -35,6 +35,28 @@ ADD $4, R8
 RET
 `
 
+var prefixedSrc = `
+TEXT test(SB),0,$0-0
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+ADD $2, R3
+MOVD 100000(R3), R4
+MOVD $0x12345678, R5
+RET
+`
+
 // TestPCalign generates two asm files containing the
 // PCALIGN directive, to verify correct values are and
 // accepted, and incorrect values are flagged in error.
@@ -107,3 +129,46 @@ func TestPCalign(t *testing.T) {
 		t.Errorf("Invalid alignment not detected for PCALIGN\n")
 	}
 }
+
+// TestPrefixed verifies that with GOPPC64=power10 large offsets and
+// constants use 8 byte prefixed instructions, and that a prefixed
+// instruction which would cross a 64 byte boundary is moved past it.
+func TestPrefixed(t *testing.T) {
+	// The padding nop is emitted as part of the padded instruction,
+	// so the pld is listed at 0x3c but its prefix word is at 0x40.
+	var patternPld = `0x003c\s.*MOVD\s100000\(R3\),\sR4`
+	var patternPli = `0x0048\s.*MOVD\s\$305419896,\sR5`
+	var patternRet = `0x0050\s.*JMP\sLR`
+	var patternEnc = `0x0040 01 00 00 04 a0 86 83 e4 34 12 00 06 78 56 a0 38`
+
+	testenv.MustHaveGoBuild(t)
+
+	dir, err := ioutil.TempDir("", "testprefixed")
+	if err != nil {
+		t.Fatalf("could not create directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmpfile := filepath.Join(dir, "x.s")
+	err = ioutil.WriteFile(tmpfile, []byte(prefixedSrc), 0644)
+	if err != nil {
+		t.Fatalf("can't write output: %v\n", err)
+	}
+
+	cmd := exec.Command(testenv.GoToolPath(t), "tool", "asm", "-o", filepath.Join(dir, "x.o"), "-S", tmpfile)
+	cmd.Env = append(os.Environ(), "GOARCH=ppc64le", "GOOS=linux", "GOPPC64=power10")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Build failed: %v, output: %s", err, out)
+	}
+
+	for _, pattern := range []string{patternPld, patternPli, patternRet, patternEnc} {
+		matched, err := regexp.MatchString(pattern, string(out))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !matched {
+			t.Errorf("pattern %s not found in output:%s\n", pattern, out)
+		}
+	}
+}
@@ -611,10 +611,17 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 				p.From.Sym.Set(obj.AttrNoFrame, true)
 			}
 
+			extrasize := int32(0)
 			if !p.From.Sym.NoFrame() {
 				// If there is a stack frame at all, it includes
 				// space to save the LR.
 				autosize += int32(c.ctxt.FixedFrameSize())
+
+				// It also includes a slot at the top for the
+				// frame pointer, which the caller saves just
+				// below its stack pointer.
+				extrasize = 8
+				autosize += extrasize
 			}
 
 			if p.Mark&LEAF != 0 && autosize < objabi.StackSmall {
@@ -623,7 +630,13 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 				p.From.Sym.Set(obj.AttrNoSplit, true)
 			}
 
-			p.To.Offset = int64(autosize)
+			// low 32 bits for autosize
+			// high 32 bits for extrasize
+			p.To.Offset = int64(autosize) | int64(extrasize)<<32
+
+			if autosize == 0 {
+				c.checkFrameWrites()
+			}
 
 			q = p
 
@@ -699,6 +712,27 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 					q.To.Offset = int64(-autosize)
 					q.To.Reg = REGSP
 					q.Spadj = autosize
+
+					q = obj.Appendp(q, c.newprog)
+					q.As = AMOVD
+					q.Pos = p.Pos
+					q.From.Type = obj.TYPE_REG
+					q.From.Reg = REGFP
+					q.To.Type = obj.TYPE_MEM
+					q.To.Offset = -8
+					q.To.Reg = REGSP
+
+					// The caller's frame pointer has been saved just below
+					// SP. Point FP at it, so that FP and FP+8 hold the
+					// caller's FP and our saved LR, as on x86.
+					q = obj.Appendp(q, c.newprog)
+					q.As = AADD
+					q.Pos = p.Pos
+					q.From.Type = obj.TYPE_CONST
+					q.From.Offset = -8
+					q.Reg = REGSP
+					q.To.Type = obj.TYPE_REG
+					q.To.Reg = REGFP
 				} else {
 					// Frame size is too large for a MOVDU instruction.
 					// Store link register before decrementing SP, so if a signal comes
@@ -706,24 +740,45 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 					// code will not see a half-updated stack frame.
 					// This sequence is not async preemptible, as if we open a frame
 					// at the current SP, it will clobber the saved LR.
+					// The frame pointer is saved and pointed at the new frame
+					// first, so that LR can be stored at a small offset from it
+					// while REGTMP holds LR.
+					q = c.ctxt.StartUnsafePoint(q, c.newprog)
+
 					q = obj.Appendp(q, c.newprog)
 					q.As = AMOVD
 					q.Pos = p.Pos
 					q.From.Type = obj.TYPE_REG
-					q.From.Reg = REG_LR
+					q.From.Reg = REGFP
+					q.To.Type = obj.TYPE_MEM
+					q.To.Offset = int64(-autosize - 8)
+					q.To.Reg = REGSP
+
+					q = obj.Appendp(q, c.newprog)
+					q.As = AADD
+					q.Pos = p.Pos
+					q.From.Type = obj.TYPE_CONST
+					q.From.Offset = int64(-autosize - 8)
+					q.Reg = REGSP
 					q.To.Type = obj.TYPE_REG
-					q.To.Reg = REG_R29 // REGTMP may be used to synthesize large offset in the next instruction
+					q.To.Reg = REGFP
 
-					q = c.ctxt.StartUnsafePoint(q, c.newprog)
+					q = obj.Appendp(q, c.newprog)
+					q.As = AMOVD
+					q.Pos = p.Pos
+					q.From.Type = obj.TYPE_REG
+					q.From.Reg = REG_LR
+					q.To.Type = obj.TYPE_REG
+					q.To.Reg = REGTMP
 
 					q = obj.Appendp(q, c.newprog)
 					q.As = AMOVD
 					q.Pos = p.Pos
 					q.From.Type = obj.TYPE_REG
-					q.From.Reg = REG_R29
+					q.From.Reg = REGTMP
 					q.To.Type = obj.TYPE_MEM
-					q.To.Offset = int64(-autosize)
-					q.To.Reg = REGSP
+					q.To.Offset = 8
+					q.To.Reg = REGFP
 
 					prologueEnd = q
 
@@ -731,13 +786,15 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 					q.As = AADD
 					q.Pos = p.Pos
 					q.From.Type = obj.TYPE_CONST
-					q.From.Offset = int64(-autosize)
+					q.From.Offset = 8
+					q.Reg = REGFP
 					q.To.Type = obj.TYPE_REG
 					q.To.Reg = REGSP
 					q.Spadj = +autosize
 
 					q = c.ctxt.EndUnsafePoint(q, c.newprog, -1)
 				}
+
 				prologueEnd.Pos = prologueEnd.Pos.WithXlogue(src.PosPrologueEnd)
 			} else if c.cursym.Func().Text.Mark&LEAF == 0 {
 				// A very few functions that do not return to their caller
@@ -875,6 +932,12 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 					break
 				}
 
+				// Restore FP.
+				p.As = AMOVD
+				p.From = obj.Addr{Type: obj.TYPE_MEM, Reg: REGSP, Offset: -8}
+				p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REGFP}
+
+				p = obj.Appendp(p, c.newprog)
 				p.As = AADD
 				p.From.Type = obj.TYPE_CONST
 				p.From.Offset = int64(autosize)
@@ -932,6 +995,20 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 			}
 			prev := p
 			if autosize != 0 && c.cursym.Name != "runtime.racecallbackthunk" {
+				// Restore FP.
+				q = c.newprog()
+				q.As = AMOVD
+				q.Pos = p.Pos
+				q.From.Type = obj.TYPE_MEM
+				q.From.Offset = -8
+				q.From.Reg = REGSP
+				q.To.Type = obj.TYPE_REG
+				q.To.Reg = REGFP
+
+				q.Link = prev.Link
+				prev.Link = q
+				prev = q
+
 				q = c.newprog()
 				q.As = AADD
 				q.Pos = p.Pos
@@ -941,7 +1018,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 				q.To.Reg = REGSP
 				q.Spadj = -autosize
 
-				q.Link = p.Link
+				q.Link = prev.Link
 				prev.Link = q
 				prev = q
 			}
@@ -962,7 +1039,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 			q1.Link = q.Link
 			prev.Link = q1
 		case AADD:
-			if p.To.Type == obj.TYPE_REG && p.To.Reg == REGSP && p.From.Type == obj.TYPE_CONST {
+			if p.To.Type == obj.TYPE_REG && p.To.Reg == REGSP && p.From.Type == obj.TYPE_CONST && (p.Reg == 0 || p.Reg == REGSP) {
 				p.Spadj = int32(-p.From.Offset)
 			}
 		case AMOVDU:
@@ -1003,6 +1080,28 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 	}
 }
 
+// checkFrameWrites reports instructions in a frameless assembly function
+// that write the frame pointer. A function with a frame saves the
+// caller's frame pointer in its prologue and restores it on return, but
+// a frameless function has nowhere to keep it, so a write would break
+// frame pointer unwinding through its callers. The runtime switches
+// frame pointers deliberately and is exempt.
+func (c *ctxt9) checkFrameWrites() {
+	if !c.ctxt.IsAsm || objabi.IsRuntimePackagePath(c.ctxt.Pkgpath) || c.ctxt.Pkgpath == "runtime/cgo" {
+		return
+	}
+	for p := c.cursym.Func().Text; p != nil; p = p.Link {
+		switch p.As {
+		case ACMP, ACMPU, ACMPW, ACMPWU, ATW, ATD:
+			// Both operands are read.
+			continue
+		}
+		if p.To.Type == obj.TYPE_REG && p.To.Reg == REGFP {
+			c.ctxt.Diag("%v: frameless function writes frame pointer %s", p, rconv(REGFP))
+		}
+	}
+}
+
 /*
 // instruction scheduling
 	if(debug['Q'] == 0)
@@ -0,0 +1,286 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riscv
+
+import (
+	"cmd/internal/obj"
+	"cmd/internal/objabi"
+)
+
+// The RISC-V C extension provides 16-bit encodings of the most common
+// instructions, for smaller code. With GORISCV64=rv64gc, instructions
+// that have one are encoded in 16 bits by assemble; see encodeCompressed.
+//
+// Instructions whose immediate is only known once PCs have been
+// assigned (branches, jumps and the instruction following an AUIPC)
+// are never compressed, so that the length of each instruction is
+// known before PCs are assigned and does not change afterwards.
+
+// shouldCompress reports whether the instructions of the function
+// cursym may be compressed.
+func shouldCompress(cursym *obj.LSym) bool {
+	if objabi.GORISCV64 != "rv64gc" {
+		return false
+	}
+	// The compiler computes offsets into these functions,
+	// assuming that each of their instructions is 4 bytes long.
+	return cursym.Name != "runtime.duffzero" && cursym.Name != "runtime.duffcopy"
+}
+
+// compressible reports whether the instructions for p may be
+// compressed, given that prev is the Prog preceding it.
+func compressible(p, prev *obj.Prog) bool {
+	// The instruction following an AUIPC takes the low bits of a
+	// PC-relative offset, which are filled in after PCs are assigned
+	// or by a relocation covering both instructions.
+	return prev == nil || prev.As != AAUIPC
+}
+
+// cReg returns the number of the integer register r, and whether r
+// is an integer register.
+func cReg(r uint32) (uint32, bool) {
+	if r < REG_X0 || r > REG_X31 {
+		return 0, false
+	}
+	return r - REG_X0, true
+}
+
+// cFReg returns the number of the float register r, and whether r
+// is a float register.
+func cFReg(r uint32) (uint32, bool) {
+	if r < REG_F0 || r > REG_F31 {
+		return 0, false
+	}
+	return r - REG_F0, true
+}
+
+// cPrime returns the 3-bit encoding of the register numbered n,
+// and whether it has one. Only x8-x15 and f8-f15 do.
+func cPrime(n uint32, ok bool) (uint32, bool) {
+	if !ok || n < 8 || n > 15 {
+		return 0, false
+	}
+	return n - 8, true
+}
+
+// cBits returns bits hi through lo of v, shifted to bit position to.
+func cBits(v int64, hi, lo, to uint) uint16 {
+	return uint16((uint64(v)>>lo)&(1<<(hi-lo+1)-1)) << to
+}
+
+// cImmFits reports whether imm is a multiple of align in [0, max).
+func cImmFits(imm, align, max int64) bool {
+	return imm >= 0 && imm < max && imm%align == 0
+}
+
+// encodeCompressed returns the 16-bit encoding of ins from the C extension,
+// and whether ins has one.
+func encodeCompressed(ins *instruction) (uint16, bool) {
+	rd, rdOK := cReg(ins.rd)
+	rs1, rs1OK := cReg(ins.rs1)
+	rs2, rs2OK := cReg(ins.rs2)
+	rdP, rdPOK := cPrime(rd, rdOK)
+	rs1P, rs1POK := cPrime(rs1, rs1OK)
+	rs2P, rs2POK := cPrime(rs2, rs2OK)
+	imm := ins.imm
+	noRS2 := ins.rs2 == obj.REG_NONE
+
+	switch ins.as {
+	case AADDI:
+		if !rdOK || !rs1OK || !noRS2 || rd == 0 {
+			break
+		}
+		switch {
+		case rd == rs1 && rd == 2 && imm != 0 && imm%16 == 0 && immIFits(imm, 10):
+			// C.ADDI16SP
+			return 0x6101 | cBits(imm, 9, 9, 12) | cBits(imm, 4, 4, 6) | cBits(imm, 6, 6, 5) |
+				cBits(imm, 8, 7, 3) | cBits(imm, 5, 5, 2), true
+		case rd == rs1 && imm != 0 && immIFits(imm, 6):
+			// C.ADDI
+			return 0x0001 | cBits(imm, 5, 5, 12) | uint16(rd)<<7 | cBits(imm, 4, 0, 2), true
+		case rs1 == 0 && immIFits(imm, 6):
+			// C.LI
+			return 0x4001 | cBits(imm, 5, 5, 12) | uint16(rd)<<7 | cBits(imm, 4, 0, 2), true
+		case rs1 != 0 && imm == 0:
+			// C.MV
+			return 0x8002 | uint16(rd)<<7 | uint16(rs1)<<2, true
+		case rs1 == 2 && rdPOK && imm != 0 && cImmFits(imm, 4, 1024):
+			// C.ADDI4SPN
+			return 0x0000 | cBits(imm, 5, 4, 11) | cBits(imm, 9, 6, 7) | cBits(imm, 2, 2, 6) |
+				cBits(imm, 3, 3, 5) | uint16(rdP)<<2, true
+		}
+
+	case AADDIW:
+		if rdOK && rd != 0 && rd == rs1 && noRS2 && immIFits(imm, 6) {
+			// C.ADDIW
+			return 0x2001 | cBits(imm, 5, 5, 12) | uint16(rd)<<7 | cBits(imm, 4, 0, 2), true
+		}
+
+	case ALUI:
+		if rdOK && rd != 0 && rd != 2 && imm != 0 && immIFits(imm, 6) {
+			// C.LUI
+			return 0x6001 | cBits(imm, 5, 5, 12) | uint16(rd)<<7 | cBits(imm, 4, 0, 2), true
+		}
+
+	case AANDI:
+		if rdPOK && rd == rs1 && noRS2 && immIFits(imm, 6) {
+			// C.ANDI
+			return 0x8801 | cBits(imm, 5, 5, 12) | uint16(rdP)<<7 | cBits(imm, 4, 0, 2), true
+		}
+
+	case ASLLI:
+		if rdOK && rd != 0 && rd == rs1 && noRS2 && imm > 0 && imm < 64 {
+			// C.SLLI
+			return 0x0002 | cBits(imm, 5, 5, 12) | uint16(rd)<<7 | cBits(imm, 4, 0, 2), true
+		}
+
+	case ASRLI, ASRAI:
+		if rdPOK && rd == rs1 && noRS2 && imm > 0 && imm < 64 {
+			// C.SRLI, C.SRAI
+			enc := uint16(0x8001)
+			if ins.as == ASRAI {
+				enc = 0x8401
+			}
+			return enc | cBits(imm, 5, 5, 12) | uint16(rdP)<<7 | cBits(imm, 4, 0, 2), true
+		}
+
+	case AADD:
+		if !rdOK || rd == 0 || !rs1OK || !rs2OK {
+			break
+		}
+		switch {
+		case rd == rs1 && rs2 != 0:
+			// C.ADD
+			return 0x9002 | uint16(rd)<<7 | uint16(rs2)<<2, true
+		case rd == rs2 && rs1 != 0:
+			// C.ADD, with the operands swapped.
+			return 0x9002 | uint16(rd)<<7 | uint16(rs1)<<2, true
+		}
+
+	case ASUB, AXOR, AOR, AAND, ASUBW, AADDW:
+		if !rdPOK || !rs1OK || !rs2OK {
+			break
+		}
+		var enc uint16
+		switch ins.as {
+		case ASUB:
+			enc = 0x8c01
+		case AXOR:
+			enc = 0x8c21
+		case AOR:
+			enc = 0x8c41
+		case AAND:
+			enc = 0x8c61
+		case ASUBW:
+			enc = 0x9c01
+		case AADDW:
+			enc = 0x9c21
+		}
+		commutative := ins.as != ASUB && ins.as != ASUBW
+		switch {
+		case rd == rs1 && rs2POK:
+			// C.SUB, C.XOR, C.OR, C.AND, C.SUBW, C.ADDW
+			return enc | uint16(rdP)<<7 | uint16(rs2P)<<2, true
+		case commutative && rd == rs2 && rs1POK:
+			return enc | uint16(rdP)<<7 | uint16(rs1P)<<2, true
+		}
+
+	case ALW, ALD, AFLD:
+		if !rs1OK || !noRS2 {
+			break
+		}
+		var rdN uint32
+		var ok bool
+		if ins.as == AFLD {
+			rdN, ok = cFReg(ins.rd)
+		} else {
+			rdN, ok = rd, rdOK && rd != 0
+		}
+		if !ok {
+			break
+		}
+		rdNP, rdNPOK := cPrime(rdN, true)
+		switch ins.as {
+		case ALW:
+			switch {
+			case rs1 == 2 && cImmFits(imm, 4, 256):
+				// C.LWSP
+				return 0x4002 | cBits(imm, 5, 5, 12) | uint16(rdN)<<7 | cBits(imm, 4, 2, 4) | cBits(imm, 7, 6, 2), true
+			case rs1POK && rdNPOK && cImmFits(imm, 4, 128):
+				// C.LW
+				return 0x4000 | cBits(imm, 5, 3, 10) | uint16(rs1P)<<7 | cBits(imm, 2, 2, 6) | cBits(imm, 6, 6, 5) | uint16(rdNP)<<2, true
+			}
+		case ALD, AFLD:
+			enc := uint16(0x6000) // C.LD, C.LDSP
+			if ins.as == AFLD {
+				enc = 0x2000 // C.FLD, C.FLDSP
+			}
+			switch {
+			case rs1 == 2 && cImmFits(imm, 8, 512):
+				// C.LDSP, C.FLDSP
+				return enc | 0x2 | cBits(imm, 5, 5, 12) | uint16(rdN)<<7 | cBits(imm, 4, 3, 5) | cBits(imm, 8, 6, 2), true
+			case rs1POK && rdNPOK && cImmFits(imm, 8, 256):
+				// C.LD, C.FLD
+				return enc | cBits(imm, 5, 3, 10) | uint16(rs1P)<<7 | cBits(imm, 7, 6, 5) | uint16(rdNP)<<2, true
+			}
+		}
+
+	case ASW, ASD, AFSD:
+		// The base register is in rd and the value in rs1.
+		base, baseP, basePOK := rd, rdP, rdPOK
+		if !rdOK || !noRS2 {
+			break
+		}
+		var val uint32
+		var ok bool
+		if ins.as == AFSD {
+			val, ok = cFReg(ins.rs1)
+		} else {
+			val, ok = rs1, rs1OK
+		}
+		if !ok {
+			break
+		}
+		valP, valPOK := cPrime(val, true)
+		switch ins.as {
+		case ASW:
+			switch {
+			case base == 2 && cImmFits(imm, 4, 256):
+				// C.SWSP
+				return 0xc002 | cBits(imm, 5, 2, 9) | cBits(imm, 7, 6, 7) | uint16(val)<<2, true
+			case basePOK && valPOK && cImmFits(imm, 4, 128):
+				// C.SW
+				return 0xc000 | cBits(imm, 5, 3, 10) | uint16(baseP)<<7 | cBits(imm, 2, 2, 6) | cBits(imm, 6, 6, 5) | uint16(valP)<<2, true
+			}
+		case ASD, AFSD:
+			enc := uint16(0xe000) // C.SD, C.SDSP
+			if ins.as == AFSD {
+				enc = 0xa000 // C.FSD, C.FSDSP
+			}
+			switch {
+			case base == 2 && cImmFits(imm, 8, 512):
+				// C.SDSP, C.FSDSP
+				return enc | 0x2 | cBits(imm, 5, 3, 10) | cBits(imm, 8, 6, 7) | uint16(val)<<2, true
+			case basePOK && valPOK && cImmFits(imm, 8, 256):
+				// C.SD, C.FSD
+				return enc | cBits(imm, 5, 3, 10) | uint16(baseP)<<7 | cBits(imm, 7, 6, 5) | uint16(valP)<<2, true
+			}
+		}
+
+	case AJALR:
+		if !rdOK || !rs1OK || rs1 == 0 || imm != 0 {
+			break
+		}
+		switch rd {
+		case 0:
+			// C.JR
+			return 0x8002 | uint16(rs1)<<7, true
+		case 1:
+			// C.JALR
+			return 0x9002 | uint16(rs1)<<7, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riscv
+
+import "testing"
+
+func TestEncodeCompressed(t *testing.T) {
+	tests := []struct {
+		ins  instruction
+		want uint16 // 0 if ins has no compressed encoding
+	}{
+		{instruction{as: AADDI, rd: REG_SP, rs1: REG_SP, imm: -64}, 0x7139},
+		{instruction{as: AADDI, rd: REG_A0, rs1: REG_A0, imm: 1}, 0x0505},
+		{instruction{as: AADDI, rd: REG_A0, rs1: REG_ZERO, imm: -3}, 0x5575},
+		{instruction{as: AADDI, rd: REG_A0, rs1: REG_A1}, 0x852e},
+		{instruction{as: AADDI, rd: REG_A0, rs1: REG_SP, imm: 16}, 0x0808},
+		{instruction{as: AADDIW, rd: REG_A0, rs1: REG_A0, imm: -1}, 0x357d},
+		{instruction{as: ALUI, rd: REG_A0, imm: 4}, 0x6511},
+		{instruction{as: AANDI, rd: REG_S0, rs1: REG_S0, imm: 15}, 0x883d},
+		{instruction{as: ASLLI, rd: REG_A0, rs1: REG_A0, imm: 3}, 0x050e},
+		{instruction{as: ASRLI, rd: REG_S0, rs1: REG_S0, imm: 3}, 0x800d},
+		{instruction{as: ASRAI, rd: REG_A5, rs1: REG_A5, imm: 63}, 0x97fd},
+		{instruction{as: AADD, rd: REG_A0, rs1: REG_A0, rs2: REG_A1}, 0x952e},
+		{instruction{as: AADD, rd: REG_A0, rs1: REG_A1, rs2: REG_A0}, 0x952e},
+		{instruction{as: ASUB, rd: REG_S0, rs1: REG_S0, rs2: REG_S1}, 0x8c05},
+		{instruction{as: AXOR, rd: REG_S0, rs1: REG_S0, rs2: REG_A0}, 0x8c29},
+		{instruction{as: AOR, rd: REG_A0, rs1: REG_A1, rs2: REG_A0}, 0x8d4d},
+		{instruction{as: AAND, rd: REG_A2, rs1: REG_A2, rs2: REG_A3}, 0x8e75},
+		{instruction{as: ASUBW, rd: REG_A4, rs1: REG_A4, rs2: REG_A5}, 0x9f1d},
+		{instruction{as: AADDW, rd: REG_S1, rs1: REG_S1, rs2: REG_A0}, 0x9ca9},
+		{instruction{as: ALW, rd: REG_A0, rs1: REG_S0, imm: 8}, 0x4408},
+		{instruction{as: ALW, rd: REG_RA, rs1: REG_SP, imm: 12}, 0x40b2},
+		{instruction{as: ALD, rd: REG_A1, rs1: REG_A2, imm: 16}, 0x6a0c},
+		{instruction{as: ALD, rd: REG_RA, rs1: REG_SP, imm: 8}, 0x60a2},
+		{instruction{as: AFLD, rd: REG_FA0, rs1: REG_S1, imm: 24}, 0x2c88},
+		{instruction{as: AFLD, rd: REG_FT0, rs1: REG_SP, imm: 16}, 0x2042},
+		{instruction{as: ASW, rd: REG_S0, rs1: REG_A0, imm: 8}, 0xc408},
+		{instruction{as: ASW, rd: REG_SP, rs1: REG_RA, imm: 12}, 0xc606},
+		{instruction{as: ASD, rd: REG_A2, rs1: REG_A1, imm: 16}, 0xea0c},
+		{instruction{as: ASD, rd: REG_SP, rs1: REG_RA, imm: 8}, 0xe406},
+		{instruction{as: AFSD, rd: REG_S1, rs1: REG_FA0, imm: 24}, 0xac88},
+		{instruction{as: AFSD, rd: REG_SP, rs1: REG_FT0, imm: 16}, 0xa802},
+		{instruction{as: AJALR, rd: REG_ZERO, rs1: REG_T0}, 0x8282},
+		{instruction{as: AJALR, rd: REG_RA, rs1: REG_T0}, 0x9282},
+
+		{instruction{as: AADDI, rd: REG_ZERO, rs1: REG_ZERO}, 0},
+		{instruction{as: AADDI, rd: REG_A0, rs1: REG_A0, imm: 100}, 0},
+		{instruction{as: AADDI, rd: REG_A0, rs1: REG_A1, imm: 1}, 0},
+		{instruction{as: AADD, rd: REG_A0, rs1: REG_A1, rs2: REG_A2}, 0},
+		{instruction{as: ASUB, rd: REG_S0, rs1: REG_S1, rs2: REG_S0}, 0},
+		{instruction{as: AXOR, rd: REG_T0, rs1: REG_T0, rs2: REG_A0}, 0},
+		{instruction{as: ALUI, rd: REG_SP, imm: 4}, 0},
+		{instruction{as: ALUI, rd: REG_A0, imm: 0x1000}, 0},
+		{instruction{as: ASRLI, rd: REG_A0, rs1: REG_A0, imm: 0}, 0},
+		{instruction{as: ALD, rd: REG_A1, rs1: REG_A2, imm: 12}, 0},
+		{instruction{as: ALD, rd: REG_A1, rs1: REG_T0, imm: 16}, 0},
+		{instruction{as: ALD, rd: REG_ZERO, rs1: REG_SP, imm: 8}, 0},
+		{instruction{as: ASD, rd: REG_T0, rs1: REG_A1, imm: 16}, 0},
+		{instruction{as: AJALR, rd: REG_RA, rs1: REG_T0, imm: 4}, 0},
+		{instruction{as: AJALR, rd: REG_A0, rs1: REG_T0}, 0},
+		{instruction{as: AJAL, rd: REG_ZERO, imm: 8}, 0},
+		{instruction{as: ABEQ, rs1: REG_A0, rs2: REG_ZERO, imm: 8}, 0},
+	}
+	for _, test := range tests {
+		ins := test.ins
+		got, ok := encodeCompressed(&ins)
+		if ok != (test.want != 0) || got != test.want {
+			t.Errorf("encodeCompressed(%v %d, %d, %d, %d) = %#04x, %v, want %#04x", ins.as, ins.rd, ins.rs1, ins.rs2, ins.imm, got, ok, test.want)
+		}
+	}
+}
@@ -142,6 +142,7 @@ const (
 	REG_G    = REG_S11 // G pointer.
 	REG_CTXT = REG_S4  // Context for closures.
 	REG_LR   = REG_RA  // Link register.
+	REG_FP   = REG_S0  // Frame pointer.
 	REG_TMP  = REG_T6  // Reserved for assembler use.
 
 	// ABI names for floating point registers.
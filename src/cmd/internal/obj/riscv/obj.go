@@ -445,9 +445,16 @@ func containsCall(sym *obj.LSym) bool {
 // setPCs sets the Pc field in all instructions reachable from p.
 // It uses pc as the initial value.
 func setPCs(p *obj.Prog, pc int64) {
-	for ; p != nil; p = p.Link {
+	compress := shouldCompress(p.From.Sym)
+	var prev *obj.Prog
+	for ; p != nil; prev, p = p, p.Link {
 		p.Pc = pc
+		c := compress && compressible(p, prev)
 		for _, ins := range instructionsForProg(p) {
+			if _, ok := encodeCompressed(ins); c && ok {
+				pc += 2
+				continue
+			}
 			pc += int64(ins.length())
 		}
 	}
@@ -464,6 +471,8 @@ func setPCs(p *obj.Prog, pc int64) {
 // -------------------
 // |    Parent RA    |   SP on function entry
 // -------------------
+// |  Parent's FP    |   saved by the parent, whose FP points here
+// -------------------
 // |                 |
 // |                 |
 // |       AUTOs     |
@@ -474,17 +483,19 @@ func setPCs(p *obj.Prog, pc int64) {
 // -------------------
 //
 // FixedFrameSize makes other packages aware of the space allocated for RA.
+// A function saves its FP register below SP, in the top slot of the frame
+// of any function it calls.
 //
 // A nicer version of this diagram can be found on slide 21 of the presentation
 // attached to:
 //
 //   https://golang.org/issue/16922#issuecomment-243748180
 //
-func stackOffset(a *obj.Addr, stacksize int64) {
+func stackOffset(a *obj.Addr, stacksize, fpsize int64) {
 	switch a.Name {
 	case obj.NAME_AUTO:
-		// Adjust to the top of AUTOs.
-		a.Offset += stacksize
+		// Adjust to the top of AUTOs, below the saved frame pointer.
+		a.Offset += stacksize - fpsize
 	case obj.NAME_PARAM:
 		// Adjust to the bottom of PARAMs.
 		a.Offset += stacksize + 8
@@ -499,6 +510,20 @@ func stackOffset(a *obj.Addr, stacksize int64) {
 // When preprocess finishes, all instructions in the symbol are either
 // concrete, real RISC-V instructions or directive pseudo-ops like TEXT,
 // PCDATA, and FUNCDATA.
+// checkFrameWrites reports writes to the frame pointer in the frameless
+// assembly function cursym, which has no frame in which to save the
+// caller's frame pointer. The runtime manages the frame pointer itself.
+func checkFrameWrites(ctxt *obj.Link, cursym *obj.LSym) {
+	if !ctxt.IsAsm || objabi.IsRuntimePackagePath(ctxt.Pkgpath) || ctxt.Pkgpath == "runtime/cgo" {
+		return
+	}
+	for p := cursym.Func().Text; p != nil; p = p.Link {
+		if p.To.Type == obj.TYPE_REG && p.To.Reg == REG_FP {
+			ctxt.Diag("%v: frameless function writes frame pointer %s", p, RegName(REG_FP))
+		}
+	}
+}
+
 func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 	if cursym.Func().Text == nil || cursym.Func().Text.Link == nil {
 		return
@@ -535,13 +560,23 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 	}
 
 	// Save LR unless there is no frame.
+	var fpsize int64
 	if !text.From.Sym.NoFrame() {
 		stacksize += ctxt.FixedFrameSize()
+
+		// Allocate 8 bytes at the top of the frame for the frame
+		// pointer, which the caller saves just below its stack pointer.
+		fpsize = 8
+		stacksize += fpsize
 	}
 
 	cursym.Func().Args = text.To.Val.(int32)
 	cursym.Func().Locals = int32(stacksize)
 
+	if stacksize == 0 {
+		checkFrameWrites(ctxt, cursym)
+	}
+
 	prologue := text
 
 	if !cursym.Func().Text.From.Sym.NoSplit() {
@@ -566,6 +601,20 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 		prologue.Spadj = int32(stacksize)
 
 		prologue = ctxt.EndUnsafePoint(prologue, newprog, -1)
+
+		// Save the frame pointer just below SP and point FP at it,
+		// so that FP and FP+8 hold the caller's FP and our return
+		// address, as on x86.
+		prologue = obj.Appendp(prologue, newprog)
+		prologue.As = AMOV
+		prologue.From = obj.Addr{Type: obj.TYPE_REG, Reg: REG_FP}
+		prologue.To = obj.Addr{Type: obj.TYPE_MEM, Reg: REG_SP, Offset: -8}
+
+		prologue = obj.Appendp(prologue, newprog)
+		prologue.As = AADDI
+		prologue.From = obj.Addr{Type: obj.TYPE_CONST, Offset: -8}
+		prologue.Reg = REG_SP
+		prologue.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_FP}
 	}
 
 	if cursym.Func().Text.From.Sym.Wrapper() {
@@ -649,8 +698,8 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 
 	// Update stack-based offsets.
 	for p := cursym.Func().Text; p != nil; p = p.Link {
-		stackOffset(&p.From, stacksize)
-		stackOffset(&p.To, stacksize)
+		stackOffset(&p.From, stacksize, fpsize)
+		stackOffset(&p.To, stacksize, fpsize)
 	}
 
 	// Additional instruction rewriting.
@@ -690,6 +739,12 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 			retJMP := p.To.Sym
 
 			if stacksize != 0 {
+				// Restore FP.
+				p.As = AMOV
+				p.From = obj.Addr{Type: obj.TYPE_MEM, Reg: REG_SP, Offset: -8}
+				p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_FP}
+				p = obj.Appendp(p, newprog)
+
 				// Restore LR.
 				p.As = AMOV
 				p.From = obj.Addr{Type: obj.TYPE_MEM, Reg: REG_SP, Offset: 0}
@@ -1981,8 +2036,10 @@ func assemble(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 		ctxt.Retpoline = false // don't keep printing
 	}
 
-	var symcode []uint32
-	for p := cursym.Func().Text; p != nil; p = p.Link {
+	compress := shouldCompress(cursym)
+	var symcode []byte
+	var prev *obj.Prog
+	for p := cursym.Func().Text; p != nil; prev, p = p, p.Link {
 		switch p.As {
 		case AJALR:
 			if p.To.Sym != nil {
@@ -2030,19 +2087,26 @@ func assemble(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 			rel.Type = rt
 		}
 
+		c := compress && compressible(p, prev)
 		for _, ins := range instructionsForProg(p) {
+			if ic, ok := encodeCompressed(ins); c && ok {
+				var b [2]byte
+				ctxt.Arch.ByteOrder.PutUint16(b[:], ic)
+				symcode = append(symcode, b[:]...)
+				continue
+			}
 			ic, err := ins.encode()
 			if err == nil {
-				symcode = append(symcode, ic)
+				var b [4]byte
+				ctxt.Arch.ByteOrder.PutUint32(b[:], ic)
+				symcode = append(symcode, b[:]...)
 			}
 		}
 	}
-	cursym.Size = int64(4 * len(symcode))
+	cursym.Size = int64(len(symcode))
 
 	cursym.Grow(cursym.Size)
-	for p, i := cursym.P, 0; i < len(symcode); p, i = p[4:], i+1 {
-		ctxt.Arch.ByteOrder.PutUint32(p, symcode[i])
-	}
+	copy(cursym.P, symcode)
 
 	obj.MarkUnsafePoints(ctxt, cursym.Func().Text, newprog, isUnsafePoint, nil)
 }
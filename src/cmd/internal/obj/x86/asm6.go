@@ -2077,6 +2077,12 @@ func span6(ctxt *obj.Link, s *obj.LSym, newprog obj.ProgAlloc) {
 			p.To.Reg = 0
 			p.To.Offset = 0
 		}
+		if ctxt.RetThunk && p.As == obj.ARET && p.To.Type == obj.TYPE_NONE {
+			p.As = obj.AJMP
+			p.To.Type = obj.TYPE_BRANCH
+			p.To.Name = obj.NAME_EXTERN
+			p.To.Sym = ctxt.Lookup("runtime.retthunk")
+		}
 	}
 
 	var count int64 // rough count of number of instructions
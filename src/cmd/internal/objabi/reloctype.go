@@ -95,6 +95,13 @@ const (
 	// This is a marker relocation (0-sized), for the linker's reachabililty
 	// analysis.
 	R_USEIFACEMETHOD
+	// R_USENAMEDMETHOD marks that the function this relocation is applied
+	// to looks up a method by name through reflection, as in
+	// reflect.Type.MethodByName("M"). The target is a string symbol
+	// holding the method name.
+	// This is a marker relocation (0-sized), for the linker's reachabililty
+	// analysis.
+	R_USENAMEDMETHOD
 	// R_METHODOFF resolves to a 32-bit offset from the beginning of the section
 	// holding the data being relocated to the referenced symbol.
 	// It is a variant of R_ADDROFF used when linking from the uncommonType of a
@@ -211,6 +218,12 @@ const (
 	// relocated symbol rather than the symbol's address.
 	R_ADDRPOWER_TOCREL_DS
 
+	// R_ADDRPOWER_PCREL34 relocates a prefixed D-form or 8LS-form instruction
+	// (the prefix word followed by the instruction word) with R=1. It inserts
+	// the 34-bit displacement from the place being relocated to the address
+	// of the relocated symbol, split between the two words.
+	R_ADDRPOWER_PCREL34
+
 	// RISC-V.
 
 	// R_RISCV_PCREL_ITYPE resolves a 32-bit PC-relative address using an
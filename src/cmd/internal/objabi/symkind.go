@@ -74,6 +74,8 @@ const (
 	SABIALIAS
 	// Coverage instrumentation counter for libfuzzer.
 	SLIBFUZZER_EXTRA_COUNTER
+	// Coverage counter for -covermode.
+	SCOVERAGE_COUNTER
 	// Update cmd/link/internal/sym/AbiSymKindToSymKind for new SymKind values.
 
 )
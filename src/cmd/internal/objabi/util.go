@@ -21,17 +21,20 @@ func envOr(key, value string) string {
 var (
 	defaultGOROOT string // set by linker
 
-	GOROOT   = envOr("GOROOT", defaultGOROOT)
-	GOARCH   = envOr("GOARCH", defaultGOARCH)
-	GOOS     = envOr("GOOS", defaultGOOS)
-	GO386    = envOr("GO386", defaultGO386)
-	GOARM    = goarm()
-	GOMIPS   = gomips()
-	GOMIPS64 = gomips64()
-	GOPPC64  = goppc64()
-	GOWASM   = gowasm()
-	GO_LDSO  = defaultGO_LDSO
-	Version  = version
+	GOROOT    = envOr("GOROOT", defaultGOROOT)
+	GOARCH    = envOr("GOARCH", defaultGOARCH)
+	GOOS      = envOr("GOOS", defaultGOOS)
+	GO386     = envOr("GO386", defaultGO386)
+	GOAMD64   = goamd64()
+	GOARM     = goarm()
+	GOARM64   = goarm64()
+	GOMIPS    = gomips()
+	GOMIPS64  = gomips64()
+	GOPPC64   = goppc64()
+	GORISCV64 = goriscv64()
+	GOWASM    = gowasm()
+	GO_LDSO   = defaultGO_LDSO
+	Version   = version
 
 	// GOEXPERIMENT is a comma-separated list of enabled
 	// experiments. This is derived from the GOEXPERIMENT
@@ -45,6 +48,21 @@ const (
 	MachoRelocOffset = 2048 // reserve enough space for ELF relocations
 )
 
+func goamd64() int {
+	switch v := envOr("GOAMD64", defaultGOAMD64); v {
+	case "v1":
+		return 1
+	case "v2":
+		return 2
+	case "v3":
+		return 3
+	case "v4":
+		return 4
+	}
+	log.Fatalf("Invalid GOAMD64 value. Must be v1, v2, v3, or v4.")
+	panic("unreachable")
+}
+
 func goarm() int {
 	def := defaultGOARM
 	if GOOS == "android" && GOARCH == "arm" {
@@ -88,8 +106,68 @@ func goppc64() int {
 		return 8
 	case "power9":
 		return 9
+	case "power10":
+		return 10
+	}
+	log.Fatalf("Invalid GOPPC64 value. Must be power8, power9 or power10.")
+	panic("unreachable")
+}
+
+// Goarm64Features is the set of ARM64 architecture features
+// selected by GOARM64.
+type Goarm64Features struct {
+	Version string // architecture version, "v8.0" through "v8.9" or "v9.0" through "v9.5"
+	LSE     bool   // Large System Extensions atomic instructions
+}
+
+func (f Goarm64Features) String() string {
+	// LSE is mandatory from v8.1 on, so only mention it for v8.0.
+	if f.LSE && f.Version == "v8.0" {
+		return f.Version + ",lse"
+	}
+	return f.Version
+}
+
+// ParseGOARM64 parses a GOARM64 setting: an architecture version,
+// optionally followed by ",lse" to select the LSE atomic instructions
+// on v8.0, which have them from v8.1 on.
+func ParseGOARM64(v string) (Goarm64Features, error) {
+	var f Goarm64Features
+	version := v
+	if i := strings.Index(v, ","); i >= 0 {
+		if opt := v[i+1:]; opt != "lse" {
+			return f, fmt.Errorf("invalid GOARM64 option %q: must be lse", opt)
+		}
+		version = v[:i]
+		f.LSE = true
+	}
+	var major, minor int
+	if n, err := fmt.Sscanf(version, "v%d.%d", &major, &minor); n != 2 || err != nil ||
+		fmt.Sprintf("v%d.%d", major, minor) != version ||
+		!(major == 8 && minor <= 9 || major == 9 && minor <= 5) {
+		return f, fmt.Errorf("invalid GOARM64 version %q: must be v8.0 through v8.9 or v9.0 through v9.5", version)
+	}
+	f.Version = version
+	if major > 8 || minor >= 1 {
+		f.LSE = true
+	}
+	return f, nil
+}
+
+func goarm64() Goarm64Features {
+	f, err := ParseGOARM64(envOr("GOARM64", defaultGOARM64))
+	if err != nil {
+		log.Fatalf("Invalid GOARM64 value. %v", err)
+	}
+	return f
+}
+
+func goriscv64() string {
+	switch v := envOr("GORISCV64", defaultGORISCV64); v {
+	case "rv64g", "rv64gc":
+		return v
 	}
-	log.Fatalf("Invalid GOPPC64 value. Must be power8 or power9.")
+	log.Fatalf("Invalid GORISCV64 value. Must be rv64g or rv64gc.")
 	panic("unreachable")
 }
 
@@ -182,7 +260,8 @@ func init() {
 // platforms that support it.
 //
 // Note: must agree with runtime.framepointer_enabled.
-var FramePointerEnabled = GOARCH == "amd64" || GOARCH == "arm64"
+var FramePointerEnabled = GOARCH == "amd64" || GOARCH == "arm64" ||
+	GOARCH == "ppc64" || GOARCH == "ppc64le" || GOARCH == "riscv64"
 
 func addexp(s string) {
 	// We could do general integer parsing here, but there's no need yet.
@@ -244,6 +323,9 @@ type ExpFlags struct {
 	//
 	// Requires wrappers, reflect, defer.
 	RegabiArgs bool
+
+	// Dwarf5 enables DWARF version 5 debug info on ELF targets.
+	Dwarf5 bool
 }
 
 // Toolchain experiments.
@@ -262,6 +344,7 @@ var exper = []struct {
 	{"regabireflect", &Experiment.RegabiReflect},
 	{"regabidefer", &Experiment.RegabiDefer},
 	{"regabiargs", &Experiment.RegabiArgs},
+	{"dwarf5", &Experiment.Dwarf5},
 }
 
 var defaultExpstring string
@@ -0,0 +1,48 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package objabi
+
+import "testing"
+
+func TestParseGOARM64(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // canonical form, or "" if in is invalid
+		lse  bool
+	}{
+		{"v8.0", "v8.0", false},
+		{"v8.0,lse", "v8.0,lse", true},
+		{"v8.1", "v8.1", true},
+		{"v8.1,lse", "v8.1", true},
+		{"v8.9", "v8.9", true},
+		{"v9.0", "v9.0", true},
+		{"v9.5", "v9.5", true},
+		{"", "", false},
+		{"v8", "", false},
+		{"8.1", "", false},
+		{"v8.01", "", false},
+		{"v8.10", "", false},
+		{"v9.6", "", false},
+		{"v10.0", "", false},
+		{"v8.0,crypto", "", false},
+		{"v8.0,", "", false},
+	}
+	for _, tc := range tests {
+		f, err := ParseGOARM64(tc.in)
+		if tc.want == "" {
+			if err == nil {
+				t.Errorf("ParseGOARM64(%q) = %v, want error", tc.in, f)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseGOARM64(%q) failed: %v", tc.in, err)
+			continue
+		}
+		if f.String() != tc.want || f.LSE != tc.lse {
+			t.Errorf("ParseGOARM64(%q) = %v with LSE %v, want %v with LSE %v", tc.in, f, f.LSE, tc.want, tc.lse)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package objabi
+
+// Functions marked //go:wasmexport are described to the linker by the
+// pkg..wasmexportinfo symbol of their package, which holds for each of
+// them, in order:
+//
+//	uvarint length of the export name, followed by the name
+//	uvarint offset of its runtime.wasmExport in pkg..wasmexports
+//	uvarint number of parameters, followed by a kind and
+//		a uvarint frame offset for each parameter
+//	uvarint number of results, followed by a kind and
+//		a uvarint frame offset for each result
+//
+// The kinds say how a value is passed in WebAssembly and how it is
+// stored in the Go argument frame.
+const (
+	WasmExportI32       = 1 + iota // i32, as a 4-byte int32 or uint32
+	WasmExportI64                  // i64, as an 8-byte integer
+	WasmExportF32                  // f32, as a float32
+	WasmExportF64                  // f64, as a float64
+	WasmExportBool                 // i32, as a 1-byte bool
+	WasmExportPtr                  // i32, as an 8-byte uintptr or unsafe.Pointer
+	WasmExportExternref            // externref, as the 4-byte index of its table slot
+)
@@ -16,6 +16,7 @@ const (
 	ARM
 	ARM64
 	I386
+	Loong64
 	MIPS
 	MIPS64
 	PPC64
@@ -89,6 +90,15 @@ var ArchARM64 = &Arch{
 	MinLC:     4,
 }
 
+var ArchLoong64 = &Arch{
+	Name:      "loong64",
+	Family:    Loong64,
+	ByteOrder: binary.LittleEndian,
+	PtrSize:   8,
+	RegSize:   8,
+	MinLC:     4,
+}
+
 var ArchMIPS = &Arch{
 	Name:      "mips",
 	Family:    MIPS,
@@ -149,7 +159,7 @@ var ArchRISCV64 = &Arch{
 	ByteOrder: binary.LittleEndian,
 	PtrSize:   8,
 	RegSize:   8,
-	MinLC:     4,
+	MinLC:     2, // compressed instructions
 }
 
 var ArchS390X = &Arch{
@@ -175,6 +185,7 @@ var Archs = [...]*Arch{
 	ArchAMD64,
 	ArchARM,
 	ArchARM64,
+	ArchLoong64,
 	ArchMIPS,
 	ArchMIPSLE,
 	ArchMIPS64,
@@ -45,14 +45,27 @@ Flags:
 		Note that before Go 1.5 this option took two separate arguments.
 	-a
 		Disassemble output.
+	-asan
+		Link with C/C++ address sanitizer support.
 	-buildid id
 		Record id as Go toolchain build id.
 	-buildmode mode
 		Set build mode (default exe).
 	-c
 		Dump call graphs.
-	-compressdwarf
-		Compress DWARF if possible (default true).
+	-callgraphorder
+		Order the functions in the text section so that each function
+		is placed next to the functions that call it most often, using
+		the call frequencies estimated by the compiler.
+	-callgraphprofile file
+		Like -callgraphorder, but also use the calls recorded in the
+		pprof profile file, such as a CPU profile of the program,
+		which take precedence over the compiler's estimates.
+	-compressdwarf=method
+		Compress DWARF if possible, using method zlib or zstd
+		(default zlib; false disables compression). With zstd,
+		ELF sections are marked SHF_COMPRESSED and keep their
+		names, which recent debuggers and binutils understand.
 	-cpuprofile file
 		Write CPU profile to file.
 	-d
@@ -75,8 +88,22 @@ Flags:
 		Set space-separated flags to pass to the external linker.
 	-f
 		Ignore version mismatch in the linked archives.
+	-fieldtrack file
+		Write the struct fields tagged go:"track" in packages compiled with
+		-gcflags=-fieldtrack to file as JSON, one object per line. Each
+		object gives the field, whether reachable code uses it, the
+		functions that use it and the chain of symbols through which the
+		field was reached. Fields that no reachable code uses are reported
+		too, so that they can be found and deleted.
 	-g
 		Disable Go package data checks.
+	-icf
+		Fold Go functions with identical machine code, relocations and
+		stack metadata into a single copy, which is useful for programs
+		with much generated code. The folded functions keep their own
+		symbols, at the address of the copy, but appear in tracebacks
+		and runtime.FuncForPC under the name of the function that was
+		kept. Only supported with internal linking.
 	-importcfg file
 		Read import configuration from file.
 		In the file, set packagefile, packageshlib to specify import resolution.
@@ -84,7 +111,8 @@ Flags:
 		Look for packages in $GOROOT/pkg/$GOOS_$GOARCH_suffix
 		instead of $GOROOT/pkg/$GOOS_$GOARCH.
 	-k symbol
-		Set field tracking symbol. Use this flag when GOEXPERIMENT=fieldtrack is set.
+		Set field tracking symbol. Use this flag when GOEXPERIMENT=fieldtrack is set
+		or when packages are compiled with -gcflags=-fieldtrack.
 	-libgcc file
 		Set name of compiler support library.
 		This is only used in internal link mode.
@@ -108,6 +136,13 @@ Flags:
 		Write output to file (default a.out, or a.out.exe on Windows).
 	-pluginpath path
 		The path name used to prefix exported plugin symbols.
+	-prunemethods
+		Remove the exported methods that are not otherwise used even
+		if the program looks up methods through reflection, as long as
+		it only does so with reflect.Type.MethodByName or
+		reflect.Value.MethodByName and a constant method name. Only the
+		methods with these names are kept. Lookups by index or by a
+		name computed at run time still keep all exported methods.
 	-r dir1:dir2:...
 		Set the ELF dynamic linker search path.
 	-race
@@ -116,6 +151,10 @@ Flags:
 		Omit the symbol table and debug information.
 	-shared
 		Generated shared object (implies -linkmode external; experimental).
+	-sharestrings
+		Store the data of each Go string that is a suffix of another
+		string in the program inside the data of the other string.
+		Identical strings are always stored once.
 	-tmpdir dir
 		Write temporary files to dir.
 		Temporary files are only used in external linking mode.
@@ -125,5 +164,9 @@ Flags:
 		Print trace of linker operations.
 	-w
 		Omit the DWARF symbol table.
+	-why regexp
+		For each reachable symbol whose name matches regexp, print the
+		chain of references from an entry point that kept it in the
+		binary, as found during dead code elimination.
 */
 package main
@@ -174,6 +174,45 @@ func (mode *LinkMode) String() string {
 	return fmt.Sprintf("LinkMode(%d)", uint8(*mode))
 }
 
+// DWARFCompression is the compression applied to DWARF sections.
+type DWARFCompression uint8
+
+const (
+	DWARFCompressNone DWARFCompression = iota
+	DWARFCompressZlib
+	DWARFCompressZstd
+)
+
+func (c *DWARFCompression) Set(s string) error {
+	switch s {
+	default:
+		return fmt.Errorf("invalid compressdwarf: %q", s)
+	case "false", "none":
+		*c = DWARFCompressNone
+	case "true", "zlib":
+		*c = DWARFCompressZlib
+	case "zstd":
+		*c = DWARFCompressZstd
+	}
+	return nil
+}
+
+func (c *DWARFCompression) String() string {
+	switch *c {
+	case DWARFCompressNone:
+		return "false"
+	case DWARFCompressZlib:
+		return "zlib"
+	case DWARFCompressZstd:
+		return "zstd"
+	}
+	return fmt.Sprintf("DWARFCompression(%d)", uint8(*c))
+}
+
+// IsBoolFlag allows -compressdwarf to be given without a value,
+// which selects zlib.
+func (c *DWARFCompression) IsBoolFlag() bool { return true }
+
 // mustLinkExternal reports whether the program being linked requires
 // the external linker be used to complete the link.
 func mustLinkExternal(ctxt *Link) (res bool, reason string) {
@@ -193,6 +232,10 @@ func mustLinkExternal(ctxt *Link) (res bool, reason string) {
 		return true, "msan"
 	}
 
+	if *flagAsan {
+		return true, "asan"
+	}
+
 	// Internally linking cgo is incomplete on some architectures.
 	// https://golang.org/issue/14449
 	// https://golang.org/issue/21961
@@ -33,14 +33,19 @@ package ld
 
 import (
 	"bytes"
+	"cmd/internal/dwarf"
 	"cmd/internal/gcprog"
 	"cmd/internal/objabi"
 	"cmd/internal/sys"
 	"cmd/link/internal/loader"
 	"cmd/link/internal/sym"
 	"compress/zlib"
+	"debug/elf"
 	"encoding/binary"
+	"flag"
 	"fmt"
+	"internal/zstd"
+	"io"
 	"log"
 	"os"
 	"sort"
@@ -1447,6 +1452,9 @@ func (ctxt *Link) dodata(symGroupType []sym.SymKind) {
 		ldr.SetAttrOnList(s, true)
 	}
 
+	// Leave out the string data stored inside other string data.
+	state.shareStringSuffixes()
+
 	// Now that we have the data symbols, but before we start
 	// to assign addresses, record all the necessary
 	// dynamic relocations. These will grow the relocation
@@ -1759,6 +1767,11 @@ func (state *dodataState) allocateDataSections(ctxt *Link) {
 		state.allocateNamedSectionAndAssignSyms(&Segdata, "__libfuzzer_extra_counters", sym.SLIBFUZZER_EXTRA_COUNTER, sym.Sxxx, 06)
 	}
 
+	// Coverage counters for -covermode.
+	if len(state.data[sym.SCOVERAGE_COUNTER]) > 0 {
+		state.allocateNamedSectionAndAssignSyms(&Segdata, ".go.covctrs", sym.SCOVERAGE_COUNTER, sym.Sxxx, 06)
+	}
+
 	if len(state.data[sym.STLSBSS]) > 0 {
 		var sect *sym.Section
 		// FIXME: not clear why it is sometimes necessary to suppress .tbss section creation.
@@ -2152,13 +2165,16 @@ func (ctxt *Link) buildinfo() {
 	// The \xff is invalid UTF-8, meant to make it less likely
 	// to find one of these accidentally.
 	const prefix = "\xff Go buildinf:" // 14 bytes, plus 2 data bytes filled in below
-	data := make([]byte, 32)
+	data := make([]byte, 48)
 	copy(data, prefix)
 	data[len(prefix)] = byte(ctxt.Arch.PtrSize)
 	data[len(prefix)+1] = 0
 	if ctxt.Arch.ByteOrder == binary.BigEndian {
 		data[len(prefix)+1] = 1
 	}
+	// Bit 1 of the flags byte says that a third pointer,
+	// to the build flags, follows the version and module info.
+	data[len(prefix)+1] |= 2
 	s.SetData(data)
 	s.SetSize(int64(len(data)))
 	r, _ := s.AddRel(objabi.R_ADDR)
@@ -2169,6 +2185,76 @@ func (ctxt *Link) buildinfo() {
 	r.SetOff(16 + int32(ctxt.Arch.PtrSize))
 	r.SetSiz(uint8(ctxt.Arch.PtrSize))
 	r.SetSym(ldr.LookupOrCreateSym("runtime.modinfo", 0))
+
+	flags := ldr.CreateSymForUpdate("go.buildflags", 0)
+	flags.SetLocal(true)
+	flags.SetType(sym.SRODATA)
+	addgostring(ctxt, ldr, flags, "go.buildflags.str", ctxt.buildflags())
+	r, _ = s.AddRel(objabi.R_ADDR)
+	r.SetOff(16 + 2*int32(ctxt.Arch.PtrSize))
+	r.SetSiz(uint8(ctxt.Arch.PtrSize))
+	r.SetSym(flags.Sym())
+}
+
+// buildflags returns a description of the flags that affected the
+// compilation of the linked packages and the link itself, for
+// inclusion in the build information. Each line has the form
+//
+//	compile\t<flags>[\t<packages>]
+//	link\t<flags>
+//
+// where the package list is omitted if every package was compiled
+// with the same flags. Packages compiled with default flags and a
+// link with default flags are not described.
+func (ctxt *Link) buildflags() string {
+	ldr := ctxt.loader
+	var (
+		variants []string                // distinct compiler flag sets, in link order
+		pkgs     = map[string][]string{} // flag set -> packages
+	)
+	for _, lib := range ctxt.Library {
+		var flags string
+		if s := ldr.Lookup(dwarf.CUInfoPrefix+"producer."+lib.Pkg, 0); s != 0 {
+			flags = string(ldr.Data(s))
+		}
+		if _, ok := pkgs[flags]; !ok {
+			variants = append(variants, flags)
+		}
+		pkgs[flags] = append(pkgs[flags], lib.Pkg)
+	}
+
+	var buf bytes.Buffer
+	for _, flags := range variants {
+		if flags == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, "compile\t%s", flags)
+		if len(variants) > 1 {
+			fmt.Fprintf(&buf, "\t%s", strings.Join(pkgs[flags], " "))
+		}
+		buf.WriteByte('\n')
+	}
+
+	// Only record link flags that were given explicitly and that
+	// affect the result; flags like -o or -buildid would cause
+	// spurious differences between binaries.
+	var link []string
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "race", "msan", "asan", "buildmode", "linkmode", "linkshared", "s", "w":
+		default:
+			return
+		}
+		if v := f.Value.String(); v == "true" {
+			link = append(link, "-"+f.Name)
+		} else {
+			link = append(link, "-"+f.Name+"="+v)
+		}
+	})
+	if len(link) > 0 {
+		fmt.Fprintf(&buf, "link\t%s\n", strings.Join(link, " "))
+	}
+	return buf.String()
 }
 
 // assign addresses to text
@@ -2335,6 +2421,10 @@ func assignAddress(ctxt *Link, sect *sym.Section, n int, s loader.Sym, va uint64
 	for sub := s; sub != 0; sub = ldr.SubSym(sub) {
 		ldr.SetSymValue(sub, ldr.SymValue(sub)+int64(va))
 	}
+	for _, f := range ctxt.foldedFuncs[s] {
+		ldr.SetSymSect(f, ldr.SymSect(s))
+		ldr.SetSymValue(f, ldr.SymValue(s))
+	}
 
 	va += funcsize
 
@@ -2483,6 +2573,7 @@ func (ctxt *Link) address() []*sym.Segment {
 			ldr.AddToSymValue(sub, v)
 		}
 	}
+	ctxt.addressSharedStrings()
 
 	for _, si := range dwarfp {
 		for _, s := range si.syms {
@@ -2626,6 +2717,11 @@ func (ctxt *Link) AddTramp(s *loader.SymbolBuilder) {
 
 // compressSyms compresses syms and returns the contents of the
 // compressed section. If the section would get larger, it returns nil.
+//
+// With zstd on ELF the contents start with a standard compression
+// header, for a section marked SHF_COMPRESSED. Otherwise they start
+// with "ZLIB" (or "ZSTD") and the big-endian uncompressed size, the
+// format of .zdebug sections.
 func compressSyms(ctxt *Link, syms []loader.Sym) []byte {
 	ldr := ctxt.loader
 	var total int64
@@ -2634,21 +2730,49 @@ func compressSyms(ctxt *Link, syms []loader.Sym) []byte {
 	}
 
 	var buf bytes.Buffer
-	buf.Write([]byte("ZLIB"))
-	var sizeBytes [8]byte
-	binary.BigEndian.PutUint64(sizeBytes[:], uint64(total))
-	buf.Write(sizeBytes[:])
+	if ctxt.compressDWARF == DWARFCompressZstd && ctxt.IsELF {
+		bo := ctxt.Arch.ByteOrder
+		if elf64 {
+			var hdr [24]byte
+			bo.PutUint32(hdr[0:], uint32(elf.COMPRESS_ZSTD))
+			bo.PutUint64(hdr[8:], uint64(total))
+			bo.PutUint64(hdr[16:], 1)
+			buf.Write(hdr[:])
+		} else {
+			var hdr [12]byte
+			bo.PutUint32(hdr[0:], uint32(elf.COMPRESS_ZSTD))
+			bo.PutUint32(hdr[4:], uint32(total))
+			bo.PutUint32(hdr[8:], 1)
+			buf.Write(hdr[:])
+		}
+	} else {
+		if ctxt.compressDWARF == DWARFCompressZstd {
+			buf.Write([]byte("ZSTD"))
+		} else {
+			buf.Write([]byte("ZLIB"))
+		}
+		var sizeBytes [8]byte
+		binary.BigEndian.PutUint64(sizeBytes[:], uint64(total))
+		buf.Write(sizeBytes[:])
+	}
+
+	var z io.WriteCloser
+	if ctxt.compressDWARF == DWARFCompressZstd {
+		z = zstd.NewWriter(&buf)
+	} else {
+		// Using zlib.BestSpeed achieves very nearly the same
+		// compression levels of zlib.DefaultCompression, but takes
+		// substantially less time. This is important because DWARF
+		// compression can be a significant fraction of link time.
+		zw, err := zlib.NewWriterLevel(&buf, zlib.BestSpeed)
+		if err != nil {
+			log.Fatalf("NewWriterLevel failed: %s", err)
+		}
+		z = zw
+	}
 
 	var relocbuf []byte // temporary buffer for applying relocations
 
-	// Using zlib.BestSpeed achieves very nearly the same
-	// compression levels of zlib.DefaultCompression, but takes
-	// substantially less time. This is important because DWARF
-	// compression can be a significant fraction of link time.
-	z, err := zlib.NewWriterLevel(&buf, zlib.BestSpeed)
-	if err != nil {
-		log.Fatalf("NewWriterLevel failed: %s", err)
-	}
 	st := ctxt.makeRelocSymState()
 	for _, s := range syms {
 		// Symbol data may be read-only. Apply relocations in a
@@ -11,6 +11,7 @@ import (
 	"cmd/link/internal/loader"
 	"cmd/link/internal/sym"
 	"fmt"
+	"regexp"
 	"unicode"
 )
 
@@ -26,16 +27,27 @@ type deadcodePass struct {
 	reflectSeen     bool               // whether we have seen a reflect method call
 	dynlink         bool
 
+	// With -prunemethods, the names of the methods looked up by
+	// constant name through reflection, and the symbols of
+	// reflect.Value.Method and MethodByName.
+	namedMethods map[string]bool
+	valueMethods [2]loader.Sym
+
 	methodsigstmp []methodsig // scratch buffer for decoding method signatures
 }
 
 func (d *deadcodePass) init() {
 	d.ldr.InitReachable()
 	d.ifaceMethod = make(map[methodsig]bool)
-	if objabi.Experiment.FieldTrack {
+	if fieldTracking() || *flagWhy != "" {
 		d.ldr.Reachparent = make([]loader.Sym, d.ldr.NSym())
 	}
 	d.dynlink = d.ctxt.DynlinkingGo()
+	if *flagPruneMethods {
+		d.namedMethods = make(map[string]bool)
+		d.valueMethods[0] = d.ldr.Lookup("reflect.Value.Method", sym.SymVerABIInternal)
+		d.valueMethods[1] = d.ldr.Lookup("reflect.Value.MethodByName", sym.SymVerABIInternal)
+	}
 
 	if d.ctxt.BuildMode == BuildModeShared {
 		// Mark all symbols defined in this library as reachable when
@@ -88,6 +100,24 @@ func (d *deadcodePass) init() {
 		}
 	}
 
+	// The coverage metadata of packages built with -covermode is
+	// referenced only by the moduledata (see symtab).
+	for _, lib := range d.ctxt.Library {
+		if name := covmetaName(lib); d.ldr.Lookup(name, 0) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	// The functions marked //go:wasmexport are called only through the
+	// exports that the wasm linker generates from their descriptions.
+	if d.ctxt.IsWasm() {
+		for _, lib := range d.ctxt.Library {
+			if name := objabi.PathToPrefix(lib.Pkg) + "..wasmexports"; d.ldr.Lookup(name, 0) != 0 {
+				names = append(names, name)
+			}
+		}
+	}
+
 	dynexpMap := d.ctxt.cgo_export_dynamic
 	if d.ctxt.LinkMode == LinkExternal {
 		dynexpMap = d.ctxt.cgo_export_static
@@ -113,11 +143,15 @@ func (d *deadcodePass) flood() {
 	for !d.wq.empty() {
 		symIdx := d.wq.pop()
 
-		d.reflectSeen = d.reflectSeen || d.ldr.IsReflectMethod(symIdx)
+		// With -prunemethods, the reflect.Value methods that look up
+		// methods are accounted for by their callers instead.
+		isValueMethod := d.isValueMethod(symIdx)
+		d.reflectSeen = d.reflectSeen || (d.ldr.IsReflectMethod(symIdx) && !isValueMethod)
 
 		isgotype := d.ldr.IsGoType(symIdx)
 		relocs := d.ldr.Relocs(symIdx)
 		var usedInIface bool
+		var refersValueMethod, usesNamedMethod bool
 
 		if isgotype {
 			if d.dynlink {
@@ -192,8 +226,19 @@ func (d *deadcodePass) flood() {
 				}
 				d.ifaceMethod[m] = true
 				continue
+			case objabi.R_USENAMEDMETHOD:
+				// R_USENAMEDMETHOD is a marker relocation that marks a
+				// method looked up by name through reflection.
+				if d.namedMethods == nil {
+					d.reflectSeen = true
+				} else {
+					d.namedMethods[string(d.ldr.Data(r.Sym()))] = true
+				}
+				usesNamedMethod = true
+				continue
 			}
 			rs := r.Sym()
+			refersValueMethod = refersValueMethod || d.isValueMethod(rs)
 			if isgotype && usedInIface && d.ldr.IsGoType(rs) && !d.ldr.AttrUsedInIface(rs) {
 				// If a type is converted to an interface, it is possible to obtain an
 				// interface with a "child" type of it using reflection (e.g. obtain an
@@ -221,6 +266,14 @@ func (d *deadcodePass) flood() {
 			}
 			d.mark(a.Sym(), symIdx)
 		}
+		if refersValueMethod && !isValueMethod && !usesNamedMethod && !d.ldr.IsReflectMethod(symIdx) {
+			// A use of reflect.Value.Method or MethodByName that the
+			// compiler did not mark, such as a method value. The DWARF
+			// of these functions refers to them too.
+			if t := d.ldr.SymType(symIdx); t < sym.SDWARFSECT || t > sym.SDWARFLINES {
+				d.reflectSeen = true
+			}
+		}
 		// Some host object symbols have an outer object, which acts like a
 		// "carrier" symbol, or it holds all the symbols for a particular
 		// section. We need to mark all "referenced" symbols from that carrier,
@@ -258,7 +311,7 @@ func (d *deadcodePass) mark(symIdx, parent loader.Sym) {
 	if symIdx != 0 && !d.ldr.AttrReachable(symIdx) {
 		d.wq.push(symIdx)
 		d.ldr.SetAttrReachable(symIdx, true)
-		if objabi.Experiment.FieldTrack && d.ldr.Reachparent[symIdx] == 0 {
+		if d.ldr.Reachparent != nil && d.ldr.Reachparent[symIdx] == 0 {
 			d.ldr.Reachparent[symIdx] = parent
 		}
 		if *flagDumpDep {
@@ -282,11 +335,21 @@ func (d *deadcodePass) mark(symIdx, parent loader.Sym) {
 
 func (d *deadcodePass) markMethod(m methodref) {
 	relocs := d.ldr.Relocs(m.src)
+	if d.isValueMethod(relocs.At(m.r+1).Sym()) || d.isValueMethod(relocs.At(m.r+2).Sym()) {
+		// Called through an interface, with any method name.
+		d.reflectSeen = true
+	}
 	d.mark(relocs.At(m.r).Sym(), m.src)
 	d.mark(relocs.At(m.r+1).Sym(), m.src)
 	d.mark(relocs.At(m.r+2).Sym(), m.src)
 }
 
+// isValueMethod reports whether s is reflect.Value.Method or
+// reflect.Value.MethodByName, with -prunemethods.
+func (d *deadcodePass) isValueMethod(s loader.Sym) bool {
+	return s != 0 && (s == d.valueMethods[0] || s == d.valueMethods[1])
+}
+
 // deadcode marks all reachable symbols.
 //
 // The basis of the dead code elimination is a flood fill of symbols,
@@ -319,9 +382,26 @@ func (d *deadcodePass) markMethod(m methodref) {
 // If any of these happen, all bets are off and all exported methods
 // of reachable types are marked reachable.
 //
+// With -prunemethods, the third case is refined using the marks the
+// compiler leaves on the callers of these methods: a call of
+// MethodByName with a constant argument (an R_USENAMEDMETHOD
+// relocation) only marks the exported methods with that name
+// reachable. Calls of Method, calls of MethodByName with other
+// arguments (the REFLECTMETHOD attribute), and uses of
+// reflect.Value.Method or MethodByName that are not calls still mark
+// all exported methods reachable.
+//
 // Any unreached text symbols are removed from ctxt.Textp.
 func deadcode(ctxt *Link) {
 	ldr := ctxt.loader
+	var why *regexp.Regexp
+	if *flagWhy != "" {
+		var err error
+		why, err = regexp.Compile(*flagWhy)
+		if err != nil {
+			Exitf("invalid -why regexp: %v", err)
+		}
+	}
 	d := deadcodePass{ctxt: ctxt, ldr: ldr}
 	d.init()
 	d.flood()
@@ -339,7 +419,9 @@ func deadcode(ctxt *Link) {
 		// Methods might be called via reflection. Give up on
 		// static analysis, mark all exported methods of
 		// all reachable types as reachable.
-		d.reflectSeen = d.reflectSeen || (methSym != 0 && ldr.AttrReachable(methSym)) || (methByNameSym != 0 && ldr.AttrReachable(methByNameSym))
+		if d.namedMethods == nil {
+			d.reflectSeen = d.reflectSeen || (methSym != 0 && ldr.AttrReachable(methSym)) || (methByNameSym != 0 && ldr.AttrReachable(methByNameSym))
+		}
 
 		// Mark all methods that could satisfy a discovered
 		// interface as reachable. We recheck old marked interfaces
@@ -347,7 +429,7 @@ func deadcode(ctxt *Link) {
 		// in the last pass.
 		rem := d.markableMethods[:0]
 		for _, m := range d.markableMethods {
-			if (d.reflectSeen && m.isExported()) || d.ifaceMethod[m.m] {
+			if (m.isExported() && (d.reflectSeen || d.namedMethods[m.m.name])) || d.ifaceMethod[m.m] {
 				d.markMethod(m)
 			} else {
 				rem = append(rem, m)
@@ -361,6 +443,37 @@ func deadcode(ctxt *Link) {
 		}
 		d.flood()
 	}
+
+	if why != nil {
+		d.printWhy(why)
+		if !fieldTracking() {
+			ldr.Reachparent = nil // we are done with it
+		}
+	}
+}
+
+// printWhy prints, for each reachable symbol whose name matches re,
+// the chain of symbols through which it was reached, starting at the
+// entry point or other root that was marked first.
+func (d *deadcodePass) printWhy(re *regexp.Regexp) {
+	var chain []loader.Sym
+	for s := loader.Sym(1); s < loader.Sym(d.ldr.NSym()); s++ {
+		if !d.ldr.AttrReachable(s) || !re.MatchString(d.ldr.SymName(s)) {
+			continue
+		}
+		chain = chain[:0]
+		for p := s; p != 0; p = d.ldr.Reachparent[p] {
+			chain = append(chain, p)
+		}
+		fmt.Printf("%s is reachable:\n", d.ldr.SymName(s))
+		for i := len(chain) - 1; i >= 0; i-- {
+			name := d.ldr.SymName(chain[i])
+			if d.ldr.AttrUsedInIface(chain[i]) {
+				name += " <UsedInIface>"
+			}
+			fmt.Printf("\t%s\n", name)
+		}
+	}
 }
 
 // methodsig is a typed method signature (name + type).
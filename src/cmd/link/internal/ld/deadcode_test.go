@@ -20,22 +20,28 @@ func TestDeadcode(t *testing.T) {
 
 	tests := []struct {
 		src      string
+		flags    string // additional linker flags
 		pos, neg string // positive and negative patterns
 	}{
-		{"reflectcall", "", "main.T.M"},
-		{"typedesc", "", "type.main.T"},
-		{"ifacemethod", "", "main.T.M"},
-		{"ifacemethod2", "main.T.M", ""},
-		{"ifacemethod3", "main.S.M", ""},
-		{"ifacemethod4", "", "main.T.M"},
+		{"reflectcall", "", "", "main.T.M"},
+		{"typedesc", "", "", "type.main.T"},
+		{"ifacemethod", "", "", "main.T.M"},
+		{"ifacemethod2", "", "main.T.M", ""},
+		{"ifacemethod3", "", "main.S.M", ""},
+		{"ifacemethod4", "", "", "main.T.M"},
+		{"methodbyname", "", "main.T.Bar", ""},
+		{"methodbyname", "-prunemethods", "main.T.Foo", "main.T.Bar"},
+		{"methodbyname2", "", "main.T.Bar", ""},
+		{"methodbyname2", "-prunemethods", "main.T.Foo", "main.T.Bar"},
+		{"methodbyname3", "-prunemethods", "main.T.Bar", ""},
 	}
 	for _, test := range tests {
 		test := test
-		t.Run(test.src, func(t *testing.T) {
+		t.Run(test.src+test.flags, func(t *testing.T) {
 			t.Parallel()
 			src := filepath.Join("testdata", "deadcode", test.src+".go")
-			exe := filepath.Join(tmpdir, test.src+".exe")
-			cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags=-dumpdep", "-o", exe, src)
+			exe := filepath.Join(tmpdir, test.src+test.flags+".exe")
+			cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags=-dumpdep "+test.flags, "-o", exe, src)
 			out, err := cmd.CombinedOutput()
 			if err != nil {
 				t.Fatalf("%v: %v:\n%s", cmd.Args, err, out)
@@ -49,3 +55,27 @@ func TestDeadcode(t *testing.T) {
 		})
 	}
 }
+
+func TestDeadcodeWhy(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	exe := filepath.Join(t.TempDir(), "why.exe")
+	src := filepath.Join("testdata", "deadcode", "why.go")
+	cmd := exec.Command(testenv.GoToolPath(t), "build", `-ldflags=-why=^main\.g$`, "-o", exe, src)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v: %v:\n%s", cmd.Args, err, out)
+	}
+	want := "main.g is reachable:\n"
+	if !bytes.Contains(out, []byte(want)) {
+		t.Errorf("output does not contain %q:\n%s", want, out)
+	}
+	want = "\tmain.main\n\tmain.f\n\tmain.g\n"
+	if !bytes.Contains(out, []byte(want)) {
+		t.Errorf("output does not contain chain %q:\n%s", want, out)
+	}
+	if bytes.Contains(out, []byte("main.f is reachable")) {
+		t.Errorf("output reports symbol not matching -why:\n%s", out)
+	}
+}
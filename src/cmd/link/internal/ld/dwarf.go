@@ -64,6 +64,21 @@ type dwctxt struct {
 	// Used at various points in that parallel portion of DWARF gen to
 	// protect against conflicting updates to globals (such as "gdbscript")
 	dwmu *sync.Mutex
+
+	// The string and address tables of the compilation unit being
+	// written, when generating DWARF 5.
+	tabs *dwUnitTables
+}
+
+// dwUnitTables holds the string and address tables of a compilation
+// unit, which the DW_FORM_strx and DW_FORM_addrx attributes of its
+// DIEs refer to in DWARF 5.
+type dwUnitTables struct {
+	str     loader.Sym // the strings, in .debug_str
+	strOffs loader.Sym // their offsets, in .debug_str_offsets
+	addr    loader.Sym // the addresses, in .debug_addr
+	strIdx  map[string]int64
+	nAddr   int64
 }
 
 func newdwctxt(linkctxt *Link, forTypeGen bool) dwctxt {
@@ -122,6 +137,26 @@ func (c dwctxt) AddAddress(s dwarf.Sym, data interface{}, value int64) {
 	dsu.AddAddrPlus(c.arch, tgtds, value)
 }
 
+func (c dwctxt) AddStringIndex(s dwarf.Sym, v string) {
+	t := c.tabs
+	idx, ok := t.strIdx[v]
+	if !ok {
+		idx = int64(len(t.strIdx))
+		t.strIdx[v] = idx
+		su := c.ldr.MakeSymbolUpdater(t.str)
+		c.AddDWARFAddrSectionOffset(dwSym(t.strOffs), dwSym(t.str), su.Size())
+		su.Addstring(v)
+	}
+	dwarf.Uleb128put(c, s, idx)
+}
+
+func (c dwctxt) AddAddressIndex(s dwarf.Sym, data interface{}, value int64) {
+	t := c.tabs
+	c.AddAddress(dwSym(t.addr), data, value)
+	dwarf.Uleb128put(c, s, t.nAddr)
+	t.nAddr++
+}
+
 func (c dwctxt) AddCURelativeAddress(s dwarf.Sym, data interface{}, value int64) {
 	ds := loader.Sym(s.(dwSym))
 	dsu := c.ldr.MakeSymbolUpdater(ds)
@@ -1199,9 +1234,38 @@ func (d *dwctxt) writeDirFileTables(unit *sym.CompilationUnit, lsu *loader.Symbo
 		}
 	}
 
+	lsDwsym := dwSym(lsu.Sym())
+	if dwarf.UseDWARF5() {
+		// DWARF 5 numbers directories and files from 0. Directory 0
+		// is the compilation directory, and file 0 the primary
+		// source file, for which we repeat the first file, so that
+		// the file numbers used by the compiler stay the same.
+		dirs[0] = getCompilationDir()
+		if len(files) > 0 {
+			files = append([]fileDir{files[0]}, files...)
+		}
+		lsu.AddUint8(1) // directory_entry_format_count
+		dwarf.Uleb128put(d, lsDwsym, dwarf.DW_LNCT_path)
+		dwarf.Uleb128put(d, lsDwsym, dwarf.DW_FORM_string)
+		dwarf.Uleb128put(d, lsDwsym, int64(len(dirs)))
+		for _, dir := range dirs {
+			d.AddString(lsDwsym, dir)
+		}
+		lsu.AddUint8(2) // file_name_entry_format_count
+		dwarf.Uleb128put(d, lsDwsym, dwarf.DW_LNCT_path)
+		dwarf.Uleb128put(d, lsDwsym, dwarf.DW_FORM_string)
+		dwarf.Uleb128put(d, lsDwsym, dwarf.DW_LNCT_directory_index)
+		dwarf.Uleb128put(d, lsDwsym, dwarf.DW_FORM_udata)
+		dwarf.Uleb128put(d, lsDwsym, int64(len(files)))
+		for _, f := range files {
+			d.AddString(lsDwsym, f.base)
+			dwarf.Uleb128put(d, lsDwsym, int64(f.dir))
+		}
+		return
+	}
+
 	// Emit directory section. This is a series of nul terminated
 	// strings, followed by a single zero byte.
-	lsDwsym := dwSym(lsu.Sym())
 	for k := 1; k < len(dirs); k++ {
 		d.AddString(lsDwsym, dirs[k])
 	}
@@ -1243,13 +1307,23 @@ func (d *dwctxt) writelines(unit *sym.CompilationUnit, lineProlog loader.Sym) []
 	unitLengthOffset := lsu.Size()
 	d.createUnitLength(lsu, 0) // unit_length (*), filled in at end
 	unitstart = lsu.Size()
-	lsu.AddUint16(d.arch, 2) // dwarf version (appendix F) -- version 3 is incompatible w/ XCode 9.0's dsymutil, latest supported on OSX 10.12 as of 2018-05
+	dwarf5 := dwarf.UseDWARF5()
+	if dwarf5 {
+		lsu.AddUint16(d.arch, 5)            // dwarf version
+		lsu.AddUint8(uint8(d.arch.PtrSize)) // address_size
+		lsu.AddUint8(0)                     // segment_selector_size
+	} else {
+		lsu.AddUint16(d.arch, 2) // dwarf version (appendix F) -- version 3 is incompatible w/ XCode 9.0's dsymutil, latest supported on OSX 10.12 as of 2018-05
+	}
 	headerLengthOffset := lsu.Size()
 	d.addDwarfAddrField(lsu, 0) // header_length (*), filled in at end
 	headerstart = lsu.Size()
 
 	// cpos == unitstart + 4 + 2 + 4
-	lsu.AddUint8(1)                // minimum_instruction_length
+	lsu.AddUint8(1) // minimum_instruction_length
+	if dwarf5 {
+		lsu.AddUint8(1) // maximum_operations_per_instruction
+	}
 	lsu.AddUint8(is_stmt)          // default_is_stmt
 	lsu.AddUint8(LINE_BASE & 0xFF) // line_base
 	lsu.AddUint8(LINE_RANGE)       // line_range
@@ -1308,6 +1382,9 @@ func (d *dwctxt) writepcranges(unit *sym.CompilationUnit, base loader.Sym, pcs [
 	syms = append(syms, rangeProlog)
 	rsu := d.ldr.MakeSymbolUpdater(rangeProlog)
 	rDwSym := dwSym(rangeProlog)
+	if dwarf.UseDWARF5() {
+		d.writeListsHeader(rsu)
+	}
 
 	// Create PC ranges for the compilation unit DIE.
 	newattr(unit.DWInfo, dwarf.DW_AT_ranges, dwarf.DW_CLS_PTR, rsu.Size(), rDwSym)
@@ -1325,10 +1402,24 @@ func (d *dwctxt) writepcranges(unit *sym.CompilationUnit, base loader.Sym, pcs [
 	if d.linkctxt.HeadType == objabi.Haix {
 		addDwsectCUSize(".debug_ranges", unit.Lib.Pkg, rsize)
 	}
+	if dwarf.UseDWARF5() {
+		rsu.SetUint32(d.arch, 0, uint32(rsize-4))
+	}
 
 	return syms
 }
 
+// writeListsHeader writes the header of a compilation unit's
+// contribution to .debug_rnglists or .debug_loclists to sb. Its
+// unit_length is filled in by the caller.
+func (d *dwctxt) writeListsHeader(sb *loader.SymbolBuilder) {
+	sb.AddUint32(d.arch, 0)            // unit_length, filled in later
+	sb.AddUint16(d.arch, 5)            // dwarf version
+	sb.AddUint8(uint8(d.arch.PtrSize)) // address_size
+	sb.AddUint8(0)                     // segment_selector_size
+	sb.AddUint32(d.arch, 0)            // offset_entry_count
+}
+
 /*
  *  Emit .debug_frame
  */
@@ -1539,12 +1630,22 @@ func (d *dwctxt) writeUnitInfo(u *sym.CompilationUnit, abbrevsym loader.Sym, inf
 	// Fields marked with (*) must be changed for 64-bit dwarf
 	// This must match COMPUNITHEADERSIZE above.
 	d.createUnitLength(su, 0) // unit_length (*), will be filled in later.
-	su.AddUint16(d.arch, 4)   // dwarf version (appendix F)
+	if d.tabs != nil {
+		// The DWARF 5 header (sec 7.5.1.1) also has a unit type,
+		// and moves the address size before the abbrev offset.
+		su.AddUint16(d.arch, 5)            // dwarf version
+		su.AddUint8(dwarf.DW_UT_compile)   // unit_type
+		su.AddUint8(uint8(d.arch.PtrSize)) // address_size
+		d.addDwarfAddrRef(su, abbrevsym)   // debug_abbrev_offset
+		d.startUnitTables(compunit)
+	} else {
+		su.AddUint16(d.arch, 4) // dwarf version (appendix F)
 
-	// debug_abbrev_offset (*)
-	d.addDwarfAddrRef(su, abbrevsym)
+		// debug_abbrev_offset (*)
+		d.addDwarfAddrRef(su, abbrevsym)
 
-	su.AddUint8(uint8(d.arch.PtrSize)) // address_size
+		su.AddUint8(uint8(d.arch.PtrSize)) // address_size
+	}
 
 	ds := dwSym(s)
 	dwarf.Uleb128put(d, ds, int64(compunit.Abbrev))
@@ -1593,9 +1694,39 @@ func (d *dwctxt) writeUnitInfo(u *sym.CompilationUnit, abbrevsym loader.Sym, inf
 		cusize -= 4 // exclude the length field.
 		su.SetUint32(d.arch, 0, uint32(cusize))
 	}
+	if d.tabs != nil {
+		d.finishUnitTables()
+	}
 	return append(syms, cu...)
 }
 
+// startUnitTables writes the headers of the compilation unit's
+// contributions to .debug_str_offsets and .debug_addr (sec 7.26 and
+// 7.27), and points the compilation unit DIE at them.
+func (d *dwctxt) startUnitTables(compunit *dwarf.DWDie) {
+	so := d.ldr.MakeSymbolUpdater(d.tabs.strOffs)
+	so.AddUint32(d.arch, 0) // unit_length, filled in later
+	so.AddUint16(d.arch, 5) // dwarf version
+	so.AddUint16(d.arch, 0) // padding
+	newattr(compunit, dwarf.DW_AT_str_offsets_base, dwarf.DW_CLS_PTR, so.Size(), dwSym(d.tabs.strOffs))
+
+	au := d.ldr.MakeSymbolUpdater(d.tabs.addr)
+	au.AddUint32(d.arch, 0)            // unit_length, filled in later
+	au.AddUint16(d.arch, 5)            // dwarf version
+	au.AddUint8(uint8(d.arch.PtrSize)) // address_size
+	au.AddUint8(0)                     // segment_selector_size
+	newattr(compunit, dwarf.DW_AT_addr_base, dwarf.DW_CLS_PTR, au.Size(), dwSym(d.tabs.addr))
+}
+
+// finishUnitTables fills in the lengths of the compilation unit's
+// string offset and address tables.
+func (d *dwctxt) finishUnitTables() {
+	for _, s := range []loader.Sym{d.tabs.strOffs, d.tabs.addr} {
+		su := d.ldr.MakeSymbolUpdater(s)
+		su.SetUint32(d.arch, 0, uint32(su.Size()-4))
+	}
+}
+
 func (d *dwctxt) writegdbscript() dwarfSecInfo {
 	// TODO (aix): make it available
 	if d.linkctxt.HeadType == objabi.Haix {
@@ -1961,7 +2092,9 @@ type dwUnitSyms struct {
 	// Inputs for a given unit.
 	lineProlog  loader.Sym
 	rangeProlog loader.Sym
+	locProlog   loader.Sym
 	infoEpilog  loader.Sym
+	tabs        *dwUnitTables
 
 	// Outputs for a given unit.
 	linesyms   []loader.Sym
@@ -1980,7 +2113,12 @@ func (d *dwctxt) dwUnitPortion(u *sym.CompilationUnit, abbrevsym loader.Sym, us
 		us.linesyms = d.writelines(u, us.lineProlog)
 		base := loader.Sym(u.Textp[0])
 		us.rangessyms = d.writepcranges(u, base, u.PCs, us.rangeProlog)
-		us.locsyms = d.collectUnitLocs(u)
+		us.locsyms = d.collectUnitLocs(u, us.locProlog)
+	}
+	if us.tabs != nil {
+		ud := *d
+		ud.tabs = us.tabs
+		d = &ud
 	}
 	us.infosyms = d.writeUnitInfo(u, abbrevsym, us.infoEpilog)
 }
@@ -2014,10 +2152,15 @@ func (d *dwctxt) dwarfGenerateDebugSyms() {
 	}
 
 	// Create the section symbols.
+	dwarf5 := dwarf.UseDWARF5()
+	locName, rangesName := ".debug_loc", ".debug_ranges"
+	if dwarf5 {
+		locName, rangesName = ".debug_loclists", ".debug_rnglists"
+	}
 	frameSym := mkSecSym(".debug_frame")
-	locSym := mkSecSym(".debug_loc")
+	locSym := mkSecSym(locName)
 	lineSym := mkSecSym(".debug_line")
-	rangesSym := mkSecSym(".debug_ranges")
+	rangesSym := mkSecSym(rangesName)
 	infoSym := mkSecSym(".debug_info")
 
 	// Create the section objects
@@ -2036,6 +2179,15 @@ func (d *dwctxt) dwarfGenerateDebugSyms() {
 		us.lineProlog = mkAnonSym(sym.SDWARFLINES)
 		us.rangeProlog = mkAnonSym(sym.SDWARFRANGE)
 		us.infoEpilog = mkAnonSym(sym.SDWARFFCN)
+		if dwarf5 {
+			us.locProlog = mkAnonSym(sym.SDWARFLOC)
+			us.tabs = &dwUnitTables{
+				str:     mkAnonSym(sym.SDWARFSECT),
+				strOffs: mkAnonSym(sym.SDWARFSECT),
+				addr:    mkAnonSym(sym.SDWARFSECT),
+				strIdx:  make(map[string]int64),
+			}
+		}
 	}
 
 	var wg sync.WaitGroup
@@ -2096,6 +2248,23 @@ func (d *dwctxt) dwarfGenerateDebugSyms() {
 		dwarfp = append(dwarfp, locSec)
 	}
 	dwarfp = append(dwarfp, rangesSec)
+	if dwarf5 {
+		strSec := dwarfSecInfo{syms: []loader.Sym{mkSecSym(".debug_str")}}
+		strOffsSec := dwarfSecInfo{syms: []loader.Sym{mkSecSym(".debug_str_offsets")}}
+		addrSec := dwarfSecInfo{syms: []loader.Sym{mkSecSym(".debug_addr")}}
+		for i := 0; i < ncu; i++ {
+			r := &unitSyms[i]
+			if len(r.infosyms) == 0 {
+				continue
+			}
+			strSec.syms = append(strSec.syms, markReachable([]loader.Sym{r.tabs.str})...)
+			strOffsSec.syms = append(strOffsSec.syms, markReachable([]loader.Sym{r.tabs.strOffs})...)
+			if r.tabs.nAddr > 0 {
+				addrSec.syms = append(addrSec.syms, markReachable([]loader.Sym{r.tabs.addr})...)
+			}
+		}
+		dwarfp = append(dwarfp, strSec, strOffsSec, addrSec)
+	}
 
 	// Check to make sure we haven't listed any symbols more than once
 	// in the info section. This used to be done by setting and
@@ -2110,8 +2279,11 @@ func (d *dwctxt) dwarfGenerateDebugSyms() {
 	}
 }
 
-func (d *dwctxt) collectUnitLocs(u *sym.CompilationUnit) []loader.Sym {
+func (d *dwctxt) collectUnitLocs(u *sym.CompilationUnit, locProlog loader.Sym) []loader.Sym {
 	syms := []loader.Sym{}
+	if locProlog != 0 {
+		syms = append(syms, locProlog)
+	}
 	for _, fn := range u.FuncDIEs {
 		relocs := d.ldr.Relocs(loader.Sym(fn))
 		for i := 0; i < relocs.Count(); i++ {
@@ -2127,6 +2299,18 @@ func (d *dwctxt) collectUnitLocs(u *sym.CompilationUnit) []loader.Sym {
 			}
 		}
 	}
+	if locProlog != 0 {
+		if len(syms) == 1 {
+			return nil
+		}
+		lsu := d.ldr.MakeSymbolUpdater(locProlog)
+		d.writeListsHeader(lsu)
+		var size int64
+		for _, s := range syms {
+			size += d.ldr.SymSize(s)
+		}
+		lsu.SetUint32(d.arch, 0, uint32(size-4))
+	}
 	return syms
 }
 
@@ -2139,6 +2323,9 @@ func dwarfaddshstrings(ctxt *Link, shstrtab *loader.SymbolBuilder) {
 	}
 
 	secs := []string{"abbrev", "frame", "info", "loc", "line", "gdb_scripts", "ranges"}
+	if dwarf.UseDWARF5() {
+		secs = append(secs, "loclists", "rnglists", "str", "str_offsets", "addr")
+	}
 	for _, sec := range secs {
 		shstrtab.Addstring(".debug_" + sec)
 		if ctxt.IsExternal() {
@@ -2177,7 +2364,7 @@ func dwarfcompress(ctxt *Link) {
 	}
 
 	supported := ctxt.IsELF || ctxt.IsWindows() || ctxt.IsDarwin()
-	if !ctxt.compressDWARF || !supported || ctxt.IsExternal() {
+	if ctxt.compressDWARF == DWARFCompressNone || !supported || ctxt.IsExternal() {
 		return
 	}
 
@@ -2206,9 +2393,22 @@ func dwarfcompress(ctxt *Link) {
 			newDwarfp = append(newDwarfp, ds)
 			Segdwarf.Sections = append(Segdwarf.Sections, ldr.SymSect(s))
 		} else {
+			var sect *sym.Section
 			compressedSegName := ".zdebug_" + ldr.SymSect(s).Name[len(".debug_"):]
-			sect := addsection(ctxt.loader, ctxt.Arch, &Segdwarf, compressedSegName, 04)
-			sect.Align = 1
+			if ctxt.IsELF && ctxt.compressDWARF == DWARFCompressZstd {
+				// The section keeps its name and is marked
+				// SHF_COMPRESSED. Its contents start with a
+				// compression header, which must be aligned.
+				sect = addsection(ctxt.loader, ctxt.Arch, &Segdwarf, ldr.SymSect(s).Name, 04)
+				sect.Compressed = true
+				sect.Align = 4
+				if elf64 {
+					sect.Align = 8
+				}
+			} else {
+				sect = addsection(ctxt.loader, ctxt.Arch, &Segdwarf, compressedSegName, 04)
+				sect.Align = 1
+			}
 			sect.Length = uint64(len(z.compressed))
 			newSym := ldr.CreateSymForUpdate(compressedSegName, 0)
 			newSym.SetData(z.compressed)
@@ -2233,8 +2433,11 @@ func dwarfcompress(ctxt *Link) {
 	var prevSect *sym.Section
 	for _, si := range dwarfp {
 		for _, s := range si.syms {
-			ldr.SetSymValue(s, int64(pos))
 			sect := ldr.SymSect(s)
+			if sect.Compressed {
+				pos = uint64(Rnd(int64(pos), int64(sect.Align)))
+			}
+			ldr.SetSymValue(s, int64(pos))
 			if sect != prevSect {
 				sect.Vaddr = uint64(pos)
 				prevSect = sect
@@ -8,6 +8,7 @@ import (
 	intdwarf "cmd/internal/dwarf"
 	objfilepkg "cmd/internal/objfile" // renamed to avoid conflict with objfile function
 	"debug/dwarf"
+	"debug/elf"
 	"debug/pe"
 	"errors"
 	"fmt"
@@ -1641,3 +1642,210 @@ func TestIssue42484(t *testing.T) {
 	}
 	f.Close()
 }
+
+func TestDWARF5(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+
+	switch runtime.GOOS {
+	case "aix", "darwin", "ios", "js", "plan9", "windows":
+		t.Skipf("skipping on %s; DWARF 5 is only generated for ELF", runtime.GOOS)
+	}
+
+	t.Parallel()
+
+	const prog = `
+package main
+
+type T struct {
+	A int
+	B string
+}
+
+var sink int
+
+func f(x int, s []T) int {
+	y := 0
+	for i := range s {
+		y += s[i].A * x
+	}
+	return y
+}
+
+func main() {
+	sink = f(3, []T{{1, "a"}})
+}
+`
+	dir := t.TempDir()
+	src := filepath.Join(dir, "test.go")
+	dst := filepath.Join(dir, "out.exe")
+	if err := ioutil.WriteFile(src, []byte(prog), 0666); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags=-compressdwarf=false", "-o", dst, src)
+	cmd.Env = append(os.Environ(), "GOEXPERIMENT=dwarf5")
+	if b, err := cmd.CombinedOutput(); err != nil {
+		t.Logf("build: %s\n", b)
+		t.Fatalf("build error: %v", err)
+	}
+
+	ef, err := elf.Open(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ef.Close()
+	for _, name := range []string{".debug_addr", ".debug_str", ".debug_str_offsets", ".debug_rnglists", ".debug_loclists"} {
+		if ef.Section(name) == nil {
+			t.Errorf("missing section %s", name)
+		}
+	}
+	for _, name := range []string{".debug_ranges", ".debug_loc"} {
+		if ef.Section(name) != nil {
+			t.Errorf("unexpected section %s", name)
+		}
+	}
+
+	dw, err := ef.DWARF()
+	if err != nil {
+		t.Fatalf("error parsing DWARF: %v", err)
+	}
+	ex := examiner{}
+	if err := ex.populate(dw.Reader()); err != nil {
+		t.Fatalf("error reading DWARF: %v", err)
+	}
+
+	// The names of compilation units and types are in .debug_str.
+	if len(ex.Named("main.T")) == 0 {
+		t.Errorf("type main.T not found")
+	}
+
+	// Parameters and variables refer to type DIEs in other units.
+	fs := ex.Named("main.f")
+	if len(fs) != 1 {
+		t.Fatalf("found %d DIEs for main.f, want 1", len(fs))
+	}
+	fidx := ex.idxFromOffset(fs[0].Offset)
+	vars := make(map[string]string)
+	for _, c := range ex.Children(fidx) {
+		name, _ := c.Val(dwarf.AttrName).(string)
+		toff, ok := c.Val(dwarf.AttrType).(dwarf.Offset)
+		if !ok {
+			continue
+		}
+		typ, err := dw.Type(toff)
+		if err != nil {
+			t.Fatalf("reading type of %s: %v", name, err)
+		}
+		vars[name] = typ.String()
+	}
+	want := map[string]string{"x": "int", "s": "struct []main.T", "y": "int"}
+	for name, typ := range want {
+		if vars[name] != typ {
+			t.Errorf("%s has type %q, want %q", name, vars[name], typ)
+		}
+	}
+
+	// The main unit has ranges and a line table with the test file.
+	rdr := dw.Reader()
+	found := false
+	for {
+		e, err := rdr.Next()
+		if err != nil {
+			t.Fatalf("error reading DWARF: %v", err)
+		}
+		if e == nil {
+			break
+		}
+		if e.Tag != dwarf.TagCompileUnit {
+			continue
+		}
+		rdr.SkipChildren()
+		if e.Val(dwarf.AttrName) != "main" {
+			continue
+		}
+		ranges, err := dw.Ranges(e)
+		if err != nil || len(ranges) == 0 {
+			t.Errorf("main unit has ranges %v, %v", ranges, err)
+		}
+		lr, err := dw.LineReader(e)
+		if err != nil {
+			t.Fatalf("error creating DWARF line reader: %v", err)
+		}
+		var lne dwarf.LineEntry
+		for lr.Next(&lne) == nil {
+			if filepath.Base(lne.File.Name) == "test.go" && lne.Line == 14 {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no line table entry for test.go:14")
+	}
+}
+
+func TestZstdCompressedDWARF(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+
+	switch runtime.GOOS {
+	case "aix", "js", "plan9":
+		t.Skipf("skipping on %s; DWARF is not compressed", runtime.GOOS)
+	}
+
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "test.go")
+	if err := ioutil.WriteFile(src, []byte("package main\n\nfunc main() { println(\"hello\") }\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range []string{"zlib", "zstd"} {
+		dst := filepath.Join(dir, method+".exe")
+		cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags=-linkmode=internal -compressdwarf="+method, "-o", dst, src)
+		if b, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("build error: %v\n%s", err, b)
+		}
+
+		f, err := objfilepkg.Open(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := f.DWARF()
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: error reading DWARF: %v", method, err)
+		}
+		rdr := d.Reader()
+		found := false
+		for {
+			e, err := rdr.Next()
+			if err != nil {
+				t.Fatalf("%s: error reading DWARF: %v", method, err)
+			}
+			if e == nil {
+				break
+			}
+			if name, _ := e.Val(dwarf.AttrName).(string); e.Tag == dwarf.TagSubprogram && name == "main.main" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s: main.main not found in DWARF", method)
+		}
+	}
+
+	if runtime.GOOS != "darwin" && runtime.GOOS != "ios" && runtime.GOOS != "windows" {
+		// ELF marks the sections compressed, keeping their names.
+		ef, err := elf.Open(filepath.Join(dir, "zstd.exe"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ef.Close()
+		sect := ef.Section(".debug_info")
+		if sect == nil {
+			t.Fatal("no .debug_info section")
+		}
+		if sect.Flags&elf.SHF_COMPRESSED == 0 {
+			t.Errorf(".debug_info flags %v, want SHF_COMPRESSED", sect.Flags)
+		}
+	}
+}
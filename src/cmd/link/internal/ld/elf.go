@@ -227,6 +227,9 @@ func Elfinit(ctxt *Link) {
 		}
 		if ctxt.Arch.Family == sys.RISCV64 {
 			ehdr.Flags = 0x4 /* RISCV Float ABI Double */
+			if objabi.GORISCV64 == "rv64gc" {
+				ehdr.Flags |= 0x1 /* RISCV RVC */
+			}
 		}
 		elf64 = true
 
@@ -1094,6 +1097,9 @@ func elfshbits(linkmode LinkMode, sect *sym.Section) *ElfShdr {
 	}
 	if strings.HasPrefix(sect.Name, ".debug") || strings.HasPrefix(sect.Name, ".zdebug") {
 		sh.Flags = 0
+		if sect.Compressed {
+			sh.Flags = uint64(elf.SHF_COMPRESSED)
+		}
 	}
 
 	if linkmode != LinkExternal {
@@ -1287,6 +1293,7 @@ func (ctxt *Link) doelf() {
 	shstrtab.Addstring(".bss")
 	shstrtab.Addstring(".noptrbss")
 	shstrtab.Addstring("__libfuzzer_extra_counters")
+	shstrtab.Addstring(".go.covctrs")
 	shstrtab.Addstring(".go.buildinfo")
 	if ctxt.IsMIPS() {
 		shstrtab.Addstring(".MIPS.abiflags")
@@ -0,0 +1,217 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ld
+
+import (
+	"cmd/internal/obj"
+	"cmd/internal/objabi"
+	"cmd/link/internal/loader"
+	"cmd/link/internal/sym"
+	"internal/profile"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxFuncCluster is the largest size, in bytes, of a group of
+// functions that orderFuncs lays out together. Beyond that, placing a
+// callee next to its caller no longer keeps them in the same pages.
+const maxFuncCluster = 64 << 10
+
+// profileEdgeWeight is the weight of a call seen once in the profile
+// given with -callgraphprofile. It is larger than any weight the
+// compiler gives to a single call site, so that the calls actually
+// made at run time take precedence over the compiler's estimates.
+const profileEdgeWeight = 1 << 16
+
+// A funcCluster is a sequence of functions that orderFuncs keeps
+// together in the text section.
+type funcCluster struct {
+	funcs  []loader.Sym
+	size   int64
+	weight int64 // total weight of the calls within the cluster
+}
+
+// orderFuncs reorders the text symbols so that functions are placed
+// next to the functions that call them most often, which improves the
+// use of the instruction cache and TLB.
+//
+// The call graph comes from the compiler, which records the static
+// calls made by each function and estimates how often each of them
+// runs (see cmd/compile/internal/ssagen.WriteCallGraph), and from the
+// pprof profile given with -callgraphprofile, if any, whose samples
+// give the calls made at run time.
+//
+// The functions are grouped following Pettis and Hansen: starting
+// with each function in its own cluster, the calls are visited from
+// the heaviest to the lightest, and the cluster of the callee is
+// appended to the cluster of the caller, unless the result would
+// exceed maxFuncCluster. The clusters are then laid out from the
+// heaviest to the lightest, followed by the functions that are not
+// part of any call, in their original order.
+func (ctxt *Link) orderFuncs() {
+	if !*flagCallGraphOrder && *flagCallGraphProfile == "" {
+		return
+	}
+	if ctxt.IsWasm() || ctxt.IsAIX() {
+		// The Wasm function indexes and the AIX TOC layout
+		// depend on the order of the text symbols.
+		return
+	}
+	ldr := ctxt.loader
+
+	index := make(map[loader.Sym]int, len(ctxt.Textp))
+	clusterOf := make(map[loader.Sym]*funcCluster, len(ctxt.Textp))
+	for i, s := range ctxt.Textp {
+		index[s] = i
+		clusterOf[s] = &funcCluster{funcs: []loader.Sym{s}, size: ldr.SymSize(s)}
+	}
+
+	type edge struct{ caller, callee loader.Sym }
+	weights := make(map[edge]int64)
+	addEdge := func(caller, callee loader.Sym, w int64) {
+		if caller == callee || clusterOf[caller] == nil || clusterOf[callee] == nil {
+			return
+		}
+		weights[edge{caller, callee}] += w
+	}
+
+	const prefix = "go.callgraph."
+	for i := loader.Sym(1); i < loader.Sym(ldr.NSym()); i++ {
+		name := ldr.SymName(i)
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		caller := lookupFunc(ldr, name[len(prefix):])
+		if caller == 0 {
+			continue
+		}
+		pkgprefix := objabi.PathToPrefix(ldr.SymPkg(i)) + "."
+		for _, line := range strings.Split(string(ldr.Data(i)), "\n") {
+			f := strings.Fields(line)
+			if len(f) != 2 {
+				continue
+			}
+			w, err := strconv.ParseInt(f[0], 10, 64)
+			if err != nil {
+				continue
+			}
+			callee := lookupFunc(ldr, strings.Replace(f[1], `"".`, pkgprefix, -1))
+			addEdge(caller, callee, w)
+		}
+	}
+
+	if *flagCallGraphProfile != "" {
+		f, err := os.Open(*flagCallGraphProfile)
+		if err != nil {
+			Exitf("%v", err)
+		}
+		p, err := profile.Parse(f)
+		f.Close()
+		if err != nil {
+			Exitf("reading %s: %v", *flagCallGraphProfile, err)
+		}
+		for _, s := range p.Sample {
+			if len(s.Value) == 0 {
+				continue
+			}
+			// Location[0] is the innermost frame. The last line of a
+			// location is the function the code was compiled into,
+			// the others are functions inlined into it.
+			for i := 0; i+1 < len(s.Location); i++ {
+				callee := profileFunc(ldr, s.Location[i])
+				caller := profileFunc(ldr, s.Location[i+1])
+				addEdge(caller, callee, s.Value[0]*profileEdgeWeight)
+			}
+		}
+	}
+
+	if len(weights) == 0 {
+		return
+	}
+	edges := make([]edge, 0, len(weights))
+	for e := range weights {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		ei, ej := edges[i], edges[j]
+		if weights[ei] != weights[ej] {
+			return weights[ei] > weights[ej]
+		}
+		if ei.caller != ej.caller {
+			return index[ei.caller] < index[ej.caller]
+		}
+		return index[ei.callee] < index[ej.callee]
+	})
+
+	for _, e := range edges {
+		caller, callee := clusterOf[e.caller], clusterOf[e.callee]
+		if caller == callee {
+			caller.weight += weights[e]
+			continue
+		}
+		if caller.size+callee.size > maxFuncCluster {
+			continue
+		}
+		for _, s := range callee.funcs {
+			clusterOf[s] = caller
+		}
+		caller.funcs = append(caller.funcs, callee.funcs...)
+		caller.size += callee.size
+		caller.weight += callee.weight + weights[e]
+	}
+
+	var ordered []*funcCluster
+	seen := make(map[*funcCluster]bool)
+	for _, s := range ctxt.Textp {
+		if c := clusterOf[s]; !seen[c] {
+			seen[c] = true
+			ordered = append(ordered, c)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].weight > ordered[j].weight
+	})
+
+	pos := make(map[sym.LoaderSym]int, len(ctxt.Textp))
+	textp := ctxt.Textp[:0]
+	for _, c := range ordered {
+		for _, s := range c.funcs {
+			pos[sym.LoaderSym(s)] = len(textp)
+			textp = append(textp, s)
+		}
+	}
+	ctxt.Textp = textp
+
+	// The DWARF generator expects the functions of each compilation
+	// unit to be in address order.
+	for _, lib := range ctxt.Library {
+		for _, unit := range lib.Units {
+			sort.SliceStable(unit.Textp, func(i, j int) bool {
+				return pos[unit.Textp[i]] < pos[unit.Textp[j]]
+			})
+		}
+	}
+}
+
+// lookupFunc returns the text symbol named name, or 0 if there is none.
+func lookupFunc(ldr *loader.Loader, name string) loader.Sym {
+	for _, abi := range []obj.ABI{obj.ABIInternal, obj.ABI0} {
+		if s := ldr.Lookup(name, sym.ABIToVersion(abi)); s != 0 && ldr.SymType(s) == sym.STEXT {
+			return s
+		}
+	}
+	return 0
+}
+
+// profileFunc returns the text symbol of the function containing the
+// profile location loc, or 0 if it is not known.
+func profileFunc(ldr *loader.Loader, loc *profile.Location) loader.Sym {
+	if len(loc.Line) == 0 || loc.Line[len(loc.Line)-1].Function == nil {
+		return 0
+	}
+	return lookupFunc(ldr, loc.Line[len(loc.Line)-1].Function.Name)
+}
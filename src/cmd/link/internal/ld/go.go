@@ -383,10 +383,30 @@ func Adddynsym(ldr *loader.Loader, target *Target, syms *ArchSyms, s loader.Sym)
 	}
 }
 
+// fieldTracking reports whether the linker records the uses of
+// struct fields tagged go:"track".
+func fieldTracking() bool {
+	return objabi.Experiment.FieldTrack || *flagFieldTrackJSON != ""
+}
+
+// A trackedField is the -fieldtrack record of one tracked field.
+type trackedField struct {
+	Field string   `json:"field"`
+	Used  bool     `json:"used"`
+	Users []string `json:"users,omitempty"` // the reachable functions that use the field
+	Chain []string `json:"chain,omitempty"` // how the field was reached, ending at a root
+}
+
 func fieldtrack(arch *sys.Arch, l *loader.Loader) {
+	var users map[loader.Sym][]string
+	if *flagFieldTrackJSON != "" {
+		users = fieldUsers(l)
+	}
 	var buf bytes.Buffer
+	var fields []trackedField
 	for i := loader.Sym(1); i < loader.Sym(l.NSym()); i++ {
 		if name := l.SymName(i); strings.HasPrefix(name, "go.track.") {
+			f := trackedField{Field: name[9:], Used: l.AttrReachable(i), Users: users[i]}
 			if l.AttrReachable(i) {
 				l.SetAttrSpecial(i, true)
 				l.SetAttrNotInSymbolTable(i, true)
@@ -394,12 +414,17 @@ func fieldtrack(arch *sys.Arch, l *loader.Loader) {
 				for p := l.Reachparent[i]; p != 0; p = l.Reachparent[p] {
 					buf.WriteString("\t")
 					buf.WriteString(l.SymName(p))
+					f.Chain = append(f.Chain, l.SymName(p))
 				}
 				buf.WriteString("\n")
 			}
+			fields = append(fields, f)
 		}
 	}
 	l.Reachparent = nil // we are done with it
+	if *flagFieldTrackJSON != "" {
+		writeFieldTrack(*flagFieldTrackJSON, fields)
+	}
 	if *flagFieldTrack == "" {
 		return
 	}
@@ -412,6 +437,46 @@ func fieldtrack(arch *sys.Arch, l *loader.Loader) {
 	addstrdata(arch, l, *flagFieldTrack, buf.String())
 }
 
+// fieldUsers returns, for each field tracking symbol, the names of
+// the reachable functions that use the field.
+func fieldUsers(l *loader.Loader) map[loader.Sym][]string {
+	users := make(map[loader.Sym][]string)
+	for s := loader.Sym(1); s < loader.Sym(l.NSym()); s++ {
+		if !l.AttrReachable(s) || l.SymType(s) != sym.STEXT {
+			continue
+		}
+		relocs := l.Relocs(s)
+		for ri := 0; ri < relocs.Count(); ri++ {
+			if r := relocs.At(ri); r.Type() == objabi.R_USEFIELD {
+				users[r.Sym()] = append(users[r.Sym()], l.SymName(s))
+			}
+		}
+	}
+	return users
+}
+
+// writeFieldTrack writes the tracked fields to file as JSON, one
+// object per line, sorted by field name.
+func writeFieldTrack(file string, fields []trackedField) {
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+	for _, f := range fields {
+		sort.Strings(f.Users)
+	}
+	out, err := os.Create(file)
+	if err != nil {
+		Exitf("cannot create %s: %v", file, err)
+	}
+	enc := json.NewEncoder(out)
+	for _, f := range fields {
+		if err := enc.Encode(f); err != nil {
+			Exitf("writing %s: %v", file, err)
+		}
+	}
+	if err := out.Close(); err != nil {
+		Exitf("writing %s: %v", file, err)
+	}
+}
+
 func (ctxt *Link) addexport() {
 	// Track undefined external symbols during external link.
 	if ctxt.LinkMode == LinkExternal {
@@ -0,0 +1,246 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ld
+
+import (
+	"cmd/internal/objabi"
+	"cmd/link/internal/loader"
+	"cmd/link/internal/sym"
+	"encoding/binary"
+	"strings"
+)
+
+// foldIdenticalCode implements -icf: it merges the Go functions whose
+// machine code is identical, keeping the first of them in the text
+// section and giving the others its address.
+//
+// Two functions are identical if they have the same bytes, the same
+// relocations, targeting the same symbols or identical functions, and
+// the same metadata used for stack unwinding and garbage collection:
+// frame layout, PC-value tables other than positions, and funcdata.
+// Their tracebacks are therefore the same except for the names and
+// positions they show, which are those of the kept function.
+//
+// The folded functions keep their own symbols, which appear in the
+// symbol table at the address of the kept function, so that the
+// relocations that target them need not change. Go does not allow
+// comparing functions, so sharing an address is not observable to
+// programs that do not use unsafe or reflection.
+//
+// The classes of identical functions are found by partition
+// refinement: the functions are first grouped by their contents,
+// ignoring the targets of relocations to other candidate functions,
+// then the groups are split according to the groups of those targets
+// until no group changes.
+func (ctxt *Link) foldIdenticalCode() {
+	if !*flagICF {
+		return
+	}
+	if ctxt.IsExternal() || ctxt.DynlinkingGo() || ctxt.IsWasm() || ctxt.IsAIX() {
+		// The external linker and other modules refer to the
+		// functions by name, and the Wasm and AIX text layouts
+		// need one symbol per function.
+		return
+	}
+	ldr := ctxt.loader
+
+	var funcs []loader.Sym
+	class := make(map[loader.Sym]int)
+	for _, s := range ctxt.Textp {
+		if canFold(ldr, s) {
+			funcs = append(funcs, s)
+			class[s] = 0
+		}
+	}
+	if len(funcs) < 2 {
+		return
+	}
+
+	// isFunc reports whether s is a candidate. The targets of the
+	// relocations to candidates are compared by class.
+	isFunc := func(s loader.Sym) bool {
+		_, ok := class[s]
+		return ok
+	}
+	keys := make(map[string]int)
+	var buf []byte
+	for _, s := range funcs {
+		buf = appendFuncKey(ldr, buf[:0], s, isFunc)
+		id, ok := keys[string(buf)]
+		if !ok {
+			id = len(keys)
+			keys[string(buf)] = id
+		}
+		class[s] = id
+	}
+
+	nclass := len(keys)
+	next := make([]int, len(funcs))
+	for {
+		keys = make(map[string]int)
+		for i, s := range funcs {
+			buf = appendVarint(buf[:0], int64(class[s]))
+			relocs := ldr.Relocs(s)
+			for ri := 0; ri < relocs.Count(); ri++ {
+				if rs := relocs.At(ri).Sym(); isFunc(rs) {
+					buf = appendVarint(buf, int64(class[rs]))
+				}
+			}
+			id, ok := keys[string(buf)]
+			if !ok {
+				id = len(keys)
+				keys[string(buf)] = id
+			}
+			next[i] = id
+		}
+		for i, s := range funcs {
+			class[s] = next[i]
+		}
+		if len(keys) == nclass {
+			break
+		}
+		nclass = len(keys)
+	}
+
+	// Keep the first function of each class, in text order.
+	kept := make([]loader.Sym, nclass)
+	folded := make(map[loader.Sym]bool)
+	ctxt.foldedFuncs = make(map[loader.Sym][]loader.Sym)
+	for _, s := range funcs {
+		c := class[s]
+		if kept[c] == 0 {
+			kept[c] = s
+			continue
+		}
+		ctxt.foldedFuncs[kept[c]] = append(ctxt.foldedFuncs[kept[c]], s)
+		folded[s] = true
+	}
+	if len(folded) == 0 {
+		return
+	}
+
+	textp := ctxt.Textp[:0]
+	for _, s := range ctxt.Textp {
+		if !folded[s] {
+			textp = append(textp, s)
+		}
+	}
+	ctxt.Textp = textp
+	for _, lib := range ctxt.Library {
+		for _, unit := range lib.Units {
+			textp := unit.Textp[:0]
+			for _, s := range unit.Textp {
+				if !folded[loader.Sym(s)] {
+					textp = append(textp, s)
+				}
+			}
+			unit.Textp = textp
+		}
+	}
+
+	if ctxt.Debugvlog != 0 {
+		var size int64
+		for s := range folded {
+			size += ldr.SymSize(s)
+		}
+		ctxt.Logf("icf: folded %d functions, %d bytes\n", len(folded), size)
+	}
+}
+
+// canFold reports whether the text symbol s may be folded with other
+// functions, or have other functions folded into it.
+func canFold(ldr *loader.Loader, s loader.Sym) bool {
+	if ldr.SymType(s) != sym.STEXT || ldr.SymVersion(s) != sym.SymVerABIInternal {
+		// Assembly functions and ABI wrappers are often
+		// referred to by address.
+		return false
+	}
+	if ldr.AttrSpecial(s) || ldr.AttrCgoExport(s) || ldr.AttrSubSymbol(s) || ldr.IsDeferReturnTramp(s) {
+		return false
+	}
+	fi := ldr.FuncInfo(s)
+	if !fi.Valid() || fi.FuncID() != objabi.FuncID_normal || fi.TopFrame() {
+		return false
+	}
+	// The runtime compares the PCs of some of its functions.
+	pkg := ldr.SymPkg(s)
+	return pkg != "runtime" && !strings.HasPrefix(pkg, "runtime/internal/")
+}
+
+// appendFuncKey appends to buf a description of the function s that is
+// the same for identical functions, except that the targets of the
+// relocations for which isFunc returns true are left out.
+func appendFuncKey(ldr *loader.Loader, buf []byte, s loader.Sym, isFunc func(loader.Sym) bool) []byte {
+	buf = appendVarint(buf, int64(ldr.SymAlign(s)))
+	buf = appendSymKey(ldr, buf, s, isFunc)
+
+	fi := ldr.FuncInfo(s)
+	fi.Preload()
+	buf = appendVarint(buf, int64(fi.Args()))
+	buf = appendVarint(buf, int64(fi.Locals()))
+	buf = append(buf, byte(fi.FuncFlag()))
+	buf = appendData(ldr, buf, fi.Pcsp())
+	pcdata := fi.Pcdata()
+	buf = appendVarint(buf, int64(len(pcdata)))
+	for i, p := range pcdata {
+		if i == objabi.PCDATA_InlTreeIndex {
+			// Refers to the inlining tree, which is part
+			// of the position information.
+			continue
+		}
+		buf = appendData(ldr, buf, p)
+	}
+	funcdata := fi.Funcdata(nil)
+	buf = appendVarint(buf, int64(len(funcdata)))
+	for i, fd := range funcdata {
+		buf = appendVarint(buf, fi.Funcdataoff(i))
+		if fd == 0 {
+			buf = append(buf, 0)
+			continue
+		}
+		buf = append(buf, 1)
+		buf = appendSymKey(ldr, buf, fd, func(loader.Sym) bool { return false })
+	}
+	return buf
+}
+
+// appendSymKey appends to buf the contents and relocations of s.
+// The targets of the relocations for which isFunc returns true are
+// left out.
+func appendSymKey(ldr *loader.Loader, buf []byte, s loader.Sym, isFunc func(loader.Sym) bool) []byte {
+	buf = appendVarint(buf, int64(ldr.SymSize(s)))
+	buf = appendData(ldr, buf, s)
+	relocs := ldr.Relocs(s)
+	buf = appendVarint(buf, int64(relocs.Count()))
+	for ri := 0; ri < relocs.Count(); ri++ {
+		r := relocs.At(ri)
+		buf = appendVarint(buf, int64(r.Off()))
+		buf = append(buf, r.Siz())
+		buf = appendVarint(buf, int64(r.Reloc.Type()))
+		buf = appendVarint(buf, r.Add())
+		if rs := r.Sym(); !isFunc(rs) {
+			buf = append(buf, 0)
+			buf = appendVarint(buf, int64(rs))
+		} else {
+			buf = append(buf, 1)
+		}
+	}
+	return buf
+}
+
+// appendData appends to buf the contents of s, if any.
+func appendData(ldr *loader.Loader, buf []byte, s loader.Sym) []byte {
+	var data []byte
+	if s != 0 {
+		data = ldr.Data(s)
+	}
+	buf = appendVarint(buf, int64(len(data)))
+	return append(buf, data...)
+}
+
+func appendVarint(buf []byte, v int64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	return append(buf, b[:binary.PutVarint(b[:], v)]...)
+}
@@ -64,7 +64,7 @@ type Link struct {
 
 	Loaded bool // set after all inputs have been loaded as symbols
 
-	compressDWARF bool
+	compressDWARF DWARFCompression
 
 	Libdir       []string
 	Library      []*sym.Library
@@ -78,6 +78,10 @@ type Link struct {
 
 	tramps []loader.Sym // trampolines
 
+	foldedFuncs map[loader.Sym][]loader.Sym // functions folded into each function by -icf
+
+	sharedStrings []sharedString // string data stored inside other string data by -sharestrings
+
 	compUnits []*sym.CompilationUnit // DWARF compilation units
 	runtimeCU *sym.CompilationUnit   // One of the runtime CUs, the last one seen.
 
@@ -0,0 +1,91 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ld
+
+import (
+	"cmd/internal/obj"
+	"cmd/link/internal/loader"
+	"cmd/link/internal/sym"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A linknameSig is the signature the compiler recorded for a function
+// linked by name to another symbol, as described by
+// cmd/compile/internal/ssagen.WriteLinknameSigs.
+type linknameSig struct {
+	pkg    string // package declaring the function
+	typ    string // function type, for error messages
+	layout string // argument layout; equal layouts are compatible
+}
+
+// argSize returns the size of the argument area recorded in the
+// layout, which follows the last parenthesis.
+func (s linknameSig) argSize() (int, bool) {
+	n, err := strconv.Atoi(s.layout[strings.LastIndexByte(s.layout, ')')+1:])
+	return n, err == nil
+}
+
+// checkLinknames checks that the functions linked to each other with
+// //go:linkname directives agree on the layout of their arguments,
+// so that a signature changed on one side only is reported instead of
+// silently corrupting arguments at run time.
+//
+// The compiler records the signature of each function that is linked
+// to a symbol sym as the value of a go.linkname.sym symbol. Signatures
+// recorded for the same symbol by different packages must match, and
+// if sym is a Go function, its argument size must match as well.
+func (ctxt *Link) checkLinknames() {
+	const prefix = "go.linkname."
+	ldr := ctxt.loader
+
+	sigs := make(map[string][]linknameSig)
+	for i := loader.Sym(1); i < loader.Sym(ldr.NSym()); i++ {
+		name := ldr.SymName(i)
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		f := strings.Split(string(ldr.Data(i)), "\x00")
+		if len(f) != 3 {
+			continue
+		}
+		target := name[len(prefix):]
+		sigs[target] = append(sigs[target], linknameSig{pkg: f[0], typ: f[1], layout: f[2]})
+	}
+
+	targets := make([]string, 0, len(sigs))
+	for target := range sigs {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		list := sigs[target]
+		first := list[0]
+		for _, s := range list[1:] {
+			if s.layout != first.layout {
+				Errorf(nil, "%s: signature %s in package %s does not match %s in package %s", target, s.typ, s.pkg, first.typ, first.pkg)
+			}
+		}
+
+		for _, abi := range []obj.ABI{obj.ABIInternal, obj.ABI0} {
+			def := ldr.Lookup(target, sym.ABIToVersion(abi))
+			if def == 0 || ldr.SymType(def) != sym.STEXT || ldr.IsFromAssembly(def) {
+				continue
+			}
+			fi := ldr.FuncInfo(def)
+			if !fi.Valid() {
+				continue
+			}
+			for _, s := range list {
+				if n, ok := s.argSize(); ok && n != fi.Args() {
+					Errorf(nil, "%s: signature %s in package %s has %d bytes of arguments, but the definition in package %s has %d", target, s.typ, s.pkg, n, ldr.SymPkg(def), fi.Args())
+				}
+			}
+			break
+		}
+	}
+}
@@ -884,6 +884,9 @@ func collectmachosyms(ctxt *Link) {
 	// Add text symbols.
 	for _, s := range ctxt.Textp {
 		addsym(s)
+		for _, f := range ctxt.foldedFuncs[s] {
+			addsym(f)
+		}
 	}
 
 	shouldBeInSymbolTable := func(s loader.Sym) bool {
@@ -10,6 +10,7 @@ import (
 	"debug/macho"
 	"encoding/binary"
 	"fmt"
+	"internal/zstd"
 	"io"
 	"os"
 	"reflect"
@@ -243,7 +244,7 @@ func machoCombineDwarf(ctxt *Link, exef *os.File, exem *macho.File, dsym, outexe
 // returning the updated sections and segment contents, nils if the sections
 // weren't compressed, or an error if there was a problem reading dwarfm.
 func machoCompressSections(ctxt *Link, dwarfm *macho.File) ([]*macho.Section, []byte, error) {
-	if !ctxt.compressDWARF {
+	if ctxt.compressDWARF == DWARFCompressNone {
 		return nil, nil, nil
 	}
 
@@ -268,7 +269,7 @@ func machoCompressSections(ctxt *Link, dwarfm *macho.File) ([]*macho.Section, []
 			return nil, nil, err
 		}
 
-		compressed, contents, err := machoCompressSection(data)
+		compressed, contents, err := machoCompressSection(data, ctxt.compressDWARF)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -286,15 +287,26 @@ func machoCompressSections(ctxt *Link, dwarfm *macho.File) ([]*macho.Section, []
 	return sects, buf.Bytes(), nil
 }
 
-// machoCompressSection compresses secBytes if it results in less data.
-func machoCompressSection(sectBytes []byte) (compressed bool, contents []byte, err error) {
+// machoCompressSection compresses secBytes using method if it
+// results in less data.
+func machoCompressSection(sectBytes []byte, method DWARFCompression) (compressed bool, contents []byte, err error) {
+	magic := "ZLIB"
+	if method == DWARFCompressZstd {
+		magic = "ZSTD"
+	}
 	var buf bytes.Buffer
-	buf.WriteString("ZLIB")
+	buf.WriteString(magic)
 	var sizeBytes [8]byte
 	binary.BigEndian.PutUint64(sizeBytes[:], uint64(len(sectBytes)))
 	buf.Write(sizeBytes[:])
 
-	z := zlib.NewWriter(&buf)
+	var z io.WriteCloser
+	if method == DWARFCompressZstd {
+		z = zstd.NewWriter(&buf)
+	} else {
+		z = zlib.NewWriter(&buf)
+	}
+
 	if _, err := z.Write(sectBytes); err != nil {
 		return false, nil, err
 	}
@@ -63,18 +63,27 @@ var (
 
 	flagInstallSuffix = flag.String("installsuffix", "", "set package directory `suffix`")
 	flagDumpDep       = flag.Bool("dumpdep", false, "dump symbol dependency graph")
+	flagWhy           = flag.String("why", "", "print why symbols matching `regexp` are reachable")
 	flagRace          = flag.Bool("race", false, "enable race detector")
 	flagMsan          = flag.Bool("msan", false, "enable MSan interface")
+	flagAsan          = flag.Bool("asan", false, "enable ASan interface")
 	flagAslr          = flag.Bool("aslr", true, "enable ASLR for buildmode=c-shared on windows")
 
-	flagFieldTrack = flag.String("k", "", "set field tracking `symbol`")
-	flagLibGCC     = flag.String("libgcc", "", "compiler support lib for internal linking; use \"none\" to disable")
-	flagTmpdir     = flag.String("tmpdir", "", "use `directory` for temporary files")
+	flagFieldTrack     = flag.String("k", "", "set field tracking `symbol`")
+	flagFieldTrackJSON = flag.String("fieldtrack", "", "write the uses of tracked struct fields to `file` as JSON")
+	flagLibGCC         = flag.String("libgcc", "", "compiler support lib for internal linking; use \"none\" to disable")
+	flagTmpdir         = flag.String("tmpdir", "", "use `directory` for temporary files")
 
 	flagExtld      = flag.String("extld", "", "use `linker` when linking in external mode")
 	flagExtldflags = flag.String("extldflags", "", "pass `flags` to external linker")
 	flagExtar      = flag.String("extar", "", "archive program for buildmode=c-archive")
 
+	flagCallGraphOrder   = flag.Bool("callgraphorder", false, "place functions next to their callers")
+	flagCallGraphProfile = flag.String("callgraphprofile", "", "order functions using the calls in pprof profile `file`")
+	flagPruneMethods     = flag.Bool("prunemethods", false, "remove methods only looked up by reflection under other names")
+	flagICF              = flag.Bool("icf", false, "fold functions with identical code")
+	flagShareStrings     = flag.Bool("sharestrings", false, "store string data that is a suffix of other string data inside it")
+
 	flagA             = flag.Bool("a", false, "no-op (deprecated)")
 	FlagC             = flag.Bool("c", false, "dump call graph")
 	FlagD             = flag.Bool("d", false, "disable dynamic executable")
@@ -128,7 +137,7 @@ func Main(arch *sys.Arch, theArch Arch) {
 	flag.BoolVar(&ctxt.linkShared, "linkshared", false, "link against installed Go shared libraries")
 	flag.Var(&ctxt.LinkMode, "linkmode", "set link `mode`")
 	flag.Var(&ctxt.BuildMode, "buildmode", "set build `mode`")
-	flag.BoolVar(&ctxt.compressDWARF, "compressdwarf", true, "compress DWARF if possible")
+	flag.Var(&ctxt.compressDWARF, "compressdwarf", "compress DWARF if possible, using `method` zlib or zstd")
 	objabi.Flagfn1("B", "add an ELF NT_GNU_BUILD_ID `note` when using ELF", addbuildinfo)
 	objabi.Flagfn1("L", "add specified `directory` to library path", func(a string) { Lflag(ctxt, a) })
 	objabi.AddVersionFlag() // -V
@@ -243,6 +252,9 @@ func Main(arch *sys.Arch, theArch Arch) {
 	bench.Start("loadlib")
 	ctxt.loadlib()
 
+	bench.Start("checkLinknames")
+	ctxt.checkLinknames()
+
 	bench.Start("deadcode")
 	deadcode(ctxt)
 
@@ -251,11 +263,14 @@ func Main(arch *sys.Arch, theArch Arch) {
 
 	bench.Start("dostrdata")
 	ctxt.dostrdata()
-	if objabi.Experiment.FieldTrack {
+	if fieldTracking() {
 		bench.Start("fieldtrack")
 		fieldtrack(ctxt.Arch, ctxt.loader)
 	}
 
+	bench.Start("foldIdenticalCode")
+	ctxt.foldIdenticalCode()
+
 	bench.Start("dwarfGenerateDebugInfo")
 	dwarfGenerateDebugInfo(ctxt)
 
@@ -265,6 +280,9 @@ func Main(arch *sys.Arch, theArch Arch) {
 	bench.Start("dostkcheck")
 	ctxt.dostkcheck()
 
+	bench.Start("orderFuncs")
+	ctxt.orderFuncs()
+
 	bench.Start("mangleTypeSym")
 	ctxt.mangleTypeSym()
 
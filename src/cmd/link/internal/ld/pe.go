@@ -741,6 +741,9 @@ func (f *peFile) writeSymbols(ctxt *Link) {
 	// Add text symbols.
 	for _, s := range ctxt.Textp {
 		addsym(s)
+		for _, f := range ctxt.foldedFuncs[s] {
+			addsym(f)
+		}
 	}
 
 	shouldBeInSymbolTable := func(s loader.Sym) bool {
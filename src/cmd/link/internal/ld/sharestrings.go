@@ -0,0 +1,102 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ld
+
+import (
+	"cmd/link/internal/loader"
+	"cmd/link/internal/sym"
+	"sort"
+	"strings"
+)
+
+// A sharedString is a Go string data symbol that -sharestrings stores
+// as the suffix of a longer one, at offset off in container.
+type sharedString struct {
+	s, container loader.Sym
+	off          int64
+}
+
+// shareStringSuffixes implements -sharestrings: it removes from the
+// data layout each Go string data symbol (go.string.*) whose contents
+// are a suffix of those of a longer one, recording it in
+// ctxt.sharedStrings so that address gives it an address inside the
+// longer one. Identical strings need no such handling: the compiler
+// names string data symbols by their contents, so they are already
+// deduplicated across packages.
+//
+// Go string data is never written and is not NUL-terminated, so
+// sharing storage is not observable to programs that do not use
+// unsafe.
+func (state *dodataState) shareStringSuffixes() {
+	ctxt := state.ctxt
+	if !*flagShareStrings {
+		return
+	}
+	if ctxt.DynlinkingGo() || ctxt.IsAIX() {
+		// Other modules refer to the strings by name, and the AIX
+		// symbol table needs the size of each string.
+		return
+	}
+	ldr := ctxt.loader
+
+	// A string is a suffix of another if its reversal is a prefix of
+	// the reversal of the other. After sorting by reversal, the
+	// strings that have a given string as a suffix follow it.
+	type str struct {
+		s   loader.Sym
+		rev string
+	}
+	var strs []str
+	for _, s := range state.data[sym.SGOSTRING] {
+		data := ldr.Data(s)
+		relocs := ldr.Relocs(s)
+		if len(data) == 0 || int64(len(data)) != ldr.SymSize(s) || relocs.Count() != 0 {
+			continue
+		}
+		rev := make([]byte, len(data))
+		for i, b := range data {
+			rev[len(data)-1-i] = b
+		}
+		strs = append(strs, str{s, string(rev)})
+	}
+	sort.Slice(strs, func(i, j int) bool { return strs[i].rev < strs[j].rev })
+
+	shared := make(map[loader.Sym]bool)
+	container := -1
+	for i := len(strs) - 1; i >= 0; i-- {
+		if container >= 0 && strings.HasPrefix(strs[container].rev, strs[i].rev) {
+			// Keep the string data aligned as the architecture
+			// requires, as symalign does.
+			off := int64(len(strs[container].rev) - len(strs[i].rev))
+			if off%int64(thearch.Minalign) == 0 {
+				ctxt.sharedStrings = append(ctxt.sharedStrings, sharedString{strs[i].s, strs[container].s, off})
+				shared[strs[i].s] = true
+				continue
+			}
+		}
+		container = i
+	}
+	if len(shared) == 0 {
+		return
+	}
+
+	syms := state.data[sym.SGOSTRING][:0]
+	for _, s := range state.data[sym.SGOSTRING] {
+		if !shared[s] {
+			syms = append(syms, s)
+		}
+	}
+	state.data[sym.SGOSTRING] = syms
+}
+
+// addressSharedStrings gives the strings shared by -sharestrings their
+// addresses, once their containers have theirs.
+func (ctxt *Link) addressSharedStrings() {
+	ldr := ctxt.loader
+	for _, ss := range ctxt.sharedStrings {
+		ldr.SetSymSect(ss.s, ldr.SymSect(ss.container))
+		ldr.SetSymValue(ss.s, ldr.SymValue(ss.container)+ss.off)
+	}
+}
@@ -51,6 +51,7 @@ func linknew(arch *sys.Arch) *Link {
 		numelfsym:     1,
 		ErrorReporter: ErrorReporter{ErrorReporter: ler},
 		generatorSyms: make(map[loader.Sym]generatorFunc),
+		compressDWARF: DWARFCompressZlib,
 	}
 
 	if objabi.GOARCH != arch.Name {
@@ -207,6 +207,9 @@ func genelfsym(ctxt *Link, elfbind elf.SymBind) {
 	// Text symbols.
 	for _, s := range ctxt.Textp {
 		putelfsym(ctxt, s, elf.STT_FUNC, elfbind)
+		for _, f := range ctxt.foldedFuncs[s] {
+			putelfsym(ctxt, f, elf.STT_FUNC, elfbind)
+		}
 	}
 
 	// runtime.etext marker symbol.
@@ -321,6 +324,9 @@ func asmbPlan9Sym(ctxt *Link) {
 	// Add text symbols.
 	for _, s := range ctxt.Textp {
 		putplan9sym(ctxt, ldr, s, TextSym)
+		for _, f := range ctxt.foldedFuncs[s] {
+			putplan9sym(ctxt, ldr, f, TextSym)
+		}
 	}
 
 	shouldBeInSymbolTable := func(s loader.Sym) bool {
@@ -425,6 +431,12 @@ func textsectionmap(ctxt *Link) (loader.Sym, uint32) {
 	return t.Sym(), uint32(n)
 }
 
+// covmetaName returns the name of the coverage metadata symbol that
+// the compiler writes for lib when it is built with -covermode.
+func covmetaName(lib *sym.Library) string {
+	return objabi.PathToPrefix(lib.Pkg) + "..covmeta"
+}
+
 func (ctxt *Link) symtab(pcln *pclntab) []sym.SymKind {
 	ldr := ctxt.loader
 
@@ -703,6 +715,28 @@ func (ctxt *Link) symtab(pcln *pclntab) []sym.SymKind {
 	moduledata.AddAddr(ctxt.Arch, itablinkSym)
 	moduledata.AddUint(ctxt.Arch, nitablinks)
 	moduledata.AddUint(ctxt.Arch, nitablinks)
+	// The covmeta slice
+	var covmeta []loader.Sym
+	for _, lib := range ctxt.Library {
+		if s := ldr.Lookup(covmetaName(lib), 0); s != 0 && ldr.AttrReachable(s) {
+			covmeta = append(covmeta, s)
+		}
+	}
+	if len(covmeta) > 0 {
+		covmetaSym := ldr.CreateSymForUpdate("go.link.covmeta", 0)
+		covmetaSym.SetType(sym.SNOPTRDATA)
+		covmetaSym.SetLocal(true)
+		for _, s := range covmeta {
+			covmetaSym.AddAddr(ctxt.Arch, s)
+		}
+		moduledata.AddAddr(ctxt.Arch, covmetaSym.Sym())
+		moduledata.AddUint(ctxt.Arch, uint64(len(covmeta)))
+		moduledata.AddUint(ctxt.Arch, uint64(len(covmeta)))
+	} else {
+		moduledata.AddUint(ctxt.Arch, 0)
+		moduledata.AddUint(ctxt.Arch, 0)
+		moduledata.AddUint(ctxt.Arch, 0)
+	}
 	// The ptab slice
 	if ptab := ldr.Lookup("go.plugin.tabs", 0); ptab != 0 && ldr.AttrReachable(ptab) {
 		ldr.SetAttrLocal(ptab, true)
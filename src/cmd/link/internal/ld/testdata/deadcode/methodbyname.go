@@ -0,0 +1,24 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This example looks up methods by constant name through
+// reflect.Type.MethodByName. With -prunemethods, only the
+// methods with that name need to be live.
+
+package main
+
+import "reflect"
+
+type T int
+
+func (T) Foo() { println("Foo") }
+func (T) Bar() { println("Bar") }
+
+func main() {
+	var t T
+	m, ok := reflect.TypeOf(t).MethodByName("Foo")
+	if ok {
+		m.Func.Call([]reflect.Value{reflect.ValueOf(t)})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This example looks up a method by a name known only at run time.
+// All exported methods need to be live, even with -prunemethods.
+
+package main
+
+import (
+	"os"
+	"reflect"
+)
+
+type T int
+
+func (T) Foo() { println("Foo") }
+func (T) Bar() { println("Bar") }
+
+func main() {
+	var t T
+	if m := reflect.ValueOf(t).MethodByName(os.Args[0]); m.IsValid() {
+		m.Call(nil)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -why reports the chain of references
+// from main.main to a reachable function.
+
+package main
+
+//go:noinline
+func f() { g() }
+
+//go:noinline
+func g() { println("g") }
+
+func main() { f() }
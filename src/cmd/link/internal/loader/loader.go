@@ -252,8 +252,8 @@ type Loader struct {
 
 	relocVariant map[relocId]sym.RelocVariant // stores variant relocs
 
-	// Used to implement field tracking; created during deadcode if
-	// field tracking is enabled. Reachparent[K] contains the index of
+	// Used to implement field tracking and -why; created during deadcode
+	// if either is enabled. Reachparent[K] contains the index of
 	// the symbol that triggered the marking of symbol K as live.
 	Reachparent []Sym
 
@@ -148,6 +148,36 @@ const (
 
 // TODO(brainman): maybe just add ReadAt method to bio.Reader instead of creating peBiobuf
 
+// arm64Addend returns the addend that COFF stores in the immediate
+// field of the ARM64 instruction at the start of b for a relocation of
+// type typ, and clears the field, since the linker ORs the relocated
+// value into it.
+func arm64Addend(b []byte, typ uint16) int64 {
+	ins := binary.LittleEndian.Uint32(b)
+	var add int64
+	switch typ {
+	case IMAGE_REL_ARM64_BRANCH26:
+		add = int64(int32(ins<<6)>>6) << 2
+		ins &^= 0x03ffffff
+	case IMAGE_REL_ARM64_PAGEBASE_REL21:
+		add = int64(int32((ins>>29&3|ins>>3&0x1ffffc)<<11) >> 11)
+		ins &^= 3<<29 | 0x7ffff<<5
+	case IMAGE_REL_ARM64_PAGEOFFSET_12A:
+		add = int64(ins >> 10 & 0xfff)
+		ins &^= 0xfff << 10
+	case IMAGE_REL_ARM64_PAGEOFFSET_12L:
+		// The immediate of a load or store is scaled by its size.
+		shift := ins >> 30
+		if shift == 0 && ins>>20&0x048 == 0x048 { // 128-bit vector load or store
+			shift = 4
+		}
+		add = int64(ins>>10&0xfff) << shift
+		ins &^= 0xfff << 10
+	}
+	binary.LittleEndian.PutUint32(b, ins)
+	return add
+}
+
 // peBiobuf makes bio.Reader look like io.ReaderAt.
 type peBiobuf bio.Reader
 
@@ -349,6 +379,33 @@ func Load(l *loader.Loader, arch *sys.Arch, localSymVersion int, input *bio.Read
 					rType = objabi.R_ADDR
 
 					rAdd = int64(int32(binary.LittleEndian.Uint32(sectdata[rsect][rOff:])))
+
+				case IMAGE_REL_ARM64_ADDR64:
+					rSize = 8
+
+					rType = objabi.R_ADDR
+
+					rAdd = int64(binary.LittleEndian.Uint64(sectdata[rsect][rOff:]))
+
+				case IMAGE_REL_ARM64_REL32:
+					rType = objabi.R_PCREL
+
+					// COFF counts from the start of the field,
+					// R_PCREL from its end.
+					rAdd = int64(int32(binary.LittleEndian.Uint32(sectdata[rsect][rOff:]))) + 4
+
+				case IMAGE_REL_ARM64_BRANCH26:
+					rType = objabi.R_CALLARM64
+
+					rAdd = arm64Addend(sectdata[rsect][rOff:], r.Type)
+
+				case IMAGE_REL_ARM64_PAGEBASE_REL21,
+					IMAGE_REL_ARM64_PAGEOFFSET_12A,
+					IMAGE_REL_ARM64_PAGEOFFSET_12L:
+					// adrp, and the add, ldr or str using its result.
+					rType = objabi.R_ARM64_PCREL
+
+					rAdd = arm64Addend(sectdata[rsect][rOff:], r.Type)
 				}
 			}
 
@@ -0,0 +1,145 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package loadpe
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cmd/internal/bio"
+	"cmd/internal/objabi"
+	"cmd/internal/sys"
+	"cmd/link/internal/loader"
+)
+
+// arm64Object returns a COFF object for ARM64 with a single .text
+// section holding text and relocated against one external symbol
+// according to relocs.
+func arm64Object(t *testing.T, text []byte, relocs []pe.Reloc) []byte {
+	const (
+		fileHeaderSize = 20
+		sectHeaderSize = 40
+		relocSize      = 10
+	)
+	textOff := uint32(fileHeaderSize + sectHeaderSize)
+	relocOff := textOff + uint32(len(text))
+	symOff := relocOff + uint32(len(relocs)*relocSize)
+
+	var buf bytes.Buffer
+	w := func(v interface{}) {
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w(pe.FileHeader{
+		Machine:              pe.IMAGE_FILE_MACHINE_ARM64,
+		NumberOfSections:     1,
+		PointerToSymbolTable: symOff,
+		NumberOfSymbols:      1,
+	})
+	w(pe.SectionHeader32{
+		Name:                 [8]uint8{'.', 't', 'e', 'x', 't'},
+		SizeOfRawData:        uint32(len(text)),
+		PointerToRawData:     textOff,
+		PointerToRelocations: relocOff,
+		NumberOfRelocations:  uint16(len(relocs)),
+		Characteristics:      IMAGE_SCN_CNT_CODE | IMAGE_SCN_MEM_EXECUTE | IMAGE_SCN_MEM_READ,
+	})
+	w(text)
+	for _, r := range relocs {
+		w(r)
+	}
+	w(pe.COFFSymbol{
+		Name:         [8]uint8{'e', 'x', 't'},
+		Type:         IMAGE_SYM_DTYPE_FUNCTION,
+		StorageClass: IMAGE_SYM_CLASS_EXTERNAL,
+	})
+	w(uint32(4)) // empty string table
+	return buf.Bytes()
+}
+
+func TestLoadARM64Relocs(t *testing.T) {
+	ins := []uint32{
+		0x94000002, // bl	.+8
+		0x90000080, // adrp	x0, .+0x10
+		0x91004000, // add	x0, x0, #0x10
+		0xf9400c01, // ldr	x1, [x0, #0x18]
+	}
+	text := make([]byte, 4*len(ins)+8+4)
+	for i, x := range ins {
+		binary.LittleEndian.PutUint32(text[4*i:], x)
+	}
+	binary.LittleEndian.PutUint64(text[16:], 0x20)
+	binary.LittleEndian.PutUint32(text[24:], 4)
+
+	obj := arm64Object(t, text, []pe.Reloc{
+		{VirtualAddress: 0, Type: IMAGE_REL_ARM64_BRANCH26},
+		{VirtualAddress: 4, Type: IMAGE_REL_ARM64_PAGEBASE_REL21},
+		{VirtualAddress: 8, Type: IMAGE_REL_ARM64_PAGEOFFSET_12A},
+		{VirtualAddress: 12, Type: IMAGE_REL_ARM64_PAGEOFFSET_12L},
+		{VirtualAddress: 16, Type: IMAGE_REL_ARM64_ADDR64},
+		{VirtualAddress: 24, Type: IMAGE_REL_ARM64_REL32},
+	})
+	name := filepath.Join(t.TempDir(), "x.o")
+	if err := os.WriteFile(name, obj, 0666); err != nil {
+		t.Fatal(err)
+	}
+	f, err := bio.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	l := loader.NewLoader(0, func(string, int) {}, &loader.ErrorReporter{})
+	const localSymVersion = 1
+	if _, _, err := Load(l, sys.ArchARM64, localSymVersion, f, "x", int64(len(obj)), name); err != nil {
+		t.Fatal(err)
+	}
+	s := l.Lookup("x(.text)", localSymVersion)
+	if s == 0 {
+		t.Fatal("no symbol for .text")
+	}
+
+	want := []struct {
+		off int32
+		typ objabi.RelocType
+		siz uint8
+		add int64
+	}{
+		{0, objabi.R_CALLARM64, 4, 8},
+		{4, objabi.R_ARM64_PCREL, 4, 0x10},
+		{8, objabi.R_ARM64_PCREL, 4, 0x10},
+		{12, objabi.R_ARM64_PCREL, 4, 0x18},
+		{16, objabi.R_ADDR, 8, 0x20},
+		{24, objabi.R_PCREL, 4, 8},
+	}
+	relocs := l.Relocs(s)
+	if relocs.Count() != len(want) {
+		t.Fatalf("got %d relocations, want %d", relocs.Count(), len(want))
+	}
+	for i, w := range want {
+		r := relocs.At(i)
+		if r.Off() != w.off || r.Type() != w.typ || r.Siz() != w.siz || r.Add() != w.add {
+			t.Errorf("relocation %d: got off=%d type=%v siz=%d add=%#x, want off=%d type=%v siz=%d add=%#x",
+				i, r.Off(), r.Type(), r.Siz(), r.Add(), w.off, w.typ, w.siz, w.add)
+		}
+		if got := l.SymName(r.Sym()); got != "ext" {
+			t.Errorf("relocation %d: target %q, want ext", i, got)
+		}
+	}
+
+	// The linker ORs relocated values into the instructions, so the
+	// addends must have been cleared from their immediate fields.
+	data := l.Data(s)
+	for i, want := range []uint32{0x94000000, 0x90000000, 0x91000000, 0xf9400001} {
+		if got := binary.LittleEndian.Uint32(data[4*i:]); got != want {
+			t.Errorf("instruction %d: got %#08x, want %#08x", i, got, want)
+		}
+	}
+}
@@ -63,4 +63,7 @@ type Section struct {
 	Relcount uint32
 	Sym      LoaderSym // symbol for the section, if any
 	Index    uint16    // each section has a unique index, used internally
+	// Compressed is set for an ELF section whose contents start
+	// with a compression header (SHF_COMPRESSED).
+	Compressed bool
 }
@@ -95,6 +95,7 @@ const (
 	SBSS
 	SNOPTRBSS
 	SLIBFUZZER_EXTRA_COUNTER
+	SCOVERAGE_COUNTER
 	STLSBSS
 	SXREF
 	SMACHOSYMSTR
@@ -145,6 +146,7 @@ var AbiSymKindToSymKind = [...]SymKind{
 	SDWARFLINES,
 	SABIALIAS,
 	SLIBFUZZER_EXTRA_COUNTER,
+	SCOVERAGE_COUNTER,
 }
 
 // ReadOnly are the symbol kinds that form read-only sections. In some
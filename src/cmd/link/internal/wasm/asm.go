@@ -19,6 +19,8 @@ const (
 	I64 = 0x7E
 	F32 = 0x7D
 	F64 = 0x7C
+
+	ExternRef = 0x6F
 )
 
 const (
@@ -197,6 +199,21 @@ func asmb2(ctxt *ld.Link, ldr *loader.Loader) {
 		fns[i] = &wasmFunc{Name: name, Type: typ, Code: wfn.Bytes()}
 	}
 
+	// add the exports of the functions marked //go:wasmexport
+	exports := loadWasmExports(ctxt, ldr)
+	if len(exports) > 0 {
+		call := ldr.SymValue(ldr.Lookup("runtime.wasmExportCall", 0))
+		resume := int64(len(hostImports)) + ldr.SymValue(ldr.Lookup("wasm_export_resume", 0))>>16 - funcValueOffset
+		for _, x := range exports {
+			fns = append(fns, &wasmFunc{
+				Name: "wasmexport." + nameRegexp.ReplaceAllString(x.name, "_"),
+				Type: lookupType(x.sig(), &types),
+				Code: x.code(call, resume),
+			})
+		}
+	}
+	externref := usesExternref(exports)
+
 	ctxt.Out.Write([]byte{0x00, 0x61, 0x73, 0x6d}) // magic
 	ctxt.Out.Write([]byte{0x01, 0x00, 0x00, 0x00}) // version
 
@@ -208,10 +225,10 @@ func asmb2(ctxt *ld.Link, ldr *loader.Loader) {
 	writeTypeSec(ctxt, types)
 	writeImportSec(ctxt, hostImports)
 	writeFunctionSec(ctxt, fns)
-	writeTableSec(ctxt, fns)
+	writeTableSec(ctxt, fns, externref)
 	writeMemorySec(ctxt, ldr)
-	writeGlobalSec(ctxt)
-	writeExportSec(ctxt, ldr, len(hostImports))
+	writeGlobalSec(ctxt, externref)
+	writeExportSec(ctxt, ldr, len(hostImports), len(ctxt.Textp), exports, externref)
 	writeElementSec(ctxt, uint64(len(hostImports)), uint64(len(fns)))
 	writeCodeSec(ctxt, fns)
 	writeDataSec(ctxt)
@@ -303,18 +320,30 @@ func writeFunctionSec(ctxt *ld.Link, fns []*wasmFunc) {
 	writeSecSize(ctxt, sizeOffset)
 }
 
-// writeTableSec writes the section that declares tables. Currently there is only a single table
-// that is used by the CallIndirect operation to dynamically call any function.
+// writeTableSec writes the section that declares tables. The first table
+// is used by the CallIndirect operation to dynamically call any function.
 // The contents of the table get initialized by the "element" section.
-func writeTableSec(ctxt *ld.Link, fns []*wasmFunc) {
+// If externref is set, a second table holds the host references passed
+// to functions marked //go:wasmexport.
+func writeTableSec(ctxt *ld.Link, fns []*wasmFunc, externref bool) {
 	sizeOffset := writeSecHeader(ctxt, sectionTable)
 
+	numTables := uint64(1)
+	if externref {
+		numTables++
+	}
 	numElements := uint64(funcValueOffset + len(fns))
-	writeUleb128(ctxt.Out, 1)           // number of tables
+	writeUleb128(ctxt.Out, numTables)   // number of tables
 	ctxt.Out.WriteByte(0x70)            // type: anyfunc
 	ctxt.Out.WriteByte(0x00)            // no max
 	writeUleb128(ctxt.Out, numElements) // min
 
+	if externref {
+		ctxt.Out.WriteByte(ExternRef) // type: externref
+		ctxt.Out.WriteByte(0x00)      // no max
+		writeUleb128(ctxt.Out, 0)     // min
+	}
+
 	writeSecSize(ctxt, sizeOffset)
 }
 
@@ -337,7 +366,9 @@ func writeMemorySec(ctxt *ld.Link, ldr *loader.Loader) {
 }
 
 // writeGlobalSec writes the section that declares global variables.
-func writeGlobalSec(ctxt *ld.Link) {
+// If externref is set, the number of slots of the externref table in use
+// follows the registers.
+func writeGlobalSec(ctxt *ld.Link, externref bool) {
 	sizeOffset := writeSecHeader(ctxt, sectionGlobal)
 
 	globalRegs := []byte{
@@ -350,6 +381,9 @@ func writeGlobalSec(ctxt *ld.Link) {
 		I64, // 6: RET3
 		I32, // 7: PAUSE
 	}
+	if externref {
+		globalRegs = append(globalRegs, I32) // 8: externref slots in use
+	}
 
 	writeUleb128(ctxt.Out, uint64(len(globalRegs))) // number of globals
 
@@ -370,11 +404,18 @@ func writeGlobalSec(ctxt *ld.Link) {
 
 // writeExportSec writes the section that declares exports.
 // Exports can be accessed by the WebAssembly host, usually JavaScript.
-// The wasm_export_* functions and the linear memory get exported.
-func writeExportSec(ctxt *ld.Link, ldr *loader.Loader, lenHostImports int) {
+// The wasm_export_* functions and the linear memory get exported,
+// followed by the functions marked //go:wasmexport, whose WebAssembly
+// functions follow the numTextp functions of the program, and the
+// externref table, if any.
+func writeExportSec(ctxt *ld.Link, ldr *loader.Loader, lenHostImports, numTextp int, exports []*wasmExport, externref bool) {
 	sizeOffset := writeSecHeader(ctxt, sectionExport)
 
-	writeUleb128(ctxt.Out, 4) // number of exports
+	numExports := 4 + len(exports)
+	if externref {
+		numExports++
+	}
+	writeUleb128(ctxt.Out, uint64(numExports)) // number of exports
 
 	for _, name := range []string{"run", "resume", "getsp"} {
 		s := ldr.Lookup("wasm_export_"+name, 0)
@@ -388,6 +429,18 @@ func writeExportSec(ctxt *ld.Link, ldr *loader.Loader, lenHostImports int) {
 	ctxt.Out.WriteByte(0x02)   // mem export
 	writeUleb128(ctxt.Out, 0)  // memidx
 
+	for i, x := range exports {
+		writeName(ctxt.Out, x.name)
+		ctxt.Out.WriteByte(0x00)                                  // func export
+		writeUleb128(ctxt.Out, uint64(lenHostImports+numTextp+i)) // funcidx
+	}
+
+	if externref {
+		writeName(ctxt.Out, "externrefs")
+		ctxt.Out.WriteByte(0x01)               // table export
+		writeUleb128(ctxt.Out, externrefTable) // tableidx
+	}
+
 	writeSecSize(ctxt, sizeOffset)
 }
 
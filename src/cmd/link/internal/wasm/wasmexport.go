@@ -0,0 +1,261 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wasm
+
+import (
+	"bytes"
+	"cmd/internal/objabi"
+	"cmd/link/internal/ld"
+	"cmd/link/internal/loader"
+	"encoding/binary"
+	"io"
+)
+
+// wasmExportSize is the size of a runtime.wasmExport, which the frame
+// of the exported function follows.
+const wasmExportSize = 3 * 8
+
+// The table and global variable that hold the host references passed
+// to exported functions, if there are any externref parameters. The
+// global variable is the number of slots of the table in use.
+const (
+	externrefTable  = 1
+	externrefGlobal = 8
+)
+
+// A wasmExport is a function marked //go:wasmexport, as described by
+// the pkg..wasmexportinfo symbol of its package.
+type wasmExport struct {
+	name    string
+	desc    int64 // address of its runtime.wasmExport
+	params  []wasmExportValue
+	results []wasmExportValue
+}
+
+type wasmExportValue struct {
+	kind byte  // objabi.WasmExport*
+	off  int64 // frame offset
+}
+
+// loadWasmExports returns the functions marked //go:wasmexport in the
+// packages being linked.
+func loadWasmExports(ctxt *ld.Link, ldr *loader.Loader) []*wasmExport {
+	var exports []*wasmExport
+	seen := map[string]bool{"run": true, "resume": true, "getsp": true, "mem": true, "externrefs": true}
+	for _, lib := range ctxt.Library {
+		prefix := objabi.PathToPrefix(lib.Pkg)
+		s := ldr.Lookup(prefix+"..wasmexports", 0)
+		if s == 0 || !ldr.AttrReachable(s) {
+			continue
+		}
+		info := ldr.Data(ldr.Lookup(prefix+"..wasmexportinfo", 0))
+		r := bytes.NewReader(info)
+		uvarint := func() uint64 {
+			v, err := binary.ReadUvarint(r)
+			if err != nil {
+				ld.Exitf("%s: malformed //go:wasmexport information", lib.Pkg)
+			}
+			return v
+		}
+		values := func() []wasmExportValue {
+			vs := make([]wasmExportValue, uvarint())
+			for i := range vs {
+				kind, err := r.ReadByte()
+				if err != nil {
+					ld.Exitf("%s: malformed //go:wasmexport information", lib.Pkg)
+				}
+				vs[i] = wasmExportValue{kind, int64(uvarint())}
+			}
+			return vs
+		}
+		for r.Len() > 0 {
+			name := make([]byte, uvarint())
+			if _, err := io.ReadFull(r, name); err != nil {
+				ld.Exitf("%s: malformed //go:wasmexport information", lib.Pkg)
+			}
+			x := &wasmExport{
+				name:    string(name),
+				desc:    ldr.SymValue(s) + int64(uvarint()),
+				params:  values(),
+				results: values(),
+			}
+			if seen[x.name] {
+				ld.Exitf("%s: duplicate or reserved //go:wasmexport name %q", lib.Pkg, x.name)
+			}
+			seen[x.name] = true
+			exports = append(exports, x)
+		}
+	}
+	return exports
+}
+
+// usesExternref reports whether any of exports has an externref
+// parameter.
+func usesExternref(exports []*wasmExport) bool {
+	for _, x := range exports {
+		for _, p := range x.params {
+			if p.kind == objabi.WasmExportExternref {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sig returns the WebAssembly signature of x.
+func (x *wasmExport) sig() *wasmFuncType {
+	valueType := func(kind byte) byte {
+		switch kind {
+		case objabi.WasmExportI64:
+			return I64
+		case objabi.WasmExportF32:
+			return F32
+		case objabi.WasmExportF64:
+			return F64
+		case objabi.WasmExportExternref:
+			return ExternRef
+		}
+		return I32
+	}
+	sig := &wasmFuncType{Params: []byte{}, Results: []byte{}}
+	for _, p := range x.params {
+		sig.Params = append(sig.Params, valueType(p.kind))
+	}
+	for _, r := range x.results {
+		sig.Results = append(sig.Results, valueType(r.kind))
+	}
+	return sig
+}
+
+// code returns the body of the WebAssembly function exporting x. It
+// stores the arguments in the frame of x, points
+// runtime.wasmExportCall at x and calls resume, which runs the Go
+// function on a new event like a call from JavaScript, and then loads
+// the result from the frame.
+//
+// An externref argument is stored in the next free slot of the
+// externref table, and its index is passed to Go. The slot is
+// cleared again before the function returns.
+func (x *wasmExport) code(call, resume int64) []byte {
+	w := new(bytes.Buffer)
+	frame := x.desc + wasmExportSize
+
+	nrefs := 0
+	for _, p := range x.params {
+		if p.kind == objabi.WasmExportExternref {
+			nrefs++
+		}
+	}
+	ref := uint64(len(x.params)) // local holding a slot index
+	if nrefs > 0 {
+		writeUleb128(w, 1) // number of sets of locals
+		writeUleb128(w, 1)
+		w.WriteByte(I32)
+	} else {
+		writeUleb128(w, 0)
+	}
+
+	for i, p := range x.params {
+		if p.kind == objabi.WasmExportExternref {
+			// Take the next slot, growing the table if needed.
+			w.WriteByte(0x23) // global.get
+			writeUleb128(w, externrefGlobal)
+			w.WriteByte(0x22) // local.tee
+			writeUleb128(w, ref)
+			writeI32Const(w, 1)
+			w.WriteByte(0x6a) // i32.add
+			w.WriteByte(0x24) // global.set
+			writeUleb128(w, externrefGlobal)
+			w.Write([]byte{0xfc, 16}) // table.size
+			writeUleb128(w, externrefTable)
+			w.WriteByte(0x20) // local.get
+			writeUleb128(w, ref)
+			w.WriteByte(0x4d)                // i32.le_u
+			w.Write([]byte{4, 64})           // if
+			w.Write([]byte{0xd0, ExternRef}) // ref.null
+			writeI32Const(w, 1)
+			w.Write([]byte{0xfc, 15}) // table.grow
+			writeUleb128(w, externrefTable)
+			w.WriteByte(0x1a) // drop
+			w.WriteByte(0x0b) // end
+			w.WriteByte(0x20) // local.get
+			writeUleb128(w, ref)
+			w.WriteByte(0x20) // local.get
+			writeUleb128(w, uint64(i))
+			w.WriteByte(0x26) // table.set
+			writeUleb128(w, externrefTable)
+
+			writeI32Const(w, int32(frame+p.off))
+			w.WriteByte(0x20) // local.get
+			writeUleb128(w, ref)
+			w.Write([]byte{0x36, 2, 0}) // i32.store
+			continue
+		}
+
+		writeI32Const(w, int32(frame+p.off))
+		w.WriteByte(0x20) // local.get
+		writeUleb128(w, uint64(i))
+		switch p.kind {
+		case objabi.WasmExportI32:
+			w.Write([]byte{0x36, 2, 0}) // i32.store
+		case objabi.WasmExportI64:
+			w.Write([]byte{0x37, 3, 0}) // i64.store
+		case objabi.WasmExportF32:
+			w.Write([]byte{0x38, 2, 0}) // f32.store
+		case objabi.WasmExportF64:
+			w.Write([]byte{0x39, 3, 0}) // f64.store
+		case objabi.WasmExportBool:
+			w.Write([]byte{0x3a, 0, 0}) // i32.store8
+		case objabi.WasmExportPtr:
+			w.WriteByte(0xad)           // i64.extend_i32_u
+			w.Write([]byte{0x37, 3, 0}) // i64.store
+		}
+	}
+
+	writeI32Const(w, int32(call))
+	writeI64Const(w, x.desc)
+	w.Write([]byte{0x37, 3, 0}) // i64.store
+	w.WriteByte(0x10)           // call
+	writeUleb128(w, uint64(resume))
+
+	for _, r := range x.results {
+		writeI32Const(w, int32(frame+r.off))
+		switch r.kind {
+		case objabi.WasmExportI32:
+			w.Write([]byte{0x28, 2, 0}) // i32.load
+		case objabi.WasmExportI64:
+			w.Write([]byte{0x29, 3, 0}) // i64.load
+		case objabi.WasmExportF32:
+			w.Write([]byte{0x2a, 2, 0}) // f32.load
+		case objabi.WasmExportF64:
+			w.Write([]byte{0x2b, 3, 0}) // f64.load
+		case objabi.WasmExportBool:
+			w.Write([]byte{0x2d, 0, 0}) // i32.load8_u
+		case objabi.WasmExportPtr:
+			w.Write([]byte{0x29, 3, 0}) // i64.load
+			w.WriteByte(0xa7)           // i32.wrap_i64
+		}
+	}
+
+	// Release the slots of the externref arguments, in reverse order.
+	for i := 0; i < nrefs; i++ {
+		w.WriteByte(0x23) // global.get
+		writeUleb128(w, externrefGlobal)
+		writeI32Const(w, 1)
+		w.WriteByte(0x6b) // i32.sub
+		w.WriteByte(0x22) // local.tee
+		writeUleb128(w, ref)
+		w.WriteByte(0x24) // global.set
+		writeUleb128(w, externrefGlobal)
+		w.WriteByte(0x20) // local.get
+		writeUleb128(w, ref)
+		w.Write([]byte{0xd0, ExternRef}) // ref.null
+		w.WriteByte(0x26)                // table.set
+		writeUleb128(w, externrefTable)
+	}
+
+	w.WriteByte(0x0b) // end
+	return w.Bytes()
+}
@@ -9,6 +9,8 @@ import (
 	"bytes"
 	"cmd/internal/sys"
 	"debug/macho"
+	"encoding/json"
+	"internal/profile"
 	"internal/testenv"
 	"io/ioutil"
 	"os"
@@ -944,3 +946,391 @@ func TestLargeReloc(t *testing.T) {
 		}
 	}
 }
+
+func TestLinknameSignature(t *testing.T) {
+	// Test that the linker reports functions linked to each other
+	// with //go:linkname whose arguments are laid out differently.
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+
+	write := func(name, content string) {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(tmpdir, name)), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(tmpdir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("go.mod", "module testlinkname\n")
+	write("main.go", `package main
+
+import (
+	_ "unsafe"
+
+	"testlinkname/p"
+)
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+//go:linkname f testlinkname/p.f
+func f(a, b, c int32)
+
+func main() {
+	p.G()
+	f(1, 2, 3)
+	println(nanotime())
+}
+`)
+	write("p/p.go", `package p
+
+import _ "unsafe"
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() (int32, int32)
+
+func f(x int64) {}
+
+func G() {
+	hi, lo := nanotime()
+	println(hi, lo)
+}
+`)
+	cmd := exec.Command(testenv.GoToolPath(t), "build")
+	cmd.Dir = tmpdir
+	cmd.Env = append(os.Environ(), "GOPATH="+filepath.Join(tmpdir, "_gopath"))
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected build to fail, but it succeeded")
+	}
+	for _, want := range []string{
+		"runtime.nanotime: signature func() ",
+		"testlinkname/p.f: signature func(int32, int32, int32) in package main has 16 bytes of arguments, but the definition in package testlinkname/p has 8",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+const testCallGraphOrderSrc = `
+package main
+
+var sink int
+
+func main() {
+	for i := 0; i < 100; i++ {
+		hot(i)
+	}
+	cold()
+	indirect(a)
+}
+
+//go:noinline
+func cold() { sink++ }
+
+//go:noinline
+func a() { sink += 2 }
+
+//go:noinline
+func hot(i int) { sink += i }
+
+//go:noinline
+func indirect(f func()) { f() }
+`
+
+func TestCallGraphOrder(t *testing.T) {
+	// Test that -callgraphorder places a function called in a loop
+	// right after its caller, and that -callgraphprofile does the same
+	// for a call that is only seen in the profile.
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "main.go")
+	if err := ioutil.WriteFile(src, []byte(testCallGraphOrderSrc), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	fn := []*profile.Function{
+		{ID: 1, Name: "main.a"},
+		{ID: 2, Name: "main.indirect"},
+	}
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "samples", Unit: "count"}},
+		Function:   fn,
+		Location: []*profile.Location{
+			{ID: 1, Address: 1, Line: []profile.Line{{Function: fn[0]}}},
+			{ID: 2, Address: 2, Line: []profile.Line{{Function: fn[1]}}},
+		},
+	}
+	p.Sample = []*profile.Sample{{Location: p.Location, Value: []int64{10}}}
+	prof := filepath.Join(tmpdir, "cpu.pprof")
+	f, err := os.Create(prof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Write(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// follows reports whether callee immediately follows caller in
+	// the text section of the binary built with ldflags.
+	follows := func(ldflags, caller, callee string) bool {
+		exe := filepath.Join(tmpdir, "main.exe")
+		cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags="+ldflags, "-o", exe, src)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("build failed: %v\n%s", err, out)
+		}
+		out, err := exec.Command(testenv.GoToolPath(t), "tool", "nm", "-n", exe).CombinedOutput()
+		if err != nil {
+			t.Fatalf("nm failed: %v\n%s", err, out)
+		}
+		var funcs []string
+		for _, line := range strings.Split(string(out), "\n") {
+			f := strings.Fields(line)
+			if len(f) == 3 && (f[1] == "T" || f[1] == "t") {
+				funcs = append(funcs, f[2])
+			}
+		}
+		for i := 0; i+1 < len(funcs); i++ {
+			if funcs[i] == caller {
+				return funcs[i+1] == callee
+			}
+		}
+		t.Fatalf("%s not found in nm output:\n%s", caller, out)
+		return false
+	}
+
+	if follows("", "main.main", "main.hot") {
+		t.Errorf("main.hot follows main.main without -callgraphorder; test is ineffective")
+	}
+	if !follows("-callgraphorder", "main.main", "main.hot") {
+		t.Errorf("main.hot does not follow main.main with -callgraphorder")
+	}
+	if !follows("-callgraphprofile="+prof, "main.indirect", "main.a") {
+		t.Errorf("main.a does not follow main.indirect with -callgraphprofile")
+	}
+}
+
+const testICFSrc = `
+package main
+
+import "fmt"
+
+type T1 struct{ a, b int }
+type T2 struct{ a, b int }
+
+//go:noinline
+func (t *T1) Sum() int { return t.a + t.b }
+
+//go:noinline
+func (t *T2) Sum() int { return t.a + t.b }
+
+//go:noinline
+func sum1(t *T1) int { return t.Sum() * 2 }
+
+//go:noinline
+func sum2(t *T2) int { return t.Sum() * 2 }
+
+//go:noinline
+func diff(t *T1) int { return t.a - t.b }
+
+func main() {
+	fmt.Println(sum1(&T1{1, 2}), sum2(&T2{3, 4}), diff(&T1{5, 6}))
+}
+`
+
+func TestICF(t *testing.T) {
+	// Test that -icf folds identical functions, including functions
+	// that only differ in calling identical functions, and that the
+	// folded program still works.
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "main.go")
+	if err := ioutil.WriteFile(src, []byte(testICFSrc), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	// addrs returns the address of each function in the binary
+	// built with ldflags.
+	addrs := func(ldflags string) map[string]string {
+		exe := filepath.Join(tmpdir, "main.exe")
+		cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags="+ldflags, "-o", exe, src)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("build failed: %v\n%s", err, out)
+		}
+		out, err := exec.Command(exe).CombinedOutput()
+		if err != nil {
+			t.Fatalf("%s failed: %v\n%s", exe, err, out)
+		}
+		if got, want := string(out), "6 14 -1\n"; got != want {
+			t.Errorf("%s printed %q, want %q", exe, got, want)
+		}
+		out, err = exec.Command(testenv.GoToolPath(t), "tool", "nm", exe).CombinedOutput()
+		if err != nil {
+			t.Fatalf("nm failed: %v\n%s", err, out)
+		}
+		m := make(map[string]string)
+		for _, line := range strings.Split(string(out), "\n") {
+			f := strings.Fields(line)
+			if len(f) == 3 && (f[1] == "T" || f[1] == "t") {
+				m[f[2]] = f[0]
+			}
+		}
+		return m
+	}
+
+	pairs := [][2]string{
+		{"main.(*T1).Sum", "main.(*T2).Sum"},
+		{"main.sum1", "main.sum2"},
+	}
+	m := addrs("")
+	for _, p := range pairs {
+		if m[p[0]] == m[p[1]] {
+			t.Errorf("%s and %s have the same address without -icf", p[0], p[1])
+		}
+	}
+	m = addrs("-icf")
+	for _, p := range pairs {
+		if m[p[0]] == "" || m[p[0]] != m[p[1]] {
+			t.Errorf("%s and %s have addresses %q and %q with -icf, want the same", p[0], p[1], m[p[0]], m[p[1]])
+		}
+	}
+	if m["main.diff"] == m["main.sum1"] || m["main.diff"] == m["main.(*T1).Sum"] {
+		t.Errorf("main.diff folded with -icf")
+	}
+}
+
+const testShareStringsSrc = `
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+var long = "hi, gopher"
+var short = "gopher"
+
+func data(s *string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(s)).Data
+}
+
+func main() {
+	fmt.Println(long, short, data(&short)-data(&long))
+}
+`
+
+func TestShareStrings(t *testing.T) {
+	// Test that -sharestrings stores a string that is a suffix of
+	// another string inside it.
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "main.go")
+	if err := ioutil.WriteFile(src, []byte(testShareStringsSrc), 0666); err != nil {
+		t.Fatal(err)
+	}
+	exe := filepath.Join(tmpdir, "main.exe")
+	cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags=-sharestrings", "-o", exe, src)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("build failed: %v\n%s", err, out)
+	}
+	out, err := exec.Command(exe).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s failed: %v\n%s", exe, err, out)
+	}
+	if got, want := string(out), "hi, gopher gopher 4\n"; got != want {
+		t.Errorf("%s printed %q, want %q", exe, got, want)
+	}
+}
+
+const testFieldTrackSrc = `
+package main
+
+type T struct {
+	Read   int ` + "`go:\"track\"`" + `
+	Unread int ` + "`go:\"track\"`" + `
+	Never  int ` + "`go:\"track\"`" + `
+	Plain  int
+}
+
+var sink int
+
+//go:noinline
+func get(t *T) int { return t.Read + t.Plain }
+
+func unused(t *T) int { return t.Unread }
+
+func main() {
+	sink = get(&T{Plain: 1})
+}
+`
+
+func TestFieldTrackJSON(t *testing.T) {
+	// Test that -fieldtrack reports the tracked fields that are
+	// used by reachable code, and those that are not.
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "main.go")
+	if err := ioutil.WriteFile(src, []byte(testFieldTrackSrc), 0666); err != nil {
+		t.Fatal(err)
+	}
+	exe := filepath.Join(tmpdir, "main.exe")
+	out := filepath.Join(tmpdir, "fieldtrack.json")
+	cmd := exec.Command(testenv.GoToolPath(t), "build", "-gcflags=-fieldtrack", "-ldflags=-fieldtrack="+out, "-o", exe, src)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("build failed: %v\n%s", err, out)
+	}
+	data, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type field struct {
+		Field string
+		Used  bool
+		Users []string
+		Chain []string
+	}
+	var got []field
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var f field
+		if err := dec.Decode(&f); err != nil {
+			t.Fatalf("bad output: %v\n%s", err, data)
+		}
+		got = append(got, f)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d tracked fields, want 3:\n%s", len(got), data)
+	}
+	for i, want := range []struct {
+		field string
+		used  bool
+	}{
+		{"main.T.Never", false},
+		{"main.T.Read", true},
+		{"main.T.Unread", false},
+	} {
+		if f := got[i]; f.Field != want.field || f.Used != want.used {
+			t.Errorf("field %d is %s, used %v; want %s, used %v", i, f.Field, f.Used, want.field, want.used)
+		}
+	}
+	if r := got[1]; len(r.Users) != 1 || r.Users[0] != "main.get" || len(r.Chain) == 0 || r.Chain[0] != "main.get" {
+		t.Errorf("main.T.Read has users %v and chain %v; want users [main.get] and a chain starting at main.get", r.Users, r.Chain)
+	}
+}
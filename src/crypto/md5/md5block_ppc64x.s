@@ -84,7 +84,9 @@
 	ADD	b, a;
 
 
-TEXT ·block(SB),NOSPLIT,$0-32
+// M08 uses R29, the frame pointer, so block has a frame in which
+// the prologue saves it.
+TEXT ·block(SB),NOSPLIT,$8-32
 	MOVD	dig+0(FP), R10
 	MOVD	p+8(FP), R6
 	MOVD	p_len+16(FP), R5
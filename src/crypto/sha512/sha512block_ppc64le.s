@@ -68,7 +68,7 @@
 #define HEX30	R26
 #define HEX40	R27
 #define HEX50	R28
-#define HEX60	R29
+#define HEX60	R24
 #define HEX70	R31
 
 // V0-V7 are A-H
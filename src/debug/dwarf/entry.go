@@ -851,6 +851,15 @@ func (r *Reader) Seek(off Offset) {
 	}
 	u := &d.unit[i]
 	r.unit = i
+	if r.cu == nil && u.vers >= 5 && off != u.off {
+		// Attributes such as DW_FORM_strx are relative to
+		// bases given in the unit's entry, so read it first.
+		b := makeBuf(r.d, u, "info", u.off, u.data)
+		if e := b.entry(nil, u.atable, u.base, u.vers); b.err == nil && e != nil &&
+			(e.Tag == TagCompileUnit || e.Tag == TagPartialUnit) {
+			r.cu = e
+		}
+	}
 	r.b = makeBuf(r.d, u, "info", off, u.data[off-u.off:])
 }
 
@@ -726,6 +726,7 @@ type CompressionType int
 
 const (
 	COMPRESS_ZLIB   CompressionType = 1          /* ZLIB compression. */
+	COMPRESS_ZSTD   CompressionType = 2          /* Zstandard compression. */
 	COMPRESS_LOOS   CompressionType = 0x60000000 /* First OS-specific. */
 	COMPRESS_HIOS   CompressionType = 0x6fffffff /* Last OS-specific. */
 	COMPRESS_LOPROC CompressionType = 0x70000000 /* First processor-specific type. */
@@ -733,7 +734,8 @@ const (
 )
 
 var compressionStrings = []intName{
-	{0, "COMPRESS_ZLIB"},
+	{1, "COMPRESS_ZLIB"},
+	{2, "COMPRESS_ZSTD"},
 	{0x60000000, "COMPRESS_LOOS"},
 	{0x6fffffff, "COMPRESS_HIOS"},
 	{0x70000000, "COMPRESS_LOPROC"},
@@ -2428,6 +2430,7 @@ const (
 	R_PPC64_REL24_NOTOC        R_PPC64 = 116
 	R_PPC64_ADDR64_LOCAL       R_PPC64 = 117
 	R_PPC64_ENTRY              R_PPC64 = 118
+	R_PPC64_PCREL34            R_PPC64 = 132
 	R_PPC64_REL16DX_HA         R_PPC64 = 246 // R_POWERPC_REL16DX_HA
 	R_PPC64_JMP_IREL           R_PPC64 = 247
 	R_PPC64_IRELATIVE          R_PPC64 = 248 // R_POWERPC_IRELATIVE
@@ -2536,6 +2539,7 @@ var rppc64Strings = []intName{
 	{116, "R_PPC64_REL24_NOTOC"},
 	{117, "R_PPC64_ADDR64_LOCAL"},
 	{118, "R_PPC64_ENTRY"},
+	{132, "R_PPC64_PCREL34"},
 	{246, "R_PPC64_REL16DX_HA"},
 	{247, "R_PPC64_JMP_IREL"},
 	{248, "R_PPC64_IRELATIVE"},
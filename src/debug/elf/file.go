@@ -12,6 +12,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"internal/zstd"
 	"io"
 	"os"
 	"strings"
@@ -123,7 +124,8 @@ func (s *Section) Open() io.ReadSeeker {
 	if s.Flags&SHF_COMPRESSED == 0 {
 		return io.NewSectionReader(s.sr, 0, 1<<63-1)
 	}
-	if s.compressionType == COMPRESS_ZLIB {
+	switch s.compressionType {
+	case COMPRESS_ZLIB:
 		return &readSeekerFromReader{
 			reset: func() (io.Reader, error) {
 				fr := io.NewSectionReader(s.sr, s.compressionOffset, int64(s.FileSize)-s.compressionOffset)
@@ -131,6 +133,14 @@ func (s *Section) Open() io.ReadSeeker {
 			},
 			size: int64(s.Size),
 		}
+	case COMPRESS_ZSTD:
+		return &readSeekerFromReader{
+			reset: func() (io.Reader, error) {
+				fr := io.NewSectionReader(s.sr, s.compressionOffset, int64(s.FileSize)-s.compressionOffset)
+				return zstd.NewReader(fr), nil
+			},
+			size: int64(s.Size),
+		}
 	}
 	err := &FormatError{int64(s.Offset), "unknown compression type", s.compressionType}
 	return errorReader{err}
@@ -11,6 +11,7 @@ import (
 	"debug/dwarf"
 	"encoding/binary"
 	"fmt"
+	"internal/zstd"
 	"io"
 	"os"
 	"strings"
@@ -244,6 +245,15 @@ func (f *File) DWARF() (*dwarf.Data, error) {
 			}
 			b = dbuf
 		}
+		if len(b) >= 12 && string(b[:4]) == "ZSTD" {
+			dlen := binary.BigEndian.Uint64(b[4:12])
+			dbuf := make([]byte, dlen)
+			r := zstd.NewReader(bytes.NewReader(b[12:]))
+			if _, err := io.ReadFull(r, dbuf); err != nil {
+				return nil, err
+			}
+			b = dbuf
+		}
 		return b, nil
 	}
 
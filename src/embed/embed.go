@@ -116,6 +116,23 @@
 //
 //	template.ParseFS(content, "*.tmpl")
 //
+// Compression
+//
+// A //go:embedcompress directive next to the //go:embed directives of an FS
+// variable asks for the files to be stored compressed in the binary, which
+// makes binaries with large, compressible assets, such as web content, smaller:
+//
+//	//go:embed static
+//	//go:embedcompress
+//	var static embed.FS
+//
+// The files are read the same way whether or not they are compressed.
+// Each compressed file is decompressed the first time it is opened or read,
+// and its contents are then kept in memory for the rest of the program.
+// Files that compression would not make smaller are stored as they are.
+//
+// The //go:embedcompress directive can only be used with FS variables.
+//
 // Tools
 //
 // To support tools that analyze Go packages, the patterns found in //go:embed lines
@@ -125,9 +142,12 @@
 package embed
 
 import (
+	"compress/flate"
 	"errors"
 	"io"
 	"io/fs"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -217,6 +237,7 @@ type file struct {
 	name string
 	data string
 	hash [16]byte // truncated SHA256 hash
+	size int      // if nonzero, data is compressed with DEFLATE and size is its decompressed size
 }
 
 var (
@@ -225,7 +246,7 @@ var (
 )
 
 func (f *file) Name() string               { _, elem, _ := split(f.name); return elem }
-func (f *file) Size() int64                { return int64(len(f.data)) }
+func (f *file) Size() int64                { return int64(f.len()) }
 func (f *file) ModTime() time.Time         { return time.Time{} }
 func (f *file) IsDir() bool                { _, _, isDir := split(f.name); return isDir }
 func (f *file) Sys() interface{}           { return nil }
@@ -239,6 +260,39 @@ func (f *file) Mode() fs.FileMode {
 	return 0444
 }
 
+// len returns the length of the contents of f.
+func (f *file) len() int {
+	if f.size != 0 {
+		return f.size
+	}
+	return len(f.data)
+}
+
+// decompressed holds the decompressed contents of the compressed files
+// that have been opened, keyed by *file.
+var decompressed sync.Map
+
+// contents returns the contents of f, decompressing them on first use.
+func (f *file) contents() (string, error) {
+	if f.size == 0 {
+		return f.data, nil
+	}
+	if data, ok := decompressed.Load(f); ok {
+		return data.(string), nil
+	}
+	var b strings.Builder
+	b.Grow(f.size)
+	zr := flate.NewReader(strings.NewReader(f.data))
+	if _, err := io.CopyN(&b, zr, int64(f.size)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+	data, _ := decompressed.LoadOrStore(f, b.String())
+	return data.(string), nil
+}
+
 // dotFile is a file for the root directory,
 // which is omitted from the files list in a FS.
 var dotFile = &file{name: "./"}
@@ -300,7 +354,11 @@ func (f FS) Open(name string) (fs.File, error) {
 	if file.IsDir() {
 		return &openDir{file, f.readDir(name), 0}, nil
 	}
-	return &openFile{file, 0}, nil
+	data, err := file.contents()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &openFile{file, data, 0}, nil
 }
 
 // ReadDir reads and returns the entire named directory.
@@ -330,26 +388,27 @@ func (f FS) ReadFile(name string) ([]byte, error) {
 	if !ok {
 		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
 	}
-	return []byte(ofile.f.data), nil
+	return []byte(ofile.data), nil
 }
 
 // An openFile is a regular file open for reading.
 type openFile struct {
-	f      *file // the file itself
-	offset int64 // current read offset
+	f      *file  // the file itself
+	data   string // the contents of the file
+	offset int64  // current read offset
 }
 
 func (f *openFile) Close() error               { return nil }
 func (f *openFile) Stat() (fs.FileInfo, error) { return f.f, nil }
 
 func (f *openFile) Read(b []byte) (int, error) {
-	if f.offset >= int64(len(f.f.data)) {
+	if f.offset >= int64(len(f.data)) {
 		return 0, io.EOF
 	}
 	if f.offset < 0 {
 		return 0, &fs.PathError{Op: "read", Path: f.f.name, Err: fs.ErrInvalid}
 	}
-	n := copy(b, f.f.data[f.offset:])
+	n := copy(b, f.data[f.offset:])
 	f.offset += int64(n)
 	return n, nil
 }
@@ -361,9 +420,9 @@ func (f *openFile) Seek(offset int64, whence int) (int64, error) {
 	case 1:
 		offset += f.offset
 	case 2:
-		offset += int64(len(f.f.data))
+		offset += int64(len(f.data))
 	}
-	if offset < 0 || offset > int64(len(f.f.data)) {
+	if offset < 0 || offset > int64(len(f.data)) {
 		return 0, &fs.PathError{Op: "seek", Path: f.f.name, Err: fs.ErrInvalid}
 	}
 	f.offset = offset
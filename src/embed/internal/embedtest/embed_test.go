@@ -6,7 +6,9 @@ package embedtest
 
 import (
 	"embed"
+	"io/fs"
 	"reflect"
+	"sync"
 	"testing"
 	"testing/fstest"
 )
@@ -113,6 +115,55 @@ func TestHidden(t *testing.T) {
 		"fortune.txt", "more/") // but not .more or _more
 }
 
+//go:embed testdata
+//go:embedcompress
+var testDirCompressed embed.FS
+
+func TestCompressed(t *testing.T) {
+	all := testDirCompressed
+	testDir(t, all, "testdata/i", "i18n.txt", "j/")
+	if err := fstest.TestFS(all, "testdata/ascii.txt", "testdata/hello.txt", "testdata/i/j/k/k8s.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := fs.WalkDir(testDirAll, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		want, err := testDirAll.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		testFiles(t, all, name, string(want))
+		info, err := fs.Stat(all, name)
+		if err != nil {
+			return err
+		}
+		if info.Size() != int64(len(want)) {
+			t.Errorf("stat %v: size = %d, want %d", name, info.Size(), len(want))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first reads of a file decompress it concurrently.
+	want, err := testDirAll.ReadFile("testdata/ascii.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			testFiles(t, testDirCompressed, "testdata/ascii.txt", string(want))
+		}()
+	}
+	wg.Wait()
+}
+
 func TestUninitialized(t *testing.T) {
 	var uninitialized embed.FS
 	testDir(t, uninitialized, ".")
@@ -104,6 +104,12 @@ var depsRules = `
 	RUNTIME
 	< io;
 
+	RUNTIME
+	< arena;
+
+	RUNTIME
+	< arch;
+
 	syscall !< io;
 	reflect !< sort;
 
@@ -168,9 +174,6 @@ var depsRules = `
 	< os
 	< os/signal;
 
-	io/fs
-	< embed;
-
 	unicode, fmt !< os, os/signal;
 
 	os/signal, STR
@@ -246,6 +249,12 @@ var depsRules = `
 	< compress/bzip2, compress/flate, compress/lzw
 	< archive/zip, compress/gzip, compress/zlib;
 
+	FMT, encoding/binary
+	< internal/zstd;
+
+	compress/flate
+	< embed;
+
 	# templates
 	FMT
 	< text/template/parse;
@@ -268,7 +277,7 @@ var depsRules = `
 	< index/suffixarray;
 
 	# executable parsing
-	FMT, encoding/binary, compress/zlib
+	FMT, encoding/binary, compress/zlib, internal/zstd
 	< debug/dwarf
 	< debug/elf, debug/gosym, debug/macho, debug/pe, debug/plan9obj, internal/xcoff
 	< DEBUG;
@@ -293,7 +302,9 @@ var depsRules = `
 	math/big, go/token
 	< go/constant;
 
-	container/heap, go/constant, go/parser, regexp
+	NONE < go/layout;
+
+	container/heap, go/constant, go/layout, go/parser, regexp
 	< go/types;
 
 	go/build/constraint, go/doc, go/parser, internal/goroot, internal/goversion
@@ -324,7 +335,7 @@ var depsRules = `
 	< C
 	< runtime/cgo
 	< CGO
-	< runtime/race, runtime/msan;
+	< runtime/race, runtime/msan, runtime/asan;
 
 	# Bulk of the standard library must not use cgo.
 	# The prohibition stops at net and os/user.
@@ -85,6 +85,24 @@ func FindPkg(path, srcDir string) (filename, id string) {
 // The packages map must contain all packages already imported.
 //
 func Import(fset *token.FileSet, packages map[string]*types.Package, path, srcDir string, lookup func(path string) (io.ReadCloser, error)) (pkg *types.Package, err error) {
+	return doImport(fset, packages, path, srcDir, lookup, nil)
+}
+
+// A Layout is the memory layout the compiler computed for a defined type.
+type Layout struct {
+	Size    int64 // -1 if the type has no layout (e.g., it is generic)
+	Align   int64
+	Offsets []int64 // field offsets, if the type is a struct type
+}
+
+// ImportLayouts is like Import, but it also records in layouts the
+// layout of each defined type the package declares. It reads the
+// package even if it was imported completely before.
+func ImportLayouts(fset *token.FileSet, packages map[string]*types.Package, path, srcDir string, lookup func(path string) (io.ReadCloser, error), layouts map[*types.TypeName]Layout) (*types.Package, error) {
+	return doImport(fset, packages, path, srcDir, lookup, layouts)
+}
+
+func doImport(fset *token.FileSet, packages map[string]*types.Package, path, srcDir string, lookup func(path string) (io.ReadCloser, error), layouts map[*types.TypeName]Layout) (pkg *types.Package, err error) {
 	var rc io.ReadCloser
 	var id string
 	if lookup != nil {
@@ -96,7 +114,7 @@ func Import(fset *token.FileSet, packages map[string]*types.Package, path, srcDi
 		id = path
 
 		// No need to re-import if the package was imported completely before.
-		if pkg = packages[id]; pkg != nil && pkg.Complete() {
+		if pkg = packages[id]; pkg != nil && pkg.Complete() && layouts == nil {
 			return
 		}
 		f, err := lookup(path)
@@ -115,7 +133,7 @@ func Import(fset *token.FileSet, packages map[string]*types.Package, path, srcDi
 		}
 
 		// no need to re-import if the package was imported completely before
-		if pkg = packages[id]; pkg != nil && pkg.Complete() {
+		if pkg = packages[id]; pkg != nil && pkg.Complete() && layouts == nil {
 			return
 		}
 
@@ -155,7 +173,7 @@ func Import(fset *token.FileSet, packages map[string]*types.Package, path, srcDi
 		// binary export format starts with a 'c', 'd', or 'v'
 		// (from "version"). Select appropriate importer.
 		if len(data) > 0 && data[0] == 'i' {
-			_, pkg, err = iImportData(fset, packages, data[1:], id)
+			_, pkg, err = iImportData(fset, packages, data[1:], id, layouts)
 		} else {
 			err = fmt.Errorf("import %q: old binary export format no longer supported (recompile library)", path)
 		}
@@ -594,6 +594,60 @@ func TestIssue25596(t *testing.T) {
 	compileAndImportPkg(t, "issue25596")
 }
 
+func TestImportLayouts(t *testing.T) {
+	skipSpecialPlatforms(t)
+
+	// This package only handles gc export data.
+	if runtime.Compiler != "gc" {
+		t.Skipf("gc-built packages not available (compiler = %s)", runtime.Compiler)
+	}
+
+	// On windows, we have to set the -D option for the compiler to avoid having a drive
+	// letter and an illegal ':' in the import path - just skip it (see also issue #3483).
+	if runtime.GOOS == "windows" {
+		t.Skip("avoid dealing with relative paths/drive letters on windows")
+	}
+
+	tmpdir := mktmpdir(t)
+	defer os.RemoveAll(tmpdir)
+	compile(t, "testdata", "layout.go", filepath.Join(tmpdir, "testdata"))
+
+	layouts := make(map[*types.TypeName]Layout)
+	pkg, err := ImportLayouts(token.NewFileSet(), make(map[string]*types.Package), "./testdata/layout", tmpdir, nil, layouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sizes := types.SizesFor("gc", runtime.GOARCH)
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		got, ok := layouts[obj]
+		if !ok {
+			t.Errorf("no layout for %s", name)
+			continue
+		}
+		typ := obj.Type()
+		want := Layout{Size: sizes.Sizeof(typ), Align: sizes.Alignof(typ)}
+		if s, ok := typ.Underlying().(*types.Struct); ok && s.NumFields() > 0 {
+			fields := make([]*types.Var, s.NumFields())
+			for i := range fields {
+				fields[i] = s.Field(i)
+			}
+			want.Offsets = sizes.Offsetsof(fields)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("layout of %s = %+v, want %+v", name, got, want)
+		}
+	}
+	if len(layouts) != len(scope.Names()) {
+		t.Errorf("got %d layouts for %d declarations", len(layouts), len(scope.Names()))
+	}
+}
+
 func importPkg(t *testing.T, path, srcDir string) *types.Package {
 	fset := token.NewFileSet()
 	pkg, err := Import(fset, make(map[string]*types.Package), path, srcDir, nil)
@@ -59,9 +59,11 @@ const (
 
 // iImportData imports a package from the serialized package data
 // and returns the number of bytes consumed and a reference to the package.
+// If layouts is not nil, it also records the layouts of the package's
+// defined types in layouts.
 // If the export data version is not recognized or the format is otherwise
 // compromised, an error is returned.
-func iImportData(fset *token.FileSet, imports map[string]*types.Package, data []byte, path string) (_ int, pkg *types.Package, err error) {
+func iImportData(fset *token.FileSet, imports map[string]*types.Package, data []byte, path string, layouts map[*types.TypeName]Layout) (_ int, pkg *types.Package, err error) {
 	const currentVersion = 1
 	version := int64(-1)
 	defer func() {
@@ -166,6 +168,10 @@ func iImportData(fset *token.FileSet, imports map[string]*types.Package, data []
 	// package was imported completely and without errors
 	localpkg.MarkComplete()
 
+	if layouts != nil {
+		p.readLayouts(r, layouts)
+	}
+
 	consumed, _ := r.Seek(0, io.SeekCurrent)
 	return int(consumed), localpkg, nil
 }
@@ -203,6 +209,34 @@ func (p *iimporter) doDecl(pkg *types.Package, name string) {
 	r.obj(name)
 }
 
+// readLayouts reads the layout index, which follows the main index,
+// and records the layouts of the imported defined types in layouts.
+func (p *iimporter) readLayouts(r *intReader, layouts map[*types.TypeName]Layout) {
+	// Skip the inline body index; only the compiler reads it.
+	for nPkgs := r.uint64(); nPkgs > 0; nPkgs-- {
+		r.uint64() // package path
+		for n := r.uint64(); n > 0; n-- {
+			r.uint64() // name
+			r.uint64() // offset
+		}
+	}
+
+	for nPkgs := r.uint64(); nPkgs > 0; nPkgs-- {
+		pkg := p.pkgAt(r.uint64())
+		for n := r.uint64(); n > 0; n-- {
+			name := p.stringAt(r.uint64())
+			off := r.uint64()
+			obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if !ok {
+				continue // not imported
+			}
+			lr := &importReader{p: p, currPkg: pkg}
+			lr.declReader.Reset(p.declData[off:])
+			layouts[obj] = lr.layout()
+		}
+	}
+}
+
 func (p *iimporter) stringAt(off uint64) string {
 	if s, ok := p.stringCache[off]; ok {
 		return s
@@ -581,6 +615,17 @@ func (r *importReader) bool() bool {
 	return r.uint64() != 0
 }
 
+func (r *importReader) layout() Layout {
+	l := Layout{Size: r.int64(), Align: r.int64()}
+	if n := r.uint64(); n > 0 {
+		l.Offsets = make([]int64, n)
+		for i := range l.Offsets {
+			l.Offsets[i] = r.int64()
+		}
+	}
+	return l
+}
+
 func (r *importReader) int64() int64 {
 	n, err := binary.ReadVarint(&r.declReader)
 	if err != nil {
@@ -0,0 +1,33 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+type Padded struct {
+	A int8
+	B int64
+	C int16
+	D [3]byte
+	E string
+}
+
+type Nested struct {
+	P    Padded
+	Q    *Padded
+	r    bool
+	S, T uint32
+}
+
+type Array [5]Nested
+
+type Map map[string]Padded
+
+type Func func(int) error
+
+type Iface interface{ M() }
+
+type (
+	Empty struct{}
+	Int   int
+)
@@ -0,0 +1,179 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package layout computes the sizes, alignments and field offsets of
+// Go types as the gc compiler lays them out in memory.
+//
+// The gc compiler uses this package for its own struct layout, so the
+// answers it gives match the compiler exactly, including rules that go
+// beyond the language specification, such as the padding added after
+// a trailing zero-size field. Package go/types uses it to implement
+// the Sizes returned by types.SizesFor("gc", arch).
+package layout
+
+// An Arch describes the properties of a target architecture that
+// determine the layout of Go types.
+type Arch struct {
+	PtrSize int64 // size in bytes of pointers, int, uint and uintptr
+	RegSize int64 // size in bytes of general purpose registers; the alignment of 64-bit values
+}
+
+var archs = map[string]*Arch{
+	"386":      {PtrSize: 4, RegSize: 4},
+	"amd64":    {PtrSize: 8, RegSize: 8},
+	"arm":      {PtrSize: 4, RegSize: 4},
+	"arm64":    {PtrSize: 8, RegSize: 8},
+	"loong64":  {PtrSize: 8, RegSize: 8},
+	"mips":     {PtrSize: 4, RegSize: 4},
+	"mipsle":   {PtrSize: 4, RegSize: 4},
+	"mips64":   {PtrSize: 8, RegSize: 8},
+	"mips64le": {PtrSize: 8, RegSize: 8},
+	"ppc64":    {PtrSize: 8, RegSize: 8},
+	"ppc64le":  {PtrSize: 8, RegSize: 8},
+	"riscv64":  {PtrSize: 8, RegSize: 8},
+	"s390x":    {PtrSize: 8, RegSize: 8},
+	"wasm":     {PtrSize: 8, RegSize: 8},
+}
+
+// ArchFor returns the Arch for the architecture named by the GOARCH
+// value goarch, or nil if the gc compiler does not support it.
+func ArchFor(goarch string) *Arch {
+	return archs[goarch]
+}
+
+// A Kind identifies a type whose layout depends only on the
+// architecture.
+type Kind int
+
+const (
+	Invalid Kind = iota
+	Bool
+	Int
+	Int8
+	Int16
+	Int32
+	Int64
+	Uint
+	Uint8
+	Uint16
+	Uint32
+	Uint64
+	Uintptr
+	Float32
+	Float64
+	Complex64
+	Complex128
+	String
+	UnsafePointer
+
+	// Pointer covers all types represented by a single pointer:
+	// pointers, channels, maps and functions.
+	Pointer
+	Interface
+	Slice
+)
+
+// Sizeof returns the size in bytes of values of kind k.
+func (a *Arch) Sizeof(k Kind) int64 {
+	switch k {
+	case Bool, Int8, Uint8:
+		return 1
+	case Int16, Uint16:
+		return 2
+	case Int32, Uint32, Float32:
+		return 4
+	case Int64, Uint64, Float64, Complex64:
+		return 8
+	case Complex128:
+		return 16
+	case Int, Uint, Uintptr, UnsafePointer, Pointer:
+		return a.PtrSize
+	case String, Interface:
+		return 2 * a.PtrSize
+	case Slice:
+		return 3 * a.PtrSize
+	}
+	panic("layout: invalid kind")
+}
+
+// Alignof returns the alignment in bytes of values of kind k.
+func (a *Arch) Alignof(k Kind) int64 {
+	switch k {
+	case Int64, Uint64, Float64, Complex128:
+		return a.RegSize
+	case Complex64:
+		return 4
+	case String, Interface, Slice:
+		return a.PtrSize
+	}
+	return a.Sizeof(k)
+}
+
+// Rnd returns o rounded up to a multiple of r, which must be a power
+// of two.
+func Rnd(o, r int64) int64 {
+	return (o + r - 1) &^ (r - 1)
+}
+
+// ArraySize returns the size of an array of n elements of size
+// elemSize. The alignment of an array is that of its elements.
+func ArraySize(elemSize, n int64) int64 {
+	// Sizes are multiples of alignments, so there is no padding
+	// between elements.
+	return elemSize * n
+}
+
+// A Struct computes the layout of a struct type one field at a time.
+// The zero value is a struct with no fields.
+type Struct struct {
+	end      int64 // end offset of the last field
+	align    int64 // largest field alignment
+	lastZero int64 // offset of the last zero-size field
+}
+
+// Field adds a field with the given size and alignment and returns its
+// offset.
+func (s *Struct) Field(size, align int64) int64 {
+	if align < 1 {
+		align = 1
+	}
+	if align > s.align {
+		s.align = align
+	}
+	o := Rnd(s.end, align)
+	if size == 0 {
+		s.lastZero = o
+	}
+	s.end = o + size
+	return o
+}
+
+// End returns the offset just past the last field added, without any
+// padding.
+func (s *Struct) End() int64 {
+	return s.end
+}
+
+// Size returns the size of the struct.
+//
+// A struct of nonzero size that ends in a zero-size field gets an
+// extra byte of padding, so that taking the address of that field
+// cannot produce a pointer to the next object in memory. The size is
+// then rounded up to a multiple of the struct's alignment.
+func (s *Struct) Size() int64 {
+	o := s.end
+	if o > 0 && o == s.lastZero {
+		o++
+	}
+	return Rnd(o, s.Align())
+}
+
+// Align returns the alignment of the struct: the largest alignment of
+// its fields, and at least 1.
+func (s *Struct) Align() int64 {
+	if s.align < 1 {
+		return 1
+	}
+	return s.align
+}
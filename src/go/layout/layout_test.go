@@ -0,0 +1,94 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout_test
+
+import (
+	"go/layout"
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+type field struct {
+	size, align int64
+}
+
+var structTests = []struct {
+	fields  []field
+	offsets []int64
+	size    int64
+	align   int64
+}{
+	{nil, nil, 0, 1},
+	{[]field{{0, 1}}, []int64{0}, 0, 1},
+	{[]field{{1, 1}, {8, 8}}, []int64{0, 8}, 16, 8},
+	{[]field{{8, 8}, {1, 1}}, []int64{0, 8}, 16, 8},
+	{[]field{{4, 4}, {0, 1}}, []int64{0, 4}, 8, 4},
+	{[]field{{1, 1}, {0, 1}}, []int64{0, 1}, 2, 1},
+	{[]field{{0, 1}, {1, 1}}, []int64{0, 0}, 1, 1},
+	{[]field{{2, 2}, {0, 8}, {1, 1}}, []int64{0, 8, 8}, 16, 8},
+}
+
+func TestStruct(t *testing.T) {
+	for i, test := range structTests {
+		var s layout.Struct
+		for j, f := range test.fields {
+			if got := s.Field(f.size, f.align); got != test.offsets[j] {
+				t.Errorf("#%d: offset of field %d = %d, want %d", i, j, got, test.offsets[j])
+			}
+		}
+		if got := s.Size(); got != test.size {
+			t.Errorf("#%d: Size() = %d, want %d", i, got, test.size)
+		}
+		if got := s.Align(); got != test.align {
+			t.Errorf("#%d: Align() = %d, want %d", i, got, test.align)
+		}
+	}
+}
+
+// TestHost checks the layout package against the compiler that built
+// the test.
+func TestHost(t *testing.T) {
+	a := layout.ArchFor(runtime.GOARCH)
+	if a == nil {
+		t.Fatalf("ArchFor(%q) = nil", runtime.GOARCH)
+	}
+
+	for _, test := range []struct {
+		k           layout.Kind
+		size, align uintptr
+	}{
+		{layout.Bool, unsafe.Sizeof(false), unsafe.Alignof(false)},
+		{layout.Int, unsafe.Sizeof(int(0)), unsafe.Alignof(int(0))},
+		{layout.Int64, unsafe.Sizeof(int64(0)), unsafe.Alignof(int64(0))},
+		{layout.Float64, unsafe.Sizeof(float64(0)), unsafe.Alignof(float64(0))},
+		{layout.Complex64, unsafe.Sizeof(complex64(0)), unsafe.Alignof(complex64(0))},
+		{layout.Complex128, unsafe.Sizeof(complex128(0)), unsafe.Alignof(complex128(0))},
+		{layout.String, unsafe.Sizeof(""), unsafe.Alignof("")},
+		{layout.Pointer, unsafe.Sizeof((*int)(nil)), unsafe.Alignof((*int)(nil))},
+		{layout.Interface, unsafe.Sizeof(interface{}(nil)), unsafe.Alignof(interface{}(nil))},
+		{layout.Slice, unsafe.Sizeof([]int(nil)), unsafe.Alignof([]int(nil))},
+	} {
+		if got := a.Sizeof(test.k); got != int64(test.size) {
+			t.Errorf("Sizeof(%d) = %d, want %d", test.k, got, test.size)
+		}
+		if got := a.Alignof(test.k); got != int64(test.align) {
+			t.Errorf("Alignof(%d) = %d, want %d", test.k, got, test.align)
+		}
+	}
+
+	var x struct {
+		a int32
+		b struct{}
+	}
+	var s layout.Struct
+	s.Field(a.Sizeof(layout.Int32), a.Alignof(layout.Int32))
+	if got := s.Field(0, 1); got != int64(unsafe.Offsetof(x.b)) {
+		t.Errorf("offset of b = %d, want %d", got, unsafe.Offsetof(x.b))
+	}
+	if got := s.Size(); got != int64(unsafe.Sizeof(x)) {
+		t.Errorf("size = %d, want %d", got, unsafe.Sizeof(x))
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import "go/layout"
+
+// gcSizes implements Sizes for the gc compiler, using package
+// go/layout to compute the same layout the compiler does. Unlike
+// StdSizes, it includes the padding the compiler adds after a
+// trailing zero-size struct field and at the end of a struct.
+type gcSizes struct {
+	arch *layout.Arch
+}
+
+var basicKinds = [...]layout.Kind{
+	Bool:          layout.Bool,
+	Int:           layout.Int,
+	Int8:          layout.Int8,
+	Int16:         layout.Int16,
+	Int32:         layout.Int32,
+	Int64:         layout.Int64,
+	Uint:          layout.Uint,
+	Uint8:         layout.Uint8,
+	Uint16:        layout.Uint16,
+	Uint32:        layout.Uint32,
+	Uint64:        layout.Uint64,
+	Uintptr:       layout.Uintptr,
+	Float32:       layout.Float32,
+	Float64:       layout.Float64,
+	Complex64:     layout.Complex64,
+	Complex128:    layout.Complex128,
+	String:        layout.String,
+	UnsafePointer: layout.UnsafePointer,
+}
+
+// kind returns the layout kind of T, or layout.Invalid if the layout
+// of T depends on other types.
+func (s *gcSizes) kind(T Type) layout.Kind {
+	switch t := optype(T).(type) {
+	case *Basic:
+		assert(isTyped(T))
+		if int(t.kind) < len(basicKinds) {
+			return basicKinds[t.kind]
+		}
+	case *Pointer, *Chan, *Map, *Signature:
+		return layout.Pointer
+	case *Interface:
+		return layout.Interface
+	case *Slice:
+		return layout.Slice
+	}
+	return layout.Invalid
+}
+
+func (s *gcSizes) Alignof(T Type) int64 {
+	switch t := optype(T).(type) {
+	case *Array:
+		return s.Alignof(t.elem)
+	case *Struct:
+		return s.layout(t).Align()
+	case *_Sum:
+		panic("Alignof unimplemented for type sum")
+	}
+	return s.arch.Alignof(s.kind(T))
+}
+
+func (s *gcSizes) Offsetsof(fields []*Var) []int64 {
+	offsets := make([]int64, len(fields))
+	var l layout.Struct
+	for i, f := range fields {
+		offsets[i] = l.Field(s.Sizeof(f.typ), s.Alignof(f.typ))
+	}
+	return offsets
+}
+
+func (s *gcSizes) Sizeof(T Type) int64 {
+	switch t := optype(T).(type) {
+	case *Array:
+		if t.len <= 0 {
+			return 0
+		}
+		return layout.ArraySize(s.Sizeof(t.elem), t.len)
+	case *Struct:
+		return s.layout(t).Size()
+	case *_Sum:
+		panic("Sizeof unimplemented for type sum")
+	}
+	return s.arch.Sizeof(s.kind(T))
+}
+
+// layout lays out the fields of struct t.
+func (s *gcSizes) layout(t *Struct) *layout.Struct {
+	var l layout.Struct
+	for _, f := range t.fields {
+		l.Field(s.Sizeof(f.typ), s.Alignof(f.typ))
+	}
+	return &l
+}
@@ -6,6 +6,8 @@
 
 package types
 
+import "go/layout"
+
 // Sizes defines the sizing functions for package unsafe.
 type Sizes interface {
 	// Alignof returns the alignment of a variable of type T.
@@ -157,6 +159,9 @@ func (s *StdSizes) Sizeof(T Type) int64 {
 }
 
 // common architecture word sizes and alignments
+//
+// SizesFor("gc", arch) uses these only for architectures that
+// go/layout does not know.
 var gcArchSizes = map[string]*StdSizes{
 	"386":      {4, 4},
 	"arm":      {4, 4},
@@ -181,12 +186,18 @@ var gcArchSizes = map[string]*StdSizes{
 // The result is nil if a compiler/architecture pair is not known.
 //
 // Supported architectures for compiler "gc":
-// "386", "arm", "arm64", "amd64", "amd64p32", "mips", "mipsle",
+// "386", "arm", "arm64", "amd64", "amd64p32", "loong64", "mips", "mipsle",
 // "mips64", "mips64le", "ppc64", "ppc64le", "riscv64", "s390x", "sparc64", "wasm".
+//
+// For the architectures the gc compiler supports, the result computes
+// exactly the layout the compiler uses; see package go/layout.
 func SizesFor(compiler, arch string) Sizes {
 	var m map[string]*StdSizes
 	switch compiler {
 	case "gc":
+		if a := layout.ArchFor(arch); a != nil {
+			return &gcSizes{a}
+		}
 		m = gcArchSizes
 	case "gccgo":
 		m = gccgoArchSizes
@@ -201,7 +212,7 @@ func SizesFor(compiler, arch string) Sizes {
 }
 
 // stdSizes is used if Config.Sizes == nil.
-var stdSizes = SizesFor("gc", "amd64")
+var stdSizes = gcArchSizes["amd64"]
 
 func (conf *Config) alignof(T Type) int64 {
 	if s := conf.Sizes; s != nil {
@@ -110,3 +110,40 @@ const _ = unsafe.Offsetof(struct{ x int64 }{}.x)
 		_ = conf.Sizes.Alignof(tv.Type)
 	}
 }
+
+// SizesFor("gc", ...) must lay out structs like the compiler does,
+// including the padding after a trailing zero-size field.
+func TestGCSizes(t *testing.T) {
+	const src = `
+package main
+
+type S struct {
+	a int32
+	b int8
+	c E
+}
+
+type E [0]byte
+`
+	ts := findStructType(t, src)
+	for _, test := range []struct {
+		arch        string
+		size, align int64
+		offsetC     int64
+	}{
+		{"amd64", 8, 4, 5},
+		{"386", 8, 4, 5},
+	} {
+		sizes := types.SizesFor("gc", test.arch)
+		if got := sizes.Sizeof(ts); got != test.size {
+			t.Errorf("%s: Sizeof(%v) = %d, want %d", test.arch, ts, got, test.size)
+		}
+		if got := sizes.Alignof(ts); got != test.align {
+			t.Errorf("%s: Alignof(%v) = %d, want %d", test.arch, ts, got, test.align)
+		}
+		fields := []*types.Var{ts.Field(0), ts.Field(1), ts.Field(2)}
+		if got := sizes.Offsetsof(fields)[2]; got != test.offsetC {
+			t.Errorf("%s: Offsetof(c) = %d, want %d", test.arch, got, test.offsetC)
+		}
+	}
+}
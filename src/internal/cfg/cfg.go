@@ -33,8 +33,10 @@ const KnownEnv = `
 	GCCGO
 	GO111MODULE
 	GO386
+	GOAMD64
 	GOARCH
 	GOARM
+	GOARM64
 	GOBIN
 	GOCACHE
 	GOENV
@@ -55,6 +57,7 @@ const KnownEnv = `
 	GOPPC64
 	GOPRIVATE
 	GOPROXY
+	GORISCV64
 	GOROOT
 	GOSUMDB
 	GOTMPDIR
@@ -0,0 +1,146 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"math/bits"
+)
+
+// A forwardBitReader reads bits from the start of a byte slice,
+// lowest bit of each byte first. It is used for FSE table
+// descriptions.
+type forwardBitReader struct {
+	data []byte
+	pos  int // bit offset of the next bit to read
+}
+
+// read returns the next n bits, n <= 32. Bits past the end of the
+// data read as zero; the caller checks the position against the
+// length of the data.
+func (r *forwardBitReader) read(n int) uint32 {
+	v := r.peek(n)
+	r.pos += n
+	return v
+}
+
+// peek returns the next n bits without consuming them.
+func (r *forwardBitReader) peek(n int) uint32 {
+	if n == 0 {
+		return 0
+	}
+	var v uint64
+	off := r.pos >> 3
+	for i := 0; i < 5 && off+i < len(r.data); i++ {
+		v |= uint64(r.data[off+i]) << (8 * uint(i))
+	}
+	v >>= uint(r.pos & 7)
+	return uint32(v & (1<<uint(n) - 1))
+}
+
+// bytesRead returns the number of bytes touched so far.
+func (r *forwardBitReader) bytesRead() int {
+	return (r.pos + 7) >> 3
+}
+
+// A reverseBitReader reads a bit stream backward: the stream is
+// started from the end of the data, past a padding marker, and each
+// read returns the bits just below the current position. FSE and
+// Huffman encoded streams are read this way.
+type reverseBitReader struct {
+	data []byte
+	pos  int // number of unread bits; negative if overread
+}
+
+// init prepares r to read data, which must end with a byte
+// holding the stream's final 1 marker bit.
+func (r *reverseBitReader) init(data []byte) error {
+	if len(data) == 0 {
+		return errBadBitstream
+	}
+	last := data[len(data)-1]
+	if last == 0 {
+		return errBadBitstream
+	}
+	r.data = data
+	r.pos = (len(data)-1)*8 + bits.Len8(last) - 1
+	return nil
+}
+
+// read consumes and returns the next n bits, n <= 32.
+// Reading past the start of the stream yields zero bits
+// and leaves r.pos negative.
+func (r *reverseBitReader) read(n int) uint32 {
+	v := r.peek(n)
+	r.pos -= n
+	return v
+}
+
+// peek returns the next n bits without consuming them.
+func (r *reverseBitReader) peek(n int) uint32 {
+	if n == 0 {
+		return 0
+	}
+	start := r.pos - n
+	if start >= 0 {
+		return r.extract(start, n)
+	}
+	if r.pos <= 0 {
+		return 0
+	}
+	// Only the low r.pos bits are available;
+	// pad the result with zeros on the right.
+	return r.extract(0, r.pos) << uint(-start)
+}
+
+// extract returns the n bits starting at bit offset start.
+func (r *reverseBitReader) extract(start, n int) uint32 {
+	var v uint64
+	off := start >> 3
+	for i := 0; i < 5 && off+i < len(r.data); i++ {
+		v |= uint64(r.data[off+i]) << (8 * uint(i))
+	}
+	v >>= uint(start & 7)
+	return uint32(v & (1<<uint(n) - 1))
+}
+
+// A bitWriter writes a bit stream, low bit first. A stream ended
+// by close, which adds the final marker bit, is read backwards by a
+// reverseBitReader; one ended by flush is read by a forwardBitReader.
+type bitWriter struct {
+	out   []byte
+	acc   uint64 // pending bits, low bits first
+	nbits uint   // number of pending bits
+}
+
+// add appends the low n bits of v, n <= 32.
+func (w *bitWriter) add(v uint32, n uint) {
+	w.acc |= uint64(v&(1<<n-1)) << w.nbits
+	w.nbits += n
+	if w.nbits >= 32 {
+		w.out = append(w.out, byte(w.acc), byte(w.acc>>8), byte(w.acc>>16), byte(w.acc>>24))
+		w.acc >>= 32
+		w.nbits -= 32
+	}
+}
+
+// close writes the marker bit and flushes all pending bits.
+func (w *bitWriter) close() []byte {
+	w.add(1, 1)
+	return w.flush()
+}
+
+// flush writes all pending bits, padding the last byte with zeros.
+func (w *bitWriter) flush() []byte {
+	for w.nbits > 0 {
+		w.out = append(w.out, byte(w.acc))
+		w.acc >>= 8
+		if w.nbits < 8 {
+			w.nbits = 0
+		} else {
+			w.nbits -= 8
+		}
+	}
+	return w.out
+}
@@ -0,0 +1,391 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+// Compressed blocks, RFC 8878 section 3.1.1.3.
+
+// Literals block types.
+const (
+	literalsRaw        = 0
+	literalsRLE        = 1
+	literalsCompressed = 2
+	literalsTreeless   = 3
+)
+
+// Sequence table modes.
+const (
+	modePredefined = 0
+	modeRLE        = 1
+	modeFSE        = 2
+	modeRepeat     = 3
+)
+
+// Largest symbols and accuracy logs of the sequence code tables.
+const (
+	maxLLCode = 35
+	maxMLCode = 52
+	maxOFCode = 31
+	maxLLLog  = 9
+	maxMLLog  = 9
+	maxOFLog  = 8
+)
+
+// Baselines and numbers of extra bits of the literal length and
+// match length codes.
+var (
+	llBase = [maxLLCode + 1]uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536,
+	}
+	llBits = [maxLLCode + 1]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	mlBase = [maxMLCode + 1]uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539,
+	}
+	mlBits = [maxMLCode + 1]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16,
+	}
+)
+
+// Predefined distributions of the sequence codes.
+var (
+	llDefaultNorm = []int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	}
+	mlDefaultNorm = []int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	}
+	ofDefaultNorm = []int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	}
+)
+
+const (
+	llDefaultLog = 6
+	mlDefaultLog = 6
+	ofDefaultLog = 5
+)
+
+var (
+	defaultTablesOnce                        sync.Once
+	llDefaultTable, mlDefaultTable           fseTable
+	ofDefaultTable                           fseTable
+	llDefaultEnc, mlDefaultEnc, ofDefaultEnc *fseEncTable
+)
+
+func initDefaultTables() {
+	llDefaultTable = buildFSETable(llDefaultNorm, llDefaultLog)
+	mlDefaultTable = buildFSETable(mlDefaultNorm, mlDefaultLog)
+	ofDefaultTable = buildFSETable(ofDefaultNorm, ofDefaultLog)
+	llDefaultEnc = buildFSEEncTable(llDefaultNorm, llDefaultLog)
+	mlDefaultEnc = buildFSEEncTable(mlDefaultNorm, mlDefaultLog)
+	ofDefaultEnc = buildFSEEncTable(ofDefaultNorm, ofDefaultLog)
+}
+
+// decodeBlock decodes the compressed block data,
+// appending the result to r.hist.
+func (r *Reader) decodeBlock(data []byte) error {
+	lits, n, err := r.readLiterals(data)
+	if err != nil {
+		return err
+	}
+	data = data[n:]
+
+	// Number of sequences.
+	if len(data) == 0 {
+		return errBadSequences
+	}
+	var nseq int
+	switch b := int(data[0]); {
+	case b < 128:
+		nseq = b
+		data = data[1:]
+	case b < 255:
+		if len(data) < 2 {
+			return errBadSequences
+		}
+		nseq = (b-128)<<8 + int(data[1])
+		data = data[2:]
+	default:
+		if len(data) < 3 {
+			return errBadSequences
+		}
+		nseq = int(data[1]) + int(data[2])<<8 + 0x7F00
+		data = data[3:]
+	}
+	if nseq == 0 {
+		if len(data) != 0 {
+			return errBadSequences
+		}
+		r.hist = append(r.hist, lits...)
+		return nil
+	}
+
+	if len(data) == 0 {
+		return errBadSequences
+	}
+	modes := data[0]
+	if modes&3 != 0 {
+		return errBadSequences
+	}
+	data = data[1:]
+	defaultTablesOnce.Do(initDefaultTables)
+	if n, err = setSeqTable(&r.llTable, modes>>6, data, llDefaultTable, maxLLCode, maxLLLog); err != nil {
+		return err
+	}
+	data = data[n:]
+	if n, err = setSeqTable(&r.ofTable, modes>>4&3, data, ofDefaultTable, maxOFCode, maxOFLog); err != nil {
+		return err
+	}
+	data = data[n:]
+	if n, err = setSeqTable(&r.mlTable, modes>>2&3, data, mlDefaultTable, maxMLCode, maxMLLog); err != nil {
+		return err
+	}
+	data = data[n:]
+
+	var br reverseBitReader
+	if err := br.init(data); err != nil {
+		return err
+	}
+	var ll, of, ml fseDecoder
+	ll.init(r.llTable, &br)
+	of.init(r.ofTable, &br)
+	ml.init(r.mlTable, &br)
+	for i := 0; i < nseq; i++ {
+		ofCode := of.symbol()
+		mlCode := ml.symbol()
+		llCode := ll.symbol()
+		if ofCode > maxOFCode || mlCode > maxMLCode || llCode > maxLLCode {
+			return errBadSequences
+		}
+		ofValue := uint32(1)<<ofCode + br.read(int(ofCode))
+		matchLen := mlBase[mlCode] + br.read(int(mlBits[mlCode]))
+		litLen := llBase[llCode] + br.read(int(llBits[llCode]))
+		if i != nseq-1 {
+			ll.update(&br)
+			ml.update(&br)
+			of.update(&br)
+		}
+		if br.pos < 0 {
+			return errBadSequences
+		}
+
+		// Resolve repeated offsets.
+		var offset uint32
+		if ofValue > 3 {
+			offset = ofValue - 3
+			r.rep[2], r.rep[1], r.rep[0] = r.rep[1], r.rep[0], offset
+		} else {
+			idx := ofValue - 1
+			if litLen == 0 {
+				idx++
+			}
+			if idx == 0 {
+				offset = r.rep[0]
+			} else {
+				if idx == 3 {
+					offset = r.rep[0] - 1
+				} else {
+					offset = r.rep[idx]
+				}
+				if idx > 1 {
+					r.rep[2] = r.rep[1]
+				}
+				r.rep[1] = r.rep[0]
+				r.rep[0] = offset
+			}
+		}
+
+		if int(litLen) > len(lits) {
+			return errBadSequences
+		}
+		r.hist = append(r.hist, lits[:litLen]...)
+		lits = lits[litLen:]
+
+		if offset == 0 || int(offset) > len(r.hist) {
+			return errBadSequences
+		}
+		from := len(r.hist) - int(offset)
+		if int(offset) >= int(matchLen) {
+			r.hist = append(r.hist, r.hist[from:from+int(matchLen)]...)
+		} else {
+			for j := 0; j < int(matchLen); j++ {
+				r.hist = append(r.hist, r.hist[from+j])
+			}
+		}
+	}
+	if br.pos != 0 {
+		return errBadSequences
+	}
+	r.hist = append(r.hist, lits...)
+	return nil
+}
+
+// setSeqTable sets *t according to mode, reading any table
+// description from data. It returns the number of bytes read.
+func setSeqTable(t *fseTable, mode uint8, data []byte, def fseTable, maxSym, maxLog int) (int, error) {
+	switch mode {
+	case modePredefined:
+		*t = def
+		return 0, nil
+	case modeRLE:
+		if len(data) == 0 || int(data[0]) > maxSym {
+			return 0, errBadSequences
+		}
+		*t = rleFSETable(data[0])
+		return 1, nil
+	case modeFSE:
+		nt, n, err := readFSETable(data, maxSym, maxLog)
+		if err != nil {
+			return 0, err
+		}
+		*t = nt
+		return n, nil
+	default: // modeRepeat
+		if *t == nil {
+			return 0, errBadSequences
+		}
+		return 0, nil
+	}
+}
+
+// readLiterals reads the literals section at the start of data.
+// It returns the literals and the size of the section.
+func (r *Reader) readLiterals(data []byte) ([]byte, int, error) {
+	if len(data) == 0 {
+		return nil, 0, errBadLiterals
+	}
+	typ := data[0] & 3
+	sizeFormat := data[0] >> 2 & 3
+
+	if typ == literalsRaw || typ == literalsRLE {
+		var size, hdr int
+		switch sizeFormat {
+		case 0, 2:
+			size, hdr = int(data[0]>>3), 1
+		case 1:
+			if len(data) < 2 {
+				return nil, 0, errBadLiterals
+			}
+			size, hdr = int(data[0]>>4)|int(data[1])<<4, 2
+		case 3:
+			if len(data) < 3 {
+				return nil, 0, errBadLiterals
+			}
+			size, hdr = int(data[0]>>4)|int(data[1])<<4|int(data[2])<<12, 3
+		}
+		if size > maxBlockSize {
+			return nil, 0, errBadLiterals
+		}
+		if typ == literalsRaw {
+			if len(data) < hdr+size {
+				return nil, 0, errBadLiterals
+			}
+			return data[hdr : hdr+size], hdr + size, nil
+		}
+		if len(data) < hdr+1 {
+			return nil, 0, errBadLiterals
+		}
+		r.literals = grow(r.literals[:0], size)
+		for i := range r.literals {
+			r.literals[i] = data[hdr]
+		}
+		return r.literals, hdr + 1, nil
+	}
+
+	var regen, comp, hdr int
+	streams := 4
+	switch sizeFormat {
+	case 0, 1:
+		if sizeFormat == 0 {
+			streams = 1
+		}
+		if len(data) < 3 {
+			return nil, 0, errBadLiterals
+		}
+		h := uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16
+		regen, comp, hdr = int(h>>4&0x3FF), int(h>>14&0x3FF), 3
+	case 2:
+		if len(data) < 4 {
+			return nil, 0, errBadLiterals
+		}
+		h := binary.LittleEndian.Uint32(data)
+		regen, comp, hdr = int(h>>4&0x3FFF), int(h>>18&0x3FFF), 4
+	case 3:
+		if len(data) < 5 {
+			return nil, 0, errBadLiterals
+		}
+		h := uint64(binary.LittleEndian.Uint32(data)) | uint64(data[4])<<32
+		regen, comp, hdr = int(h>>4&0x3FFFF), int(h>>22&0x3FFFF), 5
+	}
+	if regen > maxBlockSize || len(data) < hdr+comp {
+		return nil, 0, errBadLiterals
+	}
+	src := data[hdr : hdr+comp]
+	if typ == literalsCompressed {
+		t, n, err := readHuffTable(src)
+		if err != nil {
+			return nil, 0, err
+		}
+		r.huff = t
+		src = src[n:]
+	} else if r.huff == nil {
+		return nil, 0, errBadLiterals
+	}
+
+	r.literals = grow(r.literals[:0], regen)
+	out := r.literals
+	if streams == 1 {
+		if err := decodeHuffStream(r.huff, src, out); err != nil {
+			return nil, 0, err
+		}
+		return out, hdr + comp, nil
+	}
+	if len(src) < 6 {
+		return nil, 0, errBadLiterals
+	}
+	var sizes [4]int
+	sizes[0] = int(binary.LittleEndian.Uint16(src))
+	sizes[1] = int(binary.LittleEndian.Uint16(src[2:]))
+	sizes[2] = int(binary.LittleEndian.Uint16(src[4:]))
+	src = src[6:]
+	sizes[3] = len(src) - sizes[0] - sizes[1] - sizes[2]
+	seg := (regen + 3) / 4
+	if sizes[3] < 0 || 3*seg > regen {
+		return nil, 0, errBadLiterals
+	}
+	for i, size := range sizes {
+		o := out[i*seg:]
+		if i < 3 {
+			o = o[:seg]
+		}
+		if err := decodeHuffStream(r.huff, src[:size], o); err != nil {
+			return nil, 0, err
+		}
+		src = src[size:]
+	}
+	return out, hdr + comp, nil
+}
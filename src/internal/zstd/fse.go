@@ -0,0 +1,405 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"math/bits"
+)
+
+// Finite State Entropy tables, RFC 8878 section 4.1.
+
+// An fseEntry is one state of an FSE decoding table.
+type fseEntry struct {
+	sym  uint8  // symbol decoded in this state
+	bits uint8  // number of bits to read for the next state
+	base uint16 // next state is base + the bits read
+}
+
+// fseTable is an FSE decoding table. Its length is 1<<accuracyLog.
+type fseTable []fseEntry
+
+// accuracyLog returns the accuracy log of t.
+func (t fseTable) accuracyLog() int {
+	return bits.Len(uint(len(t))) - 1
+}
+
+// spreadSymbols distributes the symbols of the normalized
+// distribution norm over a table of size 1<<log, as both the
+// decoding and the encoding tables require. It returns the symbol
+// of each state.
+func spreadSymbols(norm []int16, log int) []uint8 {
+	size := 1 << uint(log)
+	syms := make([]uint8, size)
+	high := size - 1
+	for s, n := range norm {
+		if n == -1 {
+			syms[high] = uint8(s)
+			high--
+		}
+	}
+	step := size>>1 + size>>3 + 3
+	mask := size - 1
+	pos := 0
+	for s, n := range norm {
+		for i := 0; i < int(n); i++ {
+			syms[pos] = uint8(s)
+			pos = (pos + step) & mask
+			for pos > high {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+	return syms
+}
+
+// buildFSETable builds the decoding table for the normalized
+// distribution norm with the given accuracy log.
+func buildFSETable(norm []int16, log int) fseTable {
+	size := 1 << uint(log)
+	syms := spreadSymbols(norm, log)
+	next := make([]int, len(norm))
+	for s, n := range norm {
+		if n == -1 {
+			next[s] = 1
+		} else {
+			next[s] = int(n)
+		}
+	}
+	t := make(fseTable, size)
+	for u, s := range syms {
+		n := next[s]
+		next[s]++
+		nb := log - (bits.Len(uint(n)) - 1)
+		t[u] = fseEntry{
+			sym:  s,
+			bits: uint8(nb),
+			base: uint16(n<<uint(nb) - size),
+		}
+	}
+	return t
+}
+
+// rleFSETable returns a decoding table that always decodes sym.
+func rleFSETable(sym uint8) fseTable {
+	return fseTable{{sym: sym}}
+}
+
+// readFSETable reads an FSE table description from data,
+// allowing symbols up to maxSym and accuracy logs up to maxLog.
+// It returns the table and the number of bytes read.
+func readFSETable(data []byte, maxSym, maxLog int) (fseTable, int, error) {
+	if len(data) == 0 {
+		return nil, 0, errBadFSETable
+	}
+	r := forwardBitReader{data: data}
+	log := int(r.read(4)) + 5
+	if log > maxLog {
+		return nil, 0, errBadFSETable
+	}
+	remaining := 1<<uint(log) + 1
+	threshold := 1 << uint(log)
+	nbits := log + 1
+	norm := make([]int16, 0, maxSym+1)
+	for remaining > 1 {
+		if len(norm) > maxSym || r.bytesRead() > len(data) {
+			return nil, 0, errBadFSETable
+		}
+		max := 2*threshold - 1 - remaining
+		var v int
+		if low := int(r.peek(nbits - 1)); low < max {
+			v = low
+			r.pos += nbits - 1
+		} else {
+			v = int(r.read(nbits))
+			if v >= threshold {
+				v -= max
+			}
+		}
+		count := v - 1 // -1 means "less than one"
+		if count < 0 {
+			remaining += count
+		} else {
+			remaining -= count
+		}
+		norm = append(norm, int16(count))
+		if count == 0 {
+			// Runs of zero probabilities are given by
+			// 2-bit repeat counts; 3 means more follow.
+			for {
+				rep := r.read(2)
+				for i := uint32(0); i < rep; i++ {
+					norm = append(norm, 0)
+				}
+				if rep != 3 {
+					break
+				}
+				if r.bytesRead() > len(data) {
+					return nil, 0, errBadFSETable
+				}
+			}
+		}
+		for remaining < threshold {
+			nbits--
+			threshold >>= 1
+		}
+	}
+	if remaining != 1 || len(norm) > maxSym+1 || r.bytesRead() > len(data) {
+		return nil, 0, errBadFSETable
+	}
+	return buildFSETable(norm, log), r.bytesRead(), nil
+}
+
+// An fseDecoder is one FSE decoding state.
+type fseDecoder struct {
+	t     fseTable
+	state int
+}
+
+// init reads the initial state from br.
+func (d *fseDecoder) init(t fseTable, br *reverseBitReader) {
+	d.t = t
+	d.state = int(br.read(t.accuracyLog()))
+}
+
+// symbol returns the symbol of the current state.
+func (d *fseDecoder) symbol() uint8 {
+	return d.t[d.state].sym
+}
+
+// update moves to the next state, reading bits from br.
+func (d *fseDecoder) update(br *reverseBitReader) {
+	e := d.t[d.state]
+	d.state = int(e.base) + int(br.read(int(e.bits)))
+}
+
+// An fseEncTable is an FSE encoding table, built from the same
+// normalized distribution as the matching decoding table.
+type fseEncTable struct {
+	log    uint
+	states []uint16       // next state values, indexed by cumulated frequency
+	syms   []fseEncSymbol // per-symbol transforms
+}
+
+type fseEncSymbol struct {
+	deltaBits  uint32 // (max bits out << 16) - minimum state needing them
+	deltaState int32  // offset of the symbol's states in states
+}
+
+// buildFSEEncTable builds the encoding table for norm.
+func buildFSEEncTable(norm []int16, log int) *fseEncTable {
+	size := 1 << uint(log)
+	syms := spreadSymbols(norm, log)
+	cumul := make([]int, len(norm)+1)
+	for s, n := range norm {
+		if n == -1 {
+			n = 1
+		}
+		cumul[s+1] = cumul[s] + int(n)
+	}
+	t := &fseEncTable{
+		log:    uint(log),
+		states: make([]uint16, size),
+		syms:   make([]fseEncSymbol, len(norm)),
+	}
+	for u, s := range syms {
+		t.states[cumul[s]] = uint16(size + u)
+		cumul[s]++
+	}
+	total := 0
+	for s, n := range norm {
+		switch n {
+		case 0:
+			t.syms[s].deltaBits = uint32((log+1)<<16 - size)
+		case -1, 1:
+			t.syms[s].deltaBits = uint32(log<<16 - size)
+			t.syms[s].deltaState = int32(total - 1)
+			total++
+		default:
+			maxBits := log - (bits.Len(uint(n-1)) - 1)
+			minState := int(n) << uint(maxBits)
+			t.syms[s].deltaBits = uint32(maxBits<<16 - minState)
+			t.syms[s].deltaState = int32(total - int(n))
+			total += int(n)
+		}
+	}
+	return t
+}
+
+// fseTableLog returns the accuracy log of at most maxLog to use
+// for a table coding total symbols, the largest of which is maxSym,
+// choosing it the way the reference implementation does.
+func fseTableLog(maxLog, total, maxSym int) int {
+	log := maxLog
+	if b := bits.Len(uint(total-1)) - 3; b < log {
+		log = b // small inputs do not need precise tables
+	}
+	min := bits.Len(uint(total))
+	if b := bits.Len(uint(maxSym)) + 1; b < min {
+		min = b
+	}
+	if log < min {
+		log = min
+	}
+	if log < 5 {
+		log = 5
+	}
+	if log > maxLog {
+		log = maxLog
+	}
+	return log
+}
+
+// normalizeCounts scales the symbol counts to a distribution
+// summing to 1<<log, as an FSE table requires. Symbols too rare
+// for a state of their own get the "less than one" probability -1.
+func normalizeCounts(counts []uint32, total, log int) []int16 {
+	size := 1 << uint(log)
+	norm := make([]int16, len(counts))
+	left := size
+	largest := 0
+	for s, c := range counts {
+		if c == 0 {
+			continue
+		}
+		n := (int(c)*size + total/2) / total
+		if n == 0 {
+			n = -1
+			left--
+		} else {
+			left -= n
+		}
+		norm[s] = int16(n)
+		if n > int(norm[largest]) {
+			largest = s
+		}
+	}
+	if left >= 0 || int(norm[largest])+left > int(norm[largest])/2 {
+		// Give the rounding error to the most likely symbol.
+		norm[largest] += int16(left)
+		return norm
+	}
+	// Take the excess from the most likely symbols, one at a time.
+	for left < 0 {
+		max := 0
+		for s, n := range norm {
+			if n > norm[max] {
+				max = s
+			}
+		}
+		norm[max]--
+		left++
+	}
+	return norm
+}
+
+// appendFSETable appends the description of the distribution norm
+// with the given accuracy log, in the format read by readFSETable.
+func appendFSETable(out []byte, norm []int16, log int) []byte {
+	last := len(norm) - 1
+	for norm[last] == 0 {
+		last--
+	}
+	w := bitWriter{out: out}
+	w.add(uint32(log-5), 4)
+	remaining := 1<<uint(log) + 1
+	threshold := 1 << uint(log)
+	nbits := uint(log + 1)
+	for s := 0; s <= last; {
+		n := int(norm[s])
+		s++
+		max := 2*threshold - 1 - remaining
+		if n < 0 {
+			remaining += n
+		} else {
+			remaining -= n
+		}
+		v := n + 1
+		if v >= threshold {
+			v += max
+		}
+		if v < max {
+			w.add(uint32(v), nbits-1)
+		} else {
+			w.add(uint32(v), nbits)
+		}
+		for remaining < threshold {
+			nbits--
+			threshold >>= 1
+		}
+		if n == 0 {
+			// Give the length of the run of zeros that follows.
+			run := 0
+			for norm[s+run] == 0 {
+				run++
+			}
+			s += run
+			for ; run >= 3; run -= 3 {
+				w.add(3, 2)
+			}
+			w.add(uint32(run), 2)
+		}
+	}
+	return w.flush()
+}
+
+// fseCost returns the cost in bits of coding symbols with the given
+// counts using the distribution norm with accuracy log, or -1 if
+// the distribution cannot code them.
+func fseCost(counts []uint32, norm []int16, log int) int {
+	cost := 0
+	for s, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if s >= len(norm) || norm[s] == 0 {
+			return -1
+		}
+		n := int(norm[s])
+		if n == -1 {
+			n = 1
+		}
+		// Approximate log2(1<<log / n) in 1/256 bit units.
+		cost += int(c) * (log<<8 - log2Fixed(n))
+	}
+	return cost >> 8
+}
+
+// log2Fixed returns log2(n) with 8 fractional bits.
+func log2Fixed(n int) int {
+	b := bits.Len(uint(n)) - 1
+	// Interpolate linearly between powers of two.
+	frac := (n<<8)>>uint(b) - 256
+	return b<<8 + frac
+}
+
+// An fseEncoder is one FSE encoding state.
+type fseEncoder struct {
+	t     *fseEncTable
+	state uint32
+}
+
+// init sets the state for encoding sym first (that is, last in
+// decoding order), without writing any bits.
+func (e *fseEncoder) init(t *fseEncTable, sym uint8) {
+	e.t = t
+	s := t.syms[sym]
+	nb := (s.deltaBits + 1<<15) >> 16
+	v := nb<<16 - s.deltaBits
+	e.state = uint32(t.states[int32(v>>nb)+s.deltaState])
+}
+
+// encode writes the bits taking the decoder from the state for
+// sym to the current state, and moves to the state for sym.
+func (e *fseEncoder) encode(w *bitWriter, sym uint8) {
+	s := e.t.syms[sym]
+	nb := (e.state + s.deltaBits) >> 16
+	w.add(e.state, uint(nb))
+	e.state = uint32(e.t.states[int32(e.state>>nb)+s.deltaState])
+}
+
+// flush writes the final state, which the decoder reads first.
+func (e *fseEncoder) flush(w *bitWriter) {
+	w.add(e.state, e.t.log)
+}
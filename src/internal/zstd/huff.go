@@ -0,0 +1,397 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Huffman coding of literals, RFC 8878 section 4.2.
+
+const maxHuffmanBits = 11
+
+// A huffEntry is one entry of a Huffman decoding table.
+type huffEntry struct {
+	sym  uint8
+	bits uint8 // length of the code for sym
+}
+
+// huffTable is a Huffman decoding table indexed by the next
+// maxBits bits of the stream, where len(t) == 1<<maxBits.
+type huffTable []huffEntry
+
+// maxBits returns the longest code length of t.
+func (t huffTable) maxBits() int {
+	return bits.Len(uint(len(t))) - 1
+}
+
+// readHuffTable reads a Huffman tree description from data.
+// It returns the table and the number of bytes read.
+func readHuffTable(data []byte) (huffTable, int, error) {
+	var weights [256]uint8
+	n, size, err := readHuffWeights(data, &weights)
+	if err != nil {
+		return nil, 0, err
+	}
+	if n > 255 {
+		return nil, 0, errBadHuffmanTable
+	}
+
+	// The weight of the last symbol is implied: it completes
+	// the sum of 2^(w-1) to the next power of two.
+	var total uint32
+	for _, w := range weights[:n] {
+		if w > maxHuffmanBits {
+			return nil, 0, errBadHuffmanTable
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 {
+		return nil, 0, errBadHuffmanTable
+	}
+	maxBits := bits.Len32(total)
+	if maxBits > maxHuffmanBits {
+		return nil, 0, errBadHuffmanTable
+	}
+	left := uint32(1)<<uint(maxBits) - total
+	if left&(left-1) != 0 {
+		return nil, 0, errBadHuffmanTable
+	}
+	weights[n] = uint8(bits.Len32(left))
+	n++
+
+	// Symbols of the same weight are laid out in symbol order,
+	// lowest weights (longest codes) first.
+	var rankStart [maxHuffmanBits + 2]int
+	var count [maxHuffmanBits + 2]int
+	for _, w := range weights[:n] {
+		count[w]++
+	}
+	next := 0
+	for w := 1; w <= maxBits; w++ {
+		rankStart[w] = next
+		next += count[w] << uint(w-1)
+	}
+	t := make(huffTable, 1<<uint(maxBits))
+	for s, w := range weights[:n] {
+		if w == 0 {
+			continue
+		}
+		length := 1 << (w - 1)
+		e := huffEntry{sym: uint8(s), bits: uint8(maxBits + 1 - int(w))}
+		start := rankStart[w]
+		for i := start; i < start+length; i++ {
+			t[i] = e
+		}
+		rankStart[w] += length
+	}
+	return t, size, nil
+}
+
+// readHuffWeights reads the weights of a Huffman tree description
+// from data, all but the implied last one. It returns the number of
+// weights and the number of bytes read.
+func readHuffWeights(data []byte, weights *[256]uint8) (int, int, error) {
+	if len(data) == 0 {
+		return 0, 0, errBadHuffmanTable
+	}
+	var n int // number of weights given
+	hdr := int(data[0])
+	size := 1
+	if hdr < 128 {
+		// The weights are FSE compressed with two interleaved states.
+		if 1+hdr > len(data) {
+			return 0, 0, errBadHuffmanTable
+		}
+		comp := data[1 : 1+hdr]
+		size += hdr
+		t, tsize, err := readFSETable(comp, 255, 6)
+		if err != nil {
+			return 0, 0, err
+		}
+		var br reverseBitReader
+		if err := br.init(comp[tsize:]); err != nil {
+			return 0, 0, err
+		}
+		var s1, s2 fseDecoder
+		s1.init(t, &br)
+		s2.init(t, &br)
+		for {
+			if n > 254 {
+				return 0, 0, errBadHuffmanTable
+			}
+			weights[n] = s1.symbol()
+			n++
+			s1.update(&br)
+			if br.pos < 0 {
+				weights[n] = s2.symbol()
+				n++
+				break
+			}
+			if n > 254 {
+				return 0, 0, errBadHuffmanTable
+			}
+			weights[n] = s2.symbol()
+			n++
+			s2.update(&br)
+			if br.pos < 0 {
+				weights[n] = s1.symbol()
+				n++
+				break
+			}
+		}
+	} else {
+		// The weights are stored directly, 4 bits each.
+		n = hdr - 127
+		size += (n + 1) / 2
+		if size > len(data) {
+			return 0, 0, errBadHuffmanTable
+		}
+		for i := 0; i < n; i++ {
+			b := data[1+i/2]
+			if i%2 == 0 {
+				weights[i] = b >> 4
+			} else {
+				weights[i] = b & 15
+			}
+		}
+	}
+	return n, size, nil
+}
+
+// decodeHuffStream decodes len(out) symbols from the stream in
+// data, which must be consumed exactly.
+func decodeHuffStream(t huffTable, data []byte, out []byte) error {
+	var br reverseBitReader
+	if err := br.init(data); err != nil {
+		return err
+	}
+	maxBits := t.maxBits()
+	for i := range out {
+		e := t[br.peek(maxBits)]
+		out[i] = e.sym
+		br.pos -= int(e.bits)
+	}
+	if br.pos != 0 {
+		return errBadLiterals
+	}
+	return nil
+}
+
+// A huffEncoder codes literals with a Huffman code.
+type huffEncoder struct {
+	codes   [256]uint16
+	lens    [256]uint8
+	maxBits int
+	maxSym  int // largest symbol with a code
+}
+
+// A huffNode is a node of a Huffman tree under construction.
+type huffNode struct {
+	count  uint32
+	parent int32
+}
+
+type huffLeaves struct {
+	syms   []uint8
+	counts *[256]uint32
+}
+
+func (l huffLeaves) Len() int      { return len(l.syms) }
+func (l huffLeaves) Swap(i, j int) { l.syms[i], l.syms[j] = l.syms[j], l.syms[i] }
+func (l huffLeaves) Less(i, j int) bool {
+	ci, cj := l.counts[l.syms[i]], l.counts[l.syms[j]]
+	return ci < cj || ci == cj && l.syms[i] < l.syms[j]
+}
+
+// build builds the code for symbols occurring with the given
+// counts, of which at least two must be nonzero.
+func (e *huffEncoder) build(counts *[256]uint32) {
+	var symBuf [256]uint8
+	leaves := huffLeaves{symBuf[:0], counts}
+	for s, c := range counts {
+		if c > 0 {
+			leaves.syms = append(leaves.syms, uint8(s))
+			e.maxSym = s
+		}
+	}
+	sort.Sort(leaves)
+	syms := leaves.syms
+
+	// Build the tree, taking the two lightest of the remaining
+	// leaves and internal nodes, which are made in weight order.
+	n := len(syms)
+	var nodeBuf [2 * 256]huffNode
+	nodes := nodeBuf[:2*n-1]
+	for i, s := range syms {
+		nodes[i].count = counts[s]
+	}
+	leaf, inner := 0, n
+	pick := func(k int) int {
+		if leaf < n && (inner == k || nodes[leaf].count <= nodes[inner].count) {
+			leaf++
+			return leaf - 1
+		}
+		inner++
+		return inner - 1
+	}
+	for k := n; k < len(nodes); k++ {
+		a, b := pick(k), pick(k)
+		nodes[k].count = nodes[a].count + nodes[b].count
+		nodes[a].parent = int32(k)
+		nodes[b].parent = int32(k)
+	}
+	var depth [2 * 256]uint8
+	for k := len(nodes) - 2; k >= 0; k-- {
+		depth[k] = depth[nodes[k].parent] + 1
+	}
+
+	// Limit the code lengths. Clamping them makes the code
+	// overfull: lengthen the codes of the lightest symbols until
+	// it fits, then shorten those of the heaviest until it is
+	// complete again. kraft is the sum of 2^(max-length), which
+	// is 1<<max for a complete code.
+	const max = maxHuffmanBits
+	lens := depth[:n]
+	kraft := 0
+	for i, l := range lens {
+		if l > max {
+			lens[i] = max
+		}
+		kraft += 1 << uint(max-int(lens[i]))
+	}
+	for kraft > 1<<max {
+		for i := 0; i < n && kraft > 1<<max; i++ {
+			if lens[i] < max {
+				lens[i]++
+				kraft -= 1 << uint(max-int(lens[i]))
+			}
+		}
+	}
+	for kraft < 1<<max {
+		for i := n - 1; i >= 0 && kraft < 1<<max; i-- {
+			if l := lens[i]; l > 1 && kraft+1<<uint(max-int(l)) <= 1<<max {
+				lens[i]--
+				kraft += 1 << uint(max-int(l))
+			}
+		}
+	}
+
+	e.lens = [256]uint8{}
+	e.maxBits = 0
+	for i, s := range syms {
+		e.lens[s] = lens[i]
+		if int(lens[i]) > e.maxBits {
+			e.maxBits = int(lens[i])
+		}
+	}
+
+	// Assign codes as the decoder lays out its table: by weight,
+	// lowest weights (longest codes) first, then in symbol order.
+	var next [maxHuffmanBits + 2]int
+	for _, l := range e.lens[:e.maxSym+1] {
+		if l > 0 {
+			next[e.maxBits+1-int(l)] += 1 << uint(e.maxBits-int(l))
+		}
+	}
+	start := 0
+	for w := 1; w <= e.maxBits; w++ {
+		c := next[w]
+		next[w] = start
+		start += c
+	}
+	for s, l := range e.lens[:e.maxSym+1] {
+		if l > 0 {
+			w := e.maxBits + 1 - int(l)
+			e.codes[s] = uint16(next[w] >> uint(e.maxBits-int(l)))
+			next[w] += 1 << uint(e.maxBits-int(l))
+		}
+	}
+}
+
+// appendTable appends the description of the code, as read by
+// readHuffTable. It reports false if the code cannot be described.
+func (e *huffEncoder) appendTable(out []byte) ([]byte, bool) {
+	// The weight of the largest symbol is implied.
+	var weights [256]uint8
+	n := e.maxSym
+	var counts [maxHuffmanBits + 1]uint32
+	maxWeight := 0
+	for s, l := range e.lens[:n] {
+		if l > 0 {
+			weights[s] = uint8(e.maxBits + 1 - int(l))
+		}
+		counts[weights[s]]++
+		if int(weights[s]) > maxWeight {
+			maxWeight = int(weights[s])
+		}
+	}
+
+	// FSE compress the weights with two interleaved states,
+	// unless they are too few or all the same.
+	start := len(out)
+	if n >= 2 && counts[weights[0]] < uint32(n) {
+		out = append(out, 0) // size, filled in below
+		log := fseTableLog(6, n, maxWeight)
+		norm := normalizeCounts(counts[:maxWeight+1], n, log)
+		out = appendFSETable(out, norm, log)
+		t := buildFSEEncTable(norm, log)
+		w := bitWriter{out: out}
+		ws := weights[:n]
+		var s1, s2 fseEncoder
+		i := n - 2
+		if n%2 == 1 {
+			s1.init(t, ws[n-1])
+			s2.init(t, ws[n-2])
+			s1.encode(&w, ws[n-3])
+			i = n - 3
+		} else {
+			s2.init(t, ws[n-1])
+			s1.init(t, ws[n-2])
+		}
+		for ; i > 0; i -= 2 {
+			s2.encode(&w, ws[i-1])
+			s1.encode(&w, ws[i-2])
+		}
+		s2.flush(&w)
+		s1.flush(&w)
+		out = w.close()
+
+		// The decoder stops at the first state update that
+		// reads past the start of the stream, which can come
+		// too late; check that the weights read back.
+		size := len(out) - start - 1
+		if size < 128 && (n > 128 || size < (n+1)/2) {
+			out[start] = byte(size)
+			var got [256]uint8
+			if m, _, err := readHuffWeights(out[start:], &got); err == nil && m == n && got == weights {
+				return out, true
+			}
+		}
+		out = out[:start]
+	}
+
+	if n > 128 {
+		return out, false
+	}
+	out = append(out, byte(127+n))
+	for i := 0; i < n; i += 2 {
+		out = append(out, weights[i]<<4|weights[i+1])
+	}
+	return out, true
+}
+
+// appendStream appends lits as a Huffman coded stream.
+func (e *huffEncoder) appendStream(out, lits []byte) []byte {
+	w := bitWriter{out: out}
+	for i := len(lits) - 1; i >= 0; i-- {
+		c := lits[i]
+		w.add(uint32(e.codes[c]), uint(e.lens[c]))
+	}
+	return w.close()
+}
@@ -0,0 +1,537 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+const (
+	windowLog  = 19
+	windowSize = 1 << windowLog
+
+	hashLog = 14
+)
+
+var errClosed = errors.New("zstd: write to closed Writer")
+
+// A Writer compresses data written to it into a single Zstandard
+// frame. It finds matches with a single-entry hash table, trading
+// compression ratio for speed, and entropy codes the literals and
+// sequences of each block with tables built for it.
+type Writer struct {
+	w   io.Writer
+	err error
+
+	wroteHeader bool
+	hist        []byte // window of earlier input followed by the pending block
+	pending     int    // start of the pending block in hist
+	table       [1 << hashLog]int32
+	hash        xxhash64
+	rep         [3]uint32
+
+	lits  []byte
+	seqs  []sequence
+	codes []seqCodes
+	huff  huffEncoder
+	out   []byte
+}
+
+// A sequence is a run of literals followed by a match.
+type sequence struct {
+	litLen, matchLen, offset uint32
+}
+
+// seqCodes holds the codes of a sequence.
+type seqCodes struct {
+	ll, ml, of uint8
+	ofValue    uint32
+}
+
+// NewWriter returns a new Writer compressing data to w.
+// The caller must call Close to finish the frame.
+func NewWriter(w io.Writer) *Writer {
+	z := &Writer{w: w}
+	z.hash.reset()
+	z.rep = [3]uint32{1, 4, 8}
+	return z
+}
+
+// Write compresses p.
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if len(z.hist)-z.pending == maxBlockSize {
+			if err := z.writeBlock(false); err != nil {
+				z.err = err
+				return n - len(p), err
+			}
+		}
+		c := maxBlockSize - (len(z.hist) - z.pending)
+		if c > len(p) {
+			c = len(p)
+		}
+		z.hist = append(z.hist, p[:c]...)
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// Close writes the remaining data and the end of the frame.
+// It does not close the underlying writer.
+func (z *Writer) Close() error {
+	if z.err != nil {
+		return z.err
+	}
+	if err := z.writeBlock(true); err != nil {
+		z.err = err
+		return err
+	}
+	var sum [4]byte
+	binary.LittleEndian.PutUint32(sum[:], uint32(z.hash.sum64()))
+	if _, err := z.w.Write(sum[:]); err != nil {
+		z.err = err
+		return err
+	}
+	z.err = errClosed
+	return nil
+}
+
+// writeBlock writes the pending block.
+func (z *Writer) writeBlock(last bool) error {
+	block := z.hist[z.pending:]
+	z.hash.write(block)
+
+	out := z.out[:0]
+	if !z.wroteHeader {
+		z.wroteHeader = true
+		// Frame header: a content checksum and a window
+		// descriptor, but no content size or dictionary.
+		out = append(out, 0x28, 0xB5, 0x2F, 0xFD, 1<<2, (windowLog-10)<<3)
+	}
+	out = append(out, 0, 0, 0) // block header, filled in below
+	hdr := len(out) - 3
+
+	typ := blockRaw
+	size := len(block)
+	rep := z.rep
+	if len(block) > 0 && isRun(block) {
+		typ = blockRLE
+		out = append(out, block[0])
+	} else if body := z.compressBlock(out); len(body)-len(out) < len(block) {
+		typ = blockCompressed
+		size = len(body) - len(out)
+		out = body
+	} else {
+		z.rep = rep // the decoder will not see these sequences
+		out = append(out, block...)
+	}
+	h := uint32(size)<<3 | uint32(typ)<<1
+	if last {
+		h |= 1
+	}
+	out[hdr] = byte(h)
+	out[hdr+1] = byte(h >> 8)
+	out[hdr+2] = byte(h >> 16)
+	z.out = out
+	if _, err := z.w.Write(out); err != nil {
+		return err
+	}
+
+	z.pending = len(z.hist)
+	if len(z.hist) >= 2*windowSize {
+		// Slide the window.
+		delta := len(z.hist) - windowSize
+		copy(z.hist, z.hist[delta:])
+		z.hist = z.hist[:windowSize]
+		z.pending -= delta
+		for i, v := range z.table {
+			if int(v) <= delta {
+				z.table[i] = 0
+			} else {
+				z.table[i] = v - int32(delta)
+			}
+		}
+	}
+	return nil
+}
+
+// isRun reports whether b consists of a single repeated byte.
+func isRun(b []byte) bool {
+	for _, c := range b[1:] {
+		if c != b[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func load32(b []byte, i int) uint32 {
+	return binary.LittleEndian.Uint32(b[i:])
+}
+
+func load64(b []byte, i int) uint64 {
+	return binary.LittleEndian.Uint64(b[i:])
+}
+
+// hash6 hashes the low 6 bytes of v.
+func hash6(v uint64) uint32 {
+	return uint32(((v << 16) * 227718039650203) >> (64 - hashLog))
+}
+
+// matchLen returns the length of the common prefix of src[a:end]
+// and src[b:end], where a < b.
+func matchLen(src []byte, a, b, end int) int {
+	n := 0
+	for b+n+8 <= end {
+		if x := load64(src, a+n) ^ load64(src, b+n); x != 0 {
+			return n + bits.TrailingZeros64(x)>>3
+		}
+		n += 8
+	}
+	for b+n < end && src[a+n] == src[b+n] {
+		n++
+	}
+	return n
+}
+
+// compressBlock appends the pending block, compressed, to out.
+//
+// It finds matches the way the reference implementation's fast
+// strategy does: it looks for the most recent offset one byte ahead,
+// then for the last position with the same hash, and after each
+// match it tries the second most recent offset.
+func (z *Writer) compressBlock(out []byte) []byte {
+	src := z.hist
+	start, end := z.pending, len(src)
+	z.lits = z.lits[:0]
+	z.seqs = z.seqs[:0]
+
+	rep0, rep1 := int(z.rep[0]), int(z.rep[1])
+	litStart := start
+	limit := end - 8 // keep loads within the block
+	for i := start; i < limit; {
+		cur := load64(src, i)
+		h := hash6(cur)
+		cand := int(z.table[h]) - 1
+		z.table[h] = int32(i + 1)
+
+		var n int
+		if r := i + 1 - rep0; r >= 0 && i+1 > litStart && load32(src, r) == uint32(cur>>8) {
+			// Repeated offset at the next position.
+			i++
+			cand = r
+			n = 4 + matchLen(src, cand+4, i+4, end)
+		} else if cand >= 0 && i-cand < windowSize && load32(src, cand) == uint32(cur) {
+			n = 4 + matchLen(src, cand+4, i+4, end)
+			for i > litStart && cand > 0 && src[i-1] == src[cand-1] {
+				i--
+				cand--
+				n++
+			}
+		} else {
+			// Skip faster through data that does not compress.
+			i += 1 + (i-litStart)>>8
+			continue
+		}
+
+		z.lits = append(z.lits, src[litStart:i]...)
+		z.addSeq(i-litStart, n, i-cand)
+		if off := i - cand; off != rep0 {
+			rep0, rep1 = off, rep0
+		}
+		i += n
+		litStart = i
+		if i < limit {
+			// Index positions inside the match too.
+			z.table[hash6(load64(src, i-n+2))] = int32(i - n + 2 + 1)
+			z.table[hash6(load64(src, i-2))] = int32(i - 2 + 1)
+		}
+
+		// Try the previous offset with no literals in between.
+		for i < limit && i-rep1 >= 0 && load32(src, i-rep1) == load32(src, i) {
+			n := 4 + matchLen(src, i-rep1+4, i+4, end)
+			z.table[hash6(load64(src, i))] = int32(i + 1)
+			z.addSeq(0, n, rep1)
+			rep0, rep1 = rep1, rep0
+			i += n
+			litStart = i
+		}
+	}
+	z.lits = append(z.lits, src[litStart:end]...)
+
+	out = z.appendLiterals(out, z.lits)
+	return z.appendSequences(out)
+}
+
+// addSeq records a sequence.
+func (z *Writer) addSeq(litLen, matchLen, offset int) {
+	z.seqs = append(z.seqs, sequence{
+		litLen:   uint32(litLen),
+		matchLen: uint32(matchLen),
+		offset:   uint32(offset),
+	})
+}
+
+// appendLiterals appends a literals section holding lits,
+// Huffman coded if that makes it smaller.
+func (z *Writer) appendLiterals(out, lits []byte) []byte {
+	regen := len(lits)
+	if regen < 64 {
+		out = appendLiteralsHeader(out, literalsRaw, regen)
+		return append(out, lits...)
+	}
+	var counts [256]uint32
+	for _, c := range lits {
+		counts[c]++
+	}
+	if counts[lits[0]] == uint32(regen) {
+		out = appendLiteralsHeader(out, literalsRLE, regen)
+		return append(out, lits[0])
+	}
+
+	h := &z.huff
+	h.build(&counts)
+	size := 0
+	for s, c := range counts[:h.maxSym+1] {
+		size += int(c) * int(h.lens[s])
+	}
+	if size/8+64 >= regen {
+		// Not worth it.
+		out = appendLiteralsHeader(out, literalsRaw, regen)
+		return append(out, lits...)
+	}
+
+	// One stream for few literals, otherwise four.
+	start := len(out)
+	var format, hdr int
+	switch {
+	case regen < 1<<10:
+		format, hdr = 0, 3
+	case regen < 1<<14:
+		format, hdr = 2, 4
+	default:
+		format, hdr = 3, 5
+	}
+	out = append(out, 0, 0, 0, 0, 0)[:start+hdr]
+	out, ok := h.appendTable(out)
+	if !ok {
+		out = appendLiteralsHeader(out[:start], literalsRaw, regen)
+		return append(out, lits...)
+	}
+	if format == 0 {
+		out = h.appendStream(out, lits)
+	} else {
+		seg := (regen + 3) / 4
+		jump := len(out)
+		out = append(out, 0, 0, 0, 0, 0, 0)
+		for i := 0; i < 4; i++ {
+			lo, hi := i*seg, (i+1)*seg
+			if i == 3 {
+				hi = regen
+			}
+			n := len(out)
+			out = h.appendStream(out, lits[lo:hi])
+			if i < 3 {
+				binary.LittleEndian.PutUint16(out[jump+2*i:], uint16(len(out)-n))
+			}
+		}
+	}
+
+	comp := len(out) - start - hdr
+	if comp >= regen {
+		out = appendLiteralsHeader(out[:start], literalsRaw, regen)
+		return append(out, lits...)
+	}
+	v := uint64(literalsCompressed) | uint64(format)<<2 | uint64(regen)<<4
+	switch format {
+	case 0:
+		v |= uint64(comp) << 14
+	case 2:
+		v |= uint64(comp) << 18
+	case 3:
+		v |= uint64(comp) << 22
+	}
+	for i := 0; i < hdr; i++ {
+		out[start+i] = byte(v >> (8 * uint(i)))
+	}
+	return out
+}
+
+// appendLiteralsHeader appends the header of a raw or RLE literals
+// section regenerating n bytes.
+func appendLiteralsHeader(out []byte, typ byte, n int) []byte {
+	switch {
+	case n < 1<<5:
+		return append(out, typ|byte(n<<3))
+	case n < 1<<12:
+		return append(out, typ|1<<2|byte(n<<4), byte(n>>4))
+	}
+	return append(out, typ|3<<2|byte(n<<4), byte(n>>4), byte(n>>12))
+}
+
+// appendSequences appends the sequences section for z.seqs.
+func (z *Writer) appendSequences(out []byte) []byte {
+	seqs := z.seqs
+	switch n := len(seqs); {
+	case n < 128:
+		out = append(out, byte(n))
+	case n < 0x7F00:
+		out = append(out, byte(n>>8+128), byte(n))
+	default:
+		n -= 0x7F00
+		out = append(out, 255, byte(n), byte(n>>8))
+	}
+	if len(seqs) == 0 {
+		return out
+	}
+	defaultTablesOnce.Do(initDefaultTables)
+
+	// Turn offsets into offset values, using the repeated
+	// offsets when possible, in the order the decoder sees them,
+	// and count the codes.
+	var llCounts [maxLLCode + 1]uint32
+	var mlCounts [maxMLCode + 1]uint32
+	var ofCounts [maxOFCode + 1]uint32
+	codes := z.codes[:0]
+	for _, s := range seqs {
+		v := z.offsetValue(s)
+		c := seqCodes{ll: llCode(s.litLen), ml: mlCode(s.matchLen), of: ofCode(v), ofValue: v}
+		llCounts[c.ll]++
+		mlCounts[c.ml]++
+		ofCounts[c.of]++
+		codes = append(codes, c)
+	}
+	z.codes = codes
+
+	modes := len(out)
+	out = append(out, 0)
+	var llMode, ofMode, mlMode byte
+	var llEnc, ofEnc, mlEnc *fseEncTable
+	llMode, llEnc, out = appendSeqTable(out, llCounts[:], len(seqs), llDefaultNorm, llDefaultLog, llDefaultEnc, maxLLLog)
+	ofMode, ofEnc, out = appendSeqTable(out, ofCounts[:], len(seqs), ofDefaultNorm, ofDefaultLog, ofDefaultEnc, maxOFLog)
+	mlMode, mlEnc, out = appendSeqTable(out, mlCounts[:], len(seqs), mlDefaultNorm, mlDefaultLog, mlDefaultEnc, maxMLLog)
+	out[modes] = llMode<<6 | ofMode<<4 | mlMode<<2
+
+	// Sequences are encoded last to first.
+	bw := bitWriter{out: out}
+	var ll, ml, of fseEncoder
+	last := len(seqs) - 1
+	c := codes[last]
+	ml.init(mlEnc, c.ml)
+	of.init(ofEnc, c.of)
+	ll.init(llEnc, c.ll)
+	bw.add(seqs[last].litLen-llBase[c.ll], uint(llBits[c.ll]))
+	bw.add(seqs[last].matchLen-mlBase[c.ml], uint(mlBits[c.ml]))
+	bw.add(c.ofValue, uint(c.of))
+	for i := last - 1; i >= 0; i-- {
+		s, c := seqs[i], codes[i]
+		of.encode(&bw, c.of)
+		ml.encode(&bw, c.ml)
+		ll.encode(&bw, c.ll)
+		bw.add(s.litLen-llBase[c.ll], uint(llBits[c.ll]))
+		bw.add(s.matchLen-mlBase[c.ml], uint(mlBits[c.ml]))
+		bw.add(c.ofValue, uint(c.of))
+	}
+	ml.flush(&bw)
+	of.flush(&bw)
+	ll.flush(&bw)
+	return bw.close()
+}
+
+// appendSeqTable chooses how to code the symbols of one kind for
+// nseq sequences, given their counts: with the predefined table,
+// a table of their own, or as a single repeated symbol. It appends
+// the table description, if any, and returns the mode and the
+// encoding table.
+func appendSeqTable(out []byte, counts []uint32, nseq int, defNorm []int16, defLog int, defEnc *fseEncTable, maxLog int) (byte, *fseEncTable, []byte) {
+	maxSym := len(counts) - 1
+	for counts[maxSym] == 0 {
+		maxSym--
+	}
+	if counts[maxSym] == uint32(nseq) {
+		norm := make([]int16, maxSym+1)
+		norm[maxSym] = 1
+		return modeRLE, buildFSEEncTable(norm, 0), append(out, byte(maxSym))
+	}
+	predefined := fseCost(counts, defNorm, defLog)
+	log := fseTableLog(maxLog, nseq, maxSym)
+	norm := normalizeCounts(counts[:maxSym+1], nseq, log)
+	start := len(out)
+	out = appendFSETable(out, norm, log)
+	if predefined >= 0 && predefined <= fseCost(counts, norm, log)+8*(len(out)-start) {
+		return modePredefined, defEnc, out[:start]
+	}
+	return modeFSE, buildFSEEncTable(norm, log), out
+}
+
+// offsetValue returns the offset value encoding the offset of s,
+// and updates the repeated offsets as the decoder will.
+func (z *Writer) offsetValue(s sequence) uint32 {
+	r := z.rep
+	var v uint32
+	switch {
+	case s.offset == r[0] && s.litLen > 0:
+		return 1
+	case s.offset == r[1]:
+		z.rep = [3]uint32{r[1], r[0], r[2]}
+		v = 2
+	case s.offset == r[2]:
+		z.rep = [3]uint32{r[2], r[0], r[1]}
+		v = 3
+	case s.offset == r[0]-1 && s.litLen == 0:
+		z.rep = [3]uint32{s.offset, r[0], r[1]}
+		return 3
+	default:
+		z.rep = [3]uint32{s.offset, r[0], r[1]}
+		return s.offset + 3
+	}
+	if s.litLen == 0 {
+		// Without literals, the repeated offsets shift by one.
+		v--
+	}
+	return v
+}
+
+// llCode returns the literal length code for n.
+func llCode(n uint32) uint8 {
+	if n < 16 {
+		return uint8(n)
+	}
+	if n >= 64 {
+		return uint8(bits.Len32(n) - 1 + 19)
+	}
+	c := uint8(16)
+	for c < maxLLCode && llBase[c+1] <= n {
+		c++
+	}
+	return c
+}
+
+// mlCode returns the match length code for n.
+func mlCode(n uint32) uint8 {
+	if n < 35 {
+		return uint8(n - 3)
+	}
+	if n >= 131 {
+		return uint8(bits.Len32(n-3) - 1 + 36)
+	}
+	c := uint8(32)
+	for c < maxMLCode && mlBase[c+1] <= n {
+		c++
+	}
+	return c
+}
+
+// ofCode returns the offset code for the offset value v.
+func ofCode(v uint32) uint8 {
+	return uint8(bits.Len32(v) - 1)
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// xxhash64 computes the XXH64 hash with seed 0, which zstd uses
+// for content checksums.
+type xxhash64 struct {
+	v     [4]uint64
+	total uint64
+	buf   [32]byte
+	n     int // bytes in buf
+}
+
+const (
+	xxPrime1 = 11400714785074694791
+	xxPrime2 = 14029467366897019727
+	xxPrime3 = 1609587929392839161
+	xxPrime4 = 9650029242287828579
+	xxPrime5 = 2870177450012600261
+)
+
+func (h *xxhash64) reset() {
+	p1, p2 := uint64(xxPrime1), uint64(xxPrime2)
+	h.v[0] = p1 + p2
+	h.v[1] = p2
+	h.v[2] = 0
+	h.v[3] = -p1
+	h.total = 0
+	h.n = 0
+}
+
+func xxRound(acc, lane uint64) uint64 {
+	acc += lane * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func (h *xxhash64) write(p []byte) {
+	h.total += uint64(len(p))
+	if h.n > 0 {
+		c := copy(h.buf[h.n:], p)
+		h.n += c
+		p = p[c:]
+		if h.n < len(h.buf) {
+			return
+		}
+		h.stripe(h.buf[:])
+		h.n = 0
+	}
+	for len(p) >= 32 {
+		h.stripe(p[:32])
+		p = p[32:]
+	}
+	h.n = copy(h.buf[:], p)
+}
+
+func (h *xxhash64) stripe(p []byte) {
+	h.v[0] = xxRound(h.v[0], binary.LittleEndian.Uint64(p))
+	h.v[1] = xxRound(h.v[1], binary.LittleEndian.Uint64(p[8:]))
+	h.v[2] = xxRound(h.v[2], binary.LittleEndian.Uint64(p[16:]))
+	h.v[3] = xxRound(h.v[3], binary.LittleEndian.Uint64(p[24:]))
+}
+
+func (h *xxhash64) sum64() uint64 {
+	var acc uint64
+	if h.total >= 32 {
+		acc = bits.RotateLeft64(h.v[0], 1) + bits.RotateLeft64(h.v[1], 7) +
+			bits.RotateLeft64(h.v[2], 12) + bits.RotateLeft64(h.v[3], 18)
+		for _, v := range h.v {
+			acc ^= xxRound(0, v)
+			acc = acc*xxPrime1 + xxPrime4
+		}
+	} else {
+		acc = xxPrime5
+	}
+	acc += h.total
+
+	p := h.buf[:h.n]
+	for ; len(p) >= 8; p = p[8:] {
+		acc ^= xxRound(0, binary.LittleEndian.Uint64(p))
+		acc = bits.RotateLeft64(acc, 27)*xxPrime1 + xxPrime4
+	}
+	if len(p) >= 4 {
+		acc ^= uint64(binary.LittleEndian.Uint32(p)) * xxPrime1
+		acc = bits.RotateLeft64(acc, 23)*xxPrime2 + xxPrime3
+		p = p[4:]
+	}
+	for _, b := range p {
+		acc ^= uint64(b) * xxPrime5
+		acc = bits.RotateLeft64(acc, 11) * xxPrime1
+	}
+
+	acc ^= acc >> 33
+	acc *= xxPrime2
+	acc ^= acc >> 29
+	acc *= xxPrime3
+	acc ^= acc >> 32
+	return acc
+}
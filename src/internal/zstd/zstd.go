@@ -0,0 +1,292 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package zstd implements reading and writing of the Zstandard
+// compression format described in RFC 8878.
+//
+// The Writer is tuned for speed over compression ratio: it is used
+// by the linker to compress DWARF sections, where it replaces zlib
+// when link time matters. The Reader accepts any Zstandard stream
+// that does not use a dictionary.
+package zstd
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	frameMagic         = 0xFD2FB528
+	skippableMagic     = 0x184D2A50 // low 4 bits are free
+	skippableMagicMask = 0xFFFFFFF0
+
+	maxBlockSize = 128 << 10
+
+	// Block types.
+	blockRaw        = 0
+	blockRLE        = 1
+	blockCompressed = 2
+)
+
+var (
+	errBadMagic        = errors.New("zstd: invalid magic number")
+	errBadFrame        = errors.New("zstd: invalid frame header")
+	errDictionary      = errors.New("zstd: dictionaries are not supported")
+	errBadBlock        = errors.New("zstd: invalid block")
+	errBadBitstream    = errors.New("zstd: invalid bitstream")
+	errBadFSETable     = errors.New("zstd: invalid FSE table")
+	errBadHuffmanTable = errors.New("zstd: invalid Huffman table")
+	errBadLiterals     = errors.New("zstd: invalid literals section")
+	errBadSequences    = errors.New("zstd: invalid sequences section")
+	errChecksum        = errors.New("zstd: checksum mismatch")
+)
+
+// A Reader decompresses a Zstandard stream, which may consist of
+// several frames.
+type Reader struct {
+	in  io.Reader
+	err error // sticky error, io.EOF at the end of the input
+
+	hist   []byte // window of decoded data followed by the current block
+	off    int    // offset of the first unread byte in hist
+	window int    // window size of the current frame
+
+	inFrame  bool
+	checksum bool // the current frame has a content checksum
+	hash     xxhash64
+
+	block    []byte // contents of the current compressed block
+	literals []byte // decoded literals of the current block
+
+	// Entropy tables and repeated offsets carried from
+	// block to block within a frame.
+	huff                      huffTable
+	llTable, ofTable, mlTable fseTable
+	rep                       [3]uint32
+
+	scratch [14]byte
+}
+
+// NewReader returns a new Reader that decompresses data read from in.
+func NewReader(in io.Reader) *Reader {
+	r := new(Reader)
+	r.Reset(in)
+	return r
+}
+
+// Reset discards the state of r and makes it read from in,
+// keeping its buffers for reuse.
+func (r *Reader) Reset(in io.Reader) {
+	r.in = in
+	r.err = nil
+	r.hist = r.hist[:0]
+	r.off = 0
+	r.inFrame = false
+}
+
+// Read reads decompressed data into p.
+func (r *Reader) Read(p []byte) (int, error) {
+	for r.off == len(r.hist) {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.inFrame {
+			r.err = r.readBlock()
+		} else {
+			r.err = r.readFrameHeader()
+		}
+	}
+	n := copy(p, r.hist[r.off:])
+	r.off += n
+	return n, nil
+}
+
+// readFull reads exactly len(p) bytes, turning a short read into
+// io.ErrUnexpectedEOF.
+func (r *Reader) readFull(p []byte) error {
+	if _, err := io.ReadFull(r.in, p); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	return nil
+}
+
+// readFrameHeader reads the header of the next frame, skipping
+// skippable frames. It returns io.EOF at the end of the input.
+func (r *Reader) readFrameHeader() error {
+	magic := r.scratch[:4]
+	if _, err := io.ReadFull(r.in, magic); err != nil {
+		return err // io.EOF if there are no more frames
+	}
+	m := binary.LittleEndian.Uint32(magic)
+	if m&skippableMagicMask == skippableMagic {
+		if err := r.readFull(r.scratch[:4]); err != nil {
+			return err
+		}
+		size := int64(binary.LittleEndian.Uint32(r.scratch[:4]))
+		if n, err := io.CopyN(ioutil.Discard, r.in, size); n != size {
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		return nil
+	}
+	if m != frameMagic {
+		return errBadMagic
+	}
+
+	if err := r.readFull(r.scratch[:1]); err != nil {
+		return err
+	}
+	desc := r.scratch[0]
+	fcsFlag := desc >> 6
+	singleSegment := desc&(1<<5) != 0
+	if desc&(1<<3) != 0 {
+		return errBadFrame // reserved bit
+	}
+	r.checksum = desc&(1<<2) != 0
+	dictFlag := desc & 3
+
+	n := 0
+	if !singleSegment {
+		n++ // window descriptor
+	}
+	dictSize := [4]int{0, 1, 2, 4}[dictFlag]
+	n += dictSize
+	fcsSize := [4]int{0, 2, 4, 8}[fcsFlag]
+	if fcsFlag == 0 && singleSegment {
+		fcsSize = 1
+	}
+	n += fcsSize
+	hdr := r.scratch[:n]
+	if err := r.readFull(hdr); err != nil {
+		return err
+	}
+	if !singleSegment {
+		exp := uint(hdr[0] >> 3)
+		base := uint64(1) << (10 + exp)
+		size := base + base/8*uint64(hdr[0]&7)
+		if size > 1<<31 {
+			return errBadFrame
+		}
+		r.window = int(size)
+		hdr = hdr[1:]
+	}
+	var dict uint32
+	for i := 0; i < dictSize; i++ {
+		dict |= uint32(hdr[i]) << (8 * uint(i))
+	}
+	if dict != 0 {
+		return errDictionary
+	}
+	hdr = hdr[dictSize:]
+	if singleSegment {
+		var fcs uint64
+		for i := 0; i < fcsSize; i++ {
+			fcs |= uint64(hdr[i]) << (8 * uint(i))
+		}
+		if fcsSize == 2 {
+			fcs += 256
+		}
+		if fcs > 1<<31 {
+			return errBadFrame
+		}
+		r.window = int(fcs)
+	}
+
+	r.inFrame = true
+	r.hist = r.hist[:0]
+	r.off = 0
+	r.hash.reset()
+	r.huff = nil
+	r.llTable, r.ofTable, r.mlTable = nil, nil, nil
+	r.rep = [3]uint32{1, 4, 8}
+	return nil
+}
+
+// readBlock reads and decodes the next block of the current frame,
+// appending its contents to r.hist.
+func (r *Reader) readBlock() error {
+	// Drop history that is no longer within the window.
+	if len(r.hist) >= 2*r.window && len(r.hist) > maxBlockSize {
+		keep := r.window
+		copy(r.hist, r.hist[len(r.hist)-keep:])
+		r.hist = r.hist[:keep]
+		r.off = keep
+	}
+
+	if err := r.readFull(r.scratch[:3]); err != nil {
+		return err
+	}
+	h := uint32(r.scratch[0]) | uint32(r.scratch[1])<<8 | uint32(r.scratch[2])<<16
+	last := h&1 != 0
+	typ := (h >> 1) & 3
+	size := int(h >> 3)
+	if size > maxBlockSize {
+		return errBadBlock
+	}
+
+	start := len(r.hist)
+	switch typ {
+	case blockRaw:
+		r.hist = grow(r.hist, size)
+		if err := r.readFull(r.hist[start:]); err != nil {
+			return err
+		}
+	case blockRLE:
+		if err := r.readFull(r.scratch[:1]); err != nil {
+			return err
+		}
+		r.hist = grow(r.hist, size)
+		b := r.scratch[0]
+		for i := start; i < len(r.hist); i++ {
+			r.hist[i] = b
+		}
+	case blockCompressed:
+		if cap(r.block) < size {
+			r.block = make([]byte, size)
+		}
+		r.block = r.block[:size]
+		if err := r.readFull(r.block); err != nil {
+			return err
+		}
+		if err := r.decodeBlock(r.block); err != nil {
+			return err
+		}
+		if len(r.hist)-start > maxBlockSize {
+			return errBadBlock
+		}
+	default:
+		return errBadBlock
+	}
+	r.hash.write(r.hist[start:])
+
+	if last {
+		r.inFrame = false
+		if r.checksum {
+			if err := r.readFull(r.scratch[:4]); err != nil {
+				return err
+			}
+			if binary.LittleEndian.Uint32(r.scratch[:4]) != uint32(r.hash.sum64()) {
+				return errChecksum
+			}
+		}
+	}
+	return nil
+}
+
+// grow extends b by n bytes.
+func grow(b []byte, n int) []byte {
+	if len(b)+n <= cap(b) {
+		return b[:len(b)+n]
+	}
+	nb := make([]byte, len(b)+n, 2*cap(b)+n)
+	copy(nb, b)
+	return nb
+}
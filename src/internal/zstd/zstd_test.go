@@ -0,0 +1,344 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testInputs returns named inputs covering the different block
+// and sequence encodings.
+func testInputs(t testing.TB) map[string][]byte {
+	digits, err := ioutil.ReadFile("../../compress/testdata/e.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gettysburg, err := ioutil.ReadFile("../../compress/testdata/gettysburg.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	random := make([]byte, 300<<10)
+	rnd.Read(random)
+
+	// Data with long-distance repetition, to exercise the window.
+	var long bytes.Buffer
+	chunk := make([]byte, 64<<10)
+	rnd.Read(chunk)
+	for i := 0; i < 40; i++ {
+		long.Write(chunk)
+		long.WriteString(strings.Repeat("x", i))
+	}
+
+	return map[string][]byte{
+		"empty":      nil,
+		"byte":       []byte("a"),
+		"run":        bytes.Repeat([]byte{'z'}, 200<<10),
+		"digits":     digits,
+		"gettysburg": gettysburg,
+		"repeated":   bytes.Repeat(gettysburg, 300),
+		"random":     random,
+		"long":       long.Bytes(),
+	}
+}
+
+func compress(t testing.TB, data []byte, chunk int) []byte {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for p := data; len(p) > 0; {
+		n := chunk
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	for name, data := range testInputs(t) {
+		for _, chunk := range []int{1 << 30, 1000} {
+			if chunk < 1<<20 && len(data) > 1<<20 {
+				continue
+			}
+			comp := compress(t, data, chunk)
+			got, err := ioutil.ReadAll(NewReader(bytes.NewReader(comp)))
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("%s: round trip mismatch: got %d bytes, want %d", name, len(got), len(data))
+			}
+			if name == "repeated" && len(comp) > len(data)/20 {
+				t.Errorf("%s: compressed %d bytes to %d", name, len(data), len(comp))
+			}
+		}
+	}
+}
+
+func TestConcatenatedFrames(t *testing.T) {
+	a := compress(t, []byte("hello, "), 1<<20)
+	b := compress(t, []byte("world"), 1<<20)
+	skippable := []byte{0x50, 0x2A, 0x4D, 0x18, 3, 0, 0, 0, 'x', 'y', 'z'}
+	var in []byte
+	in = append(in, a...)
+	in = append(in, skippable...)
+	in = append(in, b...)
+	got, err := ioutil.ReadAll(NewReader(bytes.NewReader(in)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello, world" {
+		t.Errorf("got %q, want %q", got, "hello, world")
+	}
+}
+
+func TestCorrupt(t *testing.T) {
+	data := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog "), 100)
+	comp := compress(t, data, 1<<20)
+	for i := range comp {
+		bad := append([]byte(nil), comp...)
+		bad[i] ^= 0x55
+		got, err := ioutil.ReadAll(NewReader(bytes.NewReader(bad)))
+		if err == nil && !bytes.Equal(got, data) {
+			t.Errorf("corrupting byte %d: no error and wrong output", i)
+		}
+	}
+	for i := range comp {
+		if _, err := ioutil.ReadAll(NewReader(bytes.NewReader(comp[:i]))); err == nil && i > 0 {
+			t.Errorf("truncating to %d bytes: no error", i)
+		}
+	}
+}
+
+func TestXXHash(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"abc", 0x44bc2cf5ad770999},
+	}
+	for _, tt := range tests {
+		for _, split := range []int{0, 1, len(tt.in) / 2} {
+			if split > len(tt.in) {
+				continue
+			}
+			var h xxhash64
+			h.reset()
+			h.write([]byte(tt.in[:split]))
+			h.write([]byte(tt.in[split:]))
+			if got := h.sum64(); got != tt.want {
+				t.Errorf("xxhash64(%q) = %#x, want %#x", tt.in, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestDefaultTables(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		norm []int16
+		log  int
+	}{
+		{"literal lengths", llDefaultNorm, llDefaultLog},
+		{"match lengths", mlDefaultNorm, mlDefaultLog},
+		{"offsets", ofDefaultNorm, ofDefaultLog},
+	} {
+		sum := 0
+		for _, n := range tt.norm {
+			if n == -1 {
+				n = 1
+			}
+			sum += int(n)
+		}
+		if sum != 1<<uint(tt.log) {
+			t.Errorf("%s: probabilities sum to %d, want %d", tt.name, sum, 1<<uint(tt.log))
+		}
+	}
+}
+
+func TestFSETableRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		counts := make([]uint32, 1+rnd.Intn(53))
+		total := 0
+		for s := range counts {
+			if rnd.Intn(3) > 0 {
+				counts[s] = uint32(rnd.Intn(1 << uint(rnd.Intn(12))))
+				total += int(counts[s])
+			}
+		}
+		counts[len(counts)-1]++
+		total++
+		log := fseTableLog(9, total, len(counts)-1)
+		norm := normalizeCounts(counts, total, log)
+		desc := appendFSETable(nil, norm, log)
+		got, n, err := readFSETable(desc, len(counts)-1, 9)
+		if err != nil || n != len(desc) {
+			t.Fatalf("counts %v: read %d of %d bytes: %v", counts, n, len(desc), err)
+		}
+		want := buildFSETable(norm, log)
+		if len(got) != len(want) {
+			t.Fatalf("counts %v: got table of %d states, want %d", counts, len(got), len(want))
+		}
+		for j := range got {
+			if got[j] != want[j] {
+				t.Fatalf("counts %v: state %d: got %+v, want %+v", counts, j, got[j], want[j])
+			}
+		}
+	}
+}
+
+func TestHuffmanTableRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		var counts [256]uint32
+		nsym := 2 + rnd.Intn(255)
+		for s := 0; s < nsym; s++ {
+			if rnd.Intn(4) > 0 {
+				// Skewed counts need limited code lengths.
+				counts[s] = uint32(1 << uint(rnd.Intn(24)))
+			}
+		}
+		counts[0]++
+		counts[nsym-1]++
+		var e huffEncoder
+		e.build(&counts)
+		desc, ok := e.appendTable(nil)
+		if !ok {
+			if e.maxSym <= 128 {
+				t.Fatalf("no description for %d symbols", e.maxSym+1)
+			}
+			continue
+		}
+		tab, n, err := readHuffTable(desc)
+		if err != nil || n != len(desc) {
+			t.Fatalf("read %d of %d bytes: %v", n, len(desc), err)
+		}
+		if tab.maxBits() != e.maxBits || e.maxBits > maxHuffmanBits {
+			t.Fatalf("got max bits %d, want %d", tab.maxBits(), e.maxBits)
+		}
+		for s, c := range counts {
+			if c == 0 {
+				continue
+			}
+			got := tab[int(e.codes[s])<<uint(e.maxBits-int(e.lens[s]))]
+			if int(got.sym) != s || got.bits != e.lens[s] {
+				t.Fatalf("symbol %d: decodes as %+v, want %d bits", s, got, e.lens[s])
+			}
+		}
+	}
+}
+
+// TestReference checks interoperability with the reference
+// implementation, if the zstd command is installed.
+func TestReference(t *testing.T) {
+	zstd, err := exec.LookPath("zstd")
+	if err != nil {
+		t.Skip("zstd command not found")
+	}
+	dir := t.TempDir()
+	for name, data := range testInputs(t) {
+		in := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(in, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		// Decompress our output with zstd.
+		zin := filepath.Join(dir, name+".go.zst")
+		if err := ioutil.WriteFile(zin, compress(t, data, 1<<30), 0666); err != nil {
+			t.Fatal(err)
+		}
+		out, err := exec.Command(zstd, "-d", "-q", "-c", zin).Output()
+		if err != nil {
+			t.Errorf("%s: zstd -d: %v", name, err)
+		} else if !bytes.Equal(out, data) {
+			t.Errorf("%s: zstd -d produced different data", name)
+		}
+
+		// Decompress zstd's output at various levels.
+		for _, level := range []string{"-1", "-3", "-19", "--ultra -22"} {
+			args := append(strings.Fields(level), "-q", "-c", in)
+			comp, err := exec.Command(zstd, args...).Output()
+			if err != nil {
+				t.Fatalf("%s: zstd %s: %v", name, level, err)
+			}
+			got, err := ioutil.ReadAll(NewReader(bytes.NewReader(comp)))
+			if err != nil {
+				t.Errorf("%s: decompressing zstd %s output: %v", name, level, err)
+			} else if !bytes.Equal(got, data) {
+				t.Errorf("%s: decompressing zstd %s output: wrong data", name, level)
+			}
+		}
+	}
+}
+
+// TestTestdata decompresses files produced by the reference
+// implementation, which use Huffman coded literals and FSE
+// compressed sequence tables.
+func TestTestdata(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no test files")
+	}
+	for _, file := range files {
+		comp, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := ioutil.ReadFile(filepath.Join("../../compress/testdata", strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(NewReader(bytes.NewReader(comp)))
+		if err != nil {
+			t.Errorf("%s: %v", file, err)
+		} else if !bytes.Equal(got, want) {
+			t.Errorf("%s: wrong data", file)
+		}
+	}
+}
+
+func BenchmarkWriter(b *testing.B) {
+	data := bytes.Repeat(testInputs(b)["gettysburg"], 1000)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		w := NewWriter(ioutil.Discard)
+		w.Write(data)
+		w.Close()
+	}
+}
+
+func BenchmarkReader(b *testing.B) {
+	data := bytes.Repeat(testInputs(b)["gettysburg"], 1000)
+	comp := compress(b, data, 1<<30)
+	b.SetBytes(int64(len(data)))
+	r := NewReader(nil)
+	for i := 0; i < b.N; i++ {
+		r.Reset(bytes.NewReader(comp))
+		if _, err := io.Copy(ioutil.Discard, r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -14,9 +14,9 @@ TEXT ·mulWW(SB),NOSPLIT,$0
 	MOV	x+0(FP), X5
 	MOV	y+8(FP), X6
 	MULHU	X5, X6, X7
-	MUL	X5, X6, X8
+	MUL	X5, X6, X9
 	MOV	X7, z1+16(FP)
-	MOV	X8, z0+24(FP)
+	MOV	X9, z0+24(FP)
 	RET
 
 
@@ -0,0 +1,149 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Region allocation for package arena.
+//
+// A user arena hands out objects by bumping a pointer through large
+// chunks that are themselves ordinary heap objects. Each chunk holds
+// values of a single type, allocated as an array of that type, so the
+// garbage collector scans it with the type's own pointer bitmap and
+// no new GC machinery is needed. Pointer-free values of any type
+// share a single noscan chunk.
+//
+// An object allocated from an arena keeps its whole chunk alive
+// through its interior pointer, exactly as an element of an array
+// keeps the array alive. Freeing an arena only drops the arena's
+// references to its chunks; the memory is reclaimed by the next GC
+// cycles once no object in a chunk is reachable. This keeps arenas
+// memory safe: a use after Free observes valid memory rather than a
+// reused object. The saving comes from replacing many small
+// allocations with a few large ones, which makes allocation cheaper
+// and leaves far fewer objects for the GC to mark and sweep.
+
+package runtime
+
+import "unsafe"
+
+const (
+	// userArenaChunkBytes is the size of a typical arena chunk.
+	userArenaChunkBytes = 64 << 10
+
+	// Values larger than userArenaMaxObject are allocated
+	// directly from the heap. They gain little from sharing a
+	// chunk and would waste most of one.
+	userArenaMaxObject = userArenaChunkBytes / 8
+)
+
+// A userArena is the runtime state of an arena.Arena.
+// It is not safe for concurrent use.
+type userArena struct {
+	chunks map[*_type]*userArenaChunk // current chunk for each pointerful type
+	noscan userArenaChunk             // current chunk for pointer-free values
+	freed  bool
+}
+
+// A userArenaChunk is the unused tail of a chunk.
+type userArenaChunk struct {
+	base unsafe.Pointer
+	off  uintptr // offset of the first free byte
+	size uintptr // size of the chunk in bytes
+}
+
+//go:linkname arena_newArena arena.runtime_arena_newArena
+func arena_newArena() unsafe.Pointer {
+	return unsafe.Pointer(&userArena{chunks: make(map[*_type]*userArenaChunk)})
+}
+
+//go:linkname arena_arena_Free arena.runtime_arena_arena_Free
+func arena_arena_Free(arena unsafe.Pointer) {
+	a := (*userArena)(arena)
+	a.chunks = nil
+	a.noscan = userArenaChunk{}
+	a.freed = true
+}
+
+// arena_arena_New allocates a zeroed value of the type that typ, a
+// nil pointer such as (*T)(nil), points to, and returns a pointer to
+// it with the type of typ.
+//
+//go:linkname arena_arena_New arena.runtime_arena_arena_New
+func arena_arena_New(arena unsafe.Pointer, typ interface{}) interface{} {
+	t := efaceOf(&typ)._type
+	if t == nil || t.kind&kindMask != kindPtr {
+		panic(plainError("arena: New called with non-pointer type"))
+	}
+	elem := (*ptrtype)(unsafe.Pointer(t)).elem
+	var x interface{}
+	e := efaceOf(&x)
+	e._type = t
+	e.data = (*userArena)(arena).alloc(elem, 1)
+	return x
+}
+
+// arena_arena_MakeSlice allocates a slice of the type of typ, a nil
+// slice such as []T(nil), with the given length and capacity.
+//
+//go:linkname arena_arena_MakeSlice arena.runtime_arena_arena_MakeSlice
+func arena_arena_MakeSlice(arena unsafe.Pointer, typ interface{}, len, cap int) interface{} {
+	t := efaceOf(&typ)._type
+	if t == nil || t.kind&kindMask != kindSlice {
+		panic(plainError("arena: MakeSlice called with non-slice type"))
+	}
+	elem := (*slicetype)(unsafe.Pointer(t)).elem
+	if len < 0 {
+		panicmakeslicelen()
+	}
+	if cap < len {
+		panicmakeslicecap()
+	}
+	if elem.size != 0 && uintptr(cap) > maxAlloc/elem.size {
+		panicmakeslicecap()
+	}
+	var x interface{}
+	e := efaceOf(&x)
+	e._type = t
+	e.data = unsafe.Pointer(&slice{(*userArena)(arena).alloc(elem, uintptr(cap)), len, cap})
+	return x
+}
+
+// alloc returns a pointer to n zeroed, contiguous values of type typ.
+func (a *userArena) alloc(typ *_type, n uintptr) unsafe.Pointer {
+	if a.freed {
+		panic(plainError("arena: use of freed arena"))
+	}
+	size := typ.size * n
+	if size == 0 {
+		return unsafe.Pointer(&zerobase)
+	}
+	if size > userArenaMaxObject {
+		return mallocgc(size, typ, true)
+	}
+
+	var c *userArenaChunk
+	if typ.ptrdata == 0 {
+		c = &a.noscan
+		c.off = alignUp(c.off, uintptr(typ.align))
+	} else {
+		c = a.chunks[typ]
+		if c == nil {
+			c = new(userArenaChunk)
+			a.chunks[typ] = c
+		}
+	}
+	if c.off+size > c.size {
+		if typ.ptrdata == 0 {
+			c.base = mallocgc(userArenaChunkBytes, nil, true)
+			c.size = userArenaChunkBytes
+		} else {
+			// Allocate the chunk as an array of typ so the
+			// GC knows where its pointers are.
+			c.size = userArenaChunkBytes / typ.size * typ.size
+			c.base = mallocgc(c.size, typ, true)
+		}
+		c.off = 0
+	}
+	p := add(c.base, c.off)
+	c.off += size
+	return p
+}
@@ -84,6 +84,47 @@ GLOBL _rt0_amd64_lib_argc<>(SB),NOPTR, $8
 DATA _rt0_amd64_lib_argv<>(SB)/8, $0
 GLOBL _rt0_amd64_lib_argv<>(SB),NOPTR, $8
 
+// Features that the GOAMD64 microarchitecture levels require, as bits of
+// the registers CPUID and XGETBV report them in.
+
+// v2: SSE3, SSSE3, CMPXCHG16B, SSE4.1, SSE4.2, POPCNT; LAHF/SAHF.
+#define V2_FEATURES_CX (1<<0 | 1<<9 | 1<<13 | 1<<19 | 1<<20 | 1<<23)
+#define V2_EXT_FEATURES_CX (1<<0)
+// v3: FMA, MOVBE, OSXSAVE, AVX, F16C; LZCNT; BMI1, AVX2, BMI2;
+// OS support for XMM and YMM state.
+#define V3_FEATURES_CX (V2_FEATURES_CX | 1<<12 | 1<<22 | 1<<27 | 1<<28 | 1<<29)
+#define V3_EXT_FEATURES_CX (V2_EXT_FEATURES_CX | 1<<5)
+#define V3_EXT_FEATURES_BX (1<<3 | 1<<5 | 1<<8)
+#define V3_OS_SUPPORT_AX (1<<1 | 1<<2)
+// v4: AVX512F, AVX512DQ, AVX512CD, AVX512BW, AVX512VL;
+// OS support for opmask and ZMM state.
+#define V4_FEATURES_CX V3_FEATURES_CX
+#define V4_EXT_FEATURES_CX V3_EXT_FEATURES_CX
+#define V4_EXT_FEATURES_BX (V3_EXT_FEATURES_BX | 1<<16 | 1<<17 | 1<<28 | 1<<30 | 1<<31)
+#define V4_OS_SUPPORT_AX (V3_OS_SUPPORT_AX | 1<<5 | 1<<6 | 1<<7)
+
+#ifdef GOAMD64_v2
+#define NEED_MAX_CPUID 0x80000001
+#define NEED_FEATURES_CX V2_FEATURES_CX
+#define NEED_EXT_FEATURES_CX V2_EXT_FEATURES_CX
+#endif
+
+#ifdef GOAMD64_v3
+#define NEED_MAX_CPUID 0x80000001
+#define NEED_FEATURES_CX V3_FEATURES_CX
+#define NEED_EXT_FEATURES_CX V3_EXT_FEATURES_CX
+#define NEED_EXT_FEATURES_BX V3_EXT_FEATURES_BX
+#define NEED_OS_SUPPORT_AX V3_OS_SUPPORT_AX
+#endif
+
+#ifdef GOAMD64_v4
+#define NEED_MAX_CPUID 0x80000001
+#define NEED_FEATURES_CX V4_FEATURES_CX
+#define NEED_EXT_FEATURES_CX V4_EXT_FEATURES_CX
+#define NEED_EXT_FEATURES_BX V4_EXT_FEATURES_BX
+#define NEED_OS_SUPPORT_AX V4_OS_SUPPORT_AX
+#endif
+
 TEXT runtime·rt0_go(SB),NOSPLIT|TOPFRAME,$0
 	// copy arguments forward on an even stack
 	MOVQ	DI, AX		// argc
@@ -207,6 +248,46 @@ ok:
 	MOVQ	AX, g_m(CX)
 
 	CLD				// convention is D is always left cleared
+
+#ifdef NEED_MAX_CPUID
+	// Check that the processor has the features of the GOAMD64 level
+	// the program was built for. This is done once g0 is set up, so
+	// that bad_cpu can call into Go to report the failure.
+	MOVL	$0, AX
+	CPUID
+	CMPL	AX, $7
+	JLT	bad_cpu
+	MOVL	$0x80000000, AX
+	CPUID
+	CMPL	AX, $NEED_MAX_CPUID
+	JLT	bad_cpu
+	MOVL	$1, AX
+	CPUID
+	ANDL	$NEED_FEATURES_CX, CX
+	CMPL	CX, $NEED_FEATURES_CX
+	JNE	bad_cpu
+	MOVL	$0x80000001, AX
+	CPUID
+	ANDL	$NEED_EXT_FEATURES_CX, CX
+	CMPL	CX, $NEED_EXT_FEATURES_CX
+	JNE	bad_cpu
+#endif
+#ifdef NEED_EXT_FEATURES_BX
+	MOVL	$7, AX
+	MOVL	$0, CX
+	CPUID
+	ANDL	$NEED_EXT_FEATURES_BX, BX
+	CMPL	BX, $NEED_EXT_FEATURES_BX
+	JNE	bad_cpu
+#endif
+#ifdef NEED_OS_SUPPORT_AX
+	MOVL	$0, CX
+	XGETBV
+	ANDL	$NEED_OS_SUPPORT_AX, AX
+	CMPL	AX, $NEED_OS_SUPPORT_AX
+	JNE	bad_cpu
+#endif
+
 	CALL	runtime·check(SB)
 
 	MOVL	16(SP), AX		// copy argc
@@ -236,6 +317,32 @@ ok:
 	MOVQ	$runtime·debugCallV1<ABIInternal>(SB), AX
 	RET
 
+#ifdef NEED_MAX_CPUID
+bad_cpu: // show that the program requires a certain microarchitecture level.
+	MOVQ	$2, 0(SP)
+	MOVQ	$bad_cpu_msg<>(SB), AX
+	MOVQ	AX, 8(SP)
+	MOVQ	$84, 16(SP)
+	CALL	runtime·write(SB)
+	MOVQ	$1, 0(SP)
+	CALL	runtime·exit(SB)
+	CALL	runtime·abort(SB)
+	RET
+#endif
+
+#ifdef GOAMD64_v2
+DATA	bad_cpu_msg<>+0x00(SB)/84, $"This program can only be run on AMD64 processors with v2 microarchitecture support.\n"
+GLOBL	bad_cpu_msg<>(SB), RODATA, $84
+#endif
+#ifdef GOAMD64_v3
+DATA	bad_cpu_msg<>+0x00(SB)/84, $"This program can only be run on AMD64 processors with v3 microarchitecture support.\n"
+GLOBL	bad_cpu_msg<>(SB), RODATA, $84
+#endif
+#ifdef GOAMD64_v4
+DATA	bad_cpu_msg<>+0x00(SB)/84, $"This program can only be run on AMD64 processors with v4 microarchitecture support.\n"
+GLOBL	bad_cpu_msg<>(SB), RODATA, $84
+#endif
+
 // mainPC is a function value for runtime.main, to be passed to newproc.
 // The reference to runtime.main is made via ABIInternal, since the
 // actual function (not the ABI0 wrapper) is needed by newproc.
@@ -1929,3 +2036,17 @@ TEXT runtime·retpolineR12(SB),NOSPLIT,$0; RETPOLINE(12)
 TEXT runtime·retpolineR13(SB),NOSPLIT,$0; RETPOLINE(13)
 TEXT runtime·retpolineR14(SB),NOSPLIT,$0; RETPOLINE(14)
 TEXT runtime·retpolineR15(SB),NOSPLIT,$0; RETPOLINE(15)
+
+// The compiler and assembler's -spectre=retthunk mode rewrites
+// all RET instructions to be JMP retthunk. Like a retpoline,
+// the thunk's RET is predicted to return to the PAUSE loop
+// after its CALL, while it actually returns to the caller's
+// return address, which the thunk exposes by popping its own.
+TEXT runtime·retthunk(SB),NOSPLIT,$0
+	/*   CALL setup */      BYTE $0xE8; BYTE $(2+2); BYTE $0; BYTE $0; BYTE $0
+	/* nospec: */
+	/*   PAUSE */           BYTE $0xF3; BYTE $0x90
+	/*   JMP nospec */      BYTE $0xEB; BYTE $-(2+2)
+	/* setup: */
+	/*   LEAQ 8(SP), SP */  BYTE $0x48; BYTE $0x8D; BYTE $0x64; BYTE $0x24; BYTE $0x08
+	/*   RET */             BYTE $0xC3
@@ -145,6 +145,7 @@ TEXT gogo<>(SB), NOSPLIT|NOFRAME, $0
 	BL	runtime·save_g(SB)
 
 	MOVD	gobuf_sp(R5), R1
+	MOVD	gobuf_bp(R5), R29
 	MOVD	gobuf_lr(R5), R31
 #ifndef GOOS_aix
 	MOVD	24(R1), R2	// restore R2
@@ -153,6 +154,7 @@ TEXT gogo<>(SB), NOSPLIT|NOFRAME, $0
 	MOVD	gobuf_ret(R5), R3
 	MOVD	gobuf_ctxt(R5), R11
 	MOVD	R0, gobuf_sp(R5)
+	MOVD	R0, gobuf_bp(R5)
 	MOVD	R0, gobuf_ret(R5)
 	MOVD	R0, gobuf_lr(R5)
 	MOVD	R0, gobuf_ctxt(R5)
@@ -168,6 +170,7 @@ TEXT gogo<>(SB), NOSPLIT|NOFRAME, $0
 TEXT runtime·mcall(SB), NOSPLIT|NOFRAME, $0-8
 	// Save caller state in g->sched
 	MOVD	R1, (g_sched+gobuf_sp)(g)
+	MOVD	R29, (g_sched+gobuf_bp)(g)
 	MOVD	LR, R31
 	MOVD	R31, (g_sched+gobuf_pc)(g)
 	MOVD	R0, (g_sched+gobuf_lr)(g)
@@ -184,6 +187,7 @@ TEXT runtime·mcall(SB), NOSPLIT|NOFRAME, $0-8
 	MOVD	0(R11), R12			// code pointer
 	MOVD	R12, CTR
 	MOVD	(g_sched+gobuf_sp)(g), R1	// sp = m->g0->sched.sp
+	MOVD	(g_sched+gobuf_bp)(g), R29
 	MOVDU	R3, -8(R1)
 	MOVDU	R0, -8(R1)
 	MOVDU	R0, -8(R1)
@@ -242,6 +246,7 @@ switch:
 	MOVD	R5, g
 	BL	runtime·save_g(SB)
 	MOVD	(g_sched+gobuf_sp)(g), R1
+	MOVD	(g_sched+gobuf_bp)(g), R29
 
 	// call target function
 	MOVD	0(R11), R12	// code pointer
@@ -262,7 +267,9 @@ switch:
 	MOVD	m_curg(R3), g
 	BL	runtime·save_g(SB)
 	MOVD	(g_sched+gobuf_sp)(g), R1
+	MOVD	(g_sched+gobuf_bp)(g), R29
 	MOVD	R0, (g_sched+gobuf_sp)(g)
+	MOVD	R0, (g_sched+gobuf_bp)(g)
 	RET
 
 noswitch:
@@ -309,6 +316,7 @@ TEXT runtime·morestack(SB),NOSPLIT|NOFRAME,$0-0
 	// Called from f.
 	// Set g->sched to context in f.
 	MOVD	R1, (g_sched+gobuf_sp)(g)
+	MOVD	R29, (g_sched+gobuf_bp)(g)
 	MOVD	LR, R8
 	MOVD	R8, (g_sched+gobuf_pc)(g)
 	MOVD	R5, (g_sched+gobuf_lr)(g)
@@ -324,6 +332,7 @@ TEXT runtime·morestack(SB),NOSPLIT|NOFRAME,$0-0
 	MOVD	m_g0(R7), g
 	BL	runtime·save_g(SB)
 	MOVD	(g_sched+gobuf_sp)(g), R1
+	MOVD	(g_sched+gobuf_bp)(g), R29
 	MOVDU   R0, -(FIXED_FRAME+0)(R1)	// create a call frame on g0
 	BL	runtime·newstack(SB)
 
@@ -541,6 +550,7 @@ TEXT gosave_systemstack_switch<>(SB),NOSPLIT|NOFRAME,$0
 	ADD     $16, R31 // get past prologue (including r2-setting instructions when they're there)
 	MOVD	R31, (g_sched+gobuf_pc)(g)
 	MOVD	R1, (g_sched+gobuf_sp)(g)
+	MOVD	R29, (g_sched+gobuf_bp)(g)
 	MOVD	R0, (g_sched+gobuf_lr)(g)
 	MOVD	R0, (g_sched+gobuf_ret)(g)
 	// Assert ctxt is zero. See func save.
@@ -594,8 +604,9 @@ g0:
 	SUB $8, R1
 #endif
 	// Save room for two of our pointers, plus the callee
-	// save area that lives on the caller stack.
-	SUB	$(asmcgocallSaveOffset+16), R1
+	// save area that lives on the caller stack, skipping over
+	// the saved frame pointer below SP.
+	SUB	$(asmcgocallSaveOffset+24), R1
 	RLDCR	$0, R1, $~15, R1	// 16-byte alignment for gcc ABI
 	MOVD	R5, (asmcgocallSaveOffset+8)(R1)// save old g on stack
 	MOVD	(g_stack+stack_hi)(R5), R5
@@ -708,21 +719,24 @@ havem:
 	BL	runtime·save_g(SB)
 	MOVD	(g_sched+gobuf_sp)(g), R4 // prepare stack as R4
 	MOVD	(g_sched+gobuf_pc)(g), R5
-	MOVD	R5, -(24+FIXED_FRAME)(R4)       // "saved LR"; must match frame size
+	MOVD	R5, -(24+FIXED_FRAME+8)(R4)     // "saved LR"; must match frame size
+	MOVD	(g_sched+gobuf_bp)(g), R5
+	MOVD	R5, -(24+FIXED_FRAME+16)(R4)    // "saved FP", so that the frame pointer chain continues on the curg stack
 	// Gather our arguments into registers.
 	MOVD	fn+0(FP), R5
 	MOVD	frame+8(FP), R6
 	MOVD	ctxt+16(FP), R7
-	MOVD	$-(24+FIXED_FRAME)(R4), R1      // switch stack; must match frame size
+	MOVD	$-(24+FIXED_FRAME+8)(R4), R1    // switch stack; must match frame size
 	MOVD    R5, FIXED_FRAME+0(R1)
 	MOVD    R6, FIXED_FRAME+8(R1)
 	MOVD    R7, FIXED_FRAME+16(R1)
+	ADD	$-8, R1, R29                    // point FP at the "saved FP"
 	BL	runtime·cgocallbackg(SB)
 
 	// Restore g->sched (== m->curg->sched) from saved values.
 	MOVD	0(R1), R5
 	MOVD	R5, (g_sched+gobuf_pc)(g)
-	MOVD	$(24+FIXED_FRAME)(R1), R4       // must match frame size
+	MOVD	$(24+FIXED_FRAME+8)(R1), R4     // must match frame size
 	MOVD	R4, (g_sched+gobuf_sp)(g)
 
 	// Switch back to m->g0's stack and restore m->g0->sched.sp.
@@ -125,6 +125,7 @@ switch:
 	CALL	runtime·save_g(SB)
 	MOV	(g_sched+gobuf_sp)(g), T0
 	MOV	T0, X2
+	MOV	(g_sched+gobuf_bp)(g), X8
 
 	// call target function
 	MOV	0(CTXT), T1	// code pointer
@@ -135,7 +136,9 @@ switch:
 	MOV	m_curg(T0), g
 	CALL	runtime·save_g(SB)
 	MOV	(g_sched+gobuf_sp)(g), X2
+	MOV	(g_sched+gobuf_bp)(g), X8
 	MOV	ZERO, (g_sched+gobuf_sp)(g)
+	MOV	ZERO, (g_sched+gobuf_bp)(g)
 	RET
 
 noswitch:
@@ -143,7 +146,8 @@ noswitch:
 	// Using a tail call here cleans up tracebacks since we won't stop
 	// at an intermediate systemstack.
 	MOV	0(CTXT), T1	// code pointer
-	ADD	$8, X2
+	MOV	-8(X2), X8	// restore FP
+	ADD	$16, X2	// pop LR and FP slots
 	JMP	(T1)
 
 TEXT runtime·getcallerpc(SB),NOSPLIT|NOFRAME,$0-8
@@ -182,6 +186,7 @@ TEXT runtime·morestack(SB),NOSPLIT|NOFRAME,$0-0
 	// Called from f.
 	// Set g->sched to context in f.
 	MOV	X2, (g_sched+gobuf_sp)(g)
+	MOV	X8, (g_sched+gobuf_bp)(g)
 	MOV	T0, (g_sched+gobuf_pc)(g)
 	MOV	RA, (g_sched+gobuf_lr)(g)
 	MOV	CTXT, (g_sched+gobuf_ctxt)(g)
@@ -196,6 +201,7 @@ TEXT runtime·morestack(SB),NOSPLIT|NOFRAME,$0-0
 	MOV	m_g0(A0), g
 	CALL	runtime·save_g(SB)
 	MOV	(g_sched+gobuf_sp)(g), X2
+	MOV	(g_sched+gobuf_bp)(g), X8
 	// Create a stack frame on g0 to call newstack.
 	MOV	ZERO, -8(X2)	// Zero saved LR in frame
 	ADD	$-8, X2
@@ -239,10 +245,12 @@ TEXT gogo<>(SB), NOSPLIT|NOFRAME, $0
 	CALL	runtime·save_g(SB)
 
 	MOV	gobuf_sp(T0), X2
+	MOV	gobuf_bp(T0), X8
 	MOV	gobuf_lr(T0), RA
 	MOV	gobuf_ret(T0), A0
 	MOV	gobuf_ctxt(T0), CTXT
 	MOV	ZERO, gobuf_sp(T0)
+	MOV	ZERO, gobuf_bp(T0)
 	MOV	ZERO, gobuf_ret(T0)
 	MOV	ZERO, gobuf_lr(T0)
 	MOV	ZERO, gobuf_ctxt(T0)
@@ -276,6 +284,7 @@ TEXT runtime·procyield(SB),NOSPLIT,$0-0
 TEXT runtime·mcall(SB), NOSPLIT|NOFRAME, $0-8
 	// Save caller state in g->sched
 	MOV	X2, (g_sched+gobuf_sp)(g)
+	MOV	X8, (g_sched+gobuf_bp)(g)
 	MOV	RA, (g_sched+gobuf_pc)(g)
 	MOV	ZERO, (g_sched+gobuf_lr)(g)
 
@@ -289,6 +298,7 @@ TEXT runtime·mcall(SB), NOSPLIT|NOFRAME, $0-8
 	MOV	fn+0(FP), CTXT			// context
 	MOV	0(CTXT), T1			// code pointer
 	MOV	(g_sched+gobuf_sp)(g), X2	// sp = m->g0->sched.sp
+	MOV	(g_sched+gobuf_bp)(g), X8
 	ADD	$-16, X2
 	MOV	T0, 8(X2)
 	MOV	ZERO, 0(X2)
@@ -305,6 +315,7 @@ TEXT gosave_systemstack_switch<>(SB),NOSPLIT|NOFRAME,$0
 	ADD	$8, X31	// get past prologue
 	MOV	X31, (g_sched+gobuf_pc)(g)
 	MOV	X2, (g_sched+gobuf_sp)(g)
+	MOV	X8, (g_sched+gobuf_bp)(g)
 	MOV	ZERO, (g_sched+gobuf_lr)(g)
 	MOV	ZERO, (g_sched+gobuf_ret)(g)
 	// Assert ctxt is zero. See func save.
@@ -321,7 +332,7 @@ TEXT ·asmcgocall(SB),NOSPLIT,$0-20
 	MOV	fn+0(FP), X5
 	MOV	arg+8(FP), X10
 
-	MOV	X2, X8	// save original stack pointer
+	MOV	X2, X18	// save original stack pointer
 	MOV	g, X9
 
 	// Figure out if we need to switch to m->g0 stack.
@@ -338,12 +349,13 @@ TEXT ·asmcgocall(SB),NOSPLIT,$0-20
 
 	// Now on a scheduling stack (a pthread-created stack).
 g0:
-	// Save room for two of our pointers.
-	ADD	$-16, X2
+	// Save room for two of our pointers, skipping over the
+	// saved frame pointer below SP.
+	ADD	$-32, X2
 	MOV	X9, 0(X2)	// save old g on stack
 	MOV	(g_stack+stack_hi)(X9), X9
-	SUB	X8, X9, X8
-	MOV	X8, 8(X2)	// save depth in old g stack (can't just save SP, as stack might be copied during a callback)
+	SUB	X18, X9, X18
+	MOV	X18, 8(X2)	// save depth in old g stack (can't just save SP, as stack might be copied during a callback)
 
 	JALR	RA, (X5)
 
@@ -577,21 +589,24 @@ havem:
 	CALL	runtime·save_g(SB)
 	MOV	(g_sched+gobuf_sp)(g), X6 // prepare stack as X6
 	MOV	(g_sched+gobuf_pc)(g), X7
-	MOV	X7, -(24+8)(X6)		// "saved LR"; must match frame size
+	MOV	X7, -(24+16)(X6)	// "saved LR"; must match frame size
+	MOV	(g_sched+gobuf_bp)(g), X7
+	MOV	X7, -(24+24)(X6)	// "saved FP", so that the frame pointer chain continues on the curg stack
 	// Gather our arguments into registers.
 	MOV	fn+0(FP), X7
 	MOV	frame+8(FP), X8
 	MOV	ctxt+16(FP), X9
-	MOV	$-(24+8)(X6), X2	// switch stack; must match frame size
+	MOV	$-(24+16)(X6), X2	// switch stack; must match frame size
 	MOV	X7, 8(X2)
 	MOV	X8, 16(X2)
 	MOV	X9, 24(X2)
+	ADD	$-8, X2, X8	// point FP at the "saved FP"
 	CALL	runtime·cgocallbackg(SB)
 
 	// Restore g->sched (== m->curg->sched) from saved values.
 	MOV	0(X2), X7
 	MOV	X7, (g_sched+gobuf_pc)(g)
-	MOV	$(24+8)(X2), X6		// must match frame size
+	MOV	$(24+16)(X2), X6	// must match frame size
 	MOV	X6, (g_sched+gobuf_sp)(g)
 
 	// Switch back to m->g0's stack and restore m->g0->sched.sp.
@@ -301,7 +301,10 @@ func TestCallersDeferNilFuncPanicWithLoop(t *testing.T) {
 
 	}()
 
-	for i := 0; i < 1; i++ {
+	// The loop bound is not a constant, so that the compiler cannot
+	// tell that the loop runs only once and open-code the defer.
+	n := 1
+	for i := 0; i < n; i++ {
 		var f func()
 		defer f()
 	}
@@ -6,24 +6,28 @@ package runtime
 
 import "unsafe"
 
-func checkptrAlignment(p unsafe.Pointer, elem *_type, n uintptr) {
+// The checkptr functions are called by code compiled with -d=checkptr.
+// With -d=checkptrsite, site is the position and text of the conversion
+// being checked, which is reported if the check fails.
+
+func checkptrAlignment(p unsafe.Pointer, elem *_type, n uintptr, site string) {
 	// Check that (*[n]elem)(p) is appropriately aligned.
 	// Note that we allow unaligned pointers if the types they point to contain
 	// no pointers themselves. See issue 37298.
 	// TODO(mdempsky): What about fieldAlign?
 	if elem.ptrdata != 0 && uintptr(p)&(uintptr(elem.align)-1) != 0 {
-		throw("checkptr: misaligned pointer conversion")
+		checkptrFail("checkptr: misaligned pointer conversion", site)
 	}
 
 	// Check that (*[n]elem)(p) doesn't straddle multiple heap objects.
 	if size := n * elem.size; size > 1 && checkptrBase(p) != checkptrBase(add(p, size-1)) {
-		throw("checkptr: converted pointer straddles multiple allocations")
+		checkptrFail("checkptr: converted pointer straddles multiple allocations", site)
 	}
 }
 
-func checkptrArithmetic(p unsafe.Pointer, originals []unsafe.Pointer) {
+func checkptrArithmetic(p unsafe.Pointer, originals []unsafe.Pointer, site string) {
 	if 0 < uintptr(p) && uintptr(p) < minLegalPointer {
-		throw("checkptr: pointer arithmetic computed bad pointer value")
+		checkptrFail("checkptr: pointer arithmetic computed bad pointer value", site)
 	}
 
 	// Check that if the computed pointer p points into a heap
@@ -40,7 +44,15 @@ func checkptrArithmetic(p unsafe.Pointer, originals []unsafe.Pointer) {
 		}
 	}
 
-	throw("checkptr: pointer arithmetic result points to invalid allocation")
+	checkptrFail("checkptr: pointer arithmetic result points to invalid allocation", site)
+}
+
+// checkptrFail throws msg, after printing the conversion site, if known.
+func checkptrFail(msg, site string) {
+	if site != "" {
+		print("checkptr: unsafe conversion at ", site, "\n")
+	}
+	throw(msg)
 }
 
 // checkptrBase returns the base address for the allocation containing
@@ -52,3 +52,36 @@ func TestCheckPtr(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckPtrSite(t *testing.T) {
+	t.Parallel()
+	testenv.MustHaveGoRun(t)
+
+	exe, err := buildTestProg(t, "testprog", "-gcflags=all=-d=checkptr=1,checkptrsite=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		cmd  string
+		want string
+	}{
+		{"CheckPtrAlignmentPtr", "checkptr.go:27:19: (**int64)(unsafe.Pointer(uintptr(p) + 1))\n"},
+		{"CheckPtrArithmetic", "checkptr.go:33:31: unsafe.Pointer(i)\n"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.cmd, func(t *testing.T) {
+			t.Parallel()
+			got, err := testenv.CleanCmdEnv(exec.Command(exe, tc.cmd)).CombinedOutput()
+			if err != nil {
+				t.Log(err)
+			}
+			const prefix = "checkptr: unsafe conversion at "
+			if !strings.HasPrefix(string(got), prefix) || !strings.Contains(string(got), tc.want) {
+				t.Errorf("output:\n%s\n\nwant output starting with %q and containing %q", got, prefix, tc.want)
+			}
+		})
+	}
+}
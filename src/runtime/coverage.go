@@ -0,0 +1,78 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import (
+	"runtime/internal/atomic"
+	"unsafe"
+)
+
+// coverMeta describes the coverage counters of a package compiled with
+// -covermode. The linker collects them in moduledata.covmeta.
+// The layout must match cmd/compile/internal/coverage.Instrument.
+type coverMeta struct {
+	counters  *uint32
+	ncounters uintptr
+	mode      string // set, count, or atomic
+	blocks    string // a "file:line.col,line.col numstmt\n" line for each counter
+}
+
+// coverIncAtomic increments the counter at p. The compiler calls it to
+// update the counters of packages compiled with -covermode=atomic.
+func coverIncAtomic(p *uint32) {
+	atomic.Xadd(p, 1)
+}
+
+// coverFlush writes the coverage counters of the packages compiled with
+// -covermode to the file named by $GOCOVERPROFILE, in the format of
+// go test -coverprofile, so that go tool cover can report them.
+// It is called when the program exits normally.
+func coverFlush() {
+	var buf []byte
+	for _, md := range activeModules() {
+		for _, m := range md.covmeta {
+			if buf == nil {
+				buf = append(buf, "mode: "...)
+				buf = append(buf, m.mode...)
+				buf = append(buf, '\n')
+			}
+			ctrs := (*[1 << 28]uint32)(unsafe.Pointer(m.counters))[:m.ncounters:m.ncounters]
+			blocks := m.blocks
+			for i := range ctrs {
+				n := 0
+				for blocks[n] != '\n' {
+					n++
+				}
+				buf = append(buf, blocks[:n]...)
+				buf = append(buf, ' ')
+				var tmp [20]byte
+				buf = append(buf, itoa(tmp[:], uint64(atomic.Load(&ctrs[i])))...)
+				buf = append(buf, '\n')
+				blocks = blocks[n+1:]
+			}
+		}
+	}
+	if buf == nil {
+		return
+	}
+	name := gogetenv("GOCOVERPROFILE")
+	if name == "" {
+		return
+	}
+	fd := createCoverProfile(name)
+	if fd < 0 {
+		print("runtime: cannot create coverage profile ", name, "\n")
+		return
+	}
+	for len(buf) > 0 {
+		n := write(uintptr(fd), unsafe.Pointer(&buf[0]), int32(len(buf)))
+		if n <= 0 {
+			print("runtime: cannot write coverage profile ", name, "\n")
+			break
+		}
+		buf = buf[n:]
+	}
+	closefd(fd)
+}
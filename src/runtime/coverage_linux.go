@@ -0,0 +1,11 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+// createCoverProfile creates the coverage profile file name for
+// writing, and returns its file descriptor, or -1 on failure.
+func createCoverProfile(name string) int32 {
+	return open(&bytes(name + "\x00")[0], _O_WRONLY|_O_CREAT|_O_TRUNC|_O_CLOEXEC, 0666)
+}
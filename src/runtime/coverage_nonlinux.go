@@ -0,0 +1,15 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package runtime
+
+// createCoverProfile creates the coverage profile file name for
+// writing, and returns its file descriptor, or -1 on failure.
+// Writing coverage profiles is only implemented on Linux.
+func createCoverProfile(name string) int32 {
+	return -1
+}
@@ -363,8 +363,10 @@ func g2() {
 	var a foo
 	ap := &a
 	// The loop forces this defer to be heap-allocated and the remaining two
-	// to be stack-allocated.
-	for i := 0; i < 1; i++ {
+	// to be stack-allocated. Its bound is not a constant, so that the
+	// compiler cannot tell that it runs only once.
+	n := 1
+	for i := 0; i < n; i++ {
 		defer ap.method1()
 	}
 	defer ap.method2()
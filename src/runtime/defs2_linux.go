@@ -121,6 +121,9 @@ const (
 	ITIMER_PROF    = C.ITIMER_PROF
 
 	O_RDONLY  = C.O_RDONLY
+	O_WRONLY  = C.O_WRONLY
+	O_CREAT   = C.O_CREAT
+	O_TRUNC   = C.O_TRUNC
 	O_CLOEXEC = C.O_CLOEXEC
 
 	EPOLLIN       = C.POLLIN
@@ -24,6 +24,9 @@ import "C"
 
 const (
 	O_RDONLY    = C.O_RDONLY
+	O_WRONLY    = C.O_WRONLY
+	O_CREAT     = C.O_CREAT
+	O_TRUNC     = C.O_TRUNC
 	O_CLOEXEC   = C.O_CLOEXEC
 	SA_RESTORER = 0 // unused
 )
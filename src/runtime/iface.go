@@ -66,12 +66,12 @@ func getitab(inter *interfacetype, typ *_type, canfail bool) *itab {
 	m = (*itab)(persistentalloc(unsafe.Sizeof(itab{})+uintptr(len(inter.mhdr)-1)*sys.PtrSize, 0, &memstats.other_sys))
 	m.inter = inter
 	m._type = typ
-	// The hash is used in type switches. However, compiler statically generates itab's
-	// for all interface/type pairs used in switches (which are added to itabTable
-	// in itabsinit). The dynamically-generated itab's never participate in type switches,
-	// and thus the hash is irrelevant.
+	// The hash is used in type switches. It is a copy of typ.hash, as in
+	// the itabs the compiler generates statically. Dynamically-generated
+	// itabs participate in type switches when the code doing the
+	// conversion was compiled with -d=lazyitabs.
 	// Note: m.hash is _not_ the hash used for the runtime itabTable hash table.
-	m.hash = 0
+	m.hash = typ.hash
 	m.init()
 	itabAdd(m)
 	unlock(&itabLock)
@@ -91,6 +91,29 @@ finish:
 	panic(&TypeAssertionError{concrete: typ, asserted: &inter.typ, missingMethod: m.init()})
 }
 
+// An itabCache caches the itab of an interface/type pair for code
+// compiled with -d=lazyitabs, which converts to non-empty interfaces
+// without referring to an itab generated by the compiler. The compiler
+// emits a cache for each pair such code converts, with a nil tab.
+// Keep in sync with ../cmd/compile/internal/reflectdata/reflect.go:/^func.ITabCache.
+type itabCache struct {
+	tab   *itab
+	inter *interfacetype
+	_type *_type
+}
+
+// lazyitab returns the itab for the pair of c, filling in c.tab.
+// It is called when c.tab is nil. Since getitab returns the same itab
+// for every call, racing calls store the same value.
+func lazyitab(c *itabCache) *itab {
+	m := getitab(c.inter, c._type, false)
+	// Caches are not scanned by the garbage collector, like the itab
+	// table, so there is no need for a write barrier. Use an atomic
+	// store so that a reader that sees m also sees its fields.
+	atomic.StorepNoWB(unsafe.Pointer(&c.tab), unsafe.Pointer(m))
+	return m
+}
+
 // find finds the given interface/type pair in t.
 // Returns nil if the given interface/type pair isn't present.
 func (t *itabTableType) find(inter *interfacetype, typ *_type) *itab {
@@ -322,6 +345,9 @@ func convT2E(t *_type, elem unsafe.Pointer) (e eface) {
 	if msanenabled {
 		msanread(elem, t.size)
 	}
+	if asanenabled {
+		asanread(elem, t.size)
+	}
 	x := mallocgc(t.size, t, true)
 	// TODO: We allocate a zeroed object only to overwrite it with actual data.
 	// Figure out how to avoid zeroing. Also below in convT2Eslice, convT2I, convT2Islice.
@@ -395,6 +421,9 @@ func convT2Enoptr(t *_type, elem unsafe.Pointer) (e eface) {
 	if msanenabled {
 		msanread(elem, t.size)
 	}
+	if asanenabled {
+		asanread(elem, t.size)
+	}
 	x := mallocgc(t.size, t, false)
 	memmove(x, elem, t.size)
 	e._type = t
@@ -410,6 +439,9 @@ func convT2I(tab *itab, elem unsafe.Pointer) (i iface) {
 	if msanenabled {
 		msanread(elem, t.size)
 	}
+	if asanenabled {
+		asanread(elem, t.size)
+	}
 	x := mallocgc(t.size, t, true)
 	typedmemmove(t, x, elem)
 	i.tab = tab
@@ -425,6 +457,9 @@ func convT2Inoptr(tab *itab, elem unsafe.Pointer) (i iface) {
 	if msanenabled {
 		msanread(elem, t.size)
 	}
+	if asanenabled {
+		asanread(elem, t.size)
+	}
 	x := mallocgc(t.size, t, false)
 	memmove(x, elem, t.size)
 	i.tab = tab
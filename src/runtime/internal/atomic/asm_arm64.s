@@ -15,6 +15,11 @@ TEXT runtime∕internal∕atomic·Cas(SB), NOSPLIT, $0-17
 	MOVD	ptr+0(FP), R0
 	MOVW	old+8(FP), R1
 	MOVW	new+12(FP), R2
+#ifdef GOARM64_LSE
+	MOVW	R1, R3
+	CASALW	R3, (R0), R2
+	CMPW	R1, R3
+#else
 again:
 	LDAXRW	(R0), R3
 	CMPW	R1, R3
@@ -22,6 +27,7 @@ again:
 	STLXRW	R2, (R0), R3
 	CBNZ	R3, again
 ok:
+#endif
 	CSET	EQ, R0
 	MOVB	R0, ret+16(FP)
 	RET
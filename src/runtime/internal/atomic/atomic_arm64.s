@@ -77,20 +77,28 @@ TEXT runtime∕internal∕atomic·Store64(SB), NOSPLIT, $0-16
 TEXT runtime∕internal∕atomic·Xchg(SB), NOSPLIT, $0-20
 	MOVD	ptr+0(FP), R0
 	MOVW	new+8(FP), R1
+#ifdef GOARM64_LSE
+	SWPALW	R1, (R0), R2
+#else
 again:
 	LDAXRW	(R0), R2
 	STLXRW	R1, (R0), R3
 	CBNZ	R3, again
+#endif
 	MOVW	R2, ret+16(FP)
 	RET
 
 TEXT runtime∕internal∕atomic·Xchg64(SB), NOSPLIT, $0-24
 	MOVD	ptr+0(FP), R0
 	MOVD	new+8(FP), R1
+#ifdef GOARM64_LSE
+	SWPALD	R1, (R0), R2
+#else
 again:
 	LDAXR	(R0), R2
 	STLXR	R1, (R0), R3
 	CBNZ	R3, again
+#endif
 	MOVD	R2, ret+16(FP)
 	RET
 
@@ -106,6 +114,11 @@ TEXT runtime∕internal∕atomic·Cas64(SB), NOSPLIT, $0-25
 	MOVD	ptr+0(FP), R0
 	MOVD	old+8(FP), R1
 	MOVD	new+16(FP), R2
+#ifdef GOARM64_LSE
+	MOVD	R1, R3
+	CASALD	R3, (R0), R2
+	CMP	R1, R3
+#else
 again:
 	LDAXR	(R0), R3
 	CMP	R1, R3
@@ -113,6 +126,7 @@ again:
 	STLXR	R2, (R0), R3
 	CBNZ	R3, again
 ok:
+#endif
 	CSET	EQ, R0
 	MOVB	R0, ret+24(FP)
 	RET
@@ -124,22 +138,32 @@ ok:
 TEXT runtime∕internal∕atomic·Xadd(SB), NOSPLIT, $0-20
 	MOVD	ptr+0(FP), R0
 	MOVW	delta+8(FP), R1
+#ifdef GOARM64_LSE
+	LDADDALW	R1, (R0), R2
+	ADDW	R2, R1, R2
+#else
 again:
 	LDAXRW	(R0), R2
 	ADDW	R2, R1, R2
 	STLXRW	R2, (R0), R3
 	CBNZ	R3, again
+#endif
 	MOVW	R2, ret+16(FP)
 	RET
 
 TEXT runtime∕internal∕atomic·Xadd64(SB), NOSPLIT, $0-24
 	MOVD	ptr+0(FP), R0
 	MOVD	delta+8(FP), R1
+#ifdef GOARM64_LSE
+	LDADDALD	R1, (R0), R2
+	ADD	R2, R1, R2
+#else
 again:
 	LDAXR	(R0), R2
 	ADD	R2, R1, R2
 	STLXR	R2, (R0), R3
 	CBNZ	R3, again
+#endif
 	MOVD	R2, ret+16(FP)
 	RET
 
@@ -149,37 +173,55 @@ TEXT runtime∕internal∕atomic·Xchguintptr(SB), NOSPLIT, $0-24
 TEXT ·And8(SB), NOSPLIT, $0-9
 	MOVD	ptr+0(FP), R0
 	MOVB	val+8(FP), R1
+#ifdef GOARM64_LSE
+	MVN	R1, R2
+	LDCLRALB	R2, (R0), R3
+#else
 	LDAXRB	(R0), R2
 	AND	R1, R2
 	STLXRB	R2, (R0), R3
 	CBNZ	R3, -3(PC)
+#endif
 	RET
 
 TEXT ·Or8(SB), NOSPLIT, $0-9
 	MOVD	ptr+0(FP), R0
 	MOVB	val+8(FP), R1
+#ifdef GOARM64_LSE
+	LDORALB	R1, (R0), R2
+#else
 	LDAXRB	(R0), R2
 	ORR	R1, R2
 	STLXRB	R2, (R0), R3
 	CBNZ	R3, -3(PC)
+#endif
 	RET
 
 // func And(addr *uint32, v uint32)
 TEXT ·And(SB), NOSPLIT, $0-12
 	MOVD	ptr+0(FP), R0
 	MOVW	val+8(FP), R1
+#ifdef GOARM64_LSE
+	MVN	R1, R2
+	LDCLRALW	R2, (R0), R3
+#else
 	LDAXRW	(R0), R2
 	AND	R1, R2
 	STLXRW	R2, (R0), R3
 	CBNZ	R3, -3(PC)
+#endif
 	RET
 
 // func Or(addr *uint32, v uint32)
 TEXT ·Or(SB), NOSPLIT, $0-12
 	MOVD	ptr+0(FP), R0
 	MOVW	val+8(FP), R1
+#ifdef GOARM64_LSE
+	LDORALW	R1, (R0), R2
+#else
 	LDAXRW	(R0), R2
 	ORR	R1, R2
 	STLXRW	R2, (R0), R3
 	CBNZ	R3, -3(PC)
+#endif
 	RET
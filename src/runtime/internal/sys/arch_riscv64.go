@@ -7,7 +7,7 @@ package sys
 const (
 	_ArchFamily          = RISCV64
 	_DefaultPhysPageSize = 4096
-	_PCQuantum           = 4
+	_PCQuantum           = 2 // compressed instructions
 	_MinFrameSize        = 8
 	_StackAlign          = PtrSize
 )
@@ -14,6 +14,7 @@ const GoarchArm = 1
 const GoarchArmbe = 0
 const GoarchArm64 = 0
 const GoarchArm64be = 0
+const GoarchLoong64 = 0
 const GoarchPpc64 = 0
 const GoarchPpc64le = 0
 const GoarchMips = 0
@@ -14,6 +14,7 @@ const GoarchArm = 0
 const GoarchArmbe = 0
 const GoarchArm64 = 0
 const GoarchArm64be = 1
+const GoarchLoong64 = 0
 const GoarchPpc64 = 0
 const GoarchPpc64le = 0
 const GoarchMips = 0
@@ -13,17 +13,50 @@
 #ifdef GOOS_windows
 #define RARG0 CX
 #define RARG1 DX
+#define RARG2 R8
+#define RARG3 R9
 #else
 #define RARG0 DI
 #define RARG1 SI
+#define RARG2 DX
+#define RARG3 CX
 #endif
 
-// void runtime·libfuzzerCall(fn, arg0, arg1 uintptr)
-// Calls C function fn from libFuzzer and passes 2 arguments to it.
-TEXT	runtime·libfuzzerCall(SB), NOSPLIT, $0-24
+// void runtime·libfuzzerCall4(fn, fakePC uintptr, s1, s2 unsafe.Pointer, result uintptr)
+// Calls C function fn from libFuzzer and passes 4 arguments to it.
+TEXT	runtime·libfuzzerCall4(SB), NOSPLIT, $0-40
+	MOVQ	fn+0(FP), AX
+	MOVQ	fakePC+8(FP), RARG0
+	MOVQ	s1+16(FP), RARG1
+	MOVQ	s2+24(FP), RARG2
+	MOVQ	result+32(FP), RARG3
+
+	get_tls(R12)
+	MOVQ	g(R12), R14
+	MOVQ	g_m(R14), R13
+
+	// Switch to g0 stack.
+	MOVQ	SP, R12		// callee-saved, preserved across the CALL
+	MOVQ	m_g0(R13), R10
+	CMPQ	R10, R14
+	JE	call	// already on g0
+	MOVQ	(g_sched+gobuf_sp)(R10), SP
+call:
+	ANDQ	$~15, SP	// alignment for gcc ABI
+	CALL	AX
+	MOVQ	R12, SP
+	RET
+
+// void runtime·libfuzzerCallTraceIntCmp(fn, arg0, arg1, fakePC uintptr)
+// Calls C function fn from libFuzzer and passes 2 arguments to it, as
+// if it was called from fakePC. libFuzzer takes the return address of
+// its hooks as the PC of the comparison, which would otherwise be the
+// same for all of them.
+TEXT	runtime·libfuzzerCallTraceIntCmp(SB), NOSPLIT, $0-32
 	MOVQ	fn+0(FP), AX
 	MOVQ	arg0+8(FP), RARG0
 	MOVQ	arg1+16(FP), RARG1
+	MOVQ	fakePC+24(FP), R8
 
 	get_tls(R12)
 	MOVQ	g(R12), R14
@@ -37,6 +70,35 @@ TEXT	runtime·libfuzzerCall(SB), NOSPLIT, $0-24
 	MOVQ	(g_sched+gobuf_sp)(R10), SP
 call:
 	ANDQ	$~15, SP	// alignment for gcc ABI
-	CALL	AX
+	SUBQ	$8, SP
+	// Return from fn to the fakePC'th RET of the return sled,
+	// which returns to end_of_function.
+	MOVQ	$end_of_function<>(SB), BX
+	PUSHQ	BX
+	MOVQ	$ret_sled<>(SB), BX
+	ADDQ	R8, BX
+	PUSHQ	BX
+	JMP	AX
+	// Not reached; keeps the assembler's stack accounting balanced.
+	POPQ	BX
+	POPQ	BX
+	RET
+
+TEXT	end_of_function<>(SB), NOSPLIT, $0-0
 	MOVQ	R12, SP
 	RET
+
+#define REPEAT_8(a) a \
+	a \
+	a \
+	a \
+	a \
+	a \
+	a \
+	a
+
+#define REPEAT_512(a) REPEAT_8(REPEAT_8(REPEAT_8(a)))
+
+// The return sled: retSledSize one-byte RET instructions.
+TEXT	ret_sled<>(SB), NOSPLIT, $0-0
+	REPEAT_512(RET)
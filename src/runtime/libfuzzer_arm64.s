@@ -9,12 +9,14 @@
 
 // Based on race_arm64.s; see commentary there.
 
-// func runtime·libfuzzerCall(fn, arg0, arg1 uintptr)
-// Calls C function fn from libFuzzer and passes 2 arguments to it.
-TEXT	runtime·libfuzzerCall(SB), NOSPLIT, $0-24
+// func runtime·libfuzzerCall4(fn, fakePC uintptr, s1, s2 unsafe.Pointer, result uintptr)
+// Calls C function fn from libFuzzer and passes 4 arguments to it.
+TEXT	runtime·libfuzzerCall4(SB), NOSPLIT, $0-40
 	MOVD	fn+0(FP), R9
-	MOVD	arg0+8(FP), R0
-	MOVD	arg1+16(FP), R1
+	MOVD	fakePC+8(FP), R0
+	MOVD	s1+16(FP), R1
+	MOVD	s2+24(FP), R2
+	MOVD	result+32(FP), R3
 
 	MOVD	g_m(g), R10
 
@@ -29,3 +31,49 @@ call:
 	BL	R9
 	MOVD	R19, RSP
 	RET
+
+// func runtime·libfuzzerCallTraceIntCmp(fn, arg0, arg1, fakePC uintptr)
+// Calls C function fn from libFuzzer and passes 2 arguments to it, as
+// if it was called from fakePC. libFuzzer takes the return address of
+// its hooks as the PC of the comparison, which would otherwise be the
+// same for all of them.
+TEXT	runtime·libfuzzerCallTraceIntCmp(SB), NOSPLIT|NOFRAME, $0-32
+	MOVD	fn+0(FP), R9
+	MOVD	arg0+8(FP), R0
+	MOVD	arg1+16(FP), R1
+	MOVD	fakePC+24(FP), R8
+	MOVD	R30, R20	// callee-saved, restored by end_of_function
+
+	MOVD	g_m(g), R10
+
+	// Switch to g0 stack.
+	MOVD	RSP, R19	// callee-saved, restored by end_of_function
+	MOVD	m_g0(R10), R11
+	CMP	R11, g
+	BEQ	call	// already on g0
+	MOVD	(g_sched+gobuf_sp)(R11), R12
+	MOVD	R12, RSP
+call:
+	// Return from fn to the fakePC'th instruction of the return
+	// sled, which branches to end_of_function. Instructions are
+	// 4 bytes long, so the low 2 bits of fakePC are dropped.
+	MOVD	$ret_sled<>(SB), R30
+	AND	$~3, R8
+	ADD	R8, R30
+	JMP	(R9)
+
+TEXT	end_of_function<>(SB), NOSPLIT|NOFRAME, $0-0
+	MOVD	R19, RSP
+	MOVD	R20, R30
+	RET
+
+#define REPEAT_2(a) a a
+#define REPEAT_8(a) REPEAT_2(REPEAT_2(REPEAT_2(a)))
+#define REPEAT_128(a) REPEAT_2(REPEAT_8(REPEAT_8(a)))
+
+#define RET_SLED \
+	JMP	end_of_function<>(SB);
+
+// The return sled: retSledSize/4 branches to end_of_function.
+TEXT	ret_sled<>(SB), NOSPLIT|NOFRAME, $0-0
+	REPEAT_128(RET_SLED)
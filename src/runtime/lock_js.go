@@ -8,7 +8,8 @@
 package runtime
 
 import (
-	_ "unsafe"
+	"internal/abi"
+	"unsafe"
 )
 
 // js/wasm has no support for threads yet. There is no preemption.
@@ -230,13 +231,21 @@ func clearTimeoutEvent(id int32)
 // When no other goroutine is awake any more, beforeIdle resumes the handler goroutine. Now that the same goroutine
 // is running as was running when the call came in from JavaScript, execution can be safely passed back to JavaScript.
 func handleEvent() {
+	x := wasmExportCall
+	wasmExportCall = nil
+
 	e := &event{
 		gp:       getg(),
 		returned: false,
 	}
 	events = append(events, e)
 
-	eventHandler()
+	var frame unsafe.Pointer
+	if x != nil {
+		frame = x.call()
+	} else {
+		eventHandler()
+	}
 
 	clearIdleID()
 
@@ -247,12 +256,48 @@ func handleEvent() {
 	events[len(events)-1] = nil
 	events = events[:len(events)-1]
 
+	if x != nil {
+		// Only now store the results, as other calls of the same
+		// exported function may have used its frame in the meantime.
+		memmove(add(x.frame(), x.retOffset), add(frame, x.retOffset), x.frameSize-x.retOffset)
+	}
+
 	// return execution to JavaScript
 	pause(getcallersp() - 16)
 }
 
 var eventHandler func()
 
+// A wasmExport describes a function marked //go:wasmexport. The
+// compiler lays out one for each such function, followed by the frame
+// of frameSize bytes in which the function's WebAssembly export, which
+// the linker generates, passes the arguments and results as Go passes
+// them on the stack. The export stores the arguments, sets
+// wasmExportCall and resumes the program like a call from JavaScript,
+// and then loads the results.
+type wasmExport struct {
+	fn        unsafe.Pointer // *funcval
+	frameSize uintptr
+	retOffset uintptr
+}
+
+// wasmExportCall is the function whose export is being called, if any.
+var wasmExportCall *wasmExport
+
+func (x *wasmExport) frame() unsafe.Pointer {
+	return add(unsafe.Pointer(x), unsafe.Sizeof(*x))
+}
+
+// call calls the function with the arguments in x's frame, and
+// returns a copy of the frame that holds the results.
+func (x *wasmExport) call() unsafe.Pointer {
+	frame := mallocgc(x.frameSize, nil, true)
+	memmove(frame, x.frame(), x.retOffset)
+	var regs abi.RegArgs
+	reflectcall(nil, x.fn, frame, uint32(x.frameSize), uint32(x.retOffset), uint32(x.frameSize), &regs)
+	return frame
+}
+
 //go:linkname setEventHandler syscall/js.setEventHandler
 func setEventHandler(fn func()) {
 	eventHandler = fn
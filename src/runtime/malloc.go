@@ -1127,6 +1127,9 @@ func mallocgc(size uintptr, typ *_type, needzero bool) unsafe.Pointer {
 	if msanenabled {
 		msanmalloc(x, size)
 	}
+	if asanenabled {
+		asanunpoison(x, size)
+	}
 
 	mp.mallocing = 0
 	releasem(mp)
@@ -0,0 +1,51 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+// Static maps.
+//
+// With -d=staticmaps, the compiler replaces a package-level map
+// with string keys that is initialized with constant keys and
+// elements and never changed by a read-only perfect hash table, and
+// reads of the map by calls to staticmapindex. The table has a
+// power-of-two number of slots, each holding at most one key.
+// Finding the slot of a key takes two hashes: the first picks an
+// entry of disp, a power-of-two sized table of seeds, and the
+// second, seeded with that entry, picks the slot. The compiler
+// chooses the seeds so that no two keys share a slot, and fills the
+// unused slots with keys that belong in other slots.
+//
+// The elements are in a separate array with one more slot than the
+// keys, which holds the zero value, so that a map read is an index
+// of that array by the result of staticmapindex.
+
+// staticmaphash returns the hash of s with the given seed.
+// It must match cmd/compile/internal/walk.staticMapHash.
+func staticmaphash(s string, seed uint32) uint32 {
+	// FNV-1a, followed by the finalizer of MurmurHash3 to mix
+	// the seed into all bits.
+	h := seed ^ 2166136261
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+// staticmapindex returns the slot of key in keys, the key table of a
+// static map, or len(keys) if key is not in the map.
+func staticmapindex(key string, keys []string, disp []uint32) int {
+	seed := disp[staticmaphash(key, 0)&uint32(len(disp)-1)]
+	i := int(staticmaphash(key, seed) & uint32(len(keys)-1))
+	if keys[i] == key {
+		return i
+	}
+	return len(keys)
+}
@@ -529,7 +529,7 @@ func genRISCV64() {
 	l.restore()
 	p("MOV %d(X2), X1", l.stack)
 	p("MOV (X2), X31")
-	p("ADD $%d, X2", l.stack+8)
+	p("ADD $%d, X2", l.stack+16) // pop frame (including the space pushed by sigctxt.pushCall)
 	p("JMP (X31)")
 }
 
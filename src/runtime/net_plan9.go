@@ -14,7 +14,7 @@ func runtime_ignoreHangup() {
 }
 
 //go:linkname runtime_unignoreHangup internal/poll.runtime_unignoreHangup
-func runtime_unignoreHangup(sig string) {
+func runtime_unignoreHangup() {
 	getg().m.ignoreHangup = false
 }
 
@@ -126,5 +126,5 @@ TEXT ·asyncPreempt<ABIInternal>(SB),NOSPLIT|NOFRAME,$0-0
 	MOV 8(X2), X3
 	MOV 472(X2), X1
 	MOV (X2), X31
-	ADD $480, X2
+	ADD $488, X2
 	JMP (X31)
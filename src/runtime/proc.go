@@ -223,6 +223,7 @@ func main() {
 	}
 	fn := main_main // make an indirect call, as the linker doesn't know the address of the main package when laying down the runtime
 	fn()
+	coverFlush()
 	if raceenabled {
 		racefini()
 	}
@@ -254,6 +255,7 @@ func main() {
 // os_beforeExit is called from os.Exit(0).
 //go:linkname os_beforeExit os.runtime_beforeExit
 func os_beforeExit() {
+	coverFlush()
 	if raceenabled {
 		racefini()
 	}
@@ -658,6 +660,7 @@ func schedinit() {
 	stackinit()
 	mallocinit()
 	fastrandinit() // must run before mcommoninit
+	stackprotectinit()
 	mcommoninit(_g_.m, -1)
 	cpuinit()       // must run before alginit
 	alginit()       // maps must not be used before this call
@@ -792,6 +795,21 @@ func fastrandinit() {
 	getRandomData(s)
 }
 
+// stackProtectGuard is the value stored in the stack canaries of
+// functions compiled with -stackprotect.
+var stackProtectGuard uintptr
+
+func stackprotectinit() {
+	s := (*[unsafe.Sizeof(stackProtectGuard)]byte)(unsafe.Pointer(&stackProtectGuard))[:]
+	getRandomData(s)
+}
+
+// stackprotectfail is called by functions compiled with -stackprotect
+// when their stack canary has been overwritten.
+func stackprotectfail() {
+	throw("stack smashing detected")
+}
+
 // Mark gp ready to run.
 func ready(gp *g, traceskip int, next bool) {
 	if trace.enabled {
@@ -4262,6 +4280,9 @@ retry:
 		if msanenabled {
 			msanmalloc(unsafe.Pointer(gp.stack.lo), gp.stack.hi-gp.stack.lo)
 		}
+		if asanenabled {
+			asanunpoison(unsafe.Pointer(gp.stack.lo), gp.stack.hi-gp.stack.lo)
+		}
 	}
 	return gp
 }
@@ -1106,4 +1106,5 @@ var (
 )
 
 // Must agree with cmd/internal/objabi.Experiment.FramePointer.
-const framepointer_enabled = GOARCH == "amd64" || GOARCH == "arm64"
+const framepointer_enabled = GOARCH == "amd64" || GOARCH == "arm64" ||
+	GOARCH == "ppc64" || GOARCH == "ppc64le" || GOARCH == "riscv64"
@@ -412,6 +412,9 @@ func stackalloc(n uint32) stack {
 	if msanenabled {
 		msanmalloc(v, uintptr(n))
 	}
+	if asanenabled {
+		asanunpoison(v, uintptr(n))
+	}
 	if stackDebug >= 1 {
 		print("  allocated ", v, "\n")
 	}
@@ -449,6 +452,9 @@ func stackfree(stk stack) {
 	if msanenabled {
 		msanfree(v, n)
 	}
+	if asanenabled {
+		asanpoison(v, n)
+	}
 	if n < _FixedStack<<_NumStackOrders && n < _StackCacheSize {
 		order := uint8(0)
 		n2 := n
@@ -524,6 +530,8 @@ var ptrnames = []string{
 // | args from caller |
 // +------------------+ <- frame->argp
 // | caller's retaddr |
+// +------------------+ <- frame->fp
+// |   saved FP (*)   | (*) if framepointer_enabled && varp < fp
 // +------------------+ <- frame->varp
 // |     locals       |
 // +------------------+
@@ -668,6 +676,15 @@ func adjustframe(frame *stkframe, arg unsafe.Pointer) bool {
 		adjustpointer(adjinfo, unsafe.Pointer(frame.varp))
 	}
 
+	// On riscv64 and ppc64, the top word of the frame holds the
+	// frame pointer saved by the caller.
+	if (sys.ArchFamily == sys.RISCV64 || sys.ArchFamily == sys.PPC64) && frame.fp-frame.varp == sys.PtrSize {
+		if stackDebug >= 3 {
+			print("      saved fp\n")
+		}
+		adjustpointer(adjinfo, unsafe.Pointer(frame.varp))
+	}
+
 	// Adjust arguments.
 	if args.n > 0 {
 		if stackDebug >= 3 {
@@ -38,5 +38,8 @@ func retpolineR13()
 func retpolineR14()
 func retpolineR15()
 
+// Return thunk, used by -spectre=retthunk flag in cmd/asm, cmd/compile.
+func retthunk()
+
 //go:noescape
 func asmcgocall_no_g(fn, arg unsafe.Pointer)
@@ -397,6 +397,7 @@ type moduledata struct {
 	textsectmap []textsect
 	typelinks   []int32 // offsets from types
 	itablinks   []*itab
+	covmeta     []*coverMeta
 
 	ptab []ptabEntry
 
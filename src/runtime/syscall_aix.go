@@ -128,9 +128,9 @@ func syscall_chroot1(path uintptr) (err uintptr) {
 // like close, but must not split stack, for fork.
 //go:linkname syscall_close syscall.close
 //go:nosplit
-func syscall_close(fd int32) int32 {
-	_, err := syscall1(&libc_close, uintptr(fd))
-	return int32(err)
+func syscall_close(fd uintptr) (err uintptr) {
+	_, err = syscall1(&libc_close, fd)
+	return
 }
 
 //go:linkname syscall_dup2child syscall.dup2child
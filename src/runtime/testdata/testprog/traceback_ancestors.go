@@ -84,6 +84,10 @@ func recurseThenCallGo(w chan struct{}, frames int, goroutines int, main bool) {
 		return
 	}
 	recurseThenCallGo(w, frames, goroutines-1, main)
+	// Keep the call above from being a tail call, which the
+	// compiler would turn into a loop, dropping the frames the
+	// test is looking for.
+	runtime.KeepAlive(w)
 }
 
 func goroutineID() string {
@@ -21,6 +21,12 @@ import (
 
 const usesLR = sys.MinFrameSize > 0
 
+// injectedCallSize is the stack space sighandler pushes to save the LR
+// when it fakes a call to sigpanic or asyncPreempt on link register
+// architectures. On riscv64 it is one word larger than needed, so that
+// the frame pointer saved just below the interrupted SP survives.
+const injectedCallSize = (sys.MinFrameSize+sys.StackAlign-1)&^(sys.StackAlign-1) + sys.GoarchRiscv64*sys.PtrSize
+
 // Traceback over the deferred function calls.
 // Report them like calls that have been invoked but not started executing yet.
 func tracebackdefers(gp *g, callback func(*stkframe, unsafe.Pointer) bool, v unsafe.Pointer) {
@@ -295,6 +301,7 @@ func gentraceback(pc0, sp0, lr0 uintptr, gp *g, skip int, pcbuf *uintptr, max in
 		// (with R29 = RSP - 8 in Go functions).
 		// This is technically ABI-compatible but not standard.
 		// And it happens to end up mimicking the x86 layout.
+		// riscv64 and ppc64 follow the arm64 layout.
 		// Other architectures may make different decisions.
 		if frame.varp > frame.sp && framepointer_enabled {
 			frame.varp -= sys.PtrSize
@@ -515,7 +522,7 @@ func gentraceback(pc0, sp0, lr0 uintptr, gp *g, skip int, pcbuf *uintptr, max in
 		// before faking a call.
 		if usesLR && injectedCall {
 			x := *(*uintptr)(unsafe.Pointer(frame.sp))
-			frame.sp += alignUp(sys.MinFrameSize, sys.StackAlign)
+			frame.sp += injectedCallSize
 			f = findfunc(frame.pc)
 			frame.fn = f
 			if !f.valid() {
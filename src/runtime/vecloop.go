@@ -0,0 +1,81 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "unsafe"
+
+// Simple loops over slices.
+//
+// The compiler replaces some simple loops over slices with calls to
+// the functions below, which use vector instructions where they are
+// available (see arrayLoop in cmd/compile/internal/walk/range.go).
+//
+// A function that returns a bool does the work of its loop only if
+// the result is the same as running the loop one element at a time,
+// and reports whether it did. Otherwise, it changes nothing and the
+// compiled code runs the loop. That is the case if the loop would
+// panic with an index out of range, or if the destination overlaps
+// one of the sources at a higher address, so that the loop reads
+// elements it has already written. They also return false if there
+// is nothing to do.
+
+// loopmove implements
+//
+//	for i := range dst { dst[i] = src[i] }
+//
+// where dst has n elements of the given size, which contain no
+// pointers, and src has srclen elements.
+func loopmove(dst, src unsafe.Pointer, n, srclen int, size uintptr) bool {
+	if n == 0 || n > srclen || !loopDisjoint(dst, src, uintptr(n)*size) {
+		return false
+	}
+	memmove(dst, src, uintptr(n)*size)
+	return true
+}
+
+// loopset8 implements
+//
+//	for i := range b { b[i] = c }
+//
+// where b has n 1-byte elements.
+func loopset8(p unsafe.Pointer, n int, c uint8) {
+	if n <= 0 {
+		return
+	}
+	*(*uint8)(p) = c
+	for i := 1; i < n; i *= 2 {
+		m := i
+		if m > n-i {
+			m = n - i
+		}
+		memmove(add(p, uintptr(i)), p, uintptr(m))
+	}
+}
+
+// loopxor8 implements
+//
+//	for i := range dst { dst[i] = x[i] ^ y[i] }
+//
+// where dst has n 1-byte elements, and x and y have xlen and ylen.
+func loopxor8(dst, x, y unsafe.Pointer, n, xlen, ylen int) bool {
+	if n == 0 || n > xlen || n > ylen || !loopDisjoint(dst, x, uintptr(n)) || !loopDisjoint(dst, y, uintptr(n)) {
+		return false
+	}
+	xorbytes(dst, x, y, uintptr(n))
+	return true
+}
+
+// loopsum8 returns the sum of the n bytes at p.
+func loopsum8(p unsafe.Pointer, n int) uint {
+	return sumbytes(p, uintptr(n))
+}
+
+// loopDisjoint reports whether writing n bytes to dst, in
+// increasing order of address, never changes a byte of the n bytes
+// at src before it is read.
+func loopDisjoint(dst, src unsafe.Pointer, n uintptr) bool {
+	d := uintptr(dst) - uintptr(src)
+	return d == 0 || d >= n
+}
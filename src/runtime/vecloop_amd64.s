@@ -0,0 +1,108 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "go_asm.h"
+#include "textflag.h"
+
+// See vecloop.go for the requirements on overlapping operands.
+// All loops work in increasing order of address.
+
+// func xorbytes(dst, x, y unsafe.Pointer, n uintptr)
+TEXT runtime·xorbytes(SB), NOSPLIT, $0-32
+	MOVQ	dst+0(FP), DI
+	MOVQ	x+8(FP), SI
+	MOVQ	y+16(FP), DX
+	MOVQ	n+24(FP), CX
+	CMPQ	CX, $32
+	JB	loop16
+	CMPB	internal∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE	loop16
+loop32:
+	VMOVDQU	(SI), Y0
+	VPXOR	(DX), Y0, Y0
+	VMOVDQU	Y0, (DI)
+	ADDQ	$32, SI
+	ADDQ	$32, DX
+	ADDQ	$32, DI
+	SUBQ	$32, CX
+	CMPQ	CX, $32
+	JAE	loop32
+	VZEROUPPER
+loop16:
+	CMPQ	CX, $16
+	JB	tail
+	MOVOU	(SI), X0
+	MOVOU	(DX), X1
+	PXOR	X1, X0
+	MOVOU	X0, (DI)
+	ADDQ	$16, SI
+	ADDQ	$16, DX
+	ADDQ	$16, DI
+	SUBQ	$16, CX
+	JMP	loop16
+tail:
+	TESTQ	CX, CX
+	JEQ	done
+	MOVB	(SI), AX
+	XORB	(DX), AX
+	MOVB	AX, (DI)
+	INCQ	SI
+	INCQ	DX
+	INCQ	DI
+	DECQ	CX
+	JMP	tail
+done:
+	RET
+
+// func sumbytes(p unsafe.Pointer, n uintptr) uint
+TEXT runtime·sumbytes(SB), NOSPLIT, $0-24
+	MOVQ	p+0(FP), SI
+	MOVQ	n+8(FP), CX
+	XORQ	AX, AX
+	PXOR	X1, X1
+	CMPQ	CX, $32
+	JB	loop16
+	CMPB	internal∕cpu·X86+const_offsetX86HasAVX2(SB), $1
+	JNE	loop16
+	// Y2 holds four 64-bit partial sums.
+	VPXOR	Y2, Y2, Y2
+loop32:
+	VPSADBW	(SI), Y1, Y0
+	VPADDQ	Y0, Y2, Y2
+	ADDQ	$32, SI
+	SUBQ	$32, CX
+	CMPQ	CX, $32
+	JAE	loop32
+	VEXTRACTI128	$1, Y2, X0
+	VPADDQ	X0, X2, X2
+	VPSHUFD	$0x4e, X2, X0
+	VPADDQ	X0, X2, X2
+	VMOVQ	X2, AX
+	VZEROUPPER
+loop16:
+	CMPQ	CX, $16
+	JB	tail
+	// PSADBW against zero sums each half of X0
+	// into the low 16 bits of its 64-bit lane.
+	MOVOU	(SI), X0
+	PSADBW	X1, X0
+	MOVQ	X0, BX
+	ADDQ	BX, AX
+	PSHUFD	$0x4e, X0, X0
+	MOVQ	X0, BX
+	ADDQ	BX, AX
+	ADDQ	$16, SI
+	SUBQ	$16, CX
+	JMP	loop16
+tail:
+	TESTQ	CX, CX
+	JEQ	done
+	MOVBQZX	(SI), BX
+	ADDQ	BX, AX
+	INCQ	SI
+	DECQ	CX
+	JMP	tail
+done:
+	MOVQ	AX, ret+16(FP)
+	RET
@@ -0,0 +1,76 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// See vecloop.go for the requirements on overlapping operands.
+// All loops work in increasing order of address.
+
+// func xorbytes(dst, x, y unsafe.Pointer, n uintptr)
+TEXT runtime·xorbytes(SB), NOSPLIT|NOFRAME, $0-32
+	MOVD	dst+0(FP), R0
+	MOVD	x+8(FP), R1
+	MOVD	y+16(FP), R2
+	MOVD	n+24(FP), R3
+	CMP	$64, R3
+	BLT	tail
+loop64:
+	VLD1.P	64(R1), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P	64(R2), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VEOR	V0.B16, V4.B16, V4.B16
+	VEOR	V1.B16, V5.B16, V5.B16
+	VEOR	V2.B16, V6.B16, V6.B16
+	VEOR	V3.B16, V7.B16, V7.B16
+	VST1.P	[V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUB	$64, R3
+	CMP	$64, R3
+	BGE	loop64
+tail:
+	CMP	$16, R3
+	BLT	tail1
+	VLD1.P	16(R1), [V0.B16]
+	VLD1.P	16(R2), [V1.B16]
+	VEOR	V0.B16, V1.B16, V1.B16
+	VST1.P	[V1.B16], 16(R0)
+	SUB	$16, R3
+	B	tail
+tail1:
+	CBZ	R3, done
+	MOVBU.P	1(R1), R4
+	MOVBU.P	1(R2), R5
+	EORW	R4, R5, R5
+	MOVBU.P	R5, 1(R0)
+	SUB	$1, R3
+	B	tail1
+done:
+	RET
+
+// func sumbytes(p unsafe.Pointer, n uintptr) uint
+TEXT runtime·sumbytes(SB), NOSPLIT|NOFRAME, $0-24
+	MOVD	p+0(FP), R0
+	MOVD	n+8(FP), R1
+	MOVD	$0, R2
+	CMP	$32, R1
+	BLT	tail
+	// The low 64 bits of V4 hold the running sum.
+	VEOR	V4.B16, V4.B16, V4.B16
+loop32:
+	VLD1.P	32(R0), [V0.B16, V1.B16]
+	VUADDLV	V0.B16, V2
+	VUADDLV	V1.B16, V3
+	VADD	V2, V4
+	VADD	V3, V4
+	SUB	$32, R1
+	CMP	$32, R1
+	BGE	loop32
+	VMOV	V4.D[0], R2
+tail:
+	CBZ	R1, done
+	MOVBU.P	1(R0), R3
+	ADD	R3, R2
+	SUB	$1, R1
+	B	tail
+done:
+	MOVD	R2, ret+16(FP)
+	RET
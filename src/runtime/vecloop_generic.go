@@ -0,0 +1,26 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !amd64 && !arm64
+// +build !amd64,!arm64
+
+package runtime
+
+import "unsafe"
+
+// xorbytes sets the n bytes at dst to the xor of the bytes at x and y.
+func xorbytes(dst, x, y unsafe.Pointer, n uintptr) {
+	for i := uintptr(0); i < n; i++ {
+		*(*uint8)(add(dst, i)) = *(*uint8)(add(x, i)) ^ *(*uint8)(add(y, i))
+	}
+}
+
+// sumbytes returns the sum of the n bytes at p.
+func sumbytes(p unsafe.Pointer, n uintptr) uint {
+	var s uint
+	for i := uintptr(0); i < n; i++ {
+		s += uint(*(*uint8)(add(p, i)))
+	}
+	return s
+}
@@ -0,0 +1,18 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package runtime
+
+import "unsafe"
+
+// xorbytes sets the n bytes at dst to the xor of the bytes at x and y.
+//go:noescape
+func xorbytes(dst, x, y unsafe.Pointer, n uintptr)
+
+// sumbytes returns the sum of the n bytes at p.
+//go:noescape
+func sumbytes(p unsafe.Pointer, n uintptr) uint
@@ -82,5 +82,5 @@ func (v *Value) Store(x interface{}) {
 }
 
 // Disable/enable preemption, implemented in runtime.
-func runtime_procPin()
+func runtime_procPin() int
 func runtime_procUnpin()
@@ -291,4 +291,4 @@ func runtime_procUnpin()
 func runtime_LoadAcquintptr(ptr *uintptr) uintptr
 
 //go:linkname runtime_StoreReluintptr runtime/internal/atomic.StoreReluintptr
-func runtime_StoreReluintptr(ptr *uintptr, val uintptr) uintptr
+func runtime_StoreReluintptr(ptr *uintptr, val uintptr)
@@ -96,3 +96,10 @@ func handleEvent() {
 	result := f(this, args)
 	cb.Set("result", result)
 }
+
+// Externref is a reference to a value of the WebAssembly host, passed as
+// an externref parameter to a function marked //go:wasmexport. It is the
+// index of the slot of the table that the module exports as "externrefs"
+// that holds the reference while the function runs. The slot is cleared
+// when the function returns.
+type Externref uint32
@@ -198,6 +198,9 @@ func Read(fd int, p []byte) (n int, err error) {
 	if msanenabled && n > 0 {
 		msanWrite(unsafe.Pointer(&p[0]), n)
 	}
+	if asanenabled && n > 0 {
+		asanWrite(unsafe.Pointer(&p[0]), n)
+	}
 	return
 }
 
@@ -219,6 +222,9 @@ func Write(fd int, p []byte) (n int, err error) {
 	if msanenabled && n > 0 {
 		msanRead(unsafe.Pointer(&p[0]), n)
 	}
+	if asanenabled && n > 0 {
+		asanRead(unsafe.Pointer(&p[0]), n)
+	}
 	return
 }
 
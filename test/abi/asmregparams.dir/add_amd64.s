@@ -0,0 +1,10 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// func add(a, b int64) int64
+TEXT ·add(SB), 4, $0-24
+	MOVQ	a+0(FP), AX
+	ADDQ	b+8(FP), AX
+	MOVQ	AX, ret+16(FP)
+	RET
@@ -0,0 +1,20 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import "fmt"
+
+//go:registerparams
+func add(a, b int64) int64
+
+func main() {
+	if got := add(40, 2); got != 42 {
+		panic(fmt.Sprintf("add(40, 2) = %d, want 42", got))
+	}
+	f := add
+	if got := f(1, 2); got != 3 {
+		panic(fmt.Sprintf("f(1, 2) = %d, want 3", got))
+	}
+}
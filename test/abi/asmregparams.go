@@ -0,0 +1,13 @@
+// buildrundir
+
+//go:build amd64
+// +build amd64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test //go:registerparams on the Go declaration of an assembly
+// function, which is still called with its arguments on the stack.
+
+package ignored
@@ -0,0 +1,84 @@
+// run
+
+//go:build !wasm
+// +build !wasm
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that functions whose calling convention is set by
+// //go:registerparams or //go:noregisterparams can be used as
+// function values, which call them through an ABI bridge.
+
+package main
+
+import "fmt"
+
+//go:registerparams
+//go:noinline
+func reg(a, b int, s string) (int, string) {
+	return a*10 + b, s + "!"
+}
+
+//go:noregisterparams
+//go:noinline
+func stack(a, b int, s string) (int, string) {
+	return a*10 + b, s + "?"
+}
+
+//go:registerparams
+//go:noinline
+func sum(xs ...float64) (t float64) {
+	for _, x := range xs {
+		t += x
+	}
+	return
+}
+
+var global = reg
+
+func apply(f func(int, int, string) (int, string), a, b int) (int, string) {
+	return f(a, b, "x")
+}
+
+func check(what string, got int, gots string, want int, wants string) {
+	if got != want || gots != wants {
+		panic(fmt.Sprintf("%s: got %d, %q; want %d, %q", what, got, gots, want, wants))
+	}
+}
+
+func main() {
+	n, s := reg(1, 2, "a")
+	check("direct reg", n, s, 12, "a!")
+	n, s = stack(3, 4, "b")
+	check("direct stack", n, s, 34, "b?")
+
+	f := reg
+	n, s = f(5, 6, "c")
+	check("local reg", n, s, 56, "c!")
+	n, s = global(7, 8, "d")
+	check("global reg", n, s, 78, "d!")
+	n, s = apply(reg, 1, 3)
+	check("arg reg", n, s, 13, "x!")
+	n, s = apply(stack, 2, 4)
+	check("arg stack", n, s, 24, "x?")
+
+	fs := []func(int, int, string) (int, string){reg, stack}
+	n, s = fs[1](9, 9, "e")
+	check("slice stack", n, s, 99, "e?")
+
+	var iface interface{} = sum
+	if t := iface.(func(...float64) float64)(1, 2, 3.5); t != 6.5 {
+		panic(fmt.Sprintf("iface sum: got %v, want 6.5", t))
+	}
+
+	done := make(chan int)
+	go func(f func(int, int, string) (int, string)) {
+		n, _ := f(4, 2, "")
+		done <- n
+	}(reg)
+	if n := <-done; n != 42 {
+		panic(fmt.Sprintf("go reg: got %d, want 42", n))
+	}
+}
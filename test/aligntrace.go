@@ -0,0 +1,16 @@
+// +build amd64
+// compile -G=0 -d=align=2
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -d=align=2 traces the deferral of size calculations while
+// a type is declared, and their results when they resume.
+
+package p
+
+type T *struct {
+	next T
+	x    int64
+}
@@ -0,0 +1,30 @@
+// compile -l -d=allocsummary
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -d=allocsummary groups the heap allocation sites of a
+// package by allocated type, the most frequent type first.
+
+package p
+
+type T struct{ a, b int }
+
+var sink interface{}
+
+func F() *T { return &T{} }
+
+func G() *T { return new(T) }
+
+func H() *int {
+	x := 1
+	return &x
+}
+
+func K() {
+	var t T
+	sink = t
+}
+
+func L() T { return T{} }
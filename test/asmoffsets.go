@@ -0,0 +1,22 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that //go:asmoffsets reports names that are not types.
+
+package p
+
+import _ "sync"
+
+//go:asmoffsets T sync.Mutex
+
+//go:asmoffsets os.File // ERROR "package .os. is not imported"
+//go:asmoffsets sync.Nope // ERROR "sync.Nope is not a type"
+//go:asmoffsets V // ERROR "V is not a type"
+//go:asmoffsets Undefined // ERROR "Undefined is not a type"
+
+type T struct{ x int }
+
+var V int
@@ -0,0 +1,36 @@
+// +build 386 arm mips mipsle
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that 64-bit atomic operations on words known not to be 8-byte
+// aligned are reported on 32-bit systems.
+
+package p
+
+import "sync/atomic"
+
+type T struct {
+	a int32
+	n int64
+	m [2]int64
+}
+
+type U struct {
+	n int64
+	t T
+}
+
+var g T
+
+func f(t *T, u *U, s []T, p *int64) {
+	atomic.AddInt64(&t.n, 1) // ERROR "atomic.AddInt64: t.n is at offset 4 from an 8-byte aligned word"
+	atomic.AddInt64(&u.n, 1)
+	atomic.LoadInt64(&u.t.n)   // ERROR "atomic.LoadInt64: u.t.n is at offset 12 from an 8-byte aligned word"
+	atomic.StoreInt64(&g.n, 1) // ERROR "atomic.StoreInt64: g.n is at offset 4 from an 8-byte aligned word"
+	atomic.AddInt64(&s[1].m[1], 1)
+	atomic.AddInt64(p, 1)
+	atomic.AddInt32(&t.a, 1)
+}
@@ -0,0 +1,15 @@
+// errorcheck -caret -color=never
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -caret prints the source line of each error with the
+// token at its position underlined, counting columns in runes.
+
+package p
+
+func f() int {
+	x := undefinedName + 1 // ERROR "undefined: undefinedName\n\tx := undefinedName \+ 1 // ERROR .*\n\t {5}\^~{12}$"
+	return x + "héllo" + missing // ERROR "undefined: missing\n\treturn x \+ .h.llo. \+ missing // ERROR .*\n\t {21}\^~{6}$"
+}
@@ -0,0 +1,18 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test //go:cgolayout errors that do not need cgo.
+// The layout comparisons are tested in misc/cgo/errors.
+
+package p
+
+//go:cgolayout C.struct_point // ERROR "go:cgolayout: C.struct_point is not a C type known to cgo"
+type Point struct {
+	X, Y int32
+}
+
+//go:cgolayout C.struct_point // ERROR "misplaced go:cgolayout directive"
+var x int
@@ -0,0 +1,21 @@
+// run -gcflags=-d=checkptr,checkptrexclude=main
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -d=checkptrexclude turns off checkptr
+// instrumentation for the matching packages.
+
+package main
+
+import "unsafe"
+
+var x [16]byte
+
+var sink **int
+
+func main() {
+	// Misaligned, so checkptr would panic.
+	sink = (**int)(unsafe.Pointer(&x[1]))
+}
@@ -0,0 +1,30 @@
+// asmcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codegen
+
+import (
+	"arch"
+	"unsafe"
+)
+
+func prefetch(p *int) {
+	// amd64:"PREFETCHT0",-"CALL"
+	// arm64:"PRFM\t\\(R[0-9]+\\), PLDL1KEEP",-"CALL"
+	arch.Prefetch(unsafe.Pointer(p))
+}
+
+func prefetchStreamed(p *int) {
+	// amd64:"PREFETCHNTA",-"CALL"
+	// arm64:"PRFM\t\\(R[0-9]+\\), PLDL1STRM",-"CALL"
+	arch.PrefetchStreamed(unsafe.Pointer(p))
+}
+
+func equalLanes(b []byte, c byte) int {
+	// amd64:"XORQ\t\\(",-"MOVBLZX\t[1-7]\\("
+	// arm64:"MOVD\t\\(R[0-9]+\\)",-"MOVBU\t[1-7]\\("
+	return arch.FirstLane8(arch.EqualLanes8(arch.Load8(b), arch.Broadcast8(c)))
+}
@@ -31,3 +31,27 @@ func convertNeq0Q(x uint64, c bool) bool {
 	b := x&1 != 0
 	return c && b
 }
+
+func setEq(x, y int) bool {
+	// ppc64le/power10:"SETBC\t[$]2",-"ISEL"
+	// ppc64/power10:"SETBC\t[$]2",-"ISEL"
+	return x == y
+}
+
+func setNe(x, y int) bool {
+	// ppc64le/power10:"SETBCR\t[$]2",-"ISEL"
+	// ppc64/power10:"SETBCR\t[$]2",-"ISEL"
+	return x != y
+}
+
+func setLt(x, y uint32) bool {
+	// ppc64le/power10:"SETBC\t",-"ISEL"
+	// ppc64/power10:"SETBC\t",-"ISEL"
+	return x < y
+}
+
+func setGe(x, y int64) bool {
+	// ppc64le/power10:"SETBCR\t",-"ISEL"
+	// ppc64/power10:"SETBCR\t",-"ISEL"
+	return x >= y
+}
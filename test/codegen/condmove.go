@@ -400,3 +400,72 @@ func cmovFcmp1(s, t float64, a, b int) {
 	// arm64:"CSINC\tEQ", -"CSEL"
 	r5 = x5
 }
+
+func cmovmin(x, y int) int {
+	if y < x {
+		x = y
+	}
+	// amd64:"CMOVQ(LT|GT)"
+	// arm64:"CSEL\t(LT|GT)"
+	return x
+}
+
+func cmovabs(x int) int {
+	if x < 0 {
+		x = -x
+	}
+	// amd64:"CMOVQLT"
+	// arm64:"CSNEG\tGE"
+	return x
+}
+
+// The inner if joins the outer one at the return.
+func cmovclamp(x, lo, hi int) int {
+	if x < lo {
+		x = lo
+	} else if x > hi {
+		x = hi
+	}
+	// amd64:"CMOVQLT","CMOVQGT",-"JLT",-"JGT"
+	// arm64:"CSEL\tLT","CSEL\tGT",-"BLT",-"BGT"
+	return x
+}
+
+var gx, gy int
+
+type pair struct{ x, y int }
+
+// Loads from globals and from fields of a pointer
+// checked before the branch cannot fault.
+func cmovloadglobal(c bool) int {
+	var r int
+	if c {
+		r = gx
+	} else {
+		r = gy
+	}
+	// amd64:"CMOVQNE"
+	// arm64:"CSEL\tNE"
+	return r
+}
+
+func cmovloadfield(p *pair, c bool) int {
+	r := p.x
+	if c {
+		r = p.y
+	}
+	// amd64:"CMOVQNE"
+	// arm64:"CSEL\tNE"
+	return r
+}
+
+// The load is only safe when the branch is taken.
+func cmovloadnil(p *pair) int {
+	r := 0
+	if p != nil {
+		r = p.y
+	}
+	// amd64:-"CMOV"
+	// arm64:-"CSEL"
+	return r
+}
@@ -0,0 +1,26 @@
+// asmcheck -gcflags=-d=libfuzzer
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codegen
+
+// Check that with -d=libfuzzer each block bumps a saturating 8-bit
+// counter and comparisons call the libfuzzer hooks with a fake PC.
+
+// amd64:`MOVBLZX\t""\.\.stmp_\d+\(SB\)`,`CMPB\tAL, [$]-1`,`MOVB\t[$]1, ""\.\.stmp_\d+\(SB\)`
+func strEq(a, b string) bool {
+	// amd64:`MOVQ\t[$]\d+, 32\(SP\)`,`CALL\truntime\.libfuzzerHookStrCmp\(SB\)`
+	return a == b
+}
+
+func intLess(x, y int) bool {
+	// amd64:`CALL\truntime\.libfuzzerTraceCmp8\(SB\)`,-`libfuzzerHookStrCmp`
+	return x < y
+}
+
+func constLess(x int) bool {
+	// amd64:`CALL\truntime\.libfuzzerTraceConstCmp8\(SB\)`
+	return x > 0
+}
@@ -226,3 +226,13 @@ func nanGenerate32() float32 {
 	z1 := zero * inf
 	return z0 + z1
 }
+
+func constantFold() {
+	x := 2.5
+	// amd64:-"ROUNDSD",-"SQRTSD"
+	// arm64:-"FRINTMD",-"FRINTPD",-"FSQRTD"
+	sink64[0] = math.Floor(x) + math.Ceil(x) + math.Sqrt(x)
+	// amd64:-"ROUNDSD"
+	// arm64:-"FRINTZD",-"FRINTAD",-"FRINTND"
+	sink64[1] = math.Trunc(x) + math.Round(x) + math.RoundToEven(x)
+}
@@ -13,7 +13,8 @@ import "math/bits"
 // ----------------------- //
 
 func LeadingZeros(n uint) int {
-	// amd64:"BSRQ"
+	// amd64/v1:"BSRQ" amd64/v2:"BSRQ"
+	// amd64/v3:"LZCNTQ"
 	// s390x:"FLOGR"
 	// arm:"CLZ" arm64:"CLZ"
 	// mips:"CLZ"
@@ -22,7 +23,8 @@ func LeadingZeros(n uint) int {
 }
 
 func LeadingZeros64(n uint64) int {
-	// amd64:"BSRQ"
+	// amd64/v1:"BSRQ" amd64/v2:"BSRQ"
+	// amd64/v3:"LZCNTQ"
 	// s390x:"FLOGR"
 	// arm:"CLZ" arm64:"CLZ"
 	// mips:"CLZ"
@@ -31,7 +33,8 @@ func LeadingZeros64(n uint64) int {
 }
 
 func LeadingZeros32(n uint32) int {
-	// amd64:"BSRQ","LEAQ",-"CMOVQEQ"
+	// amd64/v1:"BSRQ","LEAQ",-"CMOVQEQ" amd64/v2:"BSRQ","LEAQ",-"CMOVQEQ"
+	// amd64/v3:"LZCNTL",-"CMOVQEQ"
 	// s390x:"FLOGR"
 	// arm:"CLZ" arm64:"CLZW"
 	// mips:"CLZ"
@@ -62,7 +65,8 @@ func LeadingZeros8(n uint8) int {
 // --------------- //
 
 func Len(n uint) int {
-	// amd64:"BSRQ"
+	// amd64/v1:"BSRQ" amd64/v2:"BSRQ"
+	// amd64/v3:"LZCNTQ"
 	// s390x:"FLOGR"
 	// arm:"CLZ" arm64:"CLZ"
 	// mips:"CLZ"
@@ -71,7 +75,8 @@ func Len(n uint) int {
 }
 
 func Len64(n uint64) int {
-	// amd64:"BSRQ"
+	// amd64/v1:"BSRQ" amd64/v2:"BSRQ"
+	// amd64/v3:"LZCNTQ"
 	// s390x:"FLOGR"
 	// arm:"CLZ" arm64:"CLZ"
 	// mips:"CLZ"
@@ -88,7 +93,8 @@ func SubFromLen64(n uint64) int {
 }
 
 func Len32(n uint32) int {
-	// amd64:"BSRQ","LEAQ",-"CMOVQEQ"
+	// amd64/v1:"BSRQ","LEAQ",-"CMOVQEQ" amd64/v2:"BSRQ","LEAQ",-"CMOVQEQ"
+	// amd64/v3:"LZCNTL",-"CMOVQEQ"
 	// s390x:"FLOGR"
 	// arm:"CLZ" arm64:"CLZ"
 	// mips:"CLZ"
@@ -118,7 +124,7 @@ func Len8(n uint8) int {
 //    bits.OnesCount    //
 // -------------------- //
 
-// amd64:".*x86HasPOPCNT"
+// amd64/v1:".*x86HasPOPCNT" amd64/v3:-".*x86HasPOPCNT"
 func OnesCount(n uint) int {
 	// amd64:"POPCNTQ"
 	// arm64:"VCNT","VUADDLV"
@@ -129,7 +135,7 @@ func OnesCount(n uint) int {
 	return bits.OnesCount(n)
 }
 
-// amd64:".*x86HasPOPCNT"
+// amd64/v1:".*x86HasPOPCNT" amd64/v3:-".*x86HasPOPCNT"
 func OnesCount64(n uint64) int {
 	// amd64:"POPCNTQ"
 	// arm64:"VCNT","VUADDLV"
@@ -140,7 +146,7 @@ func OnesCount64(n uint64) int {
 	return bits.OnesCount64(n)
 }
 
-// amd64:".*x86HasPOPCNT"
+// amd64/v1:".*x86HasPOPCNT" amd64/v3:-".*x86HasPOPCNT"
 func OnesCount32(n uint32) int {
 	// amd64:"POPCNTL"
 	// arm64:"VCNT","VUADDLV"
@@ -151,7 +157,7 @@ func OnesCount32(n uint32) int {
 	return bits.OnesCount32(n)
 }
 
-// amd64:".*x86HasPOPCNT"
+// amd64/v1:".*x86HasPOPCNT" amd64/v3:-".*x86HasPOPCNT"
 func OnesCount16(n uint16) int {
 	// amd64:"POPCNTL"
 	// arm64:"VCNT","VUADDLV"
@@ -275,7 +281,8 @@ func RotateLeftVariable32(n uint32, m int) uint32 {
 // ------------------------ //
 
 func TrailingZeros(n uint) int {
-	// amd64:"BSFQ","MOVL\t\\$64","CMOVQEQ"
+	// amd64/v1:"BSFQ","MOVL\t\\$64","CMOVQEQ" amd64/v2:"BSFQ","MOVL\t\\$64","CMOVQEQ"
+	// amd64/v3:"TZCNTQ",-"CMOVQEQ"
 	// arm:"CLZ"
 	// arm64:"RBIT","CLZ"
 	// s390x:"FLOGR"
@@ -288,7 +295,8 @@ func TrailingZeros(n uint) int {
 }
 
 func TrailingZeros64(n uint64) int {
-	// amd64:"BSFQ","MOVL\t\\$64","CMOVQEQ"
+	// amd64/v1:"BSFQ","MOVL\t\\$64","CMOVQEQ" amd64/v2:"BSFQ","MOVL\t\\$64","CMOVQEQ"
+	// amd64/v3:"TZCNTQ",-"CMOVQEQ"
 	// arm64:"RBIT","CLZ"
 	// s390x:"FLOGR"
 	// ppc64/power8:"ANDN","POPCNTD"
@@ -306,7 +314,8 @@ func TrailingZeros64Subtract(n uint64) int {
 }
 
 func TrailingZeros32(n uint32) int {
-	// amd64:"BTSQ\\t\\$32","BSFQ"
+	// amd64/v1:"BTSQ\\t\\$32","BSFQ" amd64/v2:"BTSQ\\t\\$32","BSFQ"
+	// amd64/v3:"TZCNTL",-"BTSQ"
 	// arm:"CLZ"
 	// arm64:"RBITW","CLZW"
 	// s390x:"FLOGR","MOVWZ"
@@ -444,6 +453,8 @@ func Add64(x, y, ci uint64) (r, co uint64) {
 	// ppc64: "ADDC", "ADDE", "ADDZE"
 	// ppc64le: "ADDC", "ADDE", "ADDZE"
 	// s390x:"ADDE","ADDC\t[$]-1,"
+	// mips64:"ADDVU","SGTU"
+	// riscv64:"ADD","SLTU"
 	return bits.Add64(x, y, ci)
 }
 
@@ -595,6 +606,8 @@ func Sub64(x, y, ci uint64) (r, co uint64) {
 	// amd64:"NEGL","SBBQ","NEGQ"
 	// arm64:"NEGS","SBCS","NGC","NEG",-"ADD",-"SUB",-"CMP"
 	// s390x:"SUBE"
+	// mips64:"SUBVU","SGTU"
+	// riscv64:"SUB","SLTU"
 	return bits.Sub64(x, y, ci)
 }
 
@@ -713,6 +726,7 @@ func Mul64(x, y uint64) (hi, lo uint64) {
 	// ppc64le:"MULHDU","MULLD"
 	// s390x:"MLGR"
 	// mips64: "MULVU"
+	// riscv64:"MULHU","MUL"
 	return bits.Mul64(x, y)
 }
 
@@ -739,3 +753,14 @@ func Div64degenerate(x uint64) (q, r uint64) {
 	// amd64:-"DIVQ"
 	return bits.Div64(0, x, 5)
 }
+
+func constantFold() (int, int) {
+	x := uint64(0x00f0f000)
+	// amd64:-"BSRQ",-"LZCNTQ"
+	// arm64:-"CLZ"
+	n := bits.Len64(x)
+	// amd64:-"POPCNTQ"
+	// arm64:-"VCNT"
+	m := bits.OnesCount64(x)
+	return n, m
+}
@@ -673,3 +673,58 @@ func zero_uint64_2(d1, d2 []uint64) {
 	d1[0], d1[1] = 0, 0 // arm64:"STP",-"MOVB",-"MOVH"
 	d2[1], d2[0] = 0, 0 // arm64:"STP",-"MOVB",-"MOVH"
 }
+
+// ------------------------------ //
+//    Combining struct fields     //
+// ------------------------------ //
+
+type hdr struct {
+	a, b, c, d byte
+}
+
+type halves struct {
+	a, b, c, d uint16
+}
+
+func store_fields_const(h *hdr) {
+	// amd64:`MOVL\s[$]67305985`,-`MOVB`
+	// arm64:`MOVW\sR[0-9]+,\s\(R[0-9]+\)`,-`MOVB`
+	// ppc64le:`MOVW\sR[0-9]+,\s\(R[0-9]+\)`,-`MOVB`
+	// ppc64:`MOVW\sR[0-9]+,\s\(R[0-9]+\)`,-`MOVB`
+	h.a, h.b, h.c, h.d = 1, 2, 3, 4
+}
+
+func store_fields_le(h *hdr, x uint32) {
+	// amd64:`MOVL\s[A-Z]+,\s\([A-Z]+\)`,-`MOVB`
+	// arm64:`MOVW\sR[0-9]+,\s\(R[0-9]+\)`,-`MOVB`
+	// ppc64le:`MOVW\sR[0-9]+,\s\(R[0-9]+\)`,-`MOVB`
+	h.a, h.b, h.c, h.d = byte(x), byte(x>>8), byte(x>>16), byte(x>>24)
+}
+
+func store_fields_be(h *hdr, x uint32) {
+	// amd64:`BSWAPL`,-`MOVB`
+	// arm64:`REVW`,-`MOVB`
+	// ppc64:`MOVW\sR[0-9]+,\s\(R[0-9]+\)`,-`MOVB`
+	h.a, h.b, h.c, h.d = byte(x>>24), byte(x>>16), byte(x>>8), byte(x)
+}
+
+func store_halves(h *halves, x uint64) {
+	// amd64:`MOVQ\s[A-Z]+,\s\([A-Z]+\)`,-`MOVW`
+	// arm64:`MOVD\sR[0-9]+,\s\(R[0-9]+\)`,-`MOVH`
+	// ppc64le:`MOVD\sR[0-9]+,\s\(R[0-9]+\)`,-`MOVH`
+	h.a, h.b, h.c, h.d = uint16(x), uint16(x>>16), uint16(x>>32), uint16(x>>48)
+}
+
+func load_fields_le(h *hdr) uint32 {
+	// amd64:`MOVL\s\([A-Z]+\)`,-`MOVBLZX`,-`OR`
+	// arm64:`MOVWU\s\(R[0-9]+\)`,-`MOVBU`,-`ORR`
+	// ppc64le:`MOVWZ\s\(R[0-9]+\)`,-`MOVBZ`,-`OR\s`
+	return uint32(h.a) | uint32(h.b)<<8 | uint32(h.c)<<16 | uint32(h.d)<<24
+}
+
+func load_fields_be(h *hdr) uint32 {
+	// amd64:`BSWAPL`,-`MOVBLZX`
+	// arm64:`REVW`,-`MOVBU`
+	// ppc64:`MOVWZ\s\(R[0-9]+\)`,-`MOVBZ`
+	return uint32(h.d) | uint32(h.c)<<8 | uint32(h.b)<<16 | uint32(h.a)<<24
+}
@@ -0,0 +1,33 @@
+// asmcheck -race -gcflags=-d=racefields
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codegen
+
+// Check that with -d=racefields a struct store is instrumented
+// with one call per field, at the field's offset and width.
+
+type T struct {
+	a int
+	b [4]int32
+	c struct{ d, e int8 }
+	_ int
+}
+
+func store(p *T, v T) {
+	// amd64:"CALL\truntime.racewrite\\(SB\\)","LEAQ\t8\\(AX\\)","MOVQ\t[$]16, 8\\(SP\\)","CALL\truntime.racewriterange\\(SB\\)"
+	// amd64:"LEAQ\t24\\(AX\\)","LEAQ\t25\\(AX\\)",-"LEAQ\t32\\(AX\\)"
+	*p = v
+}
+
+type U struct {
+	x int64
+	y int16
+}
+
+func storeU(p *U, v U) {
+	// amd64:"CALL\truntime.racewrite\\(SB\\)","LEAQ\t8\\(AX\\)",-"racewriterange"
+	*p = v
+}
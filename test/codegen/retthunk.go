@@ -0,0 +1,13 @@
+// +build amd64
+// asmcheck -gcflags=-spectre=retthunk
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codegen
+
+func Return(x int) int {
+	// amd64:`JMP\truntime.retthunk`,-`RET`
+	return x + 1
+}
@@ -36,3 +36,12 @@ func SliceSlice(x []float64, i, j int) []float64 {
 	// amd64:`CMOVQHI`
 	return x[i:j]
 }
+
+// The index of a range loop is only masked by -spectre=load.
+func RangeSlice(x []int) int {
+	s := 0
+	for _, v := range x { // amd64:-`CMOVQ`
+		s += v
+	}
+	return s
+}
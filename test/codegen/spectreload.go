@@ -0,0 +1,21 @@
+// +build amd64
+// asmcheck -gcflags=-spectre=load
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codegen
+
+func RangeSlice(x []int) int {
+	s := 0
+	for _, v := range x { // amd64:`CMOVQ(CC|LS)`
+		s += v
+	}
+	return s
+}
+
+func IndexSlice(x []float64, i int) float64 {
+	// amd64:`CMOVQLS`
+	return x[i]
+}
@@ -0,0 +1,27 @@
+// asmcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codegen
+
+// Loops with small constant trip counts are fully unrolled,
+// and the bounds checks on the constant indexes disappear.
+
+func SumArray(a *[4]int) int {
+	s := 0
+	// amd64:-`JMP`,-`JLT`,-`JGE`,-`panicIndex`
+	// arm64:-`JMP`,-`BLT`,-`BGE`,-`panicIndex`
+	for i := 0; i < 4; i++ {
+		s += a[i]
+	}
+	return s
+}
+
+func XorSlice(dst, src []byte) {
+	// amd64:-`JMP`,-`JLT`,-`JGE`
+	for i := 0; i < 3; i++ {
+		dst[i] ^= src[i]
+	}
+}
@@ -0,0 +1,50 @@
+// asmcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codegen
+
+// Test that simple loops over slices are run by the runtime,
+// which uses vector instructions.
+
+func CopyLoop(dst, src []uint32) {
+	// amd64:`CALL\truntime.loopmove`
+	// arm64:`CALL\truntime.loopmove`
+	for i := range dst {
+		dst[i] = src[i]
+	}
+}
+
+func SetLoop(b []byte) {
+	// amd64:`CALL\truntime.loopset8`
+	// arm64:`CALL\truntime.loopset8`
+	for i := range b {
+		b[i] = ' '
+	}
+}
+
+func XorLoop(dst, a, b []byte) {
+	// amd64:`CALL\truntime.loopxor8`
+	// arm64:`CALL\truntime.loopxor8`
+	for i := range dst {
+		dst[i] = a[i] ^ b[i]
+	}
+}
+
+func SumLoop(b []byte) (s uint32) {
+	// amd64:`CALL\truntime.loopsum8`
+	// arm64:`CALL\truntime.loopsum8`
+	for _, x := range b {
+		s += uint32(x)
+	}
+	return s
+}
+
+func NotLocal(dst []byte, p *[]byte) {
+	// amd64:-`runtime.loopmove`
+	for i := range dst {
+		dst[i] = (*p)[i]
+	}
+}
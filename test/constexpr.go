@@ -0,0 +1,88 @@
+// errorcheck -0 -d=constexpr
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that package initializers calling pure functions with
+// constant arguments are evaluated at compile time.
+
+package p
+
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+var f10 = fib(10) // ERROR "evaluated call to fib at compile time"
+
+func squares(n int) []uint16 {
+	s := make([]uint16, n)
+	for i := range s {
+		s[i] = uint16(i * i)
+	}
+	return s
+}
+
+var sq = squares(16) // ERROR "evaluated call to squares at compile time"
+
+type point struct {
+	x, y float64
+}
+
+func diag(n int) (a [4]point) {
+	for i := 0; i < n; i++ {
+		a[i] = point{float64(i), float64(i) / 2}
+	}
+	return
+}
+
+var d = diag(3) // ERROR "evaluated call to diag at compile time"
+
+func upper(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'a' <= c && c <= 'z' {
+			b[i] = c - 'a' + 'A'
+		}
+	}
+	return string(b)
+}
+
+var u = upper("hello") // ERROR "evaluated call to upper at compile time"
+
+var g int
+
+func global(n int) int {
+	return n + g
+}
+
+var gl = global(1) // reads a global: not evaluated
+
+func forever(n int) int {
+	for {
+		n++
+	}
+}
+
+var fe = forever(0) // out of fuel: not evaluated
+
+func div(a, b int) int {
+	return a / b
+}
+
+var dz = div(1, 0) // panics: not evaluated
+
+func alias() (a, b []int) {
+	a = make([]int, 4)
+	return a, a[1:]
+}
+
+func first() []int {
+	a, _ := alias()
+	return a
+}
+
+var al = first() // ERROR "evaluated call to first at compile time"
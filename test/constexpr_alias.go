@@ -0,0 +1,98 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that package initializers evaluated at compile time copy
+// arrays and structs on assignment, as running them does.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type P struct{ x, y int }
+
+func swapStructs() [2]P {
+	s := [2]P{{3, 1}, {1, 3}}
+	s[0], s[1] = s[1], s[0]
+	return s
+}
+
+func swapInts() [4]int {
+	a := [4]int{3, 1, 3, 1}
+	a[0], a[1] = a[1], a[0]
+	a[2], a[3] = a[3], a[2]
+	a[0], a[1] = a[1], a[0]
+	return a
+}
+
+func swapFields() P {
+	p := P{1, 2}
+	p.x, p.y = p.y, p.x
+	return p
+}
+
+func swapSlice() []P {
+	s := []P{{1, 2}, {3, 4}}
+	s[0], s[1] = s[1], s[0]
+	return s
+}
+
+func swapNested() [2][2]int {
+	a := [2][2]int{{1, 2}, {3, 4}}
+	a[0], a[1] = a[1], a[0]
+	return a
+}
+
+func selfCopy() ([2]P, [2]P) {
+	a := [2]P{{1, 2}, {3, 4}}
+	a = [2]P{a[1], a[0]}
+	b := a
+	b[0].x = 9
+	c := a[1]
+	a[1].y = 7
+	return a, [2]P{b[0], c}
+}
+
+func rotate() [3]int {
+	a := [3]int{1, 2, 3}
+	a[0], a[1], a[2] = a[1], a[2], a[0]
+	return a
+}
+
+var (
+	structs      = swapStructs()
+	ints         = swapInts()
+	fields       = swapFields()
+	slices       = swapSlice()
+	nested       = swapNested()
+	self1, self2 = selfCopy()
+	rotated      = rotate()
+)
+
+func check(name string, got, want interface{}) {
+	if !reflect.DeepEqual(got, want) {
+		panic(fmt.Sprintf("%s = %v, want %v", name, got, want))
+	}
+}
+
+func main() {
+	check("structs", structs, [2]P{{1, 3}, {3, 1}})
+	check("ints", ints, [4]int{3, 1, 1, 3})
+	check("fields", fields, P{2, 1})
+	check("slices", slices, []P{{3, 4}, {1, 2}})
+	check("nested", nested, [2][2]int{{3, 4}, {1, 2}})
+	check("self1", self1, [2]P{{3, 4}, {1, 7}})
+	check("self2", self2, [2]P{{9, 4}, {1, 2}})
+	check("rotated", rotated, [3]int{2, 3, 1})
+
+	// Running the functions gives the same results.
+	check("swapStructs", structs, swapStructs())
+	check("swapInts", ints, swapInts())
+	a, b := selfCopy()
+	check("selfCopy", [2][2]P{self1, self2}, [2][2]P{a, b})
+}
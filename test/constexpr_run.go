@@ -0,0 +1,111 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that package initializers evaluated at compile time
+// produce the same values as running them.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func crcTable(poly uint32) (t [256]uint32) {
+	for i := range t {
+		crc := uint32(i)
+		for j := 0; j < 8; j++ {
+			if crc&1 == 1 {
+				crc = crc>>1 ^ poly
+			} else {
+				crc >>= 1
+			}
+		}
+		t[i] = crc
+	}
+	return t
+}
+
+func wrap(n int8) (int8, uint8, int64) {
+	x := n
+	for i := 0; i < 10; i++ {
+		x = x*3 + 7
+	}
+	return x, uint8(x) << 3, int64(x) >> 2
+}
+
+func wrapAll(n int8) [3]int64 {
+	a, b, c := wrap(n)
+	return [3]int64{int64(a), int64(b), c}
+}
+
+func floats(n int) []float32 {
+	s := make([]float32, 0, n+2)
+	f := float32(1)
+	for i := 0; i < n; i++ {
+		s = s[:len(s)+1]
+		s[i] = f
+		f = f/3 + 0.1
+	}
+	return s
+}
+
+type pair struct {
+	name string
+	runes []rune
+}
+
+func pairs(s string) []pair {
+	var r []pair
+	switch {
+	case s == "":
+		return nil
+	default:
+		r = make([]pair, 2)
+	}
+	r[0] = pair{s + "!", []rune(s)}
+	r[1].name = string(rune(0x65e5)) + s[1:3]
+	return r
+}
+
+type shared struct {
+	a, b []int
+}
+
+func share() shared {
+	a := make([]int, 4)
+	return shared{a, a[1:]}
+}
+
+var (
+	crc   = crcTable(0xedb88320)
+	wr    = wrapAll(5)
+	fl    = floats(5)
+	ps    = pairs("héllo")
+	empty = pairs("")
+	sh    = share()
+)
+
+func check(name string, got, want interface{}) {
+	if !reflect.DeepEqual(got, want) {
+		panic(fmt.Sprintf("%s = %v, want %v", name, got, want))
+	}
+}
+
+func main() {
+	check("crc", crc, crcTable(0xedb88320))
+	check("wr", wr, wrapAll(5))
+	check("fl", fl, floats(5))
+	check("cap(fl)", cap(fl), 7)
+	check("ps", ps, pairs("héllo"))
+	check("empty", empty == nil, true)
+
+	sh.a[1] = 42
+	if sh.b[0] != 42 {
+		panic("slices returned by share do not alias")
+	}
+	crc[0] = 1 // the table is writable
+}
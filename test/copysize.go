@@ -0,0 +1,64 @@
+// errorcheck -0 -d=copysize=64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test -d=copysize, which reports copies of large values.
+
+package p
+
+type big struct {
+	a [16]int64
+}
+
+type small struct {
+	a, b int64
+}
+
+func (b big) value() int64 { return b.a[0] }
+
+func (b *big) pointer() int64 { return b.a[0] }
+
+func use(big) {}
+
+func usePtr(*big) {}
+
+var global big
+
+func f(p *big, s []big, m map[int]big, arr [4]big) {
+	x := global // ERROR "assignment copies 128 bytes of big"
+	y := *p     // ERROR "assignment copies 128 bytes of big"
+	z := s[0]   // ERROR "assignment copies 128 bytes of big"
+	w := m[0]   // ERROR "assignment copies 128 bytes of big"
+	var v big
+	x, y = y, x // ERROR "assignment copies 128 bytes of big"
+	_ = z
+	_ = w
+	_ = v
+
+	q := big{}
+	sm := small{}
+	sm2 := sm
+	_, _, _ = q, sm, sm2
+
+	use(global) // ERROR "argument copies 128 bytes of big"
+	usePtr(&global)
+	use(big{})
+
+	global.value() // ERROR "receiver copies 128 bytes of big"
+	p.pointer()
+
+	for _, e := range s { // ERROR "range value copies 128 bytes of big per iteration"
+		_ = e
+	}
+	for i := range s {
+		_ = s[i].a[0]
+	}
+	for _, e := range arr { // ERROR "range copies 512 bytes of \[4\]big" "range value copies 128 bytes of big per iteration"
+		_ = e
+	}
+	for _, e := range &arr { // ERROR "range value copies 128 bytes of big per iteration"
+		_ = e
+	}
+}
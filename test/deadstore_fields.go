@@ -0,0 +1,61 @@
+// errorcheck -0 -d=ssa/dse/debug=1
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that stores to fields of stack variables that are
+// overwritten on every path before being read are removed.
+
+package p
+
+type T struct {
+	a, b, c, d, e int
+}
+
+//go:noinline
+func use(*T) {}
+
+func overwrittenField(c bool) {
+	s := T{a: 1, b: 2} // ERROR "removed dead store to s"
+	if c {
+		s.a = 3
+	} else {
+		s.a = 4
+	}
+	use(&s)
+}
+
+func overwrittenZero(c bool, x int) {
+	var s T // ERROR "removed dead store to s"
+	if c {
+		s = T{1, 2, 3, 4, 5}
+	} else {
+		s = T{x, x, x, x, x}
+	}
+	use(&s)
+}
+
+func readOnOnePath(c bool) {
+	s := T{a: 1, b: 2}
+	if c {
+		s.a = 3
+	} else {
+		use(&s)
+	}
+	use(&s)
+}
+
+func readByCall(c bool) {
+	s := T{a: 1, b: 2}
+	use(&s)
+	if c {
+		s.a = 3
+	} else {
+		s.a = 4
+	}
+	use(&s)
+}
@@ -84,3 +84,52 @@ label:
 		fmt.Println("defer")
 	}()
 }
+
+func f7() {
+	for i := 0; i < glob; i++ {
+		defer func() { // ERROR "open-coded defer"
+			fmt.Println("defer")
+		}()
+		break
+	}
+}
+
+func f8() {
+	for i := 0; i < 1; i++ {
+		defer func() { // ERROR "open-coded defer"
+			fmt.Println("defer")
+		}()
+	}
+}
+
+func f9() {
+outer:
+	for i := 0; i < glob; i++ {
+		for j := 0; j < glob; j++ {
+			if j > 2 {
+				continue outer
+			}
+		}
+		defer func() { // ERROR "heap-allocated defer"
+			fmt.Println("defer")
+		}()
+		break
+	}
+}
+
+func f10() {
+	for i := 0; i < 2; i++ {
+		defer func() { // ERROR "heap-allocated defer"
+			fmt.Println("defer")
+		}()
+	}
+}
+
+func f11() {
+	for i := 1; i > 0; i-- {
+		defer func() { // ERROR "heap-allocated defer"
+			fmt.Println("defer")
+		}()
+		i = 2
+	}
+}
@@ -0,0 +1,75 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that deferred function literals in loops, whose closures
+// are kept on the stack, behave correctly across stack growth,
+// garbage collection and panics.
+
+package main
+
+import "runtime"
+
+func count(n int) (x int) {
+	for i := 0; i < n; i++ {
+		defer func() {
+			x++
+		}()
+	}
+	return 0
+}
+
+//go:noinline
+func grow(n int) int {
+	var buf [256]byte
+	if n == 0 {
+		return int(buf[0])
+	}
+	return grow(n-1) + int(buf[n%len(buf)])
+}
+
+func countGrow(n int) (x int) {
+	p := new(int)
+	for i := 0; i < n; i++ {
+		defer func() {
+			x += *p
+		}()
+	}
+	*p = 1
+	grow(100)
+	runtime.GC()
+	return 0
+}
+
+func countPanic(n int) (x int) {
+	defer func() {
+		if recover() == nil {
+			panic("no panic")
+		}
+	}()
+	for i := 0; i < n; i++ {
+		defer func() {
+			x++
+			grow(10)
+		}()
+	}
+	runtime.GC()
+	panic("boom")
+}
+
+func main() {
+	if x := count(10); x != 10 {
+		println("count:", x)
+		panic("bad")
+	}
+	if x := countGrow(10); x != 10 {
+		println("countGrow:", x)
+		panic("bad")
+	}
+	if x := countPanic(10); x != 10 {
+		println("countPanic:", x)
+		panic("bad")
+	}
+}
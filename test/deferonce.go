@@ -0,0 +1,63 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that defers in loops that run at most once, which may be
+// open-coded, run the right number of times with the right values,
+// including when the function panics.
+
+package main
+
+var glob int
+
+func once(n int) (s string) {
+	for i := 0; i < n; i++ {
+		x := "a"
+		defer func() { s += x }()
+		break
+	}
+	glob++
+	return ""
+}
+
+func count(n int) (s string) {
+	for i := 0; i < 1; i++ {
+		defer func() { s += "b" }()
+		if n > 0 {
+			defer func(i int) { s += string(rune('0' + i)) }(i)
+		}
+	}
+	return ""
+}
+
+func panics(n int) (s string) {
+	defer func() {
+		recover()
+	}()
+	for i := 0; i < n; i++ {
+		defer func() { s += "c" }()
+		if i >= 0 {
+			panic("boom")
+		}
+		break
+	}
+	return "no panic"
+}
+
+func main() {
+	check(once(0), "")
+	check(once(5), "a")
+	check(count(0), "b")
+	check(count(1), "0b")
+	check(panics(0), "no panic")
+	check(panics(3), "c")
+}
+
+func check(got, want string) {
+	if got != want {
+		println("got", got, "want", want)
+		panic("bad")
+	}
+}
@@ -0,0 +1,39 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test "did you mean" suggestions for misspelled names.
+
+package p
+
+import "strings"
+
+type Inner struct{ Value int }
+
+type T struct {
+	Name  string
+	count int
+	Inner
+}
+
+func (T) Method() {}
+
+type I interface{ Read() }
+
+func f(t T, p *T, i I) {
+	t.Nmae = ""   // ERROR "type T has no field or method Nmae, did you mean Name\?"
+	t.Methd()     // ERROR "type T has no field or method Methd, did you mean Method\?"
+	t.Vlue = 1    // ERROR "type T has no field or method Vlue, did you mean Value\?"
+	p.cont = 1    // ERROR "type \*T has no field or method cont, did you mean count\?"
+	i.Raed()      // ERROR "type I has no field or method Raed, did you mean Read\?"
+	t.Unrelated() // ERROR "type T has no field or method Unrelated\)"
+
+	localVariable := 1
+	_ = localVarible       // ERROR "undefined: localVarible \(did you mean localVariable\?\)"
+	_ = lenn(t.Name)       // ERROR "undefined: lenn \(did you mean len\?\)"
+	_ = unrelatedName      // ERROR "undefined: unrelatedName$"
+	_ = strings.ToUper("") // ERROR "undefined: strings.ToUper \(did you mean strings.ToUpper\?\)"
+	_ = localVariable
+}
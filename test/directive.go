@@ -90,6 +90,15 @@ func f() {
 	// ok:
 	//go:notinheap
 	type T int
+
+	//go:likely // ERROR "misplaced compiler directive"
+	x++
+
+	// ok
+	//go:unlikely
+	if x > 0 {
+		x--
+	}
 }
 
 // someday there might be a directive that can apply to type aliases, but go:notinheap doesn't.
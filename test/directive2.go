@@ -0,0 +1,31 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Verify that misplaced directives are diagnosed, when they are
+// attached to a declaration or statement that does not accept them.
+// These errors are reported after parsing, so they must be tested
+// separately from the ones in directive.go.
+
+package main
+
+//go:likely // ERROR "misplaced compiler directive"
+func f() {}
+
+//go:unlikely // ERROR "misplaced compiler directive"
+var x int
+
+func g() {
+	//go:noinline // ERROR "misplaced compiler directive"
+	if x > 0 {
+		x--
+	}
+
+	// ok
+	//go:likely
+	if x > 0 {
+		x--
+	}
+}
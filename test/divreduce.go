@@ -0,0 +1,59 @@
+// errorcheck -0 -d=ssa/divreduce/debug=1
+
+//go:build amd64
+// +build amd64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that divisions by loop-invariant divisors are
+// replaced with multiplications.
+
+package p
+
+func bucket(h []uint64, n uint64) uint64 {
+	var s uint64
+	for _, x := range h {
+		s += x % n // ERROR "reduced Mod64u by loop-invariant divisor"
+	}
+	return s
+}
+
+func rows(p []int32, stride int32) int32 {
+	var s int32
+	for i := range p {
+		s += int32(i) / stride // ERROR "reduced Div32 by loop-invariant divisor"
+	}
+	return s
+}
+
+func nested(a [][]int, d int) int {
+	s := 0
+	for _, r := range a {
+		for _, x := range r {
+			s += x / d // ERROR "reduced Div64 by loop-invariant divisor"
+		}
+	}
+	return s
+}
+
+func variant(a, b []uint) uint {
+	var s uint
+	for i := range a {
+		s += a[i] / b[i]
+	}
+	return s
+}
+
+func constant(a []uint) uint {
+	var s uint
+	for _, x := range a {
+		s += x / 10
+	}
+	return s
+}
+
+func once(x, y uint) uint {
+	return x / y
+}
@@ -0,0 +1,159 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the correctness of division by loop-invariant divisors.
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+var u64s = []uint64{
+	0, 1, 2, 3, 5, 7, 9, 10, 100, 641, 1<<31 - 1, 1 << 31, 1<<32 - 1, 1 << 32,
+	1<<32 + 1, 1<<62 - 1, 1 << 62, 1<<63 - 1, 1 << 63, 1<<63 + 1,
+	math.MaxUint64 - 1, math.MaxUint64, 0x123456789abcdef, 0xfedcba9876543210,
+}
+
+//go:noinline
+func div64u(x, y uint64) (uint64, uint64) { return x / y, x % y }
+
+//go:noinline
+func div64(x, y int64) (int64, int64) { return x / y, x % y }
+
+//go:noinline
+func div32u(x, y uint32) (uint32, uint32) { return x / y, x % y }
+
+//go:noinline
+func div32(x, y int32) (int32, int32) { return x / y, x % y }
+
+//go:noinline
+func loop64u(xs []uint64, y uint64, q, r []uint64) {
+	for i, x := range xs {
+		q[i] = x / y
+		r[i] = x % y
+	}
+}
+
+//go:noinline
+func loop64(xs []int64, y int64, q, r []int64) {
+	for i, x := range xs {
+		q[i] = x / y
+		r[i] = x % y
+	}
+}
+
+//go:noinline
+func loop32u(xs []uint32, y uint32, q, r []uint32) {
+	for i, x := range xs {
+		q[i] = x / y
+		r[i] = x % y
+	}
+}
+
+//go:noinline
+func loop32(xs []int32, y int32, q, r []int32) {
+	for i, x := range xs {
+		q[i] = x / y
+		r[i] = x % y
+	}
+}
+
+//go:noinline
+func loopZero(xs []uint64, y uint64) (s uint64) {
+	for _, x := range xs {
+		s += x / y
+	}
+	return s
+}
+
+func main() {
+	var xs64u []uint64
+	var xs64 []int64
+	var xs32u []uint32
+	var xs32 []int32
+	for _, x := range u64s {
+		xs64u = append(xs64u, x)
+		xs64 = append(xs64, int64(x), -int64(x))
+		xs32u = append(xs32u, uint32(x), uint32(x>>32))
+		xs32 = append(xs32, int32(x), -int32(x), int32(x>>32))
+	}
+	xs64 = append(xs64, math.MinInt64, math.MaxInt64)
+	xs32 = append(xs32, math.MinInt32, math.MaxInt32)
+
+	bad := false
+	q64u, r64u := make([]uint64, len(xs64u)), make([]uint64, len(xs64u))
+	q64, r64 := make([]int64, len(xs64)), make([]int64, len(xs64))
+	q32u, r32u := make([]uint32, len(xs32u)), make([]uint32, len(xs32u))
+	q32, r32 := make([]int32, len(xs32)), make([]int32, len(xs32))
+	for _, y := range xs64u {
+		if y == 0 {
+			continue
+		}
+		loop64u(xs64u, y, q64u, r64u)
+		for i, x := range xs64u {
+			if q, r := div64u(x, y); q != q64u[i] || r != r64u[i] {
+				fmt.Printf("%d / %d = %d, %d; want %d, %d\n", x, y, q64u[i], r64u[i], q, r)
+				bad = true
+			}
+		}
+	}
+	for _, y := range xs64 {
+		if y == 0 {
+			continue
+		}
+		loop64(xs64, y, q64, r64)
+		for i, x := range xs64 {
+			if q, r := div64(x, y); q != q64[i] || r != r64[i] {
+				fmt.Printf("%d / %d = %d, %d; want %d, %d\n", x, y, q64[i], r64[i], q, r)
+				bad = true
+			}
+		}
+	}
+	for _, y := range xs32u {
+		if y == 0 {
+			continue
+		}
+		loop32u(xs32u, y, q32u, r32u)
+		for i, x := range xs32u {
+			if q, r := div32u(x, y); q != q32u[i] || r != r32u[i] {
+				fmt.Printf("%d / %d = %d, %d; want %d, %d\n", x, y, q32u[i], r32u[i], q, r)
+				bad = true
+			}
+		}
+	}
+	for _, y := range xs32 {
+		if y == 0 {
+			continue
+		}
+		loop32(xs32, y, q32, r32)
+		for i, x := range xs32 {
+			if q, r := div32(x, y); q != q32[i] || r != r32[i] {
+				fmt.Printf("%d / %d = %d, %d; want %d, %d\n", x, y, q32[i], r32[i], q, r)
+				bad = true
+			}
+		}
+	}
+
+	// A zero divisor must still panic, but only if the loop runs.
+	if s := loopZero(nil, 0); s != 0 {
+		fmt.Println("loopZero(nil, 0) =", s)
+		bad = true
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				fmt.Println("loopZero did not panic")
+				bad = true
+			}
+		}()
+		loopZero([]uint64{1}, 0)
+	}()
+	if bad {
+		panic("bad division")
+	}
+}
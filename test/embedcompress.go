@@ -0,0 +1,15 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package p
+
+import "embed"
+
+//go:embedcompress // ERROR "misplaced go:embedcompress directive"
+var x embed.FS
+
+//go:embedcompress // ERROR "misplaced go:embedcompress directive"
+func f() {}
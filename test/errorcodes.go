@@ -0,0 +1,17 @@
+// errorcheck -errorcodes
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -errorcodes prints the code of each error after its
+// message.
+
+package p
+
+type T struct{ next T } // ERROR "^invalid recursive type T \[type-loop\]\n"
+
+type I interface {
+	M()
+	M(int) // ERROR "^duplicate method M \[duplicate-method\]$"
+}
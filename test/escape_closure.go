@@ -123,6 +123,36 @@ func ClosureCallArgs13() {
 	}(&x)
 }
 
+func ClosureDeferLoop1(n int) int {
+	x := 0
+	for i := 0; i < n; i++ {
+		defer func() { // ERROR "func literal does not escape"
+			x++
+		}()
+	}
+	return x
+}
+
+func ClosureDeferLoop2(n int) {
+	for i := 0; i < n; i++ {
+		x := i         // ERROR "moved to heap: x"
+		defer func() { // ERROR "func literal escapes to heap"
+			x++
+		}()
+	}
+}
+
+func ClosureDeferLoop3(n int) {
+	x := 0
+	for i := 0; i < n; i++ {
+		defer func() { // ERROR "func literal does not escape"
+			defer func() { // ERROR "func literal does not escape"
+				x++
+			}()
+		}()
+	}
+}
+
 func ClosureCallArgs14() {
 	x := 0
 	p := &x
@@ -0,0 +1,51 @@
+// errorcheck -0 -m -l -complete
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test escape analysis for calls through package-level
+// function variables that always hold the same function.
+
+package escape
+
+var sink interface{}
+
+func get(p *int) int { // ERROR "p does not escape"
+	return *p
+}
+
+func leak(p *int) int { // ERROR "leaking param: p"
+	sink = p
+	return 0
+}
+
+var (
+	getter    = get
+	leaker    = leak
+	setter    = get
+	addrTaken = get
+	Exported  = get
+	initAddr  = get
+	ranged    = get
+
+	_ = &initAddr
+)
+
+func init() {
+	setter = leak
+	_ = &addrTaken
+	for _, ranged = range []func(*int) int{leak} { // ERROR "\[\]func\(\*int\) int{...} does not escape"
+	}
+}
+
+func f() int {
+	a := 1
+	b := 2 // ERROR "moved to heap: b"
+	c := 3 // ERROR "moved to heap: c"
+	d := 4 // ERROR "moved to heap: d"
+	e := 5 // ERROR "moved to heap: e"
+	g := 6 // ERROR "moved to heap: g"
+	h := 7 // ERROR "moved to heap: h"
+	return getter(&a) + leaker(&b) + setter(&c) + addrTaken(&d) + Exported(&e) + initAddr(&g) + ranged(&h)
+}
@@ -0,0 +1,65 @@
+// run -gcflags=-complete
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that a package-level function variable whose address is taken
+// by a package-level initializer, or which is assigned by a range
+// loop, is not assumed to always hold the function it is initialized
+// to. Otherwise i does not move to the heap, and g keeps a pointer to
+// a dead stack slot.
+
+package main
+
+var sink *int
+
+func f(p *int) {}
+
+//go:noinline
+func g(p *int) { sink = p }
+
+var (
+	fv = f
+	p  = &fv
+
+	ranged = f
+)
+
+//go:noinline
+func call() {
+	i := 42
+	fv(&i)
+}
+
+//go:noinline
+func callRanged() {
+	i := 43
+	ranged(&i)
+}
+
+//go:noinline
+func clobber() int {
+	var a [16]int
+	for i := range a {
+		a[i] = 7
+	}
+	return a[3]
+}
+
+func main() {
+	*p = g
+	call()
+	clobber()
+	if *sink != 42 {
+		panic(*sink)
+	}
+
+	for _, ranged = range []func(*int){g} {
+	}
+	callRanged()
+	clobber()
+	if *sink != 43 {
+		panic(*sink)
+	}
+}
@@ -0,0 +1,51 @@
+// errorcheck -0 -m -l
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test escape analysis for values converted to interface{} and
+// passed to printf-style functions whose parameters do not escape.
+
+package escape
+
+var sink interface{}
+
+type logger struct{ n int }
+
+func (l *logger) logf(format string, args ...interface{}) { // ERROR "l does not escape" "format does not escape" "args does not escape"
+	for _, a := range args {
+		if _, ok := a.(int); ok {
+			l.n++
+		}
+	}
+}
+
+func (l *logger) leakf(format string, args ...interface{}) { // ERROR "l does not escape" "format does not escape" "leaking param content: args"
+	sink = args[0]
+}
+
+func logf(format string, args ...interface{}) { // ERROR "format does not escape" "args does not escape"
+	for _, a := range args {
+		if _, ok := a.(int); ok {
+			println(format)
+		}
+	}
+}
+
+type iface interface {
+	logf(string, ...interface{}) // ERROR "leaking param: .anon0" "leaking param: .anon1"
+}
+
+func f(l *logger, i iface, x int) { // ERROR "l does not escape" "leaking param: i"
+	logf("%d", x)    // ERROR "... argument does not escape" "x does not escape"
+	l.logf("%d", x)  // ERROR "... argument does not escape" "x does not escape"
+	l.leakf("%d", x) // ERROR "... argument does not escape" "x escapes to heap"
+
+	g := l.logf  // ERROR "l.logf does not escape"
+	g("%d", x)   // ERROR "... argument does not escape" "x does not escape"
+	h := l.leakf // ERROR "l.leakf does not escape"
+	h("%d", x)   // ERROR "... argument does not escape" "x escapes to heap"
+
+	i.logf("%d", x) // ERROR "... argument escapes to heap" "x escapes to heap"
+}
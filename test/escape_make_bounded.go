@@ -0,0 +1,34 @@
+// errorcheck -0 -m -l
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test escape analysis for make([]T, n) where n is not constant
+// but has a small constant upper bound.
+
+package escape
+
+var sink interface{}
+
+func bounded(n int, u uint, b byte, w uint32) {
+	_ = make([]byte, n&63)      // ERROR "make\(\[\]byte, n & 63\) does not escape"
+	_ = make([]int, 0, n&127)   // ERROR "make\(\[\]int, 0, n & 127\) does not escape"
+	_ = make([]byte, b)         // ERROR "make\(\[\]byte, b\) does not escape"
+	_ = make([]byte, u%100)     // ERROR "make\(\[\]byte, u % 100\) does not escape"
+	_ = make([]byte, w>>24)     // ERROR "make\(\[\]byte, w >> 24\) does not escape"
+	_ = make([]byte, int(b)&^1) // ERROR "make\(\[\]byte, int\(b\) &\^ 1\) does not escape"
+}
+
+func unbounded(n int, u uint16) {
+	_ = make([]byte, n)        // ERROR "make\(\[\]byte, n\) escapes to heap"
+	_ = make([]byte, n%100)    // ERROR "make\(\[\]byte, n % 100\) escapes to heap"
+	_ = make([]byte, u)        // ERROR "make\(\[\]byte, u\) escapes to heap"
+	_ = make([]int64, n&255)   // ERROR "make\(\[\]int64, n & 255\) escapes to heap"
+	_ = make([]byte, int8(u))  // ERROR "make\(\[\]byte, int8\(u\)\) escapes to heap"
+	_ = make([]byte, 10, n&63) // ERROR "make\(\[\]byte, 10, n & 63\) does not escape"
+}
+
+func escapes(n int) {
+	sink = make([]byte, n&63) // ERROR "make\(\[\]byte, n & 63\) escapes to heap"
+}
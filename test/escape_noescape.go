@@ -0,0 +1,57 @@
+// errorcheck -l
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that //go:noescape on a function with a body is verified by
+// escape analysis.
+
+package escape
+
+var sink interface{}
+
+type T struct{ p *int }
+
+//go:noescape
+func ok(p *int, t *T) int {
+	if t.p != nil {
+		return *t.p
+	}
+	return *p
+}
+
+//go:noescape
+func okMutate(t *T, x int) {
+	*t.p = x
+}
+
+//go:noescape
+func leakHeap(p *int) { // ERROR "p leaks to heap, but leakHeap is marked //go:noescape"
+	sink = p
+}
+
+//go:noescape
+func leakContent(t *T) { // ERROR "t leaks to heap, but leakContent is marked //go:noescape"
+	sink = t.p
+}
+
+//go:noescape
+func leakResult(p *int) *int { // ERROR "p leaks to result .*, but leakResult is marked //go:noescape"
+	return p
+}
+
+//go:noescape
+func (t *T) leakRecv() { // ERROR "t leaks to heap, but \(\*T\).leakRecv is marked //go:noescape"
+	sink = t
+}
+
+//go:noescape
+func leakAddr(p *int) { // ERROR "p leaks to heap, but leakAddr is marked //go:noescape"
+	sink = &p
+}
+
+func caller() int {
+	x, y := 1, 2
+	return ok(&x, &T{p: &y})
+}
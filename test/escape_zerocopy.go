@@ -0,0 +1,98 @@
+// errorcheck -0 -m -l
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that string/[]byte conversions share memory with their
+// operand when the result is never written.
+
+package escape
+
+var sink []byte
+
+func read(b []byte) int { // ERROR "b does not escape"
+	n := 0
+	for _, c := range b {
+		n += int(c)
+	}
+	return n
+}
+
+func write(b []byte) { // ERROR "b does not escape"
+	b[0] = 1
+}
+
+func ret(b []byte) []byte { // ERROR "leaking param: b to result ~r1 level=0"
+	return b
+}
+
+func f1(s string) int { // ERROR "s does not escape"
+	b := []byte(s) // ERROR "\(\[\]byte\)\(s\) does not escape" "zero-copy string->\[\]byte conversion"
+	return read(b) + len(b)
+}
+
+func f2(s string) int { // ERROR "s does not escape"
+	return read([]byte(s)) // ERROR "\(\[\]byte\)\(s\) does not escape" "zero-copy string->\[\]byte conversion"
+}
+
+func f3(s string) int { // ERROR "s does not escape"
+	return read([]byte(s + "x")) // ERROR "\(\[\]byte\)\(s \+ .x.\) does not escape" "zero-copy string->\[\]byte conversion" "s \+ .x. does not escape"
+}
+
+func f4() int {
+	b := []byte("abc") // ERROR "\(\[\]byte\)\(.abc.\) does not escape" "zero-copy string->\[\]byte conversion"
+	return read(b)
+}
+
+func g1(s string) { // ERROR "s does not escape"
+	b := []byte(s) // ERROR "\(\[\]byte\)\(s\) does not escape"
+	b[0] = 1
+}
+
+func g2(s string) { // ERROR "s does not escape"
+	write([]byte(s)) // ERROR "\(\[\]byte\)\(s\) does not escape"
+}
+
+func g3(s string) { // ERROR "s does not escape"
+	b := []byte(s) // ERROR "\(\[\]byte\)\(s\) does not escape"
+	c := ret(b)
+	c[0] = 1
+}
+
+func g4(s string) { // ERROR "s does not escape"
+	b := []byte(s) // ERROR "\(\[\]byte\)\(s\) does not escape"
+	copy(b, "x")
+}
+
+func g5(s string) { // ERROR "s does not escape"
+	sink = []byte(s) // ERROR "\(\[\]byte\)\(s\) escapes to heap"
+}
+
+func g6(s string) int { // ERROR "s does not escape"
+	// s may refer to a stack buffer that does not outlive b.
+	s = s + "x"    // ERROR "s \+ .x. does not escape"
+	b := []byte(s) // ERROR "\(\[\]byte\)\(s\) does not escape"
+	return read(b)
+}
+
+//go:noinline
+func lookup(s string, n int) int { // ERROR "s does not escape"
+	return len(s) + n
+}
+
+func h1(r []byte) int { // ERROR "r does not escape"
+	var buf [64]byte
+	n := copy(buf[:], r)
+	return lookup(string(buf[:n]), n) // ERROR "string\(buf\[:n\]\) does not escape" "zero-copy \[\]byte->string conversion"
+}
+
+func h2(r []byte) int { // ERROR "r does not escape"
+	var buf [64]byte
+	n := copy(buf[:], r)
+	return lookup(string(buf[:n]), copy(buf[:], "x")) // ERROR "string\(buf\[:n\]\) does not escape"
+}
+
+func h3(r []byte) int { // ERROR "r does not escape"
+	return lookup(string(r), 0) // ERROR "string\(r\) does not escape"
+}
@@ -0,0 +1,36 @@
+// errorcheck -0 -d=framesize
+
+//go:build amd64
+// +build amd64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the stack frame layouts that -d=framesize reports.
+
+package p
+
+//go:noinline
+func spill(p *int) int { // ERROR "frame size of spill: 16 bytes \(8 locals, 8 spills, 0 outargs, 16 args\)"
+	a := *p
+	g()
+	return a
+}
+
+//go:noinline
+func g() {} // ERROR "frame size of g: 0 bytes \(0 locals, 0 spills, 0 outargs, 0 args\)"
+
+func locals() int { // ERROR "frame size of locals: 536 bytes \(512 locals, 0 spills, 16 outargs, 8 args\)"
+	var a [1024]byte
+	sink = a[:]
+	var b [64]int
+	return h(&b)
+}
+
+var sink []byte
+
+//go:noinline
+func h(p *[64]int) int { // ERROR "frame size of h: 0 bytes \(0 locals, 0 spills, 0 outargs, 16 args\)"
+	return p[0]
+}
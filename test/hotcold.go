@@ -0,0 +1,48 @@
+// errorcheck -0 -d=ssa/layout/debug=1
+
+//go:build amd64
+// +build amd64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that blocks that lead to a panic, or that are only entered
+// through unlikely branches, are laid out after the hot code.
+
+package p
+
+func sum(a []int) int {
+	s := 0
+	for _, x := range a {
+		if x < 0 {
+			panic("negative") // ERROR "cold block"
+		}
+		s += x
+	}
+	return s
+}
+
+func step(op byte, acc int) (int, bool) {
+	switch op {
+	case 0:
+		acc++
+	case 1:
+		acc--
+	default:
+		if acc < 0 {
+			println("bad op", op)
+			acc = -acc // ERROR "cold block"
+		}
+		return acc, false
+	}
+	return acc, true
+}
+
+func loop(n int) int {
+	s := 0
+	for i := 0; i < n; i++ {
+		s += i
+	}
+	return s // the loop exit is not cold
+}
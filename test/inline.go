@@ -290,3 +290,141 @@ func conv2(v uint64) uint64 { // ERROR "can inline conv2"
 func conv1(v uint64) uint64 { // ERROR "can inline conv1"
 	return uint64(uint64(uint64(uint64(uint64(uint64(uint64(uint64(uint64(uint64(uint64(v)))))))))))
 }
+
+// Ensure that a wrapper around a function close to the budget
+// is charged no more than a call that is not inlined.
+func big(x, y int) int { // ERROR "can inline big"
+	if x > y {
+		x, y = y, x
+	}
+	for i := 0; i < y; i++ {
+		x += i * y
+		if x > 1000 {
+			x -= y
+		}
+		if x < -1000 {
+			x += y
+		}
+		x ^= x >> 3
+		x |= y << 2
+		x &^= i
+		if x == y {
+			break
+		}
+	}
+	if x < 0 {
+		x = -x
+	}
+	return x*x + y*3
+}
+
+func wrapBig(x int) int { // ERROR "can inline wrapBig"
+	return big(x, 10) + 1 // ERROR "inlining call to big"
+}
+
+func wrapWrapBig(x int) int { // ERROR "can inline wrapWrapBig"
+	return wrapBig(x) * 2 // ERROR "inlining call to wrapBig" "inlining call to big"
+}
+
+// Calls through method values and method expressions can be inlined
+// when the receiver is known.
+type counter struct{ n int }
+
+func (c *counter) inc() { // ERROR "can inline \(\*counter\).inc" "c does not escape" "inlining call to \(\*counter\).inc"
+	c.n++
+}
+
+type celsius float64
+
+func (c celsius) fahrenheit() float64 { // ERROR "can inline celsius.fahrenheit" "inlining call to celsius.fahrenheit"
+	return float64(c)*9/5 + 32
+}
+
+type pair struct{ a, b int }
+
+func (p pair) sum() int { // ERROR "can inline pair.sum" "inlining call to pair.sum"
+	return p.a + p.b
+}
+
+func mv1(c *counter) { // ERROR "can inline mv1" "c does not escape"
+	f := c.inc // ERROR "c.inc does not escape"
+	f()        // ERROR "inlining call to \(\*counter\).inc"
+	f()        // ERROR "inlining call to \(\*counter\).inc"
+}
+
+func mv2(c celsius) float64 { // ERROR "can inline mv2"
+	f := c.fahrenheit // ERROR "c.fahrenheit does not escape"
+	return f()        // ERROR "inlining call to celsius.fahrenheit"
+}
+
+func mv3(c celsius) float64 { // ERROR "can inline mv3"
+	f := c.fahrenheit // ERROR "c.fahrenheit does not escape"
+	c += 10           // changes c, but not the receiver bound in f
+	return f()
+}
+
+func mv4() int { // ERROR "can inline mv4"
+	p := pair{1, 2}
+	f := p.sum // ERROR "p.sum does not escape"
+	p.a = 10   // changes p, but not the receiver bound in f
+	return f()
+}
+
+func me1(p *pair) int { // ERROR "can inline me1" "p does not escape"
+	f := (*pair).sum
+	return f(p) // ERROR "inlining call to pair.sum"
+}
+
+// Functions whose only defer is a simple call can be inlined, with
+// the deferred call made at the end of the inlined body.
+type spin struct{ locked bool }
+
+func (s *spin) lock() { // ERROR "can inline \(\*spin\).lock" "s does not escape"
+	s.locked = true
+}
+
+func (s *spin) unlock() { // ERROR "can inline \(\*spin\).unlock" "s does not escape"
+	s.locked = false
+}
+
+type guarded struct {
+	mu spin
+	n  int
+	m  map[int]int
+}
+
+func (g *guarded) get() int { // ERROR "can inline \(\*guarded\).get" "g does not escape"
+	g.mu.lock() // ERROR "inlining call to \(\*spin\).lock"
+	defer g.mu.unlock()
+	return g.n
+}
+
+func (g *guarded) inc() { // ERROR "can inline \(\*guarded\).inc" "g does not escape"
+	g.mu.lock() // ERROR "inlining call to \(\*spin\).lock"
+	defer g.mu.unlock()
+	g.n++
+}
+
+func (g *guarded) lookup(k int) int { // ERROR "g does not escape"
+	g.mu.lock() // ERROR "inlining call to \(\*spin\).lock"
+	defer g.mu.unlock()
+	return g.m[k] // indexing a map may panic
+}
+
+func (g *guarded) early(b bool) int { // ERROR "g does not escape"
+	if b {
+		return 0
+	}
+	g.mu.lock() // ERROR "inlining call to \(\*spin\).lock"
+	defer g.mu.unlock()
+	return g.n
+}
+
+func useGuarded(g *guarded) int { // ERROR "can inline useGuarded" "g does not escape"
+	g.inc()        // ERROR "inlining call to \(\*guarded\).inc" "inlining call to \(\*spin\).lock" "inlining call to \(\*spin\).unlock"
+	return g.get() // ERROR "inlining call to \(\*guarded\).get" "inlining call to \(\*spin\).lock" "inlining call to \(\*spin\).unlock"
+}
+
+func useGuarded2(g *guarded) int { // ERROR "g does not escape"
+	return g.lookup(1) + g.early(false)
+}
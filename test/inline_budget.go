@@ -0,0 +1,75 @@
+// errorcheck -0 -m=2 -inlinebudget=200 -inlinebigfunc=30 -inlinebigbudget=40
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that the inlining budget and the limits for big functions
+// can be set with flags.
+
+package foo
+
+func expensive(a, b, c, d int) int { // ERROR "can inline expensive with cost 130 as:.*"
+	x := a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	return x
+}
+
+func tooExpensive(a, b, c, d int) int { // ERROR "cannot inline tooExpensive: function too complex: cost 256 exceeds budget 200 by 56; costliest lines: 23 \(cost 23\), 24 \(cost 21\), 25 \(cost 21\)$"
+	x := a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	x += a*b + c*d - a/(b|1) + c%(d|1)
+	return x
+}
+
+func small(a []int) int { // ERROR "can inline small with cost 16 as:.*" "a does not escape"
+	return a[0] + a[1] + a[2] + a[3]
+}
+
+func medium(a []int) int { // ERROR "can inline medium with cost 48 as:.*" "a does not escape"
+	return a[0] + a[1] + a[2] + a[3] + a[4] + a[5] + a[6] + a[7] + a[8] + a[9] + a[10] + a[11]
+}
+
+func f(a []int) int { // ERROR "can inline f with cost .* as:.*" "a does not escape"
+	return expensive(a[0], a[1], a[2], a[3]) // ERROR "inlining call to expensive"
+}
+
+func g(a []int) int { // ERROR "can inline g with cost .* as:.*" "a does not escape"
+	return tooExpensive(a[0], a[1], a[2], a[3])
+}
+
+func h(a []int) int { // ERROR "can inline h with cost .* as:.*" "a does not escape"
+	// Add enough nodes to make h a big function.
+	a[0] = 0
+	a[1] = 1
+	a[2] = 2
+	a[3] = 3
+	a[4] = 4
+	a[5] = 5
+	a[6] = 6
+	a[7] = 7
+	return small(a) + medium(a) // ERROR "inlining call to small" "cannot inline medium into h: cost 48 exceeds budget 40 for big function"
+}
+
+// The calls that are not inlined are the costliest.
+func calls(a []int) int { // ERROR "cannot inline calls: function too complex: cost 289 exceeds budget 200 by 89; costliest lines: 72 \(cost 129\), 73 \(cost 73\), 70 \(cost 65\)$" "a does not escape"
+	x := a[0] + a[1]
+	x += tooExpensive(x, x, x, x)
+	x += a[2] + a[3]
+	x += tooExpensive(x, x, x, x) + tooExpensive(x, x, x, x)
+	x += tooExpensive(a[0], a[1], a[2], a[3])
+	return x
+}
@@ -0,0 +1,116 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that inlined functions with a defer open-coded at the end
+// of the inlined body behave like the original functions.
+
+package main
+
+var recorded, computed int
+
+func record(x int) {
+	if computed == 0 {
+		panic("deferred call made before the results were computed")
+	}
+	recorded = x
+}
+
+type spin struct{ locked bool }
+
+func (s *spin) lock() {
+	if s.locked {
+		panic("already locked")
+	}
+	s.locked = true
+}
+
+func (s *spin) unlock() { s.locked = false }
+
+type guarded struct {
+	mu spin
+	n  int
+}
+
+func (g *guarded) get() int {
+	g.mu.lock()
+	defer g.mu.unlock()
+	return g.n
+}
+
+func (g *guarded) add(d int) {
+	g.mu.lock()
+	defer g.mu.unlock()
+	g.n += d
+}
+
+// div may panic after the defer, so it must not be inlined with the
+// deferred call moved to the end of its body.
+func (g *guarded) div(d int) {
+	g.mu.lock()
+	defer g.mu.unlock()
+	g.n /= d
+}
+
+// args checks that the deferred call's arguments are evaluated at
+// the defer statement, and that the call is made after the results
+// are computed.
+func args(x int) (r int) {
+	defer record(x)
+	x++
+	r = x * 10
+	computed = r
+	return
+}
+
+func set(p *int) { *p = 42 }
+
+// result checks that the deferred call may change the results.
+func result() (r int) {
+	defer set(&r)
+	return 1
+}
+
+func main() {
+	g := new(guarded)
+	g.add(2)
+	g.add(3)
+	if n := g.get(); n != 5 || g.mu.locked {
+		panic("bad guarded state")
+	}
+
+	if r := args(1); r != 20 {
+		panic("bad result of args")
+	}
+	if recorded != 1 {
+		panic("deferred call argument not evaluated at the defer")
+	}
+
+	if r := result(); r != 42 {
+		panic("deferred call did not set result")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				panic("no panic on nil receiver")
+			}
+		}()
+		var nilg *guarded
+		nilg.add(1)
+	}()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				panic("no panic on division by zero")
+			}
+			if g.mu.locked {
+				panic("deferred unlock not run on panic")
+			}
+		}()
+		g.div(0)
+	}()
+}
@@ -0,0 +1,56 @@
+// errorcheck -0 -m
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that functions calling their func parameters are inlined
+// when the arguments are function literals, and that the literals
+// are in turn inlined into them.
+
+package foo
+
+func apply(x int, f func(int) int) int { // ERROR "can inline apply" "f does not escape"
+	return f(x) + f(x+1)
+}
+
+func count(xs []int, f func(int) bool) int { // ERROR "can inline count" "xs does not escape" "f does not escape"
+	n := 0
+	for i := 0; i < len(xs); i++ {
+		if f(xs[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+func double(x int) int { // ERROR "can inline double"
+	return 2 * x
+}
+
+func f1(y int) int { // ERROR "can inline f1"
+	return apply(y, func(v int) int { return v * y }) // ERROR "inlining call to apply" "inlining call to f1.func1" "can inline f1.func1" "func literal does not escape"
+}
+
+func f2(xs []int, t int) int { // ERROR "can inline f2" "xs does not escape"
+	return count(xs, func(v int) bool { return v > t }) // ERROR "inlining call to count" "inlining call to f2.func1" "can inline f2.func1" "func literal does not escape"
+}
+
+func f3(y int) int { // ERROR "can inline f3"
+	return apply(y, double) // ERROR "inlining call to apply" "inlining call to double"
+}
+
+func f4(y int, g func(int) int) int { // ERROR "can inline f4" "g does not escape"
+	return apply(y, g)
+}
+
+//go:noinline
+func work(x int) int {
+	return x
+}
+
+func f5(y int) int {
+	return apply(y, func(v int) int { // ERROR "can inline f5.func1" "func literal does not escape"
+		return work(v)
+	})
+}
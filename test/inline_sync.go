@@ -51,3 +51,45 @@ func small9() { // ERROR "can inline small9"
         rwmutex.RLock() // ERROR "inlining call to sync\.\(\*RWMutex\)\.RLock"
 }
 
+type guarded struct {
+	mu sync.Mutex
+	n  int
+}
+
+// Lock-guarded getters are inlined, with the deferred Unlock made at
+// the end of the inlined body. Unlock costs more than a deferred call
+// is charged, so it stays a call.
+func (g *guarded) get() int { // ERROR "can inline \(\*guarded\)\.get" "leaking param: g"
+	g.mu.Lock() // ERROR "inlining call to sync\.\(\*Mutex\)\.Lock"
+	defer g.mu.Unlock()
+	return g.n
+}
+
+func (g *guarded) load() guarded { // ERROR "can inline \(\*guarded\)\.load" "leaking param: g"
+	g.mu.Lock() // ERROR "inlining call to sync\.\(\*Mutex\)\.Lock"
+	defer g.mu.Unlock()
+	return *g
+}
+
+var counter int
+
+func getCounter() int { // ERROR "can inline getCounter"
+	mutex.Lock() // ERROR "inlining call to sync\.\(\*Mutex\)\.Lock"
+	defer mutex.Unlock()
+	return counter
+}
+
+var table []int
+
+func small10() int { // ERROR "can inline small10"
+	rwmutex.RLock() // ERROR "inlining call to sync\.\(\*RWMutex\)\.RLock"
+	defer rwmutex.RUnlock()
+	return len(table)
+}
+
+func useGetters(g *guarded) int { // ERROR "leaking param: g"
+	n := g.get()         // ERROR "inlining call to \(\*guarded\)\.get" "inlining call to sync\.\(\*Mutex\)\.Lock"
+	n += g.load().n      // ERROR "inlining call to \(\*guarded\)\.load" "inlining call to sync\.\(\*Mutex\)\.Lock"
+	n += getCounter()    // ERROR "inlining call to getCounter" "inlining call to sync\.\(\*Mutex\)\.Lock"
+	return n + small10() // ERROR "inlining call to small10" "inlining call to sync\.\(\*RWMutex\)\.RLock"
+}
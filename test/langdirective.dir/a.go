@@ -0,0 +1,13 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:lang go1.12 // legacy code
+
+package p
+
+const A = 0b1 // ERROR "binary literals requires go1.13 or later \(file has //go:lang go1.12\)"
+
+func FA(x int) int {
+	return x << x // ERROR "signed shift count type int\) requires go1.13 or later \(file has //go:lang go1.12\)"
+}
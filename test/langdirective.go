@@ -0,0 +1,10 @@
+// errorcheckdir
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that a //go:lang directive sets the language version
+// of the file containing it.
+
+package ignored
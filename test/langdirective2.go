@@ -0,0 +1,22 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Verify that malformed and misplaced //go:lang directives are diagnosed.
+
+//go:lang go1.12
+
+//go:lang go1.13 // ERROR "multiple //go:lang directives"
+
+//go:lang // ERROR "usage: //go:lang go1.N"
+
+//go:lang go1.999 // ERROR "invalid //go:lang version .*max known version"
+
+//go:lang 1.12 // ERROR "invalid //go:lang version .*should be something like"
+
+package p
+
+//go:lang go1.12 // ERROR "misplaced go:lang directive"
+var x int
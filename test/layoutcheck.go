@@ -0,0 +1,42 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the struct layout assertions of //go:layoutcheck.
+
+package p
+
+//go:layoutcheck size=8 align=4 offset.a=0 offset.b=4 offset.c=5
+type ok struct {
+	a int32
+	b int8
+	c struct{}
+}
+
+//go:layoutcheck size=12 // ERROR "go:layoutcheck failed: size of bad is 8, not 12"
+//go:layoutcheck align=2 // ERROR "go:layoutcheck failed: align of bad is 4, not 2"
+//go:layoutcheck offset.b=2 // ERROR "go:layoutcheck failed: offset of b of bad is 4, not 2"
+//go:layoutcheck offset.z=0 // ERROR "go:layoutcheck: bad has no field z"
+type bad struct {
+	a int32
+	b int16
+}
+
+//go:layoutcheck size=4 // ERROR "go:layoutcheck applies only to struct types"
+type notStruct [4]byte
+
+type alias = ok
+
+func f() int {
+	//go:layoutcheck size=3 // ERROR "go:layoutcheck failed: size of local is 2, not 3"
+	type local struct {
+		x, y byte
+	}
+	var l local
+	return int(l.x)
+}
+
+//go:layoutcheck size=8 // ERROR "misplaced go:layoutcheck directive"
+var x int
@@ -0,0 +1,20 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test malformed //go:layoutcheck directives. These are reported
+// while parsing, so they must be tested separately from the failed
+// assertions in layoutcheck.go.
+
+package p
+
+//go:layoutcheck size // ERROR "usage: //go:layoutcheck"
+type usage1 struct{}
+
+//go:layoutcheck width=4 // ERROR "usage: //go:layoutcheck"
+type usage2 struct{}
+
+//go:layoutcheck size=-1 // ERROR "invalid //go:layoutcheck value"
+type usage3 struct{}
@@ -0,0 +1,29 @@
+// +build amd64
+// errorcheck -0 -d=layoutcompare=386
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -d=layoutcompare reports the types whose layout differs
+// on another architecture and flags misaligned 64-bit fields.
+
+package p
+
+type T struct { // ERROR "^T: size 24, align 8; on 386: size 16, align 4$"
+	a    int32
+	n    int64 // ERROR "^T.n: offset 8; on 386: offset 4, not 8-byte aligned$"
+	flag bool  // ERROR "^T.flag: offset 16; on 386: offset 12$"
+}
+
+type Same struct{ a, b int32 }
+
+type Words [2]uintptr // ERROR "^Words: size 16, align 8; on 386: size 8, align 4$"
+
+func f() {
+	type local struct { // ERROR "^local: size 16, align 8; on 386: size 12, align 4$"
+		x uint64
+		b bool
+	}
+	_ = local{}
+}
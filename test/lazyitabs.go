@@ -0,0 +1,103 @@
+// run -gcflags=-d=lazyitabs
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test conversions, assertions and type switches with itabs
+// generated at run time.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+type T int
+
+func (t T) String() string { return fmt.Sprint("T", int(t)) }
+
+func (T) Read([]byte) (int, error) { return 0, io.EOF }
+
+type P struct{ s string }
+
+func (p *P) String() string { return p.s }
+
+type B [64]byte
+
+func (B) String() string { return "B" }
+
+// Statically initialized with a static itab; dynamically with a
+// lazy one.
+var global fmt.Stringer = T(7)
+
+//go:noinline
+func kind(x fmt.Stringer) string {
+	switch v := x.(type) {
+	case nil:
+		return "nil"
+	case T:
+		return "T" + fmt.Sprint(int(v))
+	case *P:
+		return "P" + v.s
+	case B:
+		return "B"
+	}
+	return "other"
+}
+
+//go:noinline
+func toStringer(x interface{}) fmt.Stringer {
+	return x.(fmt.Stringer)
+}
+
+func main() {
+	var s fmt.Stringer = T(1)
+	tests := []struct {
+		x    fmt.Stringer
+		want string
+	}{
+		{s, "T1"},
+		{global, "T7"},
+		{&P{"x"}, "Px"},
+		{B{}, "B"},
+		{nil, "nil"},
+		{&strings.Builder{}, "other"},
+		{toStringer(T(2)), "T2"},
+		{toStringer(&P{"y"}), "Py"},
+	}
+	for _, tt := range tests {
+		if got := kind(tt.x); got != tt.want {
+			panic(fmt.Sprintf("kind(%v) = %s, want %s", tt.x, got, tt.want))
+		}
+	}
+
+	if t, ok := s.(T); !ok || t != 1 {
+		panic("s.(T) failed")
+	}
+	if _, ok := s.(*P); ok {
+		panic("s.(*P) succeeded")
+	}
+	var nilStringer fmt.Stringer
+	if _, ok := nilStringer.(T); ok {
+		panic("nil.(T) succeeded")
+	}
+	var r io.Reader = T(3)
+	if s, ok := r.(fmt.Stringer); !ok || s.String() != "T3" {
+		panic("r.(fmt.Stringer) failed")
+	}
+	if s != fmt.Stringer(T(1)) || s == global || s != T(1) {
+		panic("bad comparison")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				panic("s.(B) did not panic")
+			}
+		}()
+		_ = s.(B)
+	}()
+}
@@ -0,0 +1,82 @@
+// errorcheck -0 -d=ssa/licm/debug=1
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that loop-invariant values are hoisted out of loops,
+// and that values which might fault are not.
+
+package p
+
+type T struct {
+	s []int
+	n int
+}
+
+var g int
+
+func global(x []int) int {
+	sum := 0
+	for i := 0; i < g; i++ { // ERROR "hoisted Load out of loop"
+		sum += x[i]
+	}
+	return sum
+}
+
+func unchecked(t *T, x []int) int {
+	sum := 0
+	for i := 0; i < t.n; i++ {
+		sum += x[i]
+	}
+	return sum
+}
+
+func checked(t *T, x []int) int {
+	sum := len(t.s)
+	for i := 0; i < t.n; i++ { // ERROR "hoisted OffPtr out of loop" "hoisted Load out of loop"
+		sum += x[i]
+	}
+	return sum
+}
+
+// Loads in the loop body do not run if the loop ends
+// before reaching them.
+func body(t *T, x []int) int {
+	sum := len(t.s)
+	for i := range x {
+		sum += x[i] * t.n
+	}
+	return sum
+}
+
+// The address of a load guarded by a branch in the loop
+// may be out of bounds even though it is not nil.
+func guarded(x []int, n int) int {
+	sum := x[0]
+	for i := 0; i < n; i++ {
+		if len(x) > 1<<20 { // ERROR "hoisted Less64 out of loop"
+			sum += x[1<<20]
+		}
+	}
+	return sum
+}
+
+func arith(a []int, k int) int {
+	sum := 0
+	for i := 0; i < len(a); i++ {
+		sum += a[i] + k*k // ERROR "hoisted Mul64 out of loop"
+	}
+	return sum
+}
+
+func divide(a []int, d int) int {
+	sum := 0
+	for i := 0; i < len(a); i++ {
+		sum += a[i] + 100/d // ERROR "hoisted Neq64 out of loop"
+	}
+	return sum
+}
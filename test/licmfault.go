@@ -0,0 +1,33 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that loop-invariant code motion does not move a load out of
+// the branch that guards it: the address of s[1<<30] is not nil, but
+// it is out of bounds and faults.
+
+package main
+
+var g [1]byte
+
+var s = g[:]
+
+//go:noinline
+func sum(n int) int {
+	s := s
+	sum := int(s[0])
+	for i := 0; i < n; i++ {
+		if len(s) > 1<<30 {
+			sum += int(s[1<<30])
+		}
+	}
+	return sum
+}
+
+func main() {
+	if got := sum(10); got != 0 {
+		panic(got)
+	}
+}
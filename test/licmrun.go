@@ -0,0 +1,47 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that values hoisted out of loops are not evaluated
+// when doing so could fault and the loop body never runs.
+
+package main
+
+type T struct {
+	n int
+}
+
+//go:noinline
+func load(t *T, n int) int {
+	sum := 0
+	for i := 0; i < n; i++ {
+		sum += t.n
+	}
+	return sum
+}
+
+//go:noinline
+func divide(a []int, d int) int {
+	sum := 0
+	for i := 0; i < len(a); i++ {
+		sum += a[i] / d
+	}
+	return sum
+}
+
+func main() {
+	if got := load(nil, 0); got != 0 {
+		panic(got)
+	}
+	if got := load(&T{3}, 4); got != 12 {
+		panic(got)
+	}
+	if got := divide(nil, 0); got != 0 {
+		panic(got)
+	}
+	if got := divide([]int{4, 8}, 2); got != 6 {
+		panic(got)
+	}
+}
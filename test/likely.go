@@ -0,0 +1,44 @@
+// errorcheck -0 -d=ssa/layout/debug=1
+
+//go:build amd64
+// +build amd64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that //go:likely and //go:unlikely decide which branch
+// of an if statement is laid out after the hot code.
+
+package p
+
+func unlikely(x int) int {
+	//go:unlikely
+	if x > 100 {
+		x *= 3 // ERROR "cold block"
+	}
+	return x + 1
+}
+
+func likely(x int) int {
+	//go:likely
+	if x > 100 {
+		return x * 2
+	}
+	return x - 1 // ERROR "cold block"
+}
+
+func nohint(x int) int {
+	if x > 100 {
+		x *= 3
+	}
+	return x + 1
+}
+
+func multiple(x, y int) int {
+	//go:unlikely
+	if x > 100 && y > 100 {
+		x, y = y, x // ERROR "cold block"
+	}
+	return x - y
+}
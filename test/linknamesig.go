@@ -0,0 +1,35 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that functions linked to the same symbol with //go:linkname
+// must agree on the layout of their arguments.
+
+package p
+
+import _ "unsafe"
+
+//go:linkname nanotime1 runtime.nanotime
+func nanotime1() int64
+
+//go:linkname nanotime2 runtime.nanotime
+func nanotime2() int64
+
+//go:linkname nanotime3 runtime.nanotime
+func nanotime3() (int32, int32) // ERROR "nanotime3 has signature func\(\) \(int32, int32\), which does not match func\(\) int64 at .*; both are linked to runtime.nanotime"
+
+//go:linkname pin1 sync.runtime_procPin
+func pin1() int
+
+//go:linkname pin2 sync.runtime_procPin
+func pin2() // ERROR "pin2 has signature func\(\), which does not match func\(\) int"
+
+// Parameters of different types but the same layout are compatible.
+
+//go:linkname memmove1 runtime.memmove
+func memmove1(to, from *byte, n uintptr)
+
+//go:linkname memmove2 runtime.memmove
+func memmove2(to, from uintptr, n int)
@@ -0,0 +1,27 @@
+// +build amd64
+// compile -live=json
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -live=json reports the live variables and their pointer
+// slots at each safe point: at entry both arguments are live, and at
+// the call to g only t is.
+
+package p
+
+type T struct {
+	a *int
+	n int
+	b *int
+}
+
+//go:noinline
+func g() int { return 0 }
+
+func F(p *int, t T) int {
+	x := *p
+	n := g()
+	return x + n + *t.a + *t.b
+}
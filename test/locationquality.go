@@ -0,0 +1,29 @@
+// errorcheck -0 -p=p -d=locationquality
+
+//go:build amd64 && !goexperiment.regabiargs
+// +build amd64,!goexperiment.regabiargs
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that variables of optimized code are listed in the debug
+// info, and the report of how much of the code they have locations
+// for. Arguments passed on the stack that are never assigned to are
+// in their argument slot throughout the call, used or not.
+
+package p
+
+type S struct {
+	a, b int
+}
+
+func f(x int, s S, unused int) int { // ERROR "f: 5 variables, 60% average location coverage" "x: 100% location coverage" "s: 100% location coverage" "unused: 100% location coverage" "~r3: 0% location coverage"
+	y := x * 2 // ERROR "y: 0% location coverage"
+	g()
+	return y + s.a
+}
+
+//go:noinline
+func g() {
+}
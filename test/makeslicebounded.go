@@ -0,0 +1,62 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that stack-allocated slices with a bounded but
+// non-constant size have the right length and capacity
+// and panic like runtime.makeslice does.
+
+package main
+
+import "strings"
+
+//go:noinline
+func mk(n int, c uint) []int {
+	s := make([]int, n, c%16)
+	for i := range s {
+		s[i] = i
+	}
+	sum := 0
+	for _, v := range s[:cap(s)] {
+		sum += v
+	}
+	if want := n * (n - 1) / 2; sum != want {
+		panic("bad sum")
+	}
+	return nil
+}
+
+//go:noinline
+func lc(n int) (int, int) {
+	s := make([]byte, n&31)
+	return len(s), cap(s)
+}
+
+func shouldPanic(str string, f func()) {
+	defer func() {
+		err := recover()
+		if err == nil {
+			panic("did not panic")
+		}
+		s := err.(error).Error()
+		if !strings.Contains(s, str) {
+			panic("got panic " + s + ", want " + str)
+		}
+	}()
+	f()
+}
+
+func main() {
+	for _, n := range []int{0, 1, 31, 32, 33, 1000} {
+		if l, c := lc(n); l != n&31 || c != n&31 {
+			panic("bad len/cap")
+		}
+	}
+	mk(3, 3)
+	mk(3, 19)
+	mk(0, 16)
+	shouldPanic("cap out of range", func() { mk(4, 3) })
+	shouldPanic("len out of range", func() { mk(-1, 3) })
+}
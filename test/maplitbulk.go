@@ -0,0 +1,76 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test map literals large enough to be initialized from
+// read-only arrays of keys and elements.
+
+package main
+
+import "fmt"
+
+type big struct {
+	a [40]int32
+	s string
+}
+
+var strs = map[string]int{
+	"a": 1, "b": 2, "c": 3, "d": 4, "e": 5,
+	"f": 6, "g": 7, "h": 8, "i": 9, "j": 10,
+}
+
+var ints = map[int]string{
+	1: "a", 2: "b", 3: "c", 4: "d", 5: "e",
+	6: "f", 7: "g", 8: "h", 9: "i", 10: "j",
+}
+
+var floats = map[float64]bool{
+	0.5: true, 1.5: false, 2.5: true, 3.5: false, 4.5: true,
+	5.5: false, 6.5: true, 7.5: false, 8.5: true, -0.0: false,
+}
+
+var bigs = map[big]big{
+	{s: "a"}: {s: "A"}, {s: "b"}: {s: "B"}, {s: "c"}: {s: "C"},
+	{s: "d"}: {s: "D"}, {s: "e"}: {s: "E"}, {s: "f"}: {s: "F"},
+	{s: "g"}: {s: "G"}, {s: "h"}: {s: "H"}, {s: "i"}: {s: "I"},
+}
+
+var empties = map[int]struct{}{
+	1: {}, 2: {}, 3: {}, 4: {}, 5: {}, 6: {}, 7: {}, 8: {}, 9: {},
+}
+
+var ifaces = map[interface{}]interface{}{
+	1: "a", "b": 2, 3.0: nil, true: 4, 'e': 5,
+	6: 6, 7: 7, 8: 8, 9: 9,
+}
+
+func local() map[uint8]uint16 {
+	return map[uint8]uint16{
+		1: 100, 2: 200, 3: 300, 4: 400, 5: 500,
+		6: 600, 7: 700, 8: 800, 9: 900,
+	}
+}
+
+func main() {
+	check := func(name string, got, want interface{}) {
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			panic(fmt.Sprintf("%s = %v, want %v", name, got, want))
+		}
+	}
+	check("len(strs)", len(strs), 10)
+	check("strs[j]", strs["j"], 10)
+	check("ints[7]", ints[7], "g")
+	check("len(floats)", len(floats), 10)
+	check("floats[0]", floats[0], false)
+	check("floats[8.5]", floats[8.5], true)
+	check("bigs[e]", bigs[big{s: "e"}].s, "E")
+	check("len(empties)", len(empties), 9)
+	check("ifaces", ifaces, map[interface{}]interface{}{1: "a", "b": 2, 3.0: nil, true: 4, 'e': 5, 6: 6, 7: 7, 8: 8, 9: 9})
+
+	m := local()
+	m[1] = 1
+	check("local()[1]", local()[1], 100)
+	check("local()[9]", local()[9], 900)
+}
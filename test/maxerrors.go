@@ -0,0 +1,16 @@
+// errorcheck -maxerrors=3
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -maxerrors stops the compiler after the given number of
+// errors, even with -e.
+
+package p
+
+var _ = a0 // ERROR "undefined: a0"
+var _ = a1 // ERROR "undefined: a1"
+var _ = a2 // ERROR "undefined: a2" "too many errors"
+var _ = a3
+var _ = a4
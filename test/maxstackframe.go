@@ -0,0 +1,31 @@
+// errorcheck -maxstackframe=1024
+
+//go:build amd64
+// +build amd64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -maxstackframe reports functions with large stack frames
+// and lists their largest locals.
+
+package p
+
+//go:noescape
+func use(*[1000]byte)
+
+//go:noescape
+func use2(*[40]int64)
+
+func big() { // ERROR "stack frame too large \(1336 bytes > -maxstackframe=1024\)\n.*buf \[1000\]byte \(1000 bytes\)\n.*ints \[40\]int64 \(320 bytes\)"
+	var buf [1000]byte
+	var ints [40]int64
+	use(&buf)
+	use2(&ints)
+}
+
+func small() {
+	var buf [1000]byte
+	use(&buf)
+}
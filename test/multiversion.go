@@ -0,0 +1,64 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that //go:multiversion functions compute the same results
+// whichever version of them runs.
+
+package main
+
+import (
+	"math"
+	"math/bits"
+)
+
+type T struct{ n int }
+
+//go:multiversion
+func count(xs []uint64) (n int) {
+	for _, x := range xs {
+		n += bits.OnesCount64(x)
+	}
+	return
+}
+
+//go:multiversion
+func (t *T) add(a, _ float64, b float64) (float64, int) {
+	t.n++
+	return math.FMA(a, b, 1) + math.Floor(a), t.n
+}
+
+//go:multiversion
+func sum(p *int, xs ...int) {
+	for _, x := range xs {
+		*p += x
+	}
+}
+
+//go:multiversion
+func ceil(float64) float64 {
+	return math.Ceil(1.5)
+}
+
+func main() {
+	if n := count([]uint64{7, 255, 1}); n != 12 {
+		panic(n)
+	}
+	t := new(T)
+	if x, n := t.add(2.5, 0, 3); x != 10.5 || n != 1 {
+		panic(x)
+	}
+	if x, n := t.add(2.5, 0, 3); x != 10.5 || n != 2 {
+		panic(n)
+	}
+	var s int
+	sum(&s, 1, 2, 3)
+	if s != 6 {
+		panic(s)
+	}
+	if x := ceil(0); x != 2 {
+		panic(x)
+	}
+}
@@ -0,0 +1,27 @@
+// errorcheck -m
+
+//go:build amd64
+// +build amd64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that //go:multiversion functions are not inlined
+// and may not call recover.
+
+package p
+
+//go:multiversion
+func f(x int) int {
+	return x + 1
+}
+
+func g(x int) int { // ERROR "can inline g"
+	return f(x)
+}
+
+//go:multiversion
+func h() { // ERROR "go:multiversion function h cannot call recover"
+	recover()
+}
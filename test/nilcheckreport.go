@@ -0,0 +1,25 @@
+// errorcheck -0 -d=nilcheckreport
+
+//go:build amd64
+// +build amd64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the per-function nil check report.
+
+package p
+
+type T struct {
+	a, b int
+	next *T
+}
+
+func chain(t *T) int {
+	return t.a + t.b + t.next.a // ERROR "nil check eliminated" "chain: 4 nil checks inserted, 4 eliminated, 0 remaining"
+}
+
+func store(p *[1 << 20]byte) {
+	p[1<<19] = 1 // ERROR "nil check remains"
+} // ERROR "store: 1 nil checks inserted, 0 eliminated, 1 remaining"
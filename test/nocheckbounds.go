@@ -0,0 +1,42 @@
+// errorcheck -0 -d=ssa/check_bce/debug=1
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that //go:nocheckbounds removes the bounds checks from the
+// function it marks, but not from code inlined into it.
+
+package p
+
+import _ "unsafe" // for go:nocheckbounds
+
+//go:nocheckbounds
+func gather(dst, src []int, idx []int32) {
+	for i, j := range idx {
+		dst[i] = src[j]
+	}
+}
+
+func gatherChecked(dst, src []int, idx []int32) {
+	for i, j := range idx {
+		dst[i] = src[j] // ERROR "Found IsInBounds$"
+	}
+}
+
+//go:nocheckbounds
+func window(b []byte, i, n int) []byte {
+	return b[i : i+n : i+n]
+}
+
+func at(b []byte, i int) byte {
+	return b[i] // ERROR "Found IsInBounds$"
+}
+
+//go:nocheckbounds
+func sum(b []byte, idx []int) (s int) {
+	for _, i := range idx {
+		s += int(at(b, i)) // ERROR "Found IsInBounds$"
+	}
+	return s
+}
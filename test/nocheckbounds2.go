@@ -0,0 +1,15 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that //go:nocheckbounds is only allowed in files that
+// import unsafe.
+
+package p
+
+//go:nocheckbounds // ERROR "//go:nocheckbounds only allowed in Go files that import .unsafe."
+func f(b []byte, i int) byte {
+	return b[i]
+}
@@ -118,13 +118,14 @@ start 136
 
 # A nosplit leaf can use the whole 128-CallSize bytes available on entry.
 # (CallSize is 32 on ppc64, 8 on amd64 for frame pointer.)
-start 96 nosplit
+# ppc64 and riscv64 also reserve 8 bytes for the frame pointer.
+start 96 nosplit; REJECT ppc64 ppc64le
 start 100 nosplit; REJECT ppc64 ppc64le
 start 104 nosplit; REJECT ppc64 ppc64le arm64
 start 108 nosplit; REJECT ppc64 ppc64le
 start 112 nosplit; REJECT ppc64 ppc64le arm64
 start 116 nosplit; REJECT ppc64 ppc64le
-start 120 nosplit; REJECT ppc64 ppc64le amd64 arm64
+start 120 nosplit; REJECT ppc64 ppc64le amd64 arm64 riscv64
 start 124 nosplit; REJECT ppc64 ppc64le amd64
 start 128 nosplit; REJECT
 start 132 nosplit; REJECT
@@ -136,13 +137,14 @@ start 136 nosplit; REJECT
 # Because arm64 doesn't save LR in the leaf, it gets an extra 8 bytes.
 # ppc64 doesn't save LR in the leaf, but CallSize is 32, so it gets 24 bytes.
 # Because AMD64 uses frame pointer, it has 8 fewer bytes.
-start 96 nosplit call f; f 0 nosplit
+# So do ppc64 and riscv64.
+start 96 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le
 start 100 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le
 start 104 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le arm64
 start 108 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le
 start 112 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le amd64 arm64
 start 116 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le amd64
-start 120 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le amd64 arm64
+start 120 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le amd64 arm64 riscv64
 start 124 nosplit call f; f 0 nosplit; REJECT ppc64 ppc64le amd64 386
 start 128 nosplit call f; f 0 nosplit; REJECT
 start 132 nosplit call f; f 0 nosplit; REJECT
@@ -152,26 +154,26 @@ start 136 nosplit call f; f 0 nosplit; REJECT
 # having room for the saved caller PC of the call but also the
 # saved caller PC for the call to morestack.
 # Architectures differ in the same way as before.
-start 96 nosplit call f; f 0 call f
+start 96 nosplit call f; f 0 call f; REJECT ppc64 ppc64le
 start 100 nosplit call f; f 0 call f; REJECT ppc64 ppc64le
 start 104 nosplit call f; f 0 call f; REJECT ppc64 ppc64le amd64 arm64
 start 108 nosplit call f; f 0 call f; REJECT ppc64 ppc64le amd64
 start 112 nosplit call f; f 0 call f; REJECT ppc64 ppc64le amd64 arm64
 start 116 nosplit call f; f 0 call f; REJECT ppc64 ppc64le amd64
-start 120 nosplit call f; f 0 call f; REJECT ppc64 ppc64le amd64 386 arm64
+start 120 nosplit call f; f 0 call f; REJECT ppc64 ppc64le amd64 386 arm64 riscv64
 start 124 nosplit call f; f 0 call f; REJECT ppc64 ppc64le amd64 386
 start 128 nosplit call f; f 0 call f; REJECT
 start 132 nosplit call f; f 0 call f; REJECT
 start 136 nosplit call f; f 0 call f; REJECT
 
 # Indirect calls are assumed to be splitting functions.
-start 96 nosplit callind
+start 96 nosplit callind; REJECT ppc64 ppc64le
 start 100 nosplit callind; REJECT ppc64 ppc64le
 start 104 nosplit callind; REJECT ppc64 ppc64le amd64 arm64
 start 108 nosplit callind; REJECT ppc64 ppc64le amd64
 start 112 nosplit callind; REJECT ppc64 ppc64le amd64 arm64
 start 116 nosplit callind; REJECT ppc64 ppc64le amd64
-start 120 nosplit callind; REJECT ppc64 ppc64le amd64 386 arm64
+start 120 nosplit callind; REJECT ppc64 ppc64le amd64 386 arm64 riscv64
 start 124 nosplit callind; REJECT ppc64 ppc64le amd64 386
 start 128 nosplit callind; REJECT
 start 132 nosplit callind; REJECT
@@ -1036,6 +1036,114 @@ func divShiftClean32(n int32) int32 {
 	return n / int32(16) // ERROR "Proved Rsh32x64 shifts to zero"
 }
 
+func ring(buf []byte, i int) byte {
+	if i < 0 {
+		return 0
+	}
+	return buf[i%len(buf)] // ERROR "Proved Mod64 does not need fix-up$" "Proved IsInBounds$"
+}
+
+func ringLoop(buf []int, n int) int {
+	t := 0
+	for i := 0; i < n; i++ { // ERROR "Induction variable: limits \[0,\?\), increment 1$"
+		t += buf[i%len(buf)] // ERROR "Proved Mod64 does not need fix-up$" "Proved IsInBounds$"
+	}
+	return t
+}
+
+func ringUnsigned(buf []byte, i uint) byte {
+	return buf[i%uint(len(buf))] // ERROR "Proved IsInBounds$"
+}
+
+func ringSigned(buf []byte, i int) byte {
+	return buf[i%len(buf)] // ERROR "Proved Mod64 does not need fix-up$"
+}
+
+func maskLen(s []byte, i int) byte {
+	if len(s) == 0 {
+		return 0
+	}
+	return s[i&(len(s)-1)] // ERROR "Proved IsInBounds$"
+}
+
+func maskConst(s []byte, i int) byte {
+	if len(s) < 16 {
+		return 0
+	}
+	return s[i&15] // ERROR "Proved IsInBounds$"
+}
+
+func maskConstShort(s []byte, i int) byte {
+	if len(s) < 15 {
+		return 0
+	}
+	return s[i&15]
+}
+
+func mulConst(s []byte, i int) byte {
+	if i < 0 || i > 4 || len(s) < 13 {
+		return 0
+	}
+	return s[i*3] // ERROR "Proved IsInBounds$"
+}
+
+func shiftConst(s []byte, i int) byte {
+	if i < 0 || i >= 4 || len(s) < 16 {
+		return 0
+	}
+	return s[i*4] // ERROR "Proved IsInBounds$"
+}
+
+func mulConstShort(s []byte, i int) byte {
+	if i < 0 || i >= 4 || len(s) < 15 {
+		return 0
+	}
+	return s[i*4+2]
+}
+
+// Guards that call a helper which never returns.
+
+//go:noinline
+func fail(msg string) {
+	panic("prove: " + msg)
+}
+
+//go:noinline
+func failf(format string, args ...interface{}) {
+	if len(args) > 0 {
+		format += "..."
+	}
+	fail(format)
+}
+
+//go:noinline
+func mayFail(msg string) {
+	if msg != "" {
+		panic(msg)
+	}
+}
+
+func guardHelper(b []byte, i int) byte {
+	if i < 0 || i >= len(b) {
+		fail("index")
+	}
+	return b[i] // ERROR "Proved IsInBounds$"
+}
+
+func guardHelperChain(b []byte, n int) []byte {
+	if n < 0 || n > len(b) {
+		failf("bad length %d", n)
+	}
+	return b[:n] // ERROR "Proved IsSliceInBounds$"
+}
+
+func guardHelperMayReturn(b []byte, i int) byte {
+	if i < 0 || i >= len(b) {
+		mayFail("index")
+	}
+	return b[i]
+}
+
 //go:noinline
 func useInt(a int) {
 }
@@ -0,0 +1,78 @@
+// errorcheck -0 -d=ssa/pure_calls/debug=1
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that calls to //go:pure functions are removed when their
+// results are unused and merged when a call with the same arguments
+// always precedes them.
+
+package p
+
+//go:pure
+//go:noinline
+func sq(x int) int { return x * x }
+
+//go:pure
+//go:noinline
+func divmod(x, y int) (int, int) { return x / y, x % y }
+
+//go:noinline
+func impure(x int) int { return x }
+
+func f1(x int) int {
+	return sq(x) + sq(x) // ERROR "reused result of pure call to .*sq"
+}
+
+func f2(x int) int {
+	return sq(x) + sq(x+1)
+}
+
+func f3(x int) {
+	_ = sq(x) // ERROR "removed unused pure call to .*sq"
+}
+
+func f4(x int, b bool) int {
+	y := sq(x)
+	if b {
+		return y + sq(x) // ERROR "reused result of pure call to .*sq"
+	}
+	return y
+}
+
+func f5(x int, b bool) int {
+	y := 0
+	if b {
+		y = sq(x)
+	}
+	return y + sq(x)
+}
+
+func f6(x, y int) int {
+	q, _ := divmod(x, y)
+	_, r := divmod(x, y) // ERROR "reused result of pure call to .*divmod"
+	return q + r
+}
+
+func f7(x int) int {
+	return impure(x) + impure(x)
+}
+
+func f8(x int) int {
+	s := 0
+	for i := 0; i < 10; i++ {
+		s += sq(x) + sq(i)
+	}
+	return s + sq(x)
+}
+
+type T struct{ v int }
+
+//go:pure
+//go:noinline
+func (t T) get(i int) int { return t.v + i }
+
+func f9(t T, x int) int {
+	return t.get(x) + t.get(x) // ERROR "reused result of pure call to .*T.get"
+}
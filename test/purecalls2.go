@@ -0,0 +1,14 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that //go:pure is only allowed on functions with results.
+
+package p
+
+//go:pure
+func f(x int) { // ERROR "go:pure function must have results"
+	println(x)
+}
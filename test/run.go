@@ -837,7 +837,15 @@ func (t *test) run() {
 
 	case "compile":
 		// Compile Go file.
-		_, t.err = compileFile(runcmd, long, flags)
+		// If there is a .out file, the compiler's output must match it.
+		out, err := compileFile(runcmd, long, flags)
+		if err != nil {
+			t.err = err
+			return
+		}
+		if _, err := os.Stat(strings.TrimSuffix(long, ".go") + ".out"); err == nil {
+			t.checkExpectedOutput(bytes.Replace(out, []byte(long), []byte(t.gofile), -1))
+		}
 
 	case "compiledir":
 		// Compile all files in the directory as packages in lexicographic order.
@@ -1585,13 +1593,14 @@ var (
 	// are the supported variants.
 	archVariants = map[string][]string{
 		"386":     {"GO386", "sse2", "softfloat"},
-		"amd64":   {},
+		"amd64":   {"GOAMD64", "v1", "v2", "v3", "v4"},
 		"arm":     {"GOARM", "5", "6", "7"},
 		"arm64":   {},
 		"mips":    {"GOMIPS", "hardfloat", "softfloat"},
 		"mips64":  {"GOMIPS64", "hardfloat", "softfloat"},
-		"ppc64":   {"GOPPC64", "power8", "power9"},
-		"ppc64le": {"GOPPC64", "power8", "power9"},
+		"ppc64":   {"GOPPC64", "power8", "power9", "power10"},
+		"ppc64le": {"GOPPC64", "power8", "power9", "power10"},
+		"riscv64": {},
 		"s390x":   {},
 		"wasm":    {},
 	}
@@ -1944,20 +1953,24 @@ func overlayDir(dstRoot, srcRoot string) error {
 // List of files that the compiler cannot errorcheck with the new typechecker (compiler -G option).
 // Temporary scaffolding until we pass all the tests at which point this map can be removed.
 var excluded = map[string]bool{
-	"complit1.go":     true, // types2 reports extra errors
-	"const2.go":       true, // types2 not run after syntax errors
-	"ddd1.go":         true, // issue #42987
-	"directive.go":    true, // misplaced compiler directive checks
-	"float_lit3.go":   true, // types2 reports extra errors
-	"import1.go":      true, // types2 reports extra errors
-	"import5.go":      true, // issue #42988
-	"import6.go":      true, // issue #43109
-	"initializerr.go": true, // types2 reports extra errors
-	"linkname2.go":    true, // error reported by noder (not running for types2 errorcheck test)
-	"notinheap.go":    true, // types2 doesn't report errors about conversions that are invalid due to //go:notinheap
-	"shift1.go":       true, // issue #42989
-	"typecheck.go":    true, // invalid function is not causing errors when called
-	"writebarrier.go": true, // correct diagnostics, but different lines (probably irgen's fault)
+	"complit1.go":       true, // types2 reports extra errors
+	"const2.go":         true, // types2 not run after syntax errors
+	"ddd1.go":           true, // issue #42987
+	"didyoumean.go":     true, // types2 doesn't suggest corrections for misspelled names
+	"directive.go":      true, // misplaced compiler directive checks
+	"errorcodes.go":     true, // types2 errors have no codes
+	"float_lit3.go":     true, // types2 reports extra errors
+	"import1.go":        true, // types2 reports extra errors
+	"import5.go":        true, // issue #42988
+	"import6.go":        true, // issue #43109
+	"initializerr.go":   true, // types2 reports extra errors
+	"langdirective2.go": true, // misplaced compiler directive checks
+	"linkname2.go":      true, // error reported by noder (not running for types2 errorcheck test)
+	"notinheap.go":      true, // types2 doesn't report errors about conversions that are invalid due to //go:notinheap
+	"shift1.go":         true, // issue #42989
+	"typecheck.go":      true, // invalid function is not causing errors when called
+	"typeloops.go":      true, // types2 reports only one of the loops sharing a type
+	"writebarrier.go":   true, // correct diagnostics, but different lines (probably irgen's fault)
 
 	"fixedbugs/bug176.go":    true, // types2 reports all errors (pref: types2)
 	"fixedbugs/bug195.go":    true, // types2 reports slightly different (but correct) bugs
@@ -1978,7 +1991,6 @@ var excluded = map[string]bool{
 	"fixedbugs/issue16428.go":  true, // types2 reports two instead of one error
 	"fixedbugs/issue17038.go":  true, // types2 doesn't report a follow-on error (pref: types2)
 	"fixedbugs/issue17645.go":  true, // multiple errors on same line
-	"fixedbugs/issue18331.go":  true, // missing error about misuse of //go:noescape (irgen needs code from noder)
 	"fixedbugs/issue18393.go":  true, // types2 not run after syntax errors
 	"fixedbugs/issue19012.go":  true, // multiple errors on same line
 	"fixedbugs/issue20233.go":  true, // types2 reports two instead of one error (pref: compiler)
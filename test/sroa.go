@@ -0,0 +1,59 @@
+// errorcheck -0 -d=ssa/sroa/debug=1
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that local aggregates that are only accessed
+// piecewise are split into SSA values.
+
+package p
+
+type big struct {
+	a, b, c, d, e int
+}
+
+func fields(x, y int) int {
+	var s big // ERROR "scalar replaced s \(2 pieces\)"
+	s.a = x
+	s.e = y
+	return s.a + s.e
+}
+
+func copyIn(p *big) int {
+	s := *p // ERROR "scalar replaced s \(2 pieces\)"
+	s.c++
+	return s.a + s.c
+}
+
+func copyOut(p *big, x int) {
+	var s big // ERROR "scalar replaced s \(5 pieces\)"
+	s.a = x
+	s.b = x
+	s.c = x
+	s.d = x
+	s.e = x
+	*p = s
+}
+
+func addrTaken(x int) *big {
+	var s big
+	s.a = x
+	return &s
+}
+
+func variableIndex(i int) int {
+	var a [8]int
+	a[i] = 1
+	return a[0]
+}
+
+func array(x int) int {
+	var a [8]int // ERROR "scalar replaced a \(2 pieces\)"
+	a[1] = x
+	a[6] = x + 1
+	return a[1] * a[6]
+}
@@ -0,0 +1,99 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the correctness of scalar replacement of aggregates.
+
+package main
+
+type big struct {
+	a, b, c, d, e int
+}
+
+type mixed struct {
+	s    string
+	b    []byte
+	e    error
+	x, y int
+}
+
+//go:noinline
+func loop(x []int) int {
+	var s big
+	for i := range x {
+		s.b += x[i]
+		s.d ^= x[i]
+	}
+	return s.b + s.d
+}
+
+//go:noinline
+func branch(x string, b []byte) (int, bool) {
+	var s mixed
+	if len(x) > 2 {
+		s.s = x
+	} else {
+		s.b = b
+	}
+	return len(s.s) + len(s.b), s.e == nil
+}
+
+//go:noinline
+func copyIn(p *big) int {
+	s := *p
+	p.c = 100
+	s.c++
+	return s.a + s.c
+}
+
+//go:noinline
+func copyOut(p *big, x int) {
+	var s big
+	s.a = x
+	s.b = x + 1
+	s.c = x + 2
+	s.d = x + 3
+	s.e = x + 4
+	*p = s
+	s.a = 0
+}
+
+//go:noinline
+func zeroed(x int) int {
+	s := big{a: x, b: x}
+	for i := 0; i < x; i++ {
+		if i == 3 {
+			s = big{}
+		}
+		s.a++
+	}
+	return s.a*10 + s.b
+}
+
+func main() {
+	if got := loop([]int{1, 2, 3, 4}); got != 10+4 {
+		panic(got)
+	}
+	if got, ok := branch("abcd", nil); got != 4 || !ok {
+		panic(got)
+	}
+	if got, ok := branch("a", []byte("xy")); got != 2 || !ok {
+		panic(got)
+	}
+	p := &big{a: 1, c: 2}
+	if got := copyIn(p); got != 4 || p.c != 100 {
+		panic(got)
+	}
+	copyOut(p, 7)
+	if *p != (big{7, 8, 9, 10, 11}) {
+		panic(*p)
+	}
+	if got := zeroed(5); got != 20 {
+		panic(got)
+	}
+	if got := zeroed(2); got != 42 {
+		panic(got)
+	}
+}
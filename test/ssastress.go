@@ -0,0 +1,67 @@
+// run -gcflags=-d=ssastress=1
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -d=ssastress compiles functions with defers, write
+// barriers, combinable stores and bounds checks consistently, and
+// that the program it produces still runs correctly.
+
+package main
+
+import "fmt"
+
+var sink []*int
+
+//go:noinline
+func put(b []byte, x uint32) {
+	b[0] = byte(x)
+	b[1] = byte(x >> 8)
+	b[2] = byte(x >> 16)
+	b[3] = byte(x >> 24)
+}
+
+//go:noinline
+func deferred(n int) (r int) {
+	defer func() { r += n }()
+	if n > 10 {
+		defer func() { r *= 2 }()
+	}
+	return n
+}
+
+//go:noinline
+func loop(m map[int]*int, n int) int {
+	s := 0
+	for i := 0; i < n; i++ {
+		p := new(int)
+		*p = i
+		m[i] = p
+		sink = append(sink, p)
+		if i%3 == 0 {
+			delete(m, i)
+		}
+	}
+	for _, p := range m {
+		s += *p
+	}
+	return s
+}
+
+func main() {
+	b := make([]byte, 4)
+	put(b, 0x04030201)
+	if b[0] != 1 || b[1] != 2 || b[2] != 3 || b[3] != 4 {
+		panic(fmt.Sprintf("put: got %v", b))
+	}
+	if got := deferred(3); got != 6 {
+		panic(fmt.Sprintf("deferred(3) = %d, want 6", got))
+	}
+	if got := deferred(11); got != 33 {
+		panic(fmt.Sprintf("deferred(11) = %d, want 33", got))
+	}
+	if got := loop(map[int]*int{}, 10); got != 27 {
+		panic(fmt.Sprintf("loop = %d, want 27", got))
+	}
+}
@@ -0,0 +1,74 @@
+// run -gcflags=-stackprotect
+
+//go:build !wasm
+// +build !wasm
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -stackprotect detects overflows of local byte arrays
+// and leaves correct programs alone.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"unsafe"
+)
+
+//go:noinline
+func fill(b []byte, c byte) {
+	for i := range b {
+		b[i] = c
+	}
+}
+
+//go:noinline
+func sum(n int) (s int) {
+	var buf [64]byte
+	defer func() { s += int(buf[0]) }()
+	fill(buf[:n], 1)
+	for _, c := range buf {
+		s += int(c)
+	}
+	if s > 32 {
+		return s * 2
+	}
+	return s
+}
+
+//go:noinline
+func smash() int {
+	var buf [16]byte
+	fill(buf[:], 2)
+	// Write past the end of buf, over the canary.
+	*(*uintptr)(unsafe.Pointer(uintptr(unsafe.Pointer(&buf)) + unsafe.Sizeof(buf))) = 0
+	return int(buf[0])
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "smash" {
+		smash()
+		return
+	}
+
+	if got := sum(10); got != 11 {
+		panic(got)
+	}
+	if got := sum(40); got != 81 {
+		panic(got)
+	}
+
+	cmd := exec.Command(os.Args[0], "smash")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		panic("overflow not detected")
+	}
+	if !bytes.Contains(out, []byte("stack smashing detected")) {
+		panic("unexpected output: " + strings.TrimSpace(string(out)))
+	}
+}
@@ -0,0 +1,142 @@
+// run -gcflags=-d=staticmaps
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test reads of maps built as static perfect hash tables.
+
+package main
+
+import "fmt"
+
+type token int
+
+const (
+	tokBreak token = iota + 1
+	tokCase
+	tokChan
+	tokConst
+	tokContinue
+	tokDefault
+	tokDefer
+	tokElse
+	tokFallthrough
+	tokFor
+	tokFunc
+	tokGo
+	tokGoto
+	tokIf
+	tokImport
+	tokInterface
+	tokMap
+	tokPackage
+	tokRange
+	tokReturn
+	tokSelect
+	tokStruct
+	tokSwitch
+	tokType
+	tokVar
+)
+
+var keywords = map[string]token{
+	"break":       tokBreak,
+	"case":        tokCase,
+	"chan":        tokChan,
+	"const":       tokConst,
+	"continue":    tokContinue,
+	"default":     tokDefault,
+	"defer":       tokDefer,
+	"else":        tokElse,
+	"fallthrough": tokFallthrough,
+	"for":         tokFor,
+	"func":        tokFunc,
+	"go":          tokGo,
+	"goto":        tokGoto,
+	"if":          tokIf,
+	"import":      tokImport,
+	"interface":   tokInterface,
+	"map":         tokMap,
+	"package":     tokPackage,
+	"range":       tokRange,
+	"return":      tokReturn,
+	"select":      tokSelect,
+	"struct":      tokStruct,
+	"switch":      tokSwitch,
+	"type":        tokType,
+	"var":         tokVar,
+}
+
+var names = [...]string{
+	"break", "case", "chan", "const", "continue", "default", "defer",
+	"else", "fallthrough", "for", "func", "go", "goto", "if", "import",
+	"interface", "map", "package", "range", "return", "select", "struct",
+	"switch", "type", "var",
+}
+
+type color string
+
+var hex = map[color]string{
+	"":      "#000000",
+	"red":   "#ff0000",
+	"green": "#00ff00",
+}
+
+var one = map[string]float64{"pi": 3.14159}
+
+// mutated is changed, so it remains a runtime map.
+var mutated = map[string]int{"a": 1}
+
+func lookup(s string) token {
+	return keywords[s]
+}
+
+func main() {
+	if n := len(keywords); n != len(names) {
+		panic(fmt.Sprintf("len(keywords) = %d, want %d", n, len(names)))
+	}
+	for i, s := range names {
+		if tok := lookup(s); tok != token(i+1) {
+			panic(fmt.Sprintf("keywords[%q] = %d, want %d", s, tok, i+1))
+		}
+		if tok, ok := keywords[s]; !ok || tok != token(i+1) {
+			panic(fmt.Sprintf("keywords[%q] = %d, %v, want %d, true", s, tok, ok, i+1))
+		}
+		b := []byte(s + "x")
+		if tok, ok := keywords[string(b[:len(s)])]; !ok || tok != token(i+1) {
+			panic(fmt.Sprintf("keywords[%q] = %d, %v, want %d, true", b[:len(s)], tok, ok, i+1))
+		}
+	}
+	for _, s := range []string{"", "x", "brea", "breaks", "Func", "var\x00", "defer "} {
+		if tok, ok := keywords[s]; ok || tok != 0 {
+			panic(fmt.Sprintf("keywords[%q] = %d, %v, want 0, false", s, tok, ok))
+		}
+		if tok := lookup(s); tok != 0 {
+			panic(fmt.Sprintf("keywords[%q] = %d, want 0", s, tok))
+		}
+	}
+
+	if v, ok := hex[""]; !ok || v != "#000000" {
+		panic(fmt.Sprintf(`hex[""] = %q, %v`, v, ok))
+	}
+	if v := hex[color("gr"+"een")]; v != "#00ff00" {
+		panic(fmt.Sprintf(`hex["green"] = %q`, v))
+	}
+	if _, ok := hex["blue"]; ok {
+		panic(`hex["blue"] found`)
+	}
+
+	f := func(s string) float64 { return one[s] }
+	if v := f("pi"); v != 3.14159 {
+		panic(fmt.Sprintf(`one["pi"] = %v`, v))
+	}
+	if v, ok := one["e"]; ok || v != 0 {
+		panic(fmt.Sprintf(`one["e"] = %v, %v`, v, ok))
+	}
+
+	mutated["b"] = 2
+	if len(mutated) != 2 || mutated["a"] != 1 || mutated["b"] != 2 {
+		panic(fmt.Sprint("mutated = ", mutated))
+	}
+}
@@ -0,0 +1,109 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test switches on strings with many cases of the same length.
+
+package main
+
+import "fmt"
+
+var keywords = []string{
+	"break", "case", "chan", "const", "continue", "default", "defer",
+	"else", "fallthrough", "for", "func", "go", "goto", "if", "import",
+	"interface", "map", "package", "range", "return", "select", "struct",
+	"switch", "type", "var", "aaaa", "aaab", "aaba", "abaa", "baaa",
+}
+
+//go:noinline
+func lookup(s string) int {
+	switch s {
+	case "break":
+		return 0
+	case "case":
+		return 1
+	case "chan":
+		return 2
+	case "const":
+		return 3
+	case "continue":
+		return 4
+	case "default":
+		return 5
+	case "defer":
+		return 6
+	case "else":
+		return 7
+	case "fallthrough":
+		return 8
+	case "for":
+		return 9
+	case "func":
+		return 10
+	case "go":
+		return 11
+	case "goto":
+		return 12
+	case "if":
+		return 13
+	case "import":
+		return 14
+	case "interface":
+		return 15
+	case "map":
+		return 16
+	case "package":
+		return 17
+	case "range":
+		return 18
+	case "return":
+		return 19
+	case "select":
+		return 20
+	case "struct":
+		return 21
+	case "switch":
+		return 22
+	case "type":
+		return 23
+	case "var":
+		return 24
+	case "aaaa":
+		return 25
+	case "aaab":
+		return 26
+	case "aaba":
+		return 27
+	case "abaa":
+		return 28
+	case "baaa":
+		return 29
+	}
+	return -1
+}
+
+func main() {
+	for i, k := range keywords {
+		if got := lookup(k); got != i {
+			panic(fmt.Sprintf("lookup(%q) = %d, want %d", k, got, i))
+		}
+		// Strings that differ from a case in one byte must not match.
+		for j := 0; j < len(k); j++ {
+			b := []byte(k)
+			b[j] ^= 1
+			if got := lookup(string(b)); got != -1 && keywords[got] != string(b) {
+				panic(fmt.Sprintf("lookup(%q) = %d, want -1", b, got))
+			}
+		}
+		if got := lookup(k + "x"); got != -1 {
+			panic(fmt.Sprintf("lookup(%q) = %d, want -1", k+"x", got))
+		}
+	}
+	for _, s := range []string{"", "x", "bbbb", "aabb", "zzzzz", "breaj"} {
+		if got := lookup(s); got != -1 {
+			panic(fmt.Sprintf("lookup(%q) = %d, want -1", s, got))
+		}
+	}
+}
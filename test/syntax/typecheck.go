@@ -0,0 +1,30 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that type errors are reported along with syntax errors
+// that are all in function bodies.
+
+package p
+
+import "strings"
+
+func f() int {
+	x := 1 +
+	return x // ERROR "unexpected return"
+}
+
+func g() string {
+	var s string = 1 // ERROR "cannot use 1"
+	return s
+}
+
+func h() {
+	_ = strings.ToUpper("x" +) // ERROR "unexpected \)"
+}
+
+func i() int {
+	return "str" // ERROR "cannot use .str."
+}
@@ -0,0 +1,75 @@
+// errorcheck -0 -d=tailcall
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that self-recursive tail calls are converted to loops.
+
+package p
+
+func fact(n, acc int) int {
+	if n == 0 {
+		return acc
+	}
+	return fact(n-1, acc*n) // ERROR "converted tail call to loop"
+}
+
+func gcd(a, b uint) uint {
+	switch {
+	case b == 0:
+		return a
+	default:
+		return gcd(b, a%b) // ERROR "converted tail call to loop"
+	}
+}
+
+type list struct {
+	next *list
+	v    int
+}
+
+func (l *list) last() *list {
+	if l.next == nil {
+		return l
+	}
+	return l.next.last() // ERROR "converted tail call to loop"
+}
+
+func walk(l *list, f func(int)) {
+	if l == nil {
+		return
+	}
+	f(l.v)
+	walk(l.next, f) // ERROR "converted tail call to loop"
+}
+
+func sum(acc int, xs ...int) int {
+	if len(xs) == 0 {
+		return acc
+	}
+	return sum(acc+xs[0], xs[1:]...) // ERROR "converted tail call to loop"
+}
+
+func notTail(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return 1 + notTail(n-1)
+}
+
+func deferred(n int) int {
+	defer func() {}()
+	if n == 0 {
+		return 0
+	}
+	return deferred(n - 1)
+}
+
+func addrTaken(p *int, n int) int {
+	x := n
+	if n == 0 {
+		return *p
+	}
+	return addrTaken(&x, n-1)
+}
@@ -0,0 +1,100 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the correctness of self-recursive tail call elimination,
+// and that it does not grow the stack.
+
+package main
+
+import "fmt"
+
+//go:noinline
+func count(n, acc int) int {
+	if n == 0 {
+		return acc
+	}
+	var pad [64]byte
+	pad[n%64] = byte(n)
+	return count(n-1, acc+int(pad[n%64]&1))
+}
+
+// swap checks that the arguments are evaluated before any
+// parameter is assigned.
+func swap(a, b, n int) (int, int) {
+	if n == 0 {
+		return a, b
+	}
+	return swap(b, a, n-1)
+}
+
+// named checks that named results start out zero in each call.
+func named(n int) (r int) {
+	if n == 0 {
+		return
+	}
+	r = n
+	if n%2 == 0 {
+		return named(n - 1)
+	}
+	return r + named(n-1)
+}
+
+type node struct {
+	next *node
+	v    int
+}
+
+func (n *node) sum(acc int) int {
+	if n == nil {
+		return acc
+	}
+	return n.next.sum(acc + n.v)
+}
+
+var visited int
+
+func visit(n *node) {
+	if n == nil {
+		return
+	}
+	visited += n.v
+	visit(n.next)
+}
+
+func variadic(acc int, xs ...int) int {
+	if len(xs) == 0 {
+		return acc
+	}
+	return variadic(acc*10+xs[0], xs[1:]...)
+}
+
+func main() {
+	// Deep enough to exceed the maximum stack size
+	// if the recursion were not eliminated.
+	if got, want := count(1e8, 0), int(1e8/2); got != want {
+		panic(fmt.Sprintf("count = %d, want %d", got, want))
+	}
+	if a, b := swap(1, 2, 3); a != 2 || b != 1 {
+		panic(fmt.Sprintf("swap = %d, %d", a, b))
+	}
+	if got := named(5); got != 5+3+1 {
+		panic(fmt.Sprintf("named = %d", got))
+	}
+	var l *node
+	for i := 1; i <= 100; i++ {
+		l = &node{l, i}
+	}
+	if got := l.sum(0); got != 5050 {
+		panic(fmt.Sprintf("sum = %d", got))
+	}
+	visit(l)
+	if visited != 5050 {
+		panic(fmt.Sprintf("visited = %d", visited))
+	}
+	if got := variadic(0, 1, 2, 3); got != 123 {
+		panic(fmt.Sprintf("variadic = %d", got))
+	}
+}
@@ -0,0 +1,27 @@
+// +build amd64
+// compile -d=typebits
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -d=typebits prints the pointer masks and GC programs of
+// type descriptors.
+
+package p
+
+type T struct {
+	a *int
+	n int
+	s string
+	i interface{}
+}
+
+type Big [100000]struct {
+	p *int
+	n int
+}
+
+var X T
+var Y *Big
+var Z [4]int
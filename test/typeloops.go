@@ -0,0 +1,30 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that each invalid recursive type loop is reported,
+// including loops that share types with a loop already reported.
+
+package p
+
+type A struct { // ERROR "invalid recursive type A\n\tLINE: A refers to\n\tLINE+4: B refers to\n\tLINE: A$" "invalid recursive type A\n\tLINE: A refers to\n\tLINE+5: C refers to\n\tLINE: A$"
+	b B
+	c C
+}
+type B struct{ a A }
+type C struct{ a A }
+
+type P struct{ q Q } // ERROR "invalid recursive type P\n\tLINE: P refers to\n\tLINE+1: Q refers to\n\tLINE+2: R refers to\n\tLINE: P$"
+type Q struct{ r R } // ERROR "invalid recursive type Q\n\tLINE: Q refers to\n\tLINE+1: R refers to\n\tLINE: Q$"
+type R struct {
+	p P
+	q Q
+}
+
+type X struct{ y Y } // ERROR "invalid recursive type X\n\tLINE: X refers to\n\tLINE+1: Y refers to\n\tLINE: X$"
+type Y struct {
+	x X
+	a A
+}
@@ -0,0 +1,26 @@
+// errorcheck -G=3
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Exporting generic functions is not supported yet; check that it is
+// reported as an ordinary error rather than a compiler crash.
+
+package p
+
+func Max[T interface{ type int, float64 }](x, y T) T { // ERROR "cannot export generic function Max"
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func max[T interface{ type int, float64 }](x, y T) T {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func Use() int { return max(1, 2) }
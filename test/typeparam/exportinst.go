@@ -0,0 +1,38 @@
+// errorcheck -G=3
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Exporting instantiated generic types is not supported yet; check
+// that declarations referring to them are reported as ordinary errors
+// rather than compiler crashes, and that inlinable functions whose
+// bodies refer to them can still be exported.
+
+package p
+
+type pair[K, V any] struct {
+	key K
+	val V
+}
+
+var X pair[int, string] // ERROR "cannot export X: it refers to instantiated generic type pair\[int,string\]"
+
+func F() pair[int, int] { // ERROR "cannot export F: it refers to instantiated generic type pair\[int,int\]"
+	return pair[int, int]{}
+}
+
+type A = pair[string, int] // ERROR "cannot export A: it refers to instantiated generic type pair\[string,int\]"
+
+type wrapper struct {
+	p pair[bool, bool]
+}
+
+func W() wrapper { // ERROR "cannot export W: it refers to instantiated generic type pair\[bool,bool\]"
+	return wrapper{}
+}
+
+func Key() int {
+	var p pair[int, string]
+	return p.key
+}
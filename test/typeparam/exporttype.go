@@ -0,0 +1,22 @@
+// errorcheck -G=3
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Exporting generic types is not supported yet; check that it is
+// reported as an ordinary error rather than a compiler crash.
+
+package p
+
+type Pair[K, V any] struct { // ERROR "cannot export generic type Pair"
+	Key K
+	Val V
+}
+
+type pair[K, V any] struct {
+	key K
+	val V
+}
+
+func Use() string { return pair[int, string]{1, "a"}.val }
@@ -0,0 +1,32 @@
+// +build amd64
+// compile -d=typesizes
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -d=typesizes reports the size and alignment of every
+// declared type, including function-local ones but not aliases,
+// largest first.
+
+package p
+
+type Small struct{ a, b int8 }
+
+type Big struct {
+	a [100]byte
+	p *int32
+}
+
+type Alias = Big
+
+type Words [4]uint32
+
+func f() int64 {
+	type local struct {
+		x, y int64
+		z    bool
+	}
+	var l local
+	return l.x
+}
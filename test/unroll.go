@@ -0,0 +1,90 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the correctness of loop unrolling.
+
+package main
+
+import "strings"
+
+//go:noinline
+func sum(a *[4]int) int {
+	s := 0
+	for i := 0; i < 4; i++ {
+		s += a[i]
+	}
+	return s
+}
+
+//go:noinline
+func step(a []int) int {
+	s := 0
+	for i := 1; i <= 7; i += 3 {
+		s = s*10 + a[i]
+	}
+	return s
+}
+
+//go:noinline
+func index(a []int) (s int) {
+	defer func() {
+		if e := recover(); e != nil {
+			if !strings.Contains(e.(error).Error(), "index out of range [2]") {
+				panic(e)
+			}
+			s = -s
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		s += a[i]
+	}
+	return s
+}
+
+//go:noinline
+func find(a []int, x int) int {
+	for i := 0; i < 3; i++ {
+		if a[i] == x {
+			return i
+		}
+	}
+	return -1
+}
+
+//go:noinline
+func inner(a *[3][2]int) int {
+	s := 0
+	for i := range a {
+		for j := 0; j < 2; j++ {
+			s += a[i][j] * (j + 1)
+		}
+	}
+	return s
+}
+
+func main() {
+	if got := sum(&[4]int{1, 2, 3, 4}); got != 10 {
+		panic(got)
+	}
+	if got := step([]int{0, 1, 2, 3, 4, 5, 6, 7}); got != 147 {
+		panic(got)
+	}
+	if got := index([]int{1, 2, 3, 4}); got != 10 {
+		panic(got)
+	}
+	if got := index([]int{1, 2}); got != -3 {
+		panic(got)
+	}
+	if got := find([]int{5, 6, 7}, 6); got != 1 {
+		panic(got)
+	}
+	if got := find([]int{5, 6, 7}, 8); got != -1 {
+		panic(got)
+	}
+	if got := inner(&[3][2]int{{1, 2}, {3, 4}, {5, 6}}); got != 5+11+17 {
+		panic(got)
+	}
+}
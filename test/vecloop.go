@@ -0,0 +1,202 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that simple loops over slices that are run by the
+// runtime behave exactly like the loops as written.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type pair struct {
+	a int32
+	b int16
+}
+
+//go:noinline
+func move(dst, src []byte) (i int) {
+	for i = range dst {
+		dst[i] = src[i]
+	}
+	return i
+}
+
+//go:noinline
+func moveRef(dst, src []byte) (i int) {
+	for j := 0; j < len(dst); j++ {
+		i = j
+		dst[j] = src[j]
+	}
+	return i
+}
+
+//go:noinline
+func movePairs(dst, src []pair) {
+	for i := range dst {
+		dst[i] = src[i]
+	}
+}
+
+//go:noinline
+func set(b []byte, c byte) {
+	for i := range b {
+		b[i] = c
+	}
+}
+
+//go:noinline
+func setInt8(b []int8) {
+	for i := range b {
+		b[i] = -2
+	}
+}
+
+//go:noinline
+func xor(dst, x, y []byte) {
+	for i := range dst {
+		dst[i] = x[i] ^ y[i]
+	}
+}
+
+//go:noinline
+func xorRef(dst, x, y []byte) {
+	for i := 0; i < len(dst); i++ {
+		dst[i] = x[i] ^ y[i]
+	}
+}
+
+//go:noinline
+func xorInPlace(dst, x []byte) {
+	for i := range dst {
+		dst[i] ^= x[i]
+	}
+}
+
+//go:noinline
+func sum(b []byte) int {
+	s := 7
+	for _, x := range b {
+		s += int(x)
+	}
+	return s
+}
+
+//go:noinline
+func sum8(b []byte) byte {
+	var s byte
+	for _, x := range b {
+		s += x
+	}
+	return s
+}
+
+func bytes(n int, seed byte) []byte {
+	b := make([]byte, n)
+	for i := 0; i < n; i++ {
+		b[i] = byte(i)*13 + seed
+	}
+	return b
+}
+
+func catch(f func()) (err string) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Sprint(r)
+		}
+	}()
+	f()
+	return ""
+}
+
+var bad bool
+
+func check(what string, got, want interface{}) {
+	if g, w := fmt.Sprint(got), fmt.Sprint(want); g != w {
+		fmt.Printf("%s: got %s, want %s\n", what, g, w)
+		bad = true
+	}
+}
+
+func main() {
+	for _, n := range []int{0, 1, 15, 16, 17, 31, 32, 33, 63, 64, 65, 100, 1000} {
+		// Disjoint and overlapping copies.
+		for _, off := range []int{-3, -1, 0, 1, 5, 40, 2000} {
+			s1 := bytes(5000, 1)
+			s2 := bytes(5000, 1)
+			d, s := 1000+off, 1000
+			i1 := move(s1[d:d+n], s1[s:s+n])
+			i2 := moveRef(s2[d:d+n], s2[s:s+n])
+			check(fmt.Sprint("move ", n, off), string(s1), string(s2))
+			check(fmt.Sprint("move index ", n, off), i1, i2)
+
+			// Overlapping xors.
+			x1, y1 := bytes(5000, 2), bytes(5000, 3)
+			x2, y2 := bytes(5000, 2), bytes(5000, 3)
+			xor(x1[d:d+n], x1[s:s+n], y1[:n])
+			xorRef(x2[d:d+n], x2[s:s+n], y2[:n])
+			check(fmt.Sprint("xor ", n, off), string(x1), string(x2))
+			xor(x1[d:d+n], y1[:n], x1[s:s+n])
+			xorRef(x2[d:d+n], y2[:n], x2[s:s+n])
+			check(fmt.Sprint("xor ", n, off), string(x1), string(x2))
+		}
+
+		b := bytes(n+2, 5)
+		set(b[1:n+1], 0xaa)
+		check(fmt.Sprint("set ", n), string(b), string([]byte{5})+strings.Repeat("\xaa", n)+string(bytes(n+2, 5)[n+1:]))
+		i8 := make([]int8, n)
+		setInt8(i8)
+		for _, x := range i8 {
+			if x != -2 {
+				check(fmt.Sprint("setInt8 ", n), x, -2)
+				break
+			}
+		}
+
+		x, y := bytes(n, 7), bytes(n, 9)
+		want := make([]byte, n)
+		xorRef(want, x, y)
+		xorInPlace(x, y)
+		check(fmt.Sprint("xorInPlace ", n), string(x), string(want))
+
+		ws, w8 := 7, byte(0)
+		for _, c := range b {
+			ws += int(c)
+			w8 += c
+		}
+		check(fmt.Sprint("sum ", n), sum(b), ws)
+		check(fmt.Sprint("sum8 ", n), sum8(b), w8)
+	}
+
+	big := make([]byte, 1<<20)
+	for i := range big {
+		big[i] = 0xff
+	}
+	check("sum big", sum(big), 7+255<<20)
+
+	p := []pair{{1, 2}, {3, 4}, {5, 6}}
+	movePairs(p[:2], p[1:])
+	check("movePairs", p, []pair{{3, 4}, {5, 6}, {5, 6}})
+	p = []pair{{1, 2}, {3, 4}, {5, 6}}
+	movePairs(p[1:], p)
+	check("movePairs overlap", p, []pair{{1, 2}, {1, 2}, {1, 2}})
+
+	// Loops that panic must do the same work first.
+	dst, src := make([]byte, 10), bytes(5, 1)
+	check("move panic", catch(func() { move(dst, src) }), "runtime error: index out of range [5] with length 5")
+	check("move panic dst", string(dst[:6]), string(src)+"\x00")
+	dst = make([]byte, 10)
+	check("xor panic", catch(func() { xor(dst, bytes(10, 1), src) }), "runtime error: index out of range [5] with length 5")
+	want := make([]byte, 5)
+	xorRef(want, bytes(5, 1), src)
+	check("xor panic dst", string(dst[:6]), string(want)+"\x00")
+
+	if bad {
+		panic("failed")
+	}
+}
@@ -0,0 +1,46 @@
+// errorcheck -0 -warn=all
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the warnings enabled by -warn.
+
+package p
+
+//go:pure
+func square(x int) int { return x * x }
+
+type T struct{}
+
+//go:pure
+func (T) Get() int { return 1 }
+
+type Big [2048]byte
+
+var g Big
+
+func unusedResults(x int) int {
+	square(x) // ERROR "result of pure function square is not used \[-warn=unusedresult\]"
+	if x > 0 {
+		T{}.Get() // ERROR "result of pure function T.Get is not used"
+	}
+	_ = square(x)
+	return square(x)
+}
+
+func largeAllocs() {
+	var buf [20 << 20]byte // ERROR "buf is allocated on the heap because it is too large for the stack \[-warn=largealloc\]"
+	p := new([100 << 10]byte) // ERROR "new\(\[102400\]byte\) is allocated on the heap because it is too large for the stack"
+	q := new([100]byte)
+	use(&buf, p, q)
+}
+
+//go:noinline
+func use(a *[20 << 20]byte, p *[100 << 10]byte, q *[100]byte) {}
+
+func largeCopies() *Big {
+	b := g // ERROR "assignment copies 2048 bytes of Big \[-warn=largecopy\]"
+	p := &b
+	return p
+}
@@ -0,0 +1,21 @@
+// errorcheck -warn=unusedresult -Werror
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -Werror reports enabled warnings as errors, and that
+// warnings that are not enabled are not reported.
+
+package p
+
+//go:pure
+func square(x int) int { return x * x }
+
+var g [2048]byte
+
+func f(x int) {
+	square(x) // ERROR "result of pure function square is not used"
+	b := g
+	_ = b
+}
@@ -0,0 +1,21 @@
+// errorcheck
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Verify that //go:wasmexport is only accepted on
+// functions with bodies.
+
+package p
+
+//go:wasmexport add
+func add(x, y int32) int32 { return x + y }
+
+type T int32
+
+//go:wasmexport m
+func (T) m() {} // ERROR "go:wasmexport cannot apply to method"
+
+//go:wasmexport g
+func g() // ERROR "go:wasmexport cannot apply to func without body"
@@ -0,0 +1,39 @@
+// +build amd64
+// compile -d=wb=json
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test that -d=wb=json lists the write barrier stores of each
+// function, with their types and the reasons they were kept.
+
+package p
+
+type T struct {
+	p *int
+	a [8]*int
+}
+
+var g *T
+
+func f(x *int, t T) {
+	g.p = x
+	*g = t
+}
+
+func h(x *int) *T {
+	n := new(T)
+	n.p = x
+	return n
+}
+
+func clear(t *T) {
+	t.p = nil
+}
+
+func none(t *T) {
+	var n T
+	n.p = t.p
+	_ = n
+}
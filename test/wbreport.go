@@ -0,0 +1,43 @@
+// errorcheck -0 -l -d=wbreport
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the elision of write barriers for stores to the stack
+// and of non-heap pointers, and the per-function report.
+
+package p
+
+type T struct {
+	p, q *int
+}
+
+var g, h int
+
+func phi(c bool, x *int) {
+	var a, b T
+	p := &a
+	if c { // ERROR "phi: 1 pointer stores, 0 write barriers, 1 elided"
+		p = &b
+	}
+	p.p = x // ERROR "write barrier elided: stack destination"
+	sink(&a, &b)
+}
+
+func global(c bool) *T {
+	t := new(T)
+	p := &g
+	if c { // ERROR "global: 1 pointer stores, 0 write barriers, 1 elided"
+		p = &h
+	}
+	t.q = p // ERROR "write barrier elided: non-heap pointer stored into zeroed memory"
+	return t
+}
+
+func heap(t *T, x *int) {
+	t.p = x // ERROR "heap: 1 pointer stores, 1 write barriers, 0 elided"
+}
+
+//go:noinline
+func sink(a, b *T) {}
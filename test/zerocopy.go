@@ -0,0 +1,55 @@
+// run
+
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Test the results of string/[]byte conversions that share memory
+// with their operand.
+
+package main
+
+//go:noinline
+func sum(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n += int(c)
+	}
+	return n
+}
+
+//go:noinline
+func isNil(b []byte) bool {
+	return b == nil
+}
+
+//go:noinline
+func str(s string) string {
+	return s
+}
+
+//go:noinline
+func equal(s string, n int) bool {
+	return s == "abc"[:n]
+}
+
+func empty(s string) bool {
+	return isNil([]byte(s))
+}
+
+func main() {
+	if empty("") || empty(str("")) {
+		panic("[]byte(\"\") is nil")
+	}
+	if b := []byte(str("abc")); sum(b) != 'a'+'b'+'c' || len(b) != 3 {
+		panic("bad []byte(\"abc\")")
+	}
+
+	var buf [8]byte
+	for i := 0; i < 3; i++ {
+		buf[i] = "abc"[i]
+		if !equal(string(buf[:i+1]), i+1) {
+			panic("bad string(buf)")
+		}
+	}
+}